@@ -10,15 +10,23 @@ import (
 	"syscall"
 	"time"
 
+	"dental-scheduler-backend/internal/app/events"
 	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/app/workers"
 	"dental-scheduler-backend/internal/domain/services"
 	"dental-scheduler-backend/internal/http/handlers"
 	"dental-scheduler-backend/internal/http/middleware"
 	"dental-scheduler-backend/internal/http/routes"
+	"dental-scheduler-backend/internal/infra/auth"
 	"dental-scheduler-backend/internal/infra/config"
 	"dental-scheduler-backend/internal/infra/database/postgres"
 	postgresRepos "dental-scheduler-backend/internal/infra/database/postgres/repositories"
 	"dental-scheduler-backend/internal/infra/logger"
+	"dental-scheduler-backend/internal/infra/mailer"
+	"dental-scheduler-backend/internal/infra/pac"
+	"dental-scheduler-backend/internal/infra/payments"
+	"dental-scheduler-backend/internal/infra/storage"
+	"dental-scheduler-backend/internal/infra/webhooks"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -51,6 +59,37 @@ func main() {
 
 	appLogger.Logger.Info("Database connection established")
 
+	// Initialize attachment file storage
+	blobStorage, err := storage.NewBlobStorage(&cfg.Storage)
+	if err != nil {
+		appLogger.Logger.WithError(err).Fatal("Failed to initialize attachment storage")
+	}
+
+	// Initialize JWT validation (shared secret and/or JWKS signing keys)
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	tokenValidatorConfig := middleware.TokenValidatorConfig{
+		HMACSecret: cfg.Auth.JWTSecret,
+		Issuer:     cfg.Auth.Issuer,
+		Audience:   cfg.Auth.Audience,
+	}
+	if cfg.Auth.JWKSURL != "" || cfg.Auth.JWKSFile != "" {
+		keySet := auth.NewJWKSKeySet(
+			cfg.Auth.JWKSURL,
+			cfg.Auth.JWKSFile,
+			time.Duration(cfg.Auth.JWKSRefreshMinutes)*time.Minute,
+			appLogger,
+		)
+		if err := keySet.Refresh(backgroundCtx); err != nil {
+			// Keys are fetched again on demand when a token arrives
+			appLogger.Logger.WithError(err).Warn("Failed to load JWKS")
+		}
+		keySet.Start(backgroundCtx)
+		tokenValidatorConfig.Keys = keySet
+	}
+	tokenValidator := middleware.NewTokenValidator(tokenValidatorConfig, appLogger)
+
 	// Initialize repositories
 	clinicRepo := postgresRepos.NewClinicPostgresRepository(dbConn.GetDB())
 	unitRepo := postgresRepos.NewUnitPostgresRepository(dbConn.GetDB())
@@ -60,6 +99,32 @@ func main() {
 	availabilityRepo := postgresRepos.NewDoctorAvailabilityPostgresRepository(dbConn.GetDB())
 	userRepo := postgresRepos.NewUserPostgresRepository(dbConn.GetDB())
 	organizationRepo := postgresRepos.NewOrganizationPostgresRepository(dbConn.GetDB())
+	staffInvitationRepo := postgresRepos.NewStaffInvitationPostgresRepository(dbConn.GetDB())
+	apiKeyRepo := postgresRepos.NewAPIKeyPostgresRepository(dbConn.GetDB())
+	webhookSubscriptionRepo := postgresRepos.NewWebhookSubscriptionPostgresRepository(dbConn.GetDB())
+	webhookDeliveryRepo := postgresRepos.NewWebhookDeliveryPostgresRepository(dbConn.GetDB())
+	outboxRepo := postgresRepos.NewOutboxPostgresRepository(dbConn.GetDB())
+	idempotencyKeyRepo := postgresRepos.NewIdempotencyKeyPostgresRepository(dbConn.GetDB())
+	patientMergeRepo := postgresRepos.NewPatientMergePostgresRepository(dbConn.GetDB())
+	dentalChartRepo := postgresRepos.NewDentalChartPostgresRepository(dbConn.GetDB())
+	clinicalNoteRepo := postgresRepos.NewClinicalNotePostgresRepository(dbConn.GetDB())
+	clinicalNoteTemplateRepo := postgresRepos.NewClinicalNoteTemplatePostgresRepository(dbConn.GetDB())
+	serviceRepo := postgresRepos.NewServicePostgresRepository(dbConn.GetDB())
+	treatmentPlanRepo := postgresRepos.NewTreatmentPlanPostgresRepository(dbConn.GetDB())
+	attachmentRepo := postgresRepos.NewAttachmentPostgresRepository(dbConn.GetDB())
+	consentTemplateRepo := postgresRepos.NewConsentTemplatePostgresRepository(dbConn.GetDB())
+	consentFormRepo := postgresRepos.NewConsentFormPostgresRepository(dbConn.GetDB())
+	medicalAlertRepo := postgresRepos.NewMedicalAlertPostgresRepository(dbConn.GetDB())
+	patientRelationshipRepo := postgresRepos.NewPatientRelationshipPostgresRepository(dbConn.GetDB())
+	invoiceRepo := postgresRepos.NewInvoicePostgresRepository(dbConn.GetDB())
+	cfdiRepo := postgresRepos.NewCFDIPostgresRepository(dbConn.GetDB())
+	fiscalProfileRepo := postgresRepos.NewFiscalProfilePostgresRepository(dbConn.GetDB())
+	insuranceRepo := postgresRepos.NewInsurancePostgresRepository(dbConn.GetDB())
+	cancellationRepo := postgresRepos.NewCancellationPostgresRepository(dbConn.GetDB())
+	depositRepo := postgresRepos.NewDepositPostgresRepository(dbConn.GetDB())
+	recallRepo := postgresRepos.NewRecallPostgresRepository(dbConn.GetDB())
+	equipmentRepo := postgresRepos.NewEquipmentPostgresRepository(dbConn.GetDB())
+	txManager := postgresRepos.NewTransactionPostgresManager(dbConn.GetDB())
 
 	// Initialize domain services
 	conflictChecker := services.NewAppointmentConflictChecker(appointmentRepo, availabilityRepo)
@@ -72,20 +137,178 @@ func main() {
 	)
 
 	// Initialize use cases
-	clinicUseCase := usecases.NewClinicUseCase(clinicRepo)
+	clinicUseCase := usecases.NewClinicUseCase(clinicRepo, organizationRepo)
 	unitUseCase := usecases.NewUnitUseCase(unitRepo, clinicRepo)
-	doctorUseCase := usecases.NewDoctorUseCase(doctorRepo, unitRepo, appointmentRepo)
-	patientUseCase := usecases.NewPatientUseCase(patientRepo)
+	doctorUseCase := usecases.NewDoctorUseCase(doctorRepo, unitRepo, appointmentRepo, organizationRepo)
+	webhookUseCase := usecases.NewWebhookUseCase(webhookSubscriptionRepo, webhookDeliveryRepo)
+	patientUseCase := usecases.NewPatientUseCase(patientRepo, appointmentRepo, organizationRepo, patientRelationshipRepo, txManager, outboxRepo)
+	dentalChartUseCase := usecases.NewDentalChartUseCase(dentalChartRepo, patientRepo, appointmentRepo, doctorRepo, txManager)
+	clinicalNoteUseCase := usecases.NewClinicalNoteUseCase(clinicalNoteRepo, clinicalNoteTemplateRepo, appointmentRepo, doctorRepo, patientRepo, serviceRepo, txManager)
+	patientMergeUseCase := usecases.NewPatientMergeUseCase(patientRepo, appointmentRepo, patientMergeRepo, dentalChartRepo, clinicalNoteRepo, treatmentPlanRepo, attachmentRepo, consentFormRepo, medicalAlertRepo, patientRelationshipRepo, invoiceRepo, cfdiRepo, fiscalProfileRepo, insuranceRepo, cancellationRepo, depositRepo, recallRepo, txManager, outboxRepo)
 	// userUseCase := usecases.NewUserUseCase(userRepo, appLogger) // Available when needed
+	paymentGateway, err := payments.NewPaymentGateway(&cfg.Payments, appLogger)
+	if err != nil {
+		appLogger.Logger.WithError(err).Fatal("Failed to initialize payment gateway")
+	}
 	appointmentUseCase := usecases.NewAppointmentUseCase(
 		appointmentRepo,
 		patientRepo,
 		doctorRepo,
 		unitRepo,
+		treatmentPlanRepo,
+		consentTemplateRepo,
+		consentFormRepo,
+		medicalAlertRepo,
+		patientRelationshipRepo,
+		cancellationRepo,
+		serviceRepo,
+		organizationRepo,
+		depositRepo,
+		recallRepo,
+		equipmentRepo,
+		paymentGateway,
 		schedulingService,
+		txManager,
+		outboxRepo,
+		appLogger,
+	)
+	treatmentPlanUseCase := usecases.NewTreatmentPlanUseCase(treatmentPlanRepo, patientRepo, doctorRepo, serviceRepo, txManager)
+	attachmentUseCase := usecases.NewAttachmentUseCase(
+		attachmentRepo,
+		patientRepo,
+		appointmentRepo,
+		blobStorage,
+		int64(cfg.Storage.MaxUploadMB)<<20,
+		time.Duration(cfg.Storage.PresignTTLMinutes)*time.Minute,
+		appLogger,
+	)
+	appMailer := mailer.NewLogMailer(appLogger)
+	consentUseCase := usecases.NewConsentUseCase(
+		consentTemplateRepo,
+		consentFormRepo,
+		patientRepo,
+		patientRelationshipRepo,
+		appointmentRepo,
+		doctorRepo,
+		serviceRepo,
+		unitRepo,
+		organizationRepo,
+		blobStorage,
+		appMailer,
+		cfg.Consent.SigningSecret,
+		time.Duration(cfg.Consent.LinkTTLHours)*time.Hour,
+		cfg.Consent.SigningURL,
+		appLogger,
+	)
+	medicalAlertUseCase := usecases.NewMedicalAlertUseCase(medicalAlertRepo, patientRepo, txManager)
+	patientRelationshipUseCase := usecases.NewPatientRelationshipUseCase(patientRelationshipRepo, patientRepo, appointmentRepo, txManager)
+	invoiceUseCase := usecases.NewInvoiceUseCase(
+		invoiceRepo,
+		cfdiRepo,
+		appointmentRepo,
+		patientRepo,
+		unitRepo,
+		clinicRepo,
+		serviceRepo,
+		treatmentPlanRepo,
+		organizationRepo,
+		depositRepo,
+		txManager,
+	)
+	cfdiPAC, err := pac.NewPAC(&cfg.CFDI, appLogger)
+	if err != nil {
+		appLogger.Logger.WithError(err).Fatal("Failed to initialize CFDI stamping")
+	}
+	cfdiUseCase := usecases.NewCFDIUseCase(
+		cfdiRepo,
+		fiscalProfileRepo,
+		invoiceRepo,
+		patientRepo,
+		clinicRepo,
+		cfdiPAC,
+		txManager,
+	)
+	insuranceUseCase := usecases.NewInsuranceUseCase(
+		insuranceRepo,
+		patientRepo,
+		appointmentRepo,
+		unitRepo,
+		serviceRepo,
+		treatmentPlanRepo,
+		invoiceRepo,
+		organizationRepo,
+		txManager,
+	)
+	cancellationUseCase := usecases.NewCancellationUseCase(cancellationRepo, patientRepo, invoiceRepo, organizationRepo, txManager)
+	depositUseCase := usecases.NewDepositUseCase(
+		depositRepo,
+		appointmentRepo,
+		serviceRepo,
+		patientRepo,
+		unitRepo,
+		treatmentPlanRepo,
+		recallRepo,
+		patientRelationshipRepo,
+		organizationRepo,
+		outboxRepo,
+		paymentGateway,
+		txManager,
+		appLogger,
 	)
-	getOrgDataUseCase := usecases.NewGetOrganizationDataUseCase(organizationRepo)
+	recallUseCase := usecases.NewRecallUseCase(recallRepo, serviceRepo, patientRepo, patientRelationshipRepo, txManager)
+	resourceUseCase := usecases.NewResourceUseCase(equipmentRepo, appointmentRepo, doctorRepo, unitRepo, clinicRepo)
+	getOrgDataUseCase := usecases.NewGetOrganizationDataUseCase(organizationRepo, medicalAlertRepo)
+	organizationSettingsUseCase := usecases.NewOrganizationSettingsUseCase(organizationRepo)
 	getDoctorAvailabilityUseCase := usecases.NewGetDoctorAvailabilityUseCase(availabilityRepo, doctorRepo)
+	staffUseCase := usecases.NewStaffUseCase(
+		userRepo,
+		staffInvitationRepo,
+		doctorRepo,
+		organizationRepo,
+		txManager,
+		appMailer,
+		cfg.Staff.InvitationSecret,
+		time.Duration(cfg.Staff.InvitationTTLHours)*time.Hour,
+		cfg.Staff.InvitationURL,
+		appLogger,
+	)
+	apiKeyUseCase := usecases.NewAPIKeyUseCase(apiKeyRepo, appLogger)
+
+	// Subscribe in-process handlers to domain events
+	eventBus := events.NewBus(appLogger)
+	eventBus.Subscribe("webhooks", webhookUseCase.HandleEvent)
+
+	// Start background workers
+	if cfg.Outbox.RelayEnabled {
+		outboxRelay := workers.NewOutboxRelay(
+			outboxRepo,
+			eventBus,
+			appLogger,
+			time.Duration(cfg.Outbox.PollIntervalSeconds)*time.Second,
+			cfg.Outbox.BatchSize,
+			time.Duration(cfg.Outbox.RetentionHours)*time.Hour,
+		)
+		outboxRelay.Start(backgroundCtx)
+	}
+	if cfg.Webhooks.WorkerEnabled {
+		webhookWorker := workers.NewWebhookDeliveryWorker(
+			webhookDeliveryRepo,
+			webhookSubscriptionRepo,
+			webhooks.NewHTTPSender(time.Duration(cfg.Webhooks.TimeoutSeconds)*time.Second),
+			appLogger,
+			time.Duration(cfg.Webhooks.PollIntervalSeconds)*time.Second,
+			cfg.Webhooks.BatchSize,
+		)
+		webhookWorker.Start(backgroundCtx)
+	}
+
+	workers.NewIdempotencyKeyPurger(idempotencyKeyRepo, appLogger, time.Hour).Start(backgroundCtx)
+	workers.NewDepositHoldReleaser(
+		depositUseCase,
+		appLogger,
+		time.Duration(cfg.Payments.ReleaseIntervalSeconds)*time.Second,
+		cfg.Payments.ReleaseBatchSize,
+	).Start(backgroundCtx)
 
 	// Initialize handlers
 	healthHandler := handlers.NewHealthHandler()
@@ -93,9 +316,28 @@ func main() {
 	unitHandler := handlers.NewUnitHandler(unitUseCase, appLogger)
 	doctorHandler := handlers.NewDoctorHandler(doctorUseCase, appLogger)
 	patientHandler := handlers.NewPatientHandler(patientUseCase, appLogger)
+	patientMergeHandler := handlers.NewPatientMergeHandler(patientMergeUseCase, appLogger)
+	dentalChartHandler := handlers.NewDentalChartHandler(dentalChartUseCase, appLogger)
+	clinicalNoteHandler := handlers.NewClinicalNoteHandler(clinicalNoteUseCase, appLogger)
+	treatmentPlanHandler := handlers.NewTreatmentPlanHandler(treatmentPlanUseCase, appointmentUseCase, appLogger)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentUseCase, appLogger)
+	consentHandler := handlers.NewConsentHandler(consentUseCase, appLogger)
+	medicalAlertHandler := handlers.NewMedicalAlertHandler(medicalAlertUseCase, appLogger)
+	patientRelationshipHandler := handlers.NewPatientRelationshipHandler(patientRelationshipUseCase, appLogger)
+	invoiceHandler := handlers.NewInvoiceHandler(invoiceUseCase, appLogger)
+	cfdiHandler := handlers.NewCFDIHandler(cfdiUseCase, appLogger)
+	insuranceHandler := handlers.NewInsuranceHandler(insuranceUseCase, appLogger)
+	cancellationHandler := handlers.NewCancellationHandler(cancellationUseCase, appLogger)
+	depositHandler := handlers.NewDepositHandler(depositUseCase, appLogger)
+	recallHandler := handlers.NewRecallHandler(recallUseCase, appLogger)
+	resourceHandler := handlers.NewResourceHandler(resourceUseCase, appLogger)
 	appointmentHandler := handlers.NewAppointmentHandler(appointmentUseCase, appLogger)
 	organizationHandler := handlers.NewOrganizationHandler(getOrgDataUseCase, appLogger)
+	organizationSettingsHandler := handlers.NewOrganizationSettingsHandler(organizationSettingsUseCase, appLogger)
 	doctorAvailabilityHandler := handlers.NewDoctorAvailabilityHandler(getDoctorAvailabilityUseCase, appLogger)
+	staffHandler := handlers.NewStaffHandler(staffUseCase, appLogger)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyUseCase, appLogger)
+	webhookHandler := handlers.NewWebhookHandler(webhookUseCase, appLogger)
 
 	// Set Gin mode
 	if cfg.Log.Level == "debug" {
@@ -126,9 +368,31 @@ func main() {
 		unitHandler,
 		doctorHandler,
 		patientHandler,
+		patientMergeHandler,
+		dentalChartHandler,
+		clinicalNoteHandler,
+		treatmentPlanHandler,
+		attachmentHandler,
+		consentHandler,
+		medicalAlertHandler,
+		patientRelationshipHandler,
+		invoiceHandler,
+		cfdiHandler,
+		insuranceHandler,
+		cancellationHandler,
+		depositHandler,
+		recallHandler,
+		resourceHandler,
 		appointmentHandler,
 		organizationHandler,
+		organizationSettingsHandler,
 		doctorAvailabilityHandler,
+		staffHandler,
+		apiKeyHandler,
+		webhookHandler,
+		middleware.Idempotency(appLogger, idempotencyKeyRepo, time.Duration(cfg.Idempotency.RetentionHours)*time.Hour),
+		tokenValidator,
+		apiKeyUseCase,
 		userRepo,
 		appLogger,
 	)
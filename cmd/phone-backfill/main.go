@@ -0,0 +1,77 @@
+// Command phone-backfill normalizes the phone numbers of existing patients, doctors and
+// clinics to E.164 using each organization's default phone region, and reports the
+// numbers that could not be parsed so they can be fixed by hand.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/infra/config"
+	"dental-scheduler-backend/internal/infra/database/postgres"
+	postgresRepos "dental-scheduler-backend/internal/infra/database/postgres/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	jsonOutput := flag.Bool("json", false, "print the full report as JSON")
+	flag.Parse()
+
+	// Load environment variables from .env file if it exists
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	appLogger := logger.NewLogger(cfg.Log.Level)
+
+	dbConn, err := postgres.NewConnection(&cfg.Database)
+	if err != nil {
+		appLogger.Logger.WithError(err).Fatal("Failed to connect to database")
+	}
+	defer dbConn.Close()
+
+	backfill := usecases.NewPhoneBackfillUseCase(postgresRepos.NewPhoneBackfillPostgresRepository(dbConn.GetDB()))
+	report, err := backfill.BackfillPhones(context.Background(), *dryRun)
+	if err != nil {
+		appLogger.Logger.WithError(err).Fatal("Phone backfill failed")
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			appLogger.Logger.WithError(err).Fatal("Failed to write report")
+		}
+		return
+	}
+
+	for _, failure := range report.Unparseable {
+		appLogger.Logger.WithFields(map[string]interface{}{
+			"owner":  failure.Owner,
+			"id":     failure.ID,
+			"phone":  failure.Phone,
+			"region": failure.Region,
+			"reason": failure.Reason,
+		}).Warn("Unparseable phone number")
+	}
+
+	appLogger.Logger.WithFields(map[string]interface{}{
+		"dry_run":     report.DryRun,
+		"scanned":     report.Scanned,
+		"updated":     report.Updated,
+		"unchanged":   report.Unchanged,
+		"unparseable": len(report.Unparseable),
+	}).Info("Phone backfill finished")
+}
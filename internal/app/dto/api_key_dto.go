@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// CreateAPIKeyRequest represents the request to create an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Never expires when omitted
+}
+
+// APIKeyResponse represents an API key without its secret
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	Status     string     `json:"status"` // active, expired or revoked
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse includes the plaintext key, which is only returned once
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// ToAPIKeyResponse converts entities.APIKey to APIKeyResponse
+func ToAPIKeyResponse(k *entities.APIKey) *APIKeyResponse {
+	status := "active"
+	if k.IsRevoked() {
+		status = "revoked"
+	} else if k.IsExpired(time.Now()) {
+		status = "expired"
+	}
+
+	return &APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		Status:     status,
+		CreatedBy:  k.CreatedBy,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// ParseAPIKeyScopes converts scope names into API key scopes
+func ParseAPIKeyScopes(names []string) ([]entities.APIKeyScope, error) {
+	scopes := make([]entities.APIKeyScope, 0, len(names))
+	for _, name := range names {
+		scope := entities.APIKeyScope(name)
+		if !entities.IsValidAPIKeyScope(scope) {
+			return nil, entities.ErrInvalidAPIKeyScope
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
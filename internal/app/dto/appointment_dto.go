@@ -17,6 +17,10 @@ type CreateAppointmentRequest struct {
 	StartTime time.Time `json:"start_time" binding:"required"`
 	EndTime   time.Time `json:"end_time" binding:"required"`
 	Notes     *string   `json:"notes,omitempty"`
+	// TreatmentPlanItemID books an accepted treatment plan procedure in the appointment
+	TreatmentPlanItemID *uuid.UUID `json:"treatment_plan_item_id,omitempty"`
+	// Resources books staff, units and equipment besides the doctor and unit
+	Resources []AppointmentResourceRequest `json:"resources,omitempty"`
 }
 
 // UpdateAppointmentRequest represents the request to update an appointment (partial updates)
@@ -29,6 +33,14 @@ type UpdateAppointmentRequest struct {
 	StartTime *time.Time                  `json:"start_time,omitempty"`
 	EndTime   *time.Time                  `json:"end_time,omitempty"`
 	Notes     *string                     `json:"notes,omitempty"`
+	Version   *int                        `json:"version,omitempty"` // Expected version when no If-Match header is sent
+
+	// Replaces the resources booked besides the doctor and unit; an empty list clears them
+	Resources *[]AppointmentResourceRequest `json:"resources,omitempty"`
+
+	// Only used when the update cancels the appointment
+	CancellationReasonID *uuid.UUID               `json:"cancellation_reason_id,omitempty"`
+	FeeOverride          *CancellationFeeOverride `json:"fee_override,omitempty"`
 }
 
 // AppointmentResponse represents the response for an appointment
@@ -45,8 +57,22 @@ type AppointmentResponse struct {
 	EndTime      time.Time                  `json:"end_time"`
 	Notes        *string                    `json:"notes,omitempty"`
 	IsFirstVisit bool                       `json:"is_first_visit"`
+	Version      int                        `json:"version"`
 	CreatedAt    time.Time                  `json:"created_at"`
 	UpdatedAt    time.Time                  `json:"updated_at"`
+
+	CancellationReasonID *uuid.UUID              `json:"cancellation_reason_id,omitempty"`
+	CancellationReason   *string                 `json:"cancellation_reason,omitempty"`
+	DepositStatus        *entities.DepositStatus `json:"deposit_status,omitempty"` // pending while the slot is held for an unpaid deposit
+
+	// Staff, units and equipment booked besides the doctor and unit
+	Resources []*AppointmentResourceResponse `json:"resources,omitempty"`
+	// Required consents the patient has not signed yet; the appointment is saved regardless
+	MissingConsents []*ConsentRequirementResponse `json:"missing_consents,omitempty"`
+	// Late-cancellation fee charged when the request cancelled the appointment
+	CancellationFee *CancellationFeeResponse `json:"cancellation_fee,omitempty"`
+	// Deposit asked when the request booked the appointment, with its payment link
+	Deposit *DepositResponse `json:"deposit,omitempty"`
 }
 
 // AppointmentWithDetailsResponse represents the response for an appointment with related entity details
@@ -94,23 +120,25 @@ type PatientListDataDTO struct {
 
 // AppointmentListResponse represents an appointment with all related details for listing
 type AppointmentListResponse struct {
-	ID           string              `json:"id"`
-	Patient      *PatientListDataDTO `json:"patient"`
-	DoctorID     string              `json:"doctor_id"`
-	DoctorName   string              `json:"doctor_name"`
-	ClinicID     string              `json:"clinic_id"`
-	ClinicName   string              `json:"clinic_name"`
-	UnitID       *string             `json:"unit_id,omitempty"`
-	UnitName     *string             `json:"unit_name,omitempty"`
-	StartTime    string              `json:"start_time"` // Converted to clinic timezone, format: "2006-01-02T15:04:05"
-	EndTime      string              `json:"end_time"`   // Converted to clinic timezone, format: "2006-01-02T15:04:05"
-	Status       string              `json:"status"`
-	ServiceID    string              `json:"service_id,omitempty"`
-	ServiceName  string              `json:"service_name,omitempty"`
-	Notes        string              `json:"notes,omitempty"`
-	IsFirstVisit bool                `json:"is_first_visit"`
-	CreatedAt    time.Time           `json:"created_at"`
-	UpdatedAt    time.Time           `json:"updated_at"`
+	ID            string              `json:"id"`
+	Patient       *PatientListDataDTO `json:"patient"`
+	DoctorID      string              `json:"doctor_id"`
+	DoctorName    string              `json:"doctor_name"`
+	ClinicID      string              `json:"clinic_id"`
+	ClinicName    string              `json:"clinic_name"`
+	UnitID        *string             `json:"unit_id,omitempty"`
+	UnitName      *string             `json:"unit_name,omitempty"`
+	StartTime     string              `json:"start_time"` // Converted to clinic timezone, format: "2006-01-02T15:04:05"
+	EndTime       string              `json:"end_time"`   // Converted to clinic timezone, format: "2006-01-02T15:04:05"
+	Status        string              `json:"status"`
+	DepositStatus *string             `json:"deposit_status,omitempty"` // pending while the slot is held for an unpaid deposit
+	ServiceID     string              `json:"service_id,omitempty"`
+	ServiceName   string              `json:"service_name,omitempty"`
+	Notes         string              `json:"notes,omitempty"`
+	IsFirstVisit  bool                `json:"is_first_visit"`
+	Version       int                 `json:"version"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
 }
 
 // AppointmentSummary provides summary statistics for the appointments
@@ -175,8 +203,14 @@ func ToAppointmentResponse(a *entities.Appointment) *AppointmentResponse {
 		EndTime:      a.EndTime,
 		Notes:        a.Notes,
 		IsFirstVisit: false, // Default to false when patient info not available
+		Version:      a.Version,
 		CreatedAt:    a.CreatedAt,
 		UpdatedAt:    a.UpdatedAt,
+
+		CancellationReasonID: a.CancellationReasonID,
+		CancellationReason:   a.CancellationReason,
+		DepositStatus:        a.DepositStatus,
+		Resources:            ToAppointmentResourceResponses(a.Resources),
 	}
 }
 
@@ -195,8 +229,14 @@ func ToAppointmentResponseWithPatientName(a *entities.Appointment, patientName s
 		EndTime:      a.EndTime,
 		Notes:        a.Notes,
 		IsFirstVisit: false, // Default to false, use WithPatientNameAndFirstVisit for accurate flag
+		Version:      a.Version,
 		CreatedAt:    a.CreatedAt,
 		UpdatedAt:    a.UpdatedAt,
+
+		CancellationReasonID: a.CancellationReasonID,
+		CancellationReason:   a.CancellationReason,
+		DepositStatus:        a.DepositStatus,
+		Resources:            ToAppointmentResourceResponses(a.Resources),
 	}
 }
 
@@ -215,8 +255,14 @@ func ToAppointmentResponseWithPatientNameAndFirstVisit(a *entities.Appointment,
 		EndTime:      a.EndTime,
 		Notes:        a.Notes,
 		IsFirstVisit: isFirstVisit,
+		Version:      a.Version,
 		CreatedAt:    a.CreatedAt,
 		UpdatedAt:    a.UpdatedAt,
+
+		CancellationReasonID: a.CancellationReasonID,
+		CancellationReason:   a.CancellationReason,
+		DepositStatus:        a.DepositStatus,
+		Resources:            ToAppointmentResourceResponses(a.Resources),
 	}
 }
 
@@ -283,6 +329,8 @@ type ReschedulingQueueItem struct {
 	MovedToNeedsReschedulingAt string              `json:"moved_to_needs_rescheduling_at"` // ISO 8601
 	DaysInQueue                int                 `json:"days_in_queue"`
 	LastActionTimestamp        string              `json:"last_action_timestamp"` // ISO 8601
+
+	MedicalAlerts []*MedicalAlertFlagDTO `json:"medical_alerts,omitempty"` // Active alerts of the patient, most severe first
 }
 
 // ReschedulingQueueResponse represents the complete response for rescheduling queue listing
@@ -296,8 +344,9 @@ type ReschedulingQueueResponse struct {
 
 // CancelAppointmentRequest represents the request to cancel an appointment from the queue
 type CancelAppointmentRequest struct {
-	Reason string  `json:"reason" binding:"required"`
-	Notes  *string `json:"notes,omitempty"`
+	ReasonID    uuid.UUID                `json:"reason_id" binding:"required"` // Entry of the organization's cancellation reasons
+	Notes       *string                  `json:"notes,omitempty"`
+	FeeOverride *CancellationFeeOverride `json:"fee_override,omitempty"`
 }
 
 // RescheduleFromQueueRequest represents the request to reschedule an appointment from the queue
@@ -308,6 +357,8 @@ type RescheduleFromQueueRequest struct {
 	EndTime   time.Time `json:"end_time" binding:"required"`
 	ServiceID string    `json:"service_id" binding:"required"`
 	Notes     *string   `json:"notes,omitempty"`
+	// Resources to book besides the doctor and unit; those of the original appointment when omitted
+	Resources *[]AppointmentResourceRequest `json:"resources,omitempty"`
 }
 
 // SnoozeAppointmentRequest represents the request to snooze an appointment
@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// AppointmentResourceRequest represents a staff member, unit or piece of equipment to book in an
+// appointment besides its doctor and unit
+type AppointmentResourceRequest struct {
+	ResourceType string    `json:"resource_type" binding:"required"` // staff, unit or equipment
+	ResourceID   uuid.UUID `json:"resource_id" binding:"required"`   // Staff roster (doctor), unit or equipment ID
+	Role         *string   `json:"role,omitempty"`                   // Staff only: doctor, assistant or hygienist
+}
+
+// AppointmentResourceResponse represents a resource booked in an appointment
+type AppointmentResourceResponse struct {
+	ID           uuid.UUID `json:"id"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   uuid.UUID `json:"resource_id"`
+	Role         *string   `json:"role,omitempty"`
+}
+
+// CreateEquipmentRequest represents the creation of a piece of equipment
+type CreateEquipmentRequest struct {
+	ClinicID    uuid.UUID `json:"clinic_id" binding:"required"`
+	Name        string    `json:"name" binding:"required"`
+	Description *string   `json:"description,omitempty"`
+}
+
+// UpdateEquipmentRequest represents the update of a piece of equipment; it cannot move to another clinic
+type UpdateEquipmentRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	Description *string `json:"description,omitempty"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+}
+
+// EquipmentListRequest represents the filters to list a clinic's equipment
+type EquipmentListRequest struct {
+	ClinicID        string `form:"clinic_id" binding:"required"`
+	IncludeInactive bool   `form:"include_inactive,omitempty"`
+}
+
+// EquipmentResponse represents a piece of equipment
+type EquipmentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ClinicID    uuid.UUID `json:"clinic_id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ResourceCalendarRequest represents the filters of the calendar per resource of a clinic
+type ResourceCalendarRequest struct {
+	ClinicID     string `form:"clinic_id" binding:"required"`
+	StartDate    string `form:"start_date" binding:"required"` // YYYY-MM-DD in the clinic's timezone
+	EndDate      string `form:"end_date" binding:"required"`   // YYYY-MM-DD, inclusive
+	ResourceType string `form:"resource_type,omitempty"`       // Only staff, unit or equipment lanes
+	ResourceID   string `form:"resource_id,omitempty"`         // Only the lane of one resource
+}
+
+// ResourceBookingResponse represents an appointment on the calendar of one resource
+type ResourceBookingResponse struct {
+	AppointmentID uuid.UUID  `json:"appointment_id"`
+	PatientID     *uuid.UUID `json:"patient_id,omitempty"`
+	PatientName   string     `json:"patient_name,omitempty"`
+	ServiceID     *string    `json:"service_id,omitempty"`
+	Status        string     `json:"status"`
+	Role          *string    `json:"role,omitempty"` // Role of a staff member in the appointment
+	StartTime     string     `json:"start_time"`     // Converted to clinic timezone, format: "2006-01-02T15:04:05"
+	EndTime       string     `json:"end_time"`       // Converted to clinic timezone, format: "2006-01-02T15:04:05"
+}
+
+// ResourceLaneResponse represents the calendar of one resource
+type ResourceLaneResponse struct {
+	ResourceType string                     `json:"resource_type"`
+	ResourceID   uuid.UUID                  `json:"resource_id"`
+	Name         string                     `json:"name"`
+	Color        *string                    `json:"color,omitempty"` // Staff only
+	IsActive     bool                       `json:"is_active"`
+	Bookings     []*ResourceBookingResponse `json:"bookings"`
+}
+
+// ResourceCalendarResponse represents the calendars of a clinic's staff, units and equipment
+type ResourceCalendarResponse struct {
+	ClinicID  uuid.UUID               `json:"clinic_id"`
+	Timezone  string                  `json:"timezone"`
+	StartDate string                  `json:"start_date"`
+	EndDate   string                  `json:"end_date"`
+	Resources []*ResourceLaneResponse `json:"resources"`
+}
+
+// ToAppointmentResources converts requested resources to the entities to book
+func ToAppointmentResources(reqs []AppointmentResourceRequest) ([]*entities.AppointmentResource, error) {
+	resources := make([]*entities.AppointmentResource, 0, len(reqs))
+	for _, req := range reqs {
+		var role *entities.AppointmentRole
+		if req.Role != nil {
+			appointmentRole := entities.AppointmentRole(*req.Role)
+			role = &appointmentRole
+		}
+		resource, err := entities.NewAppointmentResource(entities.ResourceType(req.ResourceType), req.ResourceID, role)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// ToAppointmentResourceResponses converts the resources of an appointment to their responses
+func ToAppointmentResourceResponses(resources []*entities.AppointmentResource) []*AppointmentResourceResponse {
+	if len(resources) == 0 {
+		return nil
+	}
+	responses := make([]*AppointmentResourceResponse, len(resources))
+	for i, resource := range resources {
+		responses[i] = &AppointmentResourceResponse{
+			ID:           resource.ID,
+			ResourceType: string(resource.Type),
+			ResourceID:   resource.ResourceID,
+			Role:         (*string)(resource.Role),
+		}
+	}
+	return responses
+}
+
+// ToEquipmentResponse converts a piece of equipment to its response
+func ToEquipmentResponse(equipment *entities.Equipment) *EquipmentResponse {
+	return &EquipmentResponse{
+		ID:          equipment.ID,
+		ClinicID:    equipment.ClinicID,
+		Name:        equipment.Name,
+		Description: equipment.Description,
+		IsActive:    equipment.IsActive,
+		CreatedAt:   equipment.CreatedAt,
+		UpdatedAt:   equipment.UpdatedAt,
+	}
+}
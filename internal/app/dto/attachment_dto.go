@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"io"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// UploadAttachmentRequest represents the form fields sent with an uploaded file
+type UploadAttachmentRequest struct {
+	Type             string     `form:"type" binding:"required"` // radiograph, photo, consent, referral or other
+	AppointmentIDStr string     `form:"appointment_id"`          // Appointment the file was taken at
+	AppointmentID    *uuid.UUID `form:"-"`                       // Parsed from AppointmentIDStr by the handler
+	Tooth            string     `form:"tooth"`
+	Numbering        string     `form:"numbering"` // fdi (default) or universal, for tooth
+	TakenAt          string     `form:"taken_at"`  // RFC 3339 timestamp or YYYY-MM-DD date
+	Description      string     `form:"description"`
+}
+
+// AttachmentFile is the uploaded file of an attachment
+type AttachmentFile struct {
+	FileName string
+	Content  io.Reader
+}
+
+// UpdateAttachmentRequest represents changes to an attachment's metadata; the file cannot change
+type UpdateAttachmentRequest struct {
+	Numbering     string     `json:"numbering,omitempty"`
+	Type          *string    `json:"type,omitempty"`
+	AppointmentID *uuid.UUID `json:"appointment_id,omitempty"`
+	Tooth         *string    `json:"tooth,omitempty"`    // Empty string removes the tooth
+	TakenAt       *string    `json:"taken_at,omitempty"` // Empty string removes the capture date
+	Description   *string    `json:"description,omitempty"`
+}
+
+// AttachmentListRequest represents the filters and pagination of an attachment listing
+type AttachmentListRequest struct {
+	Type             string     `form:"type,omitempty"`
+	AppointmentIDStr string     `form:"appointment_id,omitempty"`
+	AppointmentID    *uuid.UUID `form:"-"`
+	Tooth            string     `form:"tooth,omitempty"`
+	Numbering        string     `form:"numbering,omitempty"` // fdi (default) or universal, for tooth and responses
+	Page             int        `form:"page,omitempty"`
+	Limit            int        `form:"limit,omitempty"`
+}
+
+// AttachmentQuery represents the numbering of teeth in attachment responses
+type AttachmentQuery struct {
+	Numbering string `form:"numbering,omitempty"`
+}
+
+// AttachmentDownloadRequest represents how a file is delivered
+type AttachmentDownloadRequest struct {
+	Stream bool `form:"stream,omitempty"` // Stream through the API even when storage can pre-sign URLs
+	Inline bool `form:"inline,omitempty"` // Display in the browser instead of saving
+}
+
+// AttachmentResponse represents an attachment's metadata and where to fetch it
+type AttachmentResponse struct {
+	ID            uuid.UUID      `json:"id"`
+	PatientID     uuid.UUID      `json:"patient_id"`
+	AppointmentID *uuid.UUID     `json:"appointment_id,omitempty"`
+	Type          string         `json:"type"`
+	Tooth         *ToothResponse `json:"tooth,omitempty"`
+	TakenAt       *time.Time     `json:"taken_at,omitempty"`
+	Description   *string        `json:"description,omitempty"`
+	FileName      string         `json:"file_name"`
+	ContentType   string         `json:"content_type"`
+	SizeBytes     int64          `json:"size_bytes"`
+	DownloadURL   string         `json:"download_url"`
+	ThumbnailURL  *string        `json:"thumbnail_url,omitempty"` // Set for images with a preview
+	UploadedBy    *uuid.UUID     `json:"uploaded_by,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// AttachmentListResponse represents a page of attachments, most recently taken first
+type AttachmentListResponse struct {
+	Attachments []*AttachmentResponse `json:"attachments"`
+	Pagination  PaginationInfo        `json:"pagination"`
+}
+
+// AttachmentContent is a file ready to be delivered: either a pre-signed URL to redirect
+// to, or a body to stream that the caller must close
+type AttachmentContent struct {
+	RedirectURL string
+	Body        io.ReadCloser
+	ContentType string
+	FileName    string
+	Size        int64 // Unknown (0) for thumbnails
+}
+
+// ToAttachmentResponse converts an attachment to its response
+func ToAttachmentResponse(attachment *entities.Attachment, numbering entities.ToothNumberingSystem) *AttachmentResponse {
+	basePath := "/api/v1/attachments/" + attachment.ID.String()
+	response := &AttachmentResponse{
+		ID:            attachment.ID,
+		PatientID:     attachment.PatientID,
+		AppointmentID: attachment.AppointmentID,
+		Type:          string(attachment.Type),
+		TakenAt:       attachment.TakenAt,
+		Description:   attachment.Description,
+		FileName:      attachment.FileName,
+		ContentType:   attachment.ContentType,
+		SizeBytes:     attachment.SizeBytes,
+		DownloadURL:   basePath + "/download",
+		UploadedBy:    attachment.UploadedBy,
+		CreatedAt:     attachment.CreatedAt,
+		UpdatedAt:     attachment.UpdatedAt,
+	}
+	if attachment.Tooth != nil {
+		tooth := ToToothResponse(*attachment.Tooth, numbering)
+		response.Tooth = &tooth
+	}
+	if attachment.HasThumbnail() {
+		thumbnailURL := basePath + "/thumbnail"
+		response.ThumbnailURL = &thumbnailURL
+	}
+	return response
+}
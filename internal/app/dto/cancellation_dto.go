@@ -0,0 +1,173 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// CancellationReasonRequest represents the creation or update of a cancellation reason
+type CancellationReasonRequest struct {
+	Label    string `json:"label" binding:"required"`
+	Category string `json:"category" binding:"required"` // patient_request, illness, emergency, weather, clinic or other
+	Active   *bool  `json:"active,omitempty"`            // Update only; new reasons are active
+}
+
+// CancellationReasonListRequest represents the filters to list cancellation reasons
+type CancellationReasonListRequest struct {
+	IncludeInactive bool `form:"include_inactive,omitempty"`
+}
+
+// CancellationPolicyRequest represents the organization's late-cancellation policy.
+// FeeAmount is in minor units of the organization's currency.
+type CancellationPolicyRequest struct {
+	Enabled          bool     `json:"enabled"`
+	NoticeHours      int      `json:"notice_hours" binding:"required"`
+	FeeType          string   `json:"fee_type" binding:"required"` // fixed or percentage
+	FeeAmount        int64    `json:"fee_amount"`
+	FeePercent       int      `json:"fee_percent"`
+	ExemptCategories []string `json:"exempt_categories"`
+}
+
+// CancellationFeeOverride represents staff replacing the fee a late cancellation was charged.
+// It only applies when the policy charges a fee.
+type CancellationFeeOverride struct {
+	Amount        int64  `json:"amount"` // Minor units; zero waives the fee
+	Justification string `json:"justification" binding:"required"`
+}
+
+// CancellationFeeListRequest represents the filters to list cancellation fees
+type CancellationFeeListRequest struct {
+	PatientIDStr string     `form:"patient_id,omitempty"`
+	PatientID    *uuid.UUID `form:"-"`
+	Status       string     `form:"status,omitempty"`
+	Page         int        `form:"page,omitempty"`
+	Limit        int        `form:"limit,omitempty"`
+}
+
+// InvoiceCancellationFeeRequest represents billing a pending cancellation fee
+type InvoiceCancellationFeeRequest struct {
+	TaxRate int     `json:"tax_rate,omitempty"` // Basis points
+	Notes   *string `json:"notes,omitempty"`
+}
+
+// CancellationReasonResponse represents a cancellation reason
+type CancellationReasonResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Label     string    `json:"label"`
+	Category  string    `json:"category"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CancellationPolicyResponse represents the organization's late-cancellation policy
+type CancellationPolicyResponse struct {
+	Enabled          bool       `json:"enabled"`
+	NoticeHours      int        `json:"notice_hours"`
+	FeeType          string     `json:"fee_type"`
+	FeeAmount        int64      `json:"fee_amount"`
+	FeePercent       int        `json:"fee_percent"`
+	ExemptCategories []string   `json:"exempt_categories"`
+	Currency         string     `json:"currency"`
+	UpdatedBy        *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"` // Omitted until the organization sets a policy
+}
+
+// CancellationFeeResponse represents a late-cancellation fee
+type CancellationFeeResponse struct {
+	ID                    uuid.UUID  `json:"id"`
+	ClinicID              uuid.UUID  `json:"clinic_id"`
+	PatientID             uuid.UUID  `json:"patient_id"`
+	AppointmentID         uuid.UUID  `json:"appointment_id"`
+	ReasonID              *uuid.UUID `json:"reason_id,omitempty"`
+	Reason                *string    `json:"reason,omitempty"`
+	Status                string     `json:"status"`
+	Currency              string     `json:"currency"`
+	PolicyAmount          int64      `json:"policy_amount"`
+	Amount                int64      `json:"amount"`
+	NoticeMinutes         int        `json:"notice_minutes"`
+	AppointmentStart      time.Time  `json:"appointment_start"`
+	CancelledAt           time.Time  `json:"cancelled_at"`
+	CancelledBy           *uuid.UUID `json:"cancelled_by,omitempty"`
+	OverrideJustification *string    `json:"override_justification,omitempty"`
+	OverriddenBy          *uuid.UUID `json:"overridden_by,omitempty"`
+	OverriddenAt          *time.Time `json:"overridden_at,omitempty"`
+	InvoiceID             *uuid.UUID `json:"invoice_id,omitempty"`
+	InvoicedAt            *time.Time `json:"invoiced_at,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// CancellationFeeListResponse represents a page of cancellation fees
+type CancellationFeeListResponse struct {
+	Fees       []*CancellationFeeResponse `json:"fees"`
+	Pagination PaginationInfo             `json:"pagination"`
+}
+
+// ToCancellationReasonResponse converts a cancellation reason to its response
+func ToCancellationReasonResponse(reason *entities.CancellationReason) *CancellationReasonResponse {
+	return &CancellationReasonResponse{
+		ID:        reason.ID,
+		Label:     reason.Label,
+		Category:  string(reason.Category),
+		Active:    reason.Active,
+		CreatedAt: reason.CreatedAt,
+		UpdatedAt: reason.UpdatedAt,
+	}
+}
+
+// ToCancellationPolicyResponse converts a cancellation policy to its response
+func ToCancellationPolicyResponse(policy *entities.CancellationPolicy, currency string) *CancellationPolicyResponse {
+	exempt := make([]string, len(policy.ExemptCategories))
+	for i, category := range policy.ExemptCategories {
+		exempt[i] = string(category)
+	}
+
+	response := &CancellationPolicyResponse{
+		Enabled:          policy.Enabled,
+		NoticeHours:      policy.NoticeHours,
+		FeeType:          string(policy.FeeType),
+		FeeAmount:        policy.FeeAmount,
+		FeePercent:       policy.FeePercent,
+		ExemptCategories: exempt,
+		Currency:         currency,
+		UpdatedBy:        policy.UpdatedBy,
+	}
+	if !policy.UpdatedAt.IsZero() {
+		response.UpdatedAt = &policy.UpdatedAt
+	}
+	return response
+}
+
+// ToCancellationFeeResponse converts a cancellation fee to its response
+func ToCancellationFeeResponse(fee *entities.CancellationFee) *CancellationFeeResponse {
+	if fee == nil {
+		return nil
+	}
+	return &CancellationFeeResponse{
+		ID:                    fee.ID,
+		ClinicID:              fee.ClinicID,
+		PatientID:             fee.PatientID,
+		AppointmentID:         fee.AppointmentID,
+		ReasonID:              fee.ReasonID,
+		Reason:                fee.Reason,
+		Status:                string(fee.Status),
+		Currency:              fee.Currency,
+		PolicyAmount:          fee.PolicyAmount,
+		Amount:                fee.Amount,
+		NoticeMinutes:         fee.NoticeMinutes,
+		AppointmentStart:      fee.AppointmentStart,
+		CancelledAt:           fee.CancelledAt,
+		CancelledBy:           fee.CancelledBy,
+		OverrideJustification: fee.OverrideJustification,
+		OverriddenBy:          fee.OverriddenBy,
+		OverriddenAt:          fee.OverriddenAt,
+		InvoiceID:             fee.InvoiceID,
+		InvoicedAt:            fee.InvoicedAt,
+		CreatedAt:             fee.CreatedAt,
+		UpdatedAt:             fee.UpdatedAt,
+	}
+}
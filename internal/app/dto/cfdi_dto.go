@@ -0,0 +1,141 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// PatientFiscalDataRequest represents the fiscal data a patient's CFDI are issued to, as on
+// the patient's constancia de situación fiscal
+type PatientFiscalDataRequest struct {
+	RFC        string `json:"rfc" binding:"required"`
+	LegalName  string `json:"legal_name" binding:"required"`  // Without the company type, e.g. "ACME" not "ACME SA DE CV"
+	TaxRegime  string `json:"tax_regime" binding:"required"`  // SAT c_RegimenFiscal code, e.g. 612
+	PostalCode string `json:"postal_code" binding:"required"` // Of the fiscal address
+	CFDIUse    string `json:"cfdi_use,omitempty"`             // SAT c_UsoCFDI code, defaults to D01 (medical expenses)
+}
+
+// IssuerFiscalProfileRequest represents the fiscal data the organization issues CFDI with
+type IssuerFiscalProfileRequest struct {
+	RFC         string `json:"rfc" binding:"required"`
+	LegalName   string `json:"legal_name" binding:"required"`
+	TaxRegime   string `json:"tax_regime" binding:"required"`
+	PostalCode  string `json:"postal_code" binding:"required"` // Place of issue of every CFDI
+	ProductCode string `json:"product_code,omitempty"`         // SAT c_ClaveProdServ code, defaults to 85121600
+}
+
+// CancelCFDIRequest represents the cancellation of a CFDI at the SAT
+type CancelCFDIRequest struct {
+	Reason          string  `json:"reason" binding:"required"`  // SAT motive: 01, 02, 03 or 04
+	ReplacementUUID *string `json:"replacement_uuid,omitempty"` // Required for motive 01
+}
+
+// PatientFiscalDataResponse represents the fiscal data of a patient
+type PatientFiscalDataResponse struct {
+	PatientID  uuid.UUID `json:"patient_id"`
+	RFC        string    `json:"rfc"`
+	LegalName  string    `json:"legal_name"`
+	TaxRegime  string    `json:"tax_regime"`
+	PostalCode string    `json:"postal_code"`
+	CFDIUse    string    `json:"cfdi_use"`
+	IsCompany  bool      `json:"is_company"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// IssuerFiscalProfileResponse represents the fiscal data of the organization
+type IssuerFiscalProfileResponse struct {
+	RFC         string    `json:"rfc"`
+	LegalName   string    `json:"legal_name"`
+	TaxRegime   string    `json:"tax_regime"`
+	PostalCode  string    `json:"postal_code"`
+	ProductCode string    `json:"product_code"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CFDIResponse represents a stamped CFDI; the XML is downloaded separately
+type CFDIResponse struct {
+	ID                      uuid.UUID  `json:"id"`
+	InvoiceID               uuid.UUID  `json:"invoice_id"`
+	PatientID               uuid.UUID  `json:"patient_id"`
+	UUID                    string     `json:"uuid"`
+	Status                  string     `json:"status"`
+	IssuerRFC               string     `json:"issuer_rfc"`
+	ReceiverRFC             string     `json:"receiver_rfc"`
+	Total                   int64      `json:"total"`
+	StampedAt               time.Time  `json:"stamped_at"`
+	CancellationReason      *string    `json:"cancellation_reason,omitempty"`
+	ReplacementUUID         *string    `json:"replacement_uuid,omitempty"`
+	CancellationRequestedAt *time.Time `json:"cancellation_requested_at,omitempty"`
+	CancelledAt             *time.Time `json:"cancelled_at,omitempty"`
+	CancelledBy             *uuid.UUID `json:"cancelled_by,omitempty"`
+	CreatedBy               *uuid.UUID `json:"created_by,omitempty"`
+}
+
+// CFDIXML is a stamped CFDI document to download
+type CFDIXML struct {
+	FileName string
+	Content  []byte
+}
+
+// ToPatientFiscalDataResponse converts a patient fiscal profile to its response
+func ToPatientFiscalDataResponse(profile *entities.PatientFiscalProfile) *PatientFiscalDataResponse {
+	return &PatientFiscalDataResponse{
+		PatientID:  profile.PatientID,
+		RFC:        profile.RFC,
+		LegalName:  profile.LegalName,
+		TaxRegime:  profile.TaxRegime,
+		PostalCode: profile.PostalCode,
+		CFDIUse:    profile.CFDIUse,
+		IsCompany:  profile.IsCompany(),
+		UpdatedAt:  profile.UpdatedAt,
+	}
+}
+
+// ToIssuerFiscalProfileResponse converts an issuer fiscal profile to its response
+func ToIssuerFiscalProfileResponse(profile *entities.IssuerFiscalProfile) *IssuerFiscalProfileResponse {
+	return &IssuerFiscalProfileResponse{
+		RFC:         profile.RFC,
+		LegalName:   profile.LegalName,
+		TaxRegime:   profile.TaxRegime,
+		PostalCode:  profile.PostalCode,
+		ProductCode: profile.ProductCode,
+		UpdatedAt:   profile.UpdatedAt,
+	}
+}
+
+// ToCFDIResponse converts a CFDI to its response
+func ToCFDIResponse(cfdi *entities.CFDI) *CFDIResponse {
+	response := &CFDIResponse{
+		ID:                      cfdi.ID,
+		InvoiceID:               cfdi.InvoiceID,
+		PatientID:               cfdi.PatientID,
+		UUID:                    cfdi.UUID,
+		Status:                  string(cfdi.Status),
+		IssuerRFC:               cfdi.IssuerRFC,
+		ReceiverRFC:             cfdi.ReceiverRFC,
+		Total:                   cfdi.Total,
+		StampedAt:               cfdi.StampedAt,
+		ReplacementUUID:         cfdi.ReplacementUUID,
+		CancellationRequestedAt: cfdi.CancellationRequestedAt,
+		CancelledAt:             cfdi.CancelledAt,
+		CancelledBy:             cfdi.CancelledBy,
+		CreatedBy:               cfdi.CreatedBy,
+	}
+	if cfdi.CancellationReason != nil {
+		reason := string(*cfdi.CancellationReason)
+		response.CancellationReason = &reason
+	}
+	return response
+}
+
+// ToCFDIResponses converts CFDI to their responses
+func ToCFDIResponses(cfdis []*entities.CFDI) []*CFDIResponse {
+	responses := make([]*CFDIResponse, len(cfdis))
+	for i, cfdi := range cfdis {
+		responses[i] = ToCFDIResponse(cfdi)
+	}
+	return responses
+}
@@ -28,6 +28,7 @@ type ClinicResponse struct {
 	Name      string    `json:"name"`
 	Address   *string   `json:"address,omitempty"`
 	Phone     *string   `json:"phone,omitempty"`
+	PhoneE164 *string   `json:"phone_e164,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -51,6 +52,7 @@ func ToClinicResponse(c *entities.Clinic) *ClinicResponse {
 		Name:      c.Name,
 		Address:   c.Address,
 		Phone:     c.Phone,
+		PhoneE164: c.PhoneE164,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: c.UpdatedAt,
 	}
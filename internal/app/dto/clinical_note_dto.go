@@ -0,0 +1,182 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// SOAPNoteInput represents the four sections of a SOAP note
+type SOAPNoteInput struct {
+	Subjective string `json:"subjective"`
+	Objective  string `json:"objective"`
+	Assessment string `json:"assessment"`
+	Plan       string `json:"plan"`
+}
+
+// ToEntity converts the sections to the domain value
+func (in SOAPNoteInput) ToEntity() entities.SOAPNote {
+	return entities.SOAPNote{
+		Subjective: in.Subjective,
+		Objective:  in.Objective,
+		Assessment: in.Assessment,
+		Plan:       in.Plan,
+	}
+}
+
+// CreateClinicalNoteTemplateRequest represents a new note template
+type CreateClinicalNoteTemplateRequest struct {
+	ServiceID *string `json:"service_id,omitempty"` // Omit for the organization default template
+	Name      string  `json:"name" binding:"required"`
+	SOAPNoteInput
+}
+
+// UpdateClinicalNoteTemplateRequest represents changes to a note template
+type UpdateClinicalNoteTemplateRequest struct {
+	Name string `json:"name" binding:"required"`
+	SOAPNoteInput
+}
+
+// ClinicalNoteTemplateResponse represents a note template
+type ClinicalNoteTemplateResponse struct {
+	ID         uuid.UUID `json:"id"`
+	ServiceID  *string   `json:"service_id,omitempty"`
+	Name       string    `json:"name"`
+	Subjective string    `json:"subjective"`
+	Objective  string    `json:"objective"`
+	Assessment string    `json:"assessment"`
+	Plan       string    `json:"plan"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ClinicalNoteTemplatesResponse represents the templates of an organization
+type ClinicalNoteTemplatesResponse struct {
+	Templates []*ClinicalNoteTemplateResponse `json:"templates"`
+}
+
+// CreateClinicalNoteRequest starts the note of an appointment
+type CreateClinicalNoteRequest struct {
+	AppointmentID uuid.UUID      `json:"appointment_id" binding:"required"`
+	TemplateID    *uuid.UUID     `json:"template_id,omitempty"` // Defaults to the service template, then the organization default
+	Content       *SOAPNoteInput `json:"content,omitempty"`     // Replaces the template sections when given
+}
+
+// UpdateClinicalNoteRequest replaces the sections of a draft note
+type UpdateClinicalNoteRequest struct {
+	SOAPNoteInput
+}
+
+// AmendClinicalNoteRequest represents an addendum to a signed note
+type AmendClinicalNoteRequest struct {
+	Content string  `json:"content" binding:"required"`
+	Reason  *string `json:"reason,omitempty"`
+}
+
+// ClinicalNoteQuery selects the note of an appointment
+type ClinicalNoteQuery struct {
+	AppointmentID string `form:"appointment_id" binding:"required"`
+}
+
+// ClinicalNoteTimelineRequest represents the pagination of a patient's notes
+type ClinicalNoteTimelineRequest struct {
+	Page  int `form:"page,omitempty"`
+	Limit int `form:"limit,omitempty"`
+}
+
+// ClinicalNoteAmendmentResponse represents an amendment
+type ClinicalNoteAmendmentResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	AuthorID  uuid.UUID  `json:"author_id"`
+	DoctorID  *uuid.UUID `json:"doctor_id,omitempty"`
+	Content   string     `json:"content"`
+	Reason    *string    `json:"reason,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ClinicalNoteResponse represents a clinical note with its amendments
+type ClinicalNoteResponse struct {
+	ID               uuid.UUID                        `json:"id"`
+	PatientID        uuid.UUID                        `json:"patient_id"`
+	AppointmentID    uuid.UUID                        `json:"appointment_id"`
+	AppointmentStart *time.Time                       `json:"appointment_start,omitempty"`
+	DoctorID         uuid.UUID                        `json:"doctor_id"`
+	DoctorName       *string                          `json:"doctor_name,omitempty"`
+	ServiceName      *string                          `json:"service_name,omitempty"`
+	AuthorID         uuid.UUID                        `json:"author_id"`
+	TemplateID       *uuid.UUID                       `json:"template_id,omitempty"`
+	Subjective       string                           `json:"subjective"`
+	Objective        string                           `json:"objective"`
+	Assessment       string                           `json:"assessment"`
+	Plan             string                           `json:"plan"`
+	Status           string                           `json:"status"`
+	SignedAt         *time.Time                       `json:"signed_at,omitempty"`
+	Amendments       []*ClinicalNoteAmendmentResponse `json:"amendments"`
+	CreatedAt        time.Time                        `json:"created_at"`
+	UpdatedAt        time.Time                        `json:"updated_at"`
+}
+
+// ClinicalNoteTimelineResponse represents a page of a patient's notes, most recent first
+type ClinicalNoteTimelineResponse struct {
+	Notes      []*ClinicalNoteResponse `json:"notes"`
+	Pagination PaginationInfo          `json:"pagination"`
+}
+
+// ToClinicalNoteTemplateResponse converts a template to its response
+func ToClinicalNoteTemplateResponse(template *entities.ClinicalNoteTemplate) *ClinicalNoteTemplateResponse {
+	return &ClinicalNoteTemplateResponse{
+		ID:         template.ID,
+		ServiceID:  template.ServiceID,
+		Name:       template.Name,
+		Subjective: template.Subjective,
+		Objective:  template.Objective,
+		Assessment: template.Assessment,
+		Plan:       template.Plan,
+		CreatedAt:  template.CreatedAt,
+		UpdatedAt:  template.UpdatedAt,
+	}
+}
+
+// ToClinicalNoteResponse converts a note and its amendments to a response
+func ToClinicalNoteResponse(note *entities.ClinicalNote, amendments []*entities.ClinicalNoteAmendment) *ClinicalNoteResponse {
+	response := &ClinicalNoteResponse{
+		ID:            note.ID,
+		PatientID:     note.PatientID,
+		AppointmentID: note.AppointmentID,
+		DoctorID:      note.DoctorID,
+		AuthorID:      note.AuthorID,
+		TemplateID:    note.TemplateID,
+		Subjective:    note.Subjective,
+		Objective:     note.Objective,
+		Assessment:    note.Assessment,
+		Plan:          note.Plan,
+		Status:        string(note.Status),
+		SignedAt:      note.SignedAt,
+		Amendments:    make([]*ClinicalNoteAmendmentResponse, len(amendments)),
+		CreatedAt:     note.CreatedAt,
+		UpdatedAt:     note.UpdatedAt,
+	}
+	for i, amendment := range amendments {
+		response.Amendments[i] = &ClinicalNoteAmendmentResponse{
+			ID:        amendment.ID,
+			AuthorID:  amendment.AuthorID,
+			DoctorID:  amendment.DoctorID,
+			Content:   amendment.Content,
+			Reason:    amendment.Reason,
+			CreatedAt: amendment.CreatedAt,
+		}
+	}
+	return response
+}
+
+// ToClinicalNoteTimelineItemResponse converts a timeline item to a response
+func ToClinicalNoteTimelineItemResponse(item *repositories.ClinicalNoteTimelineItem) *ClinicalNoteResponse {
+	response := ToClinicalNoteResponse(item.Note, item.Amendments)
+	response.AppointmentStart = &item.AppointmentStart
+	response.DoctorName = &item.DoctorName
+	response.ServiceName = item.ServiceName
+	return response
+}
@@ -0,0 +1,201 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// CreateConsentTemplateRequest represents a new consent template
+type CreateConsentTemplateRequest struct {
+	ServiceID *string `json:"service_id,omitempty"` // Omit for a consent that applies to every service
+	Name      string  `json:"name" binding:"required"`
+	Body      string  `json:"body" binding:"required"` // Text with merge fields such as {{patient.name}}
+	Required  bool    `json:"required"`                // Appointments warn until the patient has signed it
+}
+
+// UpdateConsentTemplateRequest represents the new content of a consent template
+type UpdateConsentTemplateRequest struct {
+	ServiceID *string `json:"service_id,omitempty"`
+	Name      string  `json:"name" binding:"required"`
+	Body      string  `json:"body" binding:"required"`
+	Required  bool    `json:"required"`
+}
+
+// ConsentTemplateResponse represents a consent template
+type ConsentTemplateResponse struct {
+	ID        uuid.UUID `json:"id"`
+	ServiceID *string   `json:"service_id,omitempty"`
+	Name      string    `json:"name"`
+	Body      string    `json:"body"`
+	Required  bool      `json:"required"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ConsentTemplatesResponse represents the consent templates of an organization
+type ConsentTemplatesResponse struct {
+	Templates   []*ConsentTemplateResponse `json:"templates"`
+	MergeFields []string                   `json:"merge_fields"` // Fields templates may use, as {{field}}
+}
+
+// RequestConsentFormRequest represents a consent form to be signed by a patient
+type RequestConsentFormRequest struct {
+	TemplateID    uuid.UUID  `json:"template_id" binding:"required"`
+	AppointmentID *uuid.UUID `json:"appointment_id,omitempty"` // Omit for a consent that covers every visit
+	DoctorID      *uuid.UUID `json:"doctor_id,omitempty"`      // Defaults to the appointment's doctor
+	SendEmail     bool       `json:"send_email"`               // Email the signing link to the patient
+}
+
+// ConsentFormListRequest represents the filters and pagination of a consent form listing
+type ConsentFormListRequest struct {
+	Status           string     `form:"status,omitempty"` // pending, signed or revoked
+	AppointmentIDStr string     `form:"appointment_id,omitempty"`
+	AppointmentID    *uuid.UUID `form:"-"`
+	Page             int        `form:"page,omitempty"`
+	Limit            int        `form:"limit,omitempty"`
+}
+
+// RevokeConsentFormRequest represents the withdrawal of a consent or cancellation of a request
+type RevokeConsentFormRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ConsentFormResponse represents a consent form as seen by staff
+type ConsentFormResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	PatientID        uuid.UUID  `json:"patient_id"`
+	TemplateID       *uuid.UUID `json:"template_id,omitempty"`
+	AppointmentID    *uuid.UUID `json:"appointment_id,omitempty"`
+	DoctorID         *uuid.UUID `json:"doctor_id,omitempty"`
+	ServiceID        *string    `json:"service_id,omitempty"`
+	Title            string     `json:"title"`
+	Body             string     `json:"body"`
+	Status           string     `json:"status"`
+	Expired          bool       `json:"expired"` // Pending and past the link expiry
+	ExpiresAt        time.Time  `json:"expires_at"`
+	SigningURL       *string    `json:"signing_url,omitempty"` // Set while the form can be signed
+	RequestedBy      *uuid.UUID `json:"requested_by,omitempty"`
+	SignerName       *string    `json:"signer_name,omitempty"`
+	SignatureType    *string    `json:"signature_type,omitempty"`
+	SignedIP         *string    `json:"signed_ip,omitempty"`
+	SignedUserAgent  *string    `json:"signed_user_agent,omitempty"`
+	SignedAt         *time.Time `json:"signed_at,omitempty"`
+	DocumentURL      *string    `json:"document_url,omitempty"` // Signed copy
+	DocumentSHA256   *string    `json:"document_sha256,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy        *uuid.UUID `json:"revoked_by,omitempty"`
+	RevocationReason *string    `json:"revocation_reason,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// ConsentFormListResponse represents a page of consent forms, newest first
+type ConsentFormListResponse struct {
+	ConsentForms []*ConsentFormResponse `json:"consent_forms"`
+	Pagination   PaginationInfo         `json:"pagination"`
+}
+
+// SignConsentFormRequest represents the patient's signature submitted through the signing link
+type SignConsentFormRequest struct {
+	SignerName    string `json:"signer_name" binding:"required"`
+	SignatureType string `json:"signature_type" binding:"required"` // typed or drawn
+	Signature     string `json:"signature" binding:"required"`      // Typed name, or a PNG data URL
+	Agree         bool   `json:"agree"`                             // The patient read and agrees to the consent
+}
+
+// PublicConsentFormResponse represents a consent form as shown to the patient signing it
+type PublicConsentFormResponse struct {
+	OrganizationName string     `json:"organization_name"`
+	PatientName      string     `json:"patient_name"`
+	Title            string     `json:"title"`
+	Body             string     `json:"body"`
+	Status           string     `json:"status"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	SignedAt         *time.Time `json:"signed_at,omitempty"`
+}
+
+// ConsentRequirementResponse represents a required consent of an appointment and whether it is signed
+type ConsentRequirementResponse struct {
+	TemplateID    uuid.UUID  `json:"template_id"`
+	TemplateName  string     `json:"template_name"`
+	Signed        bool       `json:"signed"`
+	ConsentFormID *uuid.UUID `json:"consent_form_id,omitempty"` // Signed form that satisfies it
+}
+
+// AppointmentConsentsResponse represents the required consents of an appointment
+type AppointmentConsentsResponse struct {
+	AppointmentID uuid.UUID                     `json:"appointment_id"`
+	Complete      bool                          `json:"complete"` // Every required consent is signed
+	Requirements  []*ConsentRequirementResponse `json:"requirements"`
+}
+
+// ToConsentTemplateResponse converts a consent template to its response
+func ToConsentTemplateResponse(template *entities.ConsentFormTemplate) *ConsentTemplateResponse {
+	return &ConsentTemplateResponse{
+		ID:        template.ID,
+		ServiceID: template.ServiceID,
+		Name:      template.Name,
+		Body:      template.Body,
+		Required:  template.Required,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
+
+// ToConsentFormResponse converts a consent form to its response; signingURL is only
+// included while the form can be signed
+func ToConsentFormResponse(form *entities.ConsentForm, signingURL string) *ConsentFormResponse {
+	now := time.Now()
+	response := &ConsentFormResponse{
+		ID:               form.ID,
+		PatientID:        form.PatientID,
+		TemplateID:       form.TemplateID,
+		AppointmentID:    form.AppointmentID,
+		DoctorID:         form.DoctorID,
+		ServiceID:        form.ServiceID,
+		Title:            form.Title,
+		Body:             form.Body,
+		Status:           string(form.Status),
+		Expired:          form.IsExpired(now),
+		ExpiresAt:        form.ExpiresAt,
+		RequestedBy:      form.RequestedBy,
+		SignerName:       form.SignerName,
+		SignedIP:         form.SignedIP,
+		SignedUserAgent:  form.SignedUserAgent,
+		SignedAt:         form.SignedAt,
+		DocumentSHA256:   form.DocumentSHA256,
+		RevokedAt:        form.RevokedAt,
+		RevokedBy:        form.RevokedBy,
+		RevocationReason: form.RevocationReason,
+		CreatedAt:        form.CreatedAt,
+		UpdatedAt:        form.UpdatedAt,
+	}
+	if form.SignatureType != nil {
+		signatureType := string(*form.SignatureType)
+		response.SignatureType = &signatureType
+	}
+	if signingURL != "" && form.CanBeSigned(now) == nil {
+		response.SigningURL = &signingURL
+	}
+	if form.HasDocument() {
+		documentURL := "/api/v1/consent-forms/" + form.ID.String() + "/document"
+		response.DocumentURL = &documentURL
+	}
+	return response
+}
+
+// ToPublicConsentFormResponse converts a consent form to the view shown to the patient
+func ToPublicConsentFormResponse(form *entities.ConsentForm, organizationName, patientName string) *PublicConsentFormResponse {
+	return &PublicConsentFormResponse{
+		OrganizationName: organizationName,
+		PatientName:      patientName,
+		Title:            form.Title,
+		Body:             form.Body,
+		Status:           string(form.Status),
+		ExpiresAt:        form.ExpiresAt,
+		SignedAt:         form.SignedAt,
+	}
+}
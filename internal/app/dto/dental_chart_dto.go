@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// RecordDentalChartRequest represents charting entries recorded together, typically during an exam
+type RecordDentalChartRequest struct {
+	Numbering     string                  `json:"numbering,omitempty"` // fdi (default) or universal, for the teeth in entries
+	AppointmentID *uuid.UUID              `json:"appointment_id,omitempty"`
+	DoctorID      *uuid.UUID              `json:"doctor_id,omitempty"`   // Defaults to the appointment's doctor
+	RecordedAt    *time.Time              `json:"recorded_at,omitempty"` // When the findings were observed, defaults to now
+	Entries       []DentalChartEntryInput `json:"entries" binding:"required,min=1,dive"`
+}
+
+// DentalChartEntryInput represents one tooth finding
+type DentalChartEntryInput struct {
+	Tooth     string   `json:"tooth" binding:"required"`
+	Surfaces  []string `json:"surfaces,omitempty"` // M, D, O, I, B, L; omit for the whole tooth
+	Condition string   `json:"condition" binding:"required"`
+	Notes     *string  `json:"notes,omitempty"`
+}
+
+// DentalChartRequest represents the query of a patient's chart
+type DentalChartRequest struct {
+	AsOf      string `form:"as_of,omitempty"`     // YYYY-MM-DD (end of day) or RFC 3339, defaults to now
+	Numbering string `form:"numbering,omitempty"` // fdi (default) or universal
+}
+
+// DentalChartEntriesRequest represents the query of a patient's charting history
+type DentalChartEntriesRequest struct {
+	Tooth     string `form:"tooth,omitempty"`     // Only entries of this tooth, in the requested numbering
+	Numbering string `form:"numbering,omitempty"` // fdi (default) or universal
+}
+
+// ToothResponse identifies a tooth in both numbering systems
+type ToothResponse struct {
+	Tooth     string `json:"tooth"` // In the requested numbering
+	FDI       string `json:"fdi"`
+	Universal string `json:"universal"`
+	Primary   bool   `json:"primary"`
+}
+
+// ToothStateResponse represents the charted state of a tooth
+type ToothStateResponse struct {
+	ToothResponse
+	Condition   *string           `json:"condition,omitempty"` // Whole-tooth condition
+	Surfaces    map[string]string `json:"surfaces"`            // Surface findings by surface
+	LastEntryID uuid.UUID         `json:"last_entry_id"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// DentalChartResponse represents a patient's chart as of a point in time
+type DentalChartResponse struct {
+	PatientID uuid.UUID             `json:"patient_id"`
+	AsOf      time.Time             `json:"as_of"`
+	Numbering string                `json:"numbering"`
+	Teeth     []*ToothStateResponse `json:"teeth"` // Charted teeth only, in FDI order
+}
+
+// DentalChartEntryResponse represents a charting entry
+type DentalChartEntryResponse struct {
+	ID uuid.UUID `json:"id"`
+	ToothResponse
+	Surfaces      []string   `json:"surfaces"`
+	Condition     string     `json:"condition"`
+	Notes         *string    `json:"notes,omitempty"`
+	AppointmentID *uuid.UUID `json:"appointment_id,omitempty"`
+	DoctorID      *uuid.UUID `json:"doctor_id,omitempty"`
+	RecordedAt    time.Time  `json:"recorded_at"`
+	RecordedBy    *uuid.UUID `json:"recorded_by,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// DentalChartEntriesResponse represents a patient's charting history in the order it was observed
+type DentalChartEntriesResponse struct {
+	Numbering string                      `json:"numbering"`
+	Entries   []*DentalChartEntryResponse `json:"entries"`
+}
+
+// ToToothResponse describes a tooth in the requested numbering and both standard notations
+func ToToothResponse(tooth entities.Tooth, numbering entities.ToothNumberingSystem) ToothResponse {
+	return ToothResponse{
+		Tooth:     tooth.Format(numbering),
+		FDI:       tooth.FDI(),
+		Universal: tooth.Universal(),
+		Primary:   tooth.IsPrimary(),
+	}
+}
+
+// ToToothStateResponse converts a charted tooth state to ToothStateResponse
+func ToToothStateResponse(state *services.ToothState, numbering entities.ToothNumberingSystem) *ToothStateResponse {
+	response := &ToothStateResponse{
+		ToothResponse: ToToothResponse(state.Tooth, numbering),
+		Surfaces:      make(map[string]string, len(state.Surfaces)),
+		LastEntryID:   state.LastEntryID,
+		UpdatedAt:     state.UpdatedAt,
+	}
+	if state.Condition != "" {
+		condition := string(state.Condition)
+		response.Condition = &condition
+	}
+	for surface, condition := range state.Surfaces {
+		response.Surfaces[string(surface)] = string(condition)
+	}
+	return response
+}
+
+// ToDentalChartEntryResponse converts a charting entry to DentalChartEntryResponse
+func ToDentalChartEntryResponse(e *entities.DentalChartEntry, numbering entities.ToothNumberingSystem) *DentalChartEntryResponse {
+	surfaces := make([]string, len(e.Surfaces))
+	for i, surface := range e.Surfaces {
+		surfaces[i] = string(surface)
+	}
+
+	return &DentalChartEntryResponse{
+		ID:            e.ID,
+		ToothResponse: ToToothResponse(e.Tooth, numbering),
+		Surfaces:      surfaces,
+		Condition:     string(e.Condition),
+		Notes:         e.Notes,
+		AppointmentID: e.AppointmentID,
+		DoctorID:      e.DoctorID,
+		RecordedAt:    e.RecordedAt,
+		RecordedBy:    e.RecordedBy,
+		CreatedAt:     e.CreatedAt,
+	}
+}
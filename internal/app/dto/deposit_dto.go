@@ -0,0 +1,147 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// DepositPolicyRequest represents the organization's deposit policy.
+// Amount is in minor units of the organization's currency.
+type DepositPolicyRequest struct {
+	Enabled            bool   `json:"enabled"`
+	HoldHours          int    `json:"hold_hours" binding:"required"`
+	CutoffHours        int    `json:"cutoff_hours"`
+	NoShowThreshold    int    `json:"no_show_threshold"`       // 0 never asks unreliable patients for a deposit
+	NoShowLookbackDays int    `json:"no_show_lookback_days"`   // 0 counts the whole history
+	Type               string `json:"type" binding:"required"` // fixed or percentage
+	Amount             int64  `json:"amount"`
+	Percent            int    `json:"percent"`
+}
+
+// ServiceDepositRequest represents the deposit required to book a service; a null type clears it
+type ServiceDepositRequest struct {
+	Type    *string `json:"type"` // fixed or percentage
+	Amount  int64   `json:"amount"`
+	Percent int     `json:"percent"`
+}
+
+// RequestDepositRequest represents staff asking for a deposit on an existing appointment
+type RequestDepositRequest struct {
+	Amount int64      `json:"amount" binding:"required"` // Minor units
+	DueAt  *time.Time `json:"due_at,omitempty"`          // Defaults to the policy's hold
+}
+
+// WaiveDepositRequest represents staff letting a patient keep an appointment without its deposit
+type WaiveDepositRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// DepositListRequest represents the filters to list deposits
+type DepositListRequest struct {
+	ClinicIDStr  string     `form:"clinic_id,omitempty"`
+	ClinicID     *uuid.UUID `form:"-"`
+	PatientIDStr string     `form:"patient_id,omitempty"`
+	PatientID    *uuid.UUID `form:"-"`
+	Status       string     `form:"status,omitempty"`
+	Page         int        `form:"page,omitempty"`
+	Limit        int        `form:"limit,omitempty"`
+}
+
+// DepositPolicyResponse represents the organization's deposit policy
+type DepositPolicyResponse struct {
+	Enabled            bool       `json:"enabled"`
+	HoldHours          int        `json:"hold_hours"`
+	CutoffHours        int        `json:"cutoff_hours"`
+	NoShowThreshold    int        `json:"no_show_threshold"`
+	NoShowLookbackDays int        `json:"no_show_lookback_days"`
+	Type               string     `json:"type"`
+	Amount             int64      `json:"amount"`
+	Percent            int        `json:"percent"`
+	Currency           string     `json:"currency"`
+	UpdatedBy          *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"` // Omitted until the organization sets a policy
+}
+
+// DepositResponse represents an appointment deposit
+type DepositResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	ClinicID         uuid.UUID  `json:"clinic_id"`
+	PatientID        uuid.UUID  `json:"patient_id"`
+	AppointmentID    uuid.UUID  `json:"appointment_id"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"`
+	Currency         string     `json:"currency"`
+	Amount           int64      `json:"amount"`
+	DueAt            time.Time  `json:"due_at"`
+	PaymentURL       *string    `json:"payment_url,omitempty"`
+	PaymentReference *string    `json:"payment_reference,omitempty"`
+	PaidAt           *time.Time `json:"paid_at,omitempty"`
+	InvoiceID        *uuid.UUID `json:"invoice_id,omitempty"`
+	AppliedAt        *time.Time `json:"applied_at,omitempty"`
+	WaiveReason      *string    `json:"waive_reason,omitempty"`
+	WaivedBy         *uuid.UUID `json:"waived_by,omitempty"`
+	WaivedAt         *time.Time `json:"waived_at,omitempty"`
+	ReleasedAt       *time.Time `json:"released_at,omitempty"`
+	RequestedBy      *uuid.UUID `json:"requested_by,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// DepositListResponse represents a page of deposits
+type DepositListResponse struct {
+	Deposits   []*DepositResponse `json:"deposits"`
+	Pagination PaginationInfo     `json:"pagination"`
+}
+
+// ToDepositPolicyResponse converts a deposit policy to its response
+func ToDepositPolicyResponse(policy *entities.DepositPolicy, currency string) *DepositPolicyResponse {
+	response := &DepositPolicyResponse{
+		Enabled:            policy.Enabled,
+		HoldHours:          policy.HoldHours,
+		CutoffHours:        policy.CutoffHours,
+		NoShowThreshold:    policy.NoShowThreshold,
+		NoShowLookbackDays: policy.NoShowLookbackDays,
+		Type:               string(policy.Type),
+		Amount:             policy.Amount,
+		Percent:            policy.Percent,
+		Currency:           currency,
+		UpdatedBy:          policy.UpdatedBy,
+	}
+	if !policy.UpdatedAt.IsZero() {
+		response.UpdatedAt = &policy.UpdatedAt
+	}
+	return response
+}
+
+// ToDepositResponse converts a deposit to its response
+func ToDepositResponse(deposit *entities.Deposit) *DepositResponse {
+	if deposit == nil {
+		return nil
+	}
+	return &DepositResponse{
+		ID:               deposit.ID,
+		ClinicID:         deposit.ClinicID,
+		PatientID:        deposit.PatientID,
+		AppointmentID:    deposit.AppointmentID,
+		Reason:           string(deposit.Reason),
+		Status:           string(deposit.Status),
+		Currency:         deposit.Currency,
+		Amount:           deposit.Amount,
+		DueAt:            deposit.DueAt,
+		PaymentURL:       deposit.PaymentURL,
+		PaymentReference: deposit.PaymentReference,
+		PaidAt:           deposit.PaidAt,
+		InvoiceID:        deposit.InvoiceID,
+		AppliedAt:        deposit.AppliedAt,
+		WaiveReason:      deposit.WaiveReason,
+		WaivedBy:         deposit.WaivedBy,
+		WaivedAt:         deposit.WaivedAt,
+		ReleasedAt:       deposit.ReleasedAt,
+		RequestedBy:      deposit.RequestedBy,
+		CreatedAt:        deposit.CreatedAt,
+		UpdatedAt:        deposit.UpdatedAt,
+	}
+}
@@ -37,6 +37,7 @@ type DoctorResponse struct {
 	Specialty     *string    `json:"specialty,omitempty"`
 	Email         *string    `json:"email,omitempty"`
 	Phone         *string    `json:"phone,omitempty"`
+	PhoneE164     *string    `json:"phone_e164,omitempty"`
 	DefaultUnitID *uuid.UUID `json:"default_unit_id,omitempty"`
 	IsActive      bool       `json:"is_active"`
 	Color         string     `json:"color"`
@@ -78,6 +79,7 @@ func ToDoctorResponse(d *entities.Doctor) *DoctorResponse {
 		Specialty:     d.Specialty,
 		Email:         d.Email,
 		Phone:         d.Phone,
+		PhoneE164:     d.PhoneE164,
 		DefaultUnitID: d.DefaultUnitID,
 		IsActive:      d.IsActive,
 		Color:         d.Color,
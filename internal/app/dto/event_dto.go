@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppointmentEventData is the payload of appointment.* domain events
+type AppointmentEventData struct {
+	Appointment                  *AppointmentResponse    `json:"appointment"`
+	PreviousStartTime            *time.Time              `json:"previous_start_time,omitempty"`             // Set on appointment.rescheduled
+	PreviousEndTime              *time.Time              `json:"previous_end_time,omitempty"`               // Set on appointment.rescheduled
+	RescheduledFromAppointmentID *uuid.UUID              `json:"rescheduled_from_appointment_id,omitempty"` // Set when rescheduled from the queue
+	Contact                      *PatientContactResponse `json:"contact,omitempty"`                         // Who to notify: a guardian for minors
+}
+
+// PatientEventData is the payload of patient.* domain events
+type PatientEventData struct {
+	Patient         *PatientResponse `json:"patient"`
+	MergedPatientID *uuid.UUID       `json:"merged_patient_id,omitempty"` // Set on patient.merged: the deleted duplicate
+}
@@ -0,0 +1,296 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// InsuranceCarrierRequest represents the creation or update of an insurance carrier
+type InsuranceCarrierRequest struct {
+	Name    string  `json:"name" binding:"required"`
+	PayerID *string `json:"payer_id,omitempty"`
+	Phone   *string `json:"phone,omitempty"`
+	Email   *string `json:"email,omitempty"`
+	Notes   *string `json:"notes,omitempty"`
+	Active  *bool   `json:"active,omitempty"` // Update only; new carriers are active
+}
+
+// InsuranceCarrierListRequest represents the filters to list carriers
+type InsuranceCarrierListRequest struct {
+	IncludeInactive bool `form:"include_inactive,omitempty"`
+}
+
+// InsurancePolicyRequest represents the creation or update of a patient's policy.
+// Amounts are in minor units of the organization's currency.
+type InsurancePolicyRequest struct {
+	CarrierID      uuid.UUID      `json:"carrier_id" binding:"required"`
+	MemberID       string         `json:"member_id" binding:"required"`
+	GroupNumber    *string        `json:"group_number,omitempty"`
+	SubscriberName *string        `json:"subscriber_name,omitempty"`
+	Coverage       map[string]int `json:"coverage"`                 // Percent by service category, e.g. {"preventive": 100}
+	AnnualMaximum  *int64         `json:"annual_maximum,omitempty"` // Omit for no maximum
+	Deductible     int64          `json:"deductible"`
+	EffectiveFrom  string         `json:"effective_from" binding:"required"` // YYYY-MM-DD
+	EffectiveTo    *string        `json:"effective_to,omitempty"`            // YYYY-MM-DD, last covered day
+}
+
+// CoverageEstimateRequest represents what to estimate under a policy: a single service,
+// a list of items or the pending items of a treatment plan
+type CoverageEstimateRequest struct {
+	ServiceID       *string             `json:"service_id,omitempty"`
+	Amount          *int64              `json:"amount,omitempty"` // Overrides the service's base price
+	Items           []CoverageItemInput `json:"items,omitempty"`
+	TreatmentPlanID *uuid.UUID          `json:"treatment_plan_id,omitempty"`
+	Date            *string             `json:"date,omitempty"` // YYYY-MM-DD of the service, defaults to today
+}
+
+// CoverageItemInput represents a service to estimate
+type CoverageItemInput struct {
+	ServiceID string `json:"service_id" binding:"required"`
+	Amount    *int64 `json:"amount,omitempty"` // Overrides the service's base price
+}
+
+// CreateInsuranceClaimRequest represents a claim for a completed appointment
+type CreateInsuranceClaimRequest struct {
+	PolicyID      uuid.UUID `json:"policy_id" binding:"required"`
+	AppointmentID uuid.UUID `json:"appointment_id" binding:"required"`
+	BilledAmount  *int64    `json:"billed_amount,omitempty"` // Defaults to the appointment's invoice total
+	ClaimNumber   *string   `json:"claim_number,omitempty"`
+	Notes         *string   `json:"notes,omitempty"`
+}
+
+// ApproveInsuranceClaimRequest represents the carrier's acceptance of a claim
+type ApproveInsuranceClaimRequest struct {
+	ApprovedAmount    int64   `json:"approved_amount"`
+	DeductibleApplied *int64  `json:"deductible_applied,omitempty"` // As reported by the carrier
+	ClaimNumber       *string `json:"claim_number,omitempty"`
+}
+
+// RejectInsuranceClaimRequest represents the carrier's refusal of a claim
+type RejectInsuranceClaimRequest struct {
+	Reason      string  `json:"reason" binding:"required"`
+	ClaimNumber *string `json:"claim_number,omitempty"`
+}
+
+// PayInsuranceClaimRequest represents the carrier's payment of an approved claim
+type PayInsuranceClaimRequest struct {
+	PaidAmount *int64 `json:"paid_amount,omitempty"` // Defaults to the approved amount
+}
+
+// ResubmitInsuranceClaimRequest represents sending a corrected claim again
+type ResubmitInsuranceClaimRequest struct {
+	Notes *string `json:"notes,omitempty"`
+}
+
+// InsuranceClaimListRequest represents the filters to list claims
+type InsuranceClaimListRequest struct {
+	PatientIDStr     string     `form:"patient_id,omitempty"`
+	PatientID        *uuid.UUID `form:"-"`
+	PolicyIDStr      string     `form:"policy_id,omitempty"`
+	PolicyID         *uuid.UUID `form:"-"`
+	AppointmentIDStr string     `form:"appointment_id,omitempty"`
+	AppointmentID    *uuid.UUID `form:"-"`
+	Status           string     `form:"status,omitempty"`
+	Page             int        `form:"page,omitempty"`
+	Limit            int        `form:"limit,omitempty"`
+}
+
+// UpdateServiceCategoryRequest represents the insurance category of a service
+type UpdateServiceCategoryRequest struct {
+	Category string `json:"category" binding:"required"`
+}
+
+// InsuranceCarrierResponse represents an insurance carrier
+type InsuranceCarrierResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	PayerID   *string   `json:"payer_id,omitempty"`
+	Phone     *string   `json:"phone,omitempty"`
+	Email     *string   `json:"email,omitempty"`
+	Notes     *string   `json:"notes,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// InsurancePolicyResponse represents a patient's policy
+type InsurancePolicyResponse struct {
+	ID             uuid.UUID      `json:"id"`
+	PatientID      uuid.UUID      `json:"patient_id"`
+	CarrierID      uuid.UUID      `json:"carrier_id"`
+	MemberID       string         `json:"member_id"`
+	GroupNumber    *string        `json:"group_number,omitempty"`
+	SubscriberName *string        `json:"subscriber_name,omitempty"`
+	Coverage       map[string]int `json:"coverage"`
+	AnnualMaximum  *int64         `json:"annual_maximum,omitempty"`
+	Deductible     int64          `json:"deductible"`
+	EffectiveFrom  string         `json:"effective_from"`
+	EffectiveTo    *string        `json:"effective_to,omitempty"`
+	CreatedBy      *uuid.UUID     `json:"created_by,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// CoverageEstimateResponse represents the split of services between carrier and patient
+type CoverageEstimateResponse struct {
+	PolicyID            uuid.UUID                       `json:"policy_id"`
+	Currency            string                          `json:"currency"`
+	BenefitYear         int                             `json:"benefit_year"`
+	Items               []*CoverageItemEstimateResponse `json:"items"`
+	Total               int64                           `json:"total"`
+	InsurerPortion      int64                           `json:"insurer_portion"`
+	PatientPortion      int64                           `json:"patient_portion"`
+	DeductibleApplied   int64                           `json:"deductible_applied"`
+	DeductibleRemaining int64                           `json:"deductible_remaining"`
+	MaximumRemaining    *int64                          `json:"maximum_remaining,omitempty"`
+}
+
+// CoverageItemEstimateResponse represents the split of one service
+type CoverageItemEstimateResponse struct {
+	ServiceID         string `json:"service_id,omitempty"`
+	Description       string `json:"description"`
+	Category          string `json:"category"`
+	Amount            int64  `json:"amount"`
+	CoveragePercent   int    `json:"coverage_percent"`
+	DeductibleApplied int64  `json:"deductible_applied"`
+	InsurerPortion    int64  `json:"insurer_portion"`
+	PatientPortion    int64  `json:"patient_portion"`
+}
+
+// InsuranceClaimResponse represents a claim and where it is with the carrier
+type InsuranceClaimResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	PatientID         uuid.UUID  `json:"patient_id"`
+	PolicyID          uuid.UUID  `json:"policy_id"`
+	AppointmentID     uuid.UUID  `json:"appointment_id"`
+	InvoiceID         *uuid.UUID `json:"invoice_id,omitempty"`
+	ClaimNumber       *string    `json:"claim_number,omitempty"`
+	Status            string     `json:"status"`
+	Currency          string     `json:"currency"`
+	BilledAmount      int64      `json:"billed_amount"`
+	EstimatedAmount   int64      `json:"estimated_amount"`
+	DeductibleApplied int64      `json:"deductible_applied"`
+	ApprovedAmount    *int64     `json:"approved_amount,omitempty"`
+	PaidAmount        *int64     `json:"paid_amount,omitempty"`
+	RejectionReason   *string    `json:"rejection_reason,omitempty"`
+	ServiceDate       string     `json:"service_date"`
+	SubmittedAt       time.Time  `json:"submitted_at"`
+	DecidedAt         *time.Time `json:"decided_at,omitempty"`
+	PaidAt            *time.Time `json:"paid_at,omitempty"`
+	Notes             *string    `json:"notes,omitempty"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// InsuranceClaimListResponse represents a page of claims
+type InsuranceClaimListResponse struct {
+	Claims     []*InsuranceClaimResponse `json:"claims"`
+	Pagination PaginationInfo            `json:"pagination"`
+}
+
+// ToInsuranceCarrierResponse converts a carrier to its response
+func ToInsuranceCarrierResponse(carrier *entities.InsuranceCarrier) *InsuranceCarrierResponse {
+	return &InsuranceCarrierResponse{
+		ID:        carrier.ID,
+		Name:      carrier.Name,
+		PayerID:   carrier.PayerID,
+		Phone:     carrier.Phone,
+		Email:     carrier.Email,
+		Notes:     carrier.Notes,
+		Active:    carrier.Active,
+		CreatedAt: carrier.CreatedAt,
+		UpdatedAt: carrier.UpdatedAt,
+	}
+}
+
+// ToInsurancePolicyResponse converts a policy to its response
+func ToInsurancePolicyResponse(policy *entities.InsurancePolicy) *InsurancePolicyResponse {
+	coverage := make(map[string]int, len(policy.Coverage))
+	for category, percent := range policy.Coverage {
+		coverage[string(category)] = percent
+	}
+
+	response := &InsurancePolicyResponse{
+		ID:             policy.ID,
+		PatientID:      policy.PatientID,
+		CarrierID:      policy.CarrierID,
+		MemberID:       policy.MemberID,
+		GroupNumber:    policy.GroupNumber,
+		SubscriberName: policy.SubscriberName,
+		Coverage:       coverage,
+		AnnualMaximum:  policy.AnnualMaximum,
+		Deductible:     policy.Deductible,
+		EffectiveFrom:  policy.EffectiveFrom.Format("2006-01-02"),
+		CreatedBy:      policy.CreatedBy,
+		CreatedAt:      policy.CreatedAt,
+		UpdatedAt:      policy.UpdatedAt,
+	}
+	if policy.EffectiveTo != nil {
+		to := policy.EffectiveTo.Format("2006-01-02")
+		response.EffectiveTo = &to
+	}
+	return response
+}
+
+// ToCoverageEstimateResponse converts a coverage estimate to its response
+func ToCoverageEstimateResponse(policyID uuid.UUID, currency string, benefitYear int, estimate services.CoverageEstimate) *CoverageEstimateResponse {
+	items := make([]*CoverageItemEstimateResponse, len(estimate.Items))
+	for i, item := range estimate.Items {
+		items[i] = &CoverageItemEstimateResponse{
+			ServiceID:         item.ServiceID,
+			Description:       item.Description,
+			Category:          string(item.Category),
+			Amount:            item.Amount,
+			CoveragePercent:   item.CoveragePercent,
+			DeductibleApplied: item.DeductibleApplied,
+			InsurerPortion:    item.InsurerPortion,
+			PatientPortion:    item.PatientPortion,
+		}
+	}
+
+	return &CoverageEstimateResponse{
+		PolicyID:            policyID,
+		Currency:            currency,
+		BenefitYear:         benefitYear,
+		Items:               items,
+		Total:               estimate.Total,
+		InsurerPortion:      estimate.InsurerPortion,
+		PatientPortion:      estimate.PatientPortion,
+		DeductibleApplied:   estimate.DeductibleApplied,
+		DeductibleRemaining: estimate.DeductibleRemaining,
+		MaximumRemaining:    estimate.MaximumRemaining,
+	}
+}
+
+// ToInsuranceClaimResponse converts a claim to its response
+func ToInsuranceClaimResponse(claim *entities.InsuranceClaim) *InsuranceClaimResponse {
+	return &InsuranceClaimResponse{
+		ID:                claim.ID,
+		PatientID:         claim.PatientID,
+		PolicyID:          claim.PolicyID,
+		AppointmentID:     claim.AppointmentID,
+		InvoiceID:         claim.InvoiceID,
+		ClaimNumber:       claim.ClaimNumber,
+		Status:            string(claim.Status),
+		Currency:          claim.Currency,
+		BilledAmount:      claim.BilledAmount,
+		EstimatedAmount:   claim.EstimatedAmount,
+		DeductibleApplied: claim.DeductibleApplied,
+		ApprovedAmount:    claim.ApprovedAmount,
+		PaidAmount:        claim.PaidAmount,
+		RejectionReason:   claim.RejectionReason,
+		ServiceDate:       claim.ServiceDate.Format("2006-01-02"),
+		SubmittedAt:       claim.SubmittedAt,
+		DecidedAt:         claim.DecidedAt,
+		PaidAt:            claim.PaidAt,
+		Notes:             claim.Notes,
+		CreatedBy:         claim.CreatedBy,
+		CreatedAt:         claim.CreatedAt,
+		UpdatedAt:         claim.UpdatedAt,
+	}
+}
@@ -0,0 +1,279 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// CreateInvoiceRequest represents an invoice for a completed appointment. Without lines, one
+// line is generated from the appointment's procedure: the estimated cost of the treatment plan
+// item booked in it, or else the base price of its service.
+type CreateInvoiceRequest struct {
+	AppointmentID uuid.UUID          `json:"appointment_id" binding:"required"`
+	Lines         []InvoiceLineInput `json:"lines,omitempty" binding:"dive"`
+	Discount      int64              `json:"discount,omitempty"` // Minor currency units off the generated line
+	TaxRate       int                `json:"tax_rate,omitempty"` // Basis points (1600 = 16%) for the generated line
+	Notes         *string            `json:"notes,omitempty"`
+}
+
+// InvoiceLineInput represents a billed item. Amounts are in minor currency units.
+type InvoiceLineInput struct {
+	ServiceID   *string `json:"service_id,omitempty"`
+	Description string  `json:"description,omitempty"` // Defaults to the service name
+	Quantity    int     `json:"quantity,omitempty"`    // Defaults to 1
+	UnitPrice   *int64  `json:"unit_price,omitempty"`  // Defaults to the service base price
+	Discount    int64   `json:"discount,omitempty"`
+	TaxRate     int     `json:"tax_rate,omitempty"` // Basis points
+}
+
+// InvoiceListRequest represents the filters and pagination of an invoice listing
+type InvoiceListRequest struct {
+	PatientIDStr string     `form:"patient_id,omitempty"`
+	PatientID    *uuid.UUID `form:"-"`
+	ClinicIDStr  string     `form:"clinic_id,omitempty"`
+	ClinicID     *uuid.UUID `form:"-"`
+	Status       string     `form:"status,omitempty"`
+	From         string     `form:"from,omitempty"` // YYYY-MM-DD, issued on or after (UTC)
+	To           string     `form:"to,omitempty"`   // YYYY-MM-DD, issued on or before (UTC)
+	Page         int        `form:"page,omitempty"`
+	Limit        int        `form:"limit,omitempty"`
+}
+
+// RecordPaymentRequest represents money received towards an invoice
+type RecordPaymentRequest struct {
+	Method     string     `json:"method" binding:"required"` // cash, card or transfer
+	Amount     int64      `json:"amount" binding:"required"` // Minor currency units
+	Reference  *string    `json:"reference,omitempty"`
+	ReceivedAt *time.Time `json:"received_at,omitempty"` // Defaults to now
+}
+
+// RefundPaymentRequest represents money given back from a payment
+type RefundPaymentRequest struct {
+	Amount *int64  `json:"amount,omitempty"` // Defaults to what is left of the payment
+	Method *string `json:"method,omitempty"` // Defaults to the payment's method
+	Reason *string `json:"reason,omitempty"`
+}
+
+// VoidInvoiceRequest represents why an invoice is cancelled
+type VoidInvoiceRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// CashCloseRequest represents the business day of a cash close
+type CashCloseRequest struct {
+	Date string `form:"date,omitempty"` // YYYY-MM-DD in the clinic's timezone, defaults to today
+}
+
+// InvoiceLineResponse represents a billed item
+type InvoiceLineResponse struct {
+	ID                  uuid.UUID  `json:"id"`
+	Position            int        `json:"position"`
+	ServiceID           *string    `json:"service_id,omitempty"`
+	TreatmentPlanItemID *uuid.UUID `json:"treatment_plan_item_id,omitempty"`
+	Description         string     `json:"description"`
+	Quantity            int        `json:"quantity"`
+	UnitPrice           int64      `json:"unit_price"`
+	Discount            int64      `json:"discount"`
+	TaxRate             int        `json:"tax_rate"`
+	Tax                 int64      `json:"tax"`
+	Total               int64      `json:"total"`
+}
+
+// PaymentResponse represents an entry of the payments ledger
+type PaymentResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	InvoiceID  uuid.UUID  `json:"invoice_id"`
+	Kind       string     `json:"kind"`
+	Method     string     `json:"method"`
+	Amount     int64      `json:"amount"`
+	Currency   string     `json:"currency"`
+	RefundOfID *uuid.UUID `json:"refund_of_id,omitempty"`
+	Reference  *string    `json:"reference,omitempty"`
+	Reason     *string    `json:"reason,omitempty"`
+	ReceivedBy *uuid.UUID `json:"received_by,omitempty"`
+	ReceivedAt time.Time  `json:"received_at"`
+}
+
+// InvoiceResponse represents an invoice; amounts are in minor units of currency
+type InvoiceResponse struct {
+	ID             uuid.UUID              `json:"id"`
+	Number         string                 `json:"number"`
+	ClinicID       uuid.UUID              `json:"clinic_id"`
+	PatientID      uuid.UUID              `json:"patient_id"`
+	AppointmentID  *uuid.UUID             `json:"appointment_id,omitempty"`
+	Currency       string                 `json:"currency"`
+	Status         string                 `json:"status"`
+	Subtotal       int64                  `json:"subtotal"`
+	DiscountTotal  int64                  `json:"discount_total"`
+	TaxTotal       int64                  `json:"tax_total"`
+	Total          int64                  `json:"total"`
+	AmountPaid     int64                  `json:"amount_paid"`
+	AmountRefunded int64                  `json:"amount_refunded"`
+	BalanceDue     int64                  `json:"balance_due"`
+	Notes          *string                `json:"notes,omitempty"`
+	IssuedAt       time.Time              `json:"issued_at"`
+	VoidedAt       *time.Time             `json:"voided_at,omitempty"`
+	VoidReason     *string                `json:"void_reason,omitempty"`
+	CreatedBy      *uuid.UUID             `json:"created_by,omitempty"`
+	Lines          []*InvoiceLineResponse `json:"lines"`
+	Payments       []*PaymentResponse     `json:"payments,omitempty"` // Only on single-invoice responses
+}
+
+// InvoiceListResponse represents a page of invoices, most recently issued first
+type InvoiceListResponse struct {
+	Invoices   []*InvoiceResponse `json:"invoices"`
+	Pagination PaginationInfo     `json:"pagination"`
+}
+
+// PatientBalanceResponse represents what a patient was billed and still owes
+type PatientBalanceResponse struct {
+	PatientID    uuid.UUID          `json:"patient_id"`
+	Currency     string             `json:"currency"`
+	Invoiced     int64              `json:"invoiced"`
+	Paid         int64              `json:"paid"`
+	Refunded     int64              `json:"refunded"`
+	BalanceDue   int64              `json:"balance_due"`
+	OpenInvoices []*InvoiceResponse `json:"open_invoices"` // Oldest first
+}
+
+// CashCloseMethodResponse represents the money movements of one payment method
+type CashCloseMethodResponse struct {
+	Method   string `json:"method"`
+	Payments int64  `json:"payments"`
+	Refunds  int64  `json:"refunds"`
+	Net      int64  `json:"net"`
+	Count    int    `json:"count"`
+}
+
+// CashCloseResponse represents a clinic's payments ledger over a business day
+type CashCloseResponse struct {
+	ClinicID uuid.UUID                  `json:"clinic_id"`
+	Date     string                     `json:"date"`
+	Timezone string                     `json:"timezone"`
+	From     time.Time                  `json:"from"`
+	To       time.Time                  `json:"to"`
+	Currency string                     `json:"currency"`
+	Methods  []*CashCloseMethodResponse `json:"methods"`
+	Payments int64                      `json:"payments"`
+	Refunds  int64                      `json:"refunds"`
+	Net      int64                      `json:"net"`
+	Count    int                        `json:"count"`
+	Entries  []*PaymentResponse         `json:"entries"`
+}
+
+// ToInvoiceResponse converts an invoice and, when given, its ledger to its response
+func ToInvoiceResponse(invoice *entities.Invoice, payments []*entities.Payment) *InvoiceResponse {
+	response := &InvoiceResponse{
+		ID:             invoice.ID,
+		Number:         invoice.Number,
+		ClinicID:       invoice.ClinicID,
+		PatientID:      invoice.PatientID,
+		AppointmentID:  invoice.AppointmentID,
+		Currency:       invoice.Currency,
+		Status:         string(invoice.Status),
+		Subtotal:       invoice.Subtotal,
+		DiscountTotal:  invoice.DiscountTotal,
+		TaxTotal:       invoice.TaxTotal,
+		Total:          invoice.Total,
+		AmountPaid:     invoice.AmountPaid,
+		AmountRefunded: invoice.AmountRefunded,
+		BalanceDue:     invoice.BalanceDue(),
+		Notes:          invoice.Notes,
+		IssuedAt:       invoice.IssuedAt,
+		VoidedAt:       invoice.VoidedAt,
+		VoidReason:     invoice.VoidReason,
+		CreatedBy:      invoice.CreatedBy,
+		Lines:          make([]*InvoiceLineResponse, len(invoice.Lines)),
+	}
+
+	for i, line := range invoice.Lines {
+		response.Lines[i] = &InvoiceLineResponse{
+			ID:                  line.ID,
+			Position:            line.Position,
+			ServiceID:           line.ServiceID,
+			TreatmentPlanItemID: line.TreatmentPlanItemID,
+			Description:         line.Description,
+			Quantity:            line.Quantity,
+			UnitPrice:           line.UnitPrice,
+			Discount:            line.Discount,
+			TaxRate:             line.TaxRate,
+			Tax:                 line.Tax,
+			Total:               line.Total,
+		}
+	}
+	if payments != nil {
+		response.Payments = ToPaymentResponses(payments)
+	}
+
+	return response
+}
+
+// ToPaymentResponses converts ledger entries to their responses
+func ToPaymentResponses(payments []*entities.Payment) []*PaymentResponse {
+	responses := make([]*PaymentResponse, len(payments))
+	for i, payment := range payments {
+		responses[i] = &PaymentResponse{
+			ID:         payment.ID,
+			InvoiceID:  payment.InvoiceID,
+			Kind:       string(payment.Kind),
+			Method:     string(payment.Method),
+			Amount:     payment.Amount,
+			Currency:   payment.Currency,
+			RefundOfID: payment.RefundOfID,
+			Reference:  payment.Reference,
+			Reason:     payment.Reason,
+			ReceivedBy: payment.ReceivedBy,
+			ReceivedAt: payment.ReceivedAt,
+		}
+	}
+	return responses
+}
+
+// ToPatientBalanceResponse converts a patient balance to its response
+func ToPatientBalanceResponse(patientID uuid.UUID, currency string, balance services.PatientBalance) *PatientBalanceResponse {
+	response := &PatientBalanceResponse{
+		PatientID:    patientID,
+		Currency:     currency,
+		Invoiced:     balance.Invoiced,
+		Paid:         balance.Paid,
+		Refunded:     balance.Refunded,
+		BalanceDue:   balance.BalanceDue,
+		OpenInvoices: make([]*InvoiceResponse, len(balance.OpenInvoices)),
+	}
+	for i, invoice := range balance.OpenInvoices {
+		response.OpenInvoices[i] = ToInvoiceResponse(invoice, nil)
+	}
+	return response
+}
+
+// ToCashCloseResponse converts a cash close summary and its ledger entries to its response
+func ToCashCloseResponse(clinicID uuid.UUID, date, timezone string, from, to time.Time, currency string, summary services.CashCloseSummary, entries []*entities.Payment) *CashCloseResponse {
+	response := &CashCloseResponse{
+		ClinicID: clinicID,
+		Date:     date,
+		Timezone: timezone,
+		From:     from,
+		To:       to,
+		Currency: currency,
+		Methods:  make([]*CashCloseMethodResponse, len(summary.Methods)),
+		Payments: summary.Payments,
+		Refunds:  summary.Refunds,
+		Net:      summary.Net,
+		Count:    summary.Count,
+		Entries:  ToPaymentResponses(entries),
+	}
+	for i, totals := range summary.Methods {
+		response.Methods[i] = &CashCloseMethodResponse{
+			Method:   string(totals.Method),
+			Payments: totals.Payments,
+			Refunds:  totals.Refunds,
+			Net:      totals.Net,
+			Count:    totals.Count,
+		}
+	}
+	return response
+}
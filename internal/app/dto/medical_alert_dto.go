@@ -0,0 +1,188 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// medicalAlertDateLayout is the format of review dates in requests and responses
+const medicalAlertDateLayout = "2006-01-02"
+
+// CreateMedicalAlertRequest represents a new medical alert of a patient
+type CreateMedicalAlertRequest struct {
+	Category   string  `json:"category" binding:"required"` // allergy, medication, condition, pregnancy or other
+	Title      string  `json:"title" binding:"required"`    // e.g. "Penicillin", "Warfarin"
+	Severity   string  `json:"severity" binding:"required"` // low, moderate or high
+	Notes      *string `json:"notes,omitempty"`
+	ReviewDate *string `json:"review_date,omitempty"` // YYYY-MM-DD
+}
+
+// UpdateMedicalAlertRequest represents changes to a medical alert; omitted fields are kept
+type UpdateMedicalAlertRequest struct {
+	Category   *string `json:"category,omitempty"`
+	Title      *string `json:"title,omitempty"`
+	Severity   *string `json:"severity,omitempty"`
+	Notes      *string `json:"notes,omitempty"`       // Empty string removes the notes
+	ReviewDate *string `json:"review_date,omitempty"` // YYYY-MM-DD; empty string removes the review date
+	Reason     *string `json:"reason,omitempty"`      // Kept in the audit trail
+}
+
+// ReviewMedicalAlertRequest represents the confirmation of an alert with the patient
+type ReviewMedicalAlertRequest struct {
+	NextReviewDate *string `json:"next_review_date,omitempty"` // YYYY-MM-DD; omit for no further review
+	Reason         *string `json:"reason,omitempty"`
+}
+
+// MedicalAlertStatusRequest represents the resolution or reactivation of an alert
+type MedicalAlertStatusRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// MedicalAlertListRequest represents the filters of a patient's alert listing
+type MedicalAlertListRequest struct {
+	IncludeResolved bool `form:"include_resolved,omitempty"`
+}
+
+// MedicalAlertResponse represents a medical alert
+type MedicalAlertResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	PatientID      uuid.UUID  `json:"patient_id"`
+	Category       string     `json:"category"`
+	Title          string     `json:"title"`
+	Severity       string     `json:"severity"`
+	Notes          *string    `json:"notes,omitempty"`
+	Active         bool       `json:"active"`
+	ReviewDate     *string    `json:"review_date,omitempty"` // YYYY-MM-DD
+	NeedsReview    bool       `json:"needs_review"`          // Active and the review date has been reached
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+	LastReviewedBy *uuid.UUID `json:"last_reviewed_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy     *uuid.UUID `json:"resolved_by,omitempty"`
+	CreatedBy      *uuid.UUID `json:"created_by,omitempty"`
+	UpdatedBy      *uuid.UUID `json:"updated_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// MedicalAlertsResponse represents the medical alerts of a patient, most severe first
+type MedicalAlertsResponse struct {
+	PatientID       uuid.UUID               `json:"patient_id"`
+	HighestSeverity *string                 `json:"highest_severity,omitempty"` // Of the active alerts
+	Alerts          []*MedicalAlertResponse `json:"alerts"`
+}
+
+// MedicalAlertChangeResponse represents an entry of a medical alert's audit trail
+type MedicalAlertChangeResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Action    string          `json:"action"`
+	Previous  json.RawMessage `json:"previous,omitempty"` // Alert before the change
+	Current   json.RawMessage `json:"current"`            // Alert after the change
+	Reason    *string         `json:"reason,omitempty"`
+	ChangedBy *uuid.UUID      `json:"changed_by,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// MedicalAlertHistoryResponse represents the audit trail of a medical alert, oldest first
+type MedicalAlertHistoryResponse struct {
+	AlertID uuid.UUID                     `json:"alert_id"`
+	Changes []*MedicalAlertChangeResponse `json:"changes"`
+}
+
+// MedicalAlertFlagDTO represents an active alert flagged on calendar appointments and queue items
+type MedicalAlertFlagDTO struct {
+	ID          uuid.UUID `json:"id"`
+	Category    string    `json:"category"`
+	Title       string    `json:"title"`
+	Severity    string    `json:"severity"`
+	NeedsReview bool      `json:"needs_review"`
+}
+
+// ToMedicalAlertResponse converts a medical alert to its response
+func ToMedicalAlertResponse(alert *entities.MedicalAlert) *MedicalAlertResponse {
+	response := &MedicalAlertResponse{
+		ID:             alert.ID,
+		PatientID:      alert.PatientID,
+		Category:       string(alert.Category),
+		Title:          alert.Title,
+		Severity:       string(alert.Severity),
+		Notes:          alert.Notes,
+		Active:         alert.IsActive(),
+		NeedsReview:    alert.NeedsReview(time.Now()),
+		LastReviewedAt: alert.LastReviewedAt,
+		LastReviewedBy: alert.LastReviewedBy,
+		ResolvedAt:     alert.ResolvedAt,
+		ResolvedBy:     alert.ResolvedBy,
+		CreatedBy:      alert.CreatedBy,
+		UpdatedBy:      alert.UpdatedBy,
+		CreatedAt:      alert.CreatedAt,
+		UpdatedAt:      alert.UpdatedAt,
+	}
+	if alert.ReviewDate != nil {
+		reviewDate := alert.ReviewDate.Format(medicalAlertDateLayout)
+		response.ReviewDate = &reviewDate
+	}
+	return response
+}
+
+// ToMedicalAlertsResponse converts a patient's alerts to the listing response
+func ToMedicalAlertsResponse(patientID uuid.UUID, alerts []*entities.MedicalAlert) *MedicalAlertsResponse {
+	response := &MedicalAlertsResponse{
+		PatientID: patientID,
+		Alerts:    make([]*MedicalAlertResponse, len(alerts)),
+	}
+	var highest entities.MedicalAlertSeverity
+	for i, alert := range alerts {
+		response.Alerts[i] = ToMedicalAlertResponse(alert)
+		if alert.IsActive() && alert.Severity.Rank() > highest.Rank() {
+			highest = alert.Severity
+		}
+	}
+	if highest != "" {
+		severity := string(highest)
+		response.HighestSeverity = &severity
+	}
+	return response
+}
+
+// ToMedicalAlertHistoryResponse converts the audit trail of an alert to its response
+func ToMedicalAlertHistoryResponse(alertID uuid.UUID, changes []*entities.MedicalAlertChange) *MedicalAlertHistoryResponse {
+	response := &MedicalAlertHistoryResponse{
+		AlertID: alertID,
+		Changes: make([]*MedicalAlertChangeResponse, len(changes)),
+	}
+	for i, change := range changes {
+		response.Changes[i] = &MedicalAlertChangeResponse{
+			ID:        change.ID,
+			Action:    string(change.Action),
+			Previous:  change.Previous,
+			Current:   change.Current,
+			Reason:    change.Reason,
+			ChangedBy: change.ChangedBy,
+			CreatedAt: change.CreatedAt,
+		}
+	}
+	return response
+}
+
+// ToMedicalAlertFlags converts a patient's active alerts to calendar flags; nil when there are none
+func ToMedicalAlertFlags(alerts []*entities.MedicalAlert) []*MedicalAlertFlagDTO {
+	if len(alerts) == 0 {
+		return nil
+	}
+	now := time.Now()
+	flags := make([]*MedicalAlertFlagDTO, len(alerts))
+	for i, alert := range alerts {
+		flags[i] = &MedicalAlertFlagDTO{
+			ID:          alert.ID,
+			Category:    string(alert.Category),
+			Title:       alert.Title,
+			Severity:    string(alert.Severity),
+			NeedsReview: alert.NeedsReview(now),
+		}
+	}
+	return flags
+}
@@ -102,13 +102,24 @@ type AppointmentCalendarDataDTO struct {
 	ServiceName  *string                 `json:"service_name,omitempty"`
 	Notes        *string                 `json:"notes,omitempty"`
 	IsFirstVisit bool                    `json:"is_first_visit"`
+
+	MedicalAlerts []*MedicalAlertFlagDTO `json:"medical_alerts,omitempty"` // Active alerts of the patient, most severe first
 }
 
 // ServiceDTO represents service data in API responses
 type ServiceDTO struct {
-	ID        string   `json:"id"`
-	Name      string   `json:"name"`
-	BasePrice *float64 `json:"base_price,omitempty"`
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	BasePrice *float64           `json:"base_price,omitempty"`
+	Category  string             `json:"category"`          // Insurance category
+	Deposit   *ServiceDepositDTO `json:"deposit,omitempty"` // Deposit required to book the service
+}
+
+// ServiceDepositDTO represents the deposit required to book a service
+type ServiceDepositDTO struct {
+	Type    string `json:"type"`
+	Amount  int64  `json:"amount,omitempty"`  // Minor units, fixed deposits
+	Percent int    `json:"percent,omitempty"` // Of the base price, percentage deposits
 }
 
 // ToOrganizationDTO converts an Organization entity to DTO
@@ -269,6 +280,8 @@ func ToAppointmentCalendarDataDTO(appt *repositories.AppointmentCalendarData) *A
 		ServiceName:  appt.ServiceName,
 		Notes:        appt.Notes,
 		IsFirstVisit: appt.IsFirstVisit,
+
+		MedicalAlerts: ToMedicalAlertFlags(appt.MedicalAlerts),
 	}
 }
 
@@ -289,11 +302,20 @@ func ToServiceDTO(service *entities.Service) *ServiceDTO {
 	if service == nil {
 		return nil
 	}
-	return &ServiceDTO{
+	response := &ServiceDTO{
 		ID:        service.ID,
 		Name:      service.Name,
 		BasePrice: service.BasePrice,
+		Category:  string(service.Category),
+	}
+	if service.DepositType != nil {
+		response.Deposit = &ServiceDepositDTO{
+			Type:    string(*service.DepositType),
+			Amount:  service.DepositAmount,
+			Percent: service.DepositPercent,
+		}
 	}
+	return response
 }
 
 // ToServiceDTOs converts a slice of Service entities to DTOs
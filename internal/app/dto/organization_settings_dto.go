@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// UpdateOrganizationSettingsRequest represents the request to update organization settings
+// All fields are optional for partial updates
+type UpdateOrganizationSettingsRequest struct {
+	DefaultPhoneRegion *string `json:"default_phone_region,omitempty" binding:"omitempty,len=2"` // ISO 3166-1 alpha-2, e.g. "MX"
+	Currency           *string `json:"currency,omitempty" binding:"omitempty,len=3"`             // ISO 4217, e.g. "MXN"
+}
+
+// OrganizationSettingsResponse represents the settings of an organization
+type OrganizationSettingsResponse struct {
+	DefaultPhoneRegion string `json:"default_phone_region"`
+	Currency           string `json:"currency"`
+}
+
+// ToOrganizationSettingsResponse converts entities.Organization to OrganizationSettingsResponse
+func ToOrganizationSettingsResponse(o *entities.Organization) *OrganizationSettingsResponse {
+	return &OrganizationSettingsResponse{
+		DefaultPhoneRegion: o.DefaultPhoneRegion,
+		Currency:           o.Currency,
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
 
 	"github.com/google/uuid"
 )
@@ -47,6 +49,7 @@ type UpdatePatientRequest struct {
 	Phone          *string    `json:"phone,omitempty"`
 	DateOfBirth    *time.Time `json:"date_of_birth,omitempty"`
 	MedicalHistory *string    `json:"medical_history,omitempty"`
+	Version        *int       `json:"version,omitempty"` // Expected version when no If-Match header is sent
 }
 
 // PatientResponse represents the response for a patient
@@ -56,8 +59,10 @@ type PatientResponse struct {
 	LastName       *string    `json:"last_name,omitempty"`
 	Email          *string    `json:"email,omitempty"`
 	Phone          *string    `json:"phone,omitempty"`
+	PhoneE164      *string    `json:"phone_e164,omitempty"`
 	DateOfBirth    *time.Time `json:"date_of_birth,omitempty"`
 	MedicalHistory *string    `json:"medical_history,omitempty"`
+	Version        int        `json:"version"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 }
@@ -85,8 +90,10 @@ func ToPatientResponse(p *entities.Patient) *PatientResponse {
 		LastName:       p.LastName,
 		Email:          p.Email,
 		Phone:          p.Phone,
+		PhoneE164:      p.PhoneE164,
 		DateOfBirth:    p.DateOfBirth,
 		MedicalHistory: p.MedicalHistory,
+		Version:        p.Version,
 		CreatedAt:      p.CreatedAt,
 		UpdatedAt:      p.UpdatedAt,
 	}
@@ -153,32 +160,163 @@ func (req *UpdatePatientRequest) ToEntityUpdate(existing *entities.Patient) *ent
 
 // PatientSearchRequest represents the request to search patients
 type PatientSearchRequest struct {
-	Query string `form:"q,omitempty"`     // Search by name, phone, or email
-	Limit int    `form:"limit,omitempty"` // Max results (default: 50, max: 100)
+	Query  string `form:"q,omitempty"`      // Search by name, phone, or email; every word has to match
+	Limit  int    `form:"limit,omitempty"`  // Max results (default: 50, max: 100)
+	Cursor string `form:"cursor,omitempty"` // next_cursor of the previous page
 }
 
 // PatientSearchResponse represents minimal patient data for autocomplete
 type PatientSearchResponse struct {
-	ID        string  `json:"id"`
-	FirstName string  `json:"first_name"`
-	LastName  *string `json:"last_name,omitempty"`
-	Phone     *string `json:"phone,omitempty"`
-	Email     *string `json:"email,omitempty"`
+	ID                string            `json:"id"`
+	FirstName         string            `json:"first_name"`
+	LastName          *string           `json:"last_name,omitempty"`
+	Phone             *string           `json:"phone,omitempty"`
+	Email             *string           `json:"email,omitempty"`
+	LastAppointmentAt *time.Time        `json:"last_appointment_at,omitempty"`
+	NextAppointmentAt *time.Time        `json:"next_appointment_at,omitempty"`
+	Relevance         float64           `json:"relevance"`
+	Household         *HouseholdSummary `json:"household,omitempty"` // Absent when the patient has no relatives
 }
 
 // PatientSearchResult represents the wrapper for search results
 type PatientSearchResult struct {
-	Patients []PatientSearchResponse `json:"patients"`
-	Total    int                     `json:"total"`
+	Patients   []PatientSearchResponse `json:"patients"`
+	Total      int                     `json:"total"`                 // Results in this page
+	NextCursor *string                 `json:"next_cursor,omitempty"` // Absent on the last page
 }
 
-// ToPatientSearchResponse converts entities.Patient to PatientSearchResponse
-func ToPatientSearchResponse(p *entities.Patient) PatientSearchResponse {
+// ToPatientSearchResponse converts a search hit to PatientSearchResponse
+func ToPatientSearchResponse(r *repositories.PatientSearchResult) PatientSearchResponse {
 	return PatientSearchResponse{
-		ID:        p.ID.String(),
-		FirstName: p.FirstName,
-		LastName:  p.LastName,
-		Phone:     p.Phone,
-		Email:     p.Email,
+		ID:                r.Patient.ID.String(),
+		FirstName:         r.Patient.FirstName,
+		LastName:          r.Patient.LastName,
+		Phone:             r.Patient.Phone,
+		Email:             r.Patient.Email,
+		LastAppointmentAt: r.LastAppointmentAt,
+		NextAppointmentAt: r.NextAppointmentAt,
+		Relevance:         r.Rank,
 	}
 }
+
+// PatientListRequest represents the filters, sorting and pagination of a patient listing
+type PatientListRequest struct {
+	Status                 string `form:"status,omitempty"`                   // active (default) or archived
+	CreatedFrom            string `form:"created_from,omitempty"`             // YYYY-MM-DD, inclusive
+	CreatedTo              string `form:"created_to,omitempty"`               // YYYY-MM-DD, inclusive
+	HasUpcomingAppointment *bool  `form:"has_upcoming_appointment,omitempty"` // Has (true) or lacks (false) a future appointment
+	LastVisitBefore        string `form:"last_visit_before,omitempty"`        // YYYY-MM-DD, patients last seen before this day
+	Sort                   string `form:"sort,omitempty"`                     // name (default), created_at, last_visit or next_appointment
+	Order                  string `form:"order,omitempty"`                    // asc (default) or desc
+	Page                   int    `form:"page,omitempty"`
+	Limit                  int    `form:"limit,omitempty"`
+}
+
+// PatientListItemResponse represents a patient in a listing with its appointment dates
+type PatientListItemResponse struct {
+	PatientResponse
+	ArchivedAt        *time.Time `json:"archived_at,omitempty"`
+	LastVisitAt       *time.Time `json:"last_visit_at,omitempty"`
+	NextAppointmentAt *time.Time `json:"next_appointment_at,omitempty"`
+}
+
+// PatientListResponse represents a page of patients
+type PatientListResponse struct {
+	Patients   []*PatientListItemResponse `json:"patients"`
+	Pagination PaginationInfo             `json:"pagination"`
+}
+
+// PatientStatsResponse summarizes a patient's appointments in the organization
+type PatientStatsResponse struct {
+	TotalAppointments int        `json:"total_appointments"`
+	Completed         int        `json:"completed"`
+	Cancelled         int        `json:"cancelled"`
+	NoShows           int        `json:"no_shows"`
+	Upcoming          int        `json:"upcoming"`
+	FirstVisitAt      *time.Time `json:"first_visit_at,omitempty"`
+	LastVisitAt       *time.Time `json:"last_visit_at,omitempty"`
+	NextAppointmentAt *time.Time `json:"next_appointment_at,omitempty"`
+}
+
+// PatientAppointmentResponse represents an appointment in a patient's history
+type PatientAppointmentResponse struct {
+	ID          uuid.UUID                  `json:"id"`
+	Status      entities.AppointmentStatus `json:"status"`
+	StartTime   time.Time                  `json:"start_time"`
+	EndTime     time.Time                  `json:"end_time"`
+	ServiceID   *string                    `json:"service_id,omitempty"`
+	ServiceName *string                    `json:"service_name,omitempty"`
+	DoctorID    *uuid.UUID                 `json:"doctor_id,omitempty"`
+	DoctorName  *string                    `json:"doctor_name,omitempty"`
+	ClinicID    *uuid.UUID                 `json:"clinic_id,omitempty"`
+	ClinicName  *string                    `json:"clinic_name,omitempty"`
+	UnitID      *uuid.UUID                 `json:"unit_id,omitempty"`
+	UnitName    *string                    `json:"unit_name,omitempty"`
+	Notes       *string                    `json:"notes,omitempty"`
+}
+
+// PatientDetailResponse represents a patient with its appointment history and stats
+type PatientDetailResponse struct {
+	Patient      *PatientResponse              `json:"patient"`
+	ArchivedAt   *time.Time                    `json:"archived_at,omitempty"`
+	Stats        PatientStatsResponse          `json:"stats"`
+	Appointments []*PatientAppointmentResponse `json:"appointments"` // Newest first
+}
+
+// PatientDeletionResponse reports how a patient was removed from the organization
+type PatientDeletionResponse struct {
+	PatientID  uuid.UUID  `json:"patient_id"`
+	Archived   bool       `json:"archived"` // false when the patient had no appointments and was deleted
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+// ToPatientListItemResponse converts a patient listing item to PatientListItemResponse
+func ToPatientListItemResponse(item *repositories.PatientListItem) *PatientListItemResponse {
+	return &PatientListItemResponse{
+		PatientResponse:   *ToPatientResponse(item.Patient),
+		ArchivedAt:        item.ArchivedAt,
+		LastVisitAt:       item.LastVisitAt,
+		NextAppointmentAt: item.NextAppointmentAt,
+	}
+}
+
+// ToPatientStatsResponse converts appointment stats to PatientStatsResponse
+func ToPatientStatsResponse(stats services.PatientAppointmentStats) PatientStatsResponse {
+	return PatientStatsResponse{
+		TotalAppointments: stats.Total,
+		Completed:         stats.Completed,
+		Cancelled:         stats.Cancelled,
+		NoShows:           stats.NoShows,
+		Upcoming:          stats.Upcoming,
+		FirstVisitAt:      stats.FirstVisitAt,
+		LastVisitAt:       stats.LastVisitAt,
+		NextAppointmentAt: stats.NextAppointmentAt,
+	}
+}
+
+// ToPatientAppointmentResponse converts a history entry to PatientAppointmentResponse
+func ToPatientAppointmentResponse(d *repositories.AppointmentWithDetails) *PatientAppointmentResponse {
+	a := d.Appointment
+	response := &PatientAppointmentResponse{
+		ID:          a.ID,
+		Status:      a.Status,
+		StartTime:   a.StartTime,
+		EndTime:     a.EndTime,
+		ServiceID:   a.ServiceID,
+		ServiceName: d.ServiceName,
+		DoctorID:    a.DoctorID,
+		UnitID:      a.UnitID,
+		Notes:       a.Notes,
+	}
+	if d.Doctor != nil {
+		response.DoctorName = &d.Doctor.Name
+	}
+	if d.Unit != nil {
+		response.UnitName = &d.Unit.Name
+	}
+	if d.Clinic != nil {
+		response.ClinicID = &d.Clinic.ID
+		response.ClinicName = &d.Clinic.Name
+	}
+	return response
+}
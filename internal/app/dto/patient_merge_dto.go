@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateCandidatesRequest represents the query parameters for listing duplicate candidates
+type DuplicateCandidatesRequest struct {
+	MinScore int `form:"min_score" binding:"omitempty,min=1,max=100"` // Default: 50
+	Limit    int `form:"limit" binding:"omitempty,min=1,max=200"`     // Default: 50
+}
+
+// DuplicateCandidateResponse represents a pair of patients that may be the same person
+type DuplicateCandidateResponse struct {
+	Patient *PatientResponse `json:"patient"`
+	Match   *PatientResponse `json:"match"`
+	Score   int              `json:"score"`   // 0-100
+	Reasons []string         `json:"reasons"` // Signals that matched: email, phone, name, date_of_birth
+}
+
+// DuplicateCandidatesResponse represents a scored list of duplicate candidates, best first
+type DuplicateCandidatesResponse struct {
+	Candidates []*DuplicateCandidateResponse `json:"candidates"`
+	Total      int                           `json:"total"` // Candidates above the threshold before the limit was applied
+}
+
+// MergePatientsRequest represents the request to merge a duplicate into the patient in the path
+type MergePatientsRequest struct {
+	MergedPatientID uuid.UUID `json:"merged_patient_id" binding:"required"`
+	Reason          *string   `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// PatientMergeListRequest represents the query parameters for listing merge records
+type PatientMergeListRequest struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// PatientMergeResponse represents a merge audit record
+type PatientMergeResponse struct {
+	ID                    uuid.UUID        `json:"id"`
+	SurvivorPatientID     uuid.UUID        `json:"survivor_patient_id"`
+	MergedPatientID       uuid.UUID        `json:"merged_patient_id"`
+	MergedPatientSnapshot json.RawMessage  `json:"merged_patient_snapshot"`
+	AppointmentsMoved     int              `json:"appointments_moved"`
+	Score                 int              `json:"score"`
+	Reason                *string          `json:"reason,omitempty"`
+	MergedBy              *uuid.UUID       `json:"merged_by,omitempty"`
+	CreatedAt             time.Time        `json:"created_at"`
+	Survivor              *PatientResponse `json:"survivor,omitempty"` // Only set in the merge response
+}
+
+// PatientMergeListResponse represents a paginated list of merge records
+type PatientMergeListResponse struct {
+	Merges     []*PatientMergeResponse `json:"merges"`
+	Pagination PaginationInfo          `json:"pagination"`
+}
+
+// ToDuplicateCandidateResponse converts a scored candidate to its response
+func ToDuplicateCandidateResponse(candidate services.DuplicateCandidate) *DuplicateCandidateResponse {
+	reasons := make([]string, len(candidate.Reasons))
+	for i, reason := range candidate.Reasons {
+		reasons[i] = string(reason)
+	}
+
+	return &DuplicateCandidateResponse{
+		Patient: ToPatientResponse(candidate.Patient),
+		Match:   ToPatientResponse(candidate.Match),
+		Score:   candidate.Score,
+		Reasons: reasons,
+	}
+}
+
+// ToPatientMergeResponse converts entities.PatientMerge to PatientMergeResponse
+func ToPatientMergeResponse(m *entities.PatientMerge) *PatientMergeResponse {
+	return &PatientMergeResponse{
+		ID:                    m.ID,
+		SurvivorPatientID:     m.SurvivorPatientID,
+		MergedPatientID:       m.MergedPatientID,
+		MergedPatientSnapshot: m.MergedPatientSnapshot,
+		AppointmentsMoved:     m.AppointmentsMoved,
+		Score:                 m.Score,
+		Reason:                m.Reason,
+		MergedBy:              m.MergedBy,
+		CreatedAt:             m.CreatedAt,
+	}
+}
@@ -0,0 +1,136 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// CreatePatientRelationshipRequest represents a new relationship of a patient with another patient
+type CreatePatientRelationshipRequest struct {
+	RelatedPatientID uuid.UUID `json:"related_patient_id" binding:"required"`
+	Type             string    `json:"type" binding:"required"` // What the related patient is to the patient: guardian, dependent or spouse
+	IsContact        bool      `json:"is_contact,omitempty"`    // Make the guardian the contact person; the first guardian becomes it anyway
+}
+
+// SetContactPersonRequest represents the choice of the guardian through whom a minor is notified
+type SetContactPersonRequest struct {
+	GuardianID uuid.UUID `json:"guardian_id" binding:"required"`
+}
+
+// PatientContactResponse represents who is notified about a patient's appointments
+type PatientContactResponse struct {
+	PatientID uuid.UUID `json:"patient_id"` // The patient, or the guardian of a minor
+	Role      string    `json:"role"`       // self or guardian
+	Name      string    `json:"name"`
+	Email     *string   `json:"email,omitempty"`
+	Phone     *string   `json:"phone,omitempty"`
+}
+
+// PatientRelativeResponse represents a relative of a patient
+type PatientRelativeResponse struct {
+	PatientID    uuid.UUID  `json:"patient_id"`
+	FirstName    string     `json:"first_name"`
+	LastName     *string    `json:"last_name,omitempty"`
+	Email        *string    `json:"email,omitempty"`
+	Phone        *string    `json:"phone,omitempty"`
+	DateOfBirth  *time.Time `json:"date_of_birth,omitempty"`
+	IsMinor      bool       `json:"is_minor"`
+	Relationship string     `json:"relationship"` // What the relative is to the patient
+	IsContact    bool       `json:"is_contact"`   // The guardian through whom the patient is notified
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// PatientRelationshipsResponse represents the relatives of a patient and its resolved contact
+type PatientRelationshipsResponse struct {
+	PatientID uuid.UUID                  `json:"patient_id"`
+	IsMinor   bool                       `json:"is_minor"`
+	Contact   *PatientContactResponse    `json:"contact"`
+	Relatives []*PatientRelativeResponse `json:"relatives"`
+}
+
+// FamilyMemberResponse represents a household member with its upcoming appointments
+type FamilyMemberResponse struct {
+	PatientID            uuid.UUID                     `json:"patient_id"`
+	FirstName            string                        `json:"first_name"`
+	LastName             *string                       `json:"last_name,omitempty"`
+	IsMinor              bool                          `json:"is_minor"`
+	Relationship         *string                       `json:"relationship,omitempty"` // To the requested patient, when directly related
+	Contact              *PatientContactResponse       `json:"contact"`
+	UpcomingAppointments []*PatientAppointmentResponse `json:"upcoming_appointments"` // Soonest first
+}
+
+// FamilyResponse represents the household of a patient
+type FamilyResponse struct {
+	PatientID   uuid.UUID               `json:"patient_id"`
+	HouseholdID *uuid.UUID              `json:"household_id,omitempty"` // Absent when the patient has no relatives
+	Members     []*FamilyMemberResponse `json:"members"`                // Including the requested patient
+}
+
+// HouseholdMemberSummary represents a household member in patient search results
+type HouseholdMemberSummary struct {
+	ID        string  `json:"id"`
+	FirstName string  `json:"first_name"`
+	LastName  *string `json:"last_name,omitempty"`
+	IsMinor   bool    `json:"is_minor"`
+}
+
+// HouseholdSummary represents the household of a patient search hit. Hits sharing an ID
+// belong to the same family, which helps tell apart patients sharing a phone number.
+type HouseholdSummary struct {
+	ID      string                   `json:"id"`
+	Members []HouseholdMemberSummary `json:"members"`
+}
+
+// ToPatientContactResponse converts a resolved contact to its response
+func ToPatientContactResponse(contact *entities.PatientContact) *PatientContactResponse {
+	if contact == nil {
+		return nil
+	}
+	return &PatientContactResponse{
+		PatientID: contact.ContactPatientID,
+		Role:      string(contact.Role),
+		Name:      contact.Name,
+		Email:     contact.Email,
+		Phone:     contact.Phone,
+	}
+}
+
+// ToPatientRelativeResponse converts a relative of a patient to its response
+func ToPatientRelativeResponse(relative *repositories.PatientRelative, now time.Time) *PatientRelativeResponse {
+	return &PatientRelativeResponse{
+		PatientID:    relative.Patient.ID,
+		FirstName:    relative.Patient.FirstName,
+		LastName:     relative.Patient.LastName,
+		Email:        relative.Patient.Email,
+		Phone:        relative.Patient.Phone,
+		DateOfBirth:  relative.Patient.DateOfBirth,
+		IsMinor:      relative.Patient.IsMinor(now),
+		Relationship: string(relative.Relationship.Type),
+		IsContact:    relative.Relationship.IsContact,
+		CreatedAt:    relative.Relationship.CreatedAt,
+	}
+}
+
+// ToHouseholdSummary converts a household to its search summary
+func ToHouseholdSummary(household *repositories.PatientHousehold, now time.Time) *HouseholdSummary {
+	if household == nil {
+		return nil
+	}
+	summary := &HouseholdSummary{
+		ID:      household.ID.String(),
+		Members: make([]HouseholdMemberSummary, len(household.Members)),
+	}
+	for i, member := range household.Members {
+		summary.Members[i] = HouseholdMemberSummary{
+			ID:        member.ID.String(),
+			FirstName: member.FirstName,
+			LastName:  member.LastName,
+			IsMinor:   member.IsMinor(now),
+		}
+	}
+	return summary
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// PhoneBackfillReport summarizes a phone normalization run
+type PhoneBackfillReport struct {
+	DryRun      bool                   `json:"dry_run"`
+	Scanned     int                    `json:"scanned"`
+	Updated     int                    `json:"updated"`   // Rows whose E.164 form was set or changed (or would be, on a dry run)
+	Unchanged   int                    `json:"unchanged"` // Rows that were already normalized
+	Unparseable []PhoneBackfillFailure `json:"unparseable"`
+}
+
+// PhoneBackfillFailure is a stored phone that could not be parsed for its region
+type PhoneBackfillFailure struct {
+	Owner  string    `json:"owner"` // patient, doctor or clinic
+	ID     uuid.UUID `json:"id"`
+	Phone  string    `json:"phone"`
+	Region string    `json:"region"`
+	Reason string    `json:"reason"`
+}
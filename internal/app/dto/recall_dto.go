@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// RecallRuleRequest represents the creation of a recall rule for a service
+type RecallRuleRequest struct {
+	ServiceID            string   `json:"service_id" binding:"required"`
+	IntervalMonths       int      `json:"interval_months" binding:"required"`
+	QualifyingServiceIDs []string `json:"qualifying_service_ids"` // Other services whose booking closes the recall
+}
+
+// UpdateRecallRuleRequest represents the update of a recall rule; its service cannot change
+type UpdateRecallRuleRequest struct {
+	IntervalMonths       int      `json:"interval_months" binding:"required"`
+	QualifyingServiceIDs []string `json:"qualifying_service_ids"`
+	Active               *bool    `json:"active,omitempty"`
+}
+
+// RecallRuleListRequest represents the filters to list recall rules
+type RecallRuleListRequest struct {
+	IncludeInactive bool `form:"include_inactive,omitempty"`
+}
+
+// RecallListRequest represents the filters of the recall worklist
+type RecallListRequest struct {
+	ClinicIDStr    string     `form:"clinic_id,omitempty"`
+	ClinicID       *uuid.UUID `form:"-"`
+	PatientIDStr   string     `form:"patient_id,omitempty"`
+	PatientID      *uuid.UUID `form:"-"`
+	Status         string     `form:"status,omitempty"` // Defaults to open on the worklist
+	OutreachStatus string     `form:"outreach_status,omitempty"`
+	DueFrom        string     `form:"due_from,omitempty"` // YYYY-MM-DD, inclusive
+	DueTo          string     `form:"due_to,omitempty"`   // YYYY-MM-DD, inclusive
+	Page           int        `form:"page,omitempty"`
+	Limit          int        `form:"limit,omitempty"`
+}
+
+// RecallOutreachRequest represents an attempt to contact a recalled patient
+type RecallOutreachRequest struct {
+	Channel     string     `json:"channel" binding:"required"` // phone, sms, email, whatsapp or in_person
+	Outcome     string     `json:"outcome" binding:"required"` // contacted, left_message, no_answer or declined
+	Notes       *string    `json:"notes,omitempty"`
+	ContactedAt *time.Time `json:"contacted_at,omitempty"` // Defaults to now
+}
+
+// DismissRecallRequest represents staff closing a recall they will not follow up on
+type DismissRecallRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// RecallRuleResponse represents a recall rule
+type RecallRuleResponse struct {
+	ID                   uuid.UUID `json:"id"`
+	ServiceID            string    `json:"service_id"`
+	IntervalMonths       int       `json:"interval_months"`
+	QualifyingServiceIDs []string  `json:"qualifying_service_ids"`
+	Active               bool      `json:"active"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// RecallOutreachResponse represents an attempt to contact a recalled patient
+type RecallOutreachResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Channel     string     `json:"channel"`
+	Outcome     string     `json:"outcome"`
+	Notes       *string    `json:"notes,omitempty"`
+	ContactedBy *uuid.UUID `json:"contacted_by,omitempty"`
+	ContactedAt time.Time  `json:"contacted_at"`
+}
+
+// RecallResponse represents a patient due back for a service
+type RecallResponse struct {
+	ID                  uuid.UUID                 `json:"id"`
+	ClinicID            *uuid.UUID                `json:"clinic_id,omitempty"`
+	PatientID           uuid.UUID                 `json:"patient_id"`
+	PatientName         string                    `json:"patient_name,omitempty"`
+	Contact             *PatientContactResponse   `json:"contact,omitempty"` // Whom to call: the patient, or the guardian of a minor
+	RuleID              uuid.UUID                 `json:"rule_id"`
+	ServiceID           string                    `json:"service_id"`
+	SourceAppointmentID *uuid.UUID                `json:"source_appointment_id,omitempty"`
+	DueDate             string                    `json:"due_date"`
+	Status              string                    `json:"status"`
+	OutreachStatus      string                    `json:"outreach_status"`
+	OutreachAttempts    int                       `json:"outreach_attempts"`
+	LastContactedAt     *time.Time                `json:"last_contacted_at,omitempty"`
+	BookedAppointmentID *uuid.UUID                `json:"booked_appointment_id,omitempty"`
+	DismissReason       *string                   `json:"dismiss_reason,omitempty"`
+	ClosedBy            *uuid.UUID                `json:"closed_by,omitempty"`
+	ClosedAt            *time.Time                `json:"closed_at,omitempty"`
+	Outreach            []*RecallOutreachResponse `json:"outreach,omitempty"` // Contact attempts, on the detail only
+	CreatedAt           time.Time                 `json:"created_at"`
+	UpdatedAt           time.Time                 `json:"updated_at"`
+}
+
+// RecallListResponse represents a page of recalls
+type RecallListResponse struct {
+	Recalls    []*RecallResponse `json:"recalls"`
+	Pagination PaginationInfo    `json:"pagination"`
+}
+
+// ToRecallRuleResponse converts a recall rule to its response
+func ToRecallRuleResponse(rule *entities.RecallRule) *RecallRuleResponse {
+	return &RecallRuleResponse{
+		ID:                   rule.ID,
+		ServiceID:            rule.ServiceID,
+		IntervalMonths:       rule.IntervalMonths,
+		QualifyingServiceIDs: rule.QualifyingServiceIDs,
+		Active:               rule.Active,
+		CreatedAt:            rule.CreatedAt,
+		UpdatedAt:            rule.UpdatedAt,
+	}
+}
+
+// ToRecallOutreachResponse converts a contact attempt to its response
+func ToRecallOutreachResponse(outreach *entities.RecallOutreach) *RecallOutreachResponse {
+	return &RecallOutreachResponse{
+		ID:          outreach.ID,
+		Channel:     string(outreach.Channel),
+		Outcome:     string(outreach.Outcome),
+		Notes:       outreach.Notes,
+		ContactedBy: outreach.ContactedBy,
+		ContactedAt: outreach.ContactedAt,
+	}
+}
+
+// ToRecallResponse converts a recall to its response
+func ToRecallResponse(recall *entities.Recall) *RecallResponse {
+	return &RecallResponse{
+		ID:                  recall.ID,
+		ClinicID:            recall.ClinicID,
+		PatientID:           recall.PatientID,
+		RuleID:              recall.RuleID,
+		ServiceID:           recall.ServiceID,
+		SourceAppointmentID: recall.SourceAppointmentID,
+		DueDate:             recall.DueDate.Format("2006-01-02"),
+		Status:              string(recall.Status),
+		OutreachStatus:      string(recall.OutreachStatus),
+		OutreachAttempts:    recall.OutreachAttempts,
+		LastContactedAt:     recall.LastContactedAt,
+		BookedAppointmentID: recall.BookedAppointmentID,
+		DismissReason:       recall.DismissReason,
+		ClosedBy:            recall.ClosedBy,
+		ClosedAt:            recall.ClosedAt,
+		CreatedAt:           recall.CreatedAt,
+		UpdatedAt:           recall.UpdatedAt,
+	}
+}
@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// InviteStaffRequest represents the request to invite a user into the organization
+type InviteStaffRequest struct {
+	Email    string     `json:"email" binding:"required,email"`
+	Roles    []string   `json:"roles" binding:"required,min=1"`
+	DoctorID *uuid.UUID `json:"doctor_id,omitempty"` // Doctor record to link when the invitation is accepted
+}
+
+// AcceptInvitationRequest represents the request to accept an invitation
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UpdateMemberRolesRequest represents the request to replace a member's roles
+type UpdateMemberRolesRequest struct {
+	Roles []string `json:"roles" binding:"required,min=1"`
+}
+
+// LinkMemberDoctorRequest represents the request to link a member to a doctor record
+type LinkMemberDoctorRequest struct {
+	DoctorID uuid.UUID `json:"doctor_id" binding:"required"`
+}
+
+// StaffInvitationResponse represents the response for a staff invitation
+type StaffInvitationResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Email      string     `json:"email"`
+	Roles      []string   `json:"roles"`
+	DoctorID   *uuid.UUID `json:"doctor_id,omitempty"`
+	InvitedBy  *uuid.UUID `json:"invited_by,omitempty"`
+	Status     string     `json:"status"` // pending, accepted, revoked or expired
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// StaffMemberResponse represents an organization member
+type StaffMemberResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Email         string     `json:"email"`
+	FullName      *string    `json:"full_name,omitempty"`
+	Roles         []string   `json:"roles"`
+	DoctorID      *uuid.UUID `json:"doctor_id,omitempty"`
+	IsActive      bool       `json:"is_active"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ToStaffInvitationResponse converts entities.StaffInvitation to StaffInvitationResponse
+func ToStaffInvitationResponse(i *entities.StaffInvitation) *StaffInvitationResponse {
+	status := string(i.Status)
+	if i.IsPending() && i.IsExpired(time.Now()) {
+		status = "expired"
+	}
+
+	return &StaffInvitationResponse{
+		ID:         i.ID,
+		Email:      i.Email,
+		Roles:      i.Roles,
+		DoctorID:   i.DoctorID,
+		InvitedBy:  i.InvitedBy,
+		Status:     status,
+		ExpiresAt:  i.ExpiresAt,
+		AcceptedAt: i.AcceptedAt,
+		CreatedAt:  i.CreatedAt,
+	}
+}
+
+// ToStaffMemberResponse converts entities.Profile to StaffMemberResponse
+func ToStaffMemberResponse(p *entities.Profile, doctorID *uuid.UUID) *StaffMemberResponse {
+	return &StaffMemberResponse{
+		ID:            p.ID,
+		Email:         p.Email,
+		FullName:      p.FullName,
+		Roles:         p.Roles,
+		DoctorID:      doctorID,
+		IsActive:      p.IsActive,
+		DeactivatedAt: p.DeactivatedAt,
+		CreatedAt:     p.CreatedAt,
+	}
+}
+
+// ParseStaffRoles converts role names into staff roles, rejecting unknown or non-staff roles
+func ParseStaffRoles(names []string) ([]entities.Role, error) {
+	roles := make([]entities.Role, 0, len(names))
+	for _, name := range names {
+		role := entities.Role(name)
+		if !entities.IsStaffRole(role) {
+			return nil, entities.ErrInvalidStaffRole
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
@@ -0,0 +1,218 @@
+package dto
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// CreateTreatmentPlanRequest represents a new treatment plan with its procedures
+type CreateTreatmentPlanRequest struct {
+	PatientID uuid.UUID                `json:"patient_id" binding:"required"`
+	DoctorID  *uuid.UUID               `json:"doctor_id,omitempty"` // Proposing doctor
+	Title     string                   `json:"title" binding:"required"`
+	Notes     *string                  `json:"notes,omitempty"`
+	Numbering string                   `json:"numbering,omitempty"` // fdi (default) or universal, for the teeth in items
+	Items     []TreatmentPlanItemInput `json:"items,omitempty" binding:"dive"`
+}
+
+// TreatmentPlanItemInput represents a planned procedure
+type TreatmentPlanItemInput struct {
+	Phase         int     `json:"phase" binding:"required"` // 1 for the first visit(s), 2 for the next, ...
+	ServiceID     string  `json:"service_id" binding:"required"`
+	Tooth         string  `json:"tooth,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	EstimatedCost *int64  `json:"estimated_cost,omitempty"` // Minor currency units, defaults to the service base price
+}
+
+// AddTreatmentPlanItemRequest represents a procedure added to an existing plan
+type AddTreatmentPlanItemRequest struct {
+	Numbering string `json:"numbering,omitempty"`
+	TreatmentPlanItemInput
+}
+
+// UpdateTreatmentPlanRequest represents changes to a plan's details
+type UpdateTreatmentPlanRequest struct {
+	DoctorID *uuid.UUID `json:"doctor_id,omitempty"`
+	Title    *string    `json:"title,omitempty"`
+	Notes    *string    `json:"notes,omitempty"`
+}
+
+// UpdateTreatmentPlanItemRequest represents changes to a procedure before the patient's decision
+type UpdateTreatmentPlanItemRequest struct {
+	Numbering     string  `json:"numbering,omitempty"`
+	Phase         *int    `json:"phase,omitempty"`
+	Position      *int    `json:"position,omitempty"`
+	Tooth         *string `json:"tooth,omitempty"` // Empty string removes the tooth
+	Description   *string `json:"description,omitempty"`
+	EstimatedCost *int64  `json:"estimated_cost,omitempty"`
+}
+
+// TreatmentPlanDecisionRequest records the procedures the patient accepted; the rest are declined
+type TreatmentPlanDecisionRequest struct {
+	AcceptedItemIDs []uuid.UUID `json:"accepted_item_ids"`
+}
+
+// ScheduleTreatmentPlanItemRequest represents the appointment booked for a procedure
+type ScheduleTreatmentPlanItemRequest struct {
+	DoctorID  uuid.UUID `json:"doctor_id" binding:"required"`
+	UnitID    uuid.UUID `json:"unit_id" binding:"required"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+	Notes     *string   `json:"notes,omitempty"`
+}
+
+// TreatmentPlanQuery represents the numbering of teeth in plan responses
+type TreatmentPlanQuery struct {
+	Numbering string `form:"numbering,omitempty"` // fdi (default) or universal
+}
+
+// TreatmentPlanProgressResponse represents how far a plan (or phase) has been carried out
+type TreatmentPlanProgressResponse struct {
+	Procedures       int   `json:"procedures"`
+	Accepted         int   `json:"accepted"`
+	Scheduled        int   `json:"scheduled"`
+	Completed        int   `json:"completed"`
+	PercentComplete  int   `json:"percent_complete"`
+	EstimatedValue   int64 `json:"estimated_value"`
+	AcceptedValue    int64 `json:"accepted_value"`
+	CompletedValue   int64 `json:"completed_value"`
+	OutstandingValue int64 `json:"outstanding_value"`
+}
+
+// TreatmentPlanItemResponse represents a planned procedure
+type TreatmentPlanItemResponse struct {
+	ID            uuid.UUID      `json:"id"`
+	Phase         int            `json:"phase"`
+	Position      int            `json:"position"`
+	ServiceID     string         `json:"service_id"`
+	Tooth         *ToothResponse `json:"tooth,omitempty"`
+	Description   *string        `json:"description,omitempty"`
+	EstimatedCost int64          `json:"estimated_cost"`
+	Status        string         `json:"status"`
+	AppointmentID *uuid.UUID     `json:"appointment_id,omitempty"`
+	CompletedAt   *time.Time     `json:"completed_at,omitempty"`
+}
+
+// TreatmentPlanPhaseResponse represents the procedures of one phase
+type TreatmentPlanPhaseResponse struct {
+	Phase    int                           `json:"phase"`
+	Items    []*TreatmentPlanItemResponse  `json:"items"`
+	Progress TreatmentPlanProgressResponse `json:"progress"`
+}
+
+// TreatmentPlanResponse represents a treatment plan with its phases and progress
+type TreatmentPlanResponse struct {
+	ID          uuid.UUID                     `json:"id"`
+	PatientID   uuid.UUID                     `json:"patient_id"`
+	DoctorID    *uuid.UUID                    `json:"doctor_id,omitempty"`
+	Title       string                        `json:"title"`
+	Notes       *string                       `json:"notes,omitempty"`
+	Status      string                        `json:"status"`
+	Numbering   string                        `json:"numbering"`
+	Phases      []*TreatmentPlanPhaseResponse `json:"phases"`
+	Progress    TreatmentPlanProgressResponse `json:"progress"`
+	PresentedAt *time.Time                    `json:"presented_at,omitempty"`
+	DecidedAt   *time.Time                    `json:"decided_at,omitempty"`
+	DecisionBy  *uuid.UUID                    `json:"decision_by,omitempty"`
+	CompletedAt *time.Time                    `json:"completed_at,omitempty"`
+	CreatedBy   *uuid.UUID                    `json:"created_by,omitempty"`
+	CreatedAt   time.Time                     `json:"created_at"`
+	UpdatedAt   time.Time                     `json:"updated_at"`
+}
+
+// PatientTreatmentPlansResponse represents a patient's plans and the value still to perform
+type PatientTreatmentPlansResponse struct {
+	PatientID        uuid.UUID                `json:"patient_id"`
+	Plans            []*TreatmentPlanResponse `json:"plans"`
+	AcceptedValue    int64                    `json:"accepted_value"`
+	CompletedValue   int64                    `json:"completed_value"`
+	OutstandingValue int64                    `json:"outstanding_value"` // Accepted procedures of active plans not performed yet
+}
+
+// ToTreatmentPlanProgressResponse converts plan progress
+func ToTreatmentPlanProgressResponse(p services.TreatmentPlanProgress) TreatmentPlanProgressResponse {
+	return TreatmentPlanProgressResponse{
+		Procedures:       p.Procedures,
+		Accepted:         p.Accepted,
+		Scheduled:        p.Scheduled,
+		Completed:        p.Completed,
+		PercentComplete:  p.PercentComplete,
+		EstimatedValue:   p.EstimatedValue,
+		AcceptedValue:    p.AcceptedValue,
+		CompletedValue:   p.CompletedValue,
+		OutstandingValue: p.OutstandingValue,
+	}
+}
+
+// ToTreatmentPlanResponse converts a plan, grouping its items by phase, with teeth in the requested numbering
+func ToTreatmentPlanResponse(plan *entities.TreatmentPlan, numbering entities.ToothNumberingSystem) *TreatmentPlanResponse {
+	response := &TreatmentPlanResponse{
+		ID:          plan.ID,
+		PatientID:   plan.PatientID,
+		DoctorID:    plan.DoctorID,
+		Title:       plan.Title,
+		Notes:       plan.Notes,
+		Status:      string(plan.Status),
+		Numbering:   string(numbering),
+		Phases:      []*TreatmentPlanPhaseResponse{},
+		Progress:    ToTreatmentPlanProgressResponse(services.SummarizeTreatmentPlan(plan)),
+		PresentedAt: plan.PresentedAt,
+		DecidedAt:   plan.DecidedAt,
+		DecisionBy:  plan.DecisionBy,
+		CompletedAt: plan.CompletedAt,
+		CreatedBy:   plan.CreatedBy,
+		CreatedAt:   plan.CreatedAt,
+		UpdatedAt:   plan.UpdatedAt,
+	}
+
+	// Items arrive ordered by phase, so each phase is a contiguous run
+	var phaseItems []*entities.TreatmentPlanItem
+	flush := func() {
+		if len(phaseItems) == 0 {
+			return
+		}
+		phase := &TreatmentPlanPhaseResponse{
+			Phase:    phaseItems[0].Phase,
+			Items:    make([]*TreatmentPlanItemResponse, len(phaseItems)),
+			Progress: ToTreatmentPlanProgressResponse(services.SummarizeTreatmentPlan(&entities.TreatmentPlan{Status: plan.Status, Items: phaseItems})),
+		}
+		for i, item := range phaseItems {
+			phase.Items[i] = ToTreatmentPlanItemResponse(item, numbering)
+		}
+		response.Phases = append(response.Phases, phase)
+		phaseItems = nil
+	}
+	for _, item := range plan.Items {
+		if len(phaseItems) > 0 && phaseItems[0].Phase != item.Phase {
+			flush()
+		}
+		phaseItems = append(phaseItems, item)
+	}
+	flush()
+
+	return response
+}
+
+// ToTreatmentPlanItemResponse converts a planned procedure
+func ToTreatmentPlanItemResponse(item *entities.TreatmentPlanItem, numbering entities.ToothNumberingSystem) *TreatmentPlanItemResponse {
+	response := &TreatmentPlanItemResponse{
+		ID:            item.ID,
+		Phase:         item.Phase,
+		Position:      item.Position,
+		ServiceID:     item.ServiceID,
+		Description:   item.Description,
+		EstimatedCost: item.EstimatedCost,
+		Status:        string(item.Status),
+		AppointmentID: item.AppointmentID,
+		CompletedAt:   item.CompletedAt,
+	}
+	if item.Tooth != nil {
+		tooth := ToToothResponse(*item.Tooth, numbering)
+		response.Tooth = &tooth
+	}
+	return response
+}
@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// CreateWebhookSubscriptionRequest represents the request to create a webhook subscription
+type CreateWebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required,url"`
+	Description *string  `json:"description,omitempty" binding:"omitempty,max=255"`
+	EventTypes  []string `json:"event_types,omitempty"` // All events when empty
+}
+
+// UpdateWebhookSubscriptionRequest represents the request to update a webhook subscription
+type UpdateWebhookSubscriptionRequest struct {
+	URL         *string   `json:"url,omitempty" binding:"omitempty,url"`
+	Description *string   `json:"description,omitempty" binding:"omitempty,max=255"`
+	EventTypes  *[]string `json:"event_types,omitempty"`
+	IsActive    *bool     `json:"is_active,omitempty"`
+}
+
+// WebhookDeliveryListRequest represents the query parameters for listing deliveries
+type WebhookDeliveryListRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+// WebhookSubscriptionResponse represents a webhook subscription without its secret
+type WebhookSubscriptionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	Description *string   `json:"description,omitempty"`
+	EventTypes  []string  `json:"event_types"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateWebhookSubscriptionResponse includes the signing secret, which is only returned once
+type CreateWebhookSubscriptionResponse struct {
+	WebhookSubscriptionResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryResponse represents a webhook delivery and its latest attempt
+type WebhookDeliveryResponse struct {
+	ID                 uuid.UUID       `json:"id"`
+	SubscriptionID     uuid.UUID       `json:"subscription_id"`
+	EventID            uuid.UUID       `json:"event_id"`
+	EventType          string          `json:"event_type"`
+	Payload            json.RawMessage `json:"payload"`
+	Status             string          `json:"status"`
+	Attempts           int             `json:"attempts"`
+	NextAttemptAt      *time.Time      `json:"next_attempt_at,omitempty"` // Only set while pending
+	LastAttemptAt      *time.Time      `json:"last_attempt_at,omitempty"`
+	LastResponseStatus *int            `json:"last_response_status,omitempty"`
+	LastError          *string         `json:"last_error,omitempty"`
+	DeliveredAt        *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+}
+
+// WebhookDeliveryListResponse represents a page of webhook deliveries
+type WebhookDeliveryListResponse struct {
+	Deliveries []*WebhookDeliveryResponse `json:"deliveries"`
+	Pagination PaginationInfo             `json:"pagination"`
+}
+
+// ToWebhookSubscriptionResponse converts entities.WebhookSubscription to WebhookSubscriptionResponse
+func ToWebhookSubscriptionResponse(s *entities.WebhookSubscription) *WebhookSubscriptionResponse {
+	eventTypes := []string(s.EventTypes)
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return &WebhookSubscriptionResponse{
+		ID:          s.ID,
+		URL:         s.URL,
+		Description: s.Description,
+		EventTypes:  eventTypes,
+		IsActive:    s.IsActive,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// ToWebhookDeliveryResponse converts entities.WebhookDelivery to WebhookDeliveryResponse
+func ToWebhookDeliveryResponse(d *entities.WebhookDelivery) *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		ID:                 d.ID,
+		SubscriptionID:     d.SubscriptionID,
+		EventID:            d.EventID,
+		EventType:          string(d.EventType),
+		Payload:            d.Payload,
+		Status:             string(d.Status),
+		Attempts:           d.Attempts,
+		LastAttemptAt:      d.LastAttemptAt,
+		LastResponseStatus: d.LastResponseStatus,
+		LastError:          d.LastError,
+		DeliveredAt:        d.DeliveredAt,
+		CreatedAt:          d.CreatedAt,
+	}
+
+	if d.Status == entities.WebhookDeliveryPending {
+		nextAttemptAt := d.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	return response
+}
+
+// ParseWebhookEventTypes converts event type names into webhook event types
+func ParseWebhookEventTypes(names []string) ([]entities.WebhookEventType, error) {
+	eventTypes := make([]entities.WebhookEventType, 0, len(names))
+	for _, name := range names {
+		eventType := entities.WebhookEventType(name)
+		if !entities.IsValidWebhookEventType(eventType) {
+			return nil, entities.ErrInvalidWebhookEventType
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	return eventTypes, nil
+}
@@ -0,0 +1,97 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// Handler processes a domain event. Delivery is at-least-once, so handlers must
+// be idempotent, using the event ID as the idempotency key.
+type Handler func(ctx context.Context, event *entities.DomainEvent) error
+
+// subscription is a named handler and the event types it listens to
+type subscription struct {
+	name       string
+	eventTypes map[entities.DomainEventType]bool // Empty means all events
+	handler    Handler
+}
+
+// accepts checks if the subscription listens to the event type
+func (s *subscription) accepts(eventType entities.DomainEventType) bool {
+	return len(s.eventTypes) == 0 || s.eventTypes[eventType]
+}
+
+// Bus dispatches outbox events to in-process subscribers
+type Bus struct {
+	subscriptions []*subscription
+	logger        *logger.Logger
+}
+
+// NewBus creates a new instance of Bus
+func NewBus(logger *logger.Logger) *Bus {
+	return &Bus{logger: logger}
+}
+
+// Subscribe registers a handler under a unique, stable name. The name is stored
+// with each event the handler completes, so renaming it re-delivers pending events.
+// Without event types the handler receives every event.
+func (b *Bus) Subscribe(name string, handler Handler, eventTypes ...entities.DomainEventType) {
+	for _, existing := range b.subscriptions {
+		if existing.name == name {
+			panic(fmt.Sprintf("events: duplicate subscriber %q", name))
+		}
+	}
+
+	types := make(map[entities.DomainEventType]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		types[eventType] = true
+	}
+
+	b.subscriptions = append(b.subscriptions, &subscription{
+		name:       name,
+		eventTypes: types,
+		handler:    handler,
+	})
+}
+
+// Dispatch runs every matching subscriber that has not yet handled the event and
+// records the ones that succeed on it. It returns the failures of the others.
+func (b *Bus) Dispatch(ctx context.Context, event *entities.OutboxEvent) error {
+	var errs []error
+
+	for _, sub := range b.subscriptions {
+		if !sub.accepts(event.Type) || event.IsHandledBy(sub.name) {
+			continue
+		}
+
+		if err := b.run(ctx, sub, &event.DomainEvent); err != nil {
+			b.logger.Logger.WithFields(map[string]interface{}{
+				"event_id":   event.ID,
+				"event_type": event.Type,
+				"subscriber": sub.name,
+			}).WithError(err).Warn("Event subscriber failed")
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+			continue
+		}
+
+		event.MarkHandledBy(sub.name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// run invokes a subscriber, turning a panic into an error so one bad handler
+// cannot stop the relay
+func (b *Bus) run(ctx context.Context, sub *subscription, event *entities.DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("subscriber panicked: %v", r)
+		}
+	}()
+
+	return sub.handler(ctx, event)
+}
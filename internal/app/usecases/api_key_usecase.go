@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/google/uuid"
+)
+
+// lastUsedResolution limits how often last_used_at is written for busy keys
+const lastUsedResolution = time.Minute
+
+// APIKeyUseCase handles API key management and authentication
+type APIKeyUseCase struct {
+	apiKeyRepo repositories.APIKeyRepository
+	logger     *logger.Logger
+}
+
+// NewAPIKeyUseCase creates a new instance of APIKeyUseCase
+func NewAPIKeyUseCase(apiKeyRepo repositories.APIKeyRepository, logger *logger.Logger) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+// CreateAPIKey creates a new API key; the plaintext secret is only returned here
+func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, orgID, createdBy uuid.UUID, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	scopes, err := dto.ParseAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, plaintext, err := entities.NewAPIKey(orgID, req.Name, scopes, &createdBy, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apiKey.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreateAPIKeyResponse{
+		APIKeyResponse: *dto.ToAPIKeyResponse(apiKey),
+		Key:            plaintext,
+	}, nil
+}
+
+// ListAPIKeys retrieves all API keys of an organization
+func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context, orgID uuid.UUID) ([]*dto.APIKeyResponse, error) {
+	apiKeys, err := uc.apiKeyRepo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		responses[i] = dto.ToAPIKeyResponse(apiKey)
+	}
+
+	return responses, nil
+}
+
+// RevokeAPIKey permanently disables an API key
+func (uc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, orgID, keyID uuid.UUID) (*dto.APIKeyResponse, error) {
+	apiKey, err := uc.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil || apiKey.OrganizationID != orgID {
+		return nil, entities.ErrAPIKeyNotFound
+	}
+
+	if !apiKey.IsRevoked() {
+		apiKey.Revoke()
+		if err := uc.apiKeyRepo.Update(ctx, apiKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return dto.ToAPIKeyResponse(apiKey), nil
+}
+
+// Authenticate resolves a plaintext API key into the stored key
+func (uc *APIKeyUseCase) Authenticate(ctx context.Context, plaintext string) (*entities.APIKey, error) {
+	prefix, ok := entities.ParseAPIKeyPrefix(plaintext)
+	if !ok {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	apiKey, err := uc.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil || !apiKey.Matches(plaintext) {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if err := apiKey.CanAuthenticate(now); err != nil {
+		return nil, err
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedResolution {
+		if err := uc.apiKeyRepo.UpdateLastUsed(ctx, apiKey.ID, now); err != nil {
+			// Usage tracking must not block the request
+			uc.logger.Logger.WithError(err).WithField("api_key_id", apiKey.ID).Warn("Failed to record API key usage")
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return apiKey, nil
+}
@@ -7,8 +7,10 @@ import (
 
 	"dental-scheduler-backend/internal/app/dto"
 	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
 	"dental-scheduler-backend/internal/domain/ports/repositories"
 	"dental-scheduler-backend/internal/domain/services"
+	"dental-scheduler-backend/internal/infra/logger"
 
 	"github.com/google/uuid"
 )
@@ -19,7 +21,22 @@ type AppointmentUseCase struct {
 	patientRepo       repositories.PatientRepository
 	doctorRepo        repositories.DoctorRepository
 	unitRepo          repositories.UnitRepository
+	treatmentPlanRepo repositories.TreatmentPlanRepository
+	consentTemplates  repositories.ConsentTemplateRepository
+	consentRepo       repositories.ConsentFormRepository
+	alertRepo         repositories.MedicalAlertRepository
+	relationshipRepo  repositories.PatientRelationshipRepository
+	cancellationRepo  repositories.CancellationRepository
+	serviceRepo       repositories.ServiceRepository
+	orgRepo           repositories.OrganizationRepository
+	depositRepo       repositories.DepositRepository
+	recallRepo        repositories.RecallRepository
+	equipmentRepo     repositories.EquipmentRepository
+	paymentGateway    gateways.PaymentGateway
 	schedulingService *services.SchedulingService
+	txManager         repositories.TransactionManager
+	outboxRepo        repositories.OutboxRepository
+	logger            *logger.Logger
 }
 
 // NewAppointmentUseCase creates a new instance of AppointmentUseCase
@@ -28,18 +45,49 @@ func NewAppointmentUseCase(
 	patientRepo repositories.PatientRepository,
 	doctorRepo repositories.DoctorRepository,
 	unitRepo repositories.UnitRepository,
+	treatmentPlanRepo repositories.TreatmentPlanRepository,
+	consentTemplates repositories.ConsentTemplateRepository,
+	consentRepo repositories.ConsentFormRepository,
+	alertRepo repositories.MedicalAlertRepository,
+	relationshipRepo repositories.PatientRelationshipRepository,
+	cancellationRepo repositories.CancellationRepository,
+	serviceRepo repositories.ServiceRepository,
+	orgRepo repositories.OrganizationRepository,
+	depositRepo repositories.DepositRepository,
+	recallRepo repositories.RecallRepository,
+	equipmentRepo repositories.EquipmentRepository,
+	paymentGateway gateways.PaymentGateway,
 	schedulingService *services.SchedulingService,
+	txManager repositories.TransactionManager,
+	outboxRepo repositories.OutboxRepository,
+	logger *logger.Logger,
 ) *AppointmentUseCase {
 	return &AppointmentUseCase{
 		appointmentRepo:   appointmentRepo,
 		patientRepo:       patientRepo,
 		doctorRepo:        doctorRepo,
 		unitRepo:          unitRepo,
+		treatmentPlanRepo: treatmentPlanRepo,
+		consentTemplates:  consentTemplates,
+		consentRepo:       consentRepo,
+		alertRepo:         alertRepo,
+		relationshipRepo:  relationshipRepo,
+		cancellationRepo:  cancellationRepo,
+		serviceRepo:       serviceRepo,
+		orgRepo:           orgRepo,
+		depositRepo:       depositRepo,
+		recallRepo:        recallRepo,
+		equipmentRepo:     equipmentRepo,
+		paymentGateway:    paymentGateway,
 		schedulingService: schedulingService,
+		txManager:         txManager,
+		outboxRepo:        outboxRepo,
+		logger:            logger,
 	}
 }
 
-// CreateAppointment creates a new appointment with basic validation (no conflict checking)
+// CreateAppointment creates a new appointment with basic validation. Only appointments booking
+// additional resources are checked for conflicts, on every resource they hold.
 func (uc *AppointmentUseCase) CreateAppointment(ctx context.Context, orgID uuid.UUID, req *dto.CreateAppointmentRequest) (*dto.AppointmentResponse, error) {
 	// Validate date logic: end date can't be before start date
 	if req.EndTime.Before(req.StartTime) {
@@ -103,29 +151,105 @@ func (uc *AppointmentUseCase) CreateAppointment(ctx context.Context, orgID uuid.
 	appointment.StartTime = startTimeUTC
 	appointment.EndTime = endTimeUTC
 
-	// Create appointment directly in repository (no conflict checking)
-	if err := uc.appointmentRepo.Create(ctx, appointment); err != nil {
-		return nil, fmt.Errorf("failed to create appointment: %w", err)
+	// Book the additional staff, units and equipment, which must all be free
+	if len(req.Resources) > 0 {
+		resources, err := dto.ToAppointmentResources(req.Resources)
+		if err != nil {
+			return nil, err
+		}
+		if err := appointment.SetResources(resources); err != nil {
+			return nil, err
+		}
+		if err := checkAppointmentResources(ctx, uc.doctorRepo, uc.unitRepo, uc.equipmentRepo, orgID, clinic.ID, appointment.Resources); err != nil {
+			return nil, err
+		}
+		if err := uc.schedulingService.CheckConflicts(ctx, appointment); err != nil {
+			return nil, err
+		}
 	}
 
-	// Link patient to organization (ignore errors if already linked)
-	if err := uc.patientRepo.AddPatientToOrganization(ctx, req.PatientID, orgID); err != nil {
-		// Log the error but don't fail the appointment creation
-		// The ON CONFLICT DO NOTHING in the query will handle duplicates gracefully
-		fmt.Printf("Warning: failed to link patient to organization: %v\n", err)
+	// Book the treatment plan procedure performed in this appointment, if any
+	var planItem *entities.TreatmentPlanItem
+	if req.TreatmentPlanItemID != nil {
+		plan, err := uc.treatmentPlanRepo.GetByItemID(ctx, orgID, *req.TreatmentPlanItemID)
+		if err != nil {
+			return nil, err
+		}
+		if plan == nil {
+			return nil, entities.ErrTreatmentPlanItemNotFound
+		}
+		if plan.PatientID != req.PatientID {
+			return nil, entities.ErrTreatmentPlanPatientMismatch
+		}
+		planItem = plan.Item(*req.TreatmentPlanItemID)
+		if err := planItem.Schedule(appointment.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Hold the slot until a deposit is paid when the organization's policy requires one
+	deposit, err := uc.holdForDeposit(ctx, orgID, clinic.ID, appointment, planItem)
+	if err != nil {
+		return nil, err
 	}
 
-	// Try to set patient's first_appointment_id if NULL (best-effort, non-critical)
-	if err := uc.patientRepo.UpdateFirstAppointmentIfNil(ctx, req.PatientID, appointment.ID); err != nil {
-		// Log but don't fail - this is a non-critical operation
-		fmt.Printf("Warning: failed to set patient's first_appointment_id: %v\n", err)
+	// Create appointment directly in repository (no conflict checking). The patient
+	// links, the deposit and the AppointmentCreated event are stored in the same transaction.
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.appointmentRepo.Create(ctx, appointment); err != nil {
+			return fmt.Errorf("failed to create appointment: %w", err)
+		}
+
+		if deposit != nil {
+			if err := uc.depositRepo.Create(ctx, deposit); err != nil {
+				return fmt.Errorf("failed to create deposit: %w", err)
+			}
+		}
+
+		// Link patient to organization (ON CONFLICT DO NOTHING handles existing links)
+		if err := uc.patientRepo.AddPatientToOrganization(ctx, req.PatientID, orgID); err != nil {
+			return fmt.Errorf("failed to link patient to organization: %w", err)
+		}
+
+		// Set patient's first_appointment_id if it is still NULL
+		if err := uc.patientRepo.UpdateFirstAppointmentIfNil(ctx, req.PatientID, appointment.ID); err != nil {
+			return fmt.Errorf("failed to set patient's first_appointment_id: %w", err)
+		}
+
+		if planItem != nil {
+			if err := uc.treatmentPlanRepo.UpdateItem(ctx, planItem); err != nil {
+				return fmt.Errorf("failed to book treatment plan item: %w", err)
+			}
+		}
+
+		// Booking closes the patient's recalls the service qualifies for
+		if err := bookRecalls(ctx, uc.recallRepo, orgID, appointment); err != nil {
+			return fmt.Errorf("failed to close recalls: %w", err)
+		}
+
+		contact, err := appointmentEventContact(ctx, uc.patientRepo, uc.relationshipRepo, orgID, appointment)
+		if err != nil {
+			return err
+		}
+		data := &dto.AppointmentEventData{
+			Appointment: dto.ToAppointmentResponse(appointment),
+			Contact:     contact,
+		}
+		data.Appointment.Deposit = dto.ToDepositResponse(deposit)
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventAppointmentCreated, entities.AggregateAppointment, appointment.ID, data)
+	})
+	if err != nil {
+		expirePaymentLink(ctx, uc.paymentGateway, uc.logger, deposit)
+		return nil, err
 	}
 
 	// Fetch patient data to include patient name and is_first_visit flag in response
 	patient, err := uc.patientRepo.GetByID(ctx, req.PatientID)
 	if err != nil {
 		// If we can't get patient data, return response without patient name
-		return dto.ToAppointmentResponse(appointment), nil
+		response := dto.ToAppointmentResponse(appointment)
+		response.Deposit = dto.ToDepositResponse(deposit)
+		return uc.withMissingConsents(ctx, orgID, appointment, response), nil
 	}
 
 	patientName := ""
@@ -142,7 +266,9 @@ func (uc *AppointmentUseCase) CreateAppointment(ctx context.Context, orgID uuid.
 		isFirstVisit = true
 	}
 
-	return dto.ToAppointmentResponseWithPatientNameAndFirstVisit(appointment, patientName, isFirstVisit), nil
+	response := dto.ToAppointmentResponseWithPatientNameAndFirstVisit(appointment, patientName, isFirstVisit)
+	response.Deposit = dto.ToDepositResponse(deposit)
+	return uc.withMissingConsents(ctx, orgID, appointment, response), nil
 }
 
 // GetAppointmentByID retrieves an appointment by its ID
@@ -189,8 +315,10 @@ func (uc *AppointmentUseCase) GetUpcomingAppointments(ctx context.Context) ([]*d
 	return responses, nil
 }
 
-// UpdateAppointment updates an existing appointment
-func (uc *AppointmentUseCase) UpdateAppointment(ctx context.Context, id uuid.UUID, req *dto.UpdateAppointmentRequest) (*dto.AppointmentResponse, error) {
+// UpdateAppointment updates an existing appointment. When req.Version is set the
+// update only succeeds if the appointment has not changed since that version.
+// Cancelling the appointment applies the organization's cancellation policy.
+func (uc *AppointmentUseCase) UpdateAppointment(ctx context.Context, id uuid.UUID, orgID uuid.UUID, userID *uuid.UUID, req *dto.UpdateAppointmentRequest) (*dto.AppointmentResponse, error) {
 	existing, err := uc.appointmentRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -200,6 +328,11 @@ func (uc *AppointmentUseCase) UpdateAppointment(ctx context.Context, id uuid.UUI
 		return nil, entities.ErrAppointmentNotFound
 	}
 
+	// A nil version means the client sent If-Match: * and overwrites unconditionally
+	if req.Version != nil && *req.Version != existing.Version {
+		return nil, entities.ErrAppointmentVersionConflict
+	}
+
 	// Validate status if provided
 	if req.Status != nil {
 		if !entities.IsValidAppointmentStatus(entities.AppointmentStatus(*req.Status)) {
@@ -284,6 +417,10 @@ func (uc *AppointmentUseCase) UpdateAppointment(ctx context.Context, id uuid.UUI
 		dateChanged = true
 	}
 
+	previousStatus := existing.Status
+	previousStartTime := existing.StartTime
+	previousEndTime := existing.EndTime
+
 	updated := req.ToEntityUpdate(existing)
 
 	// If date changed and no explicit status provided, automatically set to rescheduled
@@ -302,9 +439,104 @@ func (uc *AppointmentUseCase) UpdateAppointment(ctx context.Context, id uuid.UUI
 		return nil, err
 	}
 
-	if err := uc.appointmentRepo.Update(ctx, updated); err != nil {
+	// Additional resources are replaced when sent, and must be free again whenever they or the slot
+	// they are held in change
+	resourcesChanged := req.Resources != nil
+	resources := updated.Resources
+	if resourcesChanged {
+		if resources, err = dto.ToAppointmentResources(*req.Resources); err != nil {
+			return nil, err
+		}
+	}
+	if err := updated.SetResources(resources); err != nil {
 		return nil, err
 	}
+	slotChanged := dateChanged || req.DoctorID != nil || req.UnitID != nil
+	if updated.HasResources() && (resourcesChanged || slotChanged) && !updated.IsCancelled() {
+		if clinic == nil {
+			return nil, entities.ErrInvalidUnitID
+		}
+		if resourcesChanged || req.UnitID != nil {
+			if err := checkAppointmentResources(ctx, uc.doctorRepo, uc.unitRepo, uc.equipmentRepo, orgID, clinic.ID, updated.Resources); err != nil {
+				return nil, err
+			}
+		}
+		if err := uc.schedulingService.CheckConflicts(ctx, updated); err != nil {
+			return nil, err
+		}
+	}
+
+	if updated.Status == entities.AppointmentStatusConfirmed && previousStatus != updated.Status && updated.AwaitsDeposit() {
+		return nil, entities.ErrDepositPending
+	}
+
+	cancelled := updated.Status != previousStatus && updated.IsCancelled()
+	var reason *entities.CancellationReason
+	if cancelled && req.CancellationReasonID != nil {
+		if reason, err = uc.getCancellationReason(ctx, orgID, *req.CancellationReasonID); err != nil {
+			return nil, err
+		}
+		updated.CancelWithReason(&reason.ID, reason.Label)
+	}
+
+	var fee *entities.CancellationFee
+	var releasedDeposit *entities.Deposit
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if cancelled {
+			if releasedDeposit, err = releaseAppointmentDeposit(ctx, uc.depositRepo, updated, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.appointmentRepo.Update(ctx, updated); err != nil {
+			return err
+		}
+
+		if resourcesChanged {
+			if err := uc.appointmentRepo.ReplaceResources(ctx, updated.ID, updated.Resources); err != nil {
+				return err
+			}
+		}
+
+		if cancelled && clinic != nil {
+			if fee, err = uc.assessCancellation(ctx, orgID, clinic.ID, updated, reason, userID, req.FeeOverride); err != nil {
+				return err
+			}
+		}
+
+		if updated.Status != previousStatus {
+			if err := syncTreatmentPlanItem(ctx, uc.treatmentPlanRepo, updated); err != nil {
+				return err
+			}
+			if err := syncRecalls(ctx, uc.recallRepo, orgID, clinic, updated); err != nil {
+				return err
+			}
+		}
+
+		var eventType entities.DomainEventType
+		data := &dto.AppointmentEventData{Appointment: dto.ToAppointmentResponse(updated)}
+		switch {
+		case cancelled:
+			eventType = entities.EventAppointmentCancelled
+			data.Appointment.CancellationFee = dto.ToCancellationFeeResponse(fee)
+		case updated.Status != previousStatus && updated.IsCompleted():
+			eventType = entities.EventAppointmentCompleted
+		case dateChanged:
+			eventType = entities.EventAppointmentRescheduled
+			data.PreviousStartTime = &previousStartTime
+			data.PreviousEndTime = &previousEndTime
+		default:
+			return nil
+		}
+		if data.Contact, err = appointmentEventContact(ctx, uc.patientRepo, uc.relationshipRepo, orgID, updated); err != nil {
+			return err
+		}
+		return raiseEvent(ctx, uc.outboxRepo, orgID, eventType, entities.AggregateAppointment, updated.ID, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	expirePaymentLink(ctx, uc.paymentGateway, uc.logger, releasedDeposit)
 
 	// Fetch patient data to include patient name in response
 	patientName := ""
@@ -323,7 +555,9 @@ func (uc *AppointmentUseCase) UpdateAppointment(ctx context.Context, id uuid.UUI
 		}
 	}
 
-	return dto.ToAppointmentResponseWithPatientNameAndFirstVisit(updated, patientName, isFirstVisit), nil
+	response := dto.ToAppointmentResponseWithPatientNameAndFirstVisit(updated, patientName, isFirstVisit)
+	response.CancellationFee = dto.ToCancellationFeeResponse(fee)
+	return uc.withMissingConsents(ctx, orgID, updated, response), nil
 }
 
 // RescheduleAppointment reschedules an existing appointment
@@ -341,7 +575,7 @@ func (uc *AppointmentUseCase) RescheduleAppointment(ctx context.Context, id uuid
 	return dto.ToAppointmentResponse(appointment), nil
 }
 
-// CancelAppointment cancels an appointment
+// CancelAppointment cancels an appointment, applying its organization's cancellation policy
 func (uc *AppointmentUseCase) CancelAppointment(ctx context.Context, id uuid.UUID) error {
 	appointment, err := uc.appointmentRepo.GetByID(ctx, id)
 	if err != nil {
@@ -352,9 +586,42 @@ func (uc *AppointmentUseCase) CancelAppointment(ctx context.Context, id uuid.UUI
 		return entities.ErrAppointmentNotFound
 	}
 
+	var clinic *entities.Clinic
+	if appointment.UnitID != nil {
+		if _, clinic, err = uc.unitRepo.GetUnitWithClinic(ctx, *appointment.UnitID); err != nil {
+			return err
+		}
+	}
+
+	wasCancelled := appointment.IsCancelled()
 	appointment.Cancel()
 
-	return uc.appointmentRepo.Update(ctx, appointment)
+	var releasedDeposit *entities.Deposit
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if !wasCancelled {
+			if releasedDeposit, err = releaseAppointmentDeposit(ctx, uc.depositRepo, appointment, time.Now()); err != nil {
+				return err
+			}
+		}
+		if err := uc.appointmentRepo.Update(ctx, appointment); err != nil {
+			return err
+		}
+		if !wasCancelled && clinic != nil {
+			if _, err := uc.assessCancellation(ctx, clinic.OrganizationID, clinic.ID, appointment, nil, nil, nil); err != nil {
+				return err
+			}
+			if err := syncRecalls(ctx, uc.recallRepo, clinic.OrganizationID, clinic, appointment); err != nil {
+				return err
+			}
+		}
+		return syncTreatmentPlanItem(ctx, uc.treatmentPlanRepo, appointment)
+	})
+	if err != nil {
+		return err
+	}
+
+	expirePaymentLink(ctx, uc.paymentGateway, uc.logger, releasedDeposit)
+	return nil
 }
 
 // CompleteAppointment marks an appointment as completed
@@ -368,9 +635,26 @@ func (uc *AppointmentUseCase) CompleteAppointment(ctx context.Context, id uuid.U
 		return entities.ErrAppointmentNotFound
 	}
 
+	var clinic *entities.Clinic
+	if appointment.UnitID != nil {
+		if _, clinic, err = uc.unitRepo.GetUnitWithClinic(ctx, *appointment.UnitID); err != nil {
+			return err
+		}
+	}
+
 	appointment.Complete()
 
-	return uc.appointmentRepo.Update(ctx, appointment)
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.appointmentRepo.Update(ctx, appointment); err != nil {
+			return err
+		}
+		if clinic != nil {
+			if err := syncRecalls(ctx, uc.recallRepo, clinic.OrganizationID, clinic, appointment); err != nil {
+				return err
+			}
+		}
+		return syncTreatmentPlanItem(ctx, uc.treatmentPlanRepo, appointment)
+	})
 }
 
 // DeleteAppointment deletes an appointment by its ID
@@ -542,24 +826,32 @@ func (uc *AppointmentUseCase) buildAppointmentResponse(appointments []*repositor
 			unitName = &appt.Unit.Name
 		}
 
+		var depositStatus *string
+		if appt.Appointment.DepositStatus != nil {
+			status := string(*appt.Appointment.DepositStatus)
+			depositStatus = &status
+		}
+
 		appointmentDTOs[i] = dto.AppointmentListResponse{
-			ID:           appt.Appointment.ID.String(),
-			Patient:      patient,
-			DoctorID:     doctorID,
-			DoctorName:   doctorName,
-			ClinicID:     clinicID,
-			ClinicName:   clinicName,
-			UnitID:       unitID,
-			UnitName:     unitName,
-			StartTime:    startTimeStr,
-			EndTime:      endTimeStr,
-			Status:       string(appt.Appointment.Status),
-			ServiceID:    getStringPtr(appt.Appointment.ServiceID),
-			ServiceName:  getStringPtr(appt.ServiceName),
-			Notes:        getStringPtr(appt.Appointment.Notes),
-			IsFirstVisit: isFirstVisit,
-			CreatedAt:    appt.Appointment.CreatedAt,
-			UpdatedAt:    appt.Appointment.UpdatedAt,
+			ID:            appt.Appointment.ID.String(),
+			Patient:       patient,
+			DoctorID:      doctorID,
+			DoctorName:    doctorName,
+			ClinicID:      clinicID,
+			ClinicName:    clinicName,
+			UnitID:        unitID,
+			UnitName:      unitName,
+			StartTime:     startTimeStr,
+			EndTime:       endTimeStr,
+			Status:        string(appt.Appointment.Status),
+			DepositStatus: depositStatus,
+			ServiceID:     getStringPtr(appt.Appointment.ServiceID),
+			ServiceName:   getStringPtr(appt.ServiceName),
+			Notes:         getStringPtr(appt.Appointment.Notes),
+			IsFirstVisit:  isFirstVisit,
+			Version:       appt.Appointment.Version,
+			CreatedAt:     appt.Appointment.CreatedAt,
+			UpdatedAt:     appt.Appointment.UpdatedAt,
 		}
 
 		// Build summary data (only if clinic exists)
@@ -621,8 +913,9 @@ func getStringPtrFromUUID(u *uuid.UUID) *string {
 	return &str
 }
 
-// GetReschedulingQueue retrieves appointments in rescheduling queue with pagination
-func (uc *AppointmentUseCase) GetReschedulingQueue(ctx context.Context, orgID uuid.UUID, req *dto.ReschedulingQueueRequest) (*dto.ReschedulingQueueResponse, error) {
+// GetReschedulingQueue retrieves appointments in rescheduling queue with pagination. Medical
+// alerts are only attached when includeMedicalAlerts is set, that is for staff sessions.
+func (uc *AppointmentUseCase) GetReschedulingQueue(ctx context.Context, orgID uuid.UUID, req *dto.ReschedulingQueueRequest, includeMedicalAlerts bool) (*dto.ReschedulingQueueResponse, error) {
 	// Set defaults
 	if req.Page <= 0 {
 		req.Page = 1
@@ -673,6 +966,21 @@ func (uc *AppointmentUseCase) GetReschedulingQueue(ctx context.Context, orgID uu
 		return nil, fmt.Errorf("failed to get rescheduling queue: %w", err)
 	}
 
+	// Flag the patients' medical alerts so they are seen before the visit is rebooked
+	var alerts map[uuid.UUID][]*entities.MedicalAlert
+	if includeMedicalAlerts {
+		var patientIDs []uuid.UUID
+		for _, appt := range appointments {
+			if appt.Patient != nil {
+				patientIDs = append(patientIDs, appt.Patient.ID)
+			}
+		}
+		alerts, err = uc.alertRepo.ListActiveByPatients(ctx, orgID, patientIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get medical alerts: %w", err)
+		}
+	}
+
 	// Convert to DTOs
 	items := make([]dto.ReschedulingQueueItem, 0, len(appointments))
 	for _, appt := range appointments {
@@ -780,6 +1088,11 @@ func (uc *AppointmentUseCase) GetReschedulingQueue(ctx context.Context, orgID uu
 			item.MovedToNeedsReschedulingAt = appt.Appointment.MovedToNeedsReschedulingAt.Format(time.RFC3339)
 		}
 
+		// Add medical alerts
+		if appt.Patient != nil {
+			item.MedicalAlerts = dto.ToMedicalAlertFlags(alerts[appt.Patient.ID])
+		}
+
 		items = append(items, item)
 	}
 
@@ -795,41 +1108,93 @@ func (uc *AppointmentUseCase) GetReschedulingQueue(ctx context.Context, orgID uu
 	}, nil
 }
 
-// CancelFromQueue cancels an appointment from the rescheduling queue
-func (uc *AppointmentUseCase) CancelFromQueue(ctx context.Context, appointmentID uuid.UUID, orgID uuid.UUID, req *dto.CancelAppointmentRequest) error {
+// CancelFromQueue cancels an appointment from the rescheduling queue for one of the
+// organization's cancellation reasons, applying its cancellation policy
+func (uc *AppointmentUseCase) CancelFromQueue(ctx context.Context, appointmentID uuid.UUID, orgID uuid.UUID, userID *uuid.UUID, req *dto.CancelAppointmentRequest) (*dto.AppointmentResponse, error) {
 	// Get appointment to verify it exists and belongs to organization
 	appointment, err := uc.appointmentRepo.GetByID(ctx, appointmentID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if appointment == nil {
-		return entities.ErrAppointmentNotFound
+		return nil, entities.ErrAppointmentNotFound
 	}
 
 	// Verify appointment status is needs-rescheduling
 	if appointment.Status != entities.AppointmentStatusNeedsRescheduling {
-		return entities.ErrAppointmentNotInQueue
+		return nil, entities.ErrAppointmentNotInQueue
 	}
 
 	// Verify appointment belongs to organization (via unit -> clinic -> organization)
+	var clinic *entities.Clinic
 	if appointment.UnitID != nil {
-		unit, clinic, err := uc.unitRepo.GetUnitWithClinic(ctx, *appointment.UnitID)
+		var unit *entities.Unit
+		unit, clinic, err = uc.unitRepo.GetUnitWithClinic(ctx, *appointment.UnitID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if unit == nil || clinic == nil || clinic.OrganizationID != orgID {
-			return fmt.Errorf("appointment does not belong to organization")
+			return nil, fmt.Errorf("appointment does not belong to organization")
 		}
 	}
 
+	reason, err := uc.getCancellationReason(ctx, orgID, req.ReasonID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Combine reason and notes
-	fullReason := req.Reason
-	if req.Notes != nil && *req.Notes != "" {
-		fullReason = fmt.Sprintf("%s - %s", req.Reason, *req.Notes)
+	fullReason := reason.Label
+	if notes := optionalText(req.Notes); notes != nil {
+		fullReason = fmt.Sprintf("%s - %s", reason.Label, *notes)
 	}
 
-	// Cancel with reason
-	return uc.appointmentRepo.CancelWithReason(ctx, appointmentID, fullReason)
+	// Cancel with reason, charge any late-cancellation fee and raise AppointmentCancelled atomically
+	var fee *entities.CancellationFee
+	var releasedDeposit *entities.Deposit
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.appointmentRepo.CancelWithReason(ctx, appointmentID, &reason.ID, fullReason); err != nil {
+			return err
+		}
+		appointment.CancelWithReason(&reason.ID, fullReason)
+		if releasedDeposit, err = releaseAppointmentDeposit(ctx, uc.depositRepo, appointment, time.Now()); err != nil {
+			return err
+		}
+		if releasedDeposit != nil {
+			if err := uc.appointmentRepo.UpdateDepositStatus(ctx, appointment.ID, appointment.DepositStatus); err != nil {
+				return err
+			}
+		}
+		if clinic != nil {
+			if fee, err = uc.assessCancellation(ctx, orgID, clinic.ID, appointment, reason, userID, req.FeeOverride); err != nil {
+				return err
+			}
+		}
+		if err := syncTreatmentPlanItem(ctx, uc.treatmentPlanRepo, appointment); err != nil {
+			return err
+		}
+		if err := syncRecalls(ctx, uc.recallRepo, orgID, clinic, appointment); err != nil {
+			return err
+		}
+		contact, err := appointmentEventContact(ctx, uc.patientRepo, uc.relationshipRepo, orgID, appointment)
+		if err != nil {
+			return err
+		}
+		data := &dto.AppointmentEventData{
+			Appointment: dto.ToAppointmentResponse(appointment),
+			Contact:     contact,
+		}
+		data.Appointment.CancellationFee = dto.ToCancellationFeeResponse(fee)
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventAppointmentCancelled, entities.AggregateAppointment, appointment.ID, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	expirePaymentLink(ctx, uc.paymentGateway, uc.logger, releasedDeposit)
+
+	response := dto.ToAppointmentResponse(appointment)
+	response.CancellationFee = dto.ToCancellationFeeResponse(fee)
+	return response, nil
 }
 
 // RescheduleFromQueue reschedules an appointment from the queue by creating a new one
@@ -929,33 +1294,103 @@ func (uc *AppointmentUseCase) RescheduleFromQueue(ctx context.Context, appointme
 		return nil, err
 	}
 
-	// Check for conflicts with existing appointments
-	hasConflict, err := uc.appointmentRepo.CheckConflict(
-		ctx,
-		req.DoctorID,
-		req.UnitID,
-		startTimeUTC,
-		endTimeUTC,
-		nil, // No appointment to exclude
-	)
+	// The new appointment books the requested resources, or carries over those of the original
+	// that are not its new doctor or unit
+	resources, err := rescheduledResources(original, newAppointment, req.Resources)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check for conflicts: %w", err)
+		return nil, err
 	}
-	if hasConflict {
-		return nil, entities.ErrAppointmentConflict
+	if err := newAppointment.SetResources(resources); err != nil {
+		return nil, err
 	}
 
-	// Create new appointment
-	if err := uc.appointmentRepo.Create(ctx, newAppointment); err != nil {
-		return nil, fmt.Errorf("failed to create new appointment: %w", err)
+	// Check for conflicts with existing appointments
+	if newAppointment.HasResources() {
+		if err := checkAppointmentResources(ctx, uc.doctorRepo, uc.unitRepo, uc.equipmentRepo, orgID, clinic.ID, newAppointment.Resources); err != nil {
+			return nil, err
+		}
+		if err := uc.schedulingService.CheckConflicts(ctx, newAppointment); err != nil {
+			return nil, err
+		}
+	} else {
+		hasConflict, err := uc.appointmentRepo.CheckConflict(
+			ctx,
+			req.DoctorID,
+			req.UnitID,
+			startTimeUTC,
+			endTimeUTC,
+			nil, // No appointment to exclude
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for conflicts: %w", err)
+		}
+		if hasConflict {
+			return nil, entities.ErrAppointmentConflict
+		}
 	}
 
-	// Update original appointment to link to new one
-	original.LinkToRescheduledAppointment(newAppointment.ID)
-	if err := uc.appointmentRepo.Update(ctx, original); err != nil {
-		// If this fails, we should ideally rollback the creation
-		// For now, we'll return the error
-		return nil, fmt.Errorf("failed to update original appointment: %w", err)
+	// Create the new appointment and link the original one atomically
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// A pending or paid deposit of the original holds and pays for the new appointment
+		deposit, err := uc.depositRepo.GetActiveByAppointment(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+		if deposit != nil {
+			if deposit, err = uc.depositRepo.GetForUpdate(ctx, deposit.OrganizationID, deposit.ID); err != nil {
+				return err
+			}
+		}
+		if deposit != nil && deposit.AppointmentID == original.ID && deposit.IsActive() {
+			newAppointment.DepositStatus = &deposit.Status
+		} else {
+			deposit = nil
+		}
+
+		if err := uc.appointmentRepo.Create(ctx, newAppointment); err != nil {
+			return fmt.Errorf("failed to create new appointment: %w", err)
+		}
+
+		// Update original appointment to link to new one
+		original.LinkToRescheduledAppointment(newAppointment.ID)
+		if err := uc.appointmentRepo.Update(ctx, original); err != nil {
+			return fmt.Errorf("failed to update original appointment: %w", err)
+		}
+
+		// A treatment plan procedure booked in the original moves to the new appointment
+		if err := uc.treatmentPlanRepo.MoveAppointment(ctx, original.ID, newAppointment.ID); err != nil {
+			return err
+		}
+
+		if deposit != nil {
+			if err := uc.depositRepo.MoveAppointment(ctx, original.ID, newAppointment.ID, clinic.ID); err != nil {
+				return err
+			}
+		}
+
+		// Recalls closed by the original stay closed by the new appointment, so cancelling it
+		// reopens them; open recalls the new service qualifies for are closed as on any booking
+		if err := uc.recallRepo.MoveAppointment(ctx, original.ID, newAppointment.ID); err != nil {
+			return fmt.Errorf("failed to move recalls: %w", err)
+		}
+		if err := bookRecalls(ctx, uc.recallRepo, orgID, newAppointment); err != nil {
+			return fmt.Errorf("failed to close recalls: %w", err)
+		}
+
+		contact, err := appointmentEventContact(ctx, uc.patientRepo, uc.relationshipRepo, orgID, newAppointment)
+		if err != nil {
+			return err
+		}
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventAppointmentRescheduled, entities.AggregateAppointment, newAppointment.ID, &dto.AppointmentEventData{
+			Appointment:                  dto.ToAppointmentResponse(newAppointment),
+			PreviousStartTime:            &original.StartTime,
+			PreviousEndTime:              &original.EndTime,
+			RescheduledFromAppointmentID: &original.ID,
+			Contact:                      contact,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Build response
@@ -974,7 +1409,7 @@ func (uc *AppointmentUseCase) RescheduleFromQueue(ctx context.Context, appointme
 		}
 	}
 
-	return dto.ToAppointmentResponseWithPatientNameAndFirstVisit(newAppointment, patientName, isFirstVisit), nil
+	return uc.withMissingConsents(ctx, orgID, newAppointment, dto.ToAppointmentResponseWithPatientNameAndFirstVisit(newAppointment, patientName, isFirstVisit)), nil
 }
 
 // SnoozeFromQueue temporarily hides an appointment from the rescheduling queue
@@ -1034,3 +1469,246 @@ func (uc *AppointmentUseCase) SnoozeFromQueue(ctx context.Context, appointmentID
 
 	return nil
 }
+
+// rescheduledResources returns the additional resources of an appointment rescheduled from the
+// queue: the requested ones, or copies of the original's without its new doctor and unit
+func rescheduledResources(original, rescheduled *entities.Appointment, requested *[]dto.AppointmentResourceRequest) ([]*entities.AppointmentResource, error) {
+	if requested != nil {
+		return dto.ToAppointmentResources(*requested)
+	}
+
+	resources := make([]*entities.AppointmentResource, 0, len(original.Resources))
+	for _, resource := range original.Resources {
+		if resource.Type == entities.ResourceTypeStaff && rescheduled.DoctorID != nil && resource.ResourceID == *rescheduled.DoctorID {
+			continue
+		}
+		if resource.Type == entities.ResourceTypeUnit && rescheduled.UnitID != nil && resource.ResourceID == *rescheduled.UnitID {
+			continue
+		}
+		copied, err := entities.NewAppointmentResource(resource.Type, resource.ResourceID, resource.Role)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, copied)
+	}
+	return resources, nil
+}
+
+// withMissingConsents adds the required consents the patient has not signed yet to the
+// response of an upcoming appointment. The check only warns, so the response is returned
+// unchanged if it fails.
+func (uc *AppointmentUseCase) withMissingConsents(ctx context.Context, orgID uuid.UUID, appointment *entities.Appointment, response *dto.AppointmentResponse) *dto.AppointmentResponse {
+	if appointment.Status != entities.AppointmentStatusScheduled && appointment.Status != entities.AppointmentStatusConfirmed {
+		return response
+	}
+	requirements, err := appointmentConsentRequirements(ctx, uc.consentTemplates, uc.consentRepo, orgID, appointment)
+	if err != nil {
+		return response
+	}
+	response.MissingConsents = missingConsents(requirements)
+	return response
+}
+
+// appointmentEventContact resolves who is notified about an appointment event: a guardian for minors
+func appointmentEventContact(ctx context.Context, patientRepo repositories.PatientRepository, relationshipRepo repositories.PatientRelationshipRepository, orgID uuid.UUID, appointment *entities.Appointment) (*dto.PatientContactResponse, error) {
+	if appointment.PatientID == nil {
+		return nil, nil
+	}
+	patient, err := patientRepo.GetByID(ctx, *appointment.PatientID)
+	if err != nil || patient == nil {
+		return nil, err
+	}
+
+	contact, err := resolvePatientContact(ctx, relationshipRepo, orgID, patient)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToPatientContactResponse(contact), nil
+}
+
+// syncTreatmentPlanItem updates the treatment plan procedure booked in an appointment after a
+// status change: completion completes it (and the plan once nothing is left), while a
+// cancellation or no-show releases it so it can be booked again.
+func syncTreatmentPlanItem(ctx context.Context, treatmentPlanRepo repositories.TreatmentPlanRepository, appointment *entities.Appointment) error {
+	plan, err := treatmentPlanRepo.GetByAppointmentID(ctx, appointment.ID)
+	if err != nil || plan == nil {
+		return err
+	}
+	item := plan.ItemByAppointment(appointment.ID)
+
+	switch appointment.Status {
+	case entities.AppointmentStatusCompleted:
+		item.Complete(appointment.EndTime)
+	case entities.AppointmentStatusCancelled, entities.AppointmentStatusNoShow:
+		item.Release()
+	default:
+		return nil
+	}
+
+	if err := treatmentPlanRepo.UpdateItem(ctx, item); err != nil {
+		return err
+	}
+	if plan.RefreshCompletion() {
+		return treatmentPlanRepo.Update(ctx, plan)
+	}
+	return nil
+}
+
+// assessCancellation applies the organization's cancellation policy to an appointment that was
+// just cancelled, storing a pending fee for the patient when the cancellation was late and not
+// exempt. A staff override replaces the fee before it is stored. It must run before the
+// appointment's treatment plan procedure is released, as that procedure sets the price.
+func (uc *AppointmentUseCase) assessCancellation(ctx context.Context, orgID, clinicID uuid.UUID, appointment *entities.Appointment, reason *entities.CancellationReason, cancelledBy *uuid.UUID, override *dto.CancellationFeeOverride) (*entities.CancellationFee, error) {
+	if appointment.PatientID == nil {
+		return nil, nil
+	}
+	policy, err := uc.cancellationRepo.GetPolicy(ctx, orgID)
+	if err != nil || policy == nil || !policy.Enabled {
+		return nil, err
+	}
+
+	org, err := uc.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, entities.ErrOrganizationNotFound
+	}
+
+	var price *int64
+	if policy.FeeType == entities.CancellationFeeTypePercentage {
+		if price, err = uc.appointmentPrice(ctx, orgID, appointment, org.Currency); err != nil {
+			return nil, err
+		}
+	}
+	var category *entities.CancellationCategory
+	if reason != nil {
+		category = &reason.Category
+	}
+
+	cancelledAt := time.Now()
+	assessment := services.AssessCancellation(policy, appointment.StartTime, cancelledAt, category, price)
+	if assessment.Amount == 0 {
+		return nil, nil
+	}
+
+	fee, err := entities.NewCancellationFee(orgID, clinicID, *appointment.PatientID, appointment, reason, org.Currency, assessment.Amount, cancelledAt, cancelledBy)
+	if err != nil {
+		return nil, err
+	}
+	if override != nil {
+		if err := fee.Override(override.Amount, override.Justification, cancelledBy, cancelledAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.cancellationRepo.CreateFee(ctx, fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+// holdForDeposit applies the organization's deposit policy to an appointment being booked. When
+// a deposit is required, it opens its payment link and marks the appointment as awaiting it; the
+// caller stores the deposit with the appointment. It returns nil when none is required.
+func (uc *AppointmentUseCase) holdForDeposit(ctx context.Context, orgID, clinicID uuid.UUID, appointment *entities.Appointment, planItem *entities.TreatmentPlanItem) (*entities.Deposit, error) {
+	if appointment.PatientID == nil {
+		return nil, nil
+	}
+	policy, err := uc.depositRepo.GetPolicy(ctx, orgID)
+	if err != nil || policy == nil || !policy.Enabled {
+		return nil, err
+	}
+
+	org, err := uc.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, entities.ErrOrganizationNotFound
+	}
+
+	var service *entities.Service
+	if appointment.ServiceID != nil {
+		if service, err = uc.serviceRepo.GetByID(ctx, orgID, *appointment.ServiceID); err != nil {
+			return nil, err
+		}
+	}
+	// Priced the same way it would be invoiced: the treatment plan item, or else the service
+	var price *int64
+	if planItem != nil {
+		price = &planItem.EstimatedCost
+	} else if service != nil && service.BasePrice != nil {
+		basePrice := entities.ToMinorUnits(*service.BasePrice, org.Currency) // Base prices are stored in major units
+		price = &basePrice
+	}
+
+	now := time.Now()
+	noShows := 0
+	if policy.NoShowThreshold > 0 {
+		history, err := uc.appointmentRepo.GetPatientHistory(ctx, orgID, *appointment.PatientID)
+		if err != nil {
+			return nil, err
+		}
+		appointments := make([]*entities.Appointment, len(history))
+		for i, item := range history {
+			appointments[i] = item.Appointment
+		}
+		noShows = services.RecentNoShows(policy, appointments, now)
+	}
+
+	requirement := services.AssessDeposit(policy, service, price, noShows, now, appointment.StartTime)
+	if !requirement.Required() {
+		return nil, nil
+	}
+
+	deposit, err := entities.NewDeposit(orgID, clinicID, *appointment.PatientID, appointment.ID, requirement.Reason, org.Currency, requirement.Amount, requirement.DueAt, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := openDepositPaymentLink(ctx, uc.paymentGateway, deposit, appointment); err != nil {
+		return nil, err
+	}
+	appointment.DepositStatus = &deposit.Status
+	return deposit, nil
+}
+
+// appointmentPrice resolves the price of an appointment's procedure in minor units, the same way
+// it would be invoiced: the treatment plan item booked in it, or else its service's base price.
+// It returns nil when neither is known.
+func (uc *AppointmentUseCase) appointmentPrice(ctx context.Context, orgID uuid.UUID, appointment *entities.Appointment, currency string) (*int64, error) {
+	plan, err := uc.treatmentPlanRepo.GetByAppointmentID(ctx, appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if plan != nil {
+		if item := plan.ItemByAppointment(appointment.ID); item != nil {
+			return &item.EstimatedCost, nil
+		}
+	}
+
+	if appointment.ServiceID == nil {
+		return nil, nil
+	}
+	service, err := uc.serviceRepo.GetByID(ctx, orgID, *appointment.ServiceID)
+	if err != nil || service == nil || service.BasePrice == nil {
+		return nil, err
+	}
+	price := entities.ToMinorUnits(*service.BasePrice, currency) // Base prices are stored in major units
+	return &price, nil
+}
+
+// getCancellationReason retrieves an active cancellation reason of the organization
+func (uc *AppointmentUseCase) getCancellationReason(ctx context.Context, orgID, reasonID uuid.UUID) (*entities.CancellationReason, error) {
+	reason, err := uc.cancellationRepo.GetReason(ctx, orgID, reasonID)
+	if err != nil {
+		return nil, err
+	}
+	if reason == nil {
+		return nil, entities.ErrCancellationReasonNotFound
+	}
+	if !reason.Active {
+		return nil, entities.ErrCancellationReasonInactive
+	}
+	return reason, nil
+}
@@ -0,0 +1,447 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentUseCase handles patient files: radiographs, photos, consent forms and referral letters
+type AttachmentUseCase struct {
+	attachmentRepo  repositories.AttachmentRepository
+	patientRepo     repositories.PatientRepository
+	appointmentRepo repositories.AppointmentRepository
+	storage         gateways.BlobStorage
+	maxUploadBytes  int64
+	presignTTL      time.Duration
+	logger          *logger.Logger
+}
+
+// NewAttachmentUseCase creates a new instance of AttachmentUseCase
+func NewAttachmentUseCase(
+	attachmentRepo repositories.AttachmentRepository,
+	patientRepo repositories.PatientRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	storage gateways.BlobStorage,
+	maxUploadBytes int64,
+	presignTTL time.Duration,
+	logger *logger.Logger,
+) *AttachmentUseCase {
+	return &AttachmentUseCase{
+		attachmentRepo:  attachmentRepo,
+		patientRepo:     patientRepo,
+		appointmentRepo: appointmentRepo,
+		storage:         storage,
+		maxUploadBytes:  maxUploadBytes,
+		presignTTL:      presignTTL,
+		logger:          logger,
+	}
+}
+
+// MaxUploadBytes is the largest file accepted by UploadAttachment
+func (uc *AttachmentUseCase) MaxUploadBytes() int64 {
+	return uc.maxUploadBytes
+}
+
+// UploadAttachment stores a file for a patient. The content type is detected from the file
+// itself and a thumbnail is stored next to images that can be decoded.
+func (uc *AttachmentUseCase) UploadAttachment(ctx context.Context, orgID, patientID uuid.UUID, uploadedBy *uuid.UUID, req *dto.UploadAttachmentRequest, file dto.AttachmentFile) (*dto.AttachmentResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+	attachmentType := entities.AttachmentType(strings.ToLower(strings.TrimSpace(req.Type)))
+	if !entities.IsValidAttachmentType(attachmentType) {
+		return nil, entities.ErrInvalidAttachmentType
+	}
+	tooth, err := optionalTooth(req.Tooth, numbering)
+	if err != nil {
+		return nil, err
+	}
+	takenAt, err := parseAttachmentTakenAt(req.TakenAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ensurePatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+	if req.AppointmentID != nil {
+		if err := uc.ensurePatientAppointment(ctx, patientID, *req.AppointmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file.Content, uc.maxUploadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > uc.maxUploadBytes {
+		return nil, entities.ErrAttachmentTooLarge
+	}
+
+	contentType := services.DetectAttachmentContentType(data[:min(len(data), services.ContentSniffLength)])
+	attachment, err := entities.NewAttachment(orgID, patientID, attachmentType, file.FileName, contentType, int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	attachment.AppointmentID = req.AppointmentID
+	attachment.Tooth = tooth
+	attachment.TakenAt = takenAt
+	attachment.UploadedBy = uploadedBy
+	if description := strings.TrimSpace(req.Description); description != "" {
+		attachment.Description = &description
+	}
+	if err := attachment.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.storage.Put(ctx, attachment.StorageKey, bytes.NewReader(data), attachment.SizeBytes, attachment.ContentType); err != nil {
+		return nil, err
+	}
+	uc.storeThumbnail(ctx, attachment, data)
+
+	if err := uc.attachmentRepo.Create(ctx, attachment); err != nil {
+		uc.removeFiles(ctx, attachment)
+		return nil, err
+	}
+
+	return dto.ToAttachmentResponse(attachment, numbering), nil
+}
+
+// GetAttachment retrieves an attachment's metadata
+func (uc *AttachmentUseCase) GetAttachment(ctx context.Context, orgID, id uuid.UUID, req *dto.AttachmentQuery) (*dto.AttachmentResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := uc.getAttachment(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToAttachmentResponse(attachment, numbering), nil
+}
+
+// ListPatientAttachments retrieves a page of a patient's attachments
+func (uc *AttachmentUseCase) ListPatientAttachments(ctx context.Context, orgID, patientID uuid.UUID, req *dto.AttachmentListRequest) (*dto.AttachmentListResponse, error) {
+	if err := uc.ensurePatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	return uc.list(ctx, repositories.AttachmentFilters{
+		OrganizationID: orgID,
+		PatientID:      &patientID,
+		AppointmentID:  req.AppointmentID,
+	}, req)
+}
+
+// ListAppointmentAttachments retrieves a page of the attachments taken at an appointment
+func (uc *AttachmentUseCase) ListAppointmentAttachments(ctx context.Context, orgID, appointmentID uuid.UUID, req *dto.AttachmentListRequest) (*dto.AttachmentListResponse, error) {
+	appointment, err := uc.appointmentRepo.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if appointment == nil || appointment.PatientID == nil {
+		return nil, entities.ErrAppointmentNotFound
+	}
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, *appointment.PatientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrAppointmentNotFound
+	}
+
+	return uc.list(ctx, repositories.AttachmentFilters{
+		OrganizationID: orgID,
+		AppointmentID:  &appointmentID,
+	}, req)
+}
+
+// UpdateAttachment changes the type, appointment, tooth, capture date or description of an attachment
+func (uc *AttachmentUseCase) UpdateAttachment(ctx context.Context, orgID, id uuid.UUID, req *dto.UpdateAttachmentRequest) (*dto.AttachmentResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := uc.getAttachment(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type != nil {
+		attachment.Type = entities.AttachmentType(strings.ToLower(strings.TrimSpace(*req.Type)))
+	}
+	if req.AppointmentID != nil {
+		if err := uc.ensurePatientAppointment(ctx, attachment.PatientID, *req.AppointmentID); err != nil {
+			return nil, err
+		}
+		attachment.AppointmentID = req.AppointmentID
+	}
+	if req.Tooth != nil {
+		if attachment.Tooth, err = optionalTooth(*req.Tooth, numbering); err != nil {
+			return nil, err
+		}
+	}
+	if req.TakenAt != nil {
+		if attachment.TakenAt, err = parseAttachmentTakenAt(*req.TakenAt); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		attachment.Description = nil
+		if description := strings.TrimSpace(*req.Description); description != "" {
+			attachment.Description = &description
+		}
+	}
+	if err := attachment.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachmentRepo.Update(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	return dto.ToAttachmentResponse(attachment, numbering), nil
+}
+
+// DeleteAttachment removes an attachment and its stored files
+func (uc *AttachmentUseCase) DeleteAttachment(ctx context.Context, orgID, id uuid.UUID) error {
+	attachment, err := uc.getAttachment(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.attachmentRepo.Delete(ctx, orgID, id); err != nil {
+		return err
+	}
+	uc.removeFiles(ctx, attachment)
+
+	return nil
+}
+
+// OpenAttachment prepares the download of an attachment's file, as a pre-signed URL when
+// the storage supports them and streaming was not requested
+func (uc *AttachmentUseCase) OpenAttachment(ctx context.Context, orgID, id uuid.UUID, req *dto.AttachmentDownloadRequest) (*dto.AttachmentContent, error) {
+	attachment, err := uc.getAttachment(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &dto.AttachmentContent{
+		ContentType: attachment.ContentType,
+		FileName:    attachment.FileName,
+		Size:        attachment.SizeBytes,
+	}
+	return uc.open(ctx, attachment.StorageKey, content, req)
+}
+
+// OpenThumbnail prepares the download of an image attachment's preview
+func (uc *AttachmentUseCase) OpenThumbnail(ctx context.Context, orgID, id uuid.UUID, req *dto.AttachmentDownloadRequest) (*dto.AttachmentContent, error) {
+	attachment, err := uc.getAttachment(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !attachment.HasThumbnail() {
+		return nil, entities.ErrAttachmentNoThumbnail
+	}
+
+	content := &dto.AttachmentContent{
+		ContentType: entities.ContentTypeJPEG,
+		FileName:    strings.TrimSuffix(attachment.FileName, path.Ext(attachment.FileName)) + "-thumb.jpg",
+	}
+	return uc.open(ctx, *attachment.ThumbnailKey, content, &dto.AttachmentDownloadRequest{Stream: req.Stream, Inline: true})
+}
+
+// open resolves a pre-signed URL or opens the stored object for streaming
+func (uc *AttachmentUseCase) open(ctx context.Context, key string, content *dto.AttachmentContent, req *dto.AttachmentDownloadRequest) (*dto.AttachmentContent, error) {
+	if !req.Stream {
+		// Without a file name override the object is served inline
+		fileName := content.FileName
+		if req.Inline {
+			fileName = ""
+		}
+		url, err := uc.storage.PresignGet(ctx, key, uc.presignTTL, content.ContentType, fileName)
+		if err != nil {
+			return nil, err
+		}
+		if url != "" {
+			content.RedirectURL = url
+			return content, nil
+		}
+	}
+
+	body, err := uc.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	content.Body = body
+	return content, nil
+}
+
+// list retrieves a page of attachments matching the request filters
+func (uc *AttachmentUseCase) list(ctx context.Context, filters repositories.AttachmentFilters, req *dto.AttachmentListRequest) (*dto.AttachmentListResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+	if req.Type != "" {
+		attachmentType := entities.AttachmentType(strings.ToLower(req.Type))
+		if !entities.IsValidAttachmentType(attachmentType) {
+			return nil, entities.ErrInvalidAttachmentType
+		}
+		filters.Type = &attachmentType
+	}
+	if filters.Tooth, err = optionalTooth(req.Tooth, numbering); err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+	filters.Page = page
+	filters.Limit = limit
+
+	attachments, total, err := uc.attachmentRepo.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = dto.ToAttachmentResponse(attachment, numbering)
+	}
+
+	return &dto.AttachmentListResponse{
+		Attachments: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// storeThumbnail stores a preview of image attachments. Previews are a convenience: images
+// that cannot be decoded (WebP, BMP, oversized scans) or failed writes leave the attachment without one.
+func (uc *AttachmentUseCase) storeThumbnail(ctx context.Context, attachment *entities.Attachment, data []byte) {
+	if !attachment.IsImage() {
+		return
+	}
+
+	thumbnail, err := services.GenerateThumbnail(data, services.ThumbnailMaxDimension)
+	if err != nil {
+		return
+	}
+
+	key := attachment.AssignThumbnailKey()
+	if err := uc.storage.Put(ctx, key, bytes.NewReader(thumbnail), int64(len(thumbnail)), entities.ContentTypeJPEG); err != nil {
+		uc.logger.Logger.WithError(err).WithField("attachment_id", attachment.ID).Warn("Failed to store attachment thumbnail")
+		attachment.ThumbnailKey = nil
+	}
+}
+
+// removeFiles deletes the stored files of an attachment; failures leave orphaned objects and are only logged
+func (uc *AttachmentUseCase) removeFiles(ctx context.Context, attachment *entities.Attachment) {
+	keys := []string{attachment.StorageKey}
+	if attachment.ThumbnailKey != nil {
+		keys = append(keys, *attachment.ThumbnailKey)
+	}
+
+	for _, key := range keys {
+		if err := uc.storage.Delete(ctx, key); err != nil {
+			uc.logger.Logger.WithError(err).WithField("storage_key", key).Warn("Failed to delete attachment file")
+		}
+	}
+}
+
+// getAttachment retrieves an attachment of the organization
+func (uc *AttachmentUseCase) getAttachment(ctx context.Context, orgID, id uuid.UUID) (*entities.Attachment, error) {
+	attachment, err := uc.attachmentRepo.GetByID(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		return nil, entities.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// ensurePatient checks the patient belongs to the organization
+func (uc *AttachmentUseCase) ensurePatient(ctx context.Context, orgID, patientID uuid.UUID) error {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return entities.ErrPatientNotFound
+	}
+	return nil
+}
+
+// ensurePatientAppointment checks the appointment is one of the patient's
+func (uc *AttachmentUseCase) ensurePatientAppointment(ctx context.Context, patientID, appointmentID uuid.UUID) error {
+	appointment, err := uc.appointmentRepo.GetByID(ctx, appointmentID)
+	if err != nil {
+		return err
+	}
+	if appointment == nil {
+		return entities.ErrAppointmentNotFound
+	}
+	if appointment.PatientID == nil || *appointment.PatientID != patientID {
+		return entities.ErrAttachmentAppointmentMismatch
+	}
+	return nil
+}
+
+// optionalTooth parses a tooth that may be omitted
+func optionalTooth(value string, numbering entities.ToothNumberingSystem) (*entities.Tooth, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	tooth, err := entities.ParseTooth(value, numbering)
+	if err != nil {
+		return nil, err
+	}
+	return &tooth, nil
+}
+
+// parseAttachmentTakenAt parses an optional capture date; a date means the start of that day (UTC)
+func parseAttachmentTakenAt(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if takenAt, err := time.Parse(time.RFC3339, value); err == nil {
+		return &takenAt, nil
+	}
+	takenAt, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, entities.ErrInvalidAttachmentDate
+	}
+	return &takenAt, nil
+}
@@ -0,0 +1,341 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// CancellationUseCase handles the organization's managed cancellation reasons, its
+// late-cancellation policy and the fees charged under it. Fees are assessed when an
+// appointment is cancelled, see AppointmentUseCase.
+type CancellationUseCase struct {
+	cancellationRepo repositories.CancellationRepository
+	patientRepo      repositories.PatientRepository
+	invoiceRepo      repositories.InvoiceRepository
+	orgRepo          repositories.OrganizationRepository
+	txManager        repositories.TransactionManager
+}
+
+// NewCancellationUseCase creates a new instance of CancellationUseCase
+func NewCancellationUseCase(
+	cancellationRepo repositories.CancellationRepository,
+	patientRepo repositories.PatientRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	orgRepo repositories.OrganizationRepository,
+	txManager repositories.TransactionManager,
+) *CancellationUseCase {
+	return &CancellationUseCase{
+		cancellationRepo: cancellationRepo,
+		patientRepo:      patientRepo,
+		invoiceRepo:      invoiceRepo,
+		orgRepo:          orgRepo,
+		txManager:        txManager,
+	}
+}
+
+// ListReasons retrieves the organization's cancellation reasons
+func (uc *CancellationUseCase) ListReasons(ctx context.Context, orgID uuid.UUID, req *dto.CancellationReasonListRequest) ([]*dto.CancellationReasonResponse, error) {
+	reasons, err := uc.cancellationRepo.ListReasons(ctx, orgID, req.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.CancellationReasonResponse, len(reasons))
+	for i, reason := range reasons {
+		responses[i] = dto.ToCancellationReasonResponse(reason)
+	}
+	return responses, nil
+}
+
+// CreateReason adds a reason to the organization's list
+func (uc *CancellationUseCase) CreateReason(ctx context.Context, orgID uuid.UUID, req *dto.CancellationReasonRequest) (*dto.CancellationReasonResponse, error) {
+	reason, err := entities.NewCancellationReason(orgID, req.Label, entities.CancellationCategory(strings.ToLower(strings.TrimSpace(req.Category))))
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.checkLabel(ctx, reason); err != nil {
+			return err
+		}
+		return uc.cancellationRepo.CreateReason(ctx, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToCancellationReasonResponse(reason), nil
+}
+
+// UpdateReason changes a reason; deactivating it keeps it on past appointments and fees
+// but prevents new cancellations for it
+func (uc *CancellationUseCase) UpdateReason(ctx context.Context, orgID, reasonID uuid.UUID, req *dto.CancellationReasonRequest) (*dto.CancellationReasonResponse, error) {
+	var reason *entities.CancellationReason
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		reason, err = uc.cancellationRepo.GetReason(ctx, orgID, reasonID)
+		if err != nil {
+			return err
+		}
+		if reason == nil {
+			return entities.ErrCancellationReasonNotFound
+		}
+
+		reason.Label = strings.TrimSpace(req.Label)
+		reason.Category = entities.CancellationCategory(strings.ToLower(strings.TrimSpace(req.Category)))
+		if req.Active != nil {
+			reason.Active = *req.Active
+		}
+		if err := reason.Validate(); err != nil {
+			return err
+		}
+		if err := uc.checkLabel(ctx, reason); err != nil {
+			return err
+		}
+		return uc.cancellationRepo.UpdateReason(ctx, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToCancellationReasonResponse(reason), nil
+}
+
+// GetPolicy retrieves the organization's cancellation policy; organizations that have not
+// set one get the disabled default
+func (uc *CancellationUseCase) GetPolicy(ctx context.Context, orgID uuid.UUID) (*dto.CancellationPolicyResponse, error) {
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := uc.cancellationRepo.GetPolicy(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = entities.DefaultCancellationPolicy(orgID)
+	}
+
+	return dto.ToCancellationPolicyResponse(policy, org.Currency), nil
+}
+
+// UpdatePolicy replaces the organization's cancellation policy. It applies to cancellations
+// from then on; fees already charged are not reassessed.
+func (uc *CancellationUseCase) UpdatePolicy(ctx context.Context, orgID uuid.UUID, updatedBy *uuid.UUID, req *dto.CancellationPolicyRequest) (*dto.CancellationPolicyResponse, error) {
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &entities.CancellationPolicy{
+		OrganizationID:   orgID,
+		Enabled:          req.Enabled,
+		NoticeHours:      req.NoticeHours,
+		FeeType:          entities.CancellationFeeType(strings.ToLower(strings.TrimSpace(req.FeeType))),
+		FeeAmount:        req.FeeAmount,
+		FeePercent:       req.FeePercent,
+		ExemptCategories: []entities.CancellationCategory{},
+		UpdatedBy:        updatedBy,
+	}
+	seen := make(map[entities.CancellationCategory]bool, len(req.ExemptCategories))
+	for _, value := range req.ExemptCategories {
+		category := entities.CancellationCategory(strings.ToLower(strings.TrimSpace(value)))
+		if !seen[category] {
+			seen[category] = true
+			policy.ExemptCategories = append(policy.ExemptCategories, category)
+		}
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.cancellationRepo.SavePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return dto.ToCancellationPolicyResponse(policy, org.Currency), nil
+}
+
+// ListFees retrieves a page of the organization's cancellation fees
+func (uc *CancellationUseCase) ListFees(ctx context.Context, orgID uuid.UUID, req *dto.CancellationFeeListRequest) (*dto.CancellationFeeListResponse, error) {
+	filters := repositories.CancellationFeeFilters{
+		OrganizationID: orgID,
+		PatientID:      req.PatientID,
+	}
+	if req.Status != "" {
+		status := entities.CancellationFeeStatus(strings.ToLower(req.Status))
+		if !entities.IsValidCancellationFeeStatus(status) {
+			return nil, entities.ErrInvalidCancellationFeeStatus
+		}
+		filters.Status = &status
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+	filters.Page = page
+	filters.Limit = limit
+
+	fees, total, err := uc.cancellationRepo.ListFees(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.CancellationFeeResponse, len(fees))
+	for i, fee := range fees {
+		responses[i] = dto.ToCancellationFeeResponse(fee)
+	}
+
+	return &dto.CancellationFeeListResponse{
+		Fees: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// ListPatientFees retrieves a page of a patient's cancellation fees
+func (uc *CancellationUseCase) ListPatientFees(ctx context.Context, orgID, patientID uuid.UUID, req *dto.CancellationFeeListRequest) (*dto.CancellationFeeListResponse, error) {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	req.PatientID = &patientID
+	return uc.ListFees(ctx, orgID, req)
+}
+
+// GetFee retrieves a cancellation fee
+func (uc *CancellationUseCase) GetFee(ctx context.Context, orgID, feeID uuid.UUID) (*dto.CancellationFeeResponse, error) {
+	fee, err := uc.cancellationRepo.GetFee(ctx, orgID, feeID)
+	if err != nil {
+		return nil, err
+	}
+	if fee == nil {
+		return nil, entities.ErrCancellationFeeNotFound
+	}
+
+	return dto.ToCancellationFeeResponse(fee), nil
+}
+
+// OverrideFee replaces the amount of a pending fee, with the justification of the staff member
+func (uc *CancellationUseCase) OverrideFee(ctx context.Context, orgID, feeID uuid.UUID, overriddenBy *uuid.UUID, req *dto.CancellationFeeOverride) (*dto.CancellationFeeResponse, error) {
+	var fee *entities.CancellationFee
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if fee, err = uc.getFeeForUpdate(ctx, orgID, feeID); err != nil {
+			return err
+		}
+		if err := fee.Override(req.Amount, req.Justification, overriddenBy, time.Now()); err != nil {
+			return err
+		}
+		return uc.cancellationRepo.UpdateFee(ctx, fee)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToCancellationFeeResponse(fee), nil
+}
+
+// InvoiceFee bills a pending fee to the patient on an invoice of its own
+func (uc *CancellationUseCase) InvoiceFee(ctx context.Context, orgID, feeID uuid.UUID, createdBy *uuid.UUID, req *dto.InvoiceCancellationFeeRequest) (*dto.InvoiceResponse, error) {
+	var invoice *entities.Invoice
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		fee, err := uc.getFeeForUpdate(ctx, orgID, feeID)
+		if err != nil {
+			return err
+		}
+		if fee.Status != entities.CancellationFeeStatusPending {
+			return entities.ErrCancellationFeeNotPending
+		}
+
+		description := "Late cancellation fee - " + fee.AppointmentStart.UTC().Format("2006-01-02 15:04 UTC")
+		line, err := entities.NewInvoiceLine(description, 1, fee.Amount, 0, req.TaxRate)
+		if err != nil {
+			return err
+		}
+
+		number, err := uc.invoiceRepo.NextNumber(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		// The appointment itself is not linked: it was not carried out and stays billable on its own
+		invoice, err = entities.NewInvoice(orgID, fee.ClinicID, fee.PatientID, nil, number, fee.Currency, []*entities.InvoiceLine{line}, createdBy)
+		if err != nil {
+			return err
+		}
+		invoice.Notes = optionalText(req.Notes)
+		if err := uc.invoiceRepo.Create(ctx, invoice); err != nil {
+			return err
+		}
+
+		if err := fee.MarkInvoiced(invoice.ID, time.Now()); err != nil {
+			return err
+		}
+		return uc.cancellationRepo.UpdateFee(ctx, fee)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToInvoiceResponse(invoice, []*entities.Payment{}), nil
+}
+
+// checkLabel ensures no other reason of the organization has the same label
+func (uc *CancellationUseCase) checkLabel(ctx context.Context, reason *entities.CancellationReason) error {
+	reasons, err := uc.cancellationRepo.ListReasons(ctx, reason.OrganizationID, true)
+	if err != nil {
+		return err
+	}
+	for _, existing := range reasons {
+		if existing.ID != reason.ID && strings.EqualFold(existing.Label, reason.Label) {
+			return entities.ErrCancellationReasonExists
+		}
+	}
+	return nil
+}
+
+func (uc *CancellationUseCase) getFeeForUpdate(ctx context.Context, orgID, feeID uuid.UUID) (*entities.CancellationFee, error) {
+	fee, err := uc.cancellationRepo.GetFeeForUpdate(ctx, orgID, feeID)
+	if err != nil {
+		return nil, err
+	}
+	if fee == nil {
+		return nil, entities.ErrCancellationFeeNotFound
+	}
+	return fee, nil
+}
+
+func (uc *CancellationUseCase) getOrganization(ctx context.Context, orgID uuid.UUID) (*entities.Organization, error) {
+	org, err := uc.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, entities.ErrOrganizationNotFound
+	}
+	return org, nil
+}
@@ -0,0 +1,326 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// CFDIUseCase handles fiscal data and the CFDI electronic invoices stamped from invoices
+type CFDIUseCase struct {
+	cfdiRepo    repositories.CFDIRepository
+	fiscalRepo  repositories.FiscalProfileRepository
+	invoiceRepo repositories.InvoiceRepository
+	patientRepo repositories.PatientRepository
+	clinicRepo  repositories.ClinicRepository
+	pac         gateways.PAC
+	txManager   repositories.TransactionManager
+}
+
+// NewCFDIUseCase creates a new instance of CFDIUseCase
+func NewCFDIUseCase(
+	cfdiRepo repositories.CFDIRepository,
+	fiscalRepo repositories.FiscalProfileRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	patientRepo repositories.PatientRepository,
+	clinicRepo repositories.ClinicRepository,
+	pac gateways.PAC,
+	txManager repositories.TransactionManager,
+) *CFDIUseCase {
+	return &CFDIUseCase{
+		cfdiRepo:    cfdiRepo,
+		fiscalRepo:  fiscalRepo,
+		invoiceRepo: invoiceRepo,
+		patientRepo: patientRepo,
+		clinicRepo:  clinicRepo,
+		pac:         pac,
+		txManager:   txManager,
+	}
+}
+
+// GetPatientFiscalData retrieves the fiscal data of a patient
+func (uc *CFDIUseCase) GetPatientFiscalData(ctx context.Context, orgID, patientID uuid.UUID) (*dto.PatientFiscalDataResponse, error) {
+	if err := uc.checkPatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	profile, err := uc.fiscalRepo.GetPatientProfile(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, entities.ErrPatientFiscalDataNotFound
+	}
+
+	return dto.ToPatientFiscalDataResponse(profile), nil
+}
+
+// SetPatientFiscalData creates or replaces the fiscal data of a patient
+func (uc *CFDIUseCase) SetPatientFiscalData(ctx context.Context, orgID, patientID uuid.UUID, req *dto.PatientFiscalDataRequest) (*dto.PatientFiscalDataResponse, error) {
+	if err := uc.checkPatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	cfdiUse := req.CFDIUse
+	if strings.TrimSpace(cfdiUse) == "" {
+		cfdiUse = entities.CFDIUseMedicalExpenses
+	}
+	identity := entities.FiscalIdentity{RFC: req.RFC, LegalName: req.LegalName, TaxRegime: req.TaxRegime, PostalCode: req.PostalCode}
+	profile, err := entities.NewPatientFiscalProfile(orgID, patientID, identity, cfdiUse)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.fiscalRepo.UpsertPatientProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return dto.ToPatientFiscalDataResponse(profile), nil
+}
+
+// DeletePatientFiscalData removes the fiscal data of a patient; CFDI already stamped keep theirs
+func (uc *CFDIUseCase) DeletePatientFiscalData(ctx context.Context, orgID, patientID uuid.UUID) error {
+	if err := uc.checkPatient(ctx, orgID, patientID); err != nil {
+		return err
+	}
+
+	return uc.fiscalRepo.DeletePatientProfile(ctx, orgID, patientID)
+}
+
+// GetIssuerProfile retrieves the fiscal data the organization issues CFDI with
+func (uc *CFDIUseCase) GetIssuerProfile(ctx context.Context, orgID uuid.UUID) (*dto.IssuerFiscalProfileResponse, error) {
+	profile, err := uc.fiscalRepo.GetIssuerProfile(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, entities.ErrIssuerFiscalDataNotFound
+	}
+
+	return dto.ToIssuerFiscalProfileResponse(profile), nil
+}
+
+// SetIssuerProfile creates or replaces the fiscal data the organization issues CFDI with
+func (uc *CFDIUseCase) SetIssuerProfile(ctx context.Context, orgID uuid.UUID, req *dto.IssuerFiscalProfileRequest) (*dto.IssuerFiscalProfileResponse, error) {
+	identity := entities.FiscalIdentity{RFC: req.RFC, LegalName: req.LegalName, TaxRegime: req.TaxRegime, PostalCode: req.PostalCode}
+	profile, err := entities.NewIssuerFiscalProfile(orgID, identity, req.ProductCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.fiscalRepo.UpsertIssuerProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return dto.ToIssuerFiscalProfileResponse(profile), nil
+}
+
+// StampInvoice builds the CFDI of an invoice from the organization's and the patient's
+// fiscal data and has the PAC stamp it. The invoice stays locked while the PAC is called
+// so that it cannot be stamped twice.
+func (uc *CFDIUseCase) StampInvoice(ctx context.Context, orgID, invoiceID uuid.UUID, createdBy *uuid.UUID) (*dto.CFDIResponse, error) {
+	var cfdi *entities.CFDI
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		invoice, err := uc.invoiceRepo.GetForUpdate(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+		if invoice == nil {
+			return entities.ErrInvoiceNotFound
+		}
+
+		active, err := uc.cfdiRepo.GetActiveByInvoice(ctx, invoice.ID)
+		if err != nil {
+			return err
+		}
+		if active != nil {
+			return entities.ErrInvoiceAlreadyStamped
+		}
+
+		issuer, err := uc.fiscalRepo.GetIssuerProfile(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		if issuer == nil {
+			return entities.ErrIssuerFiscalDataNotFound
+		}
+		receiver, err := uc.fiscalRepo.GetPatientProfile(ctx, orgID, invoice.PatientID)
+		if err != nil {
+			return err
+		}
+		if receiver == nil {
+			return entities.ErrPatientFiscalDataNotFound
+		}
+
+		payments, err := uc.invoiceRepo.ListPayments(ctx, invoice.ID)
+		if err != nil {
+			return err
+		}
+		issuedAt, err := uc.clinicTime(ctx, invoice.ClinicID)
+		if err != nil {
+			return err
+		}
+
+		xml, err := services.BuildCFDI(services.CFDIInput{
+			Invoice:  invoice,
+			Payments: payments,
+			Issuer:   issuer,
+			Receiver: receiver,
+			IssuedAt: issuedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		stamped, err := uc.pac.Stamp(ctx, xml)
+		if err != nil {
+			return err
+		}
+
+		cfdi = entities.NewCFDI(invoice, stamped.UUID, issuer.RFC, receiver.RFC, stamped.XML, stamped.StampedAt, createdBy)
+		return uc.cfdiRepo.Create(ctx, cfdi)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToCFDIResponse(cfdi), nil
+}
+
+// ListCFDIs retrieves the CFDI stamped from an invoice, cancelled ones included
+func (uc *CFDIUseCase) ListCFDIs(ctx context.Context, orgID, invoiceID uuid.UUID) ([]*dto.CFDIResponse, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, entities.ErrInvoiceNotFound
+	}
+
+	cfdis, err := uc.cfdiRepo.ListByInvoice(ctx, invoice.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToCFDIResponses(cfdis), nil
+}
+
+// GetCFDIXML retrieves the stamped XML of a CFDI, named after its UUID
+func (uc *CFDIUseCase) GetCFDIXML(ctx context.Context, orgID, invoiceID, cfdiID uuid.UUID) (*dto.CFDIXML, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, entities.ErrInvoiceNotFound
+	}
+
+	cfdi, err := uc.getCFDI(ctx, invoice.ID, cfdiID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CFDIXML{FileName: cfdi.UUID + ".xml", Content: cfdi.XML}, nil
+}
+
+// CancelCFDI requests the cancellation of a CFDI at the SAT. Cancellations the receiver has
+// to accept stay pending; requesting again re-sends the request and picks up the outcome.
+func (uc *CFDIUseCase) CancelCFDI(ctx context.Context, orgID, invoiceID, cfdiID uuid.UUID, cancelledBy *uuid.UUID, req *dto.CancelCFDIRequest) (*dto.CFDIResponse, error) {
+	var cfdi *entities.CFDI
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		invoice, err := uc.invoiceRepo.GetForUpdate(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+		if invoice == nil {
+			return entities.ErrInvoiceNotFound
+		}
+
+		cfdi, err = uc.getCFDI(ctx, invoice.ID, cfdiID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := cfdi.RequestCancellation(entities.CFDICancellationReason(strings.TrimSpace(req.Reason)), req.ReplacementUUID, cancelledBy, now); err != nil {
+			return err
+		}
+
+		status, err := uc.pac.Cancel(ctx, &gateways.CFDICancellation{
+			IssuerRFC:       cfdi.IssuerRFC,
+			ReceiverRFC:     cfdi.ReceiverRFC,
+			UUID:            cfdi.UUID,
+			Total:           cfdi.Total,
+			Reason:          *cfdi.CancellationReason,
+			ReplacementUUID: cfdi.ReplacementUUID,
+		})
+		if err != nil {
+			return err
+		}
+		if status == entities.CFDIStatusCancelled {
+			cfdi.ConfirmCancellation(now)
+		}
+
+		return uc.cfdiRepo.Update(ctx, cfdi)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToCFDIResponse(cfdi), nil
+}
+
+// checkPatient ensures the patient belongs to the organization
+func (uc *CFDIUseCase) checkPatient(ctx context.Context, orgID, patientID uuid.UUID) error {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return entities.ErrPatientNotFound
+	}
+	return nil
+}
+
+// clinicTime returns the current time in the clinic's timezone, which is how the CFDI date
+// is expressed
+func (uc *CFDIUseCase) clinicTime(ctx context.Context, clinicID uuid.UUID) (time.Time, error) {
+	clinic, err := uc.clinicRepo.GetByID(ctx, clinicID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if clinic == nil {
+		return time.Time{}, entities.ErrClinicNotFound
+	}
+
+	timezone := clinic.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid clinic timezone %q: %w", timezone, err)
+	}
+
+	return time.Now().In(loc), nil
+}
+
+func (uc *CFDIUseCase) getCFDI(ctx context.Context, invoiceID, id uuid.UUID) (*entities.CFDI, error) {
+	cfdi, err := uc.cfdiRepo.GetByID(ctx, invoiceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if cfdi == nil {
+		return nil, entities.ErrCFDINotFound
+	}
+	return cfdi, nil
+}
@@ -13,12 +13,14 @@ import (
 // ClinicUseCase handles clinic-related business logic
 type ClinicUseCase struct {
 	clinicRepo repositories.ClinicRepository
+	orgRepo    repositories.OrganizationRepository
 }
 
 // NewClinicUseCase creates a new instance of ClinicUseCase
-func NewClinicUseCase(clinicRepo repositories.ClinicRepository) *ClinicUseCase {
+func NewClinicUseCase(clinicRepo repositories.ClinicRepository, orgRepo repositories.OrganizationRepository) *ClinicUseCase {
 	return &ClinicUseCase{
 		clinicRepo: clinicRepo,
+		orgRepo:    orgRepo,
 	}
 }
 
@@ -29,6 +31,9 @@ func (uc *ClinicUseCase) CreateClinic(ctx context.Context, req *dto.CreateClinic
 	if err := clinic.Validate(); err != nil {
 		return nil, err
 	}
+	if err := uc.normalizePhone(ctx, clinic); err != nil {
+		return nil, err
+	}
 
 	if err := uc.clinicRepo.Create(ctx, clinic); err != nil {
 		return nil, err
@@ -82,6 +87,9 @@ func (uc *ClinicUseCase) UpdateClinic(ctx context.Context, id uuid.UUID, req *dt
 	if err := updated.Validate(); err != nil {
 		return nil, err
 	}
+	if err := uc.normalizePhone(ctx, updated); err != nil {
+		return nil, err
+	}
 
 	if err := uc.clinicRepo.Update(ctx, updated); err != nil {
 		return nil, err
@@ -90,6 +98,15 @@ func (uc *ClinicUseCase) UpdateClinic(ctx context.Context, id uuid.UUID, req *dt
 	return dto.ToClinicResponse(updated), nil
 }
 
+// normalizePhone stores the clinic's phone in E.164 using the organization's default region
+func (uc *ClinicUseCase) normalizePhone(ctx context.Context, clinic *entities.Clinic) error {
+	region, err := organizationPhoneRegion(ctx, uc.orgRepo, clinic.OrganizationID)
+	if err != nil {
+		return err
+	}
+	return clinic.NormalizePhone(region)
+}
+
 // DeleteClinic deletes a clinic by its ID
 func (uc *ClinicUseCase) DeleteClinic(ctx context.Context, id uuid.UUID) error {
 	exists, err := uc.clinicRepo.Exists(ctx, id)
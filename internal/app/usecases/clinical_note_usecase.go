@@ -0,0 +1,365 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// ClinicalNoteUseCase handles SOAP clinical notes and the organization's note templates
+type ClinicalNoteUseCase struct {
+	noteRepo        repositories.ClinicalNoteRepository
+	templateRepo    repositories.ClinicalNoteTemplateRepository
+	appointmentRepo repositories.AppointmentRepository
+	doctorRepo      repositories.DoctorRepository
+	patientRepo     repositories.PatientRepository
+	serviceRepo     repositories.ServiceRepository
+	txManager       repositories.TransactionManager
+}
+
+// NewClinicalNoteUseCase creates a new instance of ClinicalNoteUseCase
+func NewClinicalNoteUseCase(
+	noteRepo repositories.ClinicalNoteRepository,
+	templateRepo repositories.ClinicalNoteTemplateRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	doctorRepo repositories.DoctorRepository,
+	patientRepo repositories.PatientRepository,
+	serviceRepo repositories.ServiceRepository,
+	txManager repositories.TransactionManager,
+) *ClinicalNoteUseCase {
+	return &ClinicalNoteUseCase{
+		noteRepo:        noteRepo,
+		templateRepo:    templateRepo,
+		appointmentRepo: appointmentRepo,
+		doctorRepo:      doctorRepo,
+		patientRepo:     patientRepo,
+		serviceRepo:     serviceRepo,
+		txManager:       txManager,
+	}
+}
+
+// ListTemplates retrieves the organization's note templates
+func (uc *ClinicalNoteUseCase) ListTemplates(ctx context.Context, orgID uuid.UUID) (*dto.ClinicalNoteTemplatesResponse, error) {
+	templates, err := uc.templateRepo.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ClinicalNoteTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = dto.ToClinicalNoteTemplateResponse(template)
+	}
+	return &dto.ClinicalNoteTemplatesResponse{Templates: responses}, nil
+}
+
+// CreateTemplate creates the template of a service, or the organization default
+func (uc *ClinicalNoteUseCase) CreateTemplate(ctx context.Context, orgID uuid.UUID, req *dto.CreateClinicalNoteTemplateRequest) (*dto.ClinicalNoteTemplateResponse, error) {
+	template, err := entities.NewClinicalNoteTemplate(orgID, req.ServiceID, req.Name, req.SOAPNoteInput.ToEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ServiceID != nil {
+		service, err := uc.serviceRepo.GetByID(ctx, orgID, *req.ServiceID)
+		if err != nil {
+			return nil, err
+		}
+		if service == nil {
+			return nil, entities.ErrServiceNotFound
+		}
+	}
+
+	existing, err := uc.templateRepo.GetByService(ctx, orgID, req.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, entities.ErrClinicalNoteTemplateExists
+	}
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return dto.ToClinicalNoteTemplateResponse(template), nil
+}
+
+// UpdateTemplate changes the name and sections of a template; existing notes are unaffected
+func (uc *ClinicalNoteUseCase) UpdateTemplate(ctx context.Context, orgID, templateID uuid.UUID, req *dto.UpdateClinicalNoteTemplateRequest) (*dto.ClinicalNoteTemplateResponse, error) {
+	template, err := uc.templateRepo.GetByID(ctx, orgID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, entities.ErrClinicalNoteTemplateNotFound
+	}
+
+	template.Name = strings.TrimSpace(req.Name)
+	template.SOAPNote = req.SOAPNoteInput.ToEntity()
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return dto.ToClinicalNoteTemplateResponse(template), nil
+}
+
+// DeleteTemplate removes a template; notes started from it keep their content
+func (uc *ClinicalNoteUseCase) DeleteTemplate(ctx context.Context, orgID, templateID uuid.UUID) error {
+	return uc.templateRepo.Delete(ctx, orgID, templateID)
+}
+
+// CreateNote starts the note of an appointment. Only the appointment's treating doctor
+// may write it, and the sections are pre-filled from the selected template.
+func (uc *ClinicalNoteUseCase) CreateNote(ctx context.Context, orgID, userID uuid.UUID, req *dto.CreateClinicalNoteRequest) (*dto.ClinicalNoteResponse, error) {
+	appointment, doctor, err := uc.treatingDoctor(ctx, orgID, userID, req.AppointmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.noteRepo.GetByAppointment(ctx, orgID, appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, entities.ErrClinicalNoteExists
+	}
+
+	template, err := uc.noteTemplate(ctx, orgID, req.TemplateID, appointment.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := entities.NewClinicalNote(orgID, *appointment.PatientID, appointment.ID, doctor.ID, userID, template)
+	if req.Content != nil {
+		note.SOAPNote = req.Content.ToEntity()
+	}
+
+	if err := uc.noteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return dto.ToClinicalNoteResponse(note, nil), nil
+}
+
+// GetNote retrieves a note with its amendments
+func (uc *ClinicalNoteUseCase) GetNote(ctx context.Context, orgID, noteID uuid.UUID) (*dto.ClinicalNoteResponse, error) {
+	note, err := uc.noteRepo.GetByID(ctx, orgID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if note == nil {
+		return nil, entities.ErrClinicalNoteNotFound
+	}
+	return uc.toNoteResponse(ctx, note)
+}
+
+// GetNoteByAppointment retrieves the note of an appointment with its amendments
+func (uc *ClinicalNoteUseCase) GetNoteByAppointment(ctx context.Context, orgID, appointmentID uuid.UUID) (*dto.ClinicalNoteResponse, error) {
+	note, err := uc.noteRepo.GetByAppointment(ctx, orgID, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if note == nil {
+		return nil, entities.ErrClinicalNoteNotFound
+	}
+	return uc.toNoteResponse(ctx, note)
+}
+
+// UpdateNote replaces the sections of a draft note
+func (uc *ClinicalNoteUseCase) UpdateNote(ctx context.Context, orgID, userID, noteID uuid.UUID, req *dto.UpdateClinicalNoteRequest) (*dto.ClinicalNoteResponse, error) {
+	note, err := uc.noteRepo.GetByID(ctx, orgID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if note == nil {
+		return nil, entities.ErrClinicalNoteNotFound
+	}
+
+	if err := note.Edit(userID, req.SOAPNoteInput.ToEntity()); err != nil {
+		return nil, err
+	}
+	if err := uc.noteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return dto.ToClinicalNoteResponse(note, nil), nil
+}
+
+// SignNote signs a draft note, after which it can only be amended
+func (uc *ClinicalNoteUseCase) SignNote(ctx context.Context, orgID, userID, noteID uuid.UUID) (*dto.ClinicalNoteResponse, error) {
+	note, err := uc.noteRepo.GetByID(ctx, orgID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if note == nil {
+		return nil, entities.ErrClinicalNoteNotFound
+	}
+
+	if err := note.Sign(userID); err != nil {
+		return nil, err
+	}
+	if err := uc.noteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return dto.ToClinicalNoteResponse(note, nil), nil
+}
+
+// AmendNote adds an addendum to a signed note; any doctor of the organization may amend
+func (uc *ClinicalNoteUseCase) AmendNote(ctx context.Context, orgID, userID, noteID uuid.UUID, req *dto.AmendClinicalNoteRequest) (*dto.ClinicalNoteResponse, error) {
+	doctor, err := uc.doctorForUser(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var note *entities.ClinicalNote
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		note, err = uc.noteRepo.GetByID(ctx, orgID, noteID)
+		if err != nil {
+			return err
+		}
+		if note == nil {
+			return entities.ErrClinicalNoteNotFound
+		}
+
+		amendment, err := entities.NewClinicalNoteAmendment(note, userID, &doctor.ID, req.Content, req.Reason)
+		if err != nil {
+			return err
+		}
+		return uc.noteRepo.CreateAmendment(ctx, amendment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toNoteResponse(ctx, note)
+}
+
+// GetPatientTimeline retrieves a page of the patient's notes, most recent appointment first
+func (uc *ClinicalNoteUseCase) GetPatientTimeline(ctx context.Context, orgID, patientID uuid.UUID, req *dto.ClinicalNoteTimelineRequest) (*dto.ClinicalNoteTimelineResponse, error) {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+
+	items, total, err := uc.noteRepo.GetPatientTimeline(ctx, repositories.ClinicalNoteTimelineFilters{
+		OrganizationID: orgID,
+		PatientID:      patientID,
+		Page:           page,
+		Limit:          limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]*dto.ClinicalNoteResponse, len(items))
+	for i, item := range items {
+		notes[i] = dto.ToClinicalNoteTimelineItemResponse(item)
+	}
+
+	return &dto.ClinicalNoteTimelineResponse{
+		Notes: notes,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// treatingDoctor resolves the appointment and checks the user is its treating doctor
+func (uc *ClinicalNoteUseCase) treatingDoctor(ctx context.Context, orgID, userID, appointmentID uuid.UUID) (*entities.Appointment, *entities.Doctor, error) {
+	doctor, err := uc.doctorForUser(ctx, orgID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appointment, err := uc.appointmentRepo.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if appointment == nil || appointment.PatientID == nil {
+		return nil, nil, entities.ErrAppointmentNotFound
+	}
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, *appointment.PatientID, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !belongs {
+		return nil, nil, entities.ErrAppointmentNotFound
+	}
+	if appointment.DoctorID == nil || *appointment.DoctorID != doctor.ID {
+		return nil, nil, entities.ErrNotTreatingDoctor
+	}
+
+	return appointment, doctor, nil
+}
+
+// doctorForUser resolves the doctor record linked to the user's account
+func (uc *ClinicalNoteUseCase) doctorForUser(ctx context.Context, orgID, userID uuid.UUID) (*entities.Doctor, error) {
+	doctor, err := uc.doctorRepo.GetByUserID(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if doctor == nil {
+		return nil, entities.ErrUserNotLinkedToDoctor
+	}
+	return doctor, nil
+}
+
+// noteTemplate selects the template of a new note: the requested one, else the service
+// template, else the organization default. A note may also start without a template.
+func (uc *ClinicalNoteUseCase) noteTemplate(ctx context.Context, orgID uuid.UUID, templateID *uuid.UUID, serviceID *string) (*entities.ClinicalNoteTemplate, error) {
+	if templateID != nil {
+		template, err := uc.templateRepo.GetByID(ctx, orgID, *templateID)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			return nil, entities.ErrClinicalNoteTemplateNotFound
+		}
+		return template, nil
+	}
+
+	if serviceID != nil {
+		template, err := uc.templateRepo.GetByService(ctx, orgID, serviceID)
+		if err != nil || template != nil {
+			return template, err
+		}
+	}
+	return uc.templateRepo.GetByService(ctx, orgID, nil)
+}
+
+// toNoteResponse converts a note and loads its amendments
+func (uc *ClinicalNoteUseCase) toNoteResponse(ctx context.Context, note *entities.ClinicalNote) (*dto.ClinicalNoteResponse, error) {
+	amendments, err := uc.noteRepo.GetAmendments(ctx, note.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToClinicalNoteResponse(note, amendments), nil
+}
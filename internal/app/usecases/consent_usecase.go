@@ -0,0 +1,681 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+	"dental-scheduler-backend/internal/infra/logger"
+	"dental-scheduler-backend/pkg/tokens"
+
+	"github.com/google/uuid"
+)
+
+// consentDocumentContentType is the media type of the signed copy of a consent form
+const consentDocumentContentType = "text/html; charset=utf-8"
+
+// ConsentUseCase handles consent templates, consent requests to patients and their signature
+type ConsentUseCase struct {
+	templateRepo     repositories.ConsentTemplateRepository
+	consentRepo      repositories.ConsentFormRepository
+	patientRepo      repositories.PatientRepository
+	relationshipRepo repositories.PatientRelationshipRepository
+	appointmentRepo  repositories.AppointmentRepository
+	doctorRepo       repositories.DoctorRepository
+	serviceRepo      repositories.ServiceRepository
+	unitRepo         repositories.UnitRepository
+	organizationRepo repositories.OrganizationRepository
+	storage          gateways.BlobStorage
+	mailer           gateways.Mailer
+	tokenSecret      []byte
+	linkTTL          time.Duration
+	signingURL       string
+	logger           *logger.Logger
+}
+
+// NewConsentUseCase creates a new instance of ConsentUseCase
+func NewConsentUseCase(
+	templateRepo repositories.ConsentTemplateRepository,
+	consentRepo repositories.ConsentFormRepository,
+	patientRepo repositories.PatientRepository,
+	relationshipRepo repositories.PatientRelationshipRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	doctorRepo repositories.DoctorRepository,
+	serviceRepo repositories.ServiceRepository,
+	unitRepo repositories.UnitRepository,
+	organizationRepo repositories.OrganizationRepository,
+	storage gateways.BlobStorage,
+	mailer gateways.Mailer,
+	tokenSecret string,
+	linkTTL time.Duration,
+	signingURL string,
+	logger *logger.Logger,
+) *ConsentUseCase {
+	return &ConsentUseCase{
+		templateRepo:     templateRepo,
+		consentRepo:      consentRepo,
+		patientRepo:      patientRepo,
+		relationshipRepo: relationshipRepo,
+		appointmentRepo:  appointmentRepo,
+		doctorRepo:       doctorRepo,
+		serviceRepo:      serviceRepo,
+		unitRepo:         unitRepo,
+		organizationRepo: organizationRepo,
+		storage:          storage,
+		mailer:           mailer,
+		tokenSecret:      []byte(tokenSecret),
+		linkTTL:          linkTTL,
+		signingURL:       signingURL,
+		logger:           logger,
+	}
+}
+
+// ListTemplates retrieves the organization's consent templates and the merge fields they may use
+func (uc *ConsentUseCase) ListTemplates(ctx context.Context, orgID uuid.UUID) (*dto.ConsentTemplatesResponse, error) {
+	templates, err := uc.templateRepo.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ConsentTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = dto.ToConsentTemplateResponse(template)
+	}
+	return &dto.ConsentTemplatesResponse{Templates: responses, MergeFields: services.ConsentMergeFields}, nil
+}
+
+// CreateTemplate creates a consent template for a service, or for every service
+func (uc *ConsentUseCase) CreateTemplate(ctx context.Context, orgID uuid.UUID, req *dto.CreateConsentTemplateRequest) (*dto.ConsentTemplateResponse, error) {
+	template, err := entities.NewConsentFormTemplate(orgID, req.ServiceID, req.Name, req.Body, req.Required)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return dto.ToConsentTemplateResponse(template), nil
+}
+
+// UpdateTemplate changes a consent template; forms already requested keep their wording
+func (uc *ConsentUseCase) UpdateTemplate(ctx context.Context, orgID, templateID uuid.UUID, req *dto.UpdateConsentTemplateRequest) (*dto.ConsentTemplateResponse, error) {
+	template, err := uc.templateRepo.GetByID(ctx, orgID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, entities.ErrConsentTemplateNotFound
+	}
+
+	template.ServiceID = req.ServiceID
+	template.Name = strings.TrimSpace(req.Name)
+	template.Body = strings.TrimSpace(req.Body)
+	template.Required = req.Required
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.validateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return dto.ToConsentTemplateResponse(template), nil
+}
+
+// DeleteTemplate removes a consent template; forms requested from it are kept
+func (uc *ConsentUseCase) DeleteTemplate(ctx context.Context, orgID, templateID uuid.UUID) error {
+	return uc.templateRepo.Delete(ctx, orgID, templateID)
+}
+
+// RequestConsent renders a template for a patient and returns the form with its signing
+// link, optionally emailing the link to the patient
+func (uc *ConsentUseCase) RequestConsent(ctx context.Context, orgID, patientID uuid.UUID, requestedBy *uuid.UUID, req *dto.RequestConsentFormRequest) (*dto.ConsentFormResponse, error) {
+	if len(uc.tokenSecret) == 0 {
+		return nil, fmt.Errorf("consent signing secret is not configured")
+	}
+
+	patient, err := uc.getPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := uc.templateRepo.GetByID(ctx, orgID, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, entities.ErrConsentTemplateNotFound
+	}
+
+	var appointment *entities.Appointment
+	if req.AppointmentID != nil {
+		appointment, err = uc.appointmentRepo.GetByID(ctx, *req.AppointmentID)
+		if err != nil {
+			return nil, err
+		}
+		if appointment == nil {
+			return nil, entities.ErrAppointmentNotFound
+		}
+		if appointment.PatientID == nil || *appointment.PatientID != patientID {
+			return nil, entities.ErrConsentAppointmentMismatch
+		}
+	}
+
+	doctorID := req.DoctorID
+	if doctorID == nil && appointment != nil {
+		doctorID = appointment.DoctorID
+	}
+	var doctor *entities.Doctor
+	if doctorID != nil {
+		doctor, err = uc.doctorRepo.GetByID(ctx, *doctorID)
+		if err != nil {
+			return nil, err
+		}
+		if doctor == nil || doctor.OrganizationID != orgID {
+			return nil, entities.ErrDoctorNotFound
+		}
+	}
+
+	serviceID := template.ServiceID
+	if serviceID == nil && appointment != nil {
+		serviceID = appointment.ServiceID
+	}
+
+	values, err := uc.mergeValues(ctx, orgID, patient, doctor, serviceID, appointment)
+	if err != nil {
+		return nil, err
+	}
+
+	form := entities.NewConsentForm(orgID, patientID, template, services.RenderConsentBody(template.Body, values), uc.linkTTL)
+	form.AppointmentID = req.AppointmentID
+	form.DoctorID = doctorID
+	form.ServiceID = serviceID
+	form.RequestedBy = requestedBy
+
+	if err := uc.consentRepo.Create(ctx, form); err != nil {
+		return nil, err
+	}
+
+	link := uc.signingLink(form)
+	if req.SendEmail {
+		// Minors are reached through their guardian
+		contact, err := resolvePatientContact(ctx, uc.relationshipRepo, orgID, patient)
+		if err != nil {
+			return nil, err
+		}
+		if contact.Email != nil {
+			if err := uc.sendSigningEmail(ctx, form, contact, patientFullName(patient), values[services.MergeFieldOrganizationName], link); err != nil {
+				uc.logger.Logger.WithError(err).WithField("consent_form_id", form.ID).Warn("Failed to email consent signing link")
+			}
+		}
+	}
+
+	return dto.ToConsentFormResponse(form, link), nil
+}
+
+// GetConsentForm retrieves a consent form of the organization
+func (uc *ConsentUseCase) GetConsentForm(ctx context.Context, orgID, id uuid.UUID) (*dto.ConsentFormResponse, error) {
+	form, err := uc.getForm(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToConsentFormResponse(form, uc.signingLink(form)), nil
+}
+
+// ListPatientConsentForms retrieves a page of a patient's consent forms, newest first
+func (uc *ConsentUseCase) ListPatientConsentForms(ctx context.Context, orgID, patientID uuid.UUID, req *dto.ConsentFormListRequest) (*dto.ConsentFormListResponse, error) {
+	if _, err := uc.getPatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	filters := repositories.ConsentFormFilters{
+		OrganizationID: orgID,
+		PatientID:      patientID,
+		AppointmentID:  req.AppointmentID,
+	}
+	if req.Status != "" {
+		status := entities.ConsentFormStatus(strings.ToLower(req.Status))
+		if !entities.IsValidConsentFormStatus(status) {
+			return nil, entities.ErrInvalidConsentFormStatus
+		}
+		filters.Status = &status
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+	filters.Page = page
+	filters.Limit = limit
+
+	forms, total, err := uc.consentRepo.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ConsentFormResponse, len(forms))
+	for i, form := range forms {
+		responses[i] = dto.ToConsentFormResponse(form, uc.signingLink(form))
+	}
+
+	return &dto.ConsentFormListResponse{
+		ConsentForms: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// RenewSigningLink extends the signing link of a pending form and returns the new link
+func (uc *ConsentUseCase) RenewSigningLink(ctx context.Context, orgID, id uuid.UUID) (*dto.ConsentFormResponse, error) {
+	if len(uc.tokenSecret) == 0 {
+		return nil, fmt.Errorf("consent signing secret is not configured")
+	}
+
+	form, err := uc.getForm(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := form.Renew(uc.linkTTL, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.consentRepo.Update(ctx, form); err != nil {
+		return nil, err
+	}
+
+	return dto.ToConsentFormResponse(form, uc.signingLink(form)), nil
+}
+
+// RevokeConsentForm cancels a pending request or records the withdrawal of a signed consent
+func (uc *ConsentUseCase) RevokeConsentForm(ctx context.Context, orgID, id uuid.UUID, revokedBy *uuid.UUID, req *dto.RevokeConsentFormRequest) (*dto.ConsentFormResponse, error) {
+	form, err := uc.getForm(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := form.Revoke(revokedBy, req.Reason, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.consentRepo.Update(ctx, form); err != nil {
+		return nil, err
+	}
+
+	return dto.ToConsentFormResponse(form, ""), nil
+}
+
+// OpenDocument opens the signed copy of a consent form; the caller must close its body
+func (uc *ConsentUseCase) OpenDocument(ctx context.Context, orgID, id uuid.UUID) (*dto.AttachmentContent, error) {
+	form, err := uc.getForm(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !form.HasDocument() {
+		return nil, entities.ErrConsentFormNotSigned
+	}
+
+	body, err := uc.storage.Get(ctx, *form.DocumentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AttachmentContent{
+		Body:        body,
+		ContentType: consentDocumentContentType,
+		FileName:    fmt.Sprintf("consent-%s.html", form.ID),
+	}, nil
+}
+
+// GetAppointmentConsents lists the required consents of an appointment and whether each is signed
+func (uc *ConsentUseCase) GetAppointmentConsents(ctx context.Context, orgID, appointmentID uuid.UUID) (*dto.AppointmentConsentsResponse, error) {
+	appointment, err := uc.appointmentRepo.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if appointment == nil || appointment.PatientID == nil {
+		return nil, entities.ErrAppointmentNotFound
+	}
+	if _, err := uc.getPatient(ctx, orgID, *appointment.PatientID); err != nil {
+		if err == entities.ErrPatientNotFound {
+			return nil, entities.ErrAppointmentNotFound
+		}
+		return nil, err
+	}
+
+	requirements, err := appointmentConsentRequirements(ctx, uc.templateRepo, uc.consentRepo, orgID, appointment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AppointmentConsentsResponse{
+		AppointmentID: appointment.ID,
+		Complete:      len(missingConsents(requirements)) == 0,
+		Requirements:  requirements,
+	}, nil
+}
+
+// GetSigningForm retrieves the form a signing link points to, as shown to the patient
+func (uc *ConsentUseCase) GetSigningForm(ctx context.Context, token string) (*dto.PublicConsentFormResponse, error) {
+	form, err := uc.formFromToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if form.Status == entities.ConsentFormStatusRevoked {
+		return nil, entities.ErrConsentFormRevoked
+	}
+
+	organizationName, patientName := uc.formParties(ctx, form)
+	return dto.ToPublicConsentFormResponse(form, organizationName, patientName), nil
+}
+
+// SignConsentForm records the patient's signature and stores the immutable signed copy
+// of the form in the patient's files
+func (uc *ConsentUseCase) SignConsentForm(ctx context.Context, token string, req *dto.SignConsentFormRequest, ip, userAgent string) (*dto.PublicConsentFormResponse, error) {
+	form, err := uc.formFromToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := form.CanBeSigned(now); err != nil {
+		return nil, err
+	}
+	if !req.Agree {
+		return nil, entities.ErrConsentAgreementRequired
+	}
+
+	signature := entities.ConsentSignature{
+		SignerName: req.SignerName,
+		Type:       entities.SignatureType(strings.ToLower(req.SignatureType)),
+		Data:       req.Signature,
+	}
+	if err := form.Sign(signature, ip, userAgent, now); err != nil {
+		return nil, err
+	}
+
+	organizationName, patientName := uc.formParties(ctx, form)
+	document, digest, err := services.RenderConsentDocument(services.ConsentDocument{
+		Form:             form,
+		OrganizationName: organizationName,
+		PatientName:      patientName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render consent form: %w", err)
+	}
+	form.AttachDocument(digest)
+
+	if err := uc.storage.Put(ctx, *form.DocumentKey, bytes.NewReader(document), int64(len(document)), consentDocumentContentType); err != nil {
+		return nil, err
+	}
+	if err := uc.consentRepo.Sign(ctx, form); err != nil {
+		if deleteErr := uc.storage.Delete(ctx, *form.DocumentKey); deleteErr != nil {
+			uc.logger.Logger.WithError(deleteErr).WithField("consent_form_id", form.ID).Warn("Failed to remove unused consent document")
+		}
+		return nil, err
+	}
+
+	return dto.ToPublicConsentFormResponse(form, organizationName, patientName), nil
+}
+
+// validateTemplate checks the merge fields and the service of a template
+func (uc *ConsentUseCase) validateTemplate(ctx context.Context, template *entities.ConsentFormTemplate) error {
+	if err := services.ValidateConsentTemplateBody(template.Body); err != nil {
+		return err
+	}
+	if template.ServiceID != nil {
+		service, err := uc.serviceRepo.GetByID(ctx, template.OrganizationID, *template.ServiceID)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return entities.ErrServiceNotFound
+		}
+	}
+	return nil
+}
+
+// mergeValues gathers the values of the merge fields for a form
+func (uc *ConsentUseCase) mergeValues(ctx context.Context, orgID uuid.UUID, patient *entities.Patient, doctor *entities.Doctor, serviceID *string, appointment *entities.Appointment) (map[string]string, error) {
+	values := map[string]string{
+		services.MergeFieldPatientName:      patientFullName(patient),
+		services.MergeFieldPatientFirstName: patient.FirstName,
+	}
+	if patient.LastName != nil {
+		values[services.MergeFieldPatientLastName] = *patient.LastName
+	}
+	if patient.DateOfBirth != nil {
+		values[services.MergeFieldPatientDateOfBirth] = patient.DateOfBirth.Format("2006-01-02")
+	}
+	if doctor != nil {
+		values[services.MergeFieldDoctorName] = doctor.Name
+	}
+
+	if serviceID != nil {
+		service, err := uc.serviceRepo.GetByID(ctx, orgID, *serviceID)
+		if err != nil {
+			return nil, err
+		}
+		if service != nil {
+			values[services.MergeFieldProcedureName] = service.Name
+		}
+	}
+
+	org, err := uc.organizationRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org != nil {
+		values[services.MergeFieldOrganizationName] = org.Name
+	}
+
+	// Dates are written in the clinic's timezone when the appointment has one
+	location := time.UTC
+	if appointment != nil && appointment.UnitID != nil {
+		if _, clinic, err := uc.unitRepo.GetUnitWithClinic(ctx, *appointment.UnitID); err == nil && clinic != nil && clinic.Timezone != "" {
+			if loc, err := time.LoadLocation(clinic.Timezone); err == nil {
+				location = loc
+			}
+		}
+	}
+	if appointment != nil {
+		values[services.MergeFieldAppointmentDate] = appointment.StartTime.In(location).Format("2006-01-02 15:04")
+	}
+	values[services.MergeFieldToday] = time.Now().In(location).Format("2006-01-02")
+
+	return values, nil
+}
+
+// formFromToken verifies a signing link and loads its form
+func (uc *ConsentUseCase) formFromToken(ctx context.Context, token string) (*entities.ConsentForm, error) {
+	if len(uc.tokenSecret) == 0 {
+		return nil, entities.ErrInvalidConsentToken
+	}
+
+	subject, err := tokens.Verify(uc.tokenSecret, token, time.Now())
+	if err != nil {
+		if err == tokens.ErrExpiredToken {
+			return nil, entities.ErrConsentFormExpired
+		}
+		return nil, entities.ErrInvalidConsentToken
+	}
+
+	formID, err := uuid.Parse(subject)
+	if err != nil {
+		return nil, entities.ErrInvalidConsentToken
+	}
+
+	form, err := uc.consentRepo.GetForSigning(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+	if form == nil {
+		return nil, entities.ErrInvalidConsentToken
+	}
+	return form, nil
+}
+
+// formParties returns the organization and patient names of a form, blank when unavailable
+func (uc *ConsentUseCase) formParties(ctx context.Context, form *entities.ConsentForm) (string, string) {
+	var organizationName, patientName string
+	if org, err := uc.organizationRepo.GetByID(ctx, form.OrganizationID); err == nil && org != nil {
+		organizationName = org.Name
+	}
+	if patient, err := uc.patientRepo.GetByID(ctx, form.PatientID); err == nil && patient != nil {
+		patientName = patientFullName(patient)
+	}
+	return organizationName, patientName
+}
+
+// signingLink returns the link a patient opens to sign the form, or "" when it cannot be signed
+func (uc *ConsentUseCase) signingLink(form *entities.ConsentForm) string {
+	if len(uc.tokenSecret) == 0 || form.CanBeSigned(time.Now()) != nil {
+		return ""
+	}
+	token := tokens.Sign(uc.tokenSecret, form.ID.String(), form.ExpiresAt)
+	return uc.signingURL + "?token=" + url.QueryEscape(token)
+}
+
+// sendSigningEmail emails the signing link of a form to the patient, or to the guardian of a minor
+func (uc *ConsentUseCase) sendSigningEmail(ctx context.Context, form *entities.ConsentForm, contact *entities.PatientContact, patientName, organizationName, link string) error {
+	if organizationName == "" {
+		organizationName = "your clinic"
+	}
+
+	treatment := "your treatment"
+	if contact.Role == entities.ContactRoleGuardian {
+		treatment = patientName + "'s treatment"
+	}
+
+	body := fmt.Sprintf(
+		"%s asks you to review and sign \"%s\" before %s.\n\nRead and sign it here: %s\n\nThis link expires on %s.",
+		organizationName,
+		form.Title,
+		treatment,
+		link,
+		form.ExpiresAt.UTC().Format(time.RFC1123),
+	)
+
+	return uc.mailer.Send(ctx, &gateways.EmailMessage{
+		To:       *contact.Email,
+		Subject:  fmt.Sprintf("Consent form from %s: %s", organizationName, form.Title),
+		TextBody: body,
+	})
+}
+
+// getPatient retrieves a patient of the organization
+func (uc *ConsentUseCase) getPatient(ctx context.Context, orgID, patientID uuid.UUID) (*entities.Patient, error) {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	patient, err := uc.patientRepo.GetByID(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	if patient == nil {
+		return nil, entities.ErrPatientNotFound
+	}
+	return patient, nil
+}
+
+// getForm retrieves a consent form of the organization
+func (uc *ConsentUseCase) getForm(ctx context.Context, orgID, id uuid.UUID) (*entities.ConsentForm, error) {
+	form, err := uc.consentRepo.GetByID(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if form == nil {
+		return nil, entities.ErrConsentFormNotFound
+	}
+	return form, nil
+}
+
+// appointmentConsentRequirements lists the required consents of an appointment's service
+// and whether the patient has signed each one
+func appointmentConsentRequirements(
+	ctx context.Context,
+	templateRepo repositories.ConsentTemplateRepository,
+	consentRepo repositories.ConsentFormRepository,
+	orgID uuid.UUID,
+	appointment *entities.Appointment,
+) ([]*dto.ConsentRequirementResponse, error) {
+	if appointment.PatientID == nil {
+		return nil, nil
+	}
+
+	templates, err := templateRepo.ListRequired(ctx, orgID, appointment.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	requirements := make([]*dto.ConsentRequirementResponse, 0, len(templates))
+	if len(templates) == 0 {
+		return requirements, nil
+	}
+
+	signed, err := consentRepo.ListSigned(ctx, orgID, *appointment.PatientID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates {
+		requirement := &dto.ConsentRequirementResponse{TemplateID: template.ID, TemplateName: template.Name}
+		for _, form := range signed {
+			if form.Covers(template.ID, appointment.ID) {
+				requirement.Signed = true
+				requirement.ConsentFormID = &form.ID
+				break
+			}
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// missingConsents filters the requirements that are not signed yet
+func missingConsents(requirements []*dto.ConsentRequirementResponse) []*dto.ConsentRequirementResponse {
+	var missing []*dto.ConsentRequirementResponse
+	for _, requirement := range requirements {
+		if !requirement.Signed {
+			missing = append(missing, requirement)
+		}
+	}
+	return missing
+}
+
+// patientFullName joins the first and last name of a patient
+func patientFullName(patient *entities.Patient) string {
+	name := patient.FirstName
+	if patient.LastName != nil && *patient.LastName != "" {
+		name += " " + *patient.LastName
+	}
+	return name
+}
@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// DentalChartUseCase handles the patient odontogram
+type DentalChartUseCase struct {
+	chartRepo       repositories.DentalChartRepository
+	patientRepo     repositories.PatientRepository
+	appointmentRepo repositories.AppointmentRepository
+	doctorRepo      repositories.DoctorRepository
+	txManager       repositories.TransactionManager
+}
+
+// NewDentalChartUseCase creates a new instance of DentalChartUseCase
+func NewDentalChartUseCase(
+	chartRepo repositories.DentalChartRepository,
+	patientRepo repositories.PatientRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	doctorRepo repositories.DoctorRepository,
+	txManager repositories.TransactionManager,
+) *DentalChartUseCase {
+	return &DentalChartUseCase{
+		chartRepo:       chartRepo,
+		patientRepo:     patientRepo,
+		appointmentRepo: appointmentRepo,
+		doctorRepo:      doctorRepo,
+		txManager:       txManager,
+	}
+}
+
+// RecordEntries stores charting entries for a patient, optionally linked to the appointment
+// and doctor they were recorded in. All entries are validated before any is stored.
+func (uc *DentalChartUseCase) RecordEntries(ctx context.Context, orgID, patientID uuid.UUID, recordedBy *uuid.UUID, req *dto.RecordDentalChartRequest) (*dto.DentalChartEntriesResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Entries) == 0 {
+		return nil, entities.ErrDentalChartEntriesRequired
+	}
+	if err := uc.ensurePatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	doctorID := req.DoctorID
+	if req.AppointmentID != nil {
+		appointment, err := uc.appointmentRepo.GetByID(ctx, *req.AppointmentID)
+		if err != nil {
+			return nil, err
+		}
+		if appointment == nil {
+			return nil, entities.ErrAppointmentNotFound
+		}
+		if appointment.PatientID == nil || *appointment.PatientID != patientID {
+			return nil, entities.ErrChartAppointmentMismatch
+		}
+		if doctorID == nil {
+			doctorID = appointment.DoctorID
+		}
+	}
+	if doctorID != nil {
+		doctor, err := uc.doctorRepo.GetByID(ctx, *doctorID)
+		if err != nil {
+			return nil, err
+		}
+		if doctor == nil || doctor.OrganizationID != orgID {
+			return nil, entities.ErrDoctorNotFound
+		}
+	}
+
+	recordedAt := time.Now()
+	if req.RecordedAt != nil {
+		recordedAt = *req.RecordedAt
+	}
+
+	entries := make([]*entities.DentalChartEntry, len(req.Entries))
+	for i, input := range req.Entries {
+		tooth, err := entities.ParseTooth(input.Tooth, numbering)
+		if err != nil {
+			return nil, err
+		}
+		surfaces := make([]entities.ToothSurface, len(input.Surfaces))
+		for j, surface := range input.Surfaces {
+			surfaces[j] = entities.ToothSurface(strings.ToUpper(strings.TrimSpace(surface)))
+		}
+
+		entry, err := entities.NewDentalChartEntry(orgID, patientID, tooth, surfaces, entities.ToothCondition(strings.ToLower(input.Condition)), input.Notes, recordedAt)
+		if err != nil {
+			return nil, err
+		}
+		entry.AppointmentID = req.AppointmentID
+		entry.DoctorID = doctorID
+		entry.RecordedBy = recordedBy
+		entries[i] = entry
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, entry := range entries {
+			if err := uc.chartRepo.Create(ctx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDentalChartEntriesResponse(entries, numbering), nil
+}
+
+// GetChart rebuilds the patient's chart as it was at the requested time
+func (uc *DentalChartUseCase) GetChart(ctx context.Context, orgID, patientID uuid.UUID, req *dto.DentalChartRequest) (*dto.DentalChartResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+	asOf, err := parseChartAsOf(req.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.ensurePatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.chartRepo.GetEntries(ctx, repositories.DentalChartEntryFilters{
+		OrganizationID: orgID,
+		PatientID:      patientID,
+		RecordedUntil:  &asOf,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chart := services.BuildDentalChart(entries, asOf)
+	teeth := make([]*dto.ToothStateResponse, len(chart))
+	for i, state := range chart {
+		teeth[i] = dto.ToToothStateResponse(state, numbering)
+	}
+
+	return &dto.DentalChartResponse{
+		PatientID: patientID,
+		AsOf:      asOf,
+		Numbering: string(numbering),
+		Teeth:     teeth,
+	}, nil
+}
+
+// ListEntries retrieves the patient's charting history, optionally for a single tooth
+func (uc *DentalChartUseCase) ListEntries(ctx context.Context, orgID, patientID uuid.UUID, req *dto.DentalChartEntriesRequest) (*dto.DentalChartEntriesResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := repositories.DentalChartEntryFilters{OrganizationID: orgID, PatientID: patientID}
+	if req.Tooth != "" {
+		tooth, err := entities.ParseTooth(req.Tooth, numbering)
+		if err != nil {
+			return nil, err
+		}
+		filters.Tooth = &tooth
+	}
+	if err := uc.ensurePatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.chartRepo.GetEntries(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDentalChartEntriesResponse(entries, numbering), nil
+}
+
+// ensurePatient verifies the patient belongs to the organization
+func (uc *DentalChartUseCase) ensurePatient(ctx context.Context, orgID, patientID uuid.UUID) error {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return entities.ErrPatientNotFound
+	}
+	return nil
+}
+
+// toothNumbering resolves the requested numbering system, FDI by default
+func toothNumbering(value string) (entities.ToothNumberingSystem, error) {
+	if value == "" {
+		return entities.ToothNumberingFDI, nil
+	}
+	numbering := entities.ToothNumberingSystem(strings.ToLower(value))
+	if !entities.IsValidToothNumberingSystem(numbering) {
+		return "", entities.ErrUnsupportedToothNumbering
+	}
+	return numbering, nil
+}
+
+// parseChartAsOf parses the as_of of a chart query; a date means the end of that day (UTC)
+func parseChartAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	if asOf, err := time.Parse(time.RFC3339, value); err == nil {
+		return asOf, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, entities.ErrInvalidDentalChartDate
+	}
+	return date.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// toDentalChartEntriesResponse converts charting entries in the requested numbering
+func toDentalChartEntriesResponse(entries []*entities.DentalChartEntry, numbering entities.ToothNumberingSystem) *dto.DentalChartEntriesResponse {
+	responses := make([]*dto.DentalChartEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = dto.ToDentalChartEntryResponse(entry, numbering)
+	}
+	return &dto.DentalChartEntriesResponse{
+		Numbering: string(numbering),
+		Entries:   responses,
+	}
+}
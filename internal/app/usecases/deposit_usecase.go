@@ -0,0 +1,542 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/google/uuid"
+)
+
+// depositReleaseReason is stored on appointments cancelled because their deposit was not paid in time
+const depositReleaseReason = "Deposit not paid by the deadline"
+
+// DepositUseCase handles the organization's deposit policy, the deposits asked for appointments
+// and their payment through the payment gateway. Deposits required by the policy are asked when
+// an appointment is booked, see AppointmentUseCase.
+type DepositUseCase struct {
+	depositRepo       repositories.DepositRepository
+	appointmentRepo   repositories.AppointmentRepository
+	serviceRepo       repositories.ServiceRepository
+	patientRepo       repositories.PatientRepository
+	unitRepo          repositories.UnitRepository
+	treatmentPlanRepo repositories.TreatmentPlanRepository
+	recallRepo        repositories.RecallRepository
+	relationshipRepo  repositories.PatientRelationshipRepository
+	orgRepo           repositories.OrganizationRepository
+	outboxRepo        repositories.OutboxRepository
+	paymentGateway    gateways.PaymentGateway
+	txManager         repositories.TransactionManager
+	logger            *logger.Logger
+}
+
+// NewDepositUseCase creates a new instance of DepositUseCase
+func NewDepositUseCase(
+	depositRepo repositories.DepositRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	serviceRepo repositories.ServiceRepository,
+	patientRepo repositories.PatientRepository,
+	unitRepo repositories.UnitRepository,
+	treatmentPlanRepo repositories.TreatmentPlanRepository,
+	recallRepo repositories.RecallRepository,
+	relationshipRepo repositories.PatientRelationshipRepository,
+	orgRepo repositories.OrganizationRepository,
+	outboxRepo repositories.OutboxRepository,
+	paymentGateway gateways.PaymentGateway,
+	txManager repositories.TransactionManager,
+	logger *logger.Logger,
+) *DepositUseCase {
+	return &DepositUseCase{
+		depositRepo:       depositRepo,
+		appointmentRepo:   appointmentRepo,
+		serviceRepo:       serviceRepo,
+		patientRepo:       patientRepo,
+		unitRepo:          unitRepo,
+		treatmentPlanRepo: treatmentPlanRepo,
+		recallRepo:        recallRepo,
+		relationshipRepo:  relationshipRepo,
+		orgRepo:           orgRepo,
+		outboxRepo:        outboxRepo,
+		paymentGateway:    paymentGateway,
+		txManager:         txManager,
+		logger:            logger,
+	}
+}
+
+// GetPolicy retrieves the organization's deposit policy; organizations that have not set one
+// get the disabled default
+func (uc *DepositUseCase) GetPolicy(ctx context.Context, orgID uuid.UUID) (*dto.DepositPolicyResponse, error) {
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := uc.getPolicy(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToDepositPolicyResponse(policy, org.Currency), nil
+}
+
+// UpdatePolicy replaces the organization's deposit policy. It applies to bookings from then on;
+// deposits already asked for are not reassessed.
+func (uc *DepositUseCase) UpdatePolicy(ctx context.Context, orgID uuid.UUID, updatedBy *uuid.UUID, req *dto.DepositPolicyRequest) (*dto.DepositPolicyResponse, error) {
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &entities.DepositPolicy{
+		OrganizationID:     orgID,
+		Enabled:            req.Enabled,
+		HoldHours:          req.HoldHours,
+		CutoffHours:        req.CutoffHours,
+		NoShowThreshold:    req.NoShowThreshold,
+		NoShowLookbackDays: req.NoShowLookbackDays,
+		Type:               entities.DepositType(strings.ToLower(strings.TrimSpace(req.Type))),
+		Amount:             req.Amount,
+		Percent:            req.Percent,
+		UpdatedBy:          updatedBy,
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.depositRepo.SavePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return dto.ToDepositPolicyResponse(policy, org.Currency), nil
+}
+
+// SetServiceDeposit sets or clears the deposit required to book one of the organization's services
+func (uc *DepositUseCase) SetServiceDeposit(ctx context.Context, orgID uuid.UUID, serviceID string, req *dto.ServiceDepositRequest) (*dto.ServiceDTO, error) {
+	service, err := uc.serviceRepo.GetByID(ctx, orgID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, entities.ErrServiceNotFound
+	}
+
+	var depositType *entities.DepositType
+	if req.Type != nil && strings.TrimSpace(*req.Type) != "" {
+		value := entities.DepositType(strings.ToLower(strings.TrimSpace(*req.Type)))
+		depositType = &value
+	}
+	if err := service.SetDeposit(depositType, req.Amount, req.Percent); err != nil {
+		return nil, err
+	}
+
+	if err := uc.serviceRepo.UpdateDeposit(ctx, service); err != nil {
+		return nil, err
+	}
+
+	return dto.ToServiceDTO(service), nil
+}
+
+// ListDeposits retrieves a page of the organization's deposits
+func (uc *DepositUseCase) ListDeposits(ctx context.Context, orgID uuid.UUID, req *dto.DepositListRequest) (*dto.DepositListResponse, error) {
+	filters := repositories.DepositFilters{
+		OrganizationID: orgID,
+		ClinicID:       req.ClinicID,
+		PatientID:      req.PatientID,
+	}
+	if req.Status != "" {
+		status := entities.DepositStatus(strings.ToLower(req.Status))
+		if !entities.IsValidDepositStatus(status) {
+			return nil, entities.ErrInvalidDepositStatus
+		}
+		filters.Status = &status
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+	filters.Page = page
+	filters.Limit = limit
+
+	deposits, total, err := uc.depositRepo.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.DepositResponse, len(deposits))
+	for i, deposit := range deposits {
+		responses[i] = dto.ToDepositResponse(deposit)
+	}
+
+	return &dto.DepositListResponse{
+		Deposits: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// GetDeposit retrieves a deposit of the organization
+func (uc *DepositUseCase) GetDeposit(ctx context.Context, orgID, depositID uuid.UUID) (*dto.DepositResponse, error) {
+	deposit, err := uc.depositRepo.GetByID(ctx, orgID, depositID)
+	if err != nil {
+		return nil, err
+	}
+	if deposit == nil {
+		return nil, entities.ErrDepositNotFound
+	}
+
+	return dto.ToDepositResponse(deposit), nil
+}
+
+// GetAppointmentDeposit retrieves the pending or paid deposit of an appointment
+func (uc *DepositUseCase) GetAppointmentDeposit(ctx context.Context, orgID, appointmentID uuid.UUID) (*dto.DepositResponse, error) {
+	deposit, err := uc.depositRepo.GetActiveByAppointment(ctx, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if deposit == nil || deposit.OrganizationID != orgID {
+		return nil, entities.ErrDepositNotFound
+	}
+
+	return dto.ToDepositResponse(deposit), nil
+}
+
+// RequestDeposit asks for a deposit on an upcoming appointment that the policy did not require
+// one for. The appointment is held like any other until the deposit is paid or waived.
+func (uc *DepositUseCase) RequestDeposit(ctx context.Context, orgID, appointmentID uuid.UUID, requestedBy *uuid.UUID, req *dto.RequestDepositRequest) (*dto.DepositResponse, error) {
+	appointment, clinic, err := uc.getAppointment(ctx, orgID, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if appointment.PatientID == nil || !appointment.StartTime.After(now) ||
+		appointment.Status != entities.AppointmentStatusScheduled {
+		return nil, entities.ErrDepositNotAllowed
+	}
+
+	active, err := uc.depositRepo.GetActiveByAppointment(ctx, appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, entities.ErrDepositAlreadyRequested
+	}
+
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dueAt time.Time
+	if req.DueAt != nil {
+		dueAt = *req.DueAt
+		if !dueAt.After(now) || dueAt.After(appointment.StartTime) {
+			return nil, entities.ErrInvalidDepositDueDate
+		}
+	} else {
+		policy, err := uc.getPolicy(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		dueAt = services.DepositDueAt(policy, now, appointment.StartTime)
+	}
+
+	deposit, err := entities.NewDeposit(orgID, clinic.ID, *appointment.PatientID, appointment.ID, entities.DepositReasonManual, org.Currency, req.Amount, dueAt, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := openDepositPaymentLink(ctx, uc.paymentGateway, deposit, appointment); err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.depositRepo.Create(ctx, deposit); err != nil {
+			return err
+		}
+		return uc.appointmentRepo.UpdateDepositStatus(ctx, appointment.ID, &deposit.Status)
+	})
+	if err != nil {
+		expirePaymentLink(ctx, uc.paymentGateway, uc.logger, deposit)
+		return nil, err
+	}
+
+	return dto.ToDepositResponse(deposit), nil
+}
+
+// WaiveDeposit lets the patient keep an appointment without paying its pending deposit
+func (uc *DepositUseCase) WaiveDeposit(ctx context.Context, orgID, depositID uuid.UUID, waivedBy *uuid.UUID, req *dto.WaiveDepositRequest) (*dto.DepositResponse, error) {
+	var deposit *entities.Deposit
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if deposit, err = uc.getDepositForUpdate(ctx, orgID, depositID); err != nil {
+			return err
+		}
+		if err := deposit.Waive(req.Reason, waivedBy, time.Now()); err != nil {
+			return err
+		}
+		if err := uc.depositRepo.Update(ctx, deposit); err != nil {
+			return err
+		}
+		return uc.appointmentRepo.UpdateDepositStatus(ctx, deposit.AppointmentID, &deposit.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expirePaymentLink(ctx, uc.paymentGateway, uc.logger, deposit)
+	return dto.ToDepositResponse(deposit), nil
+}
+
+// HandlePaymentNotification records the outcome of a deposit payment reported by the payment
+// gateway. Notifications are delivered at least once, so a payment already recorded is ignored.
+func (uc *DepositUseCase) HandlePaymentNotification(ctx context.Context, payload []byte, signature string) error {
+	notification, err := uc.paymentGateway.ParseNotification(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		deposit, err := uc.depositRepo.GetByPaymentLinkForUpdate(ctx, notification.LinkID)
+		if err != nil {
+			return err
+		}
+		if deposit == nil {
+			return entities.ErrDepositNotFound
+		}
+
+		if notification.Type != gateways.PaymentSucceeded {
+			// The patient can retry on the same link until the deposit is due
+			uc.logger.Logger.WithField("deposit_id", deposit.ID).WithField("notification_id", notification.ID).Info("Deposit payment failed")
+			return nil
+		}
+		if deposit.Status == entities.DepositStatusPaid {
+			return nil
+		}
+		if notification.Amount != deposit.Amount || !strings.EqualFold(notification.Currency, deposit.Currency) {
+			return entities.ErrPaymentNotificationMismatch
+		}
+
+		wasPending := deposit.Status == entities.DepositStatusPending
+		if err := deposit.MarkPaid(notification.PaymentID, notification.OccurredAt, time.Now()); err != nil {
+			return err
+		}
+		if err := uc.depositRepo.Update(ctx, deposit); err != nil {
+			return err
+		}
+		if !wasPending {
+			// Paid after the hold was released: recorded for staff to refund or rebook, the
+			// appointment keeps the status of the deposit that released it
+			uc.logger.Logger.WithField("deposit_id", deposit.ID).Warn("Deposit paid after it was released")
+			return nil
+		}
+		return uc.appointmentRepo.UpdateDepositStatus(ctx, deposit.AppointmentID, &deposit.Status)
+	})
+}
+
+// ReleaseExpiredHolds expires up to limit pending deposits that were not paid by their due date
+// and cancels their appointments, freeing the held slots. It returns how many were released.
+func (uc *DepositUseCase) ReleaseExpiredHolds(ctx context.Context, now time.Time, limit int) (int, error) {
+	deposits, err := uc.depositRepo.ListOverdue(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, deposit := range deposits {
+		ok, err := uc.releaseHold(ctx, deposit.OrganizationID, deposit.ID, now)
+		if err != nil {
+			uc.logger.Logger.WithError(err).WithField("deposit_id", deposit.ID).Error("Failed to release unpaid deposit hold")
+			continue
+		}
+		if ok {
+			released++
+		}
+	}
+	return released, nil
+}
+
+// releaseHold expires a deposit that is still overdue and cancels its appointment if it has
+// not been carried out, raising AppointmentCancelled in the same transaction
+func (uc *DepositUseCase) releaseHold(ctx context.Context, orgID, depositID uuid.UUID, now time.Time) (bool, error) {
+	var deposit *entities.Deposit
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		deposit, err = uc.depositRepo.GetForUpdate(ctx, orgID, depositID)
+		if err != nil {
+			return err
+		}
+		if deposit == nil || !deposit.IsOverdue(now) {
+			// Paid or waived since it was listed
+			deposit = nil
+			return nil
+		}
+		if err := deposit.Expire(now); err != nil {
+			return err
+		}
+		if err := uc.depositRepo.Update(ctx, deposit); err != nil {
+			return err
+		}
+
+		appointment, err := uc.appointmentRepo.GetByID(ctx, deposit.AppointmentID)
+		if err != nil {
+			return err
+		}
+		if appointment == nil {
+			return nil
+		}
+		// Only a booking still held for the deposit is released; a rescheduled original has
+		// already been replaced and its deposit moved to the replacement
+		if appointment.Status != entities.AppointmentStatusScheduled {
+			return uc.appointmentRepo.UpdateDepositStatus(ctx, appointment.ID, &deposit.Status)
+		}
+
+		appointment.CancelWithReason(nil, depositReleaseReason)
+		appointment.DepositStatus = &deposit.Status
+		if err := uc.appointmentRepo.Update(ctx, appointment); err != nil {
+			return err
+		}
+		if err := syncTreatmentPlanItem(ctx, uc.treatmentPlanRepo, appointment); err != nil {
+			return err
+		}
+		if err := reopenRecalls(ctx, uc.recallRepo, appointment); err != nil {
+			return err
+		}
+		contact, err := appointmentEventContact(ctx, uc.patientRepo, uc.relationshipRepo, orgID, appointment)
+		if err != nil {
+			return err
+		}
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventAppointmentCancelled, entities.AggregateAppointment, appointment.ID, &dto.AppointmentEventData{
+			Appointment: dto.ToAppointmentResponse(appointment),
+			Contact:     contact,
+		})
+	})
+	if err != nil || deposit == nil {
+		return false, err
+	}
+
+	expirePaymentLink(ctx, uc.paymentGateway, uc.logger, deposit)
+	return true, nil
+}
+
+// getAppointment retrieves an appointment of the organization with its clinic
+func (uc *DepositUseCase) getAppointment(ctx context.Context, orgID, appointmentID uuid.UUID) (*entities.Appointment, *entities.Clinic, error) {
+	appointment, err := uc.appointmentRepo.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if appointment == nil || appointment.UnitID == nil {
+		return nil, nil, entities.ErrAppointmentNotFound
+	}
+
+	_, clinic, err := uc.unitRepo.GetUnitWithClinic(ctx, *appointment.UnitID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if clinic == nil || clinic.OrganizationID != orgID {
+		return nil, nil, entities.ErrAppointmentNotFound
+	}
+	return appointment, clinic, nil
+}
+
+// getPolicy retrieves the organization's deposit policy, or the disabled default
+func (uc *DepositUseCase) getPolicy(ctx context.Context, orgID uuid.UUID) (*entities.DepositPolicy, error) {
+	policy, err := uc.depositRepo.GetPolicy(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = entities.DefaultDepositPolicy(orgID)
+	}
+	return policy, nil
+}
+
+func (uc *DepositUseCase) getDepositForUpdate(ctx context.Context, orgID, depositID uuid.UUID) (*entities.Deposit, error) {
+	deposit, err := uc.depositRepo.GetForUpdate(ctx, orgID, depositID)
+	if err != nil {
+		return nil, err
+	}
+	if deposit == nil {
+		return nil, entities.ErrDepositNotFound
+	}
+	return deposit, nil
+}
+
+func (uc *DepositUseCase) getOrganization(ctx context.Context, orgID uuid.UUID) (*entities.Organization, error) {
+	org, err := uc.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, entities.ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+// openDepositPaymentLink creates the gateway link the patient pays a deposit through. It is
+// called before the deposit is stored, so callers expire the link if storing it fails.
+func openDepositPaymentLink(ctx context.Context, gateway gateways.PaymentGateway, deposit *entities.Deposit, appointment *entities.Appointment) error {
+	link, err := gateway.CreatePaymentLink(ctx, &gateways.PaymentLinkRequest{
+		Reference:   deposit.ID,
+		Amount:      deposit.Amount,
+		Currency:    deposit.Currency,
+		Description: "Deposit for the appointment of " + appointment.StartTime.UTC().Format("2006-01-02 15:04 UTC"),
+		ExpiresAt:   deposit.DueAt,
+	})
+	if err != nil {
+		return err
+	}
+	deposit.AttachPaymentLink(link.ID, link.URL)
+	return nil
+}
+
+// releaseAppointmentDeposit cancels the pending deposit of an appointment that is being
+// cancelled and records it on the appointment, which the caller saves. It returns the deposit so
+// its payment link can be expired once the transaction commits, nil when there was none.
+func releaseAppointmentDeposit(ctx context.Context, depositRepo repositories.DepositRepository, appointment *entities.Appointment, now time.Time) (*entities.Deposit, error) {
+	if !appointment.AwaitsDeposit() {
+		return nil, nil
+	}
+	deposit, err := depositRepo.GetActiveByAppointment(ctx, appointment.ID)
+	if err != nil || deposit == nil || deposit.Status != entities.DepositStatusPending {
+		return nil, err
+	}
+
+	if err := deposit.Cancel(now); err != nil {
+		return nil, err
+	}
+	if err := depositRepo.Update(ctx, deposit); err != nil {
+		return nil, err
+	}
+	appointment.DepositStatus = &deposit.Status
+	return deposit, nil
+}
+
+// expirePaymentLink closes the payment link of a deposit that can no longer be paid. Failures are
+// only logged: a payment that still comes through is recorded on the deposit for staff to refund.
+func expirePaymentLink(ctx context.Context, gateway gateways.PaymentGateway, logger *logger.Logger, deposit *entities.Deposit) {
+	if deposit == nil || deposit.PaymentLinkID == nil {
+		return
+	}
+	if err := gateway.ExpirePaymentLink(ctx, *deposit.PaymentLinkID); err != nil {
+		logger.Logger.WithError(err).WithField("deposit_id", deposit.ID).Warn("Failed to expire deposit payment link")
+	}
+}
@@ -16,6 +16,7 @@ type DoctorUseCase struct {
 	doctorRepo      repositories.DoctorRepository
 	unitRepo        repositories.UnitRepository
 	appointmentRepo repositories.AppointmentRepository
+	orgRepo         repositories.OrganizationRepository
 }
 
 // NewDoctorUseCase creates a new instance of DoctorUseCase
@@ -23,11 +24,13 @@ func NewDoctorUseCase(
 	doctorRepo repositories.DoctorRepository,
 	unitRepo repositories.UnitRepository,
 	appointmentRepo repositories.AppointmentRepository,
+	orgRepo repositories.OrganizationRepository,
 ) *DoctorUseCase {
 	return &DoctorUseCase{
 		doctorRepo:      doctorRepo,
 		unitRepo:        unitRepo,
 		appointmentRepo: appointmentRepo,
+		orgRepo:         orgRepo,
 	}
 }
 
@@ -49,6 +52,9 @@ func (uc *DoctorUseCase) CreateDoctor(ctx context.Context, req *dto.CreateDoctor
 	if err := doctor.Validate(); err != nil {
 		return nil, err
 	}
+	if err := uc.normalizePhone(ctx, doctor); err != nil {
+		return nil, err
+	}
 
 	if err := uc.doctorRepo.Create(ctx, doctor); err != nil {
 		return nil, err
@@ -137,6 +143,9 @@ func (uc *DoctorUseCase) UpdateDoctor(ctx context.Context, id uuid.UUID, req *dt
 	if err := updated.Validate(); err != nil {
 		return nil, err
 	}
+	if err := uc.normalizePhone(ctx, updated); err != nil {
+		return nil, err
+	}
 
 	if err := uc.doctorRepo.Update(ctx, updated); err != nil {
 		return nil, err
@@ -145,6 +154,15 @@ func (uc *DoctorUseCase) UpdateDoctor(ctx context.Context, id uuid.UUID, req *dt
 	return dto.ToDoctorResponse(updated), nil
 }
 
+// normalizePhone stores the doctor's phone in E.164 using the organization's default region
+func (uc *DoctorUseCase) normalizePhone(ctx context.Context, doctor *entities.Doctor) error {
+	region, err := organizationPhoneRegion(ctx, uc.orgRepo, doctor.OrganizationID)
+	if err != nil {
+		return err
+	}
+	return doctor.NormalizePhone(region)
+}
+
 // DeleteDoctor deletes a doctor by its ID
 func (uc *DoctorUseCase) DeleteDoctor(ctx context.Context, id uuid.UUID) error {
 	exists, err := uc.doctorRepo.Exists(ctx, id)
@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// raiseEvent records a domain event in the outbox. ctx must carry the transaction
+// that persists the change so the event is stored if and only if the change is.
+func raiseEvent(
+	ctx context.Context,
+	outbox repositories.OutboxRepository,
+	orgID uuid.UUID,
+	eventType entities.DomainEventType,
+	aggregateType string,
+	aggregateID uuid.UUID,
+	data interface{},
+) error {
+	event, err := entities.NewDomainEvent(orgID, eventType, aggregateType, aggregateID, data)
+	if err != nil {
+		return err
+	}
+
+	return outbox.Append(ctx, event)
+}
@@ -13,18 +13,21 @@ import (
 
 // GetOrganizationDataUseCase handles getting complete organization data for calendar loading
 type GetOrganizationDataUseCase struct {
-	orgRepo repositories.OrganizationRepository
+	orgRepo   repositories.OrganizationRepository
+	alertRepo repositories.MedicalAlertRepository
 }
 
 // NewGetOrganizationDataUseCase creates a new instance of GetOrganizationDataUseCase
-func NewGetOrganizationDataUseCase(orgRepo repositories.OrganizationRepository) *GetOrganizationDataUseCase {
+func NewGetOrganizationDataUseCase(orgRepo repositories.OrganizationRepository, alertRepo repositories.MedicalAlertRepository) *GetOrganizationDataUseCase {
 	return &GetOrganizationDataUseCase{
-		orgRepo: orgRepo,
+		orgRepo:   orgRepo,
+		alertRepo: alertRepo,
 	}
 }
 
-// Execute retrieves complete organization data for calendar view
-func (uc *GetOrganizationDataUseCase) Execute(ctx context.Context, orgID uuid.UUID, req *dto.OrganizationDataRequest) (*dto.OrganizationDataResponse, error) {
+// Execute retrieves complete organization data for calendar view. Medical alerts are only
+// attached when includeMedicalAlerts is set, that is for staff sessions.
+func (uc *GetOrganizationDataUseCase) Execute(ctx context.Context, orgID uuid.UUID, req *dto.OrganizationDataRequest, includeMedicalAlerts bool) (*dto.OrganizationDataResponse, error) {
 	// Validate and parse dates
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
@@ -61,6 +64,13 @@ func (uc *GetOrganizationDataUseCase) Execute(ctx context.Context, orgID uuid.UU
 		return nil, fmt.Errorf("failed to get organization data: %w", err)
 	}
 
+	// Flag the patients' medical alerts so they are seen before the visit
+	if includeMedicalAlerts {
+		if err := uc.attachMedicalAlerts(ctx, orgID, orgData.Appointments); err != nil {
+			return nil, fmt.Errorf("failed to get medical alerts: %w", err)
+		}
+	}
+
 	// Convert to DTOs
 	response := &dto.OrganizationDataResponse{
 		Organization: dto.ToOrganizationDTO(orgData.Organization),
@@ -73,3 +83,26 @@ func (uc *GetOrganizationDataUseCase) Execute(ctx context.Context, orgID uuid.UU
 
 	return response, nil
 }
+
+// attachMedicalAlerts sets the active medical alerts of each appointment's patient
+func (uc *GetOrganizationDataUseCase) attachMedicalAlerts(ctx context.Context, orgID uuid.UUID, appointments []*repositories.AppointmentCalendarData) error {
+	seen := make(map[uuid.UUID]bool)
+	var patientIDs []uuid.UUID
+	for _, appt := range appointments {
+		if appt.PatientID != nil && !seen[*appt.PatientID] {
+			seen[*appt.PatientID] = true
+			patientIDs = append(patientIDs, *appt.PatientID)
+		}
+	}
+
+	alerts, err := uc.alertRepo.ListActiveByPatients(ctx, orgID, patientIDs)
+	if err != nil {
+		return err
+	}
+	for _, appt := range appointments {
+		if appt.PatientID != nil {
+			appt.MedicalAlerts = alerts[*appt.PatientID]
+		}
+	}
+	return nil
+}
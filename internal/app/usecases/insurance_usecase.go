@@ -0,0 +1,741 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// InsuranceUseCase handles insurance carriers, patient policies, coverage estimates and claims
+type InsuranceUseCase struct {
+	insuranceRepo   repositories.InsuranceRepository
+	patientRepo     repositories.PatientRepository
+	appointmentRepo repositories.AppointmentRepository
+	unitRepo        repositories.UnitRepository
+	serviceRepo     repositories.ServiceRepository
+	planRepo        repositories.TreatmentPlanRepository
+	invoiceRepo     repositories.InvoiceRepository
+	orgRepo         repositories.OrganizationRepository
+	txManager       repositories.TransactionManager
+}
+
+// NewInsuranceUseCase creates a new instance of InsuranceUseCase
+func NewInsuranceUseCase(
+	insuranceRepo repositories.InsuranceRepository,
+	patientRepo repositories.PatientRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	unitRepo repositories.UnitRepository,
+	serviceRepo repositories.ServiceRepository,
+	planRepo repositories.TreatmentPlanRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	orgRepo repositories.OrganizationRepository,
+	txManager repositories.TransactionManager,
+) *InsuranceUseCase {
+	return &InsuranceUseCase{
+		insuranceRepo:   insuranceRepo,
+		patientRepo:     patientRepo,
+		appointmentRepo: appointmentRepo,
+		unitRepo:        unitRepo,
+		serviceRepo:     serviceRepo,
+		planRepo:        planRepo,
+		invoiceRepo:     invoiceRepo,
+		orgRepo:         orgRepo,
+		txManager:       txManager,
+	}
+}
+
+// CreateCarrier adds an insurance carrier to the organization
+func (uc *InsuranceUseCase) CreateCarrier(ctx context.Context, orgID uuid.UUID, req *dto.InsuranceCarrierRequest) (*dto.InsuranceCarrierResponse, error) {
+	carrier, err := entities.NewInsuranceCarrier(orgID, req.Name, optionalText(req.PayerID), optionalText(req.Phone), optionalText(req.Email), optionalText(req.Notes))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.insuranceRepo.CreateCarrier(ctx, carrier); err != nil {
+		return nil, err
+	}
+
+	return dto.ToInsuranceCarrierResponse(carrier), nil
+}
+
+// ListCarriers retrieves the organization's carriers
+func (uc *InsuranceUseCase) ListCarriers(ctx context.Context, orgID uuid.UUID, req *dto.InsuranceCarrierListRequest) ([]*dto.InsuranceCarrierResponse, error) {
+	carriers, err := uc.insuranceRepo.ListCarriers(ctx, orgID, req.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.InsuranceCarrierResponse, len(carriers))
+	for i, carrier := range carriers {
+		responses[i] = dto.ToInsuranceCarrierResponse(carrier)
+	}
+	return responses, nil
+}
+
+// UpdateCarrier changes the details of a carrier; deactivating it keeps its policies but
+// prevents new ones
+func (uc *InsuranceUseCase) UpdateCarrier(ctx context.Context, orgID, carrierID uuid.UUID, req *dto.InsuranceCarrierRequest) (*dto.InsuranceCarrierResponse, error) {
+	carrier, err := uc.getCarrier(ctx, orgID, carrierID)
+	if err != nil {
+		return nil, err
+	}
+
+	carrier.Name = strings.TrimSpace(req.Name)
+	carrier.PayerID = optionalText(req.PayerID)
+	carrier.Phone = optionalText(req.Phone)
+	carrier.Email = optionalText(req.Email)
+	carrier.Notes = optionalText(req.Notes)
+	if req.Active != nil {
+		carrier.Active = *req.Active
+	}
+	if err := carrier.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.insuranceRepo.UpdateCarrier(ctx, carrier); err != nil {
+		return nil, err
+	}
+
+	return dto.ToInsuranceCarrierResponse(carrier), nil
+}
+
+// CreatePolicy records a patient's policy with an active carrier
+func (uc *InsuranceUseCase) CreatePolicy(ctx context.Context, orgID, patientID uuid.UUID, createdBy *uuid.UUID, req *dto.InsurancePolicyRequest) (*dto.InsurancePolicyResponse, error) {
+	if err := uc.checkPatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+	carrier, err := uc.getCarrier(ctx, orgID, req.CarrierID)
+	if err != nil {
+		return nil, err
+	}
+	if !carrier.Active {
+		return nil, entities.ErrInsuranceCarrierInactive
+	}
+
+	coverage, from, to, err := policyTerms(req)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := entities.NewInsurancePolicy(orgID, patientID, carrier.ID, req.MemberID, coverage, req.AnnualMaximum, req.Deductible, from, to, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	policy.GroupNumber = optionalText(req.GroupNumber)
+	policy.SubscriberName = optionalText(req.SubscriberName)
+
+	if err := uc.insuranceRepo.CreatePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return dto.ToInsurancePolicyResponse(policy), nil
+}
+
+// ListPatientPolicies retrieves a patient's policies, most recent first
+func (uc *InsuranceUseCase) ListPatientPolicies(ctx context.Context, orgID, patientID uuid.UUID) ([]*dto.InsurancePolicyResponse, error) {
+	if err := uc.checkPatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	policies, err := uc.insuranceRepo.ListPoliciesByPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.InsurancePolicyResponse, len(policies))
+	for i, policy := range policies {
+		responses[i] = dto.ToInsurancePolicyResponse(policy)
+	}
+	return responses, nil
+}
+
+// GetPolicy retrieves a policy
+func (uc *InsuranceUseCase) GetPolicy(ctx context.Context, orgID, policyID uuid.UUID) (*dto.InsurancePolicyResponse, error) {
+	policy, err := uc.getPolicy(ctx, orgID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToInsurancePolicyResponse(policy), nil
+}
+
+// UpdatePolicy replaces the terms of a policy. Claims already submitted keep the amounts
+// estimated when they were made.
+func (uc *InsuranceUseCase) UpdatePolicy(ctx context.Context, orgID, policyID uuid.UUID, req *dto.InsurancePolicyRequest) (*dto.InsurancePolicyResponse, error) {
+	policy, err := uc.getPolicy(ctx, orgID, policyID)
+	if err != nil {
+		return nil, err
+	}
+	if req.CarrierID != policy.CarrierID {
+		carrier, err := uc.getCarrier(ctx, orgID, req.CarrierID)
+		if err != nil {
+			return nil, err
+		}
+		if !carrier.Active {
+			return nil, entities.ErrInsuranceCarrierInactive
+		}
+	}
+
+	coverage, from, to, err := policyTerms(req)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.CarrierID = req.CarrierID
+	policy.MemberID = strings.TrimSpace(req.MemberID)
+	policy.GroupNumber = optionalText(req.GroupNumber)
+	policy.SubscriberName = optionalText(req.SubscriberName)
+	policy.Coverage = coverage
+	policy.AnnualMaximum = req.AnnualMaximum
+	policy.Deductible = req.Deductible
+	policy.EffectiveFrom = from
+	policy.EffectiveTo = to
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.insuranceRepo.UpdatePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return dto.ToInsurancePolicyResponse(policy), nil
+}
+
+// DeletePolicy removes a policy entered by mistake; policies with claims are ended instead
+func (uc *InsuranceUseCase) DeletePolicy(ctx context.Context, orgID, policyID uuid.UUID) error {
+	policy, err := uc.getPolicy(ctx, orgID, policyID)
+	if err != nil {
+		return err
+	}
+
+	hasClaims, err := uc.insuranceRepo.PolicyHasClaims(ctx, policy.ID)
+	if err != nil {
+		return err
+	}
+	if hasClaims {
+		return entities.ErrPolicyHasClaims
+	}
+
+	return uc.insuranceRepo.DeletePolicy(ctx, orgID, policy.ID)
+}
+
+// EstimateCoverage splits a service, a list of services or the pending items of a treatment
+// plan between the carrier and the patient, taking into account the deductible and annual
+// maximum already used in the benefit year
+func (uc *InsuranceUseCase) EstimateCoverage(ctx context.Context, orgID, policyID uuid.UUID, req *dto.CoverageEstimateRequest) (*dto.CoverageEstimateResponse, error) {
+	policy, err := uc.getPolicy(ctx, orgID, policyID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	date := time.Now().UTC()
+	if req.Date != nil {
+		parsed, err := parseInsuranceDate(*req.Date)
+		if err != nil {
+			return nil, err
+		}
+		if parsed != nil {
+			date = *parsed
+		}
+	}
+	if !policy.CoversDate(date) {
+		return nil, entities.ErrPolicyNotInEffect
+	}
+
+	var items []services.CoverageItem
+	if req.ServiceID != nil {
+		item, err := uc.serviceItem(ctx, orgID, *req.ServiceID, req.Amount, org.Currency)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	for _, input := range req.Items {
+		item, err := uc.serviceItem(ctx, orgID, input.ServiceID, input.Amount, org.Currency)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if req.TreatmentPlanID != nil {
+		planItems, err := uc.treatmentPlanItems(ctx, orgID, policy.PatientID, *req.TreatmentPlanID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, planItems...)
+	}
+	if len(items) == 0 {
+		return nil, entities.ErrEstimateItemsRequired
+	}
+
+	usage, err := uc.benefitUsage(ctx, policy.ID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := services.EstimateCoverage(policy, usage, items)
+	return dto.ToCoverageEstimateResponse(policy.ID, org.Currency, date.Year(), estimate), nil
+}
+
+// CreateClaim submits a claim for a completed appointment of the policy's patient. The
+// billed amount defaults to the appointment's invoice, and the carrier's portion is
+// estimated from the invoice lines or else the appointment's service.
+func (uc *InsuranceUseCase) CreateClaim(ctx context.Context, orgID uuid.UUID, createdBy *uuid.UUID, req *dto.CreateInsuranceClaimRequest) (*dto.InsuranceClaimResponse, error) {
+	policy, err := uc.getPolicy(ctx, orgID, req.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment, err := uc.appointmentRepo.GetByID(ctx, req.AppointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if appointment == nil || appointment.PatientID == nil || appointment.UnitID == nil {
+		return nil, entities.ErrAppointmentNotFound
+	}
+	_, clinic, err := uc.unitRepo.GetUnitWithClinic(ctx, *appointment.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	if clinic == nil || clinic.OrganizationID != orgID {
+		return nil, entities.ErrAppointmentNotFound
+	}
+	if *appointment.PatientID != policy.PatientID {
+		return nil, entities.ErrClaimPatientMismatch
+	}
+	if appointment.Status != entities.AppointmentStatusCompleted {
+		return nil, entities.ErrAppointmentNotClaimable
+	}
+
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone := clinic.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic timezone %q: %w", timezone, err)
+	}
+	start := appointment.StartTime.In(loc)
+	serviceDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	invoice, err := uc.invoiceRepo.GetActiveByAppointment(ctx, appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []services.CoverageItem
+	var invoiceID *uuid.UUID
+	if invoice != nil {
+		invoiceID = &invoice.ID
+	}
+	switch {
+	case invoice != nil && req.BilledAmount == nil:
+		for _, line := range invoice.Lines {
+			category, err := uc.serviceCategory(ctx, orgID, line.ServiceID)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, services.CoverageItem{
+				Description: line.Description,
+				Category:    category,
+				Amount:      line.Total,
+			})
+		}
+	case req.BilledAmount != nil:
+		category, err := uc.serviceCategory(ctx, orgID, appointment.ServiceID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, services.CoverageItem{Category: category, Amount: *req.BilledAmount})
+	default:
+		return nil, entities.ErrClaimAmountRequired
+	}
+
+	var billed int64
+	for _, item := range items {
+		billed += item.Amount
+	}
+
+	var claim *entities.InsuranceClaim
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := uc.insuranceRepo.GetOpenClaim(ctx, policy.ID, appointment.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return entities.ErrAppointmentAlreadyClaimed
+		}
+
+		usage, err := uc.benefitUsage(ctx, policy.ID, serviceDate)
+		if err != nil {
+			return err
+		}
+		estimate := services.EstimateCoverage(policy, usage, items)
+
+		claim, err = entities.NewInsuranceClaim(policy, appointment.ID, invoiceID, org.Currency, billed, estimate.InsurerPortion, estimate.DeductibleApplied, serviceDate, optionalText(req.ClaimNumber), optionalText(req.Notes), createdBy)
+		if err != nil {
+			return err
+		}
+
+		return uc.insuranceRepo.CreateClaim(ctx, claim)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToInsuranceClaimResponse(claim), nil
+}
+
+// GetClaim retrieves a claim
+func (uc *InsuranceUseCase) GetClaim(ctx context.Context, orgID, claimID uuid.UUID) (*dto.InsuranceClaimResponse, error) {
+	claim, err := uc.getClaim(ctx, orgID, claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToInsuranceClaimResponse(claim), nil
+}
+
+// ListClaims retrieves a page of the organization's claims
+func (uc *InsuranceUseCase) ListClaims(ctx context.Context, orgID uuid.UUID, req *dto.InsuranceClaimListRequest) (*dto.InsuranceClaimListResponse, error) {
+	filters := repositories.InsuranceClaimFilters{
+		OrganizationID: orgID,
+		PatientID:      req.PatientID,
+		PolicyID:       req.PolicyID,
+		AppointmentID:  req.AppointmentID,
+	}
+	if req.Status != "" {
+		status := entities.InsuranceClaimStatus(strings.ToLower(req.Status))
+		if !entities.IsValidInsuranceClaimStatus(status) {
+			return nil, entities.ErrInvalidInsuranceClaimStatus
+		}
+		filters.Status = &status
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+	filters.Page = page
+	filters.Limit = limit
+
+	claims, total, err := uc.insuranceRepo.ListClaims(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.InsuranceClaimResponse, len(claims))
+	for i, claim := range claims {
+		responses[i] = dto.ToInsuranceClaimResponse(claim)
+	}
+
+	return &dto.InsuranceClaimListResponse{
+		Claims: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// ApproveClaim records the carrier's acceptance of a submitted claim
+func (uc *InsuranceUseCase) ApproveClaim(ctx context.Context, orgID, claimID uuid.UUID, req *dto.ApproveInsuranceClaimRequest) (*dto.InsuranceClaimResponse, error) {
+	return uc.updateClaim(ctx, orgID, claimID, func(claim *entities.InsuranceClaim, now time.Time) error {
+		return claim.Approve(req.ApprovedAmount, req.DeductibleApplied, optionalText(req.ClaimNumber), now)
+	})
+}
+
+// RejectClaim records the carrier's refusal of a submitted claim
+func (uc *InsuranceUseCase) RejectClaim(ctx context.Context, orgID, claimID uuid.UUID, req *dto.RejectInsuranceClaimRequest) (*dto.InsuranceClaimResponse, error) {
+	return uc.updateClaim(ctx, orgID, claimID, func(claim *entities.InsuranceClaim, now time.Time) error {
+		return claim.Reject(req.Reason, optionalText(req.ClaimNumber), now)
+	})
+}
+
+// PayClaim records the carrier's payment of an approved claim
+func (uc *InsuranceUseCase) PayClaim(ctx context.Context, orgID, claimID uuid.UUID, req *dto.PayInsuranceClaimRequest) (*dto.InsuranceClaimResponse, error) {
+	return uc.updateClaim(ctx, orgID, claimID, func(claim *entities.InsuranceClaim, now time.Time) error {
+		return claim.MarkPaid(req.PaidAmount, now)
+	})
+}
+
+// ResubmitClaim sends a corrected rejected claim again, unless the appointment was claimed
+// anew under the policy in the meantime
+func (uc *InsuranceUseCase) ResubmitClaim(ctx context.Context, orgID, claimID uuid.UUID, req *dto.ResubmitInsuranceClaimRequest) (*dto.InsuranceClaimResponse, error) {
+	return uc.updateClaim(ctx, orgID, claimID, func(claim *entities.InsuranceClaim, now time.Time) error {
+		if err := claim.Resubmit(optionalText(req.Notes), now); err != nil {
+			return err
+		}
+
+		existing, err := uc.insuranceRepo.GetOpenClaim(ctx, claim.PolicyID, claim.AppointmentID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return entities.ErrAppointmentAlreadyClaimed
+		}
+		return nil
+	})
+}
+
+// SetServiceCategory changes how insurance plans cover a service
+func (uc *InsuranceUseCase) SetServiceCategory(ctx context.Context, orgID uuid.UUID, serviceID string, req *dto.UpdateServiceCategoryRequest) (*dto.ServiceDTO, error) {
+	category := entities.ServiceCategory(strings.ToLower(strings.TrimSpace(req.Category)))
+	if !entities.IsValidServiceCategory(category) {
+		return nil, entities.ErrInvalidServiceCategory
+	}
+
+	service, err := uc.getService(ctx, orgID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	service.Category = category
+	if err := uc.serviceRepo.UpdateCategory(ctx, service); err != nil {
+		return nil, err
+	}
+
+	return dto.ToServiceDTO(service), nil
+}
+
+// updateClaim applies a status change to a locked claim and saves it
+func (uc *InsuranceUseCase) updateClaim(ctx context.Context, orgID, claimID uuid.UUID, change func(*entities.InsuranceClaim, time.Time) error) (*dto.InsuranceClaimResponse, error) {
+	var claim *entities.InsuranceClaim
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		claim, err = uc.insuranceRepo.GetClaimForUpdate(ctx, orgID, claimID)
+		if err != nil {
+			return err
+		}
+		if claim == nil {
+			return entities.ErrInsuranceClaimNotFound
+		}
+
+		if err := change(claim, time.Now()); err != nil {
+			return err
+		}
+
+		return uc.insuranceRepo.UpdateClaim(ctx, claim)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToInsuranceClaimResponse(claim), nil
+}
+
+// benefitUsage adds up what the policy's claims used in the calendar year of date
+func (uc *InsuranceUseCase) benefitUsage(ctx context.Context, policyID uuid.UUID, date time.Time) (services.BenefitUsage, error) {
+	from := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	claims, err := uc.insuranceRepo.ListClaimsByPolicy(ctx, policyID, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return services.BenefitUsage{}, err
+	}
+
+	return services.SummarizeBenefitUsage(claims), nil
+}
+
+// serviceItem builds the estimate item of a service, priced at its base price unless an
+// amount is given
+func (uc *InsuranceUseCase) serviceItem(ctx context.Context, orgID uuid.UUID, serviceID string, amount *int64, currency string) (services.CoverageItem, error) {
+	service, err := uc.getService(ctx, orgID, serviceID)
+	if err != nil {
+		return services.CoverageItem{}, err
+	}
+
+	if amount == nil && service.BasePrice != nil {
+		price := entities.ToMinorUnits(*service.BasePrice, currency) // Base prices are stored in major units
+		amount = &price
+	}
+	if amount == nil {
+		return services.CoverageItem{}, entities.ErrInvoiceLinePriceRequired
+	}
+	if *amount < 0 {
+		return services.CoverageItem{}, entities.ErrInvalidInsuranceAmount
+	}
+
+	return services.CoverageItem{
+		ServiceID:   service.ID,
+		Description: service.Name,
+		Category:    service.Category,
+		Amount:      *amount,
+	}, nil
+}
+
+// treatmentPlanItems builds the estimate items of the items of a patient's plan still to be
+// done, at their estimated cost
+func (uc *InsuranceUseCase) treatmentPlanItems(ctx context.Context, orgID, patientID, planID uuid.UUID) ([]services.CoverageItem, error) {
+	plan, err := uc.planRepo.GetByID(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil || plan.PatientID != patientID {
+		return nil, entities.ErrTreatmentPlanNotFound
+	}
+
+	var items []services.CoverageItem
+	for _, item := range plan.Items {
+		if item.Status == entities.TreatmentPlanItemStatusDeclined || item.Status == entities.TreatmentPlanItemStatusCompleted {
+			continue
+		}
+		service, err := uc.getService(ctx, orgID, item.ServiceID)
+		if err != nil {
+			return nil, err
+		}
+
+		description := service.Name
+		if item.Description != nil && strings.TrimSpace(*item.Description) != "" {
+			description += " - " + strings.TrimSpace(*item.Description)
+		}
+		items = append(items, services.CoverageItem{
+			ServiceID:   service.ID,
+			Description: description,
+			Category:    service.Category,
+			Amount:      item.EstimatedCost,
+		})
+	}
+	return items, nil
+}
+
+// serviceCategory returns the category of a service; amounts without a service are treated
+// as basic care
+func (uc *InsuranceUseCase) serviceCategory(ctx context.Context, orgID uuid.UUID, serviceID *string) (entities.ServiceCategory, error) {
+	if serviceID == nil {
+		return entities.ServiceCategoryBasic, nil
+	}
+	service, err := uc.getService(ctx, orgID, *serviceID)
+	if err != nil {
+		return "", err
+	}
+	return service.Category, nil
+}
+
+// checkPatient ensures the patient belongs to the organization
+func (uc *InsuranceUseCase) checkPatient(ctx context.Context, orgID, patientID uuid.UUID) error {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return entities.ErrPatientNotFound
+	}
+	return nil
+}
+
+func (uc *InsuranceUseCase) getCarrier(ctx context.Context, orgID, id uuid.UUID) (*entities.InsuranceCarrier, error) {
+	carrier, err := uc.insuranceRepo.GetCarrier(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if carrier == nil {
+		return nil, entities.ErrInsuranceCarrierNotFound
+	}
+	return carrier, nil
+}
+
+func (uc *InsuranceUseCase) getPolicy(ctx context.Context, orgID, id uuid.UUID) (*entities.InsurancePolicy, error) {
+	policy, err := uc.insuranceRepo.GetPolicy(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, entities.ErrInsurancePolicyNotFound
+	}
+	return policy, nil
+}
+
+func (uc *InsuranceUseCase) getClaim(ctx context.Context, orgID, id uuid.UUID) (*entities.InsuranceClaim, error) {
+	claim, err := uc.insuranceRepo.GetClaim(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if claim == nil {
+		return nil, entities.ErrInsuranceClaimNotFound
+	}
+	return claim, nil
+}
+
+func (uc *InsuranceUseCase) getService(ctx context.Context, orgID uuid.UUID, serviceID string) (*entities.Service, error) {
+	service, err := uc.serviceRepo.GetByID(ctx, orgID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, entities.ErrServiceNotFound
+	}
+	return service, nil
+}
+
+func (uc *InsuranceUseCase) getOrganization(ctx context.Context, orgID uuid.UUID) (*entities.Organization, error) {
+	org, err := uc.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, entities.ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+// policyTerms parses the coverage and period of a policy request
+func policyTerms(req *dto.InsurancePolicyRequest) (map[entities.ServiceCategory]int, time.Time, *time.Time, error) {
+	coverage := make(map[entities.ServiceCategory]int, len(req.Coverage))
+	for category, percent := range req.Coverage {
+		coverage[entities.ServiceCategory(strings.ToLower(strings.TrimSpace(category)))] = percent
+	}
+
+	from, err := parseInsuranceDate(req.EffectiveFrom)
+	if err != nil {
+		return nil, time.Time{}, nil, err
+	}
+	if from == nil {
+		return nil, time.Time{}, nil, entities.ErrInvalidInsuranceDate
+	}
+
+	var to *time.Time
+	if req.EffectiveTo != nil {
+		if to, err = parseInsuranceDate(*req.EffectiveTo); err != nil {
+			return nil, time.Time{}, nil, err
+		}
+	}
+	return coverage, *from, to, nil
+}
+
+// parseInsuranceDate parses a YYYY-MM-DD policy or service date; an empty value means no date
+func parseInsuranceDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, entities.ErrInvalidInsuranceDate
+	}
+	return &date, nil
+}
@@ -0,0 +1,547 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceUseCase handles invoices, their payments ledger and cash-close reports
+type InvoiceUseCase struct {
+	invoiceRepo     repositories.InvoiceRepository
+	cfdiRepo        repositories.CFDIRepository
+	appointmentRepo repositories.AppointmentRepository
+	patientRepo     repositories.PatientRepository
+	unitRepo        repositories.UnitRepository
+	clinicRepo      repositories.ClinicRepository
+	serviceRepo     repositories.ServiceRepository
+	planRepo        repositories.TreatmentPlanRepository
+	orgRepo         repositories.OrganizationRepository
+	depositRepo     repositories.DepositRepository
+	txManager       repositories.TransactionManager
+}
+
+// NewInvoiceUseCase creates a new instance of InvoiceUseCase
+func NewInvoiceUseCase(
+	invoiceRepo repositories.InvoiceRepository,
+	cfdiRepo repositories.CFDIRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	patientRepo repositories.PatientRepository,
+	unitRepo repositories.UnitRepository,
+	clinicRepo repositories.ClinicRepository,
+	serviceRepo repositories.ServiceRepository,
+	planRepo repositories.TreatmentPlanRepository,
+	orgRepo repositories.OrganizationRepository,
+	depositRepo repositories.DepositRepository,
+	txManager repositories.TransactionManager,
+) *InvoiceUseCase {
+	return &InvoiceUseCase{
+		invoiceRepo:     invoiceRepo,
+		cfdiRepo:        cfdiRepo,
+		appointmentRepo: appointmentRepo,
+		patientRepo:     patientRepo,
+		unitRepo:        unitRepo,
+		clinicRepo:      clinicRepo,
+		serviceRepo:     serviceRepo,
+		planRepo:        planRepo,
+		orgRepo:         orgRepo,
+		depositRepo:     depositRepo,
+		txManager:       txManager,
+	}
+}
+
+// CreateInvoice bills a completed appointment in the organization's currency. A deposit paid
+// for the appointment is entered as a card payment of the invoice.
+func (uc *InvoiceUseCase) CreateInvoice(ctx context.Context, orgID uuid.UUID, createdBy *uuid.UUID, req *dto.CreateInvoiceRequest) (*dto.InvoiceResponse, error) {
+	appointment, err := uc.appointmentRepo.GetByID(ctx, req.AppointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if appointment == nil || appointment.PatientID == nil || appointment.UnitID == nil {
+		return nil, entities.ErrAppointmentNotFound
+	}
+	_, clinic, err := uc.unitRepo.GetUnitWithClinic(ctx, *appointment.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	if clinic == nil || clinic.OrganizationID != orgID {
+		return nil, entities.ErrAppointmentNotFound
+	}
+	if appointment.Status != entities.AppointmentStatusCompleted {
+		return nil, entities.ErrAppointmentNotCompleted
+	}
+
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []*entities.InvoiceLine
+	if len(req.Lines) == 0 {
+		line, err := uc.appointmentLine(ctx, orgID, appointment, org.Currency, req.Discount, req.TaxRate)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	for _, input := range req.Lines {
+		line, err := uc.newLine(ctx, orgID, input, org.Currency)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	var invoice *entities.Invoice
+	payments := []*entities.Payment{}
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := uc.invoiceRepo.GetActiveByAppointment(ctx, appointment.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return entities.ErrAppointmentAlreadyInvoiced
+		}
+
+		number, err := uc.invoiceRepo.NextNumber(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		invoice, err = entities.NewInvoice(orgID, clinic.ID, *appointment.PatientID, &appointment.ID, number, org.Currency, lines, createdBy)
+		if err != nil {
+			return err
+		}
+		invoice.Notes = req.Notes
+
+		deposit, err := uc.depositRepo.GetActiveByAppointment(ctx, appointment.ID)
+		if err != nil {
+			return err
+		}
+		var payment *entities.Payment
+		if deposit != nil && deposit.CanBeApplied() && deposit.Currency == invoice.Currency && invoice.BalanceDue() > 0 {
+			// Whatever exceeds the invoice total is left for staff to refund
+			if payment, err = entities.NewPayment(invoice, entities.PaymentMethodCard, min(deposit.Amount, invoice.BalanceDue()), deposit.PaymentReference, nil, *deposit.PaidAt); err != nil {
+				return err
+			}
+			if err := invoice.ApplyPayment(payment.Amount); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.invoiceRepo.Create(ctx, invoice); err != nil {
+			return err
+		}
+		if payment == nil {
+			return nil
+		}
+		if err := uc.invoiceRepo.CreatePayment(ctx, payment); err != nil {
+			return err
+		}
+		payments = append(payments, payment)
+		deposit.ApplyToInvoice(invoice.ID, time.Now())
+		return uc.depositRepo.Update(ctx, deposit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToInvoiceResponse(invoice, payments), nil
+}
+
+// GetInvoice retrieves an invoice with its payments ledger
+func (uc *InvoiceUseCase) GetInvoice(ctx context.Context, orgID, id uuid.UUID) (*dto.InvoiceResponse, error) {
+	invoice, err := uc.getInvoice(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.invoiceResponse(ctx, invoice)
+}
+
+// ListInvoices retrieves a page of the organization's invoices
+func (uc *InvoiceUseCase) ListInvoices(ctx context.Context, orgID uuid.UUID, req *dto.InvoiceListRequest) (*dto.InvoiceListResponse, error) {
+	filters := repositories.InvoiceFilters{
+		OrganizationID: orgID,
+		PatientID:      req.PatientID,
+		ClinicID:       req.ClinicID,
+	}
+	if req.Status != "" {
+		status := entities.InvoiceStatus(strings.ToLower(req.Status))
+		if !entities.IsValidInvoiceStatus(status) {
+			return nil, entities.ErrInvalidInvoiceStatus
+		}
+		filters.Status = &status
+	}
+	if req.From != "" {
+		from, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			return nil, entities.ErrInvalidBillingDate
+		}
+		filters.IssuedFrom = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			return nil, entities.ErrInvalidBillingDate
+		}
+		to = to.AddDate(0, 0, 1) // Include the whole day
+		filters.IssuedTo = &to
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+	filters.Page = page
+	filters.Limit = limit
+
+	invoices, total, err := uc.invoiceRepo.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.InvoiceResponse, len(invoices))
+	for i, invoice := range invoices {
+		responses[i] = dto.ToInvoiceResponse(invoice, nil)
+	}
+
+	return &dto.InvoiceListResponse{
+		Invoices: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// RecordPayment adds money received towards an invoice to its ledger
+func (uc *InvoiceUseCase) RecordPayment(ctx context.Context, orgID, invoiceID uuid.UUID, receivedBy *uuid.UUID, req *dto.RecordPaymentRequest) (*dto.InvoiceResponse, error) {
+	method := entities.PaymentMethod(strings.ToLower(strings.TrimSpace(req.Method)))
+	receivedAt := time.Now()
+	if req.ReceivedAt != nil {
+		receivedAt = *req.ReceivedAt
+	}
+
+	var invoice *entities.Invoice
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		invoice, err = uc.getInvoiceForUpdate(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		payment, err := entities.NewPayment(invoice, method, req.Amount, req.Reference, receivedBy, receivedAt)
+		if err != nil {
+			return err
+		}
+		if err := invoice.ApplyPayment(payment.Amount); err != nil {
+			return err
+		}
+
+		if err := uc.invoiceRepo.CreatePayment(ctx, payment); err != nil {
+			return err
+		}
+		return uc.invoiceRepo.Update(ctx, invoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.invoiceResponse(ctx, invoice)
+}
+
+// RefundPayment gives back part or all of a payment; the refunded amount is owed again
+func (uc *InvoiceUseCase) RefundPayment(ctx context.Context, orgID, invoiceID, paymentID uuid.UUID, refundedBy *uuid.UUID, req *dto.RefundPaymentRequest) (*dto.InvoiceResponse, error) {
+	var invoice *entities.Invoice
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		invoice, err = uc.getInvoiceForUpdate(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		payment, err := uc.invoiceRepo.GetPayment(ctx, invoice.ID, paymentID)
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return entities.ErrPaymentNotFound
+		}
+		refunded, err := uc.invoiceRepo.RefundedAmount(ctx, payment.ID)
+		if err != nil {
+			return err
+		}
+
+		amount := payment.Amount - refunded
+		if req.Amount != nil {
+			amount = *req.Amount
+		} else if amount <= 0 {
+			return entities.ErrRefundExceedsPayment
+		}
+		method := payment.Method
+		if req.Method != nil {
+			method = entities.PaymentMethod(strings.ToLower(strings.TrimSpace(*req.Method)))
+		}
+
+		refund, err := entities.NewRefund(payment, method, amount, refunded, req.Reason, refundedBy, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := invoice.ApplyRefund(refund.Amount); err != nil {
+			return err
+		}
+
+		if err := uc.invoiceRepo.CreatePayment(ctx, refund); err != nil {
+			return err
+		}
+		return uc.invoiceRepo.Update(ctx, invoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.invoiceResponse(ctx, invoice)
+}
+
+// VoidInvoice cancels an invoice with nothing paid on it, freeing its appointment to be
+// invoiced again. A stamped CFDI has to be cancelled first.
+func (uc *InvoiceUseCase) VoidInvoice(ctx context.Context, orgID, invoiceID uuid.UUID, voidedBy *uuid.UUID, req *dto.VoidInvoiceRequest) (*dto.InvoiceResponse, error) {
+	var invoice *entities.Invoice
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		invoice, err = uc.getInvoiceForUpdate(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		cfdi, err := uc.cfdiRepo.GetActiveByInvoice(ctx, invoice.ID)
+		if err != nil {
+			return err
+		}
+		if cfdi != nil {
+			return entities.ErrInvoiceHasActiveCFDI
+		}
+
+		if err := invoice.Void(voidedBy, req.Reason, time.Now()); err != nil {
+			return err
+		}
+		return uc.invoiceRepo.Update(ctx, invoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.invoiceResponse(ctx, invoice)
+}
+
+// GetPatientBalance summarizes what a patient was billed and still owes
+func (uc *InvoiceUseCase) GetPatientBalance(ctx context.Context, orgID, patientID uuid.UUID) (*dto.PatientBalanceResponse, error) {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	invoices, err := uc.invoiceRepo.ListByPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToPatientBalanceResponse(patientID, org.Currency, services.SummarizePatientBalance(invoices)), nil
+}
+
+// GetCashClose totals a clinic's payments ledger over a business day in the clinic's timezone
+func (uc *InvoiceUseCase) GetCashClose(ctx context.Context, orgID, clinicID uuid.UUID, req *dto.CashCloseRequest) (*dto.CashCloseResponse, error) {
+	clinic, err := uc.clinicRepo.GetByID(ctx, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	if clinic == nil || clinic.OrganizationID != orgID {
+		return nil, entities.ErrClinicNotFound
+	}
+
+	timezone := clinic.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic timezone %q: %w", timezone, err)
+	}
+
+	day := time.Now().In(loc)
+	if req.Date != "" {
+		if day, err = time.ParseInLocation("2006-01-02", req.Date, loc); err != nil {
+			return nil, entities.ErrInvalidBillingDate
+		}
+	}
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 1)
+
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := uc.invoiceRepo.ListPaymentsByClinic(ctx, orgID, clinic.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := services.SummarizeCashClose(entries)
+	return dto.ToCashCloseResponse(clinic.ID, from.Format("2006-01-02"), timezone, from, to, org.Currency, summary, entries), nil
+}
+
+// appointmentLine generates the line of an appointment's procedure: the treatment plan item
+// booked in it at its estimated cost, or else its service at the base price
+func (uc *InvoiceUseCase) appointmentLine(ctx context.Context, orgID uuid.UUID, appointment *entities.Appointment, currency string, discount int64, taxRate int) (*entities.InvoiceLine, error) {
+	plan, err := uc.planRepo.GetByAppointmentID(ctx, appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if plan != nil {
+		if item := plan.ItemByAppointment(appointment.ID); item != nil {
+			service, err := uc.getService(ctx, orgID, item.ServiceID)
+			if err != nil {
+				return nil, err
+			}
+			description := service.Name
+			if item.Description != nil && strings.TrimSpace(*item.Description) != "" {
+				description += " - " + strings.TrimSpace(*item.Description)
+			}
+
+			line, err := entities.NewInvoiceLine(description, 1, item.EstimatedCost, discount, taxRate)
+			if err != nil {
+				return nil, err
+			}
+			line.ServiceID = &service.ID
+			line.TreatmentPlanItemID = &item.ID
+			return line, nil
+		}
+	}
+
+	if appointment.ServiceID == nil {
+		return nil, entities.ErrInvoiceAppointmentNoService
+	}
+	return uc.newLine(ctx, orgID, dto.InvoiceLineInput{
+		ServiceID: appointment.ServiceID,
+		Discount:  discount,
+		TaxRate:   taxRate,
+	}, currency)
+}
+
+// newLine creates a line from its input, filling the description and price from its service
+func (uc *InvoiceUseCase) newLine(ctx context.Context, orgID uuid.UUID, input dto.InvoiceLineInput, currency string) (*entities.InvoiceLine, error) {
+	description := input.Description
+	quantity := input.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	unitPrice := input.UnitPrice
+
+	var service *entities.Service
+	if input.ServiceID != nil {
+		var err error
+		if service, err = uc.getService(ctx, orgID, *input.ServiceID); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(description) == "" {
+			description = service.Name
+		}
+		if unitPrice == nil && service.BasePrice != nil {
+			price := entities.ToMinorUnits(*service.BasePrice, currency) // Base prices are stored in major units
+			unitPrice = &price
+		}
+	}
+	if unitPrice == nil {
+		return nil, entities.ErrInvoiceLinePriceRequired
+	}
+
+	line, err := entities.NewInvoiceLine(description, quantity, *unitPrice, input.Discount, input.TaxRate)
+	if err != nil {
+		return nil, err
+	}
+	if service != nil {
+		line.ServiceID = &service.ID
+	}
+	return line, nil
+}
+
+// invoiceResponse converts an invoice to its response with its payments ledger
+func (uc *InvoiceUseCase) invoiceResponse(ctx context.Context, invoice *entities.Invoice) (*dto.InvoiceResponse, error) {
+	payments, err := uc.invoiceRepo.ListPayments(ctx, invoice.ID)
+	if err != nil {
+		return nil, err
+	}
+	if payments == nil {
+		payments = []*entities.Payment{}
+	}
+
+	return dto.ToInvoiceResponse(invoice, payments), nil
+}
+
+func (uc *InvoiceUseCase) getInvoice(ctx context.Context, orgID, id uuid.UUID) (*entities.Invoice, error) {
+	invoice, err := uc.invoiceRepo.GetByID(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, entities.ErrInvoiceNotFound
+	}
+	return invoice, nil
+}
+
+func (uc *InvoiceUseCase) getInvoiceForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.Invoice, error) {
+	invoice, err := uc.invoiceRepo.GetForUpdate(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, entities.ErrInvoiceNotFound
+	}
+	return invoice, nil
+}
+
+func (uc *InvoiceUseCase) getService(ctx context.Context, orgID uuid.UUID, serviceID string) (*entities.Service, error) {
+	service, err := uc.serviceRepo.GetByID(ctx, orgID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, entities.ErrServiceNotFound
+	}
+	return service, nil
+}
+
+func (uc *InvoiceUseCase) getOrganization(ctx context.Context, orgID uuid.UUID) (*entities.Organization, error) {
+	org, err := uc.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, entities.ErrOrganizationNotFound
+	}
+	return org, nil
+}
@@ -0,0 +1,262 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// MedicalAlertUseCase handles patient medical alerts (allergies, medications, conditions,
+// pregnancy). Every change is recorded in the alert's audit trail in the same transaction.
+type MedicalAlertUseCase struct {
+	alertRepo   repositories.MedicalAlertRepository
+	patientRepo repositories.PatientRepository
+	txManager   repositories.TransactionManager
+}
+
+// NewMedicalAlertUseCase creates a new instance of MedicalAlertUseCase
+func NewMedicalAlertUseCase(
+	alertRepo repositories.MedicalAlertRepository,
+	patientRepo repositories.PatientRepository,
+	txManager repositories.TransactionManager,
+) *MedicalAlertUseCase {
+	return &MedicalAlertUseCase{
+		alertRepo:   alertRepo,
+		patientRepo: patientRepo,
+		txManager:   txManager,
+	}
+}
+
+// ListPatientAlerts retrieves a patient's alerts, most severe first
+func (uc *MedicalAlertUseCase) ListPatientAlerts(ctx context.Context, orgID, patientID uuid.UUID, req *dto.MedicalAlertListRequest) (*dto.MedicalAlertsResponse, error) {
+	if err := uc.ensurePatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	alerts, err := uc.alertRepo.ListByPatient(ctx, orgID, patientID, req.IncludeResolved)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToMedicalAlertsResponse(patientID, alerts), nil
+}
+
+// CreateAlert records a new alert for a patient
+func (uc *MedicalAlertUseCase) CreateAlert(ctx context.Context, orgID, patientID uuid.UUID, userID *uuid.UUID, req *dto.CreateMedicalAlertRequest) (*dto.MedicalAlertResponse, error) {
+	alert, err := entities.NewMedicalAlert(orgID, patientID,
+		entities.MedicalAlertCategory(strings.ToLower(strings.TrimSpace(req.Category))),
+		req.Title,
+		entities.MedicalAlertSeverity(strings.ToLower(strings.TrimSpace(req.Severity))),
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	alert.Notes = optionalText(req.Notes)
+	if req.ReviewDate != nil {
+		if alert.ReviewDate, err = parseMedicalAlertDate(*req.ReviewDate); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.ensurePatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	change, err := entities.NewMedicalAlertChange(entities.MedicalAlertActionCreated, nil, alert, nil, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.alertRepo.Create(ctx, alert); err != nil {
+			return err
+		}
+		return uc.alertRepo.CreateChange(ctx, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToMedicalAlertResponse(alert), nil
+}
+
+// GetAlert retrieves an alert
+func (uc *MedicalAlertUseCase) GetAlert(ctx context.Context, orgID, alertID uuid.UUID) (*dto.MedicalAlertResponse, error) {
+	alert, err := uc.getAlert(ctx, orgID, alertID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToMedicalAlertResponse(alert), nil
+}
+
+// UpdateAlert changes the details of an active alert
+func (uc *MedicalAlertUseCase) UpdateAlert(ctx context.Context, orgID, alertID uuid.UUID, userID *uuid.UUID, req *dto.UpdateMedicalAlertRequest) (*dto.MedicalAlertResponse, error) {
+	if req.Category == nil && req.Title == nil && req.Severity == nil && req.Notes == nil && req.ReviewDate == nil {
+		return nil, entities.ErrMedicalAlertNoChanges
+	}
+
+	var reviewDate *time.Time
+	if req.ReviewDate != nil {
+		var err error
+		if reviewDate, err = parseMedicalAlertDate(*req.ReviewDate); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.change(ctx, orgID, alertID, entities.MedicalAlertActionUpdated, userID, req.Reason, func(alert *entities.MedicalAlert) error {
+		if !alert.IsActive() {
+			return entities.ErrMedicalAlertResolved
+		}
+		if req.Category != nil {
+			alert.Category = entities.MedicalAlertCategory(strings.ToLower(strings.TrimSpace(*req.Category)))
+		}
+		if req.Title != nil {
+			alert.Title = strings.TrimSpace(*req.Title)
+		}
+		if req.Severity != nil {
+			alert.Severity = entities.MedicalAlertSeverity(strings.ToLower(strings.TrimSpace(*req.Severity)))
+		}
+		if req.Notes != nil {
+			alert.Notes = optionalText(req.Notes)
+		}
+		if req.ReviewDate != nil {
+			alert.ReviewDate = reviewDate
+		}
+		if err := alert.Validate(); err != nil {
+			return err
+		}
+		alert.UpdatedBy = userID
+		alert.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+// ReviewAlert records that an active alert was confirmed with the patient
+func (uc *MedicalAlertUseCase) ReviewAlert(ctx context.Context, orgID, alertID uuid.UUID, userID *uuid.UUID, req *dto.ReviewMedicalAlertRequest) (*dto.MedicalAlertResponse, error) {
+	var nextReviewDate *time.Time
+	if req.NextReviewDate != nil {
+		var err error
+		if nextReviewDate, err = parseMedicalAlertDate(*req.NextReviewDate); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.change(ctx, orgID, alertID, entities.MedicalAlertActionReviewed, userID, req.Reason, func(alert *entities.MedicalAlert) error {
+		return alert.Review(userID, nextReviewDate, time.Now())
+	})
+}
+
+// ResolveAlert marks an alert as no longer applying
+func (uc *MedicalAlertUseCase) ResolveAlert(ctx context.Context, orgID, alertID uuid.UUID, userID *uuid.UUID, req *dto.MedicalAlertStatusRequest) (*dto.MedicalAlertResponse, error) {
+	return uc.change(ctx, orgID, alertID, entities.MedicalAlertActionResolved, userID, req.Reason, func(alert *entities.MedicalAlert) error {
+		return alert.Resolve(userID, time.Now())
+	})
+}
+
+// ReactivateAlert brings a resolved alert back
+func (uc *MedicalAlertUseCase) ReactivateAlert(ctx context.Context, orgID, alertID uuid.UUID, userID *uuid.UUID, req *dto.MedicalAlertStatusRequest) (*dto.MedicalAlertResponse, error) {
+	return uc.change(ctx, orgID, alertID, entities.MedicalAlertActionReactivated, userID, req.Reason, func(alert *entities.MedicalAlert) error {
+		return alert.Reactivate(userID, time.Now())
+	})
+}
+
+// GetAlertHistory retrieves the audit trail of an alert, oldest first
+func (uc *MedicalAlertUseCase) GetAlertHistory(ctx context.Context, orgID, alertID uuid.UUID) (*dto.MedicalAlertHistoryResponse, error) {
+	if _, err := uc.getAlert(ctx, orgID, alertID); err != nil {
+		return nil, err
+	}
+
+	changes, err := uc.alertRepo.ListChanges(ctx, orgID, alertID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToMedicalAlertHistoryResponse(alertID, changes), nil
+}
+
+// change applies apply to an alert and saves it together with the audit record of the change
+func (uc *MedicalAlertUseCase) change(ctx context.Context, orgID, alertID uuid.UUID, action entities.MedicalAlertAction, userID *uuid.UUID, reason *string, apply func(*entities.MedicalAlert) error) (*dto.MedicalAlertResponse, error) {
+	var alert *entities.MedicalAlert
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		alert, err = uc.getAlert(ctx, orgID, alertID)
+		if err != nil {
+			return err
+		}
+
+		previous := *alert
+		if err := apply(alert); err != nil {
+			return err
+		}
+		if err := uc.alertRepo.Update(ctx, alert); err != nil {
+			return err
+		}
+
+		change, err := entities.NewMedicalAlertChange(action, &previous, alert, optionalText(reason), userID)
+		if err != nil {
+			return err
+		}
+		return uc.alertRepo.CreateChange(ctx, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToMedicalAlertResponse(alert), nil
+}
+
+// getAlert retrieves an alert of the organization
+func (uc *MedicalAlertUseCase) getAlert(ctx context.Context, orgID, alertID uuid.UUID) (*entities.MedicalAlert, error) {
+	alert, err := uc.alertRepo.GetByID(ctx, orgID, alertID)
+	if err != nil {
+		return nil, err
+	}
+	if alert == nil {
+		return nil, entities.ErrMedicalAlertNotFound
+	}
+	return alert, nil
+}
+
+// ensurePatient checks the patient belongs to the organization
+func (uc *MedicalAlertUseCase) ensurePatient(ctx context.Context, orgID, patientID uuid.UUID) error {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return entities.ErrPatientNotFound
+	}
+	return nil
+}
+
+// parseMedicalAlertDate parses a YYYY-MM-DD review date; an empty value means no date
+func parseMedicalAlertDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, entities.ErrInvalidMedicalAlertDate
+	}
+	return &date, nil
+}
+
+// optionalText trims an optional text; blank values become nil
+func optionalText(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	text := strings.TrimSpace(*value)
+	if text == "" {
+		return nil
+	}
+	return &text
+}
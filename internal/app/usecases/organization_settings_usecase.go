@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationSettingsUseCase handles organization-wide settings
+type OrganizationSettingsUseCase struct {
+	orgRepo repositories.OrganizationRepository
+}
+
+// NewOrganizationSettingsUseCase creates a new instance of OrganizationSettingsUseCase
+func NewOrganizationSettingsUseCase(orgRepo repositories.OrganizationRepository) *OrganizationSettingsUseCase {
+	return &OrganizationSettingsUseCase{
+		orgRepo: orgRepo,
+	}
+}
+
+// GetSettings retrieves the settings of an organization
+func (uc *OrganizationSettingsUseCase) GetSettings(ctx context.Context, orgID uuid.UUID) (*dto.OrganizationSettingsResponse, error) {
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToOrganizationSettingsResponse(org), nil
+}
+
+// UpdateSettings updates the settings present in the request
+func (uc *OrganizationSettingsUseCase) UpdateSettings(ctx context.Context, orgID uuid.UUID, req *dto.UpdateOrganizationSettingsRequest) (*dto.OrganizationSettingsResponse, error) {
+	org, err := uc.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DefaultPhoneRegion != nil {
+		if err := org.SetDefaultPhoneRegion(*req.DefaultPhoneRegion); err != nil {
+			return nil, err
+		}
+	}
+	if req.Currency != nil {
+		if err := org.SetCurrency(*req.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.orgRepo.UpdateSettings(ctx, org); err != nil {
+		return nil, err
+	}
+
+	return dto.ToOrganizationSettingsResponse(org), nil
+}
+
+func (uc *OrganizationSettingsUseCase) getOrganization(ctx context.Context, orgID uuid.UUID) (*entities.Organization, error) {
+	org, err := uc.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, entities.ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+// organizationPhoneRegion returns the default phone region of an organization, or the
+// global default for records that do not belong to one
+func organizationPhoneRegion(ctx context.Context, orgRepo repositories.OrganizationRepository, orgID uuid.UUID) (string, error) {
+	if orgID == uuid.Nil {
+		return entities.DefaultPhoneRegion, nil
+	}
+
+	org, err := orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return "", err
+	}
+	if org == nil {
+		return "", entities.ErrOrganizationNotFound
+	}
+
+	return org.DefaultPhoneRegion, nil
+}
@@ -0,0 +1,360 @@
+package usecases
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// PatientMergeUseCase handles duplicate patient detection and merging
+type PatientMergeUseCase struct {
+	patientRepo      repositories.PatientRepository
+	appointmentRepo  repositories.AppointmentRepository
+	mergeRepo        repositories.PatientMergeRepository
+	chartRepo        repositories.DentalChartRepository
+	noteRepo         repositories.ClinicalNoteRepository
+	planRepo         repositories.TreatmentPlanRepository
+	attachmentRepo   repositories.AttachmentRepository
+	consentRepo      repositories.ConsentFormRepository
+	alertRepo        repositories.MedicalAlertRepository
+	relationshipRepo repositories.PatientRelationshipRepository
+	invoiceRepo      repositories.InvoiceRepository
+	cfdiRepo         repositories.CFDIRepository
+	fiscalRepo       repositories.FiscalProfileRepository
+	insuranceRepo    repositories.InsuranceRepository
+	cancellationRepo repositories.CancellationRepository
+	depositRepo      repositories.DepositRepository
+	recallRepo       repositories.RecallRepository
+	txManager        repositories.TransactionManager
+	outboxRepo       repositories.OutboxRepository
+}
+
+// NewPatientMergeUseCase creates a new instance of PatientMergeUseCase
+func NewPatientMergeUseCase(
+	patientRepo repositories.PatientRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	mergeRepo repositories.PatientMergeRepository,
+	chartRepo repositories.DentalChartRepository,
+	noteRepo repositories.ClinicalNoteRepository,
+	planRepo repositories.TreatmentPlanRepository,
+	attachmentRepo repositories.AttachmentRepository,
+	consentRepo repositories.ConsentFormRepository,
+	alertRepo repositories.MedicalAlertRepository,
+	relationshipRepo repositories.PatientRelationshipRepository,
+	invoiceRepo repositories.InvoiceRepository,
+	cfdiRepo repositories.CFDIRepository,
+	fiscalRepo repositories.FiscalProfileRepository,
+	insuranceRepo repositories.InsuranceRepository,
+	cancellationRepo repositories.CancellationRepository,
+	depositRepo repositories.DepositRepository,
+	recallRepo repositories.RecallRepository,
+	txManager repositories.TransactionManager,
+	outboxRepo repositories.OutboxRepository,
+) *PatientMergeUseCase {
+	return &PatientMergeUseCase{
+		patientRepo:      patientRepo,
+		appointmentRepo:  appointmentRepo,
+		mergeRepo:        mergeRepo,
+		chartRepo:        chartRepo,
+		noteRepo:         noteRepo,
+		planRepo:         planRepo,
+		attachmentRepo:   attachmentRepo,
+		consentRepo:      consentRepo,
+		alertRepo:        alertRepo,
+		relationshipRepo: relationshipRepo,
+		invoiceRepo:      invoiceRepo,
+		cfdiRepo:         cfdiRepo,
+		fiscalRepo:       fiscalRepo,
+		insuranceRepo:    insuranceRepo,
+		cancellationRepo: cancellationRepo,
+		depositRepo:      depositRepo,
+		recallRepo:       recallRepo,
+		txManager:        txManager,
+		outboxRepo:       outboxRepo,
+	}
+}
+
+// ListDuplicates scans the organization's patients for likely duplicate pairs
+func (uc *PatientMergeUseCase) ListDuplicates(ctx context.Context, orgID uuid.UUID, req *dto.DuplicateCandidatesRequest) (*dto.DuplicateCandidatesResponse, error) {
+	patients, err := uc.patientRepo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	minScore, limit := duplicateListParams(req)
+	return toDuplicateCandidatesResponse(services.FindDuplicatePairs(patients, minScore), limit), nil
+}
+
+// GetPatientDuplicates lists the likely duplicates of one patient
+func (uc *PatientMergeUseCase) GetPatientDuplicates(ctx context.Context, orgID, patientID uuid.UUID, req *dto.DuplicateCandidatesRequest) (*dto.DuplicateCandidatesResponse, error) {
+	patient, err := uc.getPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	patients, err := uc.patientRepo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	minScore, limit := duplicateListParams(req)
+	return toDuplicateCandidatesResponse(services.FindDuplicatesOf(patient, patients, minScore), limit), nil
+}
+
+// MergePatients folds the duplicate patient into the survivor: appointments, dental chart
+// entries, organization links and the first appointment are re-pointed, missing survivor
+// details are filled from the duplicate, the duplicate is deleted and an audit record is
+// kept, all in one transaction.
+func (uc *PatientMergeUseCase) MergePatients(ctx context.Context, orgID, survivorID uuid.UUID, mergedBy *uuid.UUID, req *dto.MergePatientsRequest) (*dto.PatientMergeResponse, error) {
+	if survivorID == req.MergedPatientID {
+		return nil, entities.ErrCannotMergePatientIntoItself
+	}
+
+	survivor, err := uc.getPatient(ctx, orgID, survivorID)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := uc.getPatient(ctx, orgID, req.MergedPatientID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Merging moves all of the duplicate's records and deletes it, so it must not take the
+	// records of another organization with it
+	shared, err := uc.patientRepo.IsLinkedToOtherOrganizations(ctx, merged.ID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		return nil, entities.ErrMergedPatientShared
+	}
+
+	score, _ := services.ScorePatientDuplicate(survivor, merged)
+
+	firstAppointmentID, err := uc.earliestFirstAppointment(ctx, survivor.FirstAppointmentID, merged.FirstAppointmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var merge *entities.PatientMerge
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		moved, err := uc.appointmentRepo.ReassignPatient(ctx, merged.ID, survivor.ID)
+		if err != nil {
+			return err
+		}
+		if err := uc.chartRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.noteRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.planRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.attachmentRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.consentRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.alertRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.relationshipRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.invoiceRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.cfdiRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.fiscalRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.insuranceRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.cancellationRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.depositRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.recallRepo.ReassignPatient(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := uc.patientRepo.MoveOrganizationLinks(ctx, merged.ID, survivor.ID); err != nil {
+			return err
+		}
+
+		survivor.AbsorbDuplicate(merged)
+		if err := uc.patientRepo.Update(ctx, survivor); err != nil {
+			return err
+		}
+		if err := uc.patientRepo.SetFirstAppointment(ctx, survivor.ID, firstAppointmentID); err != nil {
+			return err
+		}
+		if err := uc.patientRepo.Delete(ctx, merged.ID); err != nil {
+			return err
+		}
+
+		merge, err = entities.NewPatientMerge(orgID, survivor, merged, moved, score, req.Reason, mergedBy)
+		if err != nil {
+			return err
+		}
+		if err := uc.mergeRepo.Create(ctx, merge); err != nil {
+			return err
+		}
+
+		// Re-read the survivor so the response carries the version bumped by the last write
+		survivor, err = uc.patientRepo.GetByID(ctx, survivor.ID)
+		if err != nil {
+			return err
+		}
+
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventPatientMerged, entities.AggregatePatient, survivor.ID, &dto.PatientEventData{
+			Patient:         dto.ToPatientResponse(survivor),
+			MergedPatientID: &merged.ID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.ToPatientMergeResponse(merge)
+	response.Survivor = dto.ToPatientResponse(survivor)
+	return response, nil
+}
+
+// ListMerges retrieves the merge audit trail of an organization, newest first
+func (uc *PatientMergeUseCase) ListMerges(ctx context.Context, orgID uuid.UUID, req *dto.PatientMergeListRequest) (*dto.PatientMergeListResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+
+	merges, total, err := uc.mergeRepo.GetByOrganizationID(ctx, orgID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.PatientMergeResponse, len(merges))
+	for i, merge := range merges {
+		responses[i] = dto.ToPatientMergeResponse(merge)
+	}
+
+	return &dto.PatientMergeListResponse{
+		Merges: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// GetMerge retrieves a single merge record including the merged patient snapshot
+func (uc *PatientMergeUseCase) GetMerge(ctx context.Context, orgID, mergeID uuid.UUID) (*dto.PatientMergeResponse, error) {
+	merge, err := uc.mergeRepo.GetByID(ctx, mergeID)
+	if err != nil {
+		return nil, err
+	}
+	if merge == nil || merge.OrganizationID != orgID {
+		return nil, entities.ErrPatientMergeNotFound
+	}
+
+	return dto.ToPatientMergeResponse(merge), nil
+}
+
+// getPatient retrieves a patient and verifies it belongs to the organization
+func (uc *PatientMergeUseCase) getPatient(ctx context.Context, orgID, patientID uuid.UUID) (*entities.Patient, error) {
+	patient, err := uc.patientRepo.GetByID(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	if patient == nil {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound // Return not found to avoid leaking patient existence
+	}
+
+	return patient, nil
+}
+
+// earliestFirstAppointment picks whichever of the two first appointments starts first
+func (uc *PatientMergeUseCase) earliestFirstAppointment(ctx context.Context, survivorFirst, mergedFirst *uuid.UUID) (*uuid.UUID, error) {
+	if survivorFirst == nil {
+		return mergedFirst, nil
+	}
+	if mergedFirst == nil {
+		return survivorFirst, nil
+	}
+
+	survivorAppointment, err := uc.appointmentRepo.GetByID(ctx, *survivorFirst)
+	if err != nil {
+		return nil, err
+	}
+	mergedAppointment, err := uc.appointmentRepo.GetByID(ctx, *mergedFirst)
+	if err != nil {
+		return nil, err
+	}
+
+	if survivorAppointment == nil {
+		return mergedFirst, nil
+	}
+	if mergedAppointment != nil && mergedAppointment.StartTime.Before(survivorAppointment.StartTime) {
+		return mergedFirst, nil
+	}
+	return survivorFirst, nil
+}
+
+// duplicateListParams applies defaults to the duplicate listing parameters
+func duplicateListParams(req *dto.DuplicateCandidatesRequest) (int, int) {
+	minScore := req.MinScore
+	if minScore < 1 {
+		minScore = services.DefaultDuplicateMinScore
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 50 // Default limit
+	}
+	return minScore, limit
+}
+
+// toDuplicateCandidatesResponse converts the best candidates up to limit
+func toDuplicateCandidatesResponse(candidates []services.DuplicateCandidate, limit int) *dto.DuplicateCandidatesResponse {
+	total := len(candidates)
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	responses := make([]*dto.DuplicateCandidateResponse, len(candidates))
+	for i, candidate := range candidates {
+		responses[i] = dto.ToDuplicateCandidateResponse(candidate)
+	}
+
+	return &dto.DuplicateCandidatesResponse{
+		Candidates: responses,
+		Total:      total,
+	}
+}
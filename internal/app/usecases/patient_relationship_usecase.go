@@ -0,0 +1,308 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// PatientRelationshipUseCase handles family relationships between patients, the contact
+// person of minors and the family view of a household
+type PatientRelationshipUseCase struct {
+	relationshipRepo repositories.PatientRelationshipRepository
+	patientRepo      repositories.PatientRepository
+	appointmentRepo  repositories.AppointmentRepository
+	txManager        repositories.TransactionManager
+}
+
+// NewPatientRelationshipUseCase creates a new instance of PatientRelationshipUseCase
+func NewPatientRelationshipUseCase(
+	relationshipRepo repositories.PatientRelationshipRepository,
+	patientRepo repositories.PatientRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	txManager repositories.TransactionManager,
+) *PatientRelationshipUseCase {
+	return &PatientRelationshipUseCase{
+		relationshipRepo: relationshipRepo,
+		patientRepo:      patientRepo,
+		appointmentRepo:  appointmentRepo,
+		txManager:        txManager,
+	}
+}
+
+// ListRelationships retrieves the relatives of a patient and who is notified on its behalf
+func (uc *PatientRelationshipUseCase) ListRelationships(ctx context.Context, orgID, patientID uuid.UUID) (*dto.PatientRelationshipsResponse, error) {
+	patient, err := uc.getPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	relatives, err := uc.relationshipRepo.ListByPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response := &dto.PatientRelationshipsResponse{
+		PatientID: patient.ID,
+		IsMinor:   patient.IsMinor(now),
+		Contact:   dto.ToPatientContactResponse(services.ResolvePatientContact(patient, guardiansOf(relatives), now)),
+		Relatives: make([]*dto.PatientRelativeResponse, len(relatives)),
+	}
+	for i, relative := range relatives {
+		response.Relatives[i] = dto.ToPatientRelativeResponse(relative, now)
+	}
+	return response, nil
+}
+
+// CreateRelationship relates two patients of the organization. The first guardian of a
+// patient becomes its contact person unless another guardian is chosen.
+func (uc *PatientRelationshipUseCase) CreateRelationship(ctx context.Context, orgID, patientID uuid.UUID, userID *uuid.UUID, req *dto.CreatePatientRelationshipRequest) (*dto.PatientRelationshipsResponse, error) {
+	relationshipType := entities.PatientRelationshipType(strings.ToLower(strings.TrimSpace(req.Type)))
+	relationship, inverse, err := entities.NewPatientRelationship(orgID, patientID, req.RelatedPatientID, relationshipType, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The contact person is set on the dependent's side of the relationship
+	var guardianSide *entities.PatientRelationship
+	switch {
+	case relationship.IsGuardian():
+		guardianSide = relationship
+	case inverse.IsGuardian():
+		guardianSide = inverse
+	case req.IsContact:
+		return nil, entities.ErrContactPersonNotGuardian
+	}
+
+	for _, id := range []uuid.UUID{patientID, req.RelatedPatientID} {
+		if _, err := uc.getPatient(ctx, orgID, id); err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := uc.relationshipRepo.Get(ctx, orgID, patientID, req.RelatedPatientID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return entities.ErrPatientRelationshipExists
+		}
+
+		if err := uc.relationshipRepo.CreatePair(ctx, relationship, inverse); err != nil {
+			return err
+		}
+
+		if guardianSide == nil {
+			return nil
+		}
+		if req.IsContact {
+			return uc.relationshipRepo.SetContact(ctx, orgID, guardianSide.PatientID, guardianSide.RelatedPatientID)
+		}
+		return uc.ensureContact(ctx, orgID, guardianSide.PatientID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.ListRelationships(ctx, orgID, patientID)
+}
+
+// DeleteRelationship removes the relationship between two patients. When the removed guardian
+// was the contact person, the next guardian takes over.
+func (uc *PatientRelationshipUseCase) DeleteRelationship(ctx context.Context, orgID, patientID, relatedPatientID uuid.UUID) error {
+	if _, err := uc.getPatient(ctx, orgID, patientID); err != nil {
+		return err
+	}
+
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		relationship, err := uc.relationshipRepo.Get(ctx, orgID, patientID, relatedPatientID)
+		if err != nil {
+			return err
+		}
+		if relationship == nil {
+			return entities.ErrPatientRelationshipNotFound
+		}
+
+		if err := uc.relationshipRepo.DeletePair(ctx, orgID, patientID, relatedPatientID); err != nil {
+			return err
+		}
+
+		switch relationship.Type {
+		case entities.PatientRelationshipGuardian:
+			return uc.ensureContact(ctx, orgID, patientID)
+		case entities.PatientRelationshipDependent:
+			return uc.ensureContact(ctx, orgID, relatedPatientID)
+		}
+		return nil
+	})
+}
+
+// SetContactPerson chooses the guardian through whom a minor is notified
+func (uc *PatientRelationshipUseCase) SetContactPerson(ctx context.Context, orgID, patientID uuid.UUID, req *dto.SetContactPersonRequest) (*dto.PatientRelationshipsResponse, error) {
+	if _, err := uc.getPatient(ctx, orgID, patientID); err != nil {
+		return nil, err
+	}
+
+	relationship, err := uc.relationshipRepo.Get(ctx, orgID, patientID, req.GuardianID)
+	if err != nil {
+		return nil, err
+	}
+	if relationship == nil || !relationship.IsGuardian() {
+		return nil, entities.ErrContactPersonNotGuardian
+	}
+
+	if err := uc.relationshipRepo.SetContact(ctx, orgID, patientID, req.GuardianID); err != nil {
+		return nil, err
+	}
+
+	return uc.ListRelationships(ctx, orgID, patientID)
+}
+
+// GetFamily retrieves the household of a patient with every member's upcoming appointments
+func (uc *PatientRelationshipUseCase) GetFamily(ctx context.Context, orgID, patientID uuid.UUID) (*dto.FamilyResponse, error) {
+	patient, err := uc.getPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	households, err := uc.relationshipRepo.ListHouseholds(ctx, orgID, []uuid.UUID{patientID})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.FamilyResponse{PatientID: patientID}
+	members := []*entities.Patient{patient}
+	if household, ok := households[patientID]; ok {
+		response.HouseholdID = &household.ID
+		members = household.Members
+	}
+
+	relatives, err := uc.relationshipRepo.ListByPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+	relationships := make(map[uuid.UUID]entities.PatientRelationshipType, len(relatives))
+	for _, relative := range relatives {
+		relationships[relative.Patient.ID] = relative.Relationship.Type
+	}
+
+	now := time.Now()
+	response.Members = make([]*dto.FamilyMemberResponse, len(members))
+	for i, member := range members {
+		contact, err := resolvePatientContact(ctx, uc.relationshipRepo, orgID, member)
+		if err != nil {
+			return nil, err
+		}
+		upcoming, err := uc.upcomingAppointments(ctx, orgID, member.ID, now)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Members[i] = &dto.FamilyMemberResponse{
+			PatientID:            member.ID,
+			FirstName:            member.FirstName,
+			LastName:             member.LastName,
+			IsMinor:              member.IsMinor(now),
+			Contact:              dto.ToPatientContactResponse(contact),
+			UpcomingAppointments: upcoming,
+		}
+		if relationshipType, ok := relationships[member.ID]; ok {
+			relationship := string(relationshipType)
+			response.Members[i].Relationship = &relationship
+		}
+	}
+
+	return response, nil
+}
+
+// upcomingAppointments retrieves a patient's upcoming appointments, soonest first
+func (uc *PatientRelationshipUseCase) upcomingAppointments(ctx context.Context, orgID, patientID uuid.UUID, now time.Time) ([]*dto.PatientAppointmentResponse, error) {
+	history, err := uc.appointmentRepo.GetPatientHistory(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	// History is newest first
+	upcoming := []*dto.PatientAppointmentResponse{}
+	for i := len(history) - 1; i >= 0; i-- {
+		if services.IsUpcomingAppointment(history[i].Appointment, now) {
+			upcoming = append(upcoming, dto.ToPatientAppointmentResponse(history[i]))
+		}
+	}
+	return upcoming, nil
+}
+
+// ensureContact makes the first guardian of a patient its contact person when it has none
+func (uc *PatientRelationshipUseCase) ensureContact(ctx context.Context, orgID, patientID uuid.UUID) error {
+	relatives, err := uc.relationshipRepo.ListByPatient(ctx, orgID, patientID)
+	if err != nil {
+		return err
+	}
+
+	for _, relative := range relatives {
+		if relative.Relationship.IsContact {
+			return nil
+		}
+	}
+	for _, relative := range relatives {
+		if relative.Relationship.IsGuardian() {
+			return uc.relationshipRepo.SetContact(ctx, orgID, patientID, relative.Patient.ID)
+		}
+	}
+	return nil
+}
+
+// getPatient retrieves a patient of the organization
+func (uc *PatientRelationshipUseCase) getPatient(ctx context.Context, orgID, patientID uuid.UUID) (*entities.Patient, error) {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	patient, err := uc.patientRepo.GetByID(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	if patient == nil {
+		return nil, entities.ErrPatientNotFound
+	}
+	return patient, nil
+}
+
+// resolvePatientContact resolves who is notified about a patient's appointments: the contact
+// person or another guardian for minors, the patient itself otherwise
+func resolvePatientContact(ctx context.Context, relationshipRepo repositories.PatientRelationshipRepository, orgID uuid.UUID, patient *entities.Patient) (*entities.PatientContact, error) {
+	now := time.Now()
+	if !patient.IsMinor(now) {
+		return services.ResolvePatientContact(patient, nil, now), nil
+	}
+
+	relatives, err := relationshipRepo.ListByPatient(ctx, orgID, patient.ID)
+	if err != nil {
+		return nil, err
+	}
+	return services.ResolvePatientContact(patient, guardiansOf(relatives), now), nil
+}
+
+// guardiansOf returns the guardians among a patient's relatives, keeping the contact person first
+func guardiansOf(relatives []*repositories.PatientRelative) []*entities.Patient {
+	var guardians []*entities.Patient
+	for _, relative := range relatives {
+		if relative.Relationship.IsGuardian() {
+			guardians = append(guardians, relative.Patient)
+		}
+	}
+	return guardians
+}
@@ -2,24 +2,46 @@ package usecases
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"dental-scheduler-backend/internal/app/dto"
 	"dental-scheduler-backend/internal/domain/entities"
 	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
 
 	"github.com/google/uuid"
 )
 
 // PatientUseCase handles patient-related business logic
 type PatientUseCase struct {
-	patientRepo repositories.PatientRepository
+	patientRepo      repositories.PatientRepository
+	appointmentRepo  repositories.AppointmentRepository
+	orgRepo          repositories.OrganizationRepository
+	relationshipRepo repositories.PatientRelationshipRepository
+	txManager        repositories.TransactionManager
+	outboxRepo       repositories.OutboxRepository
 }
 
 // NewPatientUseCase creates a new instance of PatientUseCase
-func NewPatientUseCase(patientRepo repositories.PatientRepository) *PatientUseCase {
+func NewPatientUseCase(
+	patientRepo repositories.PatientRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	orgRepo repositories.OrganizationRepository,
+	relationshipRepo repositories.PatientRelationshipRepository,
+	txManager repositories.TransactionManager,
+	outboxRepo repositories.OutboxRepository,
+) *PatientUseCase {
 	return &PatientUseCase{
-		patientRepo: patientRepo,
+		patientRepo:      patientRepo,
+		appointmentRepo:  appointmentRepo,
+		orgRepo:          orgRepo,
+		relationshipRepo: relationshipRepo,
+		txManager:        txManager,
+		outboxRepo:       outboxRepo,
 	}
 }
 
@@ -30,6 +52,9 @@ func (uc *PatientUseCase) CreatePatient(ctx context.Context, req *dto.CreatePati
 	if err := patient.Validate(); err != nil {
 		return nil, err
 	}
+	if err := uc.normalizePhone(ctx, patient, uuid.Nil); err != nil {
+		return nil, err
+	}
 
 	if err := uc.patientRepo.Create(ctx, patient); err != nil {
 		return nil, err
@@ -64,9 +89,17 @@ func (uc *PatientUseCase) CreatePatientWithOrganization(ctx context.Context, req
 		return nil, err
 	}
 
+	var regionOrgID uuid.UUID // Patients outside an organization use the default region
+	if orgID != nil {
+		regionOrgID = *orgID
+	}
+	if err := uc.normalizePhone(ctx, patient, regionOrgID); err != nil {
+		return nil, err
+	}
+
 	// If organization ID is provided, use transactional creation
 	if orgID != nil {
-		if err := uc.patientRepo.CreatePatientWithOrganization(ctx, patient, *orgID); err != nil {
+		if err := uc.createInOrganization(ctx, patient, *orgID); err != nil {
 			return nil, err
 		}
 	} else {
@@ -96,15 +129,39 @@ func (uc *PatientUseCase) CreatePatientInOrganization(ctx context.Context, req *
 	if err := patient.Validate(); err != nil {
 		return nil, err
 	}
+	if err := uc.normalizePhone(ctx, patient, orgID); err != nil {
+		return nil, err
+	}
 
 	// Create patient with organization link in transaction
-	if err := uc.patientRepo.CreatePatientWithOrganization(ctx, patient, orgID); err != nil {
+	if err := uc.createInOrganization(ctx, patient, orgID); err != nil {
 		return nil, err
 	}
 
 	return dto.ToPatientResponse(patient), nil
 }
 
+// normalizePhone stores the patient's phone in E.164 using the organization's default region
+func (uc *PatientUseCase) normalizePhone(ctx context.Context, patient *entities.Patient, orgID uuid.UUID) error {
+	region, err := organizationPhoneRegion(ctx, uc.orgRepo, orgID)
+	if err != nil {
+		return err
+	}
+	return patient.NormalizePhone(region)
+}
+
+// createInOrganization stores the patient, its organization link and the PatientCreated event atomically
+func (uc *PatientUseCase) createInOrganization(ctx context.Context, patient *entities.Patient, orgID uuid.UUID) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.patientRepo.CreatePatientWithOrganization(ctx, patient, orgID); err != nil {
+			return err
+		}
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventPatientCreated, entities.AggregatePatient, patient.ID, &dto.PatientEventData{
+			Patient: dto.ToPatientResponse(patient),
+		})
+	})
+}
+
 // GetPatientByID retrieves a patient by its ID
 func (uc *PatientUseCase) GetPatientByID(ctx context.Context, id uuid.UUID) (*dto.PatientResponse, error) {
 	patient, err := uc.patientRepo.GetByID(ctx, id)
@@ -134,7 +191,8 @@ func (uc *PatientUseCase) GetAllPatients(ctx context.Context) ([]*dto.PatientRes
 	return responses, nil
 }
 
-// UpdatePatient updates an existing patient
+// UpdatePatient updates an existing patient. When req.Version is set the
+// update only succeeds if the patient has not changed since that version.
 func (uc *PatientUseCase) UpdatePatient(ctx context.Context, id uuid.UUID, orgID uuid.UUID, req *dto.UpdatePatientRequest) (*dto.PatientResponse, error) {
 	existing, err := uc.patientRepo.GetByID(ctx, id)
 	if err != nil {
@@ -154,13 +212,32 @@ func (uc *PatientUseCase) UpdatePatient(ctx context.Context, id uuid.UUID, orgID
 		return nil, entities.ErrPatientNotFound // Return not found to avoid leaking patient existence
 	}
 
+	// A nil version means the client sent If-Match: * and overwrites unconditionally
+	if req.Version != nil && *req.Version != existing.Version {
+		return nil, entities.ErrPatientVersionConflict
+	}
+
 	updated := req.ToEntityUpdate(existing)
 
 	if err := updated.Validate(); err != nil {
 		return nil, err
 	}
+	// Stored phones that predate normalization are only re-parsed when the phone changes
+	if req.Phone != nil {
+		if err := uc.normalizePhone(ctx, updated, orgID); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := uc.patientRepo.Update(ctx, updated); err != nil {
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.patientRepo.Update(ctx, updated); err != nil {
+			return err
+		}
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventPatientUpdated, entities.AggregatePatient, updated.ID, &dto.PatientEventData{
+			Patient: dto.ToPatientResponse(updated),
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -181,7 +258,256 @@ func (uc *PatientUseCase) DeletePatient(ctx context.Context, id uuid.UUID) error
 	return uc.patientRepo.Delete(ctx, id)
 }
 
-// SearchPatients searches for patients within an organization for autocomplete
+// ListPatients retrieves a page of the organization's patients with filters and sorting
+func (uc *PatientUseCase) ListPatients(ctx context.Context, orgID uuid.UUID, req *dto.PatientListRequest) (*dto.PatientListResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+
+	filters := repositories.PatientListFilters{
+		OrganizationID: orgID,
+		HasUpcoming:    req.HasUpcomingAppointment,
+		SortBy:         repositories.PatientSortName,
+		Page:           page,
+		Limit:          limit,
+	}
+
+	switch req.Status {
+	case "", "active":
+	case "archived":
+		filters.Archived = true
+	default:
+		return nil, entities.ErrInvalidPatientListStatus
+	}
+
+	switch req.Sort {
+	case "":
+	case repositories.PatientSortName, repositories.PatientSortCreatedAt, repositories.PatientSortLastVisit, repositories.PatientSortNextAppointment:
+		filters.SortBy = req.Sort
+	default:
+		return nil, entities.ErrInvalidPatientListSort
+	}
+	switch req.Order {
+	case "", "asc":
+	case "desc":
+		filters.SortDesc = true
+	default:
+		return nil, entities.ErrInvalidPatientListSort
+	}
+
+	var err error
+	if filters.CreatedFrom, err = parsePatientListDate(req.CreatedFrom); err != nil {
+		return nil, err
+	}
+	if filters.CreatedTo, err = parsePatientListDate(req.CreatedTo); err != nil {
+		return nil, err
+	}
+	if filters.CreatedTo != nil {
+		end := filters.CreatedTo.AddDate(0, 0, 1) // Include the whole end day
+		filters.CreatedTo = &end
+	}
+	if filters.LastVisitBefore, err = parsePatientListDate(req.LastVisitBefore); err != nil {
+		return nil, err
+	}
+
+	items, total, err := uc.patientRepo.ListByOrganization(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.PatientListItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = dto.ToPatientListItemResponse(item)
+	}
+
+	return &dto.PatientListResponse{
+		Patients: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// parsePatientListDate parses an optional YYYY-MM-DD listing filter
+func parsePatientListDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, entities.ErrInvalidPatientListDate
+	}
+	return &date, nil
+}
+
+// GetPatientDetail retrieves a patient of the organization with its appointment history and stats
+func (uc *PatientUseCase) GetPatientDetail(ctx context.Context, orgID, patientID uuid.UUID) (*dto.PatientDetailResponse, error) {
+	patient, err := uc.getPatientInOrganization(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	archivedAt, err := uc.patientRepo.GetArchivedAt(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.appointmentRepo.GetPatientHistory(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments := make([]*entities.Appointment, len(history))
+	responses := make([]*dto.PatientAppointmentResponse, len(history))
+	for i, entry := range history {
+		appointments[i] = entry.Appointment
+		responses[i] = dto.ToPatientAppointmentResponse(entry)
+	}
+
+	return &dto.PatientDetailResponse{
+		Patient:      dto.ToPatientResponse(patient),
+		ArchivedAt:   archivedAt,
+		Stats:        dto.ToPatientStatsResponse(services.SummarizePatientAppointments(appointments, time.Now())),
+		Appointments: responses,
+	}, nil
+}
+
+// RemovePatient removes a patient from the organization. Patients with appointments or
+// clinical records in the organization are archived so their history is kept; others are
+// unlinked, and deleted entirely when no other organization or appointment references them.
+func (uc *PatientUseCase) RemovePatient(ctx context.Context, orgID, patientID uuid.UUID, removedBy *uuid.UUID) (*dto.PatientDeletionResponse, error) {
+	patient, err := uc.getPatientInOrganization(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.appointmentRepo.GetPatientHistory(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasRecords, err := uc.patientRepo.HasClinicalRecords(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) > 0 || hasRecords {
+		archivedAt, err := uc.patientRepo.GetArchivedAt(ctx, patientID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if archivedAt != nil {
+			return &dto.PatientDeletionResponse{PatientID: patientID, Archived: true, ArchivedAt: archivedAt}, nil
+		}
+
+		now := time.Now()
+		err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := uc.patientRepo.SetArchived(ctx, patientID, orgID, &now, removedBy); err != nil {
+				return err
+			}
+			return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventPatientArchived, entities.AggregatePatient, patientID, &dto.PatientEventData{
+				Patient: dto.ToPatientResponse(patient),
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &dto.PatientDeletionResponse{PatientID: patientID, Archived: true, ArchivedAt: &now}, nil
+	}
+
+	// Appointments booked through other organizations keep the patient row alive
+	appointments, err := uc.appointmentRepo.GetByPatientID(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	organizations, err := uc.patientRepo.CountOrganizations(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.patientRepo.RemoveFromOrganization(ctx, patientID, orgID); err != nil {
+			return err
+		}
+		if organizations == 1 && len(appointments) == 0 {
+			if err := uc.patientRepo.Delete(ctx, patientID); err != nil {
+				return err
+			}
+		}
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventPatientDeleted, entities.AggregatePatient, patientID, &dto.PatientEventData{
+			Patient: dto.ToPatientResponse(patient),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PatientDeletionResponse{PatientID: patientID}, nil
+}
+
+// RestorePatient brings an archived patient back to the organization's listings and search
+func (uc *PatientUseCase) RestorePatient(ctx context.Context, orgID, patientID uuid.UUID) (*dto.PatientResponse, error) {
+	patient, err := uc.getPatientInOrganization(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	archivedAt, err := uc.patientRepo.GetArchivedAt(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if archivedAt == nil {
+		return dto.ToPatientResponse(patient), nil
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.patientRepo.SetArchived(ctx, patientID, orgID, nil, nil); err != nil {
+			return err
+		}
+		return raiseEvent(ctx, uc.outboxRepo, orgID, entities.EventPatientRestored, entities.AggregatePatient, patientID, &dto.PatientEventData{
+			Patient: dto.ToPatientResponse(patient),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToPatientResponse(patient), nil
+}
+
+// getPatientInOrganization retrieves a patient and verifies it belongs to the organization
+func (uc *PatientUseCase) getPatientInOrganization(ctx context.Context, orgID, patientID uuid.UUID) (*entities.Patient, error) {
+	patient, err := uc.patientRepo.GetByID(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	if patient == nil {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound // Return not found to avoid leaking patient existence
+	}
+
+	return patient, nil
+}
+
+// SearchPatients searches for patients within an organization for autocomplete, most relevant first
 func (uc *PatientUseCase) SearchPatients(ctx context.Context, orgID uuid.UUID, req *dto.PatientSearchRequest) (*dto.PatientSearchResult, error) {
 	// Set default limit if not provided
 	limit := req.Limit
@@ -192,24 +518,107 @@ func (uc *PatientUseCase) SearchPatients(ctx context.Context, orgID uuid.UUID, r
 		limit = 100 // Max limit
 	}
 
-	// Search for patients
-	patients, err := uc.patientRepo.SearchPatients(ctx, orgID, req.Query, limit)
+	var after *repositories.PatientSearchCursor
+	if req.Cursor != "" {
+		cursor, err := decodePatientSearchCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	query := services.ParsePatientSearchQuery(req.Query)
+	if query.IsEmpty() {
+		return &dto.PatientSearchResult{Patients: []dto.PatientSearchResponse{}}, nil
+	}
+
+	// A query that is a whole phone number is matched on its national number, so
+	// "044 55 1234 5678" finds the patient stored as +525512345678
+	if len(query.Terms) == 0 {
+		region, err := organizationPhoneRegion(ctx, uc.orgRepo, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if phone, err := entities.ParsePhoneNumber(req.Query, region); err == nil {
+			query.PhoneDigits = []string{phone.NationalNumber()}
+		}
+	}
+
+	// Fetch one extra result to know whether there is a next page
+	results, err := uc.patientRepo.SearchPatients(ctx, repositories.PatientSearchFilters{
+		OrganizationID: orgID,
+		Terms:          query.Terms,
+		PhoneDigits:    query.PhoneDigits,
+		After:          after,
+		Limit:          limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor *string
+	if len(results) > limit {
+		results = results[:limit]
+		last := results[limit-1]
+		cursor := encodePatientSearchCursor(last.Rank, last.Patient.ID)
+		nextCursor = &cursor
+	}
+
+	// Family members share a household so the same phone number is told apart
+	patientIDs := make([]uuid.UUID, len(results))
+	for i, result := range results {
+		patientIDs[i] = result.Patient.ID
+	}
+	households, err := uc.relationshipRepo.ListHouseholds(ctx, orgID, patientIDs)
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert to response DTOs
-	patientResponses := make([]dto.PatientSearchResponse, len(patients))
-	for i, patient := range patients {
-		patientResponses[i] = dto.ToPatientSearchResponse(patient)
+	now := time.Now()
+	patientResponses := make([]dto.PatientSearchResponse, len(results))
+	for i, result := range results {
+		patientResponses[i] = dto.ToPatientSearchResponse(result)
+		patientResponses[i].Household = dto.ToHouseholdSummary(households[result.Patient.ID], now)
 	}
 
 	return &dto.PatientSearchResult{
-		Patients: patientResponses,
-		Total:    len(patientResponses),
+		Patients:   patientResponses,
+		Total:      len(patientResponses),
+		NextCursor: nextCursor,
 	}, nil
 }
 
+// encodePatientSearchCursor builds the opaque cursor pointing after the given result.
+// The rank is written with full precision so the keyset comparison is exact.
+func encodePatientSearchCursor(rank float64, patientID uuid.UUID) string {
+	raw := strconv.FormatFloat(rank, 'g', -1, 64) + "|" + patientID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePatientSearchCursor parses a cursor built by encodePatientSearchCursor
+func decodePatientSearchCursor(cursor string) (*repositories.PatientSearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, entities.ErrInvalidPatientSearchCursor
+	}
+
+	rankPart, idPart, found := strings.Cut(string(raw), "|")
+	if !found {
+		return nil, entities.ErrInvalidPatientSearchCursor
+	}
+	rank, err := strconv.ParseFloat(rankPart, 64)
+	if err != nil {
+		return nil, entities.ErrInvalidPatientSearchCursor
+	}
+	patientID, err := uuid.Parse(idPart)
+	if err != nil {
+		return nil, entities.ErrInvalidPatientSearchCursor
+	}
+
+	return &repositories.PatientSearchCursor{Rank: rank, PatientID: patientID}, nil
+}
+
 // AddPatientToOrganization links a patient to an organization
 func (uc *PatientUseCase) AddPatientToOrganization(ctx context.Context, patientID, orgID uuid.UUID) error {
 	// Verify patient exists
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+)
+
+// PhoneBackfillUseCase normalizes phone numbers stored before E.164 was enforced
+type PhoneBackfillUseCase struct {
+	repo repositories.PhoneBackfillRepository
+}
+
+// NewPhoneBackfillUseCase creates a new instance of PhoneBackfillUseCase
+func NewPhoneBackfillUseCase(repo repositories.PhoneBackfillRepository) *PhoneBackfillUseCase {
+	return &PhoneBackfillUseCase{
+		repo: repo,
+	}
+}
+
+// BackfillPhones parses every stored patient, doctor and clinic phone against its
+// organization's default region and stores the E.164 form. Unparseable phones keep the
+// value as typed, lose any stale E.164 form and are listed in the report. Nothing is
+// written on a dry run.
+func (uc *PhoneBackfillUseCase) BackfillPhones(ctx context.Context, dryRun bool) (*dto.PhoneBackfillReport, error) {
+	report := &dto.PhoneBackfillReport{
+		DryRun:      dryRun,
+		Unparseable: []dto.PhoneBackfillFailure{},
+	}
+
+	for _, owner := range repositories.PhoneOwners {
+		records, err := uc.repo.GetPhoneRecords(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			report.Scanned++
+
+			var e164 *string
+			phone, err := entities.ParsePhoneNumber(record.Phone, record.Region)
+			if err == nil {
+				normalized := phone.E164()
+				e164 = &normalized
+			} else {
+				report.Unparseable = append(report.Unparseable, dto.PhoneBackfillFailure{
+					Owner:  string(record.Owner),
+					ID:     record.ID,
+					Phone:  record.Phone,
+					Region: record.Region,
+					Reason: err.Error(),
+				})
+			}
+
+			if sameOptionalString(e164, record.PhoneE164) {
+				report.Unchanged++
+				continue
+			}
+			if e164 != nil {
+				report.Updated++
+			}
+			if dryRun {
+				continue
+			}
+			if err := uc.repo.SetPhoneE164(ctx, record.Owner, record.ID, e164); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func sameOptionalString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,460 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// RecallUseCase handles the organization's recall rules and the worklist of patients due back.
+// Recalls are scheduled when an appointment is completed and closed when the patient books,
+// see AppointmentUseCase.
+type RecallUseCase struct {
+	recallRepo       repositories.RecallRepository
+	serviceRepo      repositories.ServiceRepository
+	patientRepo      repositories.PatientRepository
+	relationshipRepo repositories.PatientRelationshipRepository
+	txManager        repositories.TransactionManager
+}
+
+// NewRecallUseCase creates a new instance of RecallUseCase
+func NewRecallUseCase(
+	recallRepo repositories.RecallRepository,
+	serviceRepo repositories.ServiceRepository,
+	patientRepo repositories.PatientRepository,
+	relationshipRepo repositories.PatientRelationshipRepository,
+	txManager repositories.TransactionManager,
+) *RecallUseCase {
+	return &RecallUseCase{
+		recallRepo:       recallRepo,
+		serviceRepo:      serviceRepo,
+		patientRepo:      patientRepo,
+		relationshipRepo: relationshipRepo,
+		txManager:        txManager,
+	}
+}
+
+// ListRules retrieves the organization's recall rules
+func (uc *RecallUseCase) ListRules(ctx context.Context, orgID uuid.UUID, req *dto.RecallRuleListRequest) ([]*dto.RecallRuleResponse, error) {
+	rules, err := uc.recallRepo.ListRules(ctx, orgID, req.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.RecallRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = dto.ToRecallRuleResponse(rule)
+	}
+	return responses, nil
+}
+
+// CreateRule adds a recall rule for a service; each service has at most one
+func (uc *RecallUseCase) CreateRule(ctx context.Context, orgID uuid.UUID, req *dto.RecallRuleRequest) (*dto.RecallRuleResponse, error) {
+	rule, err := entities.NewRecallRule(orgID, req.ServiceID, req.IntervalMonths, req.QualifyingServiceIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkServices(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := uc.recallRepo.GetRuleByService(ctx, orgID, rule.ServiceID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return entities.ErrRecallRuleExists
+		}
+		return uc.recallRepo.CreateRule(ctx, rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToRecallRuleResponse(rule), nil
+}
+
+// UpdateRule changes a rule. The new interval applies to recalls scheduled from then on;
+// deactivating it stops scheduling recalls but keeps the open ones on the worklist.
+func (uc *RecallUseCase) UpdateRule(ctx context.Context, orgID, ruleID uuid.UUID, req *dto.UpdateRecallRuleRequest) (*dto.RecallRuleResponse, error) {
+	rule, err := uc.recallRepo.GetRule(ctx, orgID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, entities.ErrRecallRuleNotFound
+	}
+
+	rule.IntervalMonths = req.IntervalMonths
+	rule.SetQualifyingServices(req.QualifyingServiceIDs)
+	if req.Active != nil {
+		rule.Active = *req.Active
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.checkServices(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := uc.recallRepo.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return dto.ToRecallRuleResponse(rule), nil
+}
+
+// ListRecalls retrieves a page of the recall worklist, soonest due first. Only open recalls
+// are listed unless another status is asked for.
+func (uc *RecallUseCase) ListRecalls(ctx context.Context, orgID uuid.UUID, req *dto.RecallListRequest) (*dto.RecallListResponse, error) {
+	if req.Status == "" {
+		req.Status = string(entities.RecallStatusOpen)
+	}
+	return uc.listRecalls(ctx, orgID, req)
+}
+
+// ListPatientRecalls retrieves a page of a patient's recalls of any status
+func (uc *RecallUseCase) ListPatientRecalls(ctx context.Context, orgID, patientID uuid.UUID, req *dto.RecallListRequest) (*dto.RecallListResponse, error) {
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	req.PatientID = &patientID
+	return uc.listRecalls(ctx, orgID, req)
+}
+
+// GetRecall retrieves a recall with whom to contact and the attempts made
+func (uc *RecallUseCase) GetRecall(ctx context.Context, orgID, recallID uuid.UUID) (*dto.RecallResponse, error) {
+	recall, err := uc.recallRepo.Get(ctx, orgID, recallID)
+	if err != nil {
+		return nil, err
+	}
+	if recall == nil {
+		return nil, entities.ErrRecallNotFound
+	}
+
+	return uc.recallDetail(ctx, orgID, recall)
+}
+
+// RecordOutreach logs an attempt to contact the patient of an open recall
+func (uc *RecallUseCase) RecordOutreach(ctx context.Context, orgID, recallID uuid.UUID, contactedBy *uuid.UUID, req *dto.RecallOutreachRequest) (*dto.RecallResponse, error) {
+	now := time.Now()
+	contactedAt := now
+	if req.ContactedAt != nil {
+		contactedAt = *req.ContactedAt
+	}
+
+	var recall *entities.Recall
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if recall, err = uc.getRecallForUpdate(ctx, orgID, recallID); err != nil {
+			return err
+		}
+		outreach, err := recall.RecordOutreach(
+			entities.RecallOutreachChannel(strings.ToLower(strings.TrimSpace(req.Channel))),
+			entities.RecallOutreachStatus(strings.ToLower(strings.TrimSpace(req.Outcome))),
+			optionalText(req.Notes), contactedBy, contactedAt, now,
+		)
+		if err != nil {
+			return err
+		}
+		if err := uc.recallRepo.CreateOutreach(ctx, outreach); err != nil {
+			return err
+		}
+		return uc.recallRepo.Update(ctx, recall)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.recallDetail(ctx, orgID, recall)
+}
+
+// DismissRecall closes an open recall staff will not follow up on
+func (uc *RecallUseCase) DismissRecall(ctx context.Context, orgID, recallID uuid.UUID, dismissedBy *uuid.UUID, req *dto.DismissRecallRequest) (*dto.RecallResponse, error) {
+	var recall *entities.Recall
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if recall, err = uc.getRecallForUpdate(ctx, orgID, recallID); err != nil {
+			return err
+		}
+		if err := recall.Dismiss(req.Reason, dismissedBy, time.Now()); err != nil {
+			return err
+		}
+		return uc.recallRepo.Update(ctx, recall)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.recallDetail(ctx, orgID, recall)
+}
+
+func (uc *RecallUseCase) listRecalls(ctx context.Context, orgID uuid.UUID, req *dto.RecallListRequest) (*dto.RecallListResponse, error) {
+	filters := repositories.RecallFilters{
+		OrganizationID: orgID,
+		ClinicID:       req.ClinicID,
+		PatientID:      req.PatientID,
+	}
+	if req.Status != "" {
+		status := entities.RecallStatus(strings.ToLower(req.Status))
+		if !entities.IsValidRecallStatus(status) {
+			return nil, entities.ErrInvalidRecallStatus
+		}
+		filters.Status = &status
+	}
+	if req.OutreachStatus != "" {
+		outreachStatus := entities.RecallOutreachStatus(strings.ToLower(req.OutreachStatus))
+		if !entities.IsValidRecallOutreachStatus(outreachStatus) {
+			return nil, entities.ErrInvalidRecallOutreachStatus
+		}
+		filters.OutreachStatus = &outreachStatus
+	}
+
+	var err error
+	if filters.DueFrom, err = parseRecallDate(req.DueFrom); err != nil {
+		return nil, err
+	}
+	if filters.DueTo, err = parseRecallDate(req.DueTo); err != nil {
+		return nil, err
+	}
+	if filters.DueFrom != nil && filters.DueTo != nil && filters.DueFrom.After(*filters.DueTo) {
+		return nil, entities.ErrInvalidRecallDueWindow
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+	filters.Page = page
+	filters.Limit = limit
+
+	items, total, err := uc.recallRepo.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.RecallResponse, len(items))
+	for i, item := range items {
+		if responses[i], err = uc.withContact(ctx, orgID, item.Recall, item.Patient); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.RecallListResponse{
+		Recalls: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// recallDetail builds the response of a single recall, with its patient and contact attempts
+func (uc *RecallUseCase) recallDetail(ctx context.Context, orgID uuid.UUID, recall *entities.Recall) (*dto.RecallResponse, error) {
+	patient, err := uc.patientRepo.GetByID(ctx, recall.PatientID)
+	if err != nil {
+		return nil, err
+	}
+	response, err := uc.withContact(ctx, orgID, recall, patient)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := uc.recallRepo.ListOutreach(ctx, recall.ID)
+	if err != nil {
+		return nil, err
+	}
+	response.Outreach = make([]*dto.RecallOutreachResponse, len(attempts))
+	for i, outreach := range attempts {
+		response.Outreach[i] = dto.ToRecallOutreachResponse(outreach)
+	}
+	return response, nil
+}
+
+// withContact converts a recall to its response with the patient's name and whom to contact
+func (uc *RecallUseCase) withContact(ctx context.Context, orgID uuid.UUID, recall *entities.Recall, patient *entities.Patient) (*dto.RecallResponse, error) {
+	response := dto.ToRecallResponse(recall)
+	if patient == nil {
+		return response, nil
+	}
+
+	response.PatientName = patientFullName(patient)
+	contact, err := resolvePatientContact(ctx, uc.relationshipRepo, orgID, patient)
+	if err != nil {
+		return nil, err
+	}
+	response.Contact = dto.ToPatientContactResponse(contact)
+	return response, nil
+}
+
+// checkServices ensures the rule's service and qualifying services belong to the organization
+func (uc *RecallUseCase) checkServices(ctx context.Context, rule *entities.RecallRule) error {
+	for _, serviceID := range append([]string{rule.ServiceID}, rule.QualifyingServiceIDs...) {
+		service, err := uc.serviceRepo.GetByID(ctx, rule.OrganizationID, serviceID)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			return entities.ErrServiceNotFound
+		}
+	}
+	return nil
+}
+
+func (uc *RecallUseCase) getRecallForUpdate(ctx context.Context, orgID, recallID uuid.UUID) (*entities.Recall, error) {
+	recall, err := uc.recallRepo.GetForUpdate(ctx, orgID, recallID)
+	if err != nil {
+		return nil, err
+	}
+	if recall == nil {
+		return nil, entities.ErrRecallNotFound
+	}
+	return recall, nil
+}
+
+func parseRecallDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, entities.ErrInvalidRecallDueWindow
+	}
+	return &date, nil
+}
+
+// syncRecalls updates the patient's recalls after a status change of an appointment: completion
+// schedules the next recall, while a cancellation or no-show reopens the recalls it had closed.
+// clinic is nil when the appointment has no unit.
+func syncRecalls(ctx context.Context, recallRepo repositories.RecallRepository, orgID uuid.UUID, clinic *entities.Clinic, appointment *entities.Appointment) error {
+	switch appointment.Status {
+	case entities.AppointmentStatusCompleted:
+		return scheduleRecall(ctx, recallRepo, orgID, clinic, appointment)
+	case entities.AppointmentStatusCancelled, entities.AppointmentStatusNoShow:
+		return reopenRecalls(ctx, recallRepo, appointment)
+	default:
+		return nil
+	}
+}
+
+// scheduleRecall runs when an appointment is completed: the patient's open recalls the
+// appointment's service qualifies for are closed with it, and when the service has an active
+// recall rule the patient is recalled from the day of the appointment in the clinic's timezone
+func scheduleRecall(ctx context.Context, recallRepo repositories.RecallRepository, orgID uuid.UUID, clinic *entities.Clinic, appointment *entities.Appointment) error {
+	if appointment.PatientID == nil || appointment.ServiceID == nil {
+		return nil
+	}
+	if err := bookRecalls(ctx, recallRepo, orgID, appointment); err != nil {
+		return err
+	}
+
+	rule, err := recallRepo.GetRuleByService(ctx, orgID, *appointment.ServiceID)
+	if err != nil || rule == nil || !rule.Active {
+		return err
+	}
+
+	var clinicID *uuid.UUID
+	timezone := "UTC"
+	if clinic != nil {
+		clinicID = &clinic.ID
+		if clinic.Timezone != "" {
+			timezone = clinic.Timezone
+		}
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid clinic timezone %q: %w", timezone, err)
+	}
+
+	recall := entities.NewRecall(rule, clinicID, *appointment.PatientID, &appointment.ID, appointment.StartTime.In(loc))
+	return recallRepo.Create(ctx, recall)
+}
+
+// bookRecalls closes the patient's open recalls the appointment's service qualifies for
+func bookRecalls(ctx context.Context, recallRepo repositories.RecallRepository, orgID uuid.UUID, appointment *entities.Appointment) error {
+	if appointment.PatientID == nil || appointment.ServiceID == nil {
+		return nil
+	}
+
+	recalls, err := recallRepo.ListOpenByPatientForUpdate(ctx, orgID, *appointment.PatientID)
+	if err != nil || len(recalls) == 0 {
+		return err
+	}
+	rules, err := recallRepo.ListRules(ctx, orgID, true)
+	if err != nil {
+		return err
+	}
+	qualifies := make(map[uuid.UUID]bool, len(rules))
+	for _, rule := range rules {
+		qualifies[rule.ID] = rule.Qualifies(*appointment.ServiceID)
+	}
+
+	now := time.Now()
+	for _, recall := range recalls {
+		if !qualifies[recall.RuleID] {
+			continue
+		}
+		if err := recall.Book(appointment.ID, now); err != nil {
+			return err
+		}
+		if err := recallRepo.Update(ctx, recall); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reopenRecalls puts the recalls closed by booking an appointment back on the worklist, after
+// the appointment was cancelled or the patient did not show up. A recall stays closed when the
+// patient was recalled again under its rule in the meantime.
+func reopenRecalls(ctx context.Context, recallRepo repositories.RecallRepository, appointment *entities.Appointment) error {
+	recalls, err := recallRepo.ListBookedByAppointmentForUpdate(ctx, appointment.ID)
+	if err != nil || len(recalls) == 0 {
+		return err
+	}
+	open, err := recallRepo.ListOpenByPatientForUpdate(ctx, recalls[0].OrganizationID, recalls[0].PatientID)
+	if err != nil {
+		return err
+	}
+	recalled := make(map[uuid.UUID]bool, len(open))
+	for _, recall := range open {
+		recalled[recall.RuleID] = true
+	}
+
+	now := time.Now()
+	for _, recall := range recalls {
+		if recalled[recall.RuleID] {
+			continue
+		}
+		if err := recall.Reopen(now); err != nil {
+			return err
+		}
+		if err := recallRepo.Update(ctx, recall); err != nil {
+			return err
+		}
+	}
+	return nil
+}
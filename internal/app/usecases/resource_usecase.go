@@ -0,0 +1,385 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// maxCalendarDays caps the range of a resource calendar
+const maxCalendarDays = 31
+
+// ResourceUseCase handles equipment and the calendars of a clinic's staff, units and equipment
+type ResourceUseCase struct {
+	equipmentRepo   repositories.EquipmentRepository
+	appointmentRepo repositories.AppointmentRepository
+	doctorRepo      repositories.DoctorRepository
+	unitRepo        repositories.UnitRepository
+	clinicRepo      repositories.ClinicRepository
+}
+
+// NewResourceUseCase creates a new instance of ResourceUseCase
+func NewResourceUseCase(
+	equipmentRepo repositories.EquipmentRepository,
+	appointmentRepo repositories.AppointmentRepository,
+	doctorRepo repositories.DoctorRepository,
+	unitRepo repositories.UnitRepository,
+	clinicRepo repositories.ClinicRepository,
+) *ResourceUseCase {
+	return &ResourceUseCase{
+		equipmentRepo:   equipmentRepo,
+		appointmentRepo: appointmentRepo,
+		doctorRepo:      doctorRepo,
+		unitRepo:        unitRepo,
+		clinicRepo:      clinicRepo,
+	}
+}
+
+// ListEquipment lists the equipment of a clinic of the organization
+func (uc *ResourceUseCase) ListEquipment(ctx context.Context, orgID uuid.UUID, req *dto.EquipmentListRequest) ([]*dto.EquipmentResponse, error) {
+	clinicID, err := uuid.Parse(req.ClinicID)
+	if err != nil {
+		return nil, entities.ErrInvalidClinicID
+	}
+	if _, err := uc.getClinic(ctx, orgID, clinicID); err != nil {
+		return nil, err
+	}
+
+	list, err := uc.equipmentRepo.ListByClinic(ctx, orgID, clinicID, req.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.EquipmentResponse, len(list))
+	for i, equipment := range list {
+		responses[i] = dto.ToEquipmentResponse(equipment)
+	}
+	return responses, nil
+}
+
+// GetEquipment retrieves a piece of equipment of the organization
+func (uc *ResourceUseCase) GetEquipment(ctx context.Context, orgID, id uuid.UUID) (*dto.EquipmentResponse, error) {
+	equipment, err := uc.getEquipment(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToEquipmentResponse(equipment), nil
+}
+
+// CreateEquipment adds a piece of equipment to a clinic of the organization
+func (uc *ResourceUseCase) CreateEquipment(ctx context.Context, orgID uuid.UUID, req *dto.CreateEquipmentRequest) (*dto.EquipmentResponse, error) {
+	if _, err := uc.getClinic(ctx, orgID, req.ClinicID); err != nil {
+		return nil, err
+	}
+
+	equipment, err := entities.NewEquipment(req.ClinicID, req.Name, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.equipmentRepo.Create(ctx, equipment); err != nil {
+		return nil, err
+	}
+
+	return dto.ToEquipmentResponse(equipment), nil
+}
+
+// UpdateEquipment renames, describes or deactivates a piece of equipment. Deactivated equipment
+// keeps its bookings but cannot be booked again.
+func (uc *ResourceUseCase) UpdateEquipment(ctx context.Context, orgID, id uuid.UUID, req *dto.UpdateEquipmentRequest) (*dto.EquipmentResponse, error) {
+	equipment, err := uc.getEquipment(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	equipment.Name = strings.TrimSpace(req.Name)
+	equipment.Description = req.Description
+	if req.IsActive != nil {
+		equipment.IsActive = *req.IsActive
+	}
+	if err := equipment.Validate(); err != nil {
+		return nil, err
+	}
+	equipment.UpdatedAt = time.Now()
+
+	if err := uc.equipmentRepo.Update(ctx, equipment); err != nil {
+		return nil, err
+	}
+
+	return dto.ToEquipmentResponse(equipment), nil
+}
+
+// GetResourceCalendar returns one lane per staff member, unit and piece of equipment of a clinic
+// with the appointments holding it between two dates of the clinic's timezone, both inclusive.
+// Inactive resources only get a lane when they are still booked in the range.
+func (uc *ResourceUseCase) GetResourceCalendar(ctx context.Context, orgID uuid.UUID, req *dto.ResourceCalendarRequest) (*dto.ResourceCalendarResponse, error) {
+	clinicID, err := uuid.Parse(req.ClinicID)
+	if err != nil {
+		return nil, entities.ErrInvalidClinicID
+	}
+	var resourceType entities.ResourceType
+	if req.ResourceType != "" {
+		resourceType = entities.ResourceType(req.ResourceType)
+		if !entities.IsValidResourceType(resourceType) {
+			return nil, entities.ErrInvalidResourceType
+		}
+	}
+	var resourceID *uuid.UUID
+	if req.ResourceID != "" {
+		id, err := uuid.Parse(req.ResourceID)
+		if err != nil {
+			return nil, entities.ErrAppointmentResourceNotFound
+		}
+		resourceID = &id
+	}
+
+	clinic, err := uc.getClinic(ctx, orgID, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	timezone := clinic.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clinic timezone %q: %w", timezone, err)
+	}
+
+	start, err := time.ParseInLocation("2006-01-02", req.StartDate, loc)
+	if err != nil {
+		return nil, entities.ErrInvalidCalendarRange
+	}
+	end, err := time.ParseInLocation("2006-01-02", req.EndDate, loc)
+	if err != nil || end.Before(start) || end.Sub(start) >= maxCalendarDays*24*time.Hour {
+		return nil, entities.ErrInvalidCalendarRange
+	}
+	end = end.AddDate(0, 0, 1)
+
+	lanes, err := uc.resourceLanes(ctx, orgID, clinicID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := uc.appointmentRepo.GetResourceBookings(ctx, clinicID, start.UTC(), end.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	laneByKey := make(map[string]*dto.ResourceLaneResponse, len(lanes))
+	for _, lane := range lanes {
+		laneByKey[lane.ResourceType+":"+lane.ResourceID.String()] = lane
+	}
+	for _, booking := range bookings {
+		key := string(booking.ResourceType) + ":" + booking.ResourceID.String()
+		lane, ok := laneByKey[key]
+		if !ok {
+			// Booked before it left the clinic, e.g. a doctor now based elsewhere
+			lane = &dto.ResourceLaneResponse{
+				ResourceType: string(booking.ResourceType),
+				ResourceID:   booking.ResourceID,
+				Name:         uc.resourceName(ctx, orgID, booking.ResourceType, booking.ResourceID),
+				Bookings:     []*dto.ResourceBookingResponse{},
+			}
+			laneByKey[key] = lane
+			lanes = append(lanes, lane)
+		}
+		lane.Bookings = append(lane.Bookings, toResourceBookingResponse(booking, loc))
+	}
+
+	response := &dto.ResourceCalendarResponse{
+		ClinicID:  clinicID,
+		Timezone:  timezone,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Resources: []*dto.ResourceLaneResponse{},
+	}
+	for _, lane := range lanes {
+		if resourceType != "" && lane.ResourceType != string(resourceType) {
+			continue
+		}
+		if resourceID != nil && lane.ResourceID != *resourceID {
+			continue
+		}
+		if !lane.IsActive && len(lane.Bookings) == 0 {
+			continue
+		}
+		response.Resources = append(response.Resources, lane)
+	}
+
+	return response, nil
+}
+
+// resourceLanes returns an empty lane for each doctor based at the clinic, then each unit and
+// piece of equipment of the clinic
+func (uc *ResourceUseCase) resourceLanes(ctx context.Context, orgID, clinicID uuid.UUID) ([]*dto.ResourceLaneResponse, error) {
+	var lanes []*dto.ResourceLaneResponse
+
+	doctors, err := uc.doctorRepo.GetByOrganizationID(ctx, orgID, &clinicID)
+	if err != nil {
+		return nil, err
+	}
+	for _, doctor := range doctors {
+		color := doctor.Doctor.Color
+		lanes = append(lanes, &dto.ResourceLaneResponse{
+			ResourceType: string(entities.ResourceTypeStaff),
+			ResourceID:   doctor.Doctor.ID,
+			Name:         doctor.Doctor.Name,
+			Color:        &color,
+			IsActive:     doctor.Doctor.IsActive,
+			Bookings:     []*dto.ResourceBookingResponse{},
+		})
+	}
+
+	units, err := uc.unitRepo.GetByClinicID(ctx, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	for _, unit := range units {
+		lanes = append(lanes, &dto.ResourceLaneResponse{
+			ResourceType: string(entities.ResourceTypeUnit),
+			ResourceID:   unit.ID,
+			Name:         unit.Name,
+			IsActive:     unit.IsActive,
+			Bookings:     []*dto.ResourceBookingResponse{},
+		})
+	}
+
+	equipment, err := uc.equipmentRepo.ListByClinic(ctx, orgID, clinicID, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range equipment {
+		lanes = append(lanes, &dto.ResourceLaneResponse{
+			ResourceType: string(entities.ResourceTypeEquipment),
+			ResourceID:   item.ID,
+			Name:         item.Name,
+			IsActive:     item.IsActive,
+			Bookings:     []*dto.ResourceBookingResponse{},
+		})
+	}
+
+	return lanes, nil
+}
+
+// resourceName looks up the name of a booked resource missing from the clinic's lanes
+func (uc *ResourceUseCase) resourceName(ctx context.Context, orgID uuid.UUID, resourceType entities.ResourceType, resourceID uuid.UUID) string {
+	switch resourceType {
+	case entities.ResourceTypeStaff:
+		if doctor, err := uc.doctorRepo.GetByID(ctx, resourceID); err == nil && doctor != nil {
+			return doctor.Name
+		}
+	case entities.ResourceTypeUnit:
+		if unit, err := uc.unitRepo.GetByID(ctx, resourceID); err == nil && unit != nil {
+			return unit.Name
+		}
+	case entities.ResourceTypeEquipment:
+		if equipment, err := uc.equipmentRepo.Get(ctx, orgID, resourceID); err == nil && equipment != nil {
+			return equipment.Name
+		}
+	}
+	return ""
+}
+
+func (uc *ResourceUseCase) getClinic(ctx context.Context, orgID, clinicID uuid.UUID) (*entities.Clinic, error) {
+	clinic, err := uc.clinicRepo.GetByID(ctx, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	if clinic == nil || clinic.OrganizationID != orgID {
+		return nil, entities.ErrClinicNotFound
+	}
+	return clinic, nil
+}
+
+func (uc *ResourceUseCase) getEquipment(ctx context.Context, orgID, id uuid.UUID) (*entities.Equipment, error) {
+	equipment, err := uc.equipmentRepo.Get(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if equipment == nil {
+		return nil, entities.ErrEquipmentNotFound
+	}
+	return equipment, nil
+}
+
+// toResourceBookingResponse converts a booking to its calendar entry in the clinic's timezone
+func toResourceBookingResponse(booking *repositories.ResourceBooking, loc *time.Location) *dto.ResourceBookingResponse {
+	response := &dto.ResourceBookingResponse{
+		AppointmentID: booking.Appointment.ID,
+		PatientID:     booking.Appointment.PatientID,
+		ServiceID:     booking.Appointment.ServiceID,
+		Status:        string(booking.Appointment.Status),
+		Role:          (*string)(booking.Role),
+		StartTime:     booking.Appointment.StartTime.In(loc).Format("2006-01-02T15:04:05"),
+		EndTime:       booking.Appointment.EndTime.In(loc).Format("2006-01-02T15:04:05"),
+	}
+	if booking.Patient != nil {
+		response.PatientName = patientFullName(booking.Patient)
+	}
+	return response
+}
+
+// checkAppointmentResources verifies that the additional resources of an appointment belong to the
+// organization, are active and, for units and equipment, are in the appointment's clinic
+func checkAppointmentResources(
+	ctx context.Context,
+	doctorRepo repositories.DoctorRepository,
+	unitRepo repositories.UnitRepository,
+	equipmentRepo repositories.EquipmentRepository,
+	orgID, clinicID uuid.UUID,
+	resources []*entities.AppointmentResource,
+) error {
+	for _, resource := range resources {
+		switch resource.Type {
+		case entities.ResourceTypeStaff:
+			doctor, err := doctorRepo.GetByID(ctx, resource.ResourceID)
+			if err != nil {
+				return err
+			}
+			if doctor == nil || doctor.OrganizationID != orgID {
+				return entities.ErrAppointmentResourceNotFound
+			}
+			if !doctor.IsActive {
+				return entities.ErrAppointmentResourceInactive
+			}
+		case entities.ResourceTypeUnit:
+			unit, err := unitRepo.GetByID(ctx, resource.ResourceID)
+			if err != nil {
+				return err
+			}
+			if unit == nil {
+				return entities.ErrAppointmentResourceNotFound
+			}
+			if unit.ClinicID != clinicID {
+				return entities.ErrResourceClinicMismatch
+			}
+			if !unit.IsActive {
+				return entities.ErrAppointmentResourceInactive
+			}
+		case entities.ResourceTypeEquipment:
+			equipment, err := equipmentRepo.Get(ctx, orgID, resource.ResourceID)
+			if err != nil {
+				return err
+			}
+			if equipment == nil {
+				return entities.ErrAppointmentResourceNotFound
+			}
+			if equipment.ClinicID != clinicID {
+				return entities.ErrResourceClinicMismatch
+			}
+			if !equipment.IsActive {
+				return entities.ErrAppointmentResourceInactive
+			}
+		default:
+			return entities.ErrInvalidResourceType
+		}
+	}
+	return nil
+}
@@ -0,0 +1,430 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+	"dental-scheduler-backend/pkg/tokens"
+
+	"github.com/google/uuid"
+)
+
+// StaffUseCase handles staff invitations and organization member management
+type StaffUseCase struct {
+	userRepo         repositories.UserRepository
+	invitationRepo   repositories.StaffInvitationRepository
+	doctorRepo       repositories.DoctorRepository
+	organizationRepo repositories.OrganizationRepository
+	txManager        repositories.TransactionManager
+	mailer           gateways.Mailer
+	tokenSecret      []byte
+	invitationTTL    time.Duration
+	invitationURL    string
+	logger           *logger.Logger
+}
+
+// NewStaffUseCase creates a new instance of StaffUseCase
+func NewStaffUseCase(
+	userRepo repositories.UserRepository,
+	invitationRepo repositories.StaffInvitationRepository,
+	doctorRepo repositories.DoctorRepository,
+	organizationRepo repositories.OrganizationRepository,
+	txManager repositories.TransactionManager,
+	mailer gateways.Mailer,
+	tokenSecret string,
+	invitationTTL time.Duration,
+	invitationURL string,
+	logger *logger.Logger,
+) *StaffUseCase {
+	return &StaffUseCase{
+		userRepo:         userRepo,
+		invitationRepo:   invitationRepo,
+		doctorRepo:       doctorRepo,
+		organizationRepo: organizationRepo,
+		txManager:        txManager,
+		mailer:           mailer,
+		tokenSecret:      []byte(tokenSecret),
+		invitationTTL:    invitationTTL,
+		invitationURL:    invitationURL,
+		logger:           logger,
+	}
+}
+
+// InviteUser creates an invitation and emails a signed acceptance link. The previous pending
+// invitation of the same email is replaced in one transaction, and the new invitation is revoked
+// again when the email cannot be sent.
+func (uc *StaffUseCase) InviteUser(ctx context.Context, orgID uuid.UUID, inviterID uuid.UUID, req *dto.InviteStaffRequest) (*dto.StaffInvitationResponse, error) {
+	if len(uc.tokenSecret) == 0 {
+		return nil, fmt.Errorf("staff invitation secret is not configured")
+	}
+
+	roles, err := dto.ParseStaffRoles(req.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := entities.NewStaffInvitation(orgID, req.Email, roles, &inviterID, uc.invitationTTL)
+	if err := invitation.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Reject invitations for users that are already members
+	existingProfile, err := uc.userRepo.GetByEmail(ctx, invitation.Email)
+	if err == nil && existingProfile != nil && existingProfile.BelongsToOrganization(orgID) {
+		return nil, entities.ErrUserAlreadyMember
+	}
+
+	if req.DoctorID != nil {
+		if _, err := uc.getAssignableDoctor(ctx, orgID, *req.DoctorID, nil); err != nil {
+			return nil, err
+		}
+		invitation.DoctorID = req.DoctorID
+	}
+
+	// Re-inviting the same email replaces the previous pending invitation
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		pending, err := uc.invitationRepo.GetPendingByEmail(ctx, orgID, invitation.Email)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			pending.Revoke()
+			if err := uc.invitationRepo.Update(ctx, pending); err != nil {
+				return err
+			}
+		}
+		return uc.invitationRepo.Create(ctx, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// An invitation that never reached its recipient is revoked so it cannot be accepted later
+	if err := uc.sendInvitationEmail(ctx, invitation); err != nil {
+		invitation.Revoke()
+		if revokeErr := uc.invitationRepo.Update(ctx, invitation); revokeErr != nil {
+			uc.logger.Logger.WithError(revokeErr).WithField("invitation_id", invitation.ID).Error("Failed to revoke undelivered staff invitation")
+		}
+		return nil, fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	return dto.ToStaffInvitationResponse(invitation), nil
+}
+
+// ListInvitations retrieves all invitations of an organization
+func (uc *StaffUseCase) ListInvitations(ctx context.Context, orgID uuid.UUID) ([]*dto.StaffInvitationResponse, error) {
+	invitations, err := uc.invitationRepo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.StaffInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = dto.ToStaffInvitationResponse(invitation)
+	}
+
+	return responses, nil
+}
+
+// RevokeInvitation cancels a pending invitation
+func (uc *StaffUseCase) RevokeInvitation(ctx context.Context, orgID, invitationID uuid.UUID) error {
+	invitation, err := uc.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation == nil || invitation.OrganizationID != orgID {
+		return entities.ErrInvitationNotFound
+	}
+	if !invitation.IsPending() {
+		return entities.ErrInvitationNotPending
+	}
+
+	invitation.Revoke()
+	return uc.invitationRepo.Update(ctx, invitation)
+}
+
+// AcceptInvitation joins the authenticated profile to the inviting organization. The invitation
+// is locked while the doctor link, the profile and the invitation are saved, so it can only be
+// accepted once.
+func (uc *StaffUseCase) AcceptInvitation(ctx context.Context, profileID uuid.UUID, req *dto.AcceptInvitationRequest) (*dto.StaffMemberResponse, error) {
+	if len(uc.tokenSecret) == 0 {
+		return nil, fmt.Errorf("staff invitation secret is not configured")
+	}
+
+	subject, err := tokens.Verify(uc.tokenSecret, req.Token, time.Now())
+	if err != nil {
+		if err == tokens.ErrExpiredToken {
+			return nil, entities.ErrInvitationExpired
+		}
+		return nil, entities.ErrInvalidInvitationToken
+	}
+
+	invitationID, err := uuid.Parse(subject)
+	if err != nil {
+		return nil, entities.ErrInvalidInvitationToken
+	}
+
+	var invitation *entities.StaffInvitation
+	var profile *entities.Profile
+	var linkedDoctorID *uuid.UUID
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		invitation, err = uc.invitationRepo.GetByIDForUpdate(ctx, invitationID)
+		if err != nil {
+			return err
+		}
+		if invitation == nil {
+			return entities.ErrInvitationNotFound
+		}
+		if err := invitation.CanBeAccepted(time.Now()); err != nil {
+			return err
+		}
+
+		profile, err = uc.userRepo.GetByID(ctx, profileID)
+		if err != nil || profile == nil {
+			return entities.ErrProfileNotFound
+		}
+		if !invitation.MatchesEmail(profile.Email) {
+			return entities.ErrInvitationEmailMismatch
+		}
+		if profile.OrganizationID != nil && !profile.BelongsToOrganization(invitation.OrganizationID) {
+			return entities.ErrProfileInAnotherOrganization
+		}
+
+		profile.SetOrganization(invitation.OrganizationID)
+		profile.SetRoles(invitation.StaffRoles())
+		profile.Activate()
+
+		if invitation.DoctorID != nil {
+			doctor, err := uc.getAssignableDoctor(ctx, invitation.OrganizationID, *invitation.DoctorID, &profile.ID)
+			if err != nil {
+				return err
+			}
+			doctor.LinkToUser(profile.ID)
+			if err := uc.doctorRepo.Update(ctx, doctor); err != nil {
+				return err
+			}
+			profile.AddRole(entities.RoleDoctor)
+			linkedDoctorID = &doctor.ID
+		}
+
+		if err := uc.userRepo.Update(ctx, profile); err != nil {
+			return err
+		}
+
+		invitation.Accept(profile.ID)
+		return uc.invitationRepo.Update(ctx, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Logger.WithFields(map[string]interface{}{
+		"invitation_id":   invitation.ID,
+		"organization_id": invitation.OrganizationID,
+		"profile_id":      profile.ID,
+	}).Info("Staff invitation accepted")
+
+	return dto.ToStaffMemberResponse(profile, linkedDoctorID), nil
+}
+
+// ListMembers retrieves all profiles of an organization with their linked doctor
+func (uc *StaffUseCase) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*dto.StaffMemberResponse, error) {
+	profiles, err := uc.userRepo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	doctorsByUser, err := uc.doctorsByUserID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.StaffMemberResponse, len(profiles))
+	for i, profile := range profiles {
+		responses[i] = dto.ToStaffMemberResponse(profile, doctorsByUser[profile.ID])
+	}
+
+	return responses, nil
+}
+
+// UpdateMemberRoles replaces the roles of an organization member
+func (uc *StaffUseCase) UpdateMemberRoles(ctx context.Context, orgID, actorID, memberID uuid.UUID, req *dto.UpdateMemberRolesRequest) (*dto.StaffMemberResponse, error) {
+	roles, err := dto.ParseStaffRoles(req.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := uc.getMember(ctx, orgID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Admins cannot lock themselves out by dropping their own admin role
+	if actorID == memberID && profile.IsAdmin() && !containsRole(roles, entities.RoleAdmin) {
+		return nil, entities.ErrCannotModifyOwnAccess
+	}
+
+	profile.SetRoles(roles)
+	if err := uc.userRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return uc.toMemberResponse(ctx, orgID, profile)
+}
+
+// LinkMemberToDoctor links an organization member to a doctor record
+func (uc *StaffUseCase) LinkMemberToDoctor(ctx context.Context, orgID, memberID uuid.UUID, req *dto.LinkMemberDoctorRequest) (*dto.StaffMemberResponse, error) {
+	profile, err := uc.getMember(ctx, orgID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	doctor, err := uc.getAssignableDoctor(ctx, orgID, req.DoctorID, &profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	doctor.LinkToUser(profile.ID)
+	if err := uc.doctorRepo.Update(ctx, doctor); err != nil {
+		return nil, err
+	}
+
+	if !profile.IsDoctor() {
+		profile.AddRole(entities.RoleDoctor)
+		if err := uc.userRepo.Update(ctx, profile); err != nil {
+			return nil, err
+		}
+	}
+
+	return dto.ToStaffMemberResponse(profile, &doctor.ID), nil
+}
+
+// DeactivateMember blocks a member from accessing the organization
+func (uc *StaffUseCase) DeactivateMember(ctx context.Context, orgID, actorID, memberID uuid.UUID) (*dto.StaffMemberResponse, error) {
+	if actorID == memberID {
+		return nil, entities.ErrCannotModifyOwnAccess
+	}
+
+	profile, err := uc.getMember(ctx, orgID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Deactivate()
+	if err := uc.userRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return uc.toMemberResponse(ctx, orgID, profile)
+}
+
+// ReactivateMember restores access for a deactivated member
+func (uc *StaffUseCase) ReactivateMember(ctx context.Context, orgID, memberID uuid.UUID) (*dto.StaffMemberResponse, error) {
+	profile, err := uc.getMember(ctx, orgID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Activate()
+	if err := uc.userRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return uc.toMemberResponse(ctx, orgID, profile)
+}
+
+// getMember retrieves a profile and verifies it belongs to the organization
+func (uc *StaffUseCase) getMember(ctx context.Context, orgID, memberID uuid.UUID) (*entities.Profile, error) {
+	profile, err := uc.userRepo.GetByID(ctx, memberID)
+	if err != nil || profile == nil || !profile.BelongsToOrganization(orgID) {
+		return nil, entities.ErrProfileNotFound // Avoid leaking profiles of other organizations
+	}
+	return profile, nil
+}
+
+// getAssignableDoctor retrieves a doctor of the organization that is not linked to another user
+func (uc *StaffUseCase) getAssignableDoctor(ctx context.Context, orgID, doctorID uuid.UUID, profileID *uuid.UUID) (*entities.Doctor, error) {
+	doctor, err := uc.doctorRepo.GetByID(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+	if doctor == nil || doctor.OrganizationID != orgID {
+		return nil, entities.ErrDoctorNotFound
+	}
+	if doctor.HasUserAccount() && (profileID == nil || *doctor.UserID != *profileID) {
+		return nil, entities.ErrDoctorAlreadyLinked
+	}
+	return doctor, nil
+}
+
+// doctorsByUserID maps linked user IDs to doctor IDs within an organization
+func (uc *StaffUseCase) doctorsByUserID(ctx context.Context, orgID uuid.UUID) (map[uuid.UUID]*uuid.UUID, error) {
+	doctors, err := uc.doctorRepo.GetByOrganizationID(ctx, orgID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID]*uuid.UUID, len(doctors))
+	for _, info := range doctors {
+		if info.Doctor != nil && info.Doctor.UserID != nil {
+			doctorID := info.Doctor.ID
+			result[*info.Doctor.UserID] = &doctorID
+		}
+	}
+	return result, nil
+}
+
+// toMemberResponse builds a member response including the linked doctor
+func (uc *StaffUseCase) toMemberResponse(ctx context.Context, orgID uuid.UUID, profile *entities.Profile) (*dto.StaffMemberResponse, error) {
+	doctorsByUser, err := uc.doctorsByUserID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToStaffMemberResponse(profile, doctorsByUser[profile.ID]), nil
+}
+
+// sendInvitationEmail emails the signed acceptance link for an invitation
+func (uc *StaffUseCase) sendInvitationEmail(ctx context.Context, invitation *entities.StaffInvitation) error {
+	if len(uc.tokenSecret) == 0 {
+		return fmt.Errorf("staff invitation secret is not configured")
+	}
+
+	token := tokens.Sign(uc.tokenSecret, invitation.ID.String(), invitation.ExpiresAt)
+
+	link := uc.invitationURL + "?token=" + url.QueryEscape(token)
+
+	organizationName := "your clinic"
+	if org, err := uc.organizationRepo.GetByID(ctx, invitation.OrganizationID); err == nil && org != nil {
+		organizationName = org.Name
+	}
+
+	body := fmt.Sprintf(
+		"You have been invited to join %s on Dental Scheduler.\n\nAccept the invitation here: %s\n\nThis link expires on %s.",
+		organizationName,
+		link,
+		invitation.ExpiresAt.UTC().Format(time.RFC1123),
+	)
+
+	return uc.mailer.Send(ctx, &gateways.EmailMessage{
+		To:       invitation.Email,
+		Subject:  fmt.Sprintf("Invitation to join %s", organizationName),
+		TextBody: body,
+	})
+}
+
+// containsRole checks if role is present in roles
+func containsRole(roles []entities.Role, role entities.Role) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
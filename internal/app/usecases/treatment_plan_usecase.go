@@ -0,0 +1,424 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// TreatmentPlanUseCase handles multi-visit treatment plans
+type TreatmentPlanUseCase struct {
+	planRepo    repositories.TreatmentPlanRepository
+	patientRepo repositories.PatientRepository
+	doctorRepo  repositories.DoctorRepository
+	serviceRepo repositories.ServiceRepository
+	txManager   repositories.TransactionManager
+}
+
+// NewTreatmentPlanUseCase creates a new instance of TreatmentPlanUseCase
+func NewTreatmentPlanUseCase(
+	planRepo repositories.TreatmentPlanRepository,
+	patientRepo repositories.PatientRepository,
+	doctorRepo repositories.DoctorRepository,
+	serviceRepo repositories.ServiceRepository,
+	txManager repositories.TransactionManager,
+) *TreatmentPlanUseCase {
+	return &TreatmentPlanUseCase{
+		planRepo:    planRepo,
+		patientRepo: patientRepo,
+		doctorRepo:  doctorRepo,
+		serviceRepo: serviceRepo,
+		txManager:   txManager,
+	}
+}
+
+// CreatePlan creates a draft plan for a patient with its procedures
+func (uc *TreatmentPlanUseCase) CreatePlan(ctx context.Context, orgID uuid.UUID, createdBy *uuid.UUID, req *dto.CreateTreatmentPlanRequest) (*dto.TreatmentPlanResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, req.PatientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound
+	}
+	if err := uc.ensureDoctor(ctx, orgID, req.DoctorID); err != nil {
+		return nil, err
+	}
+
+	plan, err := entities.NewTreatmentPlan(orgID, req.PatientID, req.Title, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+	plan.DoctorID = req.DoctorID
+	plan.CreatedBy = createdBy
+
+	for _, input := range req.Items {
+		item, err := uc.newItem(ctx, orgID, plan.ID, input, numbering)
+		if err != nil {
+			return nil, err
+		}
+		if err := plan.AddItem(item); err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return uc.planRepo.Create(ctx, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToTreatmentPlanResponse(plan, numbering), nil
+}
+
+// GetPlan retrieves a plan with its phases and progress
+func (uc *TreatmentPlanUseCase) GetPlan(ctx context.Context, orgID, planID uuid.UUID, req *dto.TreatmentPlanQuery) (*dto.TreatmentPlanResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.getPlan(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToTreatmentPlanResponse(plan, numbering), nil
+}
+
+// ListPatientPlans retrieves a patient's plans and the value of the work still to perform
+func (uc *TreatmentPlanUseCase) ListPatientPlans(ctx context.Context, orgID, patientID uuid.UUID, req *dto.TreatmentPlanQuery) (*dto.PatientTreatmentPlansResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	belongs, err := uc.patientRepo.PatientBelongsToOrganization(ctx, patientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, entities.ErrPatientNotFound
+	}
+
+	plans, err := uc.planRepo.ListByPatient(ctx, orgID, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.PatientTreatmentPlansResponse{
+		PatientID: patientID,
+		Plans:     make([]*dto.TreatmentPlanResponse, len(plans)),
+	}
+	for i, plan := range plans {
+		response.Plans[i] = dto.ToTreatmentPlanResponse(plan, numbering)
+
+		progress := services.SummarizeTreatmentPlan(plan)
+		if plan.Status != entities.TreatmentPlanStatusCancelled {
+			response.AcceptedValue += progress.AcceptedValue
+		}
+		response.CompletedValue += progress.CompletedValue
+		response.OutstandingValue += progress.OutstandingValue
+	}
+
+	return response, nil
+}
+
+// UpdatePlan changes the title, notes or proposing doctor of a plan
+func (uc *TreatmentPlanUseCase) UpdatePlan(ctx context.Context, orgID, planID uuid.UUID, req *dto.UpdateTreatmentPlanRequest) (*dto.TreatmentPlanResponse, error) {
+	plan, err := uc.getPlan(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DoctorID != nil {
+		if err := uc.ensureDoctor(ctx, orgID, req.DoctorID); err != nil {
+			return nil, err
+		}
+		plan.DoctorID = req.DoctorID
+	}
+	if req.Title != nil {
+		plan.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Notes != nil {
+		plan.Notes = req.Notes
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.planRepo.Update(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return dto.ToTreatmentPlanResponse(plan, entities.ToothNumberingFDI), nil
+}
+
+// AddItem adds a procedure to a plan that is still awaiting the patient's decision
+func (uc *TreatmentPlanUseCase) AddItem(ctx context.Context, orgID, planID uuid.UUID, req *dto.AddTreatmentPlanItemRequest) (*dto.TreatmentPlanResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.getPlan(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := uc.newItem(ctx, orgID, plan.ID, req.TreatmentPlanItemInput, numbering)
+	if err != nil {
+		return nil, err
+	}
+	if err := plan.AddItem(item); err != nil {
+		return nil, err
+	}
+
+	if err := uc.planRepo.CreateItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return uc.reloadPlan(ctx, orgID, planID, numbering)
+}
+
+// UpdateItem changes a procedure of a plan that is still awaiting the patient's decision
+func (uc *TreatmentPlanUseCase) UpdateItem(ctx context.Context, orgID, planID, itemID uuid.UUID, req *dto.UpdateTreatmentPlanItemRequest) (*dto.TreatmentPlanResponse, error) {
+	numbering, err := toothNumbering(req.Numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, item, err := uc.getEditableItem(ctx, orgID, planID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Phase != nil {
+		item.Phase = *req.Phase
+	}
+	if req.Position != nil {
+		item.Position = *req.Position
+	}
+	if req.Tooth != nil {
+		item.Tooth = nil
+		if *req.Tooth != "" {
+			tooth, err := entities.ParseTooth(*req.Tooth, numbering)
+			if err != nil {
+				return nil, err
+			}
+			item.Tooth = &tooth
+		}
+	}
+	if req.Description != nil {
+		item.Description = req.Description
+	}
+	if req.EstimatedCost != nil {
+		item.EstimatedCost = *req.EstimatedCost
+	}
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.planRepo.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return uc.reloadPlan(ctx, orgID, plan.ID, numbering)
+}
+
+// RemoveItem removes a procedure from a plan that is still awaiting the patient's decision
+func (uc *TreatmentPlanUseCase) RemoveItem(ctx context.Context, orgID, planID, itemID uuid.UUID) (*dto.TreatmentPlanResponse, error) {
+	plan, _, err := uc.getEditableItem(ctx, orgID, planID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.planRepo.DeleteItem(ctx, plan.ID, itemID); err != nil {
+		return nil, err
+	}
+
+	return uc.reloadPlan(ctx, orgID, plan.ID, entities.ToothNumberingFDI)
+}
+
+// PresentPlan marks a draft plan as quoted to the patient
+func (uc *TreatmentPlanUseCase) PresentPlan(ctx context.Context, orgID, planID uuid.UUID) (*dto.TreatmentPlanResponse, error) {
+	plan, err := uc.getPlan(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := plan.Present(); err != nil {
+		return nil, err
+	}
+	if err := uc.planRepo.Update(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return dto.ToTreatmentPlanResponse(plan, entities.ToothNumberingFDI), nil
+}
+
+// RecordDecision records which procedures the patient accepted; the others are declined
+func (uc *TreatmentPlanUseCase) RecordDecision(ctx context.Context, orgID, planID uuid.UUID, recordedBy *uuid.UUID, req *dto.TreatmentPlanDecisionRequest) (*dto.TreatmentPlanResponse, error) {
+	var plan *entities.TreatmentPlan
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		plan, err = uc.getPlan(ctx, orgID, planID)
+		if err != nil {
+			return err
+		}
+
+		if err := plan.RecordDecision(req.AcceptedItemIDs, recordedBy); err != nil {
+			return err
+		}
+		for _, item := range plan.Items {
+			if err := uc.planRepo.UpdateItem(ctx, item); err != nil {
+				return err
+			}
+		}
+		return uc.planRepo.Update(ctx, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToTreatmentPlanResponse(plan, entities.ToothNumberingFDI), nil
+}
+
+// CancelPlan abandons a plan
+func (uc *TreatmentPlanUseCase) CancelPlan(ctx context.Context, orgID, planID uuid.UUID) (*dto.TreatmentPlanResponse, error) {
+	plan, err := uc.getPlan(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := plan.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := uc.planRepo.Update(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return dto.ToTreatmentPlanResponse(plan, entities.ToothNumberingFDI), nil
+}
+
+// AppointmentRequestForItem builds the appointment booking an accepted procedure, for the
+// patient of the plan and the service of the procedure
+func (uc *TreatmentPlanUseCase) AppointmentRequestForItem(ctx context.Context, orgID, planID, itemID uuid.UUID, req *dto.ScheduleTreatmentPlanItemRequest) (*dto.CreateAppointmentRequest, error) {
+	plan, err := uc.getPlan(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+	item := plan.Item(itemID)
+	if item == nil {
+		return nil, entities.ErrTreatmentPlanItemNotFound
+	}
+	if item.Status != entities.TreatmentPlanItemStatusAccepted {
+		return nil, entities.ErrTreatmentPlanItemNotSchedulable
+	}
+
+	return &dto.CreateAppointmentRequest{
+		PatientID:           plan.PatientID,
+		DoctorID:            req.DoctorID,
+		UnitID:              req.UnitID,
+		ServiceID:           item.ServiceID,
+		StartTime:           req.StartTime,
+		EndTime:             req.EndTime,
+		Notes:               req.Notes,
+		TreatmentPlanItemID: &item.ID,
+	}, nil
+}
+
+// newItem validates a procedure input, defaulting its cost to the service base price
+func (uc *TreatmentPlanUseCase) newItem(ctx context.Context, orgID, planID uuid.UUID, input dto.TreatmentPlanItemInput, numbering entities.ToothNumberingSystem) (*entities.TreatmentPlanItem, error) {
+	service, err := uc.serviceRepo.GetByID(ctx, orgID, input.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, entities.ErrServiceNotFound
+	}
+
+	var tooth *entities.Tooth
+	if input.Tooth != "" {
+		parsed, err := entities.ParseTooth(input.Tooth, numbering)
+		if err != nil {
+			return nil, err
+		}
+		tooth = &parsed
+	}
+
+	var cost int64
+	switch {
+	case input.EstimatedCost != nil:
+		cost = *input.EstimatedCost
+	case service.BasePrice != nil:
+		cost = int64(math.Round(*service.BasePrice * 100)) // Base prices are stored in major units
+	}
+
+	return entities.NewTreatmentPlanItem(planID, input.Phase, service.ID, tooth, input.Description, cost)
+}
+
+// getPlan retrieves a plan of the organization
+func (uc *TreatmentPlanUseCase) getPlan(ctx context.Context, orgID, planID uuid.UUID) (*entities.TreatmentPlan, error) {
+	plan, err := uc.planRepo.GetByID(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, entities.ErrTreatmentPlanNotFound
+	}
+	return plan, nil
+}
+
+// getEditableItem retrieves a procedure of a plan still awaiting the patient's decision
+func (uc *TreatmentPlanUseCase) getEditableItem(ctx context.Context, orgID, planID, itemID uuid.UUID) (*entities.TreatmentPlan, *entities.TreatmentPlanItem, error) {
+	plan, err := uc.getPlan(ctx, orgID, planID)
+	if err != nil {
+		return nil, nil, err
+	}
+	item := plan.Item(itemID)
+	if item == nil {
+		return nil, nil, entities.ErrTreatmentPlanItemNotFound
+	}
+	if !plan.IsEditable() {
+		return nil, nil, entities.ErrTreatmentPlanNotEditable
+	}
+	return plan, item, nil
+}
+
+// reloadPlan retrieves a plan after its items changed so they come back in order
+func (uc *TreatmentPlanUseCase) reloadPlan(ctx context.Context, orgID, planID uuid.UUID, numbering entities.ToothNumberingSystem) (*dto.TreatmentPlanResponse, error) {
+	plan, err := uc.getPlan(ctx, orgID, planID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToTreatmentPlanResponse(plan, numbering), nil
+}
+
+// ensureDoctor verifies an optional doctor belongs to the organization
+func (uc *TreatmentPlanUseCase) ensureDoctor(ctx context.Context, orgID uuid.UUID, doctorID *uuid.UUID) error {
+	if doctorID == nil {
+		return nil
+	}
+	doctor, err := uc.doctorRepo.GetByID(ctx, *doctorID)
+	if err != nil {
+		return err
+	}
+	if doctor == nil || doctor.OrganizationID != orgID {
+		return entities.ErrDoctorNotFound
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// WebhookUseCase handles webhook subscriptions, deliveries and event publishing
+type WebhookUseCase struct {
+	subscriptionRepo repositories.WebhookSubscriptionRepository
+	deliveryRepo     repositories.WebhookDeliveryRepository
+}
+
+// NewWebhookUseCase creates a new instance of WebhookUseCase
+func NewWebhookUseCase(
+	subscriptionRepo repositories.WebhookSubscriptionRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+	}
+}
+
+// HandleEvent is the event bus subscriber that turns domain events into webhook
+// deliveries. The domain event ID is reused as the webhook event ID, so a
+// re-dispatched event does not create duplicate deliveries.
+func (uc *WebhookUseCase) HandleEvent(ctx context.Context, event *entities.DomainEvent) error {
+	eventType := entities.WebhookEventType(event.Type)
+	if !entities.IsValidWebhookEventType(eventType) {
+		return nil
+	}
+
+	subscriptions, err := uc.subscriptionRepo.GetActiveByOrganizationID(ctx, event.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	var matching []*entities.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if subscription.Accepts(eventType) {
+			matching = append(matching, subscription)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(&entities.WebhookPayload{
+		ID:             event.ID,
+		Type:           eventType,
+		OrganizationID: event.OrganizationID,
+		CreatedAt:      event.OccurredAt,
+		Data:           event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	for _, subscription := range matching {
+		if err := uc.deliveryRepo.Create(ctx, entities.NewWebhookDelivery(subscription, event.ID, eventType, payload)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateSubscription creates a webhook subscription; the signing secret is only returned here
+func (uc *WebhookUseCase) CreateSubscription(ctx context.Context, orgID uuid.UUID, req *dto.CreateWebhookSubscriptionRequest) (*dto.CreateWebhookSubscriptionResponse, error) {
+	eventTypes, err := dto.ParseWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := entities.NewWebhookSubscription(orgID, req.URL, eventTypes, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreateWebhookSubscriptionResponse{
+		WebhookSubscriptionResponse: *dto.ToWebhookSubscriptionResponse(subscription),
+		Secret:                      subscription.Secret,
+	}, nil
+}
+
+// ListSubscriptions retrieves all webhook subscriptions of an organization
+func (uc *WebhookUseCase) ListSubscriptions(ctx context.Context, orgID uuid.UUID) ([]*dto.WebhookSubscriptionResponse, error) {
+	subscriptions, err := uc.subscriptionRepo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.WebhookSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = dto.ToWebhookSubscriptionResponse(subscription)
+	}
+
+	return responses, nil
+}
+
+// GetSubscription retrieves a webhook subscription of an organization
+func (uc *WebhookUseCase) GetSubscription(ctx context.Context, orgID, subscriptionID uuid.UUID) (*dto.WebhookSubscriptionResponse, error) {
+	subscription, err := uc.getSubscription(ctx, orgID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToWebhookSubscriptionResponse(subscription), nil
+}
+
+// UpdateSubscription updates the URL, filter or state of a webhook subscription
+func (uc *WebhookUseCase) UpdateSubscription(ctx context.Context, orgID, subscriptionID uuid.UUID, req *dto.UpdateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error) {
+	subscription, err := uc.getSubscription(ctx, orgID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		subscription.URL = *req.URL
+	}
+	if req.Description != nil {
+		subscription.Description = req.Description
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := dto.ParseWebhookEventTypes(*req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		subscription.SetEventTypes(eventTypes)
+	}
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return dto.ToWebhookSubscriptionResponse(subscription), nil
+}
+
+// DeleteSubscription deletes a webhook subscription and its delivery history
+func (uc *WebhookUseCase) DeleteSubscription(ctx context.Context, orgID, subscriptionID uuid.UUID) error {
+	if _, err := uc.getSubscription(ctx, orgID, subscriptionID); err != nil {
+		return err
+	}
+
+	return uc.subscriptionRepo.Delete(ctx, subscriptionID)
+}
+
+// ListDeliveries retrieves the deliveries of a subscription, newest first
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, orgID, subscriptionID uuid.UUID, req *dto.WebhookDeliveryListRequest) (*dto.WebhookDeliveryListResponse, error) {
+	if _, err := uc.getSubscription(ctx, orgID, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit < 1 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+
+	filters := repositories.WebhookDeliveryFilters{
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+	if req.Status != "" {
+		status := entities.WebhookDeliveryStatus(req.Status)
+		filters.Status = &status
+	}
+
+	deliveries, total, err := uc.deliveryRepo.GetBySubscriptionID(ctx, subscriptionID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = dto.ToWebhookDeliveryResponse(delivery)
+	}
+
+	return &dto.WebhookDeliveryListResponse{
+		Deliveries: responses,
+		Pagination: dto.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+// GetDelivery retrieves a single delivery including its payload
+func (uc *WebhookUseCase) GetDelivery(ctx context.Context, orgID, deliveryID uuid.UUID) (*dto.WebhookDeliveryResponse, error) {
+	delivery, err := uc.getDelivery(ctx, orgID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToWebhookDeliveryResponse(delivery), nil
+}
+
+// ReplayDelivery schedules a delivery to be sent again with the original payload
+func (uc *WebhookUseCase) ReplayDelivery(ctx context.Context, orgID, deliveryID uuid.UUID) (*dto.WebhookDeliveryResponse, error) {
+	delivery, err := uc.getDelivery(ctx, orgID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Replay()
+	if err := uc.deliveryRepo.Update(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return dto.ToWebhookDeliveryResponse(delivery), nil
+}
+
+// getSubscription retrieves a subscription and verifies it belongs to the organization
+func (uc *WebhookUseCase) getSubscription(ctx context.Context, orgID, subscriptionID uuid.UUID) (*entities.WebhookSubscription, error) {
+	subscription, err := uc.subscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil || subscription.OrganizationID != orgID {
+		return nil, entities.ErrWebhookSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// getDelivery retrieves a delivery and verifies it belongs to the organization
+func (uc *WebhookUseCase) getDelivery(ctx context.Context, orgID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	delivery, err := uc.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil || delivery.OrganizationID != orgID {
+		return nil, entities.ErrWebhookDeliveryNotFound
+	}
+	return delivery, nil
+}
@@ -0,0 +1,69 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// ExpiredHoldReleaser releases appointments held for deposits that were not paid in time,
+// implemented by usecases.DepositUseCase
+type ExpiredHoldReleaser interface {
+	ReleaseExpiredHolds(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+// DepositHoldReleaser periodically frees the slots of appointments whose deposit is overdue
+type DepositHoldReleaser struct {
+	releaser  ExpiredHoldReleaser
+	logger    *logger.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+// NewDepositHoldReleaser creates a new instance of DepositHoldReleaser
+func NewDepositHoldReleaser(releaser ExpiredHoldReleaser, logger *logger.Logger, interval time.Duration, batchSize int) *DepositHoldReleaser {
+	return &DepositHoldReleaser{
+		releaser:  releaser,
+		logger:    logger,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Start releases overdue holds on every interval until ctx is cancelled
+func (r *DepositHoldReleaser) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				released, err := r.ReleaseDue(ctx, time.Now())
+				if err != nil {
+					r.logger.Logger.WithError(err).Error("Failed to release unpaid deposit holds")
+					continue
+				}
+				if released > 0 {
+					r.logger.Logger.WithField("released", released).Info("Released appointments with unpaid deposits")
+				}
+			}
+		}
+	}()
+}
+
+// ReleaseDue releases every hold overdue at now, batch by batch while full batches come back,
+// and returns how many were released
+func (r *DepositHoldReleaser) ReleaseDue(ctx context.Context, now time.Time) (int, error) {
+	total := 0
+	for {
+		released, err := r.releaser.ReleaseExpiredHolds(ctx, now, r.batchSize)
+		total += released
+		if err != nil || released < r.batchSize || ctx.Err() != nil {
+			return total, err
+		}
+	}
+}
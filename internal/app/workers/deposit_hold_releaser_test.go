@@ -0,0 +1,62 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+type scriptedHoldReleaser struct {
+	batches []int
+	err     error
+	calls   int
+	limits  []int
+}
+
+func (r *scriptedHoldReleaser) ReleaseExpiredHolds(ctx context.Context, now time.Time, limit int) (int, error) {
+	r.limits = append(r.limits, limit)
+	if r.calls >= len(r.batches) {
+		return 0, r.err
+	}
+	released := r.batches[r.calls]
+	r.calls++
+	return released, nil
+}
+
+func TestDepositHoldReleaserDrainsFullBatches(t *testing.T) {
+	releaser := &scriptedHoldReleaser{batches: []int{5, 5, 2}}
+	worker := NewDepositHoldReleaser(releaser, logger.NewLogger("error"), time.Minute, 5)
+
+	released, err := worker.ReleaseDue(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if released != 12 {
+		t.Errorf("expected 12 released, got %d", released)
+	}
+	if releaser.calls != 3 {
+		t.Errorf("expected 3 batches, got %d", releaser.calls)
+	}
+	for _, limit := range releaser.limits {
+		if limit != 5 {
+			t.Errorf("expected batch size 5, got %d", limit)
+		}
+	}
+}
+
+func TestDepositHoldReleaserStopsOnError(t *testing.T) {
+	failure := errors.New("database unavailable")
+	releaser := &scriptedHoldReleaser{batches: []int{3}, err: failure}
+	worker := NewDepositHoldReleaser(releaser, logger.NewLogger("error"), time.Minute, 3)
+
+	released, err := worker.ReleaseDue(context.Background(), time.Now())
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected %v, got %v", failure, err)
+	}
+	if released != 3 {
+		t.Errorf("expected the first batch to count, got %d", released)
+	}
+}
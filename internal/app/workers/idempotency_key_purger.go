@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// IdempotencyKeyPurger periodically deletes idempotency keys past their retention window
+type IdempotencyKeyPurger struct {
+	repo     repositories.IdempotencyKeyRepository
+	logger   *logger.Logger
+	interval time.Duration
+}
+
+// NewIdempotencyKeyPurger creates a new instance of IdempotencyKeyPurger
+func NewIdempotencyKeyPurger(repo repositories.IdempotencyKeyRepository, logger *logger.Logger, interval time.Duration) *IdempotencyKeyPurger {
+	return &IdempotencyKeyPurger{
+		repo:     repo,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+// Start purges expired keys on every interval until ctx is cancelled
+func (p *IdempotencyKeyPurger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := p.repo.DeleteExpired(ctx, time.Now())
+				if err != nil {
+					p.logger.Logger.WithError(err).Error("Failed to purge idempotency keys")
+					continue
+				}
+				if deleted > 0 {
+					p.logger.Logger.WithField("deleted", deleted).Debug("Purged expired idempotency keys")
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,111 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/app/events"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// OutboxRelay drains the domain event outbox and dispatches events to the bus
+type OutboxRelay struct {
+	outboxRepo   repositories.OutboxRepository
+	bus          *events.Bus
+	logger       *logger.Logger
+	pollInterval time.Duration
+	batchSize    int
+	retention    time.Duration // How long processed events are kept, 0 keeps them forever
+	lease        time.Duration // How long a claimed event is hidden from other relays
+}
+
+// NewOutboxRelay creates a new instance of OutboxRelay
+func NewOutboxRelay(
+	outboxRepo repositories.OutboxRepository,
+	bus *events.Bus,
+	logger *logger.Logger,
+	pollInterval time.Duration,
+	batchSize int,
+	retention time.Duration,
+) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo:   outboxRepo,
+		bus:          bus,
+		logger:       logger,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		retention:    retention,
+		lease:        2 * time.Minute,
+	}
+}
+
+// Start polls for due events until ctx is cancelled, purging old processed events hourly
+func (r *OutboxRelay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.pollInterval)
+		defer ticker.Stop()
+		purgeTicker := time.NewTicker(time.Hour)
+		defer purgeTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Keep draining while full batches come back
+				for {
+					processed, err := r.ProcessDue(ctx)
+					if err != nil {
+						r.logger.Logger.WithError(err).Error("Failed to relay outbox events")
+						break
+					}
+					if processed < r.batchSize || ctx.Err() != nil {
+						break
+					}
+				}
+			case <-purgeTicker.C:
+				r.purge(ctx)
+			}
+		}
+	}()
+}
+
+// ProcessDue dispatches one batch of due events and returns how many were attempted
+func (r *OutboxRelay) ProcessDue(ctx context.Context) (int, error) {
+	claimed, err := r.outboxRepo.ClaimDue(ctx, time.Now(), r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range claimed {
+		if err := r.bus.Dispatch(ctx, event); err != nil {
+			event.MarkAttemptFailed(time.Now(), err.Error())
+		} else {
+			event.MarkProcessed(time.Now())
+		}
+
+		if err := r.outboxRepo.Update(ctx, event); err != nil {
+			// The lease expires and the event is dispatched again; subscribers deduplicate by event ID
+			r.logger.Logger.WithError(err).WithField("event_id", event.ID).Error("Failed to record outbox dispatch")
+		}
+	}
+
+	return len(claimed), nil
+}
+
+// purge removes processed events older than the retention window
+func (r *OutboxRelay) purge(ctx context.Context) {
+	if r.retention <= 0 {
+		return
+	}
+
+	deleted, err := r.outboxRepo.DeleteProcessedBefore(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		r.logger.Logger.WithError(err).Error("Failed to purge outbox events")
+		return
+	}
+	if deleted > 0 {
+		r.logger.Logger.WithField("deleted", deleted).Info("Purged processed outbox events")
+	}
+}
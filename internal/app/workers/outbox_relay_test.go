@@ -0,0 +1,194 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"dental-scheduler-backend/internal/app/events"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+type memoryOutboxRepo struct {
+	mu     sync.Mutex
+	events map[uuid.UUID]*entities.OutboxEvent
+}
+
+func (r *memoryOutboxRepo) Append(ctx context.Context, domainEvents ...*entities.DomainEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, event := range domainEvents {
+		outboxEvent := entities.NewOutboxEvent(event)
+		outboxEvent.NextAttemptAt = outboxEvent.NextAttemptAt.Add(-time.Second)
+		r.events[event.ID] = outboxEvent
+	}
+	return nil
+}
+
+func (r *memoryOutboxRepo) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*entities.OutboxEvent
+	for _, event := range r.events {
+		if event.Status == entities.OutboxStatusPending && !event.NextAttemptAt.After(now) && len(due) < limit {
+			claimed := *event
+			claimed.HandledBy = append([]string(nil), event.HandledBy...)
+			event.NextAttemptAt = now.Add(lease)
+			due = append(due, &claimed)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryOutboxRepo) Update(ctx context.Context, event *entities.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[event.ID] = event
+	return nil
+}
+
+func (r *memoryOutboxRepo) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// makeDue lets a postponed event be claimed again without waiting for its backoff
+func (r *memoryOutboxRepo) makeDue(id uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[id].NextAttemptAt = time.Now().Add(-time.Second)
+}
+
+func (r *memoryOutboxRepo) get(id uuid.UUID) *entities.OutboxEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events[id]
+}
+
+func newRelayFixture(t *testing.T) (*OutboxRelay, *events.Bus, *memoryOutboxRepo, *entities.DomainEvent) {
+	t.Helper()
+
+	event, err := entities.NewDomainEvent(uuid.New(), entities.EventAppointmentCreated, entities.AggregateAppointment, uuid.New(), map[string]string{"status": "scheduled"})
+	if err != nil {
+		t.Fatalf("failed to create domain event: %v", err)
+	}
+
+	repo := &memoryOutboxRepo{events: map[uuid.UUID]*entities.OutboxEvent{}}
+	if err := repo.Append(context.Background(), event); err != nil {
+		t.Fatalf("failed to append event: %v", err)
+	}
+
+	appLogger := logger.NewLogger("error")
+	bus := events.NewBus(appLogger)
+	relay := NewOutboxRelay(repo, bus, appLogger, time.Second, 10, 0)
+	return relay, bus, repo, event
+}
+
+func TestOutboxRelay_DispatchesToMatchingSubscribers(t *testing.T) {
+	relay, bus, repo, event := newRelayFixture(t)
+
+	var received []*entities.DomainEvent
+	bus.Subscribe("appointments", func(ctx context.Context, e *entities.DomainEvent) error {
+		received = append(received, e)
+		return nil
+	}, entities.EventAppointmentCreated)
+	bus.Subscribe("patients", func(ctx context.Context, e *entities.DomainEvent) error {
+		t.Errorf("patients subscriber should not receive %s", e.Type)
+		return nil
+	}, entities.EventPatientCreated)
+
+	processed, err := relay.ProcessDue(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+	if processed != 1 {
+		t.Fatalf("expected 1 event processed, got %d", processed)
+	}
+
+	if len(received) != 1 || received[0].ID != event.ID {
+		t.Fatalf("expected the event to be dispatched once, got %d", len(received))
+	}
+	var payload map[string]string
+	if err := received[0].DecodePayload(&payload); err != nil || payload["status"] != "scheduled" {
+		t.Errorf("unexpected payload %s (err %v)", received[0].Payload, err)
+	}
+
+	stored := repo.get(event.ID)
+	if stored.Status != entities.OutboxStatusProcessed || stored.ProcessedAt == nil {
+		t.Errorf("expected event to be processed, got status %s", stored.Status)
+	}
+}
+
+func TestOutboxRelay_RetriesOnlyFailedSubscribers(t *testing.T) {
+	relay, bus, repo, event := newRelayFixture(t)
+
+	succeeded := 0
+	attempts := 0
+	bus.Subscribe("reliable", func(ctx context.Context, e *entities.DomainEvent) error {
+		succeeded++
+		return nil
+	})
+	bus.Subscribe("flaky", func(ctx context.Context, e *entities.DomainEvent) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+
+	before := time.Now()
+	if _, err := relay.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+
+	stored := repo.get(event.ID)
+	if stored.Status != entities.OutboxStatusPending {
+		t.Fatalf("expected event to stay pending, got %s", stored.Status)
+	}
+	if stored.Attempts != 1 || stored.LastError == nil {
+		t.Errorf("expected one failed attempt with an error, got attempts=%d", stored.Attempts)
+	}
+	if stored.NextAttemptAt.Before(before.Add(entities.OutboxBackoff(1))) {
+		t.Errorf("expected next attempt after backoff, got %v", stored.NextAttemptAt)
+	}
+	if !stored.IsHandledBy("reliable") || stored.IsHandledBy("flaky") {
+		t.Errorf("unexpected handled subscribers %v", stored.HandledBy)
+	}
+
+	repo.makeDue(event.ID)
+	if _, err := relay.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+
+	stored = repo.get(event.ID)
+	if stored.Status != entities.OutboxStatusProcessed {
+		t.Errorf("expected event to be processed after retry, got %s", stored.Status)
+	}
+	if succeeded != 1 {
+		t.Errorf("expected the successful subscriber to run once, ran %d times", succeeded)
+	}
+	if attempts != 2 {
+		t.Errorf("expected the failing subscriber to run twice, ran %d times", attempts)
+	}
+}
+
+func TestOutboxRelay_RecoversFromPanickingSubscriber(t *testing.T) {
+	relay, bus, repo, event := newRelayFixture(t)
+
+	bus.Subscribe("broken", func(ctx context.Context, e *entities.DomainEvent) error {
+		panic("boom")
+	})
+
+	if _, err := relay.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+
+	if stored := repo.get(event.ID); stored.Status != entities.OutboxStatusPending || stored.Attempts != 1 {
+		t.Errorf("expected a failed attempt, got status=%s attempts=%d", stored.Status, stored.Attempts)
+	}
+}
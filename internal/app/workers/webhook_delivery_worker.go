@@ -0,0 +1,141 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookHeaderEventID   = "X-Webhook-Id"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderEventType = "X-Webhook-Event"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookDeliveryWorker drains the webhook outbox and posts signed payloads to subscribers
+type WebhookDeliveryWorker struct {
+	deliveryRepo     repositories.WebhookDeliveryRepository
+	subscriptionRepo repositories.WebhookSubscriptionRepository
+	sender           gateways.WebhookSender
+	logger           *logger.Logger
+	pollInterval     time.Duration
+	batchSize        int
+	lease            time.Duration // How long a claimed delivery is hidden from other workers
+}
+
+// NewWebhookDeliveryWorker creates a new instance of WebhookDeliveryWorker
+func NewWebhookDeliveryWorker(
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	subscriptionRepo repositories.WebhookSubscriptionRepository,
+	sender gateways.WebhookSender,
+	logger *logger.Logger,
+	pollInterval time.Duration,
+	batchSize int,
+) *WebhookDeliveryWorker {
+	return &WebhookDeliveryWorker{
+		deliveryRepo:     deliveryRepo,
+		subscriptionRepo: subscriptionRepo,
+		sender:           sender,
+		logger:           logger,
+		pollInterval:     pollInterval,
+		batchSize:        batchSize,
+		lease:            2 * time.Minute,
+	}
+}
+
+// Start polls for due deliveries until ctx is cancelled
+func (w *WebhookDeliveryWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := w.ProcessDue(ctx); err != nil {
+					w.logger.Logger.WithError(err).Error("Failed to process webhook deliveries")
+				}
+			}
+		}
+	}()
+}
+
+// ProcessDue sends one batch of due deliveries and returns how many were attempted
+func (w *WebhookDeliveryWorker) ProcessDue(ctx context.Context) (int, error) {
+	deliveries, err := w.deliveryRepo.ClaimDue(ctx, time.Now(), w.batchSize, w.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		w.deliver(ctx, delivery)
+
+		if err := w.deliveryRepo.Update(ctx, delivery); err != nil {
+			// The lease expires and the delivery is retried; receivers deduplicate by event ID
+			w.logger.Logger.WithError(err).WithField("delivery_id", delivery.ID).Error("Failed to record webhook delivery attempt")
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// deliver performs a single attempt and records its outcome on the delivery
+func (w *WebhookDeliveryWorker) deliver(ctx context.Context, delivery *entities.WebhookDelivery) {
+	now := time.Now()
+
+	subscription, err := w.subscriptionRepo.GetByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		delivery.MarkAttemptFailed(now, nil, err.Error())
+		return
+	}
+	if subscription == nil || !subscription.IsActive {
+		delivery.Abandon(now, "subscription is inactive")
+		return
+	}
+
+	timestamp := now.Unix()
+	req := &gateways.WebhookRequest{
+		URL: subscription.URL,
+		Headers: map[string]string{
+			"Content-Type":         "application/json",
+			"User-Agent":           "DentalScheduler-Webhooks/1.0",
+			WebhookHeaderEventID:   delivery.EventID.String(),
+			WebhookHeaderDelivery:  delivery.ID.String(),
+			WebhookHeaderEventType: string(delivery.EventType),
+			WebhookHeaderTimestamp: strconv.FormatInt(timestamp, 10),
+			WebhookHeaderSignature: "sha256=" + entities.SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload),
+		},
+		Body: delivery.Payload,
+	}
+
+	resp, err := w.sender.Send(ctx, req)
+	logFields := map[string]interface{}{
+		"delivery_id":     delivery.ID,
+		"subscription_id": delivery.SubscriptionID,
+		"event_type":      delivery.EventType,
+		"attempt":         delivery.Attempts + 1,
+	}
+
+	switch {
+	case err != nil:
+		delivery.MarkAttemptFailed(now, nil, err.Error())
+		w.logger.Logger.WithFields(logFields).WithError(err).Warn("Webhook delivery failed")
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		delivery.MarkSucceeded(now, resp.StatusCode)
+		w.logger.Logger.WithFields(logFields).Debug("Webhook delivered")
+	default:
+		delivery.MarkAttemptFailed(now, &resp.StatusCode, fmt.Sprintf("receiver responded with status %d: %s", resp.StatusCode, resp.Body))
+		w.logger.Logger.WithFields(logFields).WithField("status_code", resp.StatusCode).Warn("Webhook delivery rejected")
+	}
+}
@@ -0,0 +1,256 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+	"dental-scheduler-backend/internal/infra/webhooks"
+)
+
+type memorySubscriptionRepo struct {
+	repositories.WebhookSubscriptionRepository
+	subscriptions map[uuid.UUID]*entities.WebhookSubscription
+}
+
+func (r *memorySubscriptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	return r.subscriptions[id], nil
+}
+
+type memoryDeliveryRepo struct {
+	repositories.WebhookDeliveryRepository
+	mu         sync.Mutex
+	deliveries map[uuid.UUID]*entities.WebhookDelivery
+}
+
+func (r *memoryDeliveryRepo) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*entities.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == entities.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) && len(due) < limit {
+			claimed := *delivery
+			delivery.NextAttemptAt = now.Add(lease)
+			due = append(due, &claimed)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryDeliveryRepo) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (r *memoryDeliveryRepo) get(id uuid.UUID) *entities.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.deliveries[id]
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts an in-process HTTP receiver that answers with the given status
+func newReceiver(t *testing.T, status int) (*httptest.Server, <-chan receivedWebhook) {
+	t.Helper()
+	received := make(chan receivedWebhook, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{header: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+		w.Write([]byte("ack"))
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func newWorkerFixture(t *testing.T, url string) (*WebhookDeliveryWorker, *memoryDeliveryRepo, *entities.WebhookSubscription, *entities.WebhookDelivery) {
+	t.Helper()
+
+	subscription, err := entities.NewWebhookSubscription(uuid.New(), url, []entities.WebhookEventType{entities.WebhookEventAppointmentCreated}, nil)
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	payload, _ := json.Marshal(entities.WebhookPayload{
+		ID:             uuid.New(),
+		Type:           entities.WebhookEventAppointmentCreated,
+		OrganizationID: subscription.OrganizationID,
+		CreatedAt:      time.Now().UTC(),
+		Data:           map[string]string{"appointment_id": uuid.New().String()},
+	})
+	delivery := entities.NewWebhookDelivery(subscription, uuid.New(), entities.WebhookEventAppointmentCreated, payload)
+	delivery.NextAttemptAt = delivery.NextAttemptAt.Add(-time.Second)
+
+	subscriptionRepo := &memorySubscriptionRepo{subscriptions: map[uuid.UUID]*entities.WebhookSubscription{subscription.ID: subscription}}
+	deliveryRepo := &memoryDeliveryRepo{deliveries: map[uuid.UUID]*entities.WebhookDelivery{delivery.ID: delivery}}
+
+	worker := NewWebhookDeliveryWorker(deliveryRepo, subscriptionRepo, webhooks.NewHTTPSender(5*time.Second), logger.NewLogger("error"), time.Second, 10)
+	return worker, deliveryRepo, subscription, delivery
+}
+
+func TestWebhookDeliveryWorker_DeliversSignedPayload(t *testing.T) {
+	server, received := newReceiver(t, http.StatusNoContent)
+	worker, deliveryRepo, subscription, delivery := newWorkerFixture(t, server.URL)
+
+	processed, err := worker.ProcessDue(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+	if processed != 1 {
+		t.Fatalf("expected 1 delivery processed, got %d", processed)
+	}
+
+	var got receivedWebhook
+	select {
+	case got = <-received:
+	default:
+		t.Fatal("receiver was not called")
+	}
+
+	if string(got.body) != string(delivery.Payload) {
+		t.Errorf("expected body %s, got %s", delivery.Payload, got.body)
+	}
+	if got.header.Get(WebhookHeaderEventType) != string(entities.WebhookEventAppointmentCreated) {
+		t.Errorf("unexpected event type header %q", got.header.Get(WebhookHeaderEventType))
+	}
+	if got.header.Get(WebhookHeaderEventID) != delivery.EventID.String() {
+		t.Errorf("unexpected event ID header %q", got.header.Get(WebhookHeaderEventID))
+	}
+
+	timestamp, err := strconv.ParseInt(got.header.Get(WebhookHeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid timestamp header: %v", err)
+	}
+	expectedSignature := "sha256=" + entities.SignWebhookPayload(subscription.Secret, timestamp, got.body)
+	if got.header.Get(WebhookHeaderSignature) != expectedSignature {
+		t.Errorf("signature mismatch: expected %s, got %s", expectedSignature, got.header.Get(WebhookHeaderSignature))
+	}
+
+	stored := deliveryRepo.get(delivery.ID)
+	if stored.Status != entities.WebhookDeliverySucceeded {
+		t.Errorf("expected status succeeded, got %s", stored.Status)
+	}
+	if stored.Attempts != 1 || stored.DeliveredAt == nil {
+		t.Errorf("expected one attempt with a delivery time, got attempts=%d delivered_at=%v", stored.Attempts, stored.DeliveredAt)
+	}
+	if stored.LastResponseStatus == nil || *stored.LastResponseStatus != http.StatusNoContent {
+		t.Errorf("expected last response status 204, got %v", stored.LastResponseStatus)
+	}
+}
+
+func TestWebhookDeliveryWorker_RetriesWithBackoff(t *testing.T) {
+	server, received := newReceiver(t, http.StatusInternalServerError)
+	worker, deliveryRepo, _, delivery := newWorkerFixture(t, server.URL)
+
+	before := time.Now()
+	if _, err := worker.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+	<-received
+
+	stored := deliveryRepo.get(delivery.ID)
+	if stored.Status != entities.WebhookDeliveryPending {
+		t.Errorf("expected status pending, got %s", stored.Status)
+	}
+	if stored.Attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", stored.Attempts)
+	}
+	if stored.LastResponseStatus == nil || *stored.LastResponseStatus != http.StatusInternalServerError {
+		t.Errorf("expected last response status 500, got %v", stored.LastResponseStatus)
+	}
+	if stored.NextAttemptAt.Before(before.Add(entities.WebhookBackoff(1))) {
+		t.Errorf("expected next attempt after backoff, got %v", stored.NextAttemptAt)
+	}
+
+	// The delivery is not due again until the backoff has elapsed
+	processed, err := worker.ProcessDue(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+	if processed != 0 {
+		t.Errorf("expected no deliveries due during backoff, got %d", processed)
+	}
+}
+
+func TestWebhookDeliveryWorker_GivesUpAfterMaxAttempts(t *testing.T) {
+	server, _ := newReceiver(t, http.StatusBadGateway)
+	worker, deliveryRepo, _, delivery := newWorkerFixture(t, server.URL)
+	delivery.Attempts = entities.WebhookMaxAttempts - 1
+
+	if _, err := worker.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+
+	stored := deliveryRepo.get(delivery.ID)
+	if stored.Status != entities.WebhookDeliveryFailed {
+		t.Errorf("expected status failed, got %s", stored.Status)
+	}
+	if stored.Attempts != entities.WebhookMaxAttempts {
+		t.Errorf("expected %d attempts, got %d", entities.WebhookMaxAttempts, stored.Attempts)
+	}
+}
+
+func TestWebhookDeliveryWorker_AbandonsInactiveSubscription(t *testing.T) {
+	server, received := newReceiver(t, http.StatusOK)
+	worker, deliveryRepo, subscription, delivery := newWorkerFixture(t, server.URL)
+	subscription.IsActive = false
+
+	if _, err := worker.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+
+	select {
+	case <-received:
+		t.Fatal("receiver should not be called for an inactive subscription")
+	default:
+	}
+
+	stored := deliveryRepo.get(delivery.ID)
+	if stored.Status != entities.WebhookDeliveryFailed {
+		t.Errorf("expected status failed, got %s", stored.Status)
+	}
+}
+
+func TestWebhookDeliveryWorker_ReplayRedelivers(t *testing.T) {
+	server, received := newReceiver(t, http.StatusOK)
+	worker, deliveryRepo, _, delivery := newWorkerFixture(t, server.URL)
+
+	if _, err := worker.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+	first := <-received
+
+	stored := deliveryRepo.get(delivery.ID)
+	stored.Replay()
+	stored.NextAttemptAt = stored.NextAttemptAt.Add(-time.Second)
+
+	if _, err := worker.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("ProcessDue returned error: %v", err)
+	}
+	second := <-received
+
+	if first.header.Get(WebhookHeaderEventID) != second.header.Get(WebhookHeaderEventID) {
+		t.Error("expected replay to keep the event ID so receivers can deduplicate")
+	}
+	if deliveryRepo.get(delivery.ID).Status != entities.WebhookDeliverySucceeded {
+		t.Errorf("expected replayed delivery to succeed")
+	}
+}
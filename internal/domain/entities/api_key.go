@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// APIKeyScope limits the route groups an API key may call
+type APIKeyScope string
+
+const (
+	APIKeyScopeClinics            APIKeyScope = "clinics"
+	APIKeyScopeUnits              APIKeyScope = "units"
+	APIKeyScopeDoctors            APIKeyScope = "doctors"
+	APIKeyScopePatients           APIKeyScope = "patients"
+	APIKeyScopeAppointments       APIKeyScope = "appointments"
+	APIKeyScopeDoctorAvailability APIKeyScope = "doctor_availability"
+	APIKeyScopeOrganization       APIKeyScope = "organization"
+)
+
+// APIKeyPrefix identifies secrets issued by this API (e.g. in secret scanners)
+const APIKeyPrefix = "dsk"
+
+// IsValidAPIKeyScope checks if the scope is a known route group
+func IsValidAPIKeyScope(scope APIKeyScope) bool {
+	switch scope {
+	case APIKeyScopeClinics, APIKeyScopeUnits, APIKeyScopeDoctors, APIKeyScopePatients,
+		APIKeyScopeAppointments, APIKeyScopeDoctorAvailability, APIKeyScopeOrganization:
+		return true
+	}
+	return false
+}
+
+// APIKey represents an organization-scoped credential for server-to-server integrations
+type APIKey struct {
+	ID             uuid.UUID      `json:"id" db:"id"`
+	OrganizationID uuid.UUID      `json:"organization_id" db:"organization_id"`
+	Name           string         `json:"name" db:"name"`
+	Prefix         string         `json:"prefix" db:"prefix"` // Public lookup identifier embedded in the key
+	KeyHash        string         `json:"-" db:"key_hash"`    // SHA-256 of the full secret, never the secret itself
+	Scopes         pq.StringArray `json:"scopes" db:"scopes"`
+	CreatedBy      *uuid.UUID     `json:"created_by,omitempty" db:"created_by"`
+	ExpiresAt      *time.Time     `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt     *time.Time     `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt      *time.Time     `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// NewAPIKey creates a new API key and returns it together with the plaintext secret.
+// The secret has the form dsk_<prefix>_<random> and cannot be recovered afterwards.
+func NewAPIKey(organizationID uuid.UUID, name string, scopes []APIKeyScope, createdBy *uuid.UUID, expiresAt *time.Time) (*APIKey, string, error) {
+	prefix, err := randomHex(4)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, "", err
+	}
+	plaintext := fmt.Sprintf("%s_%s_%s", APIKeyPrefix, prefix, secret)
+
+	scopeNames := make(pq.StringArray, 0, len(scopes))
+	for _, scope := range scopes {
+		scopeNames = append(scopeNames, string(scope))
+	}
+
+	now := time.Now()
+	return &APIKey{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Name:           strings.TrimSpace(name),
+		Prefix:         prefix,
+		KeyHash:        HashAPIKey(plaintext),
+		Scopes:         scopeNames,
+		CreatedBy:      createdBy,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, plaintext, nil
+}
+
+// ParseAPIKeyPrefix extracts the lookup prefix from a plaintext key
+func ParseAPIKeyPrefix(plaintext string) (string, bool) {
+	parts := strings.Split(plaintext, "_")
+	if len(parts) != 3 || parts[0] != APIKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 digest stored for a plaintext key
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// Validate checks if the API key entity is valid
+func (k *APIKey) Validate() error {
+	if k.OrganizationID == uuid.Nil {
+		return ErrInvalidOrganizationID
+	}
+
+	if k.Name == "" {
+		return ErrInvalidAPIKeyName
+	}
+
+	if len(k.Scopes) == 0 {
+		return ErrInvalidAPIKeyScope
+	}
+
+	for _, scope := range k.Scopes {
+		if !IsValidAPIKeyScope(APIKeyScope(scope)) {
+			return ErrInvalidAPIKeyScope
+		}
+	}
+
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(k.CreatedAt) {
+		return ErrInvalidAPIKeyExpiry
+	}
+
+	return nil
+}
+
+// Matches compares a plaintext key against the stored hash in constant time
+func (k *APIKey) Matches(plaintext string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(plaintext)), []byte(k.KeyHash)) == 1
+}
+
+// HasScope checks if the key grants access to the given route group
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if APIKeyScope(s) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRevoked checks if the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired checks if the key is past its expiry date
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// CanAuthenticate returns an error describing why the key cannot be used
+func (k *APIKey) CanAuthenticate(now time.Time) error {
+	if k.IsRevoked() {
+		return ErrAPIKeyRevoked
+	}
+	if k.IsExpired(now) {
+		return ErrAPIKeyExpired
+	}
+	return nil
+}
+
+// Revoke permanently disables the key
+func (k *APIKey) Revoke() {
+	now := time.Now()
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
+
+// randomHex returns n random bytes encoded as hex
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
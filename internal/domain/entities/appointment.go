@@ -34,10 +34,16 @@ type Appointment struct {
 	MovedToNeedsReschedulingAt *time.Time        `json:"moved_to_needs_rescheduling_at,omitempty" db:"moved_to_needs_rescheduling_at"`
 	RescheduledToAppointmentID *uuid.UUID        `json:"rescheduled_to_appointment_id,omitempty" db:"rescheduled_to_appointment_id"`
 	CancellationReason         *string           `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
+	CancellationReasonID       *uuid.UUID        `json:"cancellation_reason_id,omitempty" db:"cancellation_reason_id"` // Entry of the organization's managed list
+	DepositStatus              *DepositStatus    `json:"deposit_status,omitempty" db:"deposit_status"`                 // Status of its latest deposit, nil when none was asked
 	SnoozedUntil               *time.Time        `json:"snoozed_until,omitempty" db:"snoozed_until"`
 	MigrationSourceID          *string           `json:"migration_source_id,omitempty" db:"migration_source_id"`
+	Version                    int               `json:"version" db:"version"` // Optimistic concurrency token, bumped on every update
 	CreatedAt                  time.Time         `json:"created_at" db:"created_at"`
 	UpdatedAt                  time.Time         `json:"updated_at" db:"updated_at"`
+
+	// Staff, units and equipment booked besides the doctor and unit; set with SetResources
+	Resources []*AppointmentResource `json:"resources,omitempty" db:"-"`
 }
 
 // Validate checks if the appointment entity is valid
@@ -92,6 +98,11 @@ func (a *Appointment) IsRescheduled() bool {
 	return a.Status == AppointmentStatusRescheduled
 }
 
+// AwaitsDeposit checks if the appointment is held until its deposit is paid
+func (a *Appointment) AwaitsDeposit() bool {
+	return a.DepositStatus != nil && *a.DepositStatus == DepositStatusPending
+}
+
 // IsValidStatus checks if the provided status is valid
 func IsValidAppointmentStatus(status AppointmentStatus) bool {
 	switch status {
@@ -109,6 +120,26 @@ func IsValidAppointmentStatus(status AppointmentStatus) bool {
 	}
 }
 
+// SlotHoldingStatuses returns the statuses of appointments that keep their doctor, unit and
+// additional resources busy, so nothing else can be booked on them at the same time. A
+// rescheduled appointment has been replaced by a new one and no longer holds its old slot.
+func SlotHoldingStatuses() []string {
+	return []string{
+		string(AppointmentStatusScheduled),
+		string(AppointmentStatusConfirmed),
+	}
+}
+
+// HoldsSlot checks if the appointment keeps its doctor, unit and additional resources busy
+func (a *Appointment) HoldsSlot() bool {
+	for _, status := range SlotHoldingStatuses() {
+		if string(a.Status) == status {
+			return true
+		}
+	}
+	return false
+}
+
 // Cancel cancels the appointment
 func (a *Appointment) Cancel() {
 	a.Status = AppointmentStatusCancelled
@@ -141,8 +172,9 @@ func (a *Appointment) IsNeedsRescheduling() bool {
 }
 
 // CancelWithReason cancels appointment with reason
-func (a *Appointment) CancelWithReason(reason string) {
+func (a *Appointment) CancelWithReason(reasonID *uuid.UUID, reason string) {
 	a.Status = AppointmentStatusCancelled
+	a.CancellationReasonID = reasonID
 	a.CancellationReason = &reason
 	a.UpdatedAt = time.Now()
 }
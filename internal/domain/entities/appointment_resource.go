@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResourceType represents the kind of resource an appointment books
+type ResourceType string
+
+const (
+	ResourceTypeStaff     ResourceType = "staff"     // Member of the staff roster (doctors table)
+	ResourceTypeUnit      ResourceType = "unit"      // Dental unit or room
+	ResourceTypeEquipment ResourceType = "equipment" // Movable equipment of the clinic
+)
+
+// AppointmentRole represents the role a staff member plays in an appointment
+type AppointmentRole string
+
+const (
+	AppointmentRoleDoctor    AppointmentRole = "doctor"
+	AppointmentRoleAssistant AppointmentRole = "assistant"
+	AppointmentRoleHygienist AppointmentRole = "hygienist"
+)
+
+// IsValidResourceType checks if the provided resource type is valid
+func IsValidResourceType(resourceType ResourceType) bool {
+	switch resourceType {
+	case ResourceTypeStaff, ResourceTypeUnit, ResourceTypeEquipment:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidAppointmentRole checks if the provided appointment role is valid
+func IsValidAppointmentRole(role AppointmentRole) bool {
+	switch role {
+	case AppointmentRoleDoctor, AppointmentRoleAssistant, AppointmentRoleHygienist:
+		return true
+	default:
+		return false
+	}
+}
+
+// AppointmentResource is a staff member, unit or piece of equipment an appointment books besides
+// its doctor and unit, which remain the appointment's primary doctor and chair
+type AppointmentResource struct {
+	ID            uuid.UUID        `json:"id" db:"id"`
+	AppointmentID uuid.UUID        `json:"appointment_id" db:"appointment_id"`
+	Type          ResourceType     `json:"resource_type" db:"resource_type"`
+	ResourceID    uuid.UUID        `json:"resource_id" db:"resource_id"`
+	Role          *AppointmentRole `json:"role,omitempty" db:"role"` // Staff only
+	CreatedAt     time.Time        `json:"created_at" db:"created_at"`
+}
+
+// NewAppointmentResource creates a resource to book in an appointment
+func NewAppointmentResource(resourceType ResourceType, resourceID uuid.UUID, role *AppointmentRole) (*AppointmentResource, error) {
+	resource := &AppointmentResource{
+		ID:         uuid.New(),
+		Type:       resourceType,
+		ResourceID: resourceID,
+		Role:       role,
+		CreatedAt:  time.Now(),
+	}
+	if err := resource.Validate(); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Validate checks the resource type and that only staff members are booked with a role
+func (r *AppointmentResource) Validate() error {
+	if !IsValidResourceType(r.Type) {
+		return ErrInvalidResourceType
+	}
+	if r.ResourceID == uuid.Nil {
+		return ErrAppointmentResourceNotFound
+	}
+	if r.Type != ResourceTypeStaff {
+		if r.Role != nil {
+			return ErrResourceRoleNotAllowed
+		}
+		return nil
+	}
+	if r.Role == nil {
+		return ErrAppointmentRoleRequired
+	}
+	if !IsValidAppointmentRole(*r.Role) {
+		return ErrInvalidAppointmentRole
+	}
+	return nil
+}
+
+// SetResources replaces the additional resources of the appointment. A resource cannot be booked
+// twice, including the appointment's own doctor as staff or its own unit.
+func (a *Appointment) SetResources(resources []*AppointmentResource) error {
+	type resourceKey struct {
+		resourceType ResourceType
+		id           uuid.UUID
+	}
+	seen := map[resourceKey]bool{}
+	if a.DoctorID != nil {
+		seen[resourceKey{ResourceTypeStaff, *a.DoctorID}] = true
+	}
+	if a.UnitID != nil {
+		seen[resourceKey{ResourceTypeUnit, *a.UnitID}] = true
+	}
+
+	for _, resource := range resources {
+		if err := resource.Validate(); err != nil {
+			return err
+		}
+		key := resourceKey{resource.Type, resource.ResourceID}
+		if seen[key] {
+			return ErrDuplicateAppointmentResource
+		}
+		seen[key] = true
+		resource.AppointmentID = a.ID
+	}
+
+	a.Resources = resources
+	return nil
+}
+
+// HasResources reports whether the appointment books resources besides its doctor and unit
+func (a *Appointment) HasResources() bool {
+	return len(a.Resources) > 0
+}
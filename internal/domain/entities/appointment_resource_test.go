@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAppointmentResource(t *testing.T) {
+	assistant := AppointmentRoleAssistant
+	unknown := AppointmentRole("surgeon")
+
+	tests := []struct {
+		name         string
+		resourceType ResourceType
+		role         *AppointmentRole
+		err          error
+	}{
+		{name: "staff with role", resourceType: ResourceTypeStaff, role: &assistant},
+		{name: "unit", resourceType: ResourceTypeUnit},
+		{name: "equipment", resourceType: ResourceTypeEquipment},
+		{name: "unknown type", resourceType: "room", err: ErrInvalidResourceType},
+		{name: "staff without role", resourceType: ResourceTypeStaff, err: ErrAppointmentRoleRequired},
+		{name: "staff with unknown role", resourceType: ResourceTypeStaff, role: &unknown, err: ErrInvalidAppointmentRole},
+		{name: "equipment with role", resourceType: ResourceTypeEquipment, role: &assistant, err: ErrResourceRoleNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewAppointmentResource(tt.resourceType, uuid.New(), tt.role); err != tt.err {
+				t.Errorf("NewAppointmentResource() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAppointmentSetResources(t *testing.T) {
+	doctorID, unitID := uuid.New(), uuid.New()
+	appointment := &Appointment{ID: uuid.New(), DoctorID: &doctorID, UnitID: &unitID}
+	assistant := AppointmentRoleAssistant
+	doctor := AppointmentRoleDoctor
+
+	staff, _ := NewAppointmentResource(ResourceTypeStaff, uuid.New(), &assistant)
+	scanner, _ := NewAppointmentResource(ResourceTypeEquipment, uuid.New(), nil)
+	if err := appointment.SetResources([]*AppointmentResource{staff, scanner}); err != nil {
+		t.Fatalf("SetResources() error = %v", err)
+	}
+	if !appointment.HasResources() || staff.AppointmentID != appointment.ID {
+		t.Errorf("SetResources() did not attach the resources to the appointment")
+	}
+
+	ownDoctor, _ := NewAppointmentResource(ResourceTypeStaff, doctorID, &doctor)
+	ownUnit, _ := NewAppointmentResource(ResourceTypeUnit, unitID, nil)
+	tests := []struct {
+		name      string
+		resources []*AppointmentResource
+	}{
+		{name: "the appointment's doctor", resources: []*AppointmentResource{ownDoctor}},
+		{name: "the appointment's unit", resources: []*AppointmentResource{ownUnit}},
+		{name: "the same equipment twice", resources: []*AppointmentResource{scanner, {ID: uuid.New(), Type: ResourceTypeEquipment, ResourceID: scanner.ResourceID}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := appointment.SetResources(tt.resources); err != ErrDuplicateAppointmentResource {
+				t.Errorf("SetResources() error = %v, want %v", err, ErrDuplicateAppointmentResource)
+			}
+		})
+	}
+
+	if err := appointment.SetResources(nil); err != nil || appointment.HasResources() {
+		t.Errorf("SetResources(nil) did not clear the resources")
+	}
+}
+
+func TestAppointmentHoldsSlot(t *testing.T) {
+	tests := []struct {
+		status AppointmentStatus
+		want   bool
+	}{
+		{AppointmentStatusScheduled, true},
+		{AppointmentStatusConfirmed, true},
+		{AppointmentStatusRescheduled, false},
+		{AppointmentStatusCompleted, false},
+		{AppointmentStatusCancelled, false},
+		{AppointmentStatusNeedsRescheduling, false},
+		{AppointmentStatusNoShow, false},
+		{AppointmentStatusWithError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			appointment := &Appointment{Status: tt.status}
+			if got := appointment.HoldsSlot(); got != tt.want {
+				t.Errorf("HoldsSlot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package entities
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentType classifies a patient document
+type AttachmentType string
+
+const (
+	AttachmentTypeRadiograph AttachmentType = "radiograph" // Periapical, bitewing, panoramic or CBCT export
+	AttachmentTypePhoto      AttachmentType = "photo"      // Intraoral or extraoral photograph
+	AttachmentTypeConsent    AttachmentType = "consent"    // Signed consent form
+	AttachmentTypeReferral   AttachmentType = "referral"   // Referral letter from or to another provider
+	AttachmentTypeOther      AttachmentType = "other"
+)
+
+// IsValidAttachmentType checks if the attachment type is supported
+func IsValidAttachmentType(attachmentType AttachmentType) bool {
+	switch attachmentType {
+	case AttachmentTypeRadiograph, AttachmentTypePhoto, AttachmentTypeConsent,
+		AttachmentTypeReferral, AttachmentTypeOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Content types accepted for attachments, as detected from the file contents
+const (
+	ContentTypeJPEG  = "image/jpeg"
+	ContentTypePNG   = "image/png"
+	ContentTypeGIF   = "image/gif"
+	ContentTypeWebP  = "image/webp"
+	ContentTypeBMP   = "image/bmp"
+	ContentTypePDF   = "application/pdf"
+	ContentTypeDICOM = "application/dicom"
+)
+
+// IsAllowedAttachmentContentType checks if files of the content type can be attached
+func IsAllowedAttachmentContentType(contentType string) bool {
+	switch contentType {
+	case ContentTypeJPEG, ContentTypePNG, ContentTypeGIF, ContentTypeWebP,
+		ContentTypeBMP, ContentTypePDF, ContentTypeDICOM:
+		return true
+	default:
+		return false
+	}
+}
+
+// maxAttachmentFileNameLength matches the file_name column
+const maxAttachmentFileNameLength = 255
+
+// Attachment is a file kept in blob storage for a patient, optionally tied to the
+// appointment it was taken at and to a tooth. The file itself is addressed by
+// StorageKey; ThumbnailKey is set for images a preview could be generated for.
+type Attachment struct {
+	ID             uuid.UUID      `json:"id" db:"id"`
+	OrganizationID uuid.UUID      `json:"organization_id" db:"organization_id"`
+	PatientID      uuid.UUID      `json:"patient_id" db:"patient_id"`
+	AppointmentID  *uuid.UUID     `json:"appointment_id,omitempty" db:"appointment_id"`
+	Type           AttachmentType `json:"type" db:"type"`
+	Tooth          *Tooth         `json:"tooth,omitempty" db:"tooth"`
+	TakenAt        *time.Time     `json:"taken_at,omitempty" db:"taken_at"` // When the image was captured or the document signed
+	Description    *string        `json:"description,omitempty" db:"description"`
+	FileName       string         `json:"file_name" db:"file_name"`       // Original name, used for downloads
+	ContentType    string         `json:"content_type" db:"content_type"` // Sniffed from the contents, never taken from the client
+	SizeBytes      int64          `json:"size_bytes" db:"size_bytes"`
+	StorageKey     string         `json:"-" db:"storage_key"`
+	ThumbnailKey   *string        `json:"-" db:"thumbnail_key"`
+	UploadedBy     *uuid.UUID     `json:"uploaded_by,omitempty" db:"uploaded_by"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// NewAttachment creates the metadata of a file uploaded for a patient and assigns its storage key
+func NewAttachment(organizationID, patientID uuid.UUID, attachmentType AttachmentType, fileName, contentType string, sizeBytes int64) (*Attachment, error) {
+	now := time.Now()
+	attachment := &Attachment{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		Type:           attachmentType,
+		FileName:       cleanAttachmentFileName(fileName),
+		ContentType:    contentType,
+		SizeBytes:      sizeBytes,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	attachment.StorageKey = attachment.objectKey("")
+
+	if err := attachment.Validate(); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// Validate validates the attachment fields
+func (a *Attachment) Validate() error {
+	if !IsValidAttachmentType(a.Type) {
+		return ErrInvalidAttachmentType
+	}
+	if a.FileName == "" {
+		return ErrAttachmentFileNameRequired
+	}
+	if a.SizeBytes <= 0 {
+		return ErrAttachmentEmpty
+	}
+	if !IsAllowedAttachmentContentType(a.ContentType) {
+		return ErrUnsupportedAttachmentType
+	}
+	if a.Tooth != nil && !a.Tooth.IsValid() {
+		return ErrInvalidToothNumber
+	}
+	if a.TakenAt != nil && a.TakenAt.After(time.Now()) {
+		return ErrAttachmentTakenInFuture
+	}
+	return nil
+}
+
+// IsImage reports whether the attachment is a raster image
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// HasThumbnail reports whether a preview image was stored for the attachment
+func (a *Attachment) HasThumbnail() bool {
+	return a.ThumbnailKey != nil
+}
+
+// AssignThumbnailKey sets and returns the storage key of the attachment's preview image
+func (a *Attachment) AssignThumbnailKey() string {
+	key := a.objectKey("-thumb.jpg")
+	a.ThumbnailKey = &key
+	return key
+}
+
+// objectKey builds a storage key grouping files by organization and patient. Keys never
+// contain client input, so they are safe as file system paths and object names.
+func (a *Attachment) objectKey(suffix string) string {
+	return path.Join("organizations", a.OrganizationID.String(), "patients", a.PatientID.String(), "attachments", a.ID.String()+suffix)
+}
+
+// cleanAttachmentFileName keeps the base name of an uploaded file, without directories or control characters
+func cleanAttachmentFileName(fileName string) string {
+	fileName = strings.ReplaceAll(fileName, "\\", "/")
+	fileName = path.Base(strings.TrimSpace(fileName))
+	if fileName == "." || fileName == "/" {
+		return ""
+	}
+
+	fileName = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, fileName)
+
+	// Long names keep their end so the extension survives
+	if runes := []rune(fileName); len(runes) > maxAttachmentFileNameLength {
+		fileName = string(runes[len(runes)-maxAttachmentFileNameLength:])
+	}
+	return fileName
+}
@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAttachment(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        AttachmentType
+		fileName    string
+		contentType string
+		size        int64
+		wantName    string
+		err         error
+	}{
+		{
+			name:        "radiograph",
+			kind:        AttachmentTypeRadiograph,
+			fileName:    "bitewing-left.png",
+			contentType: ContentTypePNG,
+			size:        2048,
+			wantName:    "bitewing-left.png",
+		},
+		{
+			name:        "directories are stripped from the file name",
+			kind:        AttachmentTypeReferral,
+			fileName:    `C:\Users\front desk\referral "ortho".pdf`,
+			contentType: ContentTypePDF,
+			size:        10,
+			wantName:    "referral ortho.pdf",
+		},
+		{
+			name:        "unknown type",
+			kind:        AttachmentType("xray"),
+			fileName:    "a.jpg",
+			contentType: ContentTypeJPEG,
+			size:        10,
+			err:         ErrInvalidAttachmentType,
+		},
+		{
+			name:        "file name required",
+			kind:        AttachmentTypePhoto,
+			fileName:    "/",
+			contentType: ContentTypeJPEG,
+			size:        10,
+			err:         ErrAttachmentFileNameRequired,
+		},
+		{
+			name:        "empty file",
+			kind:        AttachmentTypePhoto,
+			fileName:    "a.jpg",
+			contentType: ContentTypeJPEG,
+			err:         ErrAttachmentEmpty,
+		},
+		{
+			name:        "executable rejected",
+			kind:        AttachmentTypeOther,
+			fileName:    "scan.pdf",
+			contentType: "application/octet-stream",
+			size:        10,
+			err:         ErrUnsupportedAttachmentType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgID, patientID := uuid.New(), uuid.New()
+			attachment, err := NewAttachment(orgID, patientID, tt.kind, tt.fileName, tt.contentType, tt.size)
+			if err != tt.err {
+				t.Fatalf("NewAttachment() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if attachment.FileName != tt.wantName {
+				t.Errorf("FileName = %q, want %q", attachment.FileName, tt.wantName)
+			}
+			wantKey := "organizations/" + orgID.String() + "/patients/" + patientID.String() + "/attachments/" + attachment.ID.String()
+			if attachment.StorageKey != wantKey {
+				t.Errorf("StorageKey = %q, want %q", attachment.StorageKey, wantKey)
+			}
+		})
+	}
+}
+
+func TestAttachmentThumbnailKey(t *testing.T) {
+	attachment, err := NewAttachment(uuid.New(), uuid.New(), AttachmentTypePhoto, "smile.jpg", ContentTypeJPEG, 100)
+	if err != nil {
+		t.Fatalf("NewAttachment() error = %v", err)
+	}
+	if attachment.HasThumbnail() {
+		t.Fatal("HasThumbnail() = true before a thumbnail was assigned")
+	}
+
+	key := attachment.AssignThumbnailKey()
+	if !attachment.HasThumbnail() || *attachment.ThumbnailKey != key {
+		t.Errorf("ThumbnailKey = %v, want %q", attachment.ThumbnailKey, key)
+	}
+	if key != attachment.StorageKey+"-thumb.jpg" {
+		t.Errorf("AssignThumbnailKey() = %q, want it next to %q", key, attachment.StorageKey)
+	}
+}
+
+func TestCleanAttachmentFileNameKeepsExtension(t *testing.T) {
+	name := cleanAttachmentFileName(strings.Repeat("a", 300) + ".pdf")
+	if len(name) != maxAttachmentFileNameLength || !strings.HasSuffix(name, ".pdf") {
+		t.Errorf("cleanAttachmentFileName() = %d chars ending in %q", len(name), name[len(name)-4:])
+	}
+}
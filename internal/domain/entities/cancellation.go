@@ -0,0 +1,263 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CancellationCategory groups cancellation reasons for the cancellation policy
+type CancellationCategory string
+
+const (
+	CancellationCategoryPatientRequest CancellationCategory = "patient_request"
+	CancellationCategoryIllness        CancellationCategory = "illness"
+	CancellationCategoryEmergency      CancellationCategory = "emergency"
+	CancellationCategoryWeather        CancellationCategory = "weather"
+	CancellationCategoryClinic         CancellationCategory = "clinic" // Cancelled by the clinic, e.g. doctor unavailable
+	CancellationCategoryOther          CancellationCategory = "other"
+)
+
+// IsValidCancellationCategory checks if the cancellation category is supported
+func IsValidCancellationCategory(category CancellationCategory) bool {
+	switch category {
+	case CancellationCategoryPatientRequest, CancellationCategoryIllness, CancellationCategoryEmergency,
+		CancellationCategoryWeather, CancellationCategoryClinic, CancellationCategoryOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// CancellationReason is an entry of the organization's managed list of cancellation reasons
+type CancellationReason struct {
+	ID             uuid.UUID            `json:"id" db:"id"`
+	OrganizationID uuid.UUID            `json:"organization_id" db:"organization_id"`
+	Label          string               `json:"label" db:"label"`
+	Category       CancellationCategory `json:"category" db:"category"`
+	Active         bool                 `json:"active" db:"active"` // Inactive reasons stay on past appointments but cannot be picked
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// NewCancellationReason creates an active cancellation reason
+func NewCancellationReason(organizationID uuid.UUID, label string, category CancellationCategory) (*CancellationReason, error) {
+	now := time.Now()
+	reason := &CancellationReason{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Label:          strings.TrimSpace(label),
+		Category:       category,
+		Active:         true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := reason.Validate(); err != nil {
+		return nil, err
+	}
+	return reason, nil
+}
+
+// Validate validates the cancellation reason fields
+func (r *CancellationReason) Validate() error {
+	if r.Label == "" {
+		return ErrCancellationReasonLabelRequired
+	}
+	if !IsValidCancellationCategory(r.Category) {
+		return ErrInvalidCancellationCategory
+	}
+	return nil
+}
+
+// CancellationFeeType represents how a late-cancellation fee is computed
+type CancellationFeeType string
+
+const (
+	CancellationFeeTypeFixed      CancellationFeeType = "fixed"      // FeeAmount in minor units
+	CancellationFeeTypePercentage CancellationFeeType = "percentage" // FeePercent of the price of the appointment's procedure
+)
+
+// CancellationPolicy is an organization's late-cancellation policy. Appointments cancelled with
+// less than NoticeHours of notice are charged a fee, unless the reason's category is exempt.
+type CancellationPolicy struct {
+	OrganizationID   uuid.UUID              `json:"organization_id" db:"organization_id"`
+	Enabled          bool                   `json:"enabled" db:"enabled"`
+	NoticeHours      int                    `json:"notice_hours" db:"notice_hours"`
+	FeeType          CancellationFeeType    `json:"fee_type" db:"fee_type"`
+	FeeAmount        int64                  `json:"fee_amount" db:"fee_amount"`
+	FeePercent       int                    `json:"fee_percent" db:"fee_percent"`
+	ExemptCategories []CancellationCategory `json:"exempt_categories" db:"exempt_categories"`
+	UpdatedBy        *uuid.UUID             `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultCancellationPolicy returns the policy of an organization that has not set one: no
+// fees, with the defaults offered when it is enabled
+func DefaultCancellationPolicy(organizationID uuid.UUID) *CancellationPolicy {
+	return &CancellationPolicy{
+		OrganizationID:   organizationID,
+		NoticeHours:      24,
+		FeeType:          CancellationFeeTypeFixed,
+		ExemptCategories: []CancellationCategory{CancellationCategoryClinic, CancellationCategoryEmergency},
+	}
+}
+
+// Validate validates the policy fields; the fee only has to be set when the policy is enabled
+func (p *CancellationPolicy) Validate() error {
+	if p.NoticeHours < 1 || p.NoticeHours > 720 {
+		return ErrInvalidCancellationNotice
+	}
+	for _, category := range p.ExemptCategories {
+		if !IsValidCancellationCategory(category) {
+			return ErrInvalidCancellationCategory
+		}
+	}
+	if p.FeeAmount < 0 || p.FeePercent < 0 || p.FeePercent > 100 {
+		return ErrInvalidCancellationFee
+	}
+
+	switch p.FeeType {
+	case CancellationFeeTypeFixed:
+		if p.Enabled && p.FeeAmount == 0 {
+			return ErrInvalidCancellationFee
+		}
+	case CancellationFeeTypePercentage:
+		if p.Enabled && p.FeePercent == 0 {
+			return ErrInvalidCancellationFee
+		}
+	default:
+		return ErrInvalidCancellationFeeType
+	}
+	return nil
+}
+
+// IsExempt reports whether cancellations for reasons of the category are never charged
+func (p *CancellationPolicy) IsExempt(category CancellationCategory) bool {
+	for _, exempt := range p.ExemptCategories {
+		if exempt == category {
+			return true
+		}
+	}
+	return false
+}
+
+// CancellationFeeStatus represents where a late-cancellation fee is in its collection
+type CancellationFeeStatus string
+
+const (
+	CancellationFeeStatusPending  CancellationFeeStatus = "pending"  // Owed by the patient, not billed yet
+	CancellationFeeStatusWaived   CancellationFeeStatus = "waived"   // Overridden to nothing by staff
+	CancellationFeeStatusInvoiced CancellationFeeStatus = "invoiced" // Billed on InvoiceID
+)
+
+// IsValidCancellationFeeStatus checks if the cancellation fee status is supported
+func IsValidCancellationFeeStatus(status CancellationFeeStatus) bool {
+	switch status {
+	case CancellationFeeStatusPending, CancellationFeeStatusWaived, CancellationFeeStatusInvoiced:
+		return true
+	default:
+		return false
+	}
+}
+
+// CancellationFee is a charge for a late cancellation. PolicyAmount is what the policy charged
+// and Amount what the patient owes after any staff override; both in minor units of Currency.
+type CancellationFee struct {
+	ID                    uuid.UUID             `json:"id" db:"id"`
+	OrganizationID        uuid.UUID             `json:"organization_id" db:"organization_id"`
+	ClinicID              uuid.UUID             `json:"clinic_id" db:"clinic_id"`
+	PatientID             uuid.UUID             `json:"patient_id" db:"patient_id"`
+	AppointmentID         uuid.UUID             `json:"appointment_id" db:"appointment_id"`
+	ReasonID              *uuid.UUID            `json:"reason_id,omitempty" db:"reason_id"`
+	Reason                *string               `json:"reason,omitempty" db:"reason"` // Label of the reason when cancelled
+	Status                CancellationFeeStatus `json:"status" db:"status"`
+	Currency              string                `json:"currency" db:"currency"`
+	PolicyAmount          int64                 `json:"policy_amount" db:"policy_amount"`
+	Amount                int64                 `json:"amount" db:"amount"`
+	NoticeMinutes         int                   `json:"notice_minutes" db:"notice_minutes"` // Negative when cancelled after the start
+	AppointmentStart      time.Time             `json:"appointment_start" db:"appointment_start"`
+	CancelledAt           time.Time             `json:"cancelled_at" db:"cancelled_at"`
+	CancelledBy           *uuid.UUID            `json:"cancelled_by,omitempty" db:"cancelled_by"`
+	OverrideJustification *string               `json:"override_justification,omitempty" db:"override_justification"`
+	OverriddenBy          *uuid.UUID            `json:"overridden_by,omitempty" db:"overridden_by"`
+	OverriddenAt          *time.Time            `json:"overridden_at,omitempty" db:"overridden_at"`
+	InvoiceID             *uuid.UUID            `json:"invoice_id,omitempty" db:"invoice_id"`
+	InvoicedAt            *time.Time            `json:"invoiced_at,omitempty" db:"invoiced_at"`
+	CreatedAt             time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// NewCancellationFee creates a pending fee for the late cancellation of an appointment
+func NewCancellationFee(organizationID, clinicID, patientID uuid.UUID, appointment *Appointment, reason *CancellationReason, currency string, amount int64, cancelledAt time.Time, cancelledBy *uuid.UUID) (*CancellationFee, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidCancellationFee
+	}
+
+	now := time.Now()
+	fee := &CancellationFee{
+		ID:               uuid.New(),
+		OrganizationID:   organizationID,
+		ClinicID:         clinicID,
+		PatientID:        patientID,
+		AppointmentID:    appointment.ID,
+		Status:           CancellationFeeStatusPending,
+		Currency:         currency,
+		PolicyAmount:     amount,
+		Amount:           amount,
+		NoticeMinutes:    int(appointment.StartTime.Sub(cancelledAt) / time.Minute),
+		AppointmentStart: appointment.StartTime,
+		CancelledAt:      cancelledAt,
+		CancelledBy:      cancelledBy,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if reason != nil {
+		fee.ReasonID = &reason.ID
+		fee.Reason = &reason.Label
+	}
+	return fee, nil
+}
+
+// Override replaces the amount of a pending fee with what staff decided to charge, waiving it
+// when the amount is zero. The amount can be lowered, never raised above the policy's.
+func (f *CancellationFee) Override(amount int64, justification string, overriddenBy *uuid.UUID, now time.Time) error {
+	if f.Status != CancellationFeeStatusPending {
+		return ErrCancellationFeeNotPending
+	}
+	if overriddenBy == nil {
+		return ErrFeeOverrideRequiresStaff
+	}
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return ErrFeeOverrideJustificationRequired
+	}
+	if amount < 0 || amount > f.PolicyAmount {
+		return ErrInvalidFeeOverride
+	}
+
+	f.Amount = amount
+	if amount == 0 {
+		f.Status = CancellationFeeStatusWaived
+	}
+	f.OverrideJustification = &justification
+	f.OverriddenBy = overriddenBy
+	f.OverriddenAt = &now
+	f.UpdatedAt = now
+	return nil
+}
+
+// MarkInvoiced records that a pending fee was billed on an invoice
+func (f *CancellationFee) MarkInvoiced(invoiceID uuid.UUID, now time.Time) error {
+	if f.Status != CancellationFeeStatusPending {
+		return ErrCancellationFeeNotPending
+	}
+
+	f.Status = CancellationFeeStatusInvoiced
+	f.InvoiceID = &invoiceID
+	f.InvoicedAt = &now
+	f.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCancellationPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*CancellationPolicy)
+		err    error
+	}{
+		{name: "default", modify: func(p *CancellationPolicy) {}},
+		{name: "fixed fee", modify: func(p *CancellationPolicy) { p.Enabled, p.FeeAmount = true, 50000 }},
+		{name: "percentage fee", modify: func(p *CancellationPolicy) {
+			p.Enabled, p.FeeType, p.FeePercent = true, CancellationFeeTypePercentage, 50
+		}},
+		{name: "enabled without fee", modify: func(p *CancellationPolicy) { p.Enabled = true }, err: ErrInvalidCancellationFee},
+		{name: "percentage above 100", modify: func(p *CancellationPolicy) {
+			p.FeeType, p.FeePercent = CancellationFeeTypePercentage, 101
+		}, err: ErrInvalidCancellationFee},
+		{name: "no notice", modify: func(p *CancellationPolicy) { p.NoticeHours = 0 }, err: ErrInvalidCancellationNotice},
+		{name: "unknown fee type", modify: func(p *CancellationPolicy) { p.FeeType = "hourly" }, err: ErrInvalidCancellationFeeType},
+		{name: "unknown exempt category", modify: func(p *CancellationPolicy) {
+			p.ExemptCategories = []CancellationCategory{"holiday"}
+		}, err: ErrInvalidCancellationCategory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultCancellationPolicy(uuid.New())
+			tt.modify(policy)
+			if err := policy.Validate(); err != tt.err {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCancellationFeeOverride(t *testing.T) {
+	now := time.Now()
+	staff := uuid.New()
+	appointment := &Appointment{ID: uuid.New(), StartTime: now.Add(2 * time.Hour)}
+	reason, err := NewCancellationReason(uuid.New(), " Schedule conflict ", CancellationCategoryPatientRequest)
+	if err != nil {
+		t.Fatalf("NewCancellationReason() error = %v", err)
+	}
+
+	newFee := func() *CancellationFee {
+		fee, err := NewCancellationFee(uuid.New(), uuid.New(), uuid.New(), appointment, reason, "MXN", 50000, now, nil)
+		if err != nil {
+			t.Fatalf("NewCancellationFee() error = %v", err)
+		}
+		return fee
+	}
+
+	fee := newFee()
+	if fee.NoticeMinutes != 120 || fee.Reason == nil || *fee.Reason != "Schedule conflict" {
+		t.Errorf("unexpected fee: notice %d, reason %v", fee.NoticeMinutes, fee.Reason)
+	}
+
+	if err := fee.Override(10000, "  ", &staff, now); err != ErrFeeOverrideJustificationRequired {
+		t.Errorf("Override() without justification error = %v", err)
+	}
+	if err := fee.Override(10000, "Regular patient", nil, now); err != ErrFeeOverrideRequiresStaff {
+		t.Errorf("Override() without staff error = %v", err)
+	}
+	if err := fee.Override(60000, "Regular patient", &staff, now); err != ErrInvalidFeeOverride {
+		t.Errorf("Override() above the policy amount error = %v", err)
+	}
+	if err := fee.Override(20000, "Regular patient", &staff, now); err != nil || fee.Amount != 20000 || fee.Status != CancellationFeeStatusPending {
+		t.Errorf("Override() lowering = %v, amount %d, status %s", err, fee.Amount, fee.Status)
+	}
+	if err := fee.Override(0, "Hospitalized", &staff, now); err != nil || fee.Status != CancellationFeeStatusWaived {
+		t.Errorf("Override() waiving = %v, status %s", err, fee.Status)
+	}
+	if err := fee.MarkInvoiced(uuid.New(), now); err != ErrCancellationFeeNotPending {
+		t.Errorf("MarkInvoiced() on a waived fee error = %v", err)
+	}
+
+	fee = newFee()
+	if err := fee.MarkInvoiced(uuid.New(), now); err != nil || fee.Status != CancellationFeeStatusInvoiced || fee.InvoiceID == nil {
+		t.Errorf("MarkInvoiced() = %v, status %s", err, fee.Status)
+	}
+	if err := fee.Override(0, "Too late", &staff, now); err != ErrCancellationFeeNotPending {
+		t.Errorf("Override() on an invoiced fee error = %v", err)
+	}
+}
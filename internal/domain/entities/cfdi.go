@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CFDIStatus represents where a stamped CFDI is in its lifecycle at the SAT
+type CFDIStatus string
+
+const (
+	CFDIStatusStamped             CFDIStatus = "stamped"
+	CFDIStatusCancellationPending CFDIStatus = "cancellation_pending" // Awaiting the receiver's acceptance
+	CFDIStatusCancelled           CFDIStatus = "cancelled"
+)
+
+// CFDICancellationReason is the SAT motive (c_MotivoCancelacion) for cancelling a CFDI
+type CFDICancellationReason string
+
+const (
+	CFDICancellationWithErrorsReplaced CFDICancellationReason = "01" // Issued with errors, replaced by another CFDI
+	CFDICancellationWithErrors         CFDICancellationReason = "02" // Issued with errors, not replaced
+	CFDICancellationNotCarriedOut      CFDICancellationReason = "03" // The operation did not take place
+	CFDICancellationGlobalInvoice      CFDICancellationReason = "04" // Nominative operation included in a global invoice
+)
+
+// IsValidCFDICancellationReason checks if the cancellation motive is in the SAT catalog
+func IsValidCFDICancellationReason(reason CFDICancellationReason) bool {
+	switch reason {
+	case CFDICancellationWithErrorsReplaced, CFDICancellationWithErrors, CFDICancellationNotCarriedOut, CFDICancellationGlobalInvoice:
+		return true
+	default:
+		return false
+	}
+}
+
+// CFDI is an invoice stamped by a PAC, with the XML as certified by the SAT
+type CFDI struct {
+	ID                      uuid.UUID               `json:"id" db:"id"`
+	OrganizationID          uuid.UUID               `json:"organization_id" db:"organization_id"`
+	InvoiceID               uuid.UUID               `json:"invoice_id" db:"invoice_id"`
+	PatientID               uuid.UUID               `json:"patient_id" db:"patient_id"`
+	UUID                    string                  `json:"uuid" db:"uuid"` // Folio fiscal assigned by the SAT
+	Status                  CFDIStatus              `json:"status" db:"status"`
+	IssuerRFC               string                  `json:"issuer_rfc" db:"issuer_rfc"`
+	ReceiverRFC             string                  `json:"receiver_rfc" db:"receiver_rfc"`
+	Total                   int64                   `json:"total" db:"total"` // Minor units of MXN
+	XML                     []byte                  `json:"-" db:"xml"`
+	StampedAt               time.Time               `json:"stamped_at" db:"stamped_at"`
+	CancellationReason      *CFDICancellationReason `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
+	ReplacementUUID         *string                 `json:"replacement_uuid,omitempty" db:"replacement_uuid"`
+	CancellationRequestedAt *time.Time              `json:"cancellation_requested_at,omitempty" db:"cancellation_requested_at"`
+	CancelledAt             *time.Time              `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CancelledBy             *uuid.UUID              `json:"cancelled_by,omitempty" db:"cancelled_by"`
+	CreatedBy               *uuid.UUID              `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt               time.Time               `json:"created_at" db:"created_at"`
+	UpdatedAt               time.Time               `json:"updated_at" db:"updated_at"`
+}
+
+// NewCFDI records the stamped CFDI of an invoice
+func NewCFDI(invoice *Invoice, fiscalUUID, issuerRFC, receiverRFC string, xml []byte, stampedAt time.Time, createdBy *uuid.UUID) *CFDI {
+	now := time.Now()
+	return &CFDI{
+		ID:             uuid.New(),
+		OrganizationID: invoice.OrganizationID,
+		InvoiceID:      invoice.ID,
+		PatientID:      invoice.PatientID,
+		UUID:           strings.ToUpper(fiscalUUID),
+		Status:         CFDIStatusStamped,
+		IssuerRFC:      issuerRFC,
+		ReceiverRFC:    receiverRFC,
+		Total:          invoice.Total,
+		XML:            xml,
+		StampedAt:      stampedAt,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// IsActive reports whether the CFDI is still valid at the SAT
+func (c *CFDI) IsActive() bool {
+	return c.Status != CFDIStatusCancelled
+}
+
+// RequestCancellation records a cancellation request. Motive 01 needs the UUID of the CFDI
+// replacing this one. Requesting again while pending re-sends the same request.
+func (c *CFDI) RequestCancellation(reason CFDICancellationReason, replacementUUID *string, requestedBy *uuid.UUID, now time.Time) error {
+	if c.Status == CFDIStatusCancelled {
+		return ErrCFDICancelled
+	}
+	if !IsValidCFDICancellationReason(reason) {
+		return ErrInvalidCFDICancellationReason
+	}
+	if replacementUUID != nil {
+		normalized := strings.ToUpper(strings.TrimSpace(*replacementUUID))
+		if normalized == "" {
+			replacementUUID = nil
+		} else {
+			if _, err := uuid.Parse(normalized); err != nil || normalized == c.UUID {
+				return ErrInvalidReplacementUUID
+			}
+			replacementUUID = &normalized
+		}
+	}
+	if (reason == CFDICancellationWithErrorsReplaced) != (replacementUUID != nil) {
+		return ErrInvalidReplacementUUID
+	}
+
+	c.Status = CFDIStatusCancellationPending
+	c.CancellationReason = &reason
+	c.ReplacementUUID = replacementUUID
+	c.CancellationRequestedAt = &now
+	c.CancelledBy = requestedBy
+	c.UpdatedAt = now
+	return nil
+}
+
+// ConfirmCancellation marks the CFDI as cancelled at the SAT
+func (c *CFDI) ConfirmCancellation(now time.Time) {
+	c.Status = CFDIStatusCancelled
+	c.CancelledAt = &now
+	c.UpdatedAt = now
+}
@@ -13,6 +13,7 @@ type Clinic struct {
 	Name           string    `json:"name" db:"name"`
 	Address        *string   `json:"address,omitempty" db:"address"`
 	Phone          *string   `json:"phone,omitempty" db:"phone"`
+	PhoneE164      *string   `json:"phone_e164,omitempty" db:"phone_e164"` // Normalized form of Phone
 	Email          *string   `json:"email,omitempty" db:"email"`
 	Timezone       string    `json:"timezone" db:"timezone"` // IANA timezone (e.g., "America/Mexico_City")
 	IsActive       bool      `json:"is_active" db:"is_active"`
@@ -44,6 +45,16 @@ func NewClinic(name string, organizationID uuid.UUID) *Clinic {
 	}
 }
 
+// NormalizePhone validates the phone against the region and stores its E.164 form
+func (c *Clinic) NormalizePhone(region string) error {
+	phone, e164, err := normalizePhoneFields(c.Phone, region)
+	if err != nil {
+		return err
+	}
+	c.Phone, c.PhoneE164 = phone, e164
+	return nil
+}
+
 // IsValid checks if the clinic has valid data
 func (c *Clinic) IsValid() bool {
 	return c.Validate() == nil
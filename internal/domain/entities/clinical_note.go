@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClinicalNoteStatus represents the lifecycle of a clinical note
+type ClinicalNoteStatus string
+
+const (
+	ClinicalNoteStatusDraft  ClinicalNoteStatus = "draft"  // Editable by its author
+	ClinicalNoteStatusSigned ClinicalNoteStatus = "signed" // Immutable; corrected through amendments
+)
+
+// SOAPNote holds the four sections of a SOAP clinical note
+type SOAPNote struct {
+	Subjective string `json:"subjective" db:"subjective"` // What the patient reports
+	Objective  string `json:"objective" db:"objective"`   // Examination findings
+	Assessment string `json:"assessment" db:"assessment"` // Diagnosis
+	Plan       string `json:"plan" db:"plan"`             // Treatment and follow-up
+}
+
+// IsEmpty reports whether every section is blank
+func (n SOAPNote) IsEmpty() bool {
+	return strings.TrimSpace(n.Subjective) == "" && strings.TrimSpace(n.Objective) == "" &&
+		strings.TrimSpace(n.Assessment) == "" && strings.TrimSpace(n.Plan) == ""
+}
+
+// ClinicalNoteTemplate pre-fills the sections of new notes. A template with a service
+// applies to appointments of that service; the one without is the organization default.
+type ClinicalNoteTemplate struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	OrganizationID uuid.UUID `json:"organization_id" db:"organization_id"`
+	ServiceID      *string   `json:"service_id,omitempty" db:"service_id"`
+	Name           string    `json:"name" db:"name"`
+	SOAPNote
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewClinicalNoteTemplate creates a validated note template
+func NewClinicalNoteTemplate(organizationID uuid.UUID, serviceID *string, name string, content SOAPNote) (*ClinicalNoteTemplate, error) {
+	now := time.Now()
+	template := &ClinicalNoteTemplate{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		ServiceID:      serviceID,
+		Name:           strings.TrimSpace(name),
+		SOAPNote:       content,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Validate validates the template fields
+func (t *ClinicalNoteTemplate) Validate() error {
+	if t.Name == "" {
+		return ErrClinicalNoteTemplateNameRequired
+	}
+	return nil
+}
+
+// ClinicalNote is a SOAP note written by the treating doctor for an appointment. Once
+// signed the note never changes; later corrections are recorded as amendments.
+type ClinicalNote struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id" db:"organization_id"`
+	PatientID      uuid.UUID  `json:"patient_id" db:"patient_id"`
+	AppointmentID  uuid.UUID  `json:"appointment_id" db:"appointment_id"`
+	DoctorID       uuid.UUID  `json:"doctor_id" db:"doctor_id"` // Treating doctor
+	AuthorID       uuid.UUID  `json:"author_id" db:"author_id"` // Profile of the treating doctor
+	TemplateID     *uuid.UUID `json:"template_id,omitempty" db:"template_id"`
+	SOAPNote
+	Status    ClinicalNoteStatus `json:"status" db:"status"`
+	SignedAt  *time.Time         `json:"signed_at,omitempty" db:"signed_at"`
+	CreatedAt time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// NewClinicalNote creates a draft note for an appointment, pre-filled from the template if any
+func NewClinicalNote(organizationID, patientID, appointmentID, doctorID, authorID uuid.UUID, template *ClinicalNoteTemplate) *ClinicalNote {
+	now := time.Now()
+	note := &ClinicalNote{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		AppointmentID:  appointmentID,
+		DoctorID:       doctorID,
+		AuthorID:       authorID,
+		Status:         ClinicalNoteStatusDraft,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if template != nil {
+		note.TemplateID = &template.ID
+		note.SOAPNote = template.SOAPNote
+	}
+	return note
+}
+
+// IsSigned checks if the note has been signed
+func (n *ClinicalNote) IsSigned() bool {
+	return n.Status == ClinicalNoteStatusSigned
+}
+
+// Edit replaces the content of a draft note; only its author may edit it
+func (n *ClinicalNote) Edit(authorID uuid.UUID, content SOAPNote) error {
+	if n.IsSigned() {
+		return ErrClinicalNoteSigned
+	}
+	if authorID != n.AuthorID {
+		return ErrClinicalNoteNotAuthor
+	}
+	n.SOAPNote = content
+	n.UpdatedAt = time.Now()
+	return nil
+}
+
+// Sign locks the note; only its author may sign it and an empty note cannot be signed
+func (n *ClinicalNote) Sign(authorID uuid.UUID) error {
+	if n.IsSigned() {
+		return ErrClinicalNoteSigned
+	}
+	if authorID != n.AuthorID {
+		return ErrClinicalNoteNotAuthor
+	}
+	if n.IsEmpty() {
+		return ErrClinicalNoteEmpty
+	}
+	now := time.Now()
+	n.Status = ClinicalNoteStatusSigned
+	n.SignedAt = &now
+	n.UpdatedAt = now
+	return nil
+}
+
+// ClinicalNoteAmendment is an addendum to a signed note
+type ClinicalNoteAmendment struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	NoteID    uuid.UUID  `json:"note_id" db:"note_id"`
+	AuthorID  uuid.UUID  `json:"author_id" db:"author_id"`
+	DoctorID  *uuid.UUID `json:"doctor_id,omitempty" db:"doctor_id"` // Doctor record of the author, if linked
+	Content   string     `json:"content" db:"content"`
+	Reason    *string    `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NewClinicalNoteAmendment creates an amendment; drafts are edited directly instead
+func NewClinicalNoteAmendment(note *ClinicalNote, authorID uuid.UUID, doctorID *uuid.UUID, content string, reason *string) (*ClinicalNoteAmendment, error) {
+	if !note.IsSigned() {
+		return nil, ErrClinicalNoteNotSigned
+	}
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, ErrClinicalNoteAmendmentEmpty
+	}
+
+	return &ClinicalNoteAmendment{
+		ID:        uuid.New(),
+		NoteID:    note.ID,
+		AuthorID:  authorID,
+		DoctorID:  doctorID,
+		Content:   content,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}, nil
+}
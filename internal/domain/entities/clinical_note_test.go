@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newTestClinicalNote(authorID uuid.UUID) *ClinicalNote {
+	return NewClinicalNote(uuid.New(), uuid.New(), uuid.New(), uuid.New(), authorID, nil)
+}
+
+func TestNewClinicalNotePrefillsFromTemplate(t *testing.T) {
+	template, err := NewClinicalNoteTemplate(uuid.New(), nil, " Check-up ", SOAPNote{Objective: "Gingiva:", Plan: "Recall in 6 months"})
+	if err != nil {
+		t.Fatalf("NewClinicalNoteTemplate() error = %v", err)
+	}
+	if template.Name != "Check-up" {
+		t.Errorf("Name = %q, want %q", template.Name, "Check-up")
+	}
+
+	note := NewClinicalNote(uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), template)
+	if note.Status != ClinicalNoteStatusDraft {
+		t.Errorf("Status = %q, want %q", note.Status, ClinicalNoteStatusDraft)
+	}
+	if note.TemplateID == nil || *note.TemplateID != template.ID {
+		t.Errorf("TemplateID = %v, want %v", note.TemplateID, template.ID)
+	}
+	if note.SOAPNote != template.SOAPNote {
+		t.Errorf("SOAPNote = %+v, want %+v", note.SOAPNote, template.SOAPNote)
+	}
+}
+
+func TestNewClinicalNoteTemplateRequiresName(t *testing.T) {
+	if _, err := NewClinicalNoteTemplate(uuid.New(), nil, "  ", SOAPNote{}); err != ErrClinicalNoteTemplateNameRequired {
+		t.Errorf("NewClinicalNoteTemplate() error = %v, want %v", err, ErrClinicalNoteTemplateNameRequired)
+	}
+}
+
+func TestClinicalNoteEditAndSign(t *testing.T) {
+	authorID := uuid.New()
+	content := SOAPNote{Subjective: "Pain on 36 when chewing", Assessment: "Caries 36 O"}
+
+	tests := []struct {
+		name    string
+		prepare func(note *ClinicalNote)
+		actor   uuid.UUID
+		action  func(note *ClinicalNote, actor uuid.UUID) error
+		err     error
+	}{
+		{
+			name:   "author edits draft",
+			actor:  authorID,
+			action: func(n *ClinicalNote, a uuid.UUID) error { return n.Edit(a, content) },
+		},
+		{
+			name:   "other doctor cannot edit",
+			actor:  uuid.New(),
+			action: func(n *ClinicalNote, a uuid.UUID) error { return n.Edit(a, content) },
+			err:    ErrClinicalNoteNotAuthor,
+		},
+		{
+			name:    "author signs note with content",
+			prepare: func(n *ClinicalNote) { n.SOAPNote = content },
+			actor:   authorID,
+			action:  func(n *ClinicalNote, a uuid.UUID) error { return n.Sign(a) },
+		},
+		{
+			name:    "blank note cannot be signed",
+			prepare: func(n *ClinicalNote) { n.SOAPNote = SOAPNote{Plan: "  "} },
+			actor:   authorID,
+			action:  func(n *ClinicalNote, a uuid.UUID) error { return n.Sign(a) },
+			err:     ErrClinicalNoteEmpty,
+		},
+		{
+			name:    "other doctor cannot sign",
+			prepare: func(n *ClinicalNote) { n.SOAPNote = content },
+			actor:   uuid.New(),
+			action:  func(n *ClinicalNote, a uuid.UUID) error { return n.Sign(a) },
+			err:     ErrClinicalNoteNotAuthor,
+		},
+		{
+			name: "signed note cannot be edited",
+			prepare: func(n *ClinicalNote) {
+				n.SOAPNote = content
+				_ = n.Sign(authorID)
+			},
+			actor:  authorID,
+			action: func(n *ClinicalNote, a uuid.UUID) error { return n.Edit(a, SOAPNote{Plan: "changed"}) },
+			err:    ErrClinicalNoteSigned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note := newTestClinicalNote(authorID)
+			if tt.prepare != nil {
+				tt.prepare(note)
+			}
+			if err := tt.action(note, tt.actor); err != tt.err {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestClinicalNoteSignSetsSignedAt(t *testing.T) {
+	authorID := uuid.New()
+	note := newTestClinicalNote(authorID)
+	note.Assessment = "Healthy"
+
+	if err := note.Sign(authorID); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if !note.IsSigned() || note.SignedAt == nil {
+		t.Errorf("note not signed: status = %q, signed_at = %v", note.Status, note.SignedAt)
+	}
+}
+
+func TestNewClinicalNoteAmendment(t *testing.T) {
+	authorID := uuid.New()
+	note := newTestClinicalNote(authorID)
+
+	if _, err := NewClinicalNoteAmendment(note, authorID, nil, "Addendum", nil); err != ErrClinicalNoteNotSigned {
+		t.Errorf("amending a draft: error = %v, want %v", err, ErrClinicalNoteNotSigned)
+	}
+
+	note.Plan = "Filling next visit"
+	if err := note.Sign(authorID); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	if _, err := NewClinicalNoteAmendment(note, authorID, nil, "   ", nil); err != ErrClinicalNoteAmendmentEmpty {
+		t.Errorf("empty amendment: error = %v, want %v", err, ErrClinicalNoteAmendmentEmpty)
+	}
+
+	amendment, err := NewClinicalNoteAmendment(note, uuid.New(), nil, " Allergy to latex reported after signing ", nil)
+	if err != nil {
+		t.Fatalf("NewClinicalNoteAmendment() error = %v", err)
+	}
+	if amendment.NoteID != note.ID || amendment.Content != "Allergy to latex reported after signing" {
+		t.Errorf("amendment = %+v", amendment)
+	}
+}
@@ -0,0 +1,304 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ConsentFormStatus represents the lifecycle of a consent form
+type ConsentFormStatus string
+
+const (
+	ConsentFormStatusPending ConsentFormStatus = "pending" // Waiting for the patient to sign through the link
+	ConsentFormStatusSigned  ConsentFormStatus = "signed"  // Signed; the rendered copy is immutable
+	ConsentFormStatusRevoked ConsentFormStatus = "revoked" // Withdrawn by the patient or cancelled before signing
+)
+
+// IsValidConsentFormStatus checks if the status is one of the known values
+func IsValidConsentFormStatus(status ConsentFormStatus) bool {
+	switch status {
+	case ConsentFormStatusPending, ConsentFormStatusSigned, ConsentFormStatusRevoked:
+		return true
+	}
+	return false
+}
+
+// SignatureType represents how a patient signed a consent form
+type SignatureType string
+
+const (
+	SignatureTypeTyped SignatureType = "typed" // Full name typed by the patient
+	SignatureTypeDrawn SignatureType = "drawn" // PNG drawn on a signature pad, as a data URL
+)
+
+// DrawnSignaturePrefix starts every drawn signature
+const DrawnSignaturePrefix = "data:image/png;base64,"
+
+// MaxDrawnSignatureBytes limits the decoded size of a drawn signature
+const MaxDrawnSignatureBytes = 256 << 10
+
+const maxSignerNameLength = 255
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// ConsentFormTemplate is the organization's wording of an informed consent. A template
+// with a service applies to appointments of that service; one without applies to all.
+// Required templates must be signed before the appointment takes place.
+type ConsentFormTemplate struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	OrganizationID uuid.UUID `json:"organization_id" db:"organization_id"`
+	ServiceID      *string   `json:"service_id,omitempty" db:"service_id"`
+	Name           string    `json:"name" db:"name"`
+	Body           string    `json:"body" db:"body"` // Text with merge fields such as {{patient.name}}
+	Required       bool      `json:"required" db:"required"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewConsentFormTemplate creates a validated consent template
+func NewConsentFormTemplate(organizationID uuid.UUID, serviceID *string, name, body string, required bool) (*ConsentFormTemplate, error) {
+	now := time.Now()
+	template := &ConsentFormTemplate{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		ServiceID:      serviceID,
+		Name:           strings.TrimSpace(name),
+		Body:           strings.TrimSpace(body),
+		Required:       required,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Validate validates the template fields
+func (t *ConsentFormTemplate) Validate() error {
+	if t.Name == "" {
+		return ErrConsentTemplateNameRequired
+	}
+	if t.Body == "" {
+		return ErrConsentTemplateBodyRequired
+	}
+	return nil
+}
+
+// AppliesToService reports whether the template covers appointments of the service
+func (t *ConsentFormTemplate) AppliesToService(serviceID *string) bool {
+	if t.ServiceID == nil {
+		return true
+	}
+	return serviceID != nil && *serviceID == *t.ServiceID
+}
+
+// ConsentSignature is what the patient submits to sign a consent form
+type ConsentSignature struct {
+	SignerName string
+	Type       SignatureType
+	Data       string // Typed name, or the drawn PNG as a data URL
+}
+
+// Validate checks the signer and the signature content
+func (s ConsentSignature) Validate() error {
+	name := strings.TrimSpace(s.SignerName)
+	if name == "" {
+		return ErrSignerNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxSignerNameLength {
+		return ErrSignerNameTooLong
+	}
+
+	data := strings.TrimSpace(s.Data)
+	switch s.Type {
+	case SignatureTypeTyped:
+		if data == "" {
+			return ErrSignatureRequired
+		}
+		if utf8.RuneCountInString(data) > maxSignerNameLength {
+			return ErrSignerNameTooLong
+		}
+	case SignatureTypeDrawn:
+		if data == "" {
+			return ErrSignatureRequired
+		}
+		if !strings.HasPrefix(data, DrawnSignaturePrefix) {
+			return ErrInvalidDrawnSignature
+		}
+		encoded := strings.TrimPrefix(data, DrawnSignaturePrefix)
+		if base64.StdEncoding.DecodedLen(len(encoded)) > MaxDrawnSignatureBytes {
+			return ErrSignatureTooLarge
+		}
+		image, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || !bytes.HasPrefix(image, pngSignature) {
+			return ErrInvalidDrawnSignature
+		}
+	default:
+		return ErrInvalidSignatureType
+	}
+
+	return nil
+}
+
+// ConsentForm is a consent rendered for one patient, optionally for one appointment.
+// The title and body are fixed when the form is requested; once signed, the signature
+// and the stored copy never change. Signed forms may later be revoked.
+type ConsentForm struct {
+	ID               uuid.UUID         `json:"id" db:"id"`
+	OrganizationID   uuid.UUID         `json:"organization_id" db:"organization_id"`
+	PatientID        uuid.UUID         `json:"patient_id" db:"patient_id"`
+	TemplateID       *uuid.UUID        `json:"template_id,omitempty" db:"template_id"`
+	AppointmentID    *uuid.UUID        `json:"appointment_id,omitempty" db:"appointment_id"` // Nil for consents that cover every visit
+	DoctorID         *uuid.UUID        `json:"doctor_id,omitempty" db:"doctor_id"`
+	ServiceID        *string           `json:"service_id,omitempty" db:"service_id"`
+	Title            string            `json:"title" db:"title"`
+	Body             string            `json:"body" db:"body"` // Template with merge fields filled in
+	Status           ConsentFormStatus `json:"status" db:"status"`
+	ExpiresAt        time.Time         `json:"expires_at" db:"expires_at"` // Signing link expiry
+	RequestedBy      *uuid.UUID        `json:"requested_by,omitempty" db:"requested_by"`
+	SignerName       *string           `json:"signer_name,omitempty" db:"signer_name"`
+	SignatureType    *SignatureType    `json:"signature_type,omitempty" db:"signature_type"`
+	SignatureData    *string           `json:"-" db:"signature_data"`
+	SignedIP         *string           `json:"signed_ip,omitempty" db:"signed_ip"`
+	SignedUserAgent  *string           `json:"signed_user_agent,omitempty" db:"signed_user_agent"`
+	SignedAt         *time.Time        `json:"signed_at,omitempty" db:"signed_at"`
+	DocumentKey      *string           `json:"-" db:"document_key"`
+	DocumentSHA256   *string           `json:"document_sha256,omitempty" db:"document_sha256"`
+	RevokedAt        *time.Time        `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedBy        *uuid.UUID        `json:"revoked_by,omitempty" db:"revoked_by"`
+	RevocationReason *string           `json:"revocation_reason,omitempty" db:"revocation_reason"`
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// NewConsentForm creates a pending consent form from a template and its rendered body;
+// the signing link expires after ttl
+func NewConsentForm(organizationID, patientID uuid.UUID, template *ConsentFormTemplate, body string, ttl time.Duration) *ConsentForm {
+	now := time.Now()
+	return &ConsentForm{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		TemplateID:     &template.ID,
+		ServiceID:      template.ServiceID,
+		Title:          template.Name,
+		Body:           body,
+		Status:         ConsentFormStatusPending,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// IsSigned checks if the form has been signed and not revoked
+func (f *ConsentForm) IsSigned() bool {
+	return f.Status == ConsentFormStatusSigned
+}
+
+// IsExpired reports whether the signing link of a pending form has expired
+func (f *ConsentForm) IsExpired(now time.Time) bool {
+	return f.Status == ConsentFormStatusPending && !now.Before(f.ExpiresAt)
+}
+
+// CanBeSigned checks that the form is pending and its link has not expired
+func (f *ConsentForm) CanBeSigned(now time.Time) error {
+	switch f.Status {
+	case ConsentFormStatusSigned:
+		return ErrConsentFormAlreadySigned
+	case ConsentFormStatusRevoked:
+		return ErrConsentFormRevoked
+	}
+	if f.IsExpired(now) {
+		return ErrConsentFormExpired
+	}
+	return nil
+}
+
+// Sign records the patient's signature and where the signed copy will be stored
+func (f *ConsentForm) Sign(signature ConsentSignature, ip, userAgent string, now time.Time) error {
+	if err := f.CanBeSigned(now); err != nil {
+		return err
+	}
+	if err := signature.Validate(); err != nil {
+		return err
+	}
+
+	signerName := strings.TrimSpace(signature.SignerName)
+	signatureType := signature.Type
+	data := strings.TrimSpace(signature.Data)
+	documentKey := fmt.Sprintf("organizations/%s/patients/%s/consents/%s/%s.html", f.OrganizationID, f.PatientID, f.ID, uuid.New())
+
+	f.Status = ConsentFormStatusSigned
+	f.SignerName = &signerName
+	f.SignatureType = &signatureType
+	f.SignatureData = &data
+	f.SignedIP = optionalString(ip)
+	f.SignedUserAgent = optionalString(userAgent)
+	f.SignedAt = &now
+	f.DocumentKey = &documentKey
+	f.UpdatedAt = now
+	return nil
+}
+
+// AttachDocument records the digest of the stored signed copy
+func (f *ConsentForm) AttachDocument(sha256Hex string) {
+	f.DocumentSHA256 = &sha256Hex
+}
+
+// Renew extends the signing link of a pending form
+func (f *ConsentForm) Renew(ttl time.Duration, now time.Time) error {
+	switch f.Status {
+	case ConsentFormStatusSigned:
+		return ErrConsentFormAlreadySigned
+	case ConsentFormStatusRevoked:
+		return ErrConsentFormRevoked
+	}
+	f.ExpiresAt = now.Add(ttl)
+	f.UpdatedAt = now
+	return nil
+}
+
+// Revoke cancels a pending form or withdraws a signed consent; the signed copy is kept
+func (f *ConsentForm) Revoke(revokedBy *uuid.UUID, reason string, now time.Time) error {
+	if f.Status == ConsentFormStatusRevoked {
+		return ErrConsentFormRevoked
+	}
+	f.Status = ConsentFormStatusRevoked
+	f.RevokedAt = &now
+	f.RevokedBy = revokedBy
+	f.RevocationReason = optionalString(reason)
+	f.UpdatedAt = now
+	return nil
+}
+
+// HasDocument checks if a signed copy was stored
+func (f *ConsentForm) HasDocument() bool {
+	return f.DocumentKey != nil && f.SignedAt != nil
+}
+
+// Covers reports whether the signed form satisfies the template for an appointment:
+// forms without an appointment cover every visit
+func (f *ConsentForm) Covers(templateID, appointmentID uuid.UUID) bool {
+	if !f.IsSigned() || f.TemplateID == nil || *f.TemplateID != templateID {
+		return false
+	}
+	return f.AppointmentID == nil || *f.AppointmentID == appointmentID
+}
+
+// optionalString returns nil for blank values
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
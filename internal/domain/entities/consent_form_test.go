@@ -0,0 +1,174 @@
+package entities
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func drawnSignature(image []byte) string {
+	return DrawnSignaturePrefix + base64.StdEncoding.EncodeToString(image)
+}
+
+func TestConsentSignatureValidate(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+
+	tests := []struct {
+		name      string
+		signature ConsentSignature
+		err       error
+	}{
+		{
+			name:      "typed",
+			signature: ConsentSignature{SignerName: "Ana López", Type: SignatureTypeTyped, Data: "Ana López"},
+		},
+		{
+			name:      "drawn",
+			signature: ConsentSignature{SignerName: "Ana López", Type: SignatureTypeDrawn, Data: drawnSignature(png)},
+		},
+		{
+			name:      "signer required",
+			signature: ConsentSignature{SignerName: "  ", Type: SignatureTypeTyped, Data: "Ana"},
+			err:       ErrSignerNameRequired,
+		},
+		{
+			name:      "typed signature required",
+			signature: ConsentSignature{SignerName: "Ana", Type: SignatureTypeTyped},
+			err:       ErrSignatureRequired,
+		},
+		{
+			name:      "unknown type",
+			signature: ConsentSignature{SignerName: "Ana", Type: SignatureType("biometric"), Data: "x"},
+			err:       ErrInvalidSignatureType,
+		},
+		{
+			name:      "drawn signature must be a data URL",
+			signature: ConsentSignature{SignerName: "Ana", Type: SignatureTypeDrawn, Data: "https://example.com/sig.png"},
+			err:       ErrInvalidDrawnSignature,
+		},
+		{
+			name:      "drawn signature must be a PNG",
+			signature: ConsentSignature{SignerName: "Ana", Type: SignatureTypeDrawn, Data: drawnSignature([]byte("<svg onload=alert(1)>"))},
+			err:       ErrInvalidDrawnSignature,
+		},
+		{
+			name:      "drawn signature size is limited",
+			signature: ConsentSignature{SignerName: "Ana", Type: SignatureTypeDrawn, Data: drawnSignature(append(png, make([]byte, MaxDrawnSignatureBytes)...))},
+			err:       ErrSignatureTooLarge,
+		},
+		{
+			name:      "signer name length is limited",
+			signature: ConsentSignature{SignerName: strings.Repeat("a", 256), Type: SignatureTypeTyped, Data: "a"},
+			err:       ErrSignerNameTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.signature.Validate(); err != tt.err {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestConsentFormLifecycle(t *testing.T) {
+	serviceID := "extraction"
+	template, err := NewConsentFormTemplate(uuid.New(), &serviceID, " Extraction consent ", "I consent.", true)
+	if err != nil {
+		t.Fatalf("NewConsentFormTemplate() error = %v", err)
+	}
+
+	form := NewConsentForm(template.OrganizationID, uuid.New(), template, "I consent.", time.Hour)
+	if form.Title != "Extraction consent" || form.Status != ConsentFormStatusPending {
+		t.Fatalf("NewConsentForm() = %+v", form)
+	}
+
+	signature := ConsentSignature{SignerName: "Ana López", Type: SignatureTypeTyped, Data: "Ana López"}
+
+	if err := form.Sign(signature, "203.0.113.7", "Mozilla/5.0", form.ExpiresAt); err != ErrConsentFormExpired {
+		t.Errorf("Sign() after expiry error = %v, want %v", err, ErrConsentFormExpired)
+	}
+
+	now := time.Now()
+	if err := form.Sign(signature, "203.0.113.7", "Mozilla/5.0", now); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if !form.IsSigned() || form.SignedAt == nil || *form.SignedIP != "203.0.113.7" || !form.HasDocument() {
+		t.Errorf("Sign() did not record the signature: %+v", form)
+	}
+	wantPrefix := "organizations/" + form.OrganizationID.String() + "/patients/" + form.PatientID.String() + "/consents/"
+	if !strings.HasPrefix(*form.DocumentKey, wantPrefix) {
+		t.Errorf("DocumentKey = %q, want prefix %q", *form.DocumentKey, wantPrefix)
+	}
+
+	if err := form.Sign(signature, "", "", now); err != ErrConsentFormAlreadySigned {
+		t.Errorf("second Sign() error = %v, want %v", err, ErrConsentFormAlreadySigned)
+	}
+	if err := form.Renew(time.Hour, now); err != ErrConsentFormAlreadySigned {
+		t.Errorf("Renew() of a signed form error = %v, want %v", err, ErrConsentFormAlreadySigned)
+	}
+
+	if err := form.Revoke(nil, "patient withdrew consent", now); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if form.Status != ConsentFormStatusRevoked || !form.HasDocument() {
+		t.Errorf("Revoke() should keep the signed copy: %+v", form)
+	}
+	if err := form.Revoke(nil, "", now); err != ErrConsentFormRevoked {
+		t.Errorf("second Revoke() error = %v, want %v", err, ErrConsentFormRevoked)
+	}
+}
+
+func TestConsentFormCovers(t *testing.T) {
+	templateID := uuid.New()
+	appointmentID := uuid.New()
+	otherAppointmentID := uuid.New()
+	signedAt := time.Now()
+
+	signed := func(appointmentID *uuid.UUID) *ConsentForm {
+		return &ConsentForm{TemplateID: &templateID, AppointmentID: appointmentID, Status: ConsentFormStatusSigned, SignedAt: &signedAt}
+	}
+
+	tests := []struct {
+		name string
+		form *ConsentForm
+		want bool
+	}{
+		{name: "signed for the appointment", form: signed(&appointmentID), want: true},
+		{name: "signed for every visit", form: signed(nil), want: true},
+		{name: "signed for another appointment", form: signed(&otherAppointmentID)},
+		{name: "pending", form: &ConsentForm{TemplateID: &templateID, Status: ConsentFormStatusPending}},
+		{name: "revoked", form: &ConsentForm{TemplateID: &templateID, Status: ConsentFormStatusRevoked, SignedAt: &signedAt}},
+		{name: "another template", form: &ConsentForm{TemplateID: &otherAppointmentID, Status: ConsentFormStatusSigned}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.form.Covers(templateID, appointmentID); got != tt.want {
+				t.Errorf("Covers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsentFormTemplateAppliesToService(t *testing.T) {
+	extraction := "extraction"
+	implant := "implant"
+
+	general := &ConsentFormTemplate{}
+	specific := &ConsentFormTemplate{ServiceID: &extraction}
+
+	if !general.AppliesToService(nil) || !general.AppliesToService(&implant) {
+		t.Error("a template without a service should apply to every appointment")
+	}
+	if !specific.AppliesToService(&extraction) {
+		t.Error("a service template should apply to its service")
+	}
+	if specific.AppliesToService(&implant) || specific.AppliesToService(nil) {
+		t.Error("a service template should not apply to other services")
+	}
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is the currency of organizations that have not chosen one
+const DefaultCurrency = "MXN"
+
+// currencyDecimals are the ISO 4217 currencies organizations can bill in, with the number of
+// minor units in one major unit expressed as decimal places
+var currencyDecimals = map[string]int{
+	"MXN": 2,
+	"USD": 2,
+	"CAD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"COP": 2,
+	"PEN": 2,
+	"CLP": 0,
+}
+
+// NormalizeCurrency uppercases a currency code and checks it is supported
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if _, ok := currencyDecimals[code]; !ok {
+		return "", ErrUnsupportedCurrency
+	}
+	return code, nil
+}
+
+// ToMinorUnits converts an amount in major units (such as a service base price) to the
+// integer minor units of the currency, rounding half away from zero
+func ToMinorUnits(amount float64, currency string) int64 {
+	decimals, ok := currencyDecimals[currency]
+	if !ok {
+		decimals = 2
+	}
+	return int64(math.Round(amount * math.Pow10(decimals)))
+}
@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ToothCondition is a finding or treatment recorded on a tooth or some of its surfaces
+type ToothCondition string
+
+const (
+	ToothConditionSound   ToothCondition = "sound" // Clears earlier findings
+	ToothConditionCaries  ToothCondition = "caries"
+	ToothConditionFilling ToothCondition = "filling"
+	ToothConditionCrown   ToothCondition = "crown"
+	ToothConditionMissing ToothCondition = "missing"
+	ToothConditionImplant ToothCondition = "implant"
+)
+
+// IsValidToothCondition checks if the condition is supported
+func IsValidToothCondition(condition ToothCondition) bool {
+	switch condition {
+	case ToothConditionSound, ToothConditionCaries, ToothConditionFilling,
+		ToothConditionCrown, ToothConditionMissing, ToothConditionImplant:
+		return true
+	}
+	return false
+}
+
+// AppliesToSurfaces reports whether the condition can be charted on individual surfaces;
+// crowns, missing teeth and implants always affect the whole tooth
+func (c ToothCondition) AppliesToSurfaces() bool {
+	return c == ToothConditionSound || c == ToothConditionCaries || c == ToothConditionFilling
+}
+
+// DentalChartEntry is a timestamped charting record for one tooth. Entries are never
+// edited: a later entry supersedes earlier ones, so the chart can be rebuilt for any date.
+type DentalChartEntry struct {
+	ID             uuid.UUID      `json:"id" db:"id"`
+	OrganizationID uuid.UUID      `json:"organization_id" db:"organization_id"`
+	PatientID      uuid.UUID      `json:"patient_id" db:"patient_id"`
+	Tooth          Tooth          `json:"tooth" db:"tooth"`
+	Surfaces       []ToothSurface `json:"surfaces" db:"surfaces"` // Empty when the entry applies to the whole tooth
+	Condition      ToothCondition `json:"condition" db:"condition"`
+	Notes          *string        `json:"notes,omitempty" db:"notes"`
+	AppointmentID  *uuid.UUID     `json:"appointment_id,omitempty" db:"appointment_id"`
+	DoctorID       *uuid.UUID     `json:"doctor_id,omitempty" db:"doctor_id"`
+	RecordedAt     time.Time      `json:"recorded_at" db:"recorded_at"` // When the finding was observed
+	RecordedBy     *uuid.UUID     `json:"recorded_by,omitempty" db:"recorded_by"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+}
+
+// NewDentalChartEntry creates a validated charting entry. Surfaces are deduplicated and
+// kept in a stable order.
+func NewDentalChartEntry(organizationID, patientID uuid.UUID, tooth Tooth, surfaces []ToothSurface, condition ToothCondition, notes *string, recordedAt time.Time) (*DentalChartEntry, error) {
+	entry := &DentalChartEntry{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		Tooth:          tooth,
+		Surfaces:       normalizeSurfaces(surfaces),
+		Condition:      condition,
+		Notes:          notes,
+		RecordedAt:     recordedAt,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Validate checks the tooth, surfaces and condition against the tooth's dentition
+func (e *DentalChartEntry) Validate() error {
+	if !e.Tooth.IsValid() {
+		return ErrInvalidToothNumber
+	}
+	if !IsValidToothCondition(e.Condition) {
+		return ErrInvalidToothCondition
+	}
+	if len(e.Surfaces) > 0 && !e.Condition.AppliesToSurfaces() {
+		return ErrToothConditionNotOnSurfaces
+	}
+	for _, surface := range e.Surfaces {
+		if !e.Tooth.HasSurface(surface) {
+			return ErrInvalidToothSurface
+		}
+	}
+	if e.Condition == ToothConditionImplant && e.Tooth.IsPrimary() {
+		return ErrImplantOnPrimaryTooth
+	}
+	// A few minutes of clock skew between the charting device and the server are tolerated
+	if e.RecordedAt.After(time.Now().Add(5 * time.Minute)) {
+		return ErrDentalChartEntryInFuture
+	}
+	return nil
+}
+
+// surfaceOrder is the conventional order surfaces are written in (e.g. "MOD")
+var surfaceOrder = map[ToothSurface]int{
+	SurfaceMesial: 0, SurfaceOcclusal: 1, SurfaceIncisal: 2, SurfaceDistal: 3, SurfaceBuccal: 4, SurfaceLingual: 5,
+}
+
+// normalizeSurfaces deduplicates surfaces and sorts them in conventional order. Unknown
+// surfaces are kept at the end so validation can reject them.
+func normalizeSurfaces(surfaces []ToothSurface) []ToothSurface {
+	seen := make(map[ToothSurface]bool)
+	normalized := make([]ToothSurface, 0, len(surfaces))
+	for _, surface := range surfaces {
+		if !seen[surface] {
+			seen[surface] = true
+			normalized = append(normalized, surface)
+		}
+	}
+
+	sort.SliceStable(normalized, func(i, j int) bool {
+		oi, okI := surfaceOrder[normalized[i]]
+		oj, okJ := surfaceOrder[normalized[j]]
+		if okI != okJ {
+			return okI
+		}
+		return oi < oj
+	})
+	return normalized
+}
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewDentalChartEntryValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		tooth     Tooth
+		surfaces  []ToothSurface
+		condition ToothCondition
+		err       error
+	}{
+		{name: "occlusal caries on a molar", tooth: 36, surfaces: []ToothSurface{SurfaceOcclusal}, condition: ToothConditionCaries},
+		{name: "incisal filling on an incisor", tooth: 11, surfaces: []ToothSurface{SurfaceIncisal, SurfaceMesial}, condition: ToothConditionFilling},
+		{name: "incisors have no occlusal surface", tooth: 11, surfaces: []ToothSurface{SurfaceOcclusal}, condition: ToothConditionCaries, err: ErrInvalidToothSurface},
+		{name: "molars have no incisal edge", tooth: 46, surfaces: []ToothSurface{SurfaceIncisal}, condition: ToothConditionFilling, err: ErrInvalidToothSurface},
+		{name: "unknown surface", tooth: 46, surfaces: []ToothSurface{"X"}, condition: ToothConditionFilling, err: ErrInvalidToothSurface},
+		{name: "crowns cover the whole tooth", tooth: 46, surfaces: []ToothSurface{SurfaceOcclusal}, condition: ToothConditionCrown, err: ErrToothConditionNotOnSurfaces},
+		{name: "missing primary tooth", tooth: 84, condition: ToothConditionMissing},
+		{name: "no implants on primary teeth", tooth: 84, condition: ToothConditionImplant, err: ErrImplantOnPrimaryTooth},
+		{name: "unknown condition", tooth: 21, condition: "fracture", err: ErrInvalidToothCondition},
+		{name: "invalid tooth", tooth: 59, condition: ToothConditionCaries, err: ErrInvalidToothNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDentalChartEntry(uuid.New(), uuid.New(), tt.tooth, tt.surfaces, tt.condition, nil, time.Now())
+			if err != tt.err {
+				t.Errorf("NewDentalChartEntry() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewDentalChartEntryNormalizesSurfaces(t *testing.T) {
+	entry, err := NewDentalChartEntry(uuid.New(), uuid.New(), 26, []ToothSurface{SurfaceDistal, SurfaceOcclusal, SurfaceMesial, SurfaceDistal}, ToothConditionFilling, nil, time.Now())
+	if err != nil {
+		t.Fatalf("NewDentalChartEntry() error = %v", err)
+	}
+
+	want := []ToothSurface{SurfaceMesial, SurfaceOcclusal, SurfaceDistal}
+	if !reflect.DeepEqual(entry.Surfaces, want) {
+		t.Errorf("Surfaces = %v, want %v", entry.Surfaces, want)
+	}
+}
+
+func TestNewDentalChartEntryRejectsFutureDates(t *testing.T) {
+	_, err := NewDentalChartEntry(uuid.New(), uuid.New(), 11, nil, ToothConditionSound, nil, time.Now().Add(time.Hour))
+	if err != ErrDentalChartEntryInFuture {
+		t.Errorf("NewDentalChartEntry() error = %v, want %v", err, ErrDentalChartEntryInFuture)
+	}
+}
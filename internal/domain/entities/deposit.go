@@ -0,0 +1,281 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DepositType represents how a deposit amount is computed
+type DepositType string
+
+const (
+	DepositTypeFixed      DepositType = "fixed"      // Amount in minor units
+	DepositTypePercentage DepositType = "percentage" // Percent of the price of the appointment's procedure
+)
+
+// IsValidDepositType checks if the deposit type is supported
+func IsValidDepositType(depositType DepositType) bool {
+	return depositType == DepositTypeFixed || depositType == DepositTypePercentage
+}
+
+// validateDepositRule checks that a deposit rule charges something under its type
+func validateDepositRule(depositType DepositType, amount int64, percent int) error {
+	switch depositType {
+	case DepositTypeFixed:
+		if amount <= 0 {
+			return ErrInvalidDepositAmount
+		}
+	case DepositTypePercentage:
+		if percent < 1 || percent > 100 {
+			return ErrInvalidDepositAmount
+		}
+	default:
+		return ErrInvalidDepositType
+	}
+	return nil
+}
+
+// DepositPolicy is an organization's deposit policy. When enabled, appointments for services
+// that require a deposit, or for patients with NoShowThreshold no-shows in the last
+// NoShowLookbackDays, are held until the deposit is paid. A hold lasts HoldHours after booking,
+// and ends at the latest CutoffHours before the appointment starts.
+type DepositPolicy struct {
+	OrganizationID     uuid.UUID   `json:"organization_id" db:"organization_id"`
+	Enabled            bool        `json:"enabled" db:"enabled"`
+	HoldHours          int         `json:"hold_hours" db:"hold_hours"`
+	CutoffHours        int         `json:"cutoff_hours" db:"cutoff_hours"`
+	NoShowThreshold    int         `json:"no_show_threshold" db:"no_show_threshold"`         // 0 never asks unreliable patients for a deposit
+	NoShowLookbackDays int         `json:"no_show_lookback_days" db:"no_show_lookback_days"` // 0 counts the whole history
+	Type               DepositType `json:"type" db:"deposit_type"`                           // Deposit asked from unreliable patients
+	Amount             int64       `json:"amount" db:"deposit_amount"`
+	Percent            int         `json:"percent" db:"deposit_percent"`
+	UpdatedBy          *uuid.UUID  `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt          time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultDepositPolicy returns the policy of an organization that has not set one: no
+// deposits, with the defaults offered when it is enabled
+func DefaultDepositPolicy(organizationID uuid.UUID) *DepositPolicy {
+	return &DepositPolicy{
+		OrganizationID:     organizationID,
+		HoldHours:          48,
+		CutoffHours:        24,
+		NoShowThreshold:    2,
+		NoShowLookbackDays: 365,
+		Type:               DepositTypeFixed,
+	}
+}
+
+// Validate validates the policy fields; the reliability deposit only has to be set when the
+// policy is enabled and asks unreliable patients for one
+func (p *DepositPolicy) Validate() error {
+	if p.HoldHours < 1 || p.HoldHours > 720 || p.CutoffHours < 0 || p.CutoffHours > 720 {
+		return ErrInvalidDepositHold
+	}
+	if p.NoShowThreshold < 0 || p.NoShowLookbackDays < 0 {
+		return ErrInvalidDepositReliability
+	}
+	if p.Amount < 0 || p.Percent < 0 || p.Percent > 100 {
+		return ErrInvalidDepositAmount
+	}
+	if !IsValidDepositType(p.Type) {
+		return ErrInvalidDepositType
+	}
+	if p.Enabled && p.NoShowThreshold > 0 {
+		return validateDepositRule(p.Type, p.Amount, p.Percent)
+	}
+	return nil
+}
+
+// SetDeposit sets the deposit required to book the service, or clears it when depositType is nil
+func (s *Service) SetDeposit(depositType *DepositType, amount int64, percent int) error {
+	if depositType == nil {
+		s.DepositType = nil
+		s.DepositAmount = 0
+		s.DepositPercent = 0
+		return nil
+	}
+	if err := validateDepositRule(*depositType, amount, percent); err != nil {
+		return err
+	}
+
+	s.DepositType = depositType
+	s.DepositAmount = 0
+	s.DepositPercent = 0
+	if *depositType == DepositTypeFixed {
+		s.DepositAmount = amount
+	} else {
+		s.DepositPercent = percent
+	}
+	return nil
+}
+
+// DepositReason tells why a deposit was asked for
+type DepositReason string
+
+const (
+	DepositReasonService     DepositReason = "service"     // The service requires one
+	DepositReasonReliability DepositReason = "reliability" // The patient missed too many appointments
+	DepositReasonManual      DepositReason = "manual"      // Requested by staff
+)
+
+// DepositStatus represents where a deposit is in its collection
+type DepositStatus string
+
+const (
+	DepositStatusPending   DepositStatus = "pending"   // Slot held until DueAt
+	DepositStatusPaid      DepositStatus = "paid"      // Received through the payment gateway
+	DepositStatusWaived    DepositStatus = "waived"    // Staff let the patient book without it
+	DepositStatusExpired   DepositStatus = "expired"   // Not paid by DueAt; the held slot was released
+	DepositStatusCancelled DepositStatus = "cancelled" // The appointment was cancelled before it was paid
+)
+
+// IsValidDepositStatus checks if the deposit status is supported
+func IsValidDepositStatus(status DepositStatus) bool {
+	switch status {
+	case DepositStatusPending, DepositStatusPaid, DepositStatusWaived, DepositStatusExpired, DepositStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Deposit is a prepayment asked for an appointment before it can be confirmed. Amount is in
+// minor units of Currency and is paid through a link of the payment gateway.
+type Deposit struct {
+	ID               uuid.UUID     `json:"id" db:"id"`
+	OrganizationID   uuid.UUID     `json:"organization_id" db:"organization_id"`
+	ClinicID         uuid.UUID     `json:"clinic_id" db:"clinic_id"`
+	PatientID        uuid.UUID     `json:"patient_id" db:"patient_id"`
+	AppointmentID    uuid.UUID     `json:"appointment_id" db:"appointment_id"`
+	Reason           DepositReason `json:"reason" db:"reason"`
+	Status           DepositStatus `json:"status" db:"status"`
+	Currency         string        `json:"currency" db:"currency"`
+	Amount           int64         `json:"amount" db:"amount"`
+	DueAt            time.Time     `json:"due_at" db:"due_at"`
+	PaymentLinkID    *string       `json:"payment_link_id,omitempty" db:"payment_link_id"` // Gateway identifier of the payment link
+	PaymentURL       *string       `json:"payment_url,omitempty" db:"payment_url"`
+	PaymentReference *string       `json:"payment_reference,omitempty" db:"payment_reference"` // Gateway identifier of the payment
+	PaidAt           *time.Time    `json:"paid_at,omitempty" db:"paid_at"`
+	InvoiceID        *uuid.UUID    `json:"invoice_id,omitempty" db:"invoice_id"` // Invoice the deposit was applied to as a payment
+	AppliedAt        *time.Time    `json:"applied_at,omitempty" db:"applied_at"`
+	WaiveReason      *string       `json:"waive_reason,omitempty" db:"waive_reason"`
+	WaivedBy         *uuid.UUID    `json:"waived_by,omitempty" db:"waived_by"`
+	WaivedAt         *time.Time    `json:"waived_at,omitempty" db:"waived_at"`
+	ReleasedAt       *time.Time    `json:"released_at,omitempty" db:"released_at"` // When it expired or was cancelled
+	RequestedBy      *uuid.UUID    `json:"requested_by,omitempty" db:"requested_by"`
+	CreatedAt        time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// NewDeposit creates a pending deposit for an appointment, due at dueAt
+func NewDeposit(organizationID, clinicID, patientID, appointmentID uuid.UUID, reason DepositReason, currency string, amount int64, dueAt time.Time, requestedBy *uuid.UUID) (*Deposit, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidDepositAmount
+	}
+
+	now := time.Now()
+	return &Deposit{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		ClinicID:       clinicID,
+		PatientID:      patientID,
+		AppointmentID:  appointmentID,
+		Reason:         reason,
+		Status:         DepositStatusPending,
+		Currency:       currency,
+		Amount:         amount,
+		DueAt:          dueAt,
+		RequestedBy:    requestedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// AttachPaymentLink records the gateway link the patient pays the deposit through
+func (d *Deposit) AttachPaymentLink(linkID, url string) {
+	d.PaymentLinkID = &linkID
+	d.PaymentURL = &url
+}
+
+// IsActive reports whether the deposit still holds or pays for its appointment
+func (d *Deposit) IsActive() bool {
+	return d.Status == DepositStatusPending || d.Status == DepositStatusPaid
+}
+
+// IsOverdue reports whether a pending deposit was not paid by its due date
+func (d *Deposit) IsOverdue(now time.Time) bool {
+	return d.Status == DepositStatusPending && !now.Before(d.DueAt)
+}
+
+// MarkPaid records the payment of the deposit. A payment that comes through after the deposit
+// expired or was cancelled is still recorded, so staff can refund it or rebook the patient.
+func (d *Deposit) MarkPaid(reference string, paidAt, now time.Time) error {
+	if d.Status == DepositStatusPaid {
+		return ErrDepositNotPending
+	}
+
+	d.Status = DepositStatusPaid
+	d.PaymentReference = &reference
+	d.PaidAt = &paidAt
+	d.UpdatedAt = now
+	return nil
+}
+
+// Waive lets the patient keep the appointment without paying a pending deposit
+func (d *Deposit) Waive(reason string, waivedBy *uuid.UUID, now time.Time) error {
+	if d.Status != DepositStatusPending {
+		return ErrDepositNotPending
+	}
+	if waivedBy == nil {
+		return ErrDepositWaiveRequiresStaff
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrDepositWaiveReasonRequired
+	}
+
+	d.Status = DepositStatusWaived
+	d.WaiveReason = &reason
+	d.WaivedBy = waivedBy
+	d.WaivedAt = &now
+	d.UpdatedAt = now
+	return nil
+}
+
+// Expire releases a pending deposit that was not paid in time
+func (d *Deposit) Expire(now time.Time) error {
+	return d.release(DepositStatusExpired, now)
+}
+
+// Cancel releases a pending deposit whose appointment was cancelled
+func (d *Deposit) Cancel(now time.Time) error {
+	return d.release(DepositStatusCancelled, now)
+}
+
+// release ends a pending deposit with the status
+func (d *Deposit) release(status DepositStatus, now time.Time) error {
+	if d.Status != DepositStatusPending {
+		return ErrDepositNotPending
+	}
+
+	d.Status = status
+	d.ReleasedAt = &now
+	d.UpdatedAt = now
+	return nil
+}
+
+// CanBeApplied reports whether the deposit was paid and not yet applied to an invoice
+func (d *Deposit) CanBeApplied() bool {
+	return d.Status == DepositStatusPaid && d.InvoiceID == nil
+}
+
+// ApplyToInvoice records that the paid deposit was entered as a payment of an invoice
+func (d *Deposit) ApplyToInvoice(invoiceID uuid.UUID, now time.Time) {
+	d.InvoiceID = &invoiceID
+	d.AppliedAt = &now
+	d.UpdatedAt = now
+}
@@ -0,0 +1,162 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDepositPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*DepositPolicy)
+		err    error
+	}{
+		{name: "default", modify: func(p *DepositPolicy) {}},
+		{name: "fixed reliability deposit", modify: func(p *DepositPolicy) { p.Enabled, p.Amount = true, 50000 }},
+		{name: "percentage reliability deposit", modify: func(p *DepositPolicy) {
+			p.Enabled, p.Type, p.Percent = true, DepositTypePercentage, 30
+		}},
+		{name: "enabled without reliability deposit", modify: func(p *DepositPolicy) { p.Enabled = true }, err: ErrInvalidDepositAmount},
+		{name: "enabled for services only", modify: func(p *DepositPolicy) { p.Enabled, p.NoShowThreshold = true, 0 }},
+		{name: "percentage above 100", modify: func(p *DepositPolicy) { p.Type, p.Percent = DepositTypePercentage, 101 }, err: ErrInvalidDepositAmount},
+		{name: "no hold", modify: func(p *DepositPolicy) { p.HoldHours = 0 }, err: ErrInvalidDepositHold},
+		{name: "negative cutoff", modify: func(p *DepositPolicy) { p.CutoffHours = -1 }, err: ErrInvalidDepositHold},
+		{name: "negative threshold", modify: func(p *DepositPolicy) { p.NoShowThreshold = -1 }, err: ErrInvalidDepositReliability},
+		{name: "unknown type", modify: func(p *DepositPolicy) { p.Type = "hourly" }, err: ErrInvalidDepositType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultDepositPolicy(uuid.New())
+			tt.modify(policy)
+			if err := policy.Validate(); err != tt.err {
+				t.Errorf("Validate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestServiceSetDeposit(t *testing.T) {
+	fixed := DepositTypeFixed
+	percentage := DepositTypePercentage
+	unknown := DepositType("hourly")
+
+	tests := []struct {
+		name        string
+		depositType *DepositType
+		amount      int64
+		percent     int
+		wantAmount  int64
+		wantPercent int
+		err         error
+	}{
+		{name: "fixed keeps only the amount", depositType: &fixed, amount: 100000, percent: 30, wantAmount: 100000},
+		{name: "percentage keeps only the percent", depositType: &percentage, amount: 100000, percent: 30, wantPercent: 30},
+		{name: "cleared", depositType: nil, amount: 100000},
+		{name: "fixed without amount", depositType: &fixed, err: ErrInvalidDepositAmount},
+		{name: "percentage out of range", depositType: &percentage, percent: 0, err: ErrInvalidDepositAmount},
+		{name: "unknown type", depositType: &unknown, amount: 100000, err: ErrInvalidDepositType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &Service{DepositType: &fixed, DepositAmount: 5000}
+			err := service.SetDeposit(tt.depositType, tt.amount, tt.percent)
+			if err != tt.err {
+				t.Fatalf("SetDeposit() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if service.DepositType != tt.depositType || service.DepositAmount != tt.wantAmount || service.DepositPercent != tt.wantPercent {
+				t.Errorf("SetDeposit() = %v %d %d%%, want %v %d %d%%", service.DepositType, service.DepositAmount, service.DepositPercent, tt.depositType, tt.wantAmount, tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestDepositTransitions(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	staff := uuid.New()
+
+	newDeposit := func(status DepositStatus) *Deposit {
+		deposit, err := NewDeposit(uuid.New(), uuid.New(), uuid.New(), uuid.New(), DepositReasonService, "MXN", 100000, now.Add(48*time.Hour), nil)
+		if err != nil {
+			t.Fatalf("NewDeposit() error = %v", err)
+		}
+		deposit.Status = status
+		return deposit
+	}
+
+	tests := []struct {
+		name   string
+		status DepositStatus
+		change func(*Deposit) error
+		want   DepositStatus
+		err    error
+	}{
+		{"pay pending", DepositStatusPending, func(d *Deposit) error { return d.MarkPaid("pay_1", now, now) }, DepositStatusPaid, nil},
+		{"pay after expiry", DepositStatusExpired, func(d *Deposit) error { return d.MarkPaid("pay_1", now, now) }, DepositStatusPaid, nil},
+		{"pay twice", DepositStatusPaid, func(d *Deposit) error { return d.MarkPaid("pay_2", now, now) }, DepositStatusPaid, ErrDepositNotPending},
+		{"waive pending", DepositStatusPending, func(d *Deposit) error { return d.Waive("Long-time patient", &staff, now) }, DepositStatusWaived, nil},
+		{"waive without reason", DepositStatusPending, func(d *Deposit) error { return d.Waive("  ", &staff, now) }, DepositStatusPending, ErrDepositWaiveReasonRequired},
+		{"waive without staff", DepositStatusPending, func(d *Deposit) error { return d.Waive("Long-time patient", nil, now) }, DepositStatusPending, ErrDepositWaiveRequiresStaff},
+		{"waive paid", DepositStatusPaid, func(d *Deposit) error { return d.Waive("Long-time patient", &staff, now) }, DepositStatusPaid, ErrDepositNotPending},
+		{"expire pending", DepositStatusPending, func(d *Deposit) error { return d.Expire(now) }, DepositStatusExpired, nil},
+		{"expire paid", DepositStatusPaid, func(d *Deposit) error { return d.Expire(now) }, DepositStatusPaid, ErrDepositNotPending},
+		{"cancel pending", DepositStatusPending, func(d *Deposit) error { return d.Cancel(now) }, DepositStatusCancelled, nil},
+		{"cancel waived", DepositStatusWaived, func(d *Deposit) error { return d.Cancel(now) }, DepositStatusWaived, ErrDepositNotPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deposit := newDeposit(tt.status)
+			if err := tt.change(deposit); err != tt.err {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if deposit.Status != tt.want {
+				t.Errorf("status = %s, want %s", deposit.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestDepositIsOverdue(t *testing.T) {
+	dueAt := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	deposit := &Deposit{Status: DepositStatusPending, DueAt: dueAt}
+
+	if deposit.IsOverdue(dueAt.Add(-time.Second)) {
+		t.Error("IsOverdue() before the due date = true, want false")
+	}
+	if !deposit.IsOverdue(dueAt) {
+		t.Error("IsOverdue() at the due date = false, want true")
+	}
+	deposit.Status = DepositStatusPaid
+	if deposit.IsOverdue(dueAt.Add(time.Hour)) {
+		t.Error("IsOverdue() of a paid deposit = true, want false")
+	}
+}
+
+func TestDepositIsActive(t *testing.T) {
+	tests := []struct {
+		status DepositStatus
+		want   bool
+	}{
+		{DepositStatusPending, true},
+		{DepositStatusPaid, true},
+		{DepositStatusWaived, false},
+		{DepositStatusExpired, false},
+		{DepositStatusCancelled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			deposit := &Deposit{Status: tt.status}
+			if got := deposit.IsActive(); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
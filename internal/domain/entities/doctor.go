@@ -16,6 +16,7 @@ type Doctor struct {
 	Specialty      *string    `json:"specialty,omitempty" db:"specialty"`
 	Email          *string    `json:"email,omitempty" db:"email"`
 	Phone          *string    `json:"phone,omitempty" db:"phone"`
+	PhoneE164      *string    `json:"phone_e164,omitempty" db:"phone_e164"` // Normalized form of Phone
 	DefaultUnitID  *uuid.UUID `json:"default_unit_id,omitempty" db:"default_unit_id"`
 	Color          string     `json:"color" db:"color"` // Hex color code (e.g., "#3B82F6")
 	IsActive       bool       `json:"is_active" db:"is_active"`
@@ -95,6 +96,16 @@ func (d *Doctor) HasUserAccount() bool {
 	return d.UserID != nil
 }
 
+// NormalizePhone validates the phone against the region and stores its E.164 form
+func (d *Doctor) NormalizePhone(region string) error {
+	phone, e164, err := normalizePhoneFields(d.Phone, region)
+	if err != nil {
+		return err
+	}
+	d.Phone, d.PhoneE164 = phone, e164
+	return nil
+}
+
 // IsValid checks if the doctor has valid data
 func (d *Doctor) IsValid() bool {
 	return d.Validate() == nil
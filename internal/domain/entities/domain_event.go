@@ -0,0 +1,166 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// DomainEventType identifies something that happened in the domain
+type DomainEventType string
+
+const (
+	EventAppointmentCreated     DomainEventType = "appointment.created"
+	EventAppointmentRescheduled DomainEventType = "appointment.rescheduled"
+	EventAppointmentCancelled   DomainEventType = "appointment.cancelled"
+	EventAppointmentCompleted   DomainEventType = "appointment.completed"
+	EventPatientCreated         DomainEventType = "patient.created"
+	EventPatientUpdated         DomainEventType = "patient.updated"
+	EventPatientMerged          DomainEventType = "patient.merged"
+	EventPatientArchived        DomainEventType = "patient.archived"
+	EventPatientRestored        DomainEventType = "patient.restored"
+	EventPatientDeleted         DomainEventType = "patient.deleted"
+)
+
+// Aggregate types that raise domain events
+const (
+	AggregateAppointment = "appointment"
+	AggregatePatient     = "patient"
+)
+
+// DomainEvent is a fact raised by a use case and recorded in the outbox in the
+// same transaction as the change that produced it
+type DomainEvent struct {
+	ID             uuid.UUID       `json:"id" db:"id"` // Idempotency key for subscribers
+	OrganizationID uuid.UUID       `json:"organization_id" db:"organization_id"`
+	Type           DomainEventType `json:"type" db:"event_type"`
+	AggregateType  string          `json:"aggregate_type" db:"aggregate_type"`
+	AggregateID    uuid.UUID       `json:"aggregate_id" db:"aggregate_id"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	OccurredAt     time.Time       `json:"occurred_at" db:"occurred_at"`
+}
+
+// NewDomainEvent creates a new domain event with data encoded as its payload
+func NewDomainEvent(organizationID uuid.UUID, eventType DomainEventType, aggregateType string, aggregateID uuid.UUID, data interface{}) (*DomainEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event payload: %w", eventType, err)
+	}
+
+	return &DomainEvent{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Type:           eventType,
+		AggregateType:  aggregateType,
+		AggregateID:    aggregateID,
+		Payload:        payload,
+		OccurredAt:     time.Now().UTC(),
+	}, nil
+}
+
+// DecodePayload decodes the event payload into v
+func (e *DomainEvent) DecodePayload(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// OutboxStatus represents the dispatch state of an outbox event
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusProcessed OutboxStatus = "processed"
+	OutboxStatusFailed    OutboxStatus = "failed"
+)
+
+// OutboxMaxAttempts is the number of dispatch attempts before an event is given up
+const OutboxMaxAttempts = 10
+
+const (
+	outboxInitialBackoff = 10 * time.Second
+	outboxMaxBackoff     = time.Hour
+)
+
+// OutboxEvent is a domain event awaiting dispatch to in-process subscribers.
+// HandledBy records the subscribers that already succeeded so a retry only
+// re-runs the ones that failed.
+type OutboxEvent struct {
+	DomainEvent
+	Status        OutboxStatus   `json:"status" db:"status"`
+	Attempts      int            `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time      `json:"next_attempt_at" db:"next_attempt_at"`
+	HandledBy     pq.StringArray `json:"handled_by" db:"handled_by"`
+	LastError     *string        `json:"last_error,omitempty" db:"last_error"`
+	ProcessedAt   *time.Time     `json:"processed_at,omitempty" db:"processed_at"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// NewOutboxEvent wraps a domain event in a pending outbox record that is due immediately
+func NewOutboxEvent(event *DomainEvent) *OutboxEvent {
+	now := time.Now()
+	return &OutboxEvent{
+		DomainEvent:   *event,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		HandledBy:     pq.StringArray{},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// IsHandledBy checks if the subscriber already processed the event
+func (e *OutboxEvent) IsHandledBy(subscriber string) bool {
+	for _, name := range e.HandledBy {
+		if name == subscriber {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkHandledBy records that the subscriber processed the event
+func (e *OutboxEvent) MarkHandledBy(subscriber string) {
+	if !e.IsHandledBy(subscriber) {
+		e.HandledBy = append(e.HandledBy, subscriber)
+	}
+}
+
+// MarkProcessed records that every subscriber processed the event
+func (e *OutboxEvent) MarkProcessed(now time.Time) {
+	e.Attempts++
+	e.Status = OutboxStatusProcessed
+	e.LastError = nil
+	e.ProcessedAt = &now
+	e.UpdatedAt = now
+}
+
+// MarkAttemptFailed records a failed dispatch and schedules the next one with
+// exponential backoff, giving up after OutboxMaxAttempts
+func (e *OutboxEvent) MarkAttemptFailed(now time.Time, reason string) {
+	e.Attempts++
+	e.LastError = &reason
+	e.UpdatedAt = now
+
+	if e.Attempts >= OutboxMaxAttempts {
+		e.Status = OutboxStatusFailed
+		return
+	}
+
+	e.Status = OutboxStatusPending
+	e.NextAttemptAt = now.Add(OutboxBackoff(e.Attempts))
+}
+
+// OutboxBackoff returns the wait after the given number of failed dispatch attempts
+func OutboxBackoff(attempts int) time.Duration {
+	backoff := outboxInitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Equipment represents a movable piece of equipment of a clinic that appointments can book,
+// such as an intraoral scanner or a surgical motor shared between units
+type Equipment struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	ClinicID    uuid.UUID `json:"clinic_id" db:"clinic_id"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	IsActive    bool      `json:"is_active" db:"is_active"` // Inactive equipment cannot be booked; past bookings are kept
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewEquipment creates an active piece of equipment for a clinic
+func NewEquipment(clinicID uuid.UUID, name string, description *string) (*Equipment, error) {
+	now := time.Now()
+	equipment := &Equipment{
+		ID:          uuid.New(),
+		ClinicID:    clinicID,
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := equipment.Validate(); err != nil {
+		return nil, err
+	}
+	return equipment, nil
+}
+
+// Validate checks if the equipment entity is valid
+func (e *Equipment) Validate() error {
+	if e.Name == "" {
+		return ErrInvalidEquipmentName
+	}
+	if e.ClinicID == uuid.Nil {
+		return ErrInvalidClinicID
+	}
+	return nil
+}
@@ -9,10 +9,30 @@ var (
 	ErrOrganizationNotFound    = errors.New("organization not found")
 	ErrInvalidOrganizationID   = errors.New("organization ID is required")
 
+	// Phone number errors
+	ErrInvalidPhoneNumber     = errors.New("invalid phone number")
+	ErrUnsupportedPhoneRegion = errors.New("unsupported phone region")
+
+	// Currency errors
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+
 	// Profile errors
 	ErrInvalidProfileID = errors.New("profile ID is required")
 	ErrInvalidRoles     = errors.New("at least one role is required")
 	ErrProfileNotFound  = errors.New("profile not found")
+	ErrInvalidStaffRole = errors.New("invalid staff role")
+	ErrProfileInactive  = errors.New("profile is deactivated")
+
+	// Staff management errors
+	ErrInvitationNotFound           = errors.New("invitation not found")
+	ErrInvitationExpired            = errors.New("invitation has expired")
+	ErrInvitationNotPending         = errors.New("invitation is no longer pending")
+	ErrInvalidInvitationToken       = errors.New("invalid invitation token")
+	ErrInvitationEmailMismatch      = errors.New("invitation was sent to a different email")
+	ErrUserAlreadyMember            = errors.New("user is already a member of the organization")
+	ErrProfileInAnotherOrganization = errors.New("profile already belongs to another organization")
+	ErrCannotModifyOwnAccess        = errors.New("cannot remove your own admin access")
+	ErrDoctorAlreadyLinked          = errors.New("doctor is already linked to another user")
 
 	// Clinic errors
 	ErrInvalidClinicName = errors.New("clinic name is required")
@@ -31,8 +51,242 @@ var (
 	ErrDoctorUnitOrganizationMismatch = errors.New("doctor's default unit must belong to the same organization")
 
 	// Patient errors
-	ErrInvalidPatientName = errors.New("patient name is required")
-	ErrPatientNotFound    = errors.New("patient not found")
+	ErrInvalidPatientName         = errors.New("patient name is required")
+	ErrPatientNotFound            = errors.New("patient not found")
+	ErrPatientVersionConflict     = errors.New("patient was modified by another request")
+	ErrInvalidPatientSearchCursor = errors.New("invalid patient search cursor")
+	ErrInvalidPatientListDate     = errors.New("patient list dates must use the YYYY-MM-DD format")
+	ErrInvalidPatientListSort     = errors.New("sort must be name, created_at, last_visit or next_appointment and order asc or desc")
+	ErrInvalidPatientListStatus   = errors.New("status must be active or archived")
+
+	// Patient relationship errors
+	ErrInvalidPatientRelationshipType = errors.New("relationship type must be guardian, dependent or spouse")
+	ErrPatientRelatedToItself         = errors.New("a patient cannot be related to itself")
+	ErrPatientRelationshipExists      = errors.New("the patients are already related")
+	ErrPatientRelationshipNotFound    = errors.New("patient relationship not found")
+	ErrContactPersonNotGuardian       = errors.New("the contact person of a patient must be one of its guardians")
+
+	// Patient merge errors
+	ErrCannotMergePatientIntoItself = errors.New("a patient cannot be merged into itself")
+	ErrPatientMergeNotFound         = errors.New("patient merge not found")
+	ErrMergedPatientShared          = errors.New("the duplicate patient is also linked to another organization and cannot be merged")
+
+	// Dental chart errors
+	ErrInvalidToothNumber          = errors.New("tooth does not exist in the adult or primary dentition")
+	ErrUnsupportedToothNumbering   = errors.New("numbering must be fdi or universal")
+	ErrInvalidToothSurface         = errors.New("surface does not exist on the tooth")
+	ErrInvalidToothCondition       = errors.New("invalid tooth condition")
+	ErrToothConditionNotOnSurfaces = errors.New("crown, missing and implant apply to the whole tooth")
+	ErrImplantOnPrimaryTooth       = errors.New("implants cannot be charted on primary teeth")
+	ErrDentalChartEntryInFuture    = errors.New("charting entries cannot be recorded in the future")
+	ErrDentalChartEntriesRequired  = errors.New("at least one charting entry is required")
+	ErrInvalidDentalChartDate      = errors.New("as_of must be a YYYY-MM-DD date or an RFC 3339 timestamp")
+	ErrChartAppointmentMismatch    = errors.New("appointment does not belong to the patient")
+
+	// Clinical note errors
+	ErrClinicalNoteNotFound             = errors.New("clinical note not found")
+	ErrClinicalNoteExists               = errors.New("the appointment already has a clinical note")
+	ErrClinicalNoteTemplateNotFound     = errors.New("clinical note template not found")
+	ErrServiceNotFound                  = errors.New("service not found")
+	ErrClinicalNoteTemplateNameRequired = errors.New("clinical note template name is required")
+	ErrClinicalNoteTemplateExists       = errors.New("a clinical note template already exists for this service")
+	ErrClinicalNoteSigned               = errors.New("clinical note is signed and can only be amended")
+	ErrClinicalNoteNotSigned            = errors.New("only signed clinical notes can be amended")
+	ErrClinicalNoteNotAuthor            = errors.New("only the author can edit or sign a draft clinical note")
+	ErrClinicalNoteEmpty                = errors.New("an empty clinical note cannot be signed")
+	ErrClinicalNoteAmendmentEmpty       = errors.New("amendment content is required")
+	ErrNotTreatingDoctor                = errors.New("clinical notes are written by the appointment's treating doctor")
+	ErrUserNotLinkedToDoctor            = errors.New("your account is not linked to a doctor of the organization")
+
+	// Treatment plan errors
+	ErrTreatmentPlanNotFound           = errors.New("treatment plan not found")
+	ErrTreatmentPlanItemNotFound       = errors.New("treatment plan item not found")
+	ErrTreatmentPlanTitleRequired      = errors.New("treatment plan title is required")
+	ErrTreatmentPlanItemsRequired      = errors.New("treatment plan has no procedures")
+	ErrTreatmentPlanServiceRequired    = errors.New("treatment plan procedures require a service")
+	ErrInvalidTreatmentPlanPhase       = errors.New("treatment plan phase must be 1 or greater")
+	ErrInvalidEstimatedCost            = errors.New("estimated cost cannot be negative")
+	ErrTreatmentPlanNotEditable        = errors.New("procedures can only change before the patient's decision")
+	ErrTreatmentPlanAlreadyDecided     = errors.New("the patient's decision on this treatment plan was already recorded")
+	ErrTreatmentPlanNotCancellable     = errors.New("completed or cancelled treatment plans cannot be cancelled")
+	ErrTreatmentPlanHasScheduledItems  = errors.New("treatment plan has procedures booked in appointments")
+	ErrTreatmentPlanItemNotSchedulable = errors.New("only accepted procedures that are not booked yet can be scheduled")
+	ErrTreatmentPlanPatientMismatch    = errors.New("treatment plan belongs to another patient")
+
+	// Attachment errors
+	ErrAttachmentNotFound            = errors.New("attachment not found")
+	ErrInvalidAttachmentType         = errors.New("invalid attachment type")
+	ErrAttachmentFileRequired        = errors.New("a file is required")
+	ErrAttachmentFileNameRequired    = errors.New("attachment file name is required")
+	ErrAttachmentEmpty               = errors.New("attached file is empty")
+	ErrAttachmentTooLarge            = errors.New("attached file exceeds the maximum upload size")
+	ErrUnsupportedAttachmentType     = errors.New("file type is not supported; upload images, PDFs or DICOM files")
+	ErrAttachmentTakenInFuture       = errors.New("attachment capture date cannot be in the future")
+	ErrInvalidAttachmentDate         = errors.New("taken_at must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+	ErrAttachmentAppointmentMismatch = errors.New("appointment belongs to another patient")
+	ErrAttachmentNoThumbnail         = errors.New("attachment has no thumbnail")
+	ErrThumbnailUnsupported          = errors.New("thumbnail cannot be generated for this image")
+	ErrBlobNotFound                  = errors.New("stored file not found")
+
+	// Consent form errors
+	ErrConsentTemplateNotFound     = errors.New("consent template not found")
+	ErrConsentTemplateNameRequired = errors.New("consent template name is required")
+	ErrConsentTemplateBodyRequired = errors.New("consent template body is required")
+	ErrUnknownConsentMergeField    = errors.New("consent template uses an unknown merge field")
+	ErrConsentFormNotFound         = errors.New("consent form not found")
+	ErrConsentFormAlreadySigned    = errors.New("consent form is already signed")
+	ErrConsentFormRevoked          = errors.New("consent form has been revoked")
+	ErrConsentFormExpired          = errors.New("consent signing link has expired")
+	ErrConsentFormNotSigned        = errors.New("consent form has not been signed")
+	ErrInvalidConsentFormStatus    = errors.New("invalid consent form status")
+	ErrInvalidConsentToken         = errors.New("invalid or expired consent signing link")
+	ErrConsentAgreementRequired    = errors.New("the patient must agree to the consent to sign it")
+	ErrConsentAppointmentMismatch  = errors.New("appointment belongs to another patient")
+	ErrInvalidSignatureType        = errors.New("signature type must be typed or drawn")
+	ErrSignerNameRequired          = errors.New("signer name is required")
+	ErrSignerNameTooLong           = errors.New("signer name must be at most 255 characters")
+	ErrSignatureRequired           = errors.New("signature is required")
+	ErrInvalidDrawnSignature       = errors.New("drawn signature must be a PNG data URL")
+	ErrSignatureTooLarge           = errors.New("drawn signature is too large")
+
+	// Medical alert errors
+	ErrMedicalAlertNotFound        = errors.New("medical alert not found")
+	ErrInvalidMedicalAlertCategory = errors.New("category must be allergy, medication, condition, pregnancy or other")
+	ErrInvalidMedicalAlertSeverity = errors.New("severity must be low, moderate or high")
+	ErrMedicalAlertTitleRequired   = errors.New("medical alert title is required")
+	ErrMedicalAlertTitleTooLong    = errors.New("medical alert title must be at most 255 characters")
+	ErrInvalidMedicalAlertDate     = errors.New("review_date must use the YYYY-MM-DD format")
+	ErrMedicalAlertResolved        = errors.New("medical alert is resolved")
+	ErrMedicalAlertActive          = errors.New("medical alert is already active")
+	ErrMedicalAlertNoChanges       = errors.New("no changes to the medical alert were provided")
+
+	// Invoice errors
+	ErrInvoiceNotFound                = errors.New("invoice not found")
+	ErrInvoiceLinesRequired           = errors.New("an invoice needs at least one line")
+	ErrInvoiceLineDescriptionRequired = errors.New("invoice line description is required")
+	ErrInvalidInvoiceQuantity         = errors.New("invoice line quantity must be at least 1")
+	ErrInvalidInvoiceAmount           = errors.New("invoice amounts must not be negative")
+	ErrInvalidInvoiceDiscount         = errors.New("discount must not exceed the line amount")
+	ErrInvalidTaxRate                 = errors.New("tax rate must be between 0 and 10000 basis points")
+	ErrInvoiceVoided                  = errors.New("invoice is void")
+	ErrInvoiceHasPayments             = errors.New("invoice has payments; refund them before voiding it")
+	ErrAppointmentNotCompleted        = errors.New("only completed appointments can be invoiced")
+	ErrAppointmentAlreadyInvoiced     = errors.New("appointment already has an invoice")
+	ErrInvoiceAppointmentNoService    = errors.New("appointment has no service to invoice; provide the lines")
+	ErrInvoiceLinePriceRequired       = errors.New("invoice line needs a unit price or a service with a base price")
+	ErrInvalidPaymentMethod           = errors.New("payment method must be cash, card or transfer")
+	ErrInvalidPaymentAmount           = errors.New("payment amount must be greater than zero")
+	ErrPaymentExceedsBalance          = errors.New("payment exceeds the balance due")
+	ErrPaymentNotFound                = errors.New("payment not found")
+	ErrRefundExceedsPayment           = errors.New("refund exceeds the refundable amount of the payment")
+	ErrInvalidBillingDate             = errors.New("dates must use the YYYY-MM-DD format")
+	ErrInvalidInvoiceStatus           = errors.New("invalid invoice status")
+
+	// Fiscal data and CFDI errors
+	ErrInvalidRFC                    = errors.New("invalid RFC")
+	ErrGenericRFC                    = errors.New("generic RFCs are reserved for global invoices")
+	ErrFiscalNameRequired            = errors.New("legal name is required")
+	ErrInvalidFiscalPostalCode       = errors.New("fiscal postal code must have 5 digits")
+	ErrInvalidTaxRegime              = errors.New("tax regime is not valid for the RFC")
+	ErrInvalidCFDIUse                = errors.New("invalid CFDI use")
+	ErrCFDIUseNotAllowed             = errors.New("CFDI use is not allowed for the tax regime")
+	ErrInvalidCFDIProductCode        = errors.New("product code must have 8 digits")
+	ErrPatientFiscalDataNotFound     = errors.New("patient has no fiscal data")
+	ErrIssuerFiscalDataNotFound      = errors.New("organization has no issuer fiscal data")
+	ErrCFDICurrencyNotSupported      = errors.New("CFDI can only be issued for invoices in MXN")
+	ErrInvoiceAlreadyStamped         = errors.New("invoice already has a CFDI")
+	ErrInvoiceHasActiveCFDI          = errors.New("invoice has a CFDI; cancel it before voiding the invoice")
+	ErrCFDINotFound                  = errors.New("CFDI not found")
+	ErrCFDICancelled                 = errors.New("CFDI is cancelled")
+	ErrInvalidCFDICancellationReason = errors.New("cancellation reason must be 01, 02, 03 or 04")
+	ErrInvalidReplacementUUID        = errors.New("a replacement UUID is required for reason 01 and only for it")
+
+	// Insurance errors
+	ErrInsuranceCarrierNotFound     = errors.New("insurance carrier not found")
+	ErrInsuranceCarrierNameRequired = errors.New("insurance carrier name is required")
+	ErrInsuranceCarrierInactive     = errors.New("insurance carrier is inactive")
+	ErrInsurancePolicyNotFound      = errors.New("insurance policy not found")
+	ErrInsuranceMemberIDRequired    = errors.New("member ID is required")
+	ErrInvalidServiceCategory       = errors.New("service category must be preventive, basic, major, orthodontic or cosmetic")
+	ErrInvalidCoveragePercent       = errors.New("coverage must be between 0 and 100 percent")
+	ErrInvalidInsuranceAmount       = errors.New("insurance amounts must not be negative nor exceed the billed amount")
+	ErrInvalidPolicyPeriod          = errors.New("effective_to must not be before effective_from")
+	ErrInvalidInsuranceDate         = errors.New("insurance dates must use the YYYY-MM-DD format")
+	ErrPolicyNotInEffect            = errors.New("insurance policy is not in effect on the service date")
+	ErrPolicyHasClaims              = errors.New("insurance policy has claims; end it with effective_to instead")
+	ErrInsuranceClaimNotFound       = errors.New("insurance claim not found")
+	ErrInvalidClaimTransition       = errors.New("insurance claim cannot change to that status")
+	ErrInvalidInsuranceClaimStatus  = errors.New("invalid insurance claim status")
+	ErrClaimRejectionReasonRequired = errors.New("a rejection reason is required")
+	ErrAppointmentAlreadyClaimed    = errors.New("appointment already has an open claim under this policy")
+	ErrAppointmentNotClaimable      = errors.New("only completed appointments can be claimed")
+	ErrClaimPatientMismatch         = errors.New("appointment is not of the policy's patient")
+	ErrClaimAmountRequired          = errors.New("appointment has no invoice; provide the billed amount")
+	ErrEstimateItemsRequired        = errors.New("provide a service, items or a treatment plan to estimate")
+
+	// Cancellation policy errors
+	ErrCancellationReasonNotFound       = errors.New("cancellation reason not found")
+	ErrCancellationReasonLabelRequired  = errors.New("cancellation reason label is required")
+	ErrCancellationReasonExists         = errors.New("a cancellation reason with this label already exists")
+	ErrCancellationReasonInactive       = errors.New("cancellation reason is inactive")
+	ErrInvalidCancellationCategory      = errors.New("cancellation category must be patient_request, illness, emergency, weather, clinic or other")
+	ErrInvalidCancellationNotice        = errors.New("notice window must be between 1 and 720 hours")
+	ErrInvalidCancellationFeeType       = errors.New("fee type must be fixed or percentage")
+	ErrInvalidCancellationFee           = errors.New("fee must be a positive amount or a percentage between 1 and 100")
+	ErrCancellationFeeNotFound          = errors.New("cancellation fee not found")
+	ErrCancellationFeeNotPending        = errors.New("cancellation fee is no longer pending")
+	ErrInvalidCancellationFeeStatus     = errors.New("invalid cancellation fee status")
+	ErrInvalidFeeOverride               = errors.New("override amount must be between 0 and the fee the policy charges")
+	ErrFeeOverrideJustificationRequired = errors.New("a justification is required to override a cancellation fee")
+	ErrFeeOverrideRequiresStaff         = errors.New("cancellation fees can only be overridden by a staff member")
+
+	// Deposit errors
+	ErrDepositNotFound             = errors.New("deposit not found")
+	ErrInvalidDepositType          = errors.New("deposit type must be fixed or percentage")
+	ErrInvalidDepositAmount        = errors.New("deposit amount must be positive and percentages between 1 and 100")
+	ErrInvalidDepositHold          = errors.New("deposit hold must be between 1 and 720 hours and the cutoff between 0 and 720 hours")
+	ErrInvalidDepositReliability   = errors.New("no-show threshold and lookback days must not be negative")
+	ErrInvalidDepositStatus        = errors.New("invalid deposit status")
+	ErrInvalidDepositDueDate       = errors.New("deposit due date must be in the future and before the appointment starts")
+	ErrDepositNotPending           = errors.New("deposit is not pending")
+	ErrDepositPending              = errors.New("appointment cannot be confirmed until its deposit is paid")
+	ErrDepositAlreadyRequested     = errors.New("appointment already has a pending or paid deposit")
+	ErrDepositNotAllowed           = errors.New("deposits can only be requested for scheduled appointments with a patient")
+	ErrDepositWaiveReasonRequired  = errors.New("a reason is required to waive a deposit")
+	ErrDepositWaiveRequiresStaff   = errors.New("deposits can only be waived by a staff member")
+	ErrInvalidPaymentNotification  = errors.New("payment notification could not be verified")
+	ErrPaymentNotificationMismatch = errors.New("payment notification does not match the deposit")
+
+	// Recall errors
+	ErrRecallNotFound               = errors.New("recall not found")
+	ErrRecallRuleNotFound           = errors.New("recall rule not found")
+	ErrRecallRuleExists             = errors.New("the service already has a recall rule")
+	ErrRecallServiceRequired        = errors.New("recall rule service is required")
+	ErrInvalidRecallInterval        = errors.New("recall interval must be between 1 and 60 months")
+	ErrInvalidRecallStatus          = errors.New("recall status must be open, booked or dismissed")
+	ErrInvalidRecallOutreachStatus  = errors.New("outreach status must be not_contacted, contacted, left_message, no_answer or declined")
+	ErrInvalidRecallOutreachChannel = errors.New("outreach channel must be phone, sms, email, whatsapp or in_person")
+	ErrInvalidRecallOutreachOutcome = errors.New("outreach outcome must be contacted, left_message, no_answer or declined")
+	ErrInvalidRecallOutreachTime    = errors.New("outreach cannot be recorded in the future")
+	ErrInvalidRecallDueWindow       = errors.New("due dates must be YYYY-MM-DD and due_from must not be after due_to")
+	ErrRecallNotOpen                = errors.New("recall is no longer open")
+	ErrRecallNotBooked              = errors.New("recall was not closed by a booking")
+	ErrRecallDismissReasonRequired  = errors.New("a reason is required to dismiss a recall")
+
+	// Appointment resource errors
+	ErrEquipmentNotFound            = errors.New("equipment not found")
+	ErrInvalidEquipmentName         = errors.New("equipment name is required")
+	ErrInvalidResourceType          = errors.New("resource type must be staff, unit or equipment")
+	ErrInvalidAppointmentRole       = errors.New("appointment role must be doctor, assistant or hygienist")
+	ErrAppointmentRoleRequired      = errors.New("a role is required to book a staff member")
+	ErrResourceRoleNotAllowed       = errors.New("only staff members are booked with a role")
+	ErrDuplicateAppointmentResource = errors.New("a resource is booked more than once in the appointment")
+	ErrAppointmentResourceNotFound  = errors.New("appointment resource not found")
+	ErrAppointmentResourceInactive  = errors.New("appointment resource is inactive")
+	ErrResourceClinicMismatch       = errors.New("units and equipment must belong to the appointment's clinic")
+	ErrAppointmentResourceConflict  = errors.New("a resource of the appointment is already booked at the requested time")
+	ErrStaffNotAvailable            = errors.New("a staff member of the appointment is not available at the requested time")
+	ErrInvalidCalendarRange         = errors.New("calendar dates must be YYYY-MM-DD, in order and at most 31 days apart")
 
 	// Appointment errors
 	ErrInvalidPatientID           = errors.New("patient ID is required")
@@ -41,6 +295,7 @@ var (
 	ErrInvalidAppointmentTime     = errors.New("invalid appointment time")
 	ErrEndTimeBeforeStartTime     = errors.New("end time must be after start time")
 	ErrAppointmentNotFound        = errors.New("appointment not found")
+	ErrAppointmentVersionConflict = errors.New("appointment was modified by another request")
 	ErrAppointmentConflict        = errors.New("appointment conflicts with existing appointment")
 	ErrPastAppointmentTime        = errors.New("appointment time cannot be in the past")
 	ErrInvalidAppointmentStatus   = errors.New("invalid appointment status")
@@ -49,6 +304,27 @@ var (
 	ErrCancellationReasonRequired = errors.New("cancellation reason is required")
 	ErrUnauthorizedAccess         = errors.New("unauthorized access to resource")
 
+	// API key errors
+	ErrAPIKeyNotFound      = errors.New("API key not found")
+	ErrInvalidAPIKey       = errors.New("invalid API key")
+	ErrAPIKeyRevoked       = errors.New("API key has been revoked")
+	ErrAPIKeyExpired       = errors.New("API key has expired")
+	ErrInvalidAPIKeyName   = errors.New("API key name is required")
+	ErrInvalidAPIKeyScope  = errors.New("invalid API key scope")
+	ErrInvalidAPIKeyExpiry = errors.New("API key expiry must be in the future")
+
+	// Webhook errors
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL           = errors.New("webhook URL must be an absolute http(s) URL")
+	ErrInvalidWebhookEventType     = errors.New("invalid webhook event type")
+
+	// Outbox errors
+	ErrOutboxEventNotFound = errors.New("outbox event not found")
+
+	// Idempotency errors
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
 	// Doctor Availability errors
 	ErrInvalidAvailabilityTime = errors.New("invalid availability time")
 	ErrAvailabilityNotFound    = errors.New("availability not found")
@@ -0,0 +1,208 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mexican fiscal data used to issue CFDI electronic invoices. Codes follow the SAT catalogs
+// (c_RegimenFiscal, c_UsoCFDI) of CFDI 4.0.
+
+var (
+	rfcPattern         = regexp.MustCompile(`^([A-ZÑ&]{3,4})(\d{6})([A-Z\d]{2}[A\d])$`)
+	postalCodePattern  = regexp.MustCompile(`^\d{5}$`)
+	productCodePattern = regexp.MustCompile(`^\d{8}$`)
+)
+
+// Generic RFCs of the SAT, reserved for global invoices to the general public and to foreigners
+const (
+	GenericRFCPublic  = "XAXX010101000"
+	GenericRFCForeign = "XEXX010101000"
+)
+
+// Regimes (c_RegimenFiscal) by taxpayer type
+var (
+	individualTaxRegimes = map[string]bool{
+		"605": true, // Sueldos y salarios
+		"606": true, // Arrendamiento
+		"607": true, // Enajenación o adquisición de bienes
+		"608": true, // Demás ingresos
+		"610": true, // Residentes en el extranjero
+		"611": true, // Dividendos
+		"612": true, // Actividades empresariales y profesionales
+		"614": true, // Intereses
+		"615": true, // Obtención de premios
+		"616": true, // Sin obligaciones fiscales
+		"621": true, // Incorporación fiscal
+		"625": true, // Plataformas tecnológicas
+		"626": true, // Simplificado de confianza
+	}
+	companyTaxRegimes = map[string]bool{
+		"601": true, // General de ley personas morales
+		"603": true, // Personas morales con fines no lucrativos
+		"610": true, // Residentes en el extranjero
+		"620": true, // Sociedades cooperativas de producción
+		"622": true, // Actividades agrícolas, ganaderas, silvícolas y pesqueras
+		"623": true, // Opcional para grupos de sociedades
+		"624": true, // Coordinados
+		"626": true, // Simplificado de confianza
+	}
+)
+
+// CFDI uses (c_UsoCFDI) a patient may request on an invoice for services
+var cfdiUses = map[string]bool{
+	"G01": true, "G02": true, "G03": true,
+	"I01": true, "I02": true, "I03": true, "I04": true, "I05": true, "I06": true, "I07": true, "I08": true,
+	"D01": true, "D02": true, "D03": true, "D04": true, "D05": true, "D06": true, "D07": true, "D08": true, "D09": true, "D10": true,
+	"S01": true,
+}
+
+// CFDIUseMedicalExpenses is the personal deduction of medical, dental and hospital expenses
+const CFDIUseMedicalExpenses = "D01"
+
+// DefaultCFDIProductCode is the c_ClaveProdServ code billed for services without their own
+const DefaultCFDIProductCode = "85121600"
+
+// FiscalIdentity identifies a taxpayer as registered with the SAT
+type FiscalIdentity struct {
+	RFC        string `json:"rfc" db:"rfc"`
+	LegalName  string `json:"legal_name" db:"legal_name"`   // As on the constancia de situación fiscal, without the company type
+	TaxRegime  string `json:"tax_regime" db:"tax_regime"`   // c_RegimenFiscal code, e.g. 612
+	PostalCode string `json:"postal_code" db:"postal_code"` // Of the fiscal address
+}
+
+// Normalize upper-cases and trims the identity fields as the SAT registers them
+func (f *FiscalIdentity) Normalize() {
+	f.RFC = strings.ToUpper(strings.TrimSpace(f.RFC))
+	f.LegalName = strings.Join(strings.Fields(strings.ToUpper(f.LegalName)), " ")
+	f.TaxRegime = strings.TrimSpace(f.TaxRegime)
+	f.PostalCode = strings.TrimSpace(f.PostalCode)
+}
+
+// Validate checks the format of the identity fields and that the regime fits the taxpayer type
+func (f *FiscalIdentity) Validate() error {
+	if !IsValidRFC(f.RFC) {
+		return ErrInvalidRFC
+	}
+	if f.RFC == GenericRFCPublic || f.RFC == GenericRFCForeign {
+		return ErrGenericRFC
+	}
+	if f.LegalName == "" {
+		return ErrFiscalNameRequired
+	}
+	if !postalCodePattern.MatchString(f.PostalCode) {
+		return ErrInvalidFiscalPostalCode
+	}
+
+	regimes := individualTaxRegimes
+	if f.IsCompany() {
+		regimes = companyTaxRegimes
+	}
+	if !regimes[f.TaxRegime] {
+		return ErrInvalidTaxRegime
+	}
+	return nil
+}
+
+// IsCompany reports whether the RFC belongs to a legal entity (persona moral)
+func (f *FiscalIdentity) IsCompany() bool {
+	return len([]rune(f.RFC)) == 12
+}
+
+// IsValidRFC checks the RFC format: 3 (companies) or 4 (individuals) letters, a YYMMDD date and
+// a 3-character homoclave
+func IsValidRFC(rfc string) bool {
+	match := rfcPattern.FindStringSubmatch(rfc)
+	if match == nil {
+		return false
+	}
+	_, err := time.Parse("060102", match[2])
+	return err == nil
+}
+
+// PatientFiscalProfile is the fiscal data a patient's invoices are issued to
+type PatientFiscalProfile struct {
+	OrganizationID uuid.UUID `json:"organization_id" db:"organization_id"`
+	PatientID      uuid.UUID `json:"patient_id" db:"patient_id"`
+	FiscalIdentity
+	CFDIUse   string    `json:"cfdi_use" db:"cfdi_use"` // c_UsoCFDI code, e.g. D01
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewPatientFiscalProfile creates a patient's fiscal data
+func NewPatientFiscalProfile(organizationID, patientID uuid.UUID, identity FiscalIdentity, cfdiUse string) (*PatientFiscalProfile, error) {
+	now := time.Now()
+	profile := &PatientFiscalProfile{
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		FiscalIdentity: identity,
+		CFDIUse:        strings.ToUpper(strings.TrimSpace(cfdiUse)),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	profile.Normalize()
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Validate checks the identity and that the CFDI use is allowed for the patient's regime
+func (p *PatientFiscalProfile) Validate() error {
+	if err := p.FiscalIdentity.Validate(); err != nil {
+		return err
+	}
+	if !cfdiUses[p.CFDIUse] {
+		return ErrInvalidCFDIUse
+	}
+
+	personalDeduction := strings.HasPrefix(p.CFDIUse, "D")
+	switch {
+	case personalDeduction && p.IsCompany():
+		return ErrCFDIUseNotAllowed // Personal deductions are for individuals
+	case p.TaxRegime == "616" && p.CFDIUse != "S01":
+		return ErrCFDIUseNotAllowed // Without fiscal obligations nothing is deductible
+	case p.TaxRegime == "605" && !personalDeduction && p.CFDIUse != "S01":
+		return ErrCFDIUseNotAllowed // Salaried employees only deduct personal expenses
+	}
+	return nil
+}
+
+// IssuerFiscalProfile is the fiscal data an organization issues its CFDI with
+type IssuerFiscalProfile struct {
+	OrganizationID uuid.UUID `json:"organization_id" db:"organization_id"`
+	FiscalIdentity
+	ProductCode string    `json:"product_code" db:"product_code"` // c_ClaveProdServ code billed for dental services
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewIssuerFiscalProfile creates an organization's issuer data; the postal code is the
+// place of issue (lugar de expedición) of its CFDI
+func NewIssuerFiscalProfile(organizationID uuid.UUID, identity FiscalIdentity, productCode string) (*IssuerFiscalProfile, error) {
+	now := time.Now()
+	profile := &IssuerFiscalProfile{
+		OrganizationID: organizationID,
+		FiscalIdentity: identity,
+		ProductCode:    strings.TrimSpace(productCode),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	profile.Normalize()
+	if profile.ProductCode == "" {
+		profile.ProductCode = DefaultCFDIProductCode
+	}
+
+	if err := profile.FiscalIdentity.Validate(); err != nil {
+		return nil, err
+	}
+	if !productCodePattern.MatchString(profile.ProductCode) {
+		return nil, ErrInvalidCFDIProductCode
+	}
+	return profile, nil
+}
@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestIsValidRFC(t *testing.T) {
+	tests := []struct {
+		rfc   string
+		valid bool
+	}{
+		{rfc: "GODE561231GR8", valid: true},
+		{rfc: "ÑAND800101AB1", valid: true},
+		{rfc: "ABC010203AB1", valid: true},
+		{rfc: "GODE561331GR8", valid: false}, // Month 13
+		{rfc: "GODE560230GR8", valid: false}, // February 30
+		{rfc: "GODE561231GR", valid: false},
+		{rfc: "GO1E561231GR8", valid: false},
+		{rfc: "gode561231gr8", valid: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidRFC(tt.rfc); got != tt.valid {
+			t.Errorf("IsValidRFC(%q) = %v, want %v", tt.rfc, got, tt.valid)
+		}
+	}
+}
+
+func TestNewPatientFiscalProfile(t *testing.T) {
+	individual := FiscalIdentity{RFC: " gode561231gr8 ", LegalName: "  Ernesto  Gómez  Díaz ", TaxRegime: "612", PostalCode: "06600"}
+	company := FiscalIdentity{RFC: "ABC010203AB1", LegalName: "Aseguradora", TaxRegime: "601", PostalCode: "06600"}
+
+	with := func(identity FiscalIdentity, change func(*FiscalIdentity)) FiscalIdentity {
+		change(&identity)
+		return identity
+	}
+
+	tests := []struct {
+		name     string
+		identity FiscalIdentity
+		cfdiUse  string
+		err      error
+	}{
+		{name: "medical expenses", identity: individual, cfdiUse: "d01"},
+		{name: "company general expenses", identity: company, cfdiUse: "G03"},
+		{name: "generic RFC", identity: with(individual, func(f *FiscalIdentity) { f.RFC = GenericRFCPublic }), cfdiUse: "S01", err: ErrGenericRFC},
+		{name: "missing name", identity: with(individual, func(f *FiscalIdentity) { f.LegalName = " " }), cfdiUse: "D01", err: ErrFiscalNameRequired},
+		{name: "short postal code", identity: with(individual, func(f *FiscalIdentity) { f.PostalCode = "6600" }), cfdiUse: "D01", err: ErrInvalidFiscalPostalCode},
+		{name: "company regime on individual", identity: with(individual, func(f *FiscalIdentity) { f.TaxRegime = "601" }), cfdiUse: "D01", err: ErrInvalidTaxRegime},
+		{name: "unknown use", identity: individual, cfdiUse: "P01", err: ErrInvalidCFDIUse},
+		{name: "company personal deduction", identity: company, cfdiUse: "D01", err: ErrCFDIUseNotAllowed},
+		{name: "no obligations", identity: with(individual, func(f *FiscalIdentity) { f.TaxRegime = "616" }), cfdiUse: "G03", err: ErrCFDIUseNotAllowed},
+		{name: "salaried general expenses", identity: with(individual, func(f *FiscalIdentity) { f.TaxRegime = "605" }), cfdiUse: "G03", err: ErrCFDIUseNotAllowed},
+		{name: "salaried medical expenses", identity: with(individual, func(f *FiscalIdentity) { f.TaxRegime = "605" }), cfdiUse: "D01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := NewPatientFiscalProfile(uuid.New(), uuid.New(), tt.identity, tt.cfdiUse)
+			if err != tt.err {
+				t.Fatalf("NewPatientFiscalProfile() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && profile.IsCompany() != (tt.identity.RFC == company.RFC) {
+				t.Errorf("IsCompany() = %v for %s", profile.IsCompany(), profile.RFC)
+			}
+		})
+	}
+
+	profile, _ := NewPatientFiscalProfile(uuid.New(), uuid.New(), individual, "d01")
+	if profile.RFC != "GODE561231GR8" || profile.LegalName != "ERNESTO GÓMEZ DÍAZ" || profile.CFDIUse != "D01" {
+		t.Errorf("fields were not normalized: %+v", profile)
+	}
+}
+
+func TestNewIssuerFiscalProfile(t *testing.T) {
+	identity := FiscalIdentity{RFC: "CDS150101AB1", LegalName: "Clínica Dental Sonrisa", TaxRegime: "601", PostalCode: "44100"}
+
+	profile, err := NewIssuerFiscalProfile(uuid.New(), identity, "")
+	if err != nil {
+		t.Fatalf("NewIssuerFiscalProfile() error = %v", err)
+	}
+	if profile.ProductCode != DefaultCFDIProductCode {
+		t.Errorf("ProductCode = %q, want the default", profile.ProductCode)
+	}
+
+	if _, err := NewIssuerFiscalProfile(uuid.New(), identity, "8512"); err != ErrInvalidCFDIProductCode {
+		t.Errorf("short product code error = %v, want %v", err, ErrInvalidCFDIProductCode)
+	}
+}
+
+func TestCFDICancellation(t *testing.T) {
+	invoice := &Invoice{ID: uuid.New(), Total: 116000}
+	now := time.Now()
+	replacement := "a1b2c3d4-0000-4000-8000-000000000001"
+	blank := " "
+
+	tests := []struct {
+		name        string
+		reason      CFDICancellationReason
+		replacement *string
+		err         error
+	}{
+		{name: "not carried out", reason: CFDICancellationNotCarriedOut},
+		{name: "replaced", reason: CFDICancellationWithErrorsReplaced, replacement: &replacement},
+		{name: "blank replacement ignored", reason: CFDICancellationWithErrors, replacement: &blank},
+		{name: "unknown reason", reason: "05", err: ErrInvalidCFDICancellationReason},
+		{name: "replaced without replacement", reason: CFDICancellationWithErrorsReplaced, err: ErrInvalidReplacementUUID},
+		{name: "replacement for another reason", reason: CFDICancellationNotCarriedOut, replacement: &replacement, err: ErrInvalidReplacementUUID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfdi := NewCFDI(invoice, "f0e1d2c3-0000-4000-8000-000000000002", "CDS150101AB1", "GODE561231GR8", nil, now, nil)
+			err := cfdi.RequestCancellation(tt.reason, tt.replacement, nil, now)
+			if err != tt.err {
+				t.Fatalf("RequestCancellation() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && cfdi.Status != CFDIStatusCancellationPending {
+				t.Errorf("Status = %s, want %s", cfdi.Status, CFDIStatusCancellationPending)
+			}
+		})
+	}
+
+	cfdi := NewCFDI(invoice, "f0e1d2c3-0000-4000-8000-000000000002", "CDS150101AB1", "GODE561231GR8", nil, now, nil)
+	if cfdi.UUID != "F0E1D2C3-0000-4000-8000-000000000002" {
+		t.Errorf("UUID = %s, want it upper-cased", cfdi.UUID)
+	}
+	self := cfdi.UUID
+	if err := cfdi.RequestCancellation(CFDICancellationWithErrorsReplaced, &self, nil, now); err != ErrInvalidReplacementUUID {
+		t.Errorf("self replacement error = %v, want %v", err, ErrInvalidReplacementUUID)
+	}
+
+	cfdi.ConfirmCancellation(now)
+	if cfdi.IsActive() {
+		t.Error("cancelled CFDI should not be active")
+	}
+	if err := cfdi.RequestCancellation(CFDICancellationNotCarriedOut, nil, nil, now); err != ErrCFDICancelled {
+		t.Errorf("cancel again error = %v, want %v", err, ErrCFDICancelled)
+	}
+}
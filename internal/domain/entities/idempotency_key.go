@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyMaxLength is the longest Idempotency-Key header value accepted
+const IdempotencyKeyMaxLength = 255
+
+// IdempotencyStatus represents the state of a request made with an idempotency key
+type IdempotencyStatus string
+
+const (
+	IdempotencyStatusInProgress IdempotencyStatus = "in_progress"
+	IdempotencyStatusCompleted  IdempotencyStatus = "completed"
+)
+
+// IdempotencyKey stores the outcome of a request so a retry with the same key
+// receives the original response instead of repeating the side effects
+type IdempotencyKey struct {
+	OrganizationID uuid.UUID         `json:"organization_id" db:"organization_id"`
+	Key            string            `json:"key" db:"key"`
+	RequestHash    string            `json:"-" db:"request_hash"` // Fingerprint of method, route and body
+	Status         IdempotencyStatus `json:"status" db:"status"`
+	ResponseStatus *int              `json:"response_status,omitempty" db:"response_status"`
+	ResponseBody   []byte            `json:"-" db:"response_body"`
+	ContentType    *string           `json:"content_type,omitempty" db:"content_type"`
+	ETag           *string           `json:"etag,omitempty" db:"etag"` // Replayed so clients can send If-Match on their next write
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+	ExpiresAt      time.Time         `json:"expires_at" db:"expires_at"`
+}
+
+// NewIdempotencyKey creates an in-progress record for a request
+func NewIdempotencyKey(organizationID uuid.UUID, key, requestHash string, retention time.Duration) *IdempotencyKey {
+	now := time.Now()
+	return &IdempotencyKey{
+		OrganizationID: organizationID,
+		Key:            key,
+		RequestHash:    requestHash,
+		Status:         IdempotencyStatusInProgress,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(retention),
+	}
+}
+
+// IsValidIdempotencyKey checks the key is non-empty, printable ASCII and not too long
+func IsValidIdempotencyKey(key string) bool {
+	if key == "" || len(key) > IdempotencyKeyMaxLength {
+		return false
+	}
+	for _, r := range key {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// IdempotencyRequestHash fingerprints a request so a reused key can be detected
+func IdempotencyRequestHash(method, route string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(strings.ToUpper(method)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(route))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Matches checks if a request has the same fingerprint as the stored one
+func (k *IdempotencyKey) Matches(requestHash string) bool {
+	return k.RequestHash == requestHash
+}
+
+// IsCompleted checks if the original response is available for replay
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.Status == IdempotencyStatusCompleted
+}
+
+// Complete stores the response of the original request
+func (k *IdempotencyKey) Complete(status int, contentType, etag string, body []byte) {
+	k.Status = IdempotencyStatusCompleted
+	k.ResponseStatus = &status
+	k.ResponseBody = body
+	if contentType != "" {
+		k.ContentType = &contentType
+	}
+	if etag != "" {
+		k.ETag = &etag
+	}
+}
@@ -0,0 +1,345 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceCategory groups services the way dental insurance plans set their coverage
+type ServiceCategory string
+
+const (
+	ServiceCategoryPreventive  ServiceCategory = "preventive" // Exams, cleanings, X-rays
+	ServiceCategoryBasic       ServiceCategory = "basic"      // Fillings, extractions, periodontics
+	ServiceCategoryMajor       ServiceCategory = "major"      // Crowns, bridges, implants, endodontics
+	ServiceCategoryOrthodontic ServiceCategory = "orthodontic"
+	ServiceCategoryCosmetic    ServiceCategory = "cosmetic" // Whitening, veneers; rarely covered
+)
+
+// ServiceCategories lists the service categories
+var ServiceCategories = []ServiceCategory{
+	ServiceCategoryPreventive, ServiceCategoryBasic, ServiceCategoryMajor, ServiceCategoryOrthodontic, ServiceCategoryCosmetic,
+}
+
+// IsValidServiceCategory checks if the service category is supported
+func IsValidServiceCategory(category ServiceCategory) bool {
+	for _, c := range ServiceCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// InsuranceCarrier is a dental insurance company patients hold policies with
+type InsuranceCarrier struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	OrganizationID uuid.UUID `json:"organization_id" db:"organization_id"`
+	Name           string    `json:"name" db:"name"`
+	PayerID        *string   `json:"payer_id,omitempty" db:"payer_id"` // Carrier's code for electronic claims
+	Phone          *string   `json:"phone,omitempty" db:"phone"`
+	Email          *string   `json:"email,omitempty" db:"email"`
+	Notes          *string   `json:"notes,omitempty" db:"notes"`
+	Active         bool      `json:"active" db:"active"` // Inactive carriers keep their policies but take no new ones
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewInsuranceCarrier creates an active carrier
+func NewInsuranceCarrier(organizationID uuid.UUID, name string, payerID, phone, email, notes *string) (*InsuranceCarrier, error) {
+	now := time.Now()
+	carrier := &InsuranceCarrier{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Name:           strings.TrimSpace(name),
+		PayerID:        payerID,
+		Phone:          phone,
+		Email:          email,
+		Notes:          notes,
+		Active:         true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := carrier.Validate(); err != nil {
+		return nil, err
+	}
+	return carrier, nil
+}
+
+// Validate validates the carrier fields
+func (c *InsuranceCarrier) Validate() error {
+	if c.Name == "" {
+		return ErrInsuranceCarrierNameRequired
+	}
+	return nil
+}
+
+// InsurancePolicy is a patient's coverage with a carrier. Coverage is the percentage of each
+// service category the carrier pays once the deductible is met; categories left out are not
+// covered. Amounts are in minor units of the organization's currency and renew every
+// calendar year.
+type InsurancePolicy struct {
+	ID             uuid.UUID               `json:"id" db:"id"`
+	OrganizationID uuid.UUID               `json:"organization_id" db:"organization_id"`
+	PatientID      uuid.UUID               `json:"patient_id" db:"patient_id"`
+	CarrierID      uuid.UUID               `json:"carrier_id" db:"carrier_id"`
+	MemberID       string                  `json:"member_id" db:"member_id"`
+	GroupNumber    *string                 `json:"group_number,omitempty" db:"group_number"`
+	SubscriberName *string                 `json:"subscriber_name,omitempty" db:"subscriber_name"` // Policy holder, when not the patient
+	Coverage       map[ServiceCategory]int `json:"coverage" db:"coverage"`
+	AnnualMaximum  *int64                  `json:"annual_maximum,omitempty" db:"annual_maximum"` // Nil for no maximum
+	Deductible     int64                   `json:"deductible" db:"deductible"`                   // Per year; preventive care is exempt
+	EffectiveFrom  time.Time               `json:"effective_from" db:"effective_from"`
+	EffectiveTo    *time.Time              `json:"effective_to,omitempty" db:"effective_to"` // Last covered day
+	CreatedBy      *uuid.UUID              `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time               `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time               `json:"updated_at" db:"updated_at"`
+}
+
+// NewInsurancePolicy creates a patient's policy with a carrier
+func NewInsurancePolicy(organizationID, patientID, carrierID uuid.UUID, memberID string, coverage map[ServiceCategory]int, annualMaximum *int64, deductible int64, effectiveFrom time.Time, effectiveTo *time.Time, createdBy *uuid.UUID) (*InsurancePolicy, error) {
+	now := time.Now()
+	policy := &InsurancePolicy{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		CarrierID:      carrierID,
+		MemberID:       strings.TrimSpace(memberID),
+		Coverage:       coverage,
+		AnnualMaximum:  annualMaximum,
+		Deductible:     deductible,
+		EffectiveFrom:  effectiveFrom,
+		EffectiveTo:    effectiveTo,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if policy.Coverage == nil {
+		policy.Coverage = map[ServiceCategory]int{}
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate validates the policy fields
+func (p *InsurancePolicy) Validate() error {
+	if p.MemberID == "" {
+		return ErrInsuranceMemberIDRequired
+	}
+	for category, percent := range p.Coverage {
+		if !IsValidServiceCategory(category) {
+			return ErrInvalidServiceCategory
+		}
+		if percent < 0 || percent > 100 {
+			return ErrInvalidCoveragePercent
+		}
+	}
+	if (p.AnnualMaximum != nil && *p.AnnualMaximum < 0) || p.Deductible < 0 {
+		return ErrInvalidInsuranceAmount
+	}
+	if p.EffectiveTo != nil && p.EffectiveTo.Before(p.EffectiveFrom) {
+		return ErrInvalidPolicyPeriod
+	}
+	return nil
+}
+
+// CoveragePercent returns the percentage of a service category the carrier pays
+func (p *InsurancePolicy) CoveragePercent(category ServiceCategory) int {
+	return p.Coverage[category]
+}
+
+// CoversDate reports whether the policy is in effect on a day
+func (p *InsurancePolicy) CoversDate(day time.Time) bool {
+	date := day.Format("2006-01-02")
+	if date < p.EffectiveFrom.Format("2006-01-02") {
+		return false
+	}
+	return p.EffectiveTo == nil || date <= p.EffectiveTo.Format("2006-01-02")
+}
+
+// InsuranceClaimStatus represents where a claim is with the carrier
+type InsuranceClaimStatus string
+
+const (
+	InsuranceClaimStatusSubmitted InsuranceClaimStatus = "submitted" // Sent, awaiting the carrier's decision
+	InsuranceClaimStatusApproved  InsuranceClaimStatus = "approved"  // Accepted for ApprovedAmount, awaiting payment
+	InsuranceClaimStatusPaid      InsuranceClaimStatus = "paid"
+	InsuranceClaimStatusRejected  InsuranceClaimStatus = "rejected" // May be corrected and resubmitted
+)
+
+// IsValidInsuranceClaimStatus checks if the claim status is supported
+func IsValidInsuranceClaimStatus(status InsuranceClaimStatus) bool {
+	switch status {
+	case InsuranceClaimStatusSubmitted, InsuranceClaimStatusApproved, InsuranceClaimStatusPaid, InsuranceClaimStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// InsuranceClaim asks a carrier to pay its portion of the services of an appointment.
+// Amounts are in minor units of Currency.
+type InsuranceClaim struct {
+	ID                uuid.UUID            `json:"id" db:"id"`
+	OrganizationID    uuid.UUID            `json:"organization_id" db:"organization_id"`
+	PatientID         uuid.UUID            `json:"patient_id" db:"patient_id"`
+	PolicyID          uuid.UUID            `json:"policy_id" db:"policy_id"`
+	AppointmentID     uuid.UUID            `json:"appointment_id" db:"appointment_id"`
+	InvoiceID         *uuid.UUID           `json:"invoice_id,omitempty" db:"invoice_id"`
+	ClaimNumber       *string              `json:"claim_number,omitempty" db:"claim_number"` // Carrier's reference
+	Status            InsuranceClaimStatus `json:"status" db:"status"`
+	Currency          string               `json:"currency" db:"currency"`
+	BilledAmount      int64                `json:"billed_amount" db:"billed_amount"`
+	EstimatedAmount   int64                `json:"estimated_amount" db:"estimated_amount"`     // Insurer portion estimated when submitted
+	DeductibleApplied int64                `json:"deductible_applied" db:"deductible_applied"` // Estimated, or as the carrier applied it
+	ApprovedAmount    *int64               `json:"approved_amount,omitempty" db:"approved_amount"`
+	PaidAmount        *int64               `json:"paid_amount,omitempty" db:"paid_amount"`
+	RejectionReason   *string              `json:"rejection_reason,omitempty" db:"rejection_reason"`
+	ServiceDate       time.Time            `json:"service_date" db:"service_date"`
+	SubmittedAt       time.Time            `json:"submitted_at" db:"submitted_at"`
+	DecidedAt         *time.Time           `json:"decided_at,omitempty" db:"decided_at"` // When approved or rejected
+	PaidAt            *time.Time           `json:"paid_at,omitempty" db:"paid_at"`
+	Notes             *string              `json:"notes,omitempty" db:"notes"`
+	CreatedBy         *uuid.UUID           `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt         time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// NewInsuranceClaim creates a submitted claim for an appointment under a policy
+func NewInsuranceClaim(policy *InsurancePolicy, appointmentID uuid.UUID, invoiceID *uuid.UUID, currency string, billed, estimated, deductibleApplied int64, serviceDate time.Time, claimNumber, notes *string, createdBy *uuid.UUID) (*InsuranceClaim, error) {
+	if billed <= 0 || estimated < 0 || estimated > billed || deductibleApplied < 0 {
+		return nil, ErrInvalidInsuranceAmount
+	}
+	if !policy.CoversDate(serviceDate) {
+		return nil, ErrPolicyNotInEffect
+	}
+
+	now := time.Now()
+	return &InsuranceClaim{
+		ID:                uuid.New(),
+		OrganizationID:    policy.OrganizationID,
+		PatientID:         policy.PatientID,
+		PolicyID:          policy.ID,
+		AppointmentID:     appointmentID,
+		InvoiceID:         invoiceID,
+		ClaimNumber:       claimNumber,
+		Status:            InsuranceClaimStatusSubmitted,
+		Currency:          currency,
+		BilledAmount:      billed,
+		EstimatedAmount:   estimated,
+		DeductibleApplied: deductibleApplied,
+		ServiceDate:       serviceDate,
+		SubmittedAt:       now,
+		Notes:             notes,
+		CreatedBy:         createdBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// IsOpen reports whether the claim still counts towards the appointment; rejected claims
+// do not
+func (c *InsuranceClaim) IsOpen() bool {
+	return c.Status != InsuranceClaimStatusRejected
+}
+
+// InsurerAmount returns what the carrier pays or is expected to pay on the claim
+func (c *InsuranceClaim) InsurerAmount() int64 {
+	switch {
+	case c.Status == InsuranceClaimStatusRejected:
+		return 0
+	case c.PaidAmount != nil:
+		return *c.PaidAmount
+	case c.ApprovedAmount != nil:
+		return *c.ApprovedAmount
+	default:
+		return c.EstimatedAmount
+	}
+}
+
+// Approve records the carrier's acceptance of a submitted claim. deductibleApplied replaces
+// the estimate when the carrier reports it.
+func (c *InsuranceClaim) Approve(amount int64, deductibleApplied *int64, claimNumber *string, now time.Time) error {
+	if c.Status != InsuranceClaimStatusSubmitted {
+		return ErrInvalidClaimTransition
+	}
+	if amount < 0 || amount > c.BilledAmount || (deductibleApplied != nil && *deductibleApplied < 0) {
+		return ErrInvalidInsuranceAmount
+	}
+
+	c.Status = InsuranceClaimStatusApproved
+	c.ApprovedAmount = &amount
+	if deductibleApplied != nil {
+		c.DeductibleApplied = *deductibleApplied
+	}
+	if claimNumber != nil {
+		c.ClaimNumber = claimNumber
+	}
+	c.DecidedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// Reject records the carrier's refusal of a submitted claim
+func (c *InsuranceClaim) Reject(reason string, claimNumber *string, now time.Time) error {
+	if c.Status != InsuranceClaimStatusSubmitted {
+		return ErrInvalidClaimTransition
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrClaimRejectionReasonRequired
+	}
+
+	c.Status = InsuranceClaimStatusRejected
+	c.RejectionReason = &reason
+	if claimNumber != nil {
+		c.ClaimNumber = claimNumber
+	}
+	c.DecidedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// MarkPaid records the carrier's payment of an approved claim, defaulting to the approved
+// amount
+func (c *InsuranceClaim) MarkPaid(amount *int64, now time.Time) error {
+	if c.Status != InsuranceClaimStatusApproved {
+		return ErrInvalidClaimTransition
+	}
+	paid := *c.ApprovedAmount
+	if amount != nil {
+		paid = *amount
+	}
+	if paid < 0 || paid > c.BilledAmount {
+		return ErrInvalidInsuranceAmount
+	}
+
+	c.Status = InsuranceClaimStatusPaid
+	c.PaidAmount = &paid
+	c.PaidAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// Resubmit sends a rejected claim again after it was corrected
+func (c *InsuranceClaim) Resubmit(notes *string, now time.Time) error {
+	if c.Status != InsuranceClaimStatusRejected {
+		return ErrInvalidClaimTransition
+	}
+
+	c.Status = InsuranceClaimStatusSubmitted
+	c.RejectionReason = nil
+	c.DecidedAt = nil
+	c.SubmittedAt = now
+	if notes != nil {
+		c.Notes = notes
+	}
+	c.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewInsurancePolicy(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := from.AddDate(0, 0, -1)
+	negative := int64(-1)
+
+	tests := []struct {
+		name     string
+		memberID string
+		coverage map[ServiceCategory]int
+		maximum  *int64
+		to       *time.Time
+		err      error
+	}{
+		{name: "valid", memberID: "ABC123", coverage: map[ServiceCategory]int{ServiceCategoryPreventive: 100, ServiceCategoryBasic: 80}},
+		{name: "no coverage", memberID: "ABC123"},
+		{name: "blank member ID", memberID: "  ", err: ErrInsuranceMemberIDRequired},
+		{name: "unknown category", memberID: "ABC123", coverage: map[ServiceCategory]int{"surgery": 50}, err: ErrInvalidServiceCategory},
+		{name: "coverage above 100", memberID: "ABC123", coverage: map[ServiceCategory]int{ServiceCategoryMajor: 101}, err: ErrInvalidCoveragePercent},
+		{name: "negative maximum", memberID: "ABC123", maximum: &negative, err: ErrInvalidInsuranceAmount},
+		{name: "ends before it starts", memberID: "ABC123", to: &before, err: ErrInvalidPolicyPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewInsurancePolicy(uuid.New(), uuid.New(), uuid.New(), tt.memberID, tt.coverage, tt.maximum, 0, from, tt.to, nil)
+			if err != tt.err {
+				t.Errorf("NewInsurancePolicy() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestInsurancePolicyCoversDate(t *testing.T) {
+	to := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	policy := &InsurancePolicy{EffectiveFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), EffectiveTo: &to}
+
+	if policy.CoversDate(time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC)) {
+		t.Error("policy should not cover the day before it starts")
+	}
+	if !policy.CoversDate(time.Date(2025, 12, 31, 18, 0, 0, 0, time.UTC)) {
+		t.Error("policy should cover its last day")
+	}
+	if policy.CoversDate(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("policy should not cover the day after it ends")
+	}
+}
+
+func TestInsuranceClaimTransitions(t *testing.T) {
+	now := time.Now()
+	policy := &InsurancePolicy{ID: uuid.New(), EffectiveFrom: now.AddDate(-1, 0, 0)}
+
+	newClaim := func(t *testing.T) *InsuranceClaim {
+		claim, err := NewInsuranceClaim(policy, uuid.New(), nil, "MXN", 100000, 80000, 0, now, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("NewInsuranceClaim() error = %v", err)
+		}
+		return claim
+	}
+
+	if _, err := NewInsuranceClaim(policy, uuid.New(), nil, "MXN", 100000, 120000, 0, now, nil, nil, nil); err != ErrInvalidInsuranceAmount {
+		t.Errorf("estimate above billed error = %v, want %v", err, ErrInvalidInsuranceAmount)
+	}
+	if _, err := NewInsuranceClaim(policy, uuid.New(), nil, "MXN", 100000, 0, 0, now.AddDate(-2, 0, 0), nil, nil, nil); err != ErrPolicyNotInEffect {
+		t.Errorf("service before the policy error = %v, want %v", err, ErrPolicyNotInEffect)
+	}
+
+	claim := newClaim(t)
+	if claim.InsurerAmount() != 80000 {
+		t.Errorf("submitted InsurerAmount() = %d, want the estimate", claim.InsurerAmount())
+	}
+	if err := claim.MarkPaid(nil, now); err != ErrInvalidClaimTransition {
+		t.Errorf("pay before approval error = %v, want %v", err, ErrInvalidClaimTransition)
+	}
+	if err := claim.Approve(70000, nil, nil, now); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if err := claim.MarkPaid(nil, now); err != nil {
+		t.Fatalf("MarkPaid() error = %v", err)
+	}
+	if claim.Status != InsuranceClaimStatusPaid || claim.InsurerAmount() != 70000 {
+		t.Errorf("paid claim = %s %d, want paid 70000", claim.Status, claim.InsurerAmount())
+	}
+	if err := claim.Reject("late", nil, now); err != ErrInvalidClaimTransition {
+		t.Errorf("reject paid claim error = %v, want %v", err, ErrInvalidClaimTransition)
+	}
+
+	claim = newClaim(t)
+	if err := claim.Reject(" ", nil, now); err != ErrClaimRejectionReasonRequired {
+		t.Errorf("blank rejection reason error = %v, want %v", err, ErrClaimRejectionReasonRequired)
+	}
+	if err := claim.Reject("Missing X-ray", nil, now); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if claim.IsOpen() || claim.InsurerAmount() != 0 {
+		t.Error("rejected claim should be closed and pay nothing")
+	}
+	if err := claim.Resubmit(nil, now); err != nil {
+		t.Fatalf("Resubmit() error = %v", err)
+	}
+	if claim.Status != InsuranceClaimStatusSubmitted || claim.RejectionReason != nil {
+		t.Errorf("resubmitted claim = %s %v", claim.Status, claim.RejectionReason)
+	}
+}
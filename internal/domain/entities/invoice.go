@@ -0,0 +1,246 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceStatus represents how much of an invoice has been paid
+type InvoiceStatus string
+
+const (
+	InvoiceStatusIssued        InvoiceStatus = "issued"         // Nothing paid yet
+	InvoiceStatusPartiallyPaid InvoiceStatus = "partially_paid" // Some of the total is still due
+	InvoiceStatusPaid          InvoiceStatus = "paid"
+	InvoiceStatusVoid          InvoiceStatus = "void" // Cancelled; no longer counts towards the patient balance
+)
+
+// IsValidInvoiceStatus checks if the invoice status is supported
+func IsValidInvoiceStatus(status InvoiceStatus) bool {
+	switch status {
+	case InvoiceStatusIssued, InvoiceStatusPartiallyPaid, InvoiceStatusPaid, InvoiceStatusVoid:
+		return true
+	default:
+		return false
+	}
+}
+
+// MaxTaxRate is 100% in basis points
+const MaxTaxRate = 10000
+
+// Invoice bills a patient for the services of an appointment. Every amount is in minor
+// units of Currency (cents for MXN). Paid and refunded amounts are kept in sync with the
+// invoice's payments ledger.
+type Invoice struct {
+	ID             uuid.UUID      `json:"id" db:"id"`
+	OrganizationID uuid.UUID      `json:"organization_id" db:"organization_id"`
+	ClinicID       uuid.UUID      `json:"clinic_id" db:"clinic_id"`
+	PatientID      uuid.UUID      `json:"patient_id" db:"patient_id"`
+	AppointmentID  *uuid.UUID     `json:"appointment_id,omitempty" db:"appointment_id"`
+	Number         string         `json:"number" db:"number"` // Sequential per organization, e.g. INV-000042
+	Currency       string         `json:"currency" db:"currency"`
+	Status         InvoiceStatus  `json:"status" db:"status"`
+	Subtotal       int64          `json:"subtotal" db:"subtotal"` // Sum of line amounts before discounts
+	DiscountTotal  int64          `json:"discount_total" db:"discount_total"`
+	TaxTotal       int64          `json:"tax_total" db:"tax_total"`
+	Total          int64          `json:"total" db:"total"`
+	AmountPaid     int64          `json:"amount_paid" db:"amount_paid"`
+	AmountRefunded int64          `json:"amount_refunded" db:"amount_refunded"`
+	Notes          *string        `json:"notes,omitempty" db:"notes"`
+	IssuedAt       time.Time      `json:"issued_at" db:"issued_at"`
+	VoidedAt       *time.Time     `json:"voided_at,omitempty" db:"voided_at"`
+	VoidedBy       *uuid.UUID     `json:"voided_by,omitempty" db:"voided_by"`
+	VoidReason     *string        `json:"void_reason,omitempty" db:"void_reason"`
+	CreatedBy      *uuid.UUID     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+	Lines          []*InvoiceLine `json:"lines"` // Ordered by position
+}
+
+// InvoiceLine is a billed service. Discount is taken off Quantity × UnitPrice before the tax,
+// which is TaxRate basis points (1600 = 16%) of the discounted amount.
+type InvoiceLine struct {
+	ID                  uuid.UUID  `json:"id" db:"id"`
+	InvoiceID           uuid.UUID  `json:"invoice_id" db:"invoice_id"`
+	Position            int        `json:"position" db:"position"`
+	ServiceID           *string    `json:"service_id,omitempty" db:"service_id"`
+	TreatmentPlanItemID *uuid.UUID `json:"treatment_plan_item_id,omitempty" db:"treatment_plan_item_id"`
+	Description         string     `json:"description" db:"description"`
+	Quantity            int        `json:"quantity" db:"quantity"`
+	UnitPrice           int64      `json:"unit_price" db:"unit_price"`
+	Discount            int64      `json:"discount" db:"discount"`
+	TaxRate             int        `json:"tax_rate" db:"tax_rate"`
+	Tax                 int64      `json:"tax" db:"tax"`
+	Total               int64      `json:"total" db:"total"` // Discounted amount plus tax
+}
+
+// NewInvoiceLine creates a line and computes its tax and total
+func NewInvoiceLine(description string, quantity int, unitPrice, discount int64, taxRate int) (*InvoiceLine, error) {
+	line := &InvoiceLine{
+		ID:          uuid.New(),
+		Description: strings.TrimSpace(description),
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Discount:    discount,
+		TaxRate:     taxRate,
+	}
+
+	if err := line.Validate(); err != nil {
+		return nil, err
+	}
+
+	net := line.Amount() - line.Discount
+	line.Tax = roundDiv(net*int64(line.TaxRate), MaxTaxRate)
+	line.Total = net + line.Tax
+	return line, nil
+}
+
+// Validate validates the line fields
+func (l *InvoiceLine) Validate() error {
+	if l.Description == "" {
+		return ErrInvoiceLineDescriptionRequired
+	}
+	if l.Quantity < 1 {
+		return ErrInvalidInvoiceQuantity
+	}
+	if l.UnitPrice < 0 || l.Discount < 0 {
+		return ErrInvalidInvoiceAmount
+	}
+	if l.Discount > l.Amount() {
+		return ErrInvalidInvoiceDiscount
+	}
+	if l.TaxRate < 0 || l.TaxRate > MaxTaxRate {
+		return ErrInvalidTaxRate
+	}
+	return nil
+}
+
+// Amount returns the line amount before discount and tax
+func (l *InvoiceLine) Amount() int64 {
+	return int64(l.Quantity) * l.UnitPrice
+}
+
+// NewInvoice creates an issued invoice for a patient from its lines
+func NewInvoice(organizationID, clinicID, patientID uuid.UUID, appointmentID *uuid.UUID, number, currency string, lines []*InvoiceLine, createdBy *uuid.UUID) (*Invoice, error) {
+	if len(lines) == 0 {
+		return nil, ErrInvoiceLinesRequired
+	}
+
+	now := time.Now()
+	invoice := &Invoice{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		ClinicID:       clinicID,
+		PatientID:      patientID,
+		AppointmentID:  appointmentID,
+		Number:         number,
+		Currency:       currency,
+		IssuedAt:       now,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		Lines:          lines,
+	}
+
+	for i, line := range lines {
+		line.InvoiceID = invoice.ID
+		line.Position = i + 1
+		invoice.Subtotal += line.Amount()
+		invoice.DiscountTotal += line.Discount
+		invoice.TaxTotal += line.Tax
+		invoice.Total += line.Total
+	}
+	invoice.refreshStatus()
+
+	return invoice, nil
+}
+
+// IsVoid reports whether the invoice was cancelled
+func (i *Invoice) IsVoid() bool {
+	return i.Status == InvoiceStatusVoid
+}
+
+// NetPaid returns what the patient has paid after refunds
+func (i *Invoice) NetPaid() int64 {
+	return i.AmountPaid - i.AmountRefunded
+}
+
+// BalanceDue returns what the patient still owes; void invoices owe nothing
+func (i *Invoice) BalanceDue() int64 {
+	if i.IsVoid() {
+		return 0
+	}
+	return i.Total - i.NetPaid()
+}
+
+// ApplyPayment records a payment against the balance due
+func (i *Invoice) ApplyPayment(amount int64) error {
+	if i.IsVoid() {
+		return ErrInvoiceVoided
+	}
+	if amount <= 0 {
+		return ErrInvalidPaymentAmount
+	}
+	if amount > i.BalanceDue() {
+		return ErrPaymentExceedsBalance
+	}
+
+	i.AmountPaid += amount
+	i.refreshStatus()
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// ApplyRefund records money given back to the patient, which is owed again
+func (i *Invoice) ApplyRefund(amount int64) error {
+	if i.IsVoid() {
+		return ErrInvoiceVoided
+	}
+	if amount <= 0 {
+		return ErrInvalidPaymentAmount
+	}
+	if amount > i.NetPaid() {
+		return ErrRefundExceedsPayment
+	}
+
+	i.AmountRefunded += amount
+	i.refreshStatus()
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// Void cancels an invoice that has nothing paid on it
+func (i *Invoice) Void(userID *uuid.UUID, reason *string, now time.Time) error {
+	if i.IsVoid() {
+		return ErrInvoiceVoided
+	}
+	if i.NetPaid() > 0 {
+		return ErrInvoiceHasPayments
+	}
+
+	i.Status = InvoiceStatusVoid
+	i.VoidedAt = &now
+	i.VoidedBy = userID
+	i.VoidReason = reason
+	i.UpdatedAt = now
+	return nil
+}
+
+// refreshStatus derives the status of a non-void invoice from what has been paid
+func (i *Invoice) refreshStatus() {
+	switch paid := i.NetPaid(); {
+	case paid >= i.Total:
+		i.Status = InvoiceStatusPaid
+	case paid > 0:
+		i.Status = InvoiceStatusPartiallyPaid
+	default:
+		i.Status = InvoiceStatusIssued
+	}
+}
+
+// roundDiv divides a non-negative amount rounding half up
+func roundDiv(amount, divisor int64) int64 {
+	return (amount + divisor/2) / divisor
+}
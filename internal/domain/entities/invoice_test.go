@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewInvoiceLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		quantity  int
+		unitPrice int64
+		discount  int64
+		taxRate   int
+		tax       int64
+		total     int64
+		err       error
+	}{
+		{name: "no tax", quantity: 1, unitPrice: 80000, total: 80000},
+		{name: "discount before tax", quantity: 2, unitPrice: 50000, discount: 10000, taxRate: 1600, tax: 14400, total: 104400},
+		{name: "tax rounds half up", quantity: 1, unitPrice: 1003, taxRate: 1550, tax: 155, total: 1158},
+		{name: "zero quantity", quantity: 0, unitPrice: 100, err: ErrInvalidInvoiceQuantity},
+		{name: "negative price", quantity: 1, unitPrice: -1, err: ErrInvalidInvoiceAmount},
+		{name: "discount above amount", quantity: 1, unitPrice: 100, discount: 101, err: ErrInvalidInvoiceDiscount},
+		{name: "tax above 100%", quantity: 1, unitPrice: 100, taxRate: 10001, err: ErrInvalidTaxRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, err := NewInvoiceLine("Cleaning", tt.quantity, tt.unitPrice, tt.discount, tt.taxRate)
+			if err != tt.err {
+				t.Fatalf("NewInvoiceLine() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if line.Tax != tt.tax || line.Total != tt.total {
+				t.Errorf("tax, total = %d, %d, want %d, %d", line.Tax, line.Total, tt.tax, tt.total)
+			}
+		})
+	}
+
+	if _, err := NewInvoiceLine("  ", 1, 100, 0, 0); err != ErrInvoiceLineDescriptionRequired {
+		t.Errorf("blank description error = %v, want %v", err, ErrInvoiceLineDescriptionRequired)
+	}
+}
+
+func TestInvoicePaymentsAndRefunds(t *testing.T) {
+	cleaning, _ := NewInvoiceLine("Cleaning", 1, 60000, 10000, 0)
+	filling, _ := NewInvoiceLine("Filling", 1, 100000, 0, 1600)
+
+	invoice, err := NewInvoice(uuid.New(), uuid.New(), uuid.New(), nil, "INV-000001", "MXN", []*InvoiceLine{cleaning, filling}, nil)
+	if err != nil {
+		t.Fatalf("NewInvoice() error = %v", err)
+	}
+	if invoice.Subtotal != 160000 || invoice.DiscountTotal != 10000 || invoice.TaxTotal != 16000 || invoice.Total != 166000 {
+		t.Errorf("unexpected totals: %+v", invoice)
+	}
+	if invoice.Status != InvoiceStatusIssued || filling.Position != 2 || filling.InvoiceID != invoice.ID {
+		t.Errorf("unexpected status or line numbering: %s, %d", invoice.Status, filling.Position)
+	}
+
+	if err := invoice.ApplyPayment(100000); err != nil || invoice.Status != InvoiceStatusPartiallyPaid {
+		t.Fatalf("partial payment: err = %v, status = %s", err, invoice.Status)
+	}
+	if err := invoice.ApplyPayment(66001); err != ErrPaymentExceedsBalance {
+		t.Errorf("overpayment error = %v, want %v", err, ErrPaymentExceedsBalance)
+	}
+	if err := invoice.ApplyPayment(66000); err != nil || invoice.Status != InvoiceStatusPaid || invoice.BalanceDue() != 0 {
+		t.Fatalf("final payment: err = %v, status = %s, due = %d", err, invoice.Status, invoice.BalanceDue())
+	}
+	if err := invoice.Void(nil, nil, time.Now()); err != ErrInvoiceHasPayments {
+		t.Errorf("void with payments error = %v, want %v", err, ErrInvoiceHasPayments)
+	}
+
+	if err := invoice.ApplyRefund(166001); err != ErrRefundExceedsPayment {
+		t.Errorf("excess refund error = %v, want %v", err, ErrRefundExceedsPayment)
+	}
+	if err := invoice.ApplyRefund(166000); err != nil || invoice.Status != InvoiceStatusIssued || invoice.BalanceDue() != 166000 {
+		t.Fatalf("full refund: err = %v, status = %s, due = %d", err, invoice.Status, invoice.BalanceDue())
+	}
+
+	if err := invoice.Void(nil, nil, time.Now()); err != nil || !invoice.IsVoid() || invoice.BalanceDue() != 0 {
+		t.Fatalf("void: err = %v, status = %s", err, invoice.Status)
+	}
+	if err := invoice.ApplyPayment(100); err != ErrInvoiceVoided {
+		t.Errorf("payment on void invoice error = %v, want %v", err, ErrInvoiceVoided)
+	}
+}
+
+func TestNewInvoiceRequiresLines(t *testing.T) {
+	if _, err := NewInvoice(uuid.New(), uuid.New(), uuid.New(), nil, "INV-000001", "MXN", nil, nil); err != ErrInvoiceLinesRequired {
+		t.Errorf("NewInvoice() error = %v, want %v", err, ErrInvoiceLinesRequired)
+	}
+}
+
+func TestNewRefund(t *testing.T) {
+	line, _ := NewInvoiceLine("Crown", 1, 500000, 0, 0)
+	invoice, _ := NewInvoice(uuid.New(), uuid.New(), uuid.New(), nil, "INV-000002", "MXN", []*InvoiceLine{line}, nil)
+
+	payment, err := NewPayment(invoice, PaymentMethodCard, 300000, nil, nil, time.Now())
+	if err != nil {
+		t.Fatalf("NewPayment() error = %v", err)
+	}
+	if _, err := NewPayment(invoice, PaymentMethod("cheque"), 100, nil, nil, time.Now()); err != ErrInvalidPaymentMethod {
+		t.Errorf("unknown method error = %v, want %v", err, ErrInvalidPaymentMethod)
+	}
+
+	refund, err := NewRefund(payment, PaymentMethodCash, 100000, 250000, nil, nil, time.Now())
+	if err != ErrRefundExceedsPayment || refund != nil {
+		t.Errorf("refund above remaining error = %v, want %v", err, ErrRefundExceedsPayment)
+	}
+
+	refund, err = NewRefund(payment, PaymentMethodCash, 50000, 250000, nil, nil, time.Now())
+	if err != nil {
+		t.Fatalf("NewRefund() error = %v", err)
+	}
+	if !refund.IsRefund() || *refund.RefundOfID != payment.ID || refund.InvoiceID != invoice.ID || refund.Currency != "MXN" {
+		t.Errorf("unexpected refund: %+v", refund)
+	}
+	if _, err := NewRefund(refund, PaymentMethodCash, 1, 0, nil, nil, time.Now()); err != ErrRefundExceedsPayment {
+		t.Errorf("refund of a refund error = %v, want %v", err, ErrRefundExceedsPayment)
+	}
+}
+
+func TestToMinorUnits(t *testing.T) {
+	if got := ToMinorUnits(850.505, "MXN"); got != 85051 {
+		t.Errorf("ToMinorUnits(850.505, MXN) = %d, want 85051", got)
+	}
+	if got := ToMinorUnits(25000, "CLP"); got != 25000 {
+		t.Errorf("ToMinorUnits(25000, CLP) = %d, want 25000", got)
+	}
+	if code, err := NormalizeCurrency(" usd "); err != nil || code != "USD" {
+		t.Errorf("NormalizeCurrency(usd) = %q, %v", code, err)
+	}
+	if _, err := NormalizeCurrency("XYZ"); err != ErrUnsupportedCurrency {
+		t.Errorf("NormalizeCurrency(XYZ) error = %v, want %v", err, ErrUnsupportedCurrency)
+	}
+}
@@ -0,0 +1,227 @@
+package entities
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MedicalAlertCategory classifies what a medical alert warns about
+type MedicalAlertCategory string
+
+const (
+	MedicalAlertCategoryAllergy    MedicalAlertCategory = "allergy"    // Penicillin, latex, local anaesthetics...
+	MedicalAlertCategoryMedication MedicalAlertCategory = "medication" // Anticoagulants, bisphosphonates...
+	MedicalAlertCategoryCondition  MedicalAlertCategory = "condition"  // Heart conditions, diabetes, epilepsy...
+	MedicalAlertCategoryPregnancy  MedicalAlertCategory = "pregnancy"
+	MedicalAlertCategoryOther      MedicalAlertCategory = "other"
+)
+
+// IsValidMedicalAlertCategory checks if the category is supported
+func IsValidMedicalAlertCategory(category MedicalAlertCategory) bool {
+	switch category {
+	case MedicalAlertCategoryAllergy, MedicalAlertCategoryMedication, MedicalAlertCategoryCondition,
+		MedicalAlertCategoryPregnancy, MedicalAlertCategoryOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// MedicalAlertSeverity tells how careful treatment must be around an alert
+type MedicalAlertSeverity string
+
+const (
+	MedicalAlertSeverityLow      MedicalAlertSeverity = "low"      // Worth knowing
+	MedicalAlertSeverityModerate MedicalAlertSeverity = "moderate" // Changes how treatment is done
+	MedicalAlertSeverityHigh     MedicalAlertSeverity = "high"     // Life-threatening if ignored
+)
+
+// IsValidMedicalAlertSeverity checks if the severity is supported
+func IsValidMedicalAlertSeverity(severity MedicalAlertSeverity) bool {
+	return severity.Rank() > 0
+}
+
+// Rank orders severities from low (1) to high (3); unknown severities rank 0
+func (s MedicalAlertSeverity) Rank() int {
+	switch s {
+	case MedicalAlertSeverityLow:
+		return 1
+	case MedicalAlertSeverityModerate:
+		return 2
+	case MedicalAlertSeverityHigh:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// maxMedicalAlertTitleLength matches the title column
+const maxMedicalAlertTitleLength = 255
+
+// MedicalAlert is a structured warning about a patient (an allergy, a medication, a condition
+// or a pregnancy) shown to staff before treatment. Alerts are never deleted: an alert that no
+// longer applies is resolved, and every change is kept as a MedicalAlertChange.
+type MedicalAlert struct {
+	ID             uuid.UUID            `json:"id" db:"id"`
+	OrganizationID uuid.UUID            `json:"organization_id" db:"organization_id"`
+	PatientID      uuid.UUID            `json:"patient_id" db:"patient_id"`
+	Category       MedicalAlertCategory `json:"category" db:"category"`
+	Title          string               `json:"title" db:"title"` // What the alert is about, e.g. "Penicillin"
+	Severity       MedicalAlertSeverity `json:"severity" db:"severity"`
+	Notes          *string              `json:"notes,omitempty" db:"notes"`
+	ReviewDate     *time.Time           `json:"review_date,omitempty" db:"review_date"` // Date by which the alert should be confirmed again
+	LastReviewedAt *time.Time           `json:"last_reviewed_at,omitempty" db:"last_reviewed_at"`
+	LastReviewedBy *uuid.UUID           `json:"last_reviewed_by,omitempty" db:"last_reviewed_by"`
+	ResolvedAt     *time.Time           `json:"resolved_at,omitempty" db:"resolved_at"` // Set when the alert no longer applies
+	ResolvedBy     *uuid.UUID           `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedBy      *uuid.UUID           `json:"created_by,omitempty" db:"created_by"`
+	UpdatedBy      *uuid.UUID           `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// NewMedicalAlert creates a validated active alert for a patient
+func NewMedicalAlert(organizationID, patientID uuid.UUID, category MedicalAlertCategory, title string, severity MedicalAlertSeverity, createdBy *uuid.UUID) (*MedicalAlert, error) {
+	now := time.Now()
+	alert := &MedicalAlert{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		Category:       category,
+		Title:          strings.TrimSpace(title),
+		Severity:       severity,
+		CreatedBy:      createdBy,
+		UpdatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := alert.Validate(); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+// Validate validates the alert fields
+func (a *MedicalAlert) Validate() error {
+	if !IsValidMedicalAlertCategory(a.Category) {
+		return ErrInvalidMedicalAlertCategory
+	}
+	if !IsValidMedicalAlertSeverity(a.Severity) {
+		return ErrInvalidMedicalAlertSeverity
+	}
+	if a.Title == "" {
+		return ErrMedicalAlertTitleRequired
+	}
+	if len(a.Title) > maxMedicalAlertTitleLength {
+		return ErrMedicalAlertTitleTooLong
+	}
+	return nil
+}
+
+// IsActive checks if the alert still applies
+func (a *MedicalAlert) IsActive() bool {
+	return a.ResolvedAt == nil
+}
+
+// NeedsReview reports whether an active alert has reached its review date
+func (a *MedicalAlert) NeedsReview(now time.Time) bool {
+	if !a.IsActive() || a.ReviewDate == nil {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return !a.ReviewDate.After(today)
+}
+
+// Review records that the alert was confirmed with the patient and sets the next review date
+func (a *MedicalAlert) Review(reviewedBy *uuid.UUID, nextReviewDate *time.Time, now time.Time) error {
+	if !a.IsActive() {
+		return ErrMedicalAlertResolved
+	}
+	a.ReviewDate = nextReviewDate
+	a.LastReviewedAt = &now
+	a.LastReviewedBy = reviewedBy
+	a.UpdatedBy = reviewedBy
+	a.UpdatedAt = now
+	return nil
+}
+
+// Resolve marks the alert as no longer applying
+func (a *MedicalAlert) Resolve(resolvedBy *uuid.UUID, now time.Time) error {
+	if !a.IsActive() {
+		return ErrMedicalAlertResolved
+	}
+	a.ResolvedAt = &now
+	a.ResolvedBy = resolvedBy
+	a.UpdatedBy = resolvedBy
+	a.UpdatedAt = now
+	return nil
+}
+
+// Reactivate brings a resolved alert back
+func (a *MedicalAlert) Reactivate(updatedBy *uuid.UUID, now time.Time) error {
+	if a.IsActive() {
+		return ErrMedicalAlertActive
+	}
+	a.ResolvedAt = nil
+	a.ResolvedBy = nil
+	a.UpdatedBy = updatedBy
+	a.UpdatedAt = now
+	return nil
+}
+
+// MedicalAlertAction is the kind of change recorded in a medical alert's audit trail
+type MedicalAlertAction string
+
+const (
+	MedicalAlertActionCreated     MedicalAlertAction = "created"
+	MedicalAlertActionUpdated     MedicalAlertAction = "updated"
+	MedicalAlertActionReviewed    MedicalAlertAction = "reviewed"
+	MedicalAlertActionResolved    MedicalAlertAction = "resolved"
+	MedicalAlertActionReactivated MedicalAlertAction = "reactivated"
+)
+
+// MedicalAlertChange is the audit record of a change to a medical alert, with the alert's
+// state before (absent on creation) and after the change.
+type MedicalAlertChange struct {
+	ID             uuid.UUID          `json:"id" db:"id"`
+	OrganizationID uuid.UUID          `json:"organization_id" db:"organization_id"`
+	PatientID      uuid.UUID          `json:"patient_id" db:"patient_id"`
+	AlertID        uuid.UUID          `json:"alert_id" db:"alert_id"`
+	Action         MedicalAlertAction `json:"action" db:"action"`
+	Previous       json.RawMessage    `json:"previous,omitempty" db:"previous"`
+	Current        json.RawMessage    `json:"current" db:"current"`
+	Reason         *string            `json:"reason,omitempty" db:"reason"`
+	ChangedBy      *uuid.UUID         `json:"changed_by,omitempty" db:"changed_by"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+}
+
+// NewMedicalAlertChange creates the audit record of a change; previous is nil for new alerts
+func NewMedicalAlertChange(action MedicalAlertAction, previous, current *MedicalAlert, reason *string, changedBy *uuid.UUID) (*MedicalAlertChange, error) {
+	change := &MedicalAlertChange{
+		ID:             uuid.New(),
+		OrganizationID: current.OrganizationID,
+		PatientID:      current.PatientID,
+		AlertID:        current.ID,
+		Action:         action,
+		Reason:         reason,
+		ChangedBy:      changedBy,
+		CreatedAt:      time.Now(),
+	}
+
+	var err error
+	if previous != nil {
+		if change.Previous, err = json.Marshal(previous); err != nil {
+			return nil, err
+		}
+	}
+	if change.Current, err = json.Marshal(current); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
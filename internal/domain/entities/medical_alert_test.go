@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewMedicalAlert(t *testing.T) {
+	tests := []struct {
+		name     string
+		category MedicalAlertCategory
+		title    string
+		severity MedicalAlertSeverity
+		err      error
+	}{
+		{name: "allergy", category: MedicalAlertCategoryAllergy, title: " Penicillin ", severity: MedicalAlertSeverityHigh},
+		{name: "pregnancy", category: MedicalAlertCategoryPregnancy, title: "24 weeks", severity: MedicalAlertSeverityModerate},
+		{name: "unknown category", category: MedicalAlertCategory("diet"), title: "Vegan", severity: MedicalAlertSeverityLow, err: ErrInvalidMedicalAlertCategory},
+		{name: "unknown severity", category: MedicalAlertCategoryAllergy, title: "Latex", severity: MedicalAlertSeverity("critical"), err: ErrInvalidMedicalAlertSeverity},
+		{name: "title required", category: MedicalAlertCategoryMedication, title: "  ", severity: MedicalAlertSeverityHigh, err: ErrMedicalAlertTitleRequired},
+		{name: "title too long", category: MedicalAlertCategoryOther, title: strings.Repeat("a", 256), severity: MedicalAlertSeverityLow, err: ErrMedicalAlertTitleTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert, err := NewMedicalAlert(uuid.New(), uuid.New(), tt.category, tt.title, tt.severity, nil)
+			if err != tt.err {
+				t.Fatalf("NewMedicalAlert() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if alert.Title != strings.TrimSpace(tt.title) {
+				t.Errorf("Title = %q, want %q", alert.Title, strings.TrimSpace(tt.title))
+			}
+			if !alert.IsActive() {
+				t.Error("new alerts should be active")
+			}
+		})
+	}
+}
+
+func TestMedicalAlertSeverityRank(t *testing.T) {
+	if !(MedicalAlertSeverityHigh.Rank() > MedicalAlertSeverityModerate.Rank() &&
+		MedicalAlertSeverityModerate.Rank() > MedicalAlertSeverityLow.Rank()) {
+		t.Error("severities should rank low < moderate < high")
+	}
+	if MedicalAlertSeverity("unknown").Rank() != 0 {
+		t.Error("unknown severities should rank 0")
+	}
+}
+
+func TestMedicalAlertNeedsReview(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 30, 0, 0, time.UTC)
+	date := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+
+	tests := []struct {
+		name       string
+		reviewDate *time.Time
+		resolved   bool
+		want       bool
+	}{
+		{name: "no review date", want: false},
+		{name: "review date ahead", reviewDate: date("2024-05-11"), want: false},
+		{name: "review date today", reviewDate: date("2024-05-10"), want: true},
+		{name: "review date passed", reviewDate: date("2024-01-01"), want: true},
+		{name: "resolved alerts are never due", reviewDate: date("2024-01-01"), resolved: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert, _ := NewMedicalAlert(uuid.New(), uuid.New(), MedicalAlertCategoryCondition, "Hypertension", MedicalAlertSeverityModerate, nil)
+			alert.ReviewDate = tt.reviewDate
+			if tt.resolved {
+				_ = alert.Resolve(nil, now)
+			}
+			if got := alert.NeedsReview(now); got != tt.want {
+				t.Errorf("NeedsReview() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMedicalAlertLifecycle(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	alert, _ := NewMedicalAlert(uuid.New(), uuid.New(), MedicalAlertCategoryMedication, "Warfarin", MedicalAlertSeverityHigh, &userID)
+
+	next := now.AddDate(0, 6, 0)
+	if err := alert.Review(&userID, &next, now); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if alert.LastReviewedAt == nil || alert.ReviewDate != &next {
+		t.Error("Review() should record the review and the next review date")
+	}
+
+	if err := alert.Reactivate(&userID, now); err != ErrMedicalAlertActive {
+		t.Errorf("Reactivate() on an active alert error = %v, want %v", err, ErrMedicalAlertActive)
+	}
+	if err := alert.Resolve(&userID, now); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if alert.IsActive() {
+		t.Error("resolved alerts should not be active")
+	}
+	if err := alert.Resolve(&userID, now); err != ErrMedicalAlertResolved {
+		t.Errorf("Resolve() twice error = %v, want %v", err, ErrMedicalAlertResolved)
+	}
+	if err := alert.Review(&userID, nil, now); err != ErrMedicalAlertResolved {
+		t.Errorf("Review() of a resolved alert error = %v, want %v", err, ErrMedicalAlertResolved)
+	}
+	if err := alert.Reactivate(&userID, now); err != nil {
+		t.Fatalf("Reactivate() error = %v", err)
+	}
+	if !alert.IsActive() || alert.ResolvedBy != nil {
+		t.Error("Reactivate() should clear the resolution")
+	}
+}
+
+func TestNewMedicalAlertChange(t *testing.T) {
+	userID := uuid.New()
+	alert, _ := NewMedicalAlert(uuid.New(), uuid.New(), MedicalAlertCategoryAllergy, "Latex", MedicalAlertSeverityModerate, &userID)
+
+	created, err := NewMedicalAlertChange(MedicalAlertActionCreated, nil, alert, nil, &userID)
+	if err != nil {
+		t.Fatalf("NewMedicalAlertChange() error = %v", err)
+	}
+	if created.Previous != nil {
+		t.Error("creation should have no previous state")
+	}
+
+	previous := *alert
+	alert.Severity = MedicalAlertSeverityHigh
+	updated, err := NewMedicalAlertChange(MedicalAlertActionUpdated, &previous, alert, nil, &userID)
+	if err != nil {
+		t.Fatalf("NewMedicalAlertChange() error = %v", err)
+	}
+	if updated.AlertID != alert.ID || updated.PatientID != alert.PatientID {
+		t.Error("change should reference the alert and its patient")
+	}
+
+	var before, after MedicalAlert
+	if err := json.Unmarshal(updated.Previous, &before); err != nil {
+		t.Fatalf("previous snapshot: %v", err)
+	}
+	if err := json.Unmarshal(updated.Current, &after); err != nil {
+		t.Fatalf("current snapshot: %v", err)
+	}
+	if before.Severity != MedicalAlertSeverityModerate || after.Severity != MedicalAlertSeverityHigh {
+		t.Errorf("snapshots severity = %s -> %s, want moderate -> high", before.Severity, after.Severity)
+	}
+}
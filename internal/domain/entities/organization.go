@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,27 +9,31 @@ import (
 
 // Organization represents a dental organization that can have multiple clinics
 type Organization struct {
-	ID          uuid.UUID `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	Description *string   `json:"description,omitempty" db:"description"`
-	Address     *string   `json:"address,omitempty" db:"address"`
-	Phone       *string   `json:"phone,omitempty" db:"phone"`
-	Email       *string   `json:"email,omitempty" db:"email"`
-	Website     *string   `json:"website,omitempty" db:"website"`
-	IsActive    bool      `json:"is_active" db:"is_active"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID                 uuid.UUID `json:"id" db:"id"`
+	Name               string    `json:"name" db:"name"`
+	Description        *string   `json:"description,omitempty" db:"description"`
+	Address            *string   `json:"address,omitempty" db:"address"`
+	Phone              *string   `json:"phone,omitempty" db:"phone"`
+	Email              *string   `json:"email,omitempty" db:"email"`
+	Website            *string   `json:"website,omitempty" db:"website"`
+	IsActive           bool      `json:"is_active" db:"is_active"`
+	DefaultPhoneRegion string    `json:"default_phone_region" db:"default_phone_region"` // ISO 3166-1 alpha-2 region for national phone numbers
+	Currency           string    `json:"currency" db:"currency"`                         // ISO 4217 code invoices are issued in
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
 }
 
 // NewOrganization creates a new organization with the given name
 func NewOrganization(name string) *Organization {
 	now := time.Now()
 	return &Organization{
-		ID:        uuid.New(),
-		Name:      name,
-		IsActive:  true,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:                 uuid.New(),
+		Name:               name,
+		IsActive:           true,
+		DefaultPhoneRegion: DefaultPhoneRegion,
+		Currency:           DefaultCurrency,
+		CreatedAt:          now,
+		UpdatedAt:          now,
 	}
 }
 
@@ -40,6 +45,28 @@ func (o *Organization) Validate() error {
 	return nil
 }
 
+// SetDefaultPhoneRegion sets the region used to read phone numbers without a country code
+func (o *Organization) SetDefaultPhoneRegion(region string) error {
+	region = strings.ToUpper(region)
+	if !IsSupportedPhoneRegion(region) {
+		return ErrUnsupportedPhoneRegion
+	}
+	o.DefaultPhoneRegion = region
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetCurrency sets the currency invoices are issued in
+func (o *Organization) SetCurrency(currency string) error {
+	code, err := NormalizeCurrency(currency)
+	if err != nil {
+		return err
+	}
+	o.Currency = code
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
 // SetDescription sets the organization description
 func (o *Organization) SetDescription(description string) {
 	o.Description = &description
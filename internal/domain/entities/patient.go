@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,13 +15,18 @@ type Patient struct {
 	LastName           *string    `json:"last_name,omitempty" db:"last_name"`
 	Email              *string    `json:"email,omitempty" db:"email"`
 	Phone              *string    `json:"phone,omitempty" db:"phone"`
+	PhoneE164          *string    `json:"phone_e164,omitempty" db:"phone_e164"` // Normalized form of Phone
 	DateOfBirth        *time.Time `json:"date_of_birth,omitempty" db:"date_of_birth"`
 	MedicalHistory     *string    `json:"medical_history,omitempty" db:"medical_history"`
 	FirstAppointmentID *uuid.UUID `json:"first_appointment_id,omitempty" db:"first_appointment_id"` // ID of patient's first appointment
+	Version            int        `json:"version" db:"version"`                                     // Optimistic concurrency token, bumped on every update
 	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
 }
 
+// AdultAge is the age from which patients are contacted directly instead of through a guardian
+const AdultAge = 18
+
 // Validate checks if the patient entity is valid
 func (p *Patient) Validate() error {
 	if p.FirstName == "" {
@@ -36,6 +42,16 @@ func (p *Patient) Validate() error {
 	return nil
 }
 
+// NormalizePhone validates the phone against the region and stores its E.164 form
+func (p *Patient) NormalizePhone(region string) error {
+	phone, e164, err := normalizePhoneFields(p.Phone, region)
+	if err != nil {
+		return err
+	}
+	p.Phone, p.PhoneE164 = phone, e164
+	return nil
+}
+
 // IsValid checks if the patient has valid data
 func (p *Patient) IsValid() bool {
 	return p.Validate() == nil
@@ -53,7 +69,59 @@ func (p *Patient) UnlinkFromUser() {
 	p.UpdatedAt = time.Now()
 }
 
+// AbsorbDuplicate fills the patient's missing details from a duplicate record that is
+// being merged into it. Medical histories are concatenated so no clinical notes are lost.
+func (p *Patient) AbsorbDuplicate(duplicate *Patient) {
+	if isBlank(p.LastName) {
+		p.LastName = duplicate.LastName
+	}
+	if isBlank(p.Email) {
+		p.Email = duplicate.Email
+	}
+	if isBlank(p.Phone) {
+		p.Phone = duplicate.Phone
+		p.PhoneE164 = duplicate.PhoneE164
+	}
+	if p.DateOfBirth == nil {
+		p.DateOfBirth = duplicate.DateOfBirth
+	}
+	if !isBlank(duplicate.MedicalHistory) {
+		if isBlank(p.MedicalHistory) {
+			p.MedicalHistory = duplicate.MedicalHistory
+		} else if *p.MedicalHistory != *duplicate.MedicalHistory {
+			combined := *p.MedicalHistory + "\n\n" + *duplicate.MedicalHistory
+			p.MedicalHistory = &combined
+		}
+	}
+	p.UpdatedAt = time.Now()
+}
+
+// isBlank checks if an optional text field is unset or empty
+func isBlank(value *string) bool {
+	return value == nil || strings.TrimSpace(*value) == ""
+}
+
 // HasUserAccount checks if the patient has a linked user account
 func (p *Patient) HasUserAccount() bool {
 	return p.UserID != nil
 }
+
+// AgeAt returns the patient's age in whole years at the given time; false when the date of birth is unknown
+func (p *Patient) AgeAt(now time.Time) (int, bool) {
+	if p.DateOfBirth == nil {
+		return 0, false
+	}
+	birth := p.DateOfBirth.UTC()
+	today := now.UTC()
+	age := today.Year() - birth.Year()
+	if today.Month() < birth.Month() || (today.Month() == birth.Month() && today.Day() < birth.Day()) {
+		age--
+	}
+	return age, true
+}
+
+// IsMinor checks if the patient is under AdultAge; patients without a date of birth are treated as adults
+func (p *Patient) IsMinor(now time.Time) bool {
+	age, known := p.AgeAt(now)
+	return known && age < AdultAge
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PatientMerge is the audit record of a duplicate patient folded into a surviving record.
+// The merged patient row is deleted, so its last state is kept as a snapshot.
+type PatientMerge struct {
+	ID                    uuid.UUID       `json:"id" db:"id"`
+	OrganizationID        uuid.UUID       `json:"organization_id" db:"organization_id"`
+	SurvivorPatientID     uuid.UUID       `json:"survivor_patient_id" db:"survivor_patient_id"`
+	MergedPatientID       uuid.UUID       `json:"merged_patient_id" db:"merged_patient_id"`
+	MergedPatientSnapshot json.RawMessage `json:"merged_patient_snapshot" db:"merged_patient_snapshot"`
+	AppointmentsMoved     int             `json:"appointments_moved" db:"appointments_moved"`
+	Score                 int             `json:"score" db:"score"` // Duplicate score between the two records at merge time
+	Reason                *string         `json:"reason,omitempty" db:"reason"`
+	MergedBy              *uuid.UUID      `json:"merged_by,omitempty" db:"merged_by"`
+	CreatedAt             time.Time       `json:"created_at" db:"created_at"`
+}
+
+// NewPatientMerge creates the audit record for merging merged into survivor
+func NewPatientMerge(organizationID uuid.UUID, survivor, merged *Patient, appointmentsMoved, score int, reason *string, mergedBy *uuid.UUID) (*PatientMerge, error) {
+	snapshot, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PatientMerge{
+		ID:                    uuid.New(),
+		OrganizationID:        organizationID,
+		SurvivorPatientID:     survivor.ID,
+		MergedPatientID:       merged.ID,
+		MergedPatientSnapshot: snapshot,
+		AppointmentsMoved:     appointmentsMoved,
+		Score:                 score,
+		Reason:                reason,
+		MergedBy:              mergedBy,
+		CreatedAt:             time.Now(),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PatientRelationshipType is what the related patient is to the patient
+type PatientRelationshipType string
+
+const (
+	PatientRelationshipGuardian  PatientRelationshipType = "guardian"  // Parent or legal guardian of the patient
+	PatientRelationshipDependent PatientRelationshipType = "dependent" // Child or ward of the patient
+	PatientRelationshipSpouse    PatientRelationshipType = "spouse"
+)
+
+// IsValidPatientRelationshipType checks if the relationship type is supported
+func IsValidPatientRelationshipType(relationshipType PatientRelationshipType) bool {
+	switch relationshipType {
+	case PatientRelationshipGuardian, PatientRelationshipDependent, PatientRelationshipSpouse:
+		return true
+	default:
+		return false
+	}
+}
+
+// Inverse returns the relationship seen from the related patient
+func (t PatientRelationshipType) Inverse() PatientRelationshipType {
+	switch t {
+	case PatientRelationshipGuardian:
+		return PatientRelationshipDependent
+	case PatientRelationshipDependent:
+		return PatientRelationshipGuardian
+	default:
+		return t
+	}
+}
+
+// PatientRelationship links a patient to a relative within an organization: RelatedPatientID
+// is the patient's Type ("the guardian of PatientID is RelatedPatientID"). Relationships are
+// stored in both directions. IsContact marks the guardian through whom a minor is reached.
+type PatientRelationship struct {
+	ID               uuid.UUID               `json:"id" db:"id"`
+	OrganizationID   uuid.UUID               `json:"organization_id" db:"organization_id"`
+	PatientID        uuid.UUID               `json:"patient_id" db:"patient_id"`
+	RelatedPatientID uuid.UUID               `json:"related_patient_id" db:"related_patient_id"`
+	Type             PatientRelationshipType `json:"type" db:"type"`
+	IsContact        bool                    `json:"is_contact" db:"is_contact"` // Only set on guardian relationships
+	CreatedBy        *uuid.UUID              `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt        time.Time               `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at" db:"updated_at"`
+}
+
+// NewPatientRelationship creates a validated relationship and its inverse
+func NewPatientRelationship(organizationID, patientID, relatedPatientID uuid.UUID, relationshipType PatientRelationshipType, createdBy *uuid.UUID) (*PatientRelationship, *PatientRelationship, error) {
+	if !IsValidPatientRelationshipType(relationshipType) {
+		return nil, nil, ErrInvalidPatientRelationshipType
+	}
+	if patientID == relatedPatientID {
+		return nil, nil, ErrPatientRelatedToItself
+	}
+
+	now := time.Now()
+	relationship := &PatientRelationship{
+		ID:               uuid.New(),
+		OrganizationID:   organizationID,
+		PatientID:        patientID,
+		RelatedPatientID: relatedPatientID,
+		Type:             relationshipType,
+		CreatedBy:        createdBy,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	inverse := &PatientRelationship{
+		ID:               uuid.New(),
+		OrganizationID:   organizationID,
+		PatientID:        relatedPatientID,
+		RelatedPatientID: patientID,
+		Type:             relationshipType.Inverse(),
+		CreatedBy:        createdBy,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	return relationship, inverse, nil
+}
+
+// IsGuardian checks if the related patient is a guardian of the patient
+func (r *PatientRelationship) IsGuardian() bool {
+	return r.Type == PatientRelationshipGuardian
+}
+
+// ContactRole tells whose details are used to reach a patient
+type ContactRole string
+
+const (
+	ContactRoleSelf     ContactRole = "self"     // The patient's own details
+	ContactRoleGuardian ContactRole = "guardian" // A minor reached through a guardian
+)
+
+// PatientContact is who is notified about a patient's appointments and how to reach them
+type PatientContact struct {
+	PatientID        uuid.UUID   `json:"patient_id"`
+	ContactPatientID uuid.UUID   `json:"contact_patient_id"` // The patient, or the guardian of a minor
+	Role             ContactRole `json:"role"`
+	Name             string      `json:"name"`
+	Email            *string     `json:"email,omitempty"`
+	Phone            *string     `json:"phone,omitempty"`
+	PhoneE164        *string     `json:"phone_e164,omitempty"`
+}
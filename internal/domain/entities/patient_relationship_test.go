@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewPatientRelationship(t *testing.T) {
+	orgID, childID, parentID := uuid.New(), uuid.New(), uuid.New()
+
+	relationship, inverse, err := NewPatientRelationship(orgID, childID, parentID, PatientRelationshipGuardian, nil)
+	if err != nil {
+		t.Fatalf("NewPatientRelationship() error = %v", err)
+	}
+	if relationship.PatientID != childID || relationship.RelatedPatientID != parentID || !relationship.IsGuardian() {
+		t.Errorf("unexpected relationship: %+v", relationship)
+	}
+	if inverse.PatientID != parentID || inverse.RelatedPatientID != childID || inverse.Type != PatientRelationshipDependent {
+		t.Errorf("unexpected inverse: %+v", inverse)
+	}
+	if relationship.ID == inverse.ID || relationship.IsContact || inverse.IsContact {
+		t.Errorf("relationships must have their own IDs and no contact person yet")
+	}
+
+	if _, _, err := NewPatientRelationship(orgID, childID, childID, PatientRelationshipSpouse, nil); err != ErrPatientRelatedToItself {
+		t.Errorf("self relationship error = %v, want %v", err, ErrPatientRelatedToItself)
+	}
+	if _, _, err := NewPatientRelationship(orgID, childID, parentID, PatientRelationshipType("cousin"), nil); err != ErrInvalidPatientRelationshipType {
+		t.Errorf("unknown type error = %v, want %v", err, ErrInvalidPatientRelationshipType)
+	}
+}
+
+func TestPatientRelationshipTypeInverse(t *testing.T) {
+	tests := map[PatientRelationshipType]PatientRelationshipType{
+		PatientRelationshipGuardian:  PatientRelationshipDependent,
+		PatientRelationshipDependent: PatientRelationshipGuardian,
+		PatientRelationshipSpouse:    PatientRelationshipSpouse,
+	}
+
+	for relationshipType, want := range tests {
+		if got := relationshipType.Inverse(); got != want {
+			t.Errorf("%s.Inverse() = %s, want %s", relationshipType, got, want)
+		}
+	}
+}
+
+func TestPatientIsMinor(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	tests := []struct {
+		name        string
+		dateOfBirth *time.Time
+		age         int
+		minor       bool
+	}{
+		{name: "child", dateOfBirth: date(2016, 3, 1), age: 8, minor: true},
+		{name: "turns eighteen tomorrow", dateOfBirth: date(2006, 6, 16), age: 17, minor: true},
+		{name: "turns eighteen today", dateOfBirth: date(2006, 6, 15), age: 18, minor: false},
+		{name: "adult", dateOfBirth: date(1980, 12, 31), age: 43, minor: false},
+		{name: "unknown date of birth", minor: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patient := &Patient{FirstName: "Ana", DateOfBirth: tt.dateOfBirth}
+			age, known := patient.AgeAt(now)
+			if known != (tt.dateOfBirth != nil) || age != tt.age {
+				t.Errorf("AgeAt() = %d, %v, want %d", age, known, tt.age)
+			}
+			if got := patient.IsMinor(now); got != tt.minor {
+				t.Errorf("IsMinor() = %v, want %v", got, tt.minor)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentMethod is how money was received or given back
+type PaymentMethod string
+
+const (
+	PaymentMethodCash     PaymentMethod = "cash"
+	PaymentMethodCard     PaymentMethod = "card"
+	PaymentMethodTransfer PaymentMethod = "transfer"
+)
+
+// PaymentMethods lists the payment methods in the order cash-close reports show them
+var PaymentMethods = []PaymentMethod{PaymentMethodCash, PaymentMethodCard, PaymentMethodTransfer}
+
+// IsValidPaymentMethod checks if the payment method is supported
+func IsValidPaymentMethod(method PaymentMethod) bool {
+	for _, m := range PaymentMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// PaymentKind tells money received from money given back
+type PaymentKind string
+
+const (
+	PaymentKindPayment PaymentKind = "payment"
+	PaymentKindRefund  PaymentKind = "refund"
+)
+
+// Payment is an entry of an invoice's payments ledger. Amount is always positive, in minor
+// units of Currency; refunds point to the payment they give money back from. Entries are
+// never changed or deleted.
+type Payment struct {
+	ID             uuid.UUID     `json:"id" db:"id"`
+	OrganizationID uuid.UUID     `json:"organization_id" db:"organization_id"`
+	ClinicID       uuid.UUID     `json:"clinic_id" db:"clinic_id"`
+	InvoiceID      uuid.UUID     `json:"invoice_id" db:"invoice_id"`
+	PatientID      uuid.UUID     `json:"patient_id" db:"patient_id"`
+	Kind           PaymentKind   `json:"kind" db:"kind"`
+	Method         PaymentMethod `json:"method" db:"method"`
+	Amount         int64         `json:"amount" db:"amount"`
+	Currency       string        `json:"currency" db:"currency"`
+	RefundOfID     *uuid.UUID    `json:"refund_of_id,omitempty" db:"refund_of_id"`
+	Reference      *string       `json:"reference,omitempty" db:"reference"` // Card authorization, transfer tracking key...
+	Reason         *string       `json:"reason,omitempty" db:"reason"`       // Why a refund was given
+	ReceivedBy     *uuid.UUID    `json:"received_by,omitempty" db:"received_by"`
+	ReceivedAt     time.Time     `json:"received_at" db:"received_at"` // When the money changed hands; cash close groups by it
+	CreatedAt      time.Time     `json:"created_at" db:"created_at"`
+}
+
+// NewPayment creates a payment towards an invoice
+func NewPayment(invoice *Invoice, method PaymentMethod, amount int64, reference *string, receivedBy *uuid.UUID, receivedAt time.Time) (*Payment, error) {
+	if !IsValidPaymentMethod(method) {
+		return nil, ErrInvalidPaymentMethod
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidPaymentAmount
+	}
+
+	return &Payment{
+		ID:             uuid.New(),
+		OrganizationID: invoice.OrganizationID,
+		ClinicID:       invoice.ClinicID,
+		InvoiceID:      invoice.ID,
+		PatientID:      invoice.PatientID,
+		Kind:           PaymentKindPayment,
+		Method:         method,
+		Amount:         amount,
+		Currency:       invoice.Currency,
+		Reference:      reference,
+		ReceivedBy:     receivedBy,
+		ReceivedAt:     receivedAt,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// NewRefund creates a refund of part or all of a payment. refunded is what was already given
+// back from that payment.
+func NewRefund(original *Payment, method PaymentMethod, amount, refunded int64, reason *string, refundedBy *uuid.UUID, refundedAt time.Time) (*Payment, error) {
+	if !IsValidPaymentMethod(method) {
+		return nil, ErrInvalidPaymentMethod
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidPaymentAmount
+	}
+	if original.Kind != PaymentKindPayment || amount > original.Amount-refunded {
+		return nil, ErrRefundExceedsPayment
+	}
+
+	return &Payment{
+		ID:             uuid.New(),
+		OrganizationID: original.OrganizationID,
+		ClinicID:       original.ClinicID,
+		InvoiceID:      original.InvoiceID,
+		PatientID:      original.PatientID,
+		Kind:           PaymentKindRefund,
+		Method:         method,
+		Amount:         amount,
+		Currency:       original.Currency,
+		RefundOfID:     &original.ID,
+		Reason:         reason,
+		ReceivedBy:     refundedBy,
+		ReceivedAt:     refundedAt,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// IsRefund reports whether the entry gives money back
+func (p *Payment) IsRefund() bool {
+	return p.Kind == PaymentKindRefund
+}
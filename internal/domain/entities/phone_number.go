@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"strings"
+)
+
+// DefaultPhoneRegion is used for numbers that do not belong to an organization
+const DefaultPhoneRegion = "MX"
+
+// E.164 numbers have at most 15 digits; shorter than 8 is never a reachable number
+const (
+	minE164Digits = 8
+	maxE164Digits = 15
+)
+
+// phoneRegion describes how national numbers are written in a country
+type phoneRegion struct {
+	countryCode     string
+	nationalLengths []int    // Valid lengths of the national significant number
+	trunkPrefixes   []string // Dialed before national numbers and dropped in E.164
+}
+
+// phoneRegions are the regions organizations can use as their default phone region
+var phoneRegions = map[string]phoneRegion{
+	// "044"/"045" (mobile) and "01" (long distance) were retired in 2019 but are still
+	// common in stored numbers; "1" is the old mobile marker after +52
+	"MX": {countryCode: "52", nationalLengths: []int{10}, trunkPrefixes: []string{"044", "045", "01", "1"}},
+	"US": {countryCode: "1", nationalLengths: []int{10}, trunkPrefixes: []string{"1"}},
+	"CA": {countryCode: "1", nationalLengths: []int{10}, trunkPrefixes: []string{"1"}},
+	"CO": {countryCode: "57", nationalLengths: []int{10}},
+	"CL": {countryCode: "56", nationalLengths: []int{9}},
+	"PE": {countryCode: "51", nationalLengths: []int{8, 9}, trunkPrefixes: []string{"0"}},
+	"ES": {countryCode: "34", nationalLengths: []int{9}},
+	"GB": {countryCode: "44", nationalLengths: []int{10}, trunkPrefixes: []string{"0"}},
+}
+
+// PhoneNumber is a validated phone number kept in two forms: E.164 for matching and
+// messaging, and the display form as it was typed
+type PhoneNumber struct {
+	countryCode string // Empty for countries outside phoneRegions
+	national    string // National significant number; every digit after "+" when the country is unknown
+	display     string
+}
+
+// ParsePhoneNumber parses a phone number written in any common format ("55 1234 5678",
+// "(55) 1234-5678", "+52 1 55 1234 5678", "0052..."). Numbers without an international
+// prefix are read as national numbers of defaultRegion (ISO 3166-1 alpha-2).
+func ParsePhoneNumber(raw, defaultRegion string) (PhoneNumber, error) {
+	region, ok := phoneRegions[strings.ToUpper(defaultRegion)]
+	if !ok {
+		return PhoneNumber{}, ErrUnsupportedPhoneRegion
+	}
+
+	display := strings.Join(strings.Fields(raw), " ")
+	digits, international, err := phoneDigits(display)
+	if err != nil {
+		return PhoneNumber{}, err
+	}
+	if !international && strings.HasPrefix(digits, "00") {
+		digits, international = digits[2:], true
+	}
+
+	var phone PhoneNumber
+	if international {
+		phone, ok = parseInternational(digits)
+	} else {
+		phone, ok = region.parseNational(digits)
+		if !ok && strings.HasPrefix(digits, region.countryCode) {
+			// Typed with the country code but without the "+"
+			phone, ok = parseInternational(digits)
+		}
+	}
+	if !ok {
+		return PhoneNumber{}, ErrInvalidPhoneNumber
+	}
+
+	phone.display = display
+	return phone, nil
+}
+
+// IsSupportedPhoneRegion checks if region can be used as a default phone region
+func IsSupportedPhoneRegion(region string) bool {
+	_, ok := phoneRegions[region]
+	return ok
+}
+
+// E164 returns the number in E.164 format (e.g. "+525512345678")
+func (p PhoneNumber) E164() string {
+	return "+" + p.countryCode + p.national
+}
+
+// NationalNumber returns the number without its country code (e.g. "5512345678")
+func (p PhoneNumber) NationalNumber() string {
+	return p.national
+}
+
+// Display returns the number as it was typed, with whitespace collapsed
+func (p PhoneNumber) Display() string {
+	return p.display
+}
+
+// parseNational reads a national number of the region, dropping a trunk prefix when the
+// number is only valid without it
+func (r phoneRegion) parseNational(digits string) (PhoneNumber, bool) {
+	if r.validLength(digits) {
+		return PhoneNumber{countryCode: r.countryCode, national: digits}, true
+	}
+	for _, prefix := range r.trunkPrefixes {
+		if national := strings.TrimPrefix(digits, prefix); national != digits && r.validLength(national) {
+			return PhoneNumber{countryCode: r.countryCode, national: national}, true
+		}
+	}
+	return PhoneNumber{}, false
+}
+
+func (r phoneRegion) validLength(national string) bool {
+	for _, length := range r.nationalLengths {
+		if len(national) == length {
+			return true
+		}
+	}
+	return false
+}
+
+// parseInternational reads digits that start with a country code. Numbers of known
+// regions are validated; for other countries only the E.164 length can be checked.
+func parseInternational(digits string) (PhoneNumber, bool) {
+	for _, region := range phoneRegions {
+		if strings.HasPrefix(digits, region.countryCode) {
+			return region.parseNational(digits[len(region.countryCode):])
+		}
+	}
+	if len(digits) < minE164Digits || len(digits) > maxE164Digits {
+		return PhoneNumber{}, false
+	}
+	return PhoneNumber{national: digits}, true
+}
+
+// phoneDigits extracts the digits of a phone number, reporting whether it starts with "+".
+// Only digits and the usual separators are accepted.
+func phoneDigits(phone string) (string, bool, error) {
+	var digits strings.Builder
+	international := false
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case strings.ContainsRune(" -()./", r):
+		default:
+			return "", false, ErrInvalidPhoneNumber
+		}
+	}
+	return digits.String(), international, nil
+}
+
+// normalizePhoneFields parses an optional phone, returning its display and E.164 forms.
+// A blank phone clears both.
+func normalizePhoneFields(phone *string, region string) (*string, *string, error) {
+	if isBlank(phone) {
+		return nil, nil, nil
+	}
+
+	parsed, err := ParsePhoneNumber(*phone, region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	display, e164 := parsed.Display(), parsed.E164()
+	return &display, &e164, nil
+}
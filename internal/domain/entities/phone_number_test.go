@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		region  string
+		e164    string
+		display string
+		err     error
+	}{
+		{name: "national number", raw: "55 1234 5678", region: "MX", e164: "+525512345678", display: "55 1234 5678"},
+		{name: "punctuation", raw: "(55) 1234-5678", region: "MX", e164: "+525512345678", display: "(55) 1234-5678"},
+		{name: "international", raw: "+52 55 1234 5678", region: "MX", e164: "+525512345678", display: "+52 55 1234 5678"},
+		{name: "legacy mobile marker", raw: "+52 1 55 1234 5678", region: "MX", e164: "+525512345678", display: "+52 1 55 1234 5678"},
+		{name: "legacy mobile prefix", raw: "044 55 1234 5678", region: "MX", e164: "+525512345678", display: "044 55 1234 5678"},
+		{name: "legacy long distance prefix", raw: "01 33 1234 5678", region: "MX", e164: "+523312345678", display: "01 33 1234 5678"},
+		{name: "country code without plus", raw: "525512345678", region: "MX", e164: "+525512345678", display: "525512345678"},
+		{name: "00 international prefix", raw: "0034 612 345 678", region: "MX", e164: "+34612345678", display: "0034 612 345 678"},
+		{name: "whitespace collapsed", raw: "  55  1234\t5678 ", region: "MX", e164: "+525512345678", display: "55 1234 5678"},
+		{name: "US trunk prefix", raw: "1 (415) 555-2671", region: "US", e164: "+14155552671", display: "1 (415) 555-2671"},
+		{name: "GB trunk prefix", raw: "020 7946 0018", region: "GB", e164: "+442079460018", display: "020 7946 0018"},
+		{name: "lowercase region", raw: "5512345678", region: "mx", e164: "+525512345678", display: "5512345678"},
+		{name: "unknown country code", raw: "+49 30 901820", region: "MX", e164: "+4930901820", display: "+49 30 901820"},
+		{name: "too short", raw: "1234", region: "MX", err: ErrInvalidPhoneNumber},
+		{name: "wrong length for region", raw: "55 1234 567", region: "MX", err: ErrInvalidPhoneNumber},
+		{name: "wrong length for known country code", raw: "+52 55 1234", region: "US", err: ErrInvalidPhoneNumber},
+		{name: "letters", raw: "55 1234 5678 ext 2", region: "MX", err: ErrInvalidPhoneNumber},
+		{name: "plus in the middle", raw: "55+1234 5678", region: "MX", err: ErrInvalidPhoneNumber},
+		{name: "unsupported region", raw: "5512345678", region: "ZZ", err: ErrUnsupportedPhoneRegion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone, err := ParsePhoneNumber(tt.raw, tt.region)
+			if err != tt.err {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && phone.E164() != tt.e164 {
+				t.Errorf("E164() = %q, want %q", phone.E164(), tt.e164)
+			}
+			if phone.Display() != tt.display {
+				t.Errorf("Display() = %q, want %q", phone.Display(), tt.display)
+			}
+			if tt.err == nil && !strings.HasSuffix(phone.E164(), phone.NationalNumber()) {
+				t.Errorf("NationalNumber() = %q is not the end of %q", phone.NationalNumber(), phone.E164())
+			}
+		})
+	}
+}
+
+func TestPatientNormalizePhone(t *testing.T) {
+	phone := " 55-1234-5678 "
+	patient := &Patient{FirstName: "Ana", Phone: &phone}
+
+	if err := patient.NormalizePhone("MX"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *patient.Phone != "55-1234-5678" || *patient.PhoneE164 != "+525512345678" {
+		t.Errorf("phone = %q / %q", *patient.Phone, *patient.PhoneE164)
+	}
+
+	blank := "  "
+	patient.Phone = &blank
+	if err := patient.NormalizePhone("MX"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patient.Phone != nil || patient.PhoneE164 != nil {
+		t.Error("expected blank phone to clear both forms")
+	}
+}
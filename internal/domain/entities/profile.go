@@ -26,6 +26,8 @@ type Profile struct {
 	FullName       *string        `json:"full_name,omitempty" db:"full_name"`
 	Roles          pq.StringArray `json:"roles" db:"roles"`
 	AvatarURL      *string        `json:"avatar_url,omitempty" db:"avatar_url"`
+	IsActive       bool           `json:"is_active" db:"is_active"`
+	DeactivatedAt  *time.Time     `json:"deactivated_at,omitempty" db:"deactivated_at"`
 	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
 }
@@ -37,6 +39,7 @@ func NewProfile(id uuid.UUID, email string) *Profile {
 		ID:        id,
 		Email:     email,
 		Roles:     pq.StringArray{string(RoleReceptionist)},
+		IsActive:  true,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -112,6 +115,47 @@ func (p *Profile) UpdateProfile(fullName, avatarURL *string) {
 	p.UpdatedAt = time.Now()
 }
 
+// SetRoles replaces the profile roles
+func (p *Profile) SetRoles(roles []Role) {
+	p.Roles = make(pq.StringArray, 0, len(roles))
+	for _, role := range roles {
+		if !p.HasRole(role) {
+			p.Roles = append(p.Roles, string(role))
+		}
+	}
+	p.UpdatedAt = time.Now()
+}
+
+// Deactivate blocks the profile from accessing the organization
+func (p *Profile) Deactivate() {
+	now := time.Now()
+	p.IsActive = false
+	p.DeactivatedAt = &now
+	p.UpdatedAt = now
+}
+
+// Activate restores access for a previously deactivated profile
+func (p *Profile) Activate() {
+	p.IsActive = true
+	p.DeactivatedAt = nil
+	p.UpdatedAt = time.Now()
+}
+
+// BelongsToOrganization checks if the profile is linked to the given organization
+func (p *Profile) BelongsToOrganization(organizationID uuid.UUID) bool {
+	return p.OrganizationID != nil && *p.OrganizationID == organizationID
+}
+
+// IsStaffRole checks if the role can be granted to organization staff
+func IsStaffRole(role Role) bool {
+	switch role {
+	case RoleAdmin, RoleDoctor, RoleReceptionist:
+		return true
+	default:
+		return false
+	}
+}
+
 // UserProfile represents user profile information with organization details
 type UserProfile struct {
 	Profile      *Profile      `json:"profile"`
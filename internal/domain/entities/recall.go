@@ -0,0 +1,294 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecallRule brings patients back after a service: completing an appointment for ServiceID
+// schedules a recall IntervalMonths later, closed when the patient books the service again or
+// one of the QualifyingServiceIDs.
+type RecallRule struct {
+	ID                   uuid.UUID `json:"id" db:"id"`
+	OrganizationID       uuid.UUID `json:"organization_id" db:"organization_id"`
+	ServiceID            string    `json:"service_id" db:"service_id"`
+	IntervalMonths       int       `json:"interval_months" db:"interval_months"`
+	QualifyingServiceIDs []string  `json:"qualifying_service_ids" db:"qualifying_service_ids"`
+	Active               bool      `json:"active" db:"active"` // Inactive rules schedule no new recalls; open ones stay on the worklist
+	CreatedAt            time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewRecallRule creates an active recall rule for a service
+func NewRecallRule(organizationID uuid.UUID, serviceID string, intervalMonths int, qualifyingServiceIDs []string) (*RecallRule, error) {
+	now := time.Now()
+	rule := &RecallRule{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		ServiceID:      strings.TrimSpace(serviceID),
+		IntervalMonths: intervalMonths,
+		Active:         true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	rule.SetQualifyingServices(qualifyingServiceIDs)
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Validate validates the recall rule fields
+func (r *RecallRule) Validate() error {
+	if r.ServiceID == "" {
+		return ErrRecallServiceRequired
+	}
+	if r.IntervalMonths < 1 || r.IntervalMonths > 60 {
+		return ErrInvalidRecallInterval
+	}
+	return nil
+}
+
+// SetQualifyingServices replaces the other services whose booking closes the rule's recalls,
+// dropping blanks, duplicates and the rule's own service, which always qualifies
+func (r *RecallRule) SetQualifyingServices(serviceIDs []string) {
+	r.QualifyingServiceIDs = []string{}
+	seen := map[string]bool{r.ServiceID: true}
+	for _, serviceID := range serviceIDs {
+		serviceID = strings.TrimSpace(serviceID)
+		if serviceID != "" && !seen[serviceID] {
+			seen[serviceID] = true
+			r.QualifyingServiceIDs = append(r.QualifyingServiceIDs, serviceID)
+		}
+	}
+}
+
+// Qualifies reports whether booking the service closes the rule's recalls
+func (r *RecallRule) Qualifies(serviceID string) bool {
+	if serviceID == r.ServiceID {
+		return true
+	}
+	for _, qualifying := range r.QualifyingServiceIDs {
+		if qualifying == serviceID {
+			return true
+		}
+	}
+	return false
+}
+
+// DueDate returns when a patient seen on serviceDate is due back. Days past the end of a
+// shorter month are moved to its last day, so a visit on August 31 is recalled on February 28.
+func (r *RecallRule) DueDate(serviceDate time.Time) time.Time {
+	year, month, day := serviceDate.Date()
+	first := time.Date(year, month+time.Month(r.IntervalMonths), 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// RecallStatus represents where a recall is in bringing the patient back
+type RecallStatus string
+
+const (
+	RecallStatusOpen      RecallStatus = "open"      // On the worklist until the patient books
+	RecallStatusBooked    RecallStatus = "booked"    // Closed by BookedAppointmentID
+	RecallStatusDismissed RecallStatus = "dismissed" // Closed by staff, e.g. the patient moved away
+)
+
+// IsValidRecallStatus checks if the recall status is supported
+func IsValidRecallStatus(status RecallStatus) bool {
+	switch status {
+	case RecallStatusOpen, RecallStatusBooked, RecallStatusDismissed:
+		return true
+	default:
+		return false
+	}
+}
+
+// RecallOutreachStatus represents the outcome of the latest attempt to contact a recalled patient
+type RecallOutreachStatus string
+
+const (
+	RecallOutreachNotContacted RecallOutreachStatus = "not_contacted"
+	RecallOutreachContacted    RecallOutreachStatus = "contacted" // Reached the patient, who has yet to book
+	RecallOutreachLeftMessage  RecallOutreachStatus = "left_message"
+	RecallOutreachNoAnswer     RecallOutreachStatus = "no_answer"
+	RecallOutreachDeclined     RecallOutreachStatus = "declined" // The patient does not want to come back for now
+)
+
+// IsValidRecallOutreachStatus checks if the outreach status is supported
+func IsValidRecallOutreachStatus(status RecallOutreachStatus) bool {
+	switch status {
+	case RecallOutreachNotContacted, RecallOutreachContacted, RecallOutreachLeftMessage, RecallOutreachNoAnswer, RecallOutreachDeclined:
+		return true
+	default:
+		return false
+	}
+}
+
+// RecallOutreachChannel represents how a recalled patient was contacted
+type RecallOutreachChannel string
+
+const (
+	RecallOutreachPhone    RecallOutreachChannel = "phone"
+	RecallOutreachSMS      RecallOutreachChannel = "sms"
+	RecallOutreachEmail    RecallOutreachChannel = "email"
+	RecallOutreachWhatsApp RecallOutreachChannel = "whatsapp"
+	RecallOutreachInPerson RecallOutreachChannel = "in_person"
+)
+
+// IsValidRecallOutreachChannel checks if the outreach channel is supported
+func IsValidRecallOutreachChannel(channel RecallOutreachChannel) bool {
+	switch channel {
+	case RecallOutreachPhone, RecallOutreachSMS, RecallOutreachEmail, RecallOutreachWhatsApp, RecallOutreachInPerson:
+		return true
+	default:
+		return false
+	}
+}
+
+// Recall is a patient due back for a service on DueDate under a recall rule
+type Recall struct {
+	ID                  uuid.UUID            `json:"id" db:"id"`
+	OrganizationID      uuid.UUID            `json:"organization_id" db:"organization_id"`
+	ClinicID            *uuid.UUID           `json:"clinic_id,omitempty" db:"clinic_id"` // Clinic of the source appointment, nil when it had no unit
+	PatientID           uuid.UUID            `json:"patient_id" db:"patient_id"`
+	RuleID              uuid.UUID            `json:"rule_id" db:"rule_id"`
+	ServiceID           string               `json:"service_id" db:"service_id"`
+	SourceAppointmentID *uuid.UUID           `json:"source_appointment_id,omitempty" db:"source_appointment_id"`
+	DueDate             time.Time            `json:"due_date" db:"due_date"`
+	Status              RecallStatus         `json:"status" db:"status"`
+	OutreachStatus      RecallOutreachStatus `json:"outreach_status" db:"outreach_status"`
+	OutreachAttempts    int                  `json:"outreach_attempts" db:"outreach_attempts"`
+	LastContactedAt     *time.Time           `json:"last_contacted_at,omitempty" db:"last_contacted_at"`
+	BookedAppointmentID *uuid.UUID           `json:"booked_appointment_id,omitempty" db:"booked_appointment_id"`
+	DismissReason       *string              `json:"dismiss_reason,omitempty" db:"dismiss_reason"`
+	ClosedBy            *uuid.UUID           `json:"closed_by,omitempty" db:"closed_by"`
+	ClosedAt            *time.Time           `json:"closed_at,omitempty" db:"closed_at"`
+	CreatedAt           time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// NewRecall creates an open recall of the patient seen on serviceDate in the source appointment
+func NewRecall(rule *RecallRule, clinicID *uuid.UUID, patientID uuid.UUID, sourceAppointmentID *uuid.UUID, serviceDate time.Time) *Recall {
+	now := time.Now()
+	return &Recall{
+		ID:                  uuid.New(),
+		OrganizationID:      rule.OrganizationID,
+		ClinicID:            clinicID,
+		PatientID:           patientID,
+		RuleID:              rule.ID,
+		ServiceID:           rule.ServiceID,
+		SourceAppointmentID: sourceAppointmentID,
+		DueDate:             rule.DueDate(serviceDate),
+		Status:              RecallStatusOpen,
+		OutreachStatus:      RecallOutreachNotContacted,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+}
+
+// IsOpen reports whether the recall is still on the worklist
+func (r *Recall) IsOpen() bool {
+	return r.Status == RecallStatusOpen
+}
+
+// Book closes an open recall with the appointment the patient booked
+func (r *Recall) Book(appointmentID uuid.UUID, now time.Time) error {
+	if !r.IsOpen() {
+		return ErrRecallNotOpen
+	}
+
+	r.Status = RecallStatusBooked
+	r.BookedAppointmentID = &appointmentID
+	r.ClosedBy = nil
+	r.ClosedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Reopen puts a recall closed by a booking back on the worklist, after that appointment was
+// cancelled
+func (r *Recall) Reopen(now time.Time) error {
+	if r.Status != RecallStatusBooked {
+		return ErrRecallNotBooked
+	}
+
+	r.Status = RecallStatusOpen
+	r.BookedAppointmentID = nil
+	r.ClosedAt = nil
+	r.UpdatedAt = now
+	return nil
+}
+
+// Dismiss closes an open recall that staff will not follow up on
+func (r *Recall) Dismiss(reason string, dismissedBy *uuid.UUID, now time.Time) error {
+	if !r.IsOpen() {
+		return ErrRecallNotOpen
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrRecallDismissReasonRequired
+	}
+
+	r.Status = RecallStatusDismissed
+	r.DismissReason = &reason
+	r.ClosedBy = dismissedBy
+	r.ClosedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// RecallOutreach is an attempt to contact a recalled patient
+type RecallOutreach struct {
+	ID          uuid.UUID             `json:"id" db:"id"`
+	RecallID    uuid.UUID             `json:"recall_id" db:"recall_id"`
+	Channel     RecallOutreachChannel `json:"channel" db:"channel"`
+	Outcome     RecallOutreachStatus  `json:"outcome" db:"outcome"`
+	Notes       *string               `json:"notes,omitempty" db:"notes"`
+	ContactedBy *uuid.UUID            `json:"contacted_by,omitempty" db:"contacted_by"`
+	ContactedAt time.Time             `json:"contacted_at" db:"contacted_at"`
+	CreatedAt   time.Time             `json:"created_at" db:"created_at"`
+}
+
+// RecordOutreach logs an attempt to contact the patient of an open recall, whose outreach status
+// becomes the attempt's outcome
+func (r *Recall) RecordOutreach(channel RecallOutreachChannel, outcome RecallOutreachStatus, notes *string, contactedBy *uuid.UUID, contactedAt, now time.Time) (*RecallOutreach, error) {
+	if !r.IsOpen() {
+		return nil, ErrRecallNotOpen
+	}
+	if !IsValidRecallOutreachChannel(channel) {
+		return nil, ErrInvalidRecallOutreachChannel
+	}
+	if outcome == RecallOutreachNotContacted || !IsValidRecallOutreachStatus(outcome) {
+		return nil, ErrInvalidRecallOutreachOutcome
+	}
+	if contactedAt.IsZero() {
+		contactedAt = now
+	}
+	if contactedAt.After(now) {
+		return nil, ErrInvalidRecallOutreachTime
+	}
+
+	r.OutreachAttempts++
+	if r.LastContactedAt == nil || !contactedAt.Before(*r.LastContactedAt) {
+		r.OutreachStatus = outcome
+		r.LastContactedAt = &contactedAt
+	}
+	r.UpdatedAt = now
+
+	return &RecallOutreach{
+		ID:          uuid.New(),
+		RecallID:    r.ID,
+		Channel:     channel,
+		Outcome:     outcome,
+		Notes:       notes,
+		ContactedBy: contactedBy,
+		ContactedAt: contactedAt,
+		CreatedAt:   now,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRecallRule(t *testing.T) {
+	rule, err := NewRecallRule(uuid.New(), " srv_cleaning ", 6, []string{"srv_exam", " ", "srv_cleaning", "srv_exam"})
+	if err != nil {
+		t.Fatalf("NewRecallRule() error = %v", err)
+	}
+	if rule.ServiceID != "srv_cleaning" {
+		t.Errorf("ServiceID = %q, want srv_cleaning", rule.ServiceID)
+	}
+	if len(rule.QualifyingServiceIDs) != 1 || rule.QualifyingServiceIDs[0] != "srv_exam" {
+		t.Errorf("QualifyingServiceIDs = %v, want [srv_exam]", rule.QualifyingServiceIDs)
+	}
+	if !rule.Qualifies("srv_cleaning") || !rule.Qualifies("srv_exam") || rule.Qualifies("srv_implant") {
+		t.Errorf("Qualifies() does not match the rule's own and qualifying services")
+	}
+
+	if _, err := NewRecallRule(uuid.New(), "srv_cleaning", 0, nil); err != ErrInvalidRecallInterval {
+		t.Errorf("NewRecallRule() with no interval error = %v, want %v", err, ErrInvalidRecallInterval)
+	}
+	if _, err := NewRecallRule(uuid.New(), " ", 6, nil); err != ErrRecallServiceRequired {
+		t.Errorf("NewRecallRule() without service error = %v, want %v", err, ErrRecallServiceRequired)
+	}
+}
+
+func TestRecallRuleDueDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		months      int
+		serviceDate time.Time
+		want        time.Time
+	}{
+		{name: "six months", months: 6, serviceDate: time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), want: time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "into next year", months: 6, serviceDate: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "end of a longer month", months: 6, serviceDate: time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC), want: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{name: "a year later", months: 12, serviceDate: time.Date(2027, 2, 28, 0, 0, 0, 0, time.UTC), want: time.Date(2028, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{name: "into a leap february", months: 6, serviceDate: time.Date(2027, 8, 31, 0, 0, 0, 0, time.UTC), want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &RecallRule{IntervalMonths: tt.months}
+			if got := rule.DueDate(tt.serviceDate); !got.Equal(tt.want) {
+				t.Errorf("DueDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecallLifecycle(t *testing.T) {
+	now := time.Now()
+	rule := &RecallRule{ID: uuid.New(), OrganizationID: uuid.New(), ServiceID: "srv_cleaning", IntervalMonths: 6}
+	recall := NewRecall(rule, nil, uuid.New(), nil, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC))
+	if !recall.IsOpen() || recall.OutreachStatus != RecallOutreachNotContacted {
+		t.Fatalf("NewRecall() status = %s/%s, want open/not_contacted", recall.Status, recall.OutreachStatus)
+	}
+
+	appointmentID := uuid.New()
+	if err := recall.Book(appointmentID, now); err != nil {
+		t.Fatalf("Book() error = %v", err)
+	}
+	if recall.Status != RecallStatusBooked || recall.BookedAppointmentID == nil || *recall.BookedAppointmentID != appointmentID {
+		t.Errorf("Book() did not close the recall with the appointment")
+	}
+	if err := recall.Book(uuid.New(), now); err != ErrRecallNotOpen {
+		t.Errorf("Book() of a booked recall error = %v, want %v", err, ErrRecallNotOpen)
+	}
+	if err := recall.Dismiss("moved away", nil, now); err != ErrRecallNotOpen {
+		t.Errorf("Dismiss() of a booked recall error = %v, want %v", err, ErrRecallNotOpen)
+	}
+
+	if err := recall.Reopen(now); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	if !recall.IsOpen() || recall.BookedAppointmentID != nil || recall.ClosedAt != nil {
+		t.Errorf("Reopen() did not put the recall back on the worklist")
+	}
+	if err := recall.Reopen(now); err != ErrRecallNotBooked {
+		t.Errorf("Reopen() of an open recall error = %v, want %v", err, ErrRecallNotBooked)
+	}
+
+	if err := recall.Dismiss(" ", nil, now); err != ErrRecallDismissReasonRequired {
+		t.Errorf("Dismiss() without reason error = %v, want %v", err, ErrRecallDismissReasonRequired)
+	}
+	staff := uuid.New()
+	if err := recall.Dismiss(" Moved away ", &staff, now); err != nil {
+		t.Fatalf("Dismiss() error = %v", err)
+	}
+	if recall.Status != RecallStatusDismissed || *recall.DismissReason != "Moved away" || recall.ClosedBy == nil {
+		t.Errorf("Dismiss() did not close the recall with its reason")
+	}
+	if err := recall.Reopen(now); err != ErrRecallNotBooked {
+		t.Errorf("Reopen() of a dismissed recall error = %v, want %v", err, ErrRecallNotBooked)
+	}
+}
+
+func TestRecallRecordOutreach(t *testing.T) {
+	now := time.Now()
+	rule := &RecallRule{ID: uuid.New(), OrganizationID: uuid.New(), ServiceID: "srv_cleaning", IntervalMonths: 6}
+	recall := NewRecall(rule, nil, uuid.New(), nil, now)
+
+	outreach, err := recall.RecordOutreach(RecallOutreachPhone, RecallOutreachNoAnswer, nil, nil, time.Time{}, now)
+	if err != nil {
+		t.Fatalf("RecordOutreach() error = %v", err)
+	}
+	if !outreach.ContactedAt.Equal(now) || outreach.RecallID != recall.ID {
+		t.Errorf("RecordOutreach() attempt = %+v, want one contacted now for the recall", outreach)
+	}
+	if recall.OutreachStatus != RecallOutreachNoAnswer || recall.OutreachAttempts != 1 {
+		t.Errorf("RecordOutreach() recall = %s after %d attempts, want no_answer after 1", recall.OutreachStatus, recall.OutreachAttempts)
+	}
+
+	// An attempt logged late does not replace the outcome of a more recent one
+	if _, err := recall.RecordOutreach(RecallOutreachSMS, RecallOutreachLeftMessage, nil, nil, now.Add(-time.Hour), now); err != nil {
+		t.Fatalf("RecordOutreach() error = %v", err)
+	}
+	if recall.OutreachStatus != RecallOutreachNoAnswer || recall.OutreachAttempts != 2 || !recall.LastContactedAt.Equal(now) {
+		t.Errorf("RecordOutreach() of an earlier attempt changed the latest outcome to %s", recall.OutreachStatus)
+	}
+
+	tests := []struct {
+		name        string
+		channel     RecallOutreachChannel
+		outcome     RecallOutreachStatus
+		contactedAt time.Time
+		err         error
+	}{
+		{name: "unknown channel", channel: "fax", outcome: RecallOutreachContacted, err: ErrInvalidRecallOutreachChannel},
+		{name: "not contacted", channel: RecallOutreachPhone, outcome: RecallOutreachNotContacted, err: ErrInvalidRecallOutreachOutcome},
+		{name: "in the future", channel: RecallOutreachPhone, outcome: RecallOutreachContacted, contactedAt: now.Add(time.Hour), err: ErrInvalidRecallOutreachTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := recall.RecordOutreach(tt.channel, tt.outcome, nil, nil, tt.contactedAt, now); err != tt.err {
+				t.Errorf("RecordOutreach() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	if err := recall.Book(uuid.New(), now); err != nil {
+		t.Fatalf("Book() error = %v", err)
+	}
+	if _, err := recall.RecordOutreach(RecallOutreachPhone, RecallOutreachContacted, nil, nil, now, now); err != ErrRecallNotOpen {
+		t.Errorf("RecordOutreach() of a booked recall error = %v, want %v", err, ErrRecallNotOpen)
+	}
+}
@@ -11,6 +11,10 @@ type Service struct {
 	ID             string
 	Name           string
 	BasePrice      *float64
+	Category       ServiceCategory // How insurance plans cover the service
+	DepositType    *DepositType    // Deposit required to book the service, nil when none
+	DepositAmount  int64           // Fixed deposit in minor units
+	DepositPercent int             // Deposit as a percentage of the base price
 	OrganizationID uuid.UUID
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
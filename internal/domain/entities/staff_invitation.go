@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// InvitationStatus represents the status of a staff invitation
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+// StaffInvitation represents an invitation for a user to join an organization
+type StaffInvitation struct {
+	ID             uuid.UUID        `json:"id" db:"id"`
+	OrganizationID uuid.UUID        `json:"organization_id" db:"organization_id"`
+	Email          string           `json:"email" db:"email"`
+	Roles          pq.StringArray   `json:"roles" db:"roles"`
+	DoctorID       *uuid.UUID       `json:"doctor_id,omitempty" db:"doctor_id"`   // Doctor record linked on acceptance
+	InvitedBy      *uuid.UUID       `json:"invited_by,omitempty" db:"invited_by"` // Profile that sent the invitation
+	Status         InvitationStatus `json:"status" db:"status"`
+	ExpiresAt      time.Time        `json:"expires_at" db:"expires_at"`
+	AcceptedBy     *uuid.UUID       `json:"accepted_by,omitempty" db:"accepted_by"`
+	AcceptedAt     *time.Time       `json:"accepted_at,omitempty" db:"accepted_at"`
+	RevokedAt      *time.Time       `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// NewStaffInvitation creates a new pending invitation that expires after ttl
+func NewStaffInvitation(organizationID uuid.UUID, email string, roles []Role, invitedBy *uuid.UUID, ttl time.Duration) *StaffInvitation {
+	now := time.Now()
+	roleNames := make(pq.StringArray, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, string(role))
+	}
+
+	return &StaffInvitation{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Email:          strings.ToLower(strings.TrimSpace(email)),
+		Roles:          roleNames,
+		InvitedBy:      invitedBy,
+		Status:         InvitationStatusPending,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Validate checks if the invitation entity is valid
+func (i *StaffInvitation) Validate() error {
+	if i.OrganizationID == uuid.Nil {
+		return ErrInvalidOrganizationID
+	}
+
+	if !isValidEmail(i.Email) {
+		return ErrInvalidEmail
+	}
+
+	if len(i.Roles) == 0 {
+		return ErrInvalidRoles
+	}
+
+	for _, role := range i.Roles {
+		if !IsStaffRole(Role(role)) {
+			return ErrInvalidStaffRole
+		}
+	}
+
+	return nil
+}
+
+// StaffRoles returns the invitation roles as Role values
+func (i *StaffInvitation) StaffRoles() []Role {
+	roles := make([]Role, 0, len(i.Roles))
+	for _, role := range i.Roles {
+		roles = append(roles, Role(role))
+	}
+	return roles
+}
+
+// IsExpired checks if the invitation can no longer be accepted because of its age
+func (i *StaffInvitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// IsPending checks if the invitation is waiting to be accepted
+func (i *StaffInvitation) IsPending() bool {
+	return i.Status == InvitationStatusPending
+}
+
+// CanBeAccepted returns an error describing why the invitation cannot be accepted
+func (i *StaffInvitation) CanBeAccepted(now time.Time) error {
+	if !i.IsPending() {
+		return ErrInvitationNotPending
+	}
+	if i.IsExpired(now) {
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+// MatchesEmail checks if the given email is the invited one (case-insensitive)
+func (i *StaffInvitation) MatchesEmail(email string) bool {
+	return strings.EqualFold(strings.TrimSpace(email), i.Email)
+}
+
+// Accept marks the invitation as accepted by the given profile
+func (i *StaffInvitation) Accept(profileID uuid.UUID) {
+	now := time.Now()
+	i.Status = InvitationStatusAccepted
+	i.AcceptedBy = &profileID
+	i.AcceptedAt = &now
+	i.UpdatedAt = now
+}
+
+// Revoke cancels a pending invitation
+func (i *StaffInvitation) Revoke() {
+	now := time.Now()
+	i.Status = InvitationStatusRevoked
+	i.RevokedAt = &now
+	i.UpdatedAt = now
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestStaffInvitationCanBeAccepted(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		status InvitationStatus
+		expiry time.Time
+		want   error
+	}{
+		{name: "pending", status: InvitationStatusPending, expiry: now.Add(time.Hour), want: nil},
+		{name: "expired", status: InvitationStatusPending, expiry: now, want: ErrInvitationExpired},
+		{name: "accepted", status: InvitationStatusAccepted, expiry: now.Add(time.Hour), want: ErrInvitationNotPending},
+		{name: "revoked", status: InvitationStatusRevoked, expiry: now.Add(time.Hour), want: ErrInvitationNotPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitation := NewStaffInvitation(uuid.New(), "dr@example.com", []Role{RoleDoctor}, nil, time.Hour)
+			invitation.Status = tt.status
+			invitation.ExpiresAt = tt.expiry
+
+			if err := invitation.CanBeAccepted(now); err != tt.want {
+				t.Errorf("CanBeAccepted() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaffInvitationMatchesEmail(t *testing.T) {
+	invitation := NewStaffInvitation(uuid.New(), " Dr.Smith@Example.com ", []Role{RoleDoctor}, nil, time.Hour)
+
+	if invitation.Email != "dr.smith@example.com" {
+		t.Errorf("Email = %q, want dr.smith@example.com", invitation.Email)
+	}
+	for _, email := range []string{"dr.smith@example.com", "DR.SMITH@EXAMPLE.COM", " dr.smith@example.com "} {
+		if !invitation.MatchesEmail(email) {
+			t.Errorf("MatchesEmail(%q) = false, want true", email)
+		}
+	}
+	if invitation.MatchesEmail("other@example.com") {
+		t.Errorf("MatchesEmail(other@example.com) = true, want false")
+	}
+}
@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ToothNumberingSystem identifies how teeth are written in requests and responses
+type ToothNumberingSystem string
+
+const (
+	ToothNumberingFDI       ToothNumberingSystem = "fdi"       // ISO 3950 two-digit notation: 11-48 adult, 51-85 primary
+	ToothNumberingUniversal ToothNumberingSystem = "universal" // 1-32 adult, A-T primary
+)
+
+// IsValidToothNumberingSystem checks if the numbering system is supported
+func IsValidToothNumberingSystem(system ToothNumberingSystem) bool {
+	return system == ToothNumberingFDI || system == ToothNumberingUniversal
+}
+
+// Tooth is a tooth identified by its FDI number, the notation charts are stored in
+type Tooth int
+
+// ToothSurface is a surface of a tooth crown
+type ToothSurface string
+
+const (
+	SurfaceMesial   ToothSurface = "M"
+	SurfaceDistal   ToothSurface = "D"
+	SurfaceOcclusal ToothSurface = "O" // Chewing surface of premolars and molars
+	SurfaceIncisal  ToothSurface = "I" // Biting edge of incisors and canines
+	SurfaceBuccal   ToothSurface = "B" // Buccal or facial
+	SurfaceLingual  ToothSurface = "L" // Lingual or palatal
+)
+
+// ParseTooth parses a tooth written in the given numbering system and checks that it
+// exists in the adult or primary dentition
+func ParseTooth(value string, system ToothNumberingSystem) (Tooth, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+
+	switch system {
+	case ToothNumberingFDI:
+		number, err := strconv.Atoi(value)
+		if err != nil || !Tooth(number).IsValid() {
+			return 0, ErrInvalidToothNumber
+		}
+		return Tooth(number), nil
+	case ToothNumberingUniversal:
+		tooth, ok := universalTeeth[value]
+		if !ok {
+			return 0, ErrInvalidToothNumber
+		}
+		return tooth, nil
+	default:
+		return 0, ErrUnsupportedToothNumbering
+	}
+}
+
+// universalTeeth maps Universal notation to FDI numbers
+var universalTeeth = func() map[string]Tooth {
+	teeth := make(map[string]Tooth)
+	for quadrant := 1; quadrant <= 8; quadrant++ {
+		for position := 1; position <= 8; position++ {
+			if tooth := Tooth(quadrant*10 + position); tooth.IsValid() {
+				teeth[tooth.Universal()] = tooth
+			}
+		}
+	}
+	return teeth
+}()
+
+func (t Tooth) quadrant() int { return int(t) / 10 }
+func (t Tooth) position() int { return int(t) % 10 }
+
+// IsValid reports whether the tooth exists: quadrants 1-4 hold eight permanent teeth
+// each and quadrants 5-8 five primary teeth each
+func (t Tooth) IsValid() bool {
+	switch q, p := t.quadrant(), t.position(); {
+	case q >= 1 && q <= 4:
+		return p >= 1 && p <= 8
+	case q >= 5 && q <= 8:
+		return p >= 1 && p <= 5
+	}
+	return false
+}
+
+// IsPrimary reports whether the tooth belongs to the primary (deciduous) dentition
+func (t Tooth) IsPrimary() bool {
+	return t.quadrant() >= 5
+}
+
+// IsPosterior reports whether the tooth is a premolar or molar
+func (t Tooth) IsPosterior() bool {
+	return t.position() >= 4
+}
+
+// FDI returns the tooth in FDI notation (e.g. "11")
+func (t Tooth) FDI() string {
+	return strconv.Itoa(int(t))
+}
+
+// Universal returns the tooth in Universal notation: 1-32 starting at the upper right third
+// molar for adult teeth, A-T starting at the upper right second molar for primary teeth
+func (t Tooth) Universal() string {
+	p := t.position()
+	switch t.quadrant() {
+	case 1:
+		return strconv.Itoa(9 - p)
+	case 2:
+		return strconv.Itoa(8 + p)
+	case 3:
+		return strconv.Itoa(25 - p)
+	case 4:
+		return strconv.Itoa(24 + p)
+	case 5:
+		return string(rune('A' + 5 - p))
+	case 6:
+		return string(rune('E' + p))
+	case 7:
+		return string(rune('K' + 5 - p))
+	case 8:
+		return string(rune('O' + p))
+	}
+	return ""
+}
+
+// Format returns the tooth in the given numbering system
+func (t Tooth) Format(system ToothNumberingSystem) string {
+	if system == ToothNumberingUniversal {
+		return t.Universal()
+	}
+	return t.FDI()
+}
+
+// HasSurface reports whether the surface exists on the tooth: posterior teeth have an
+// occlusal surface and anterior teeth an incisal edge
+func (t Tooth) HasSurface(surface ToothSurface) bool {
+	switch surface {
+	case SurfaceMesial, SurfaceDistal, SurfaceBuccal, SurfaceLingual:
+		return true
+	case SurfaceOcclusal:
+		return t.IsPosterior()
+	case SurfaceIncisal:
+		return !t.IsPosterior()
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package entities
+
+import "testing"
+
+func TestParseTooth(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		system ToothNumberingSystem
+		want   Tooth
+		err    error
+	}{
+		{name: "FDI adult", value: "36", system: ToothNumberingFDI, want: 36},
+		{name: "FDI primary", value: "75", system: ToothNumberingFDI, want: 75},
+		{name: "FDI adult quadrant has no ninth tooth", value: "19", system: ToothNumberingFDI, err: ErrInvalidToothNumber},
+		{name: "FDI primary quadrant has five teeth", value: "56", system: ToothNumberingFDI, err: ErrInvalidToothNumber},
+		{name: "FDI not a number", value: "1a", system: ToothNumberingFDI, err: ErrInvalidToothNumber},
+		{name: "Universal upper right third molar", value: "1", system: ToothNumberingUniversal, want: 18},
+		{name: "Universal upper left central incisor", value: "9", system: ToothNumberingUniversal, want: 21},
+		{name: "Universal lower left first molar", value: "19", system: ToothNumberingUniversal, want: 36},
+		{name: "Universal lower right third molar", value: "32", system: ToothNumberingUniversal, want: 48},
+		{name: "Universal primary is case-insensitive", value: "a", system: ToothNumberingUniversal, want: 55},
+		{name: "Universal primary lower left", value: "K", system: ToothNumberingUniversal, want: 75},
+		{name: "Universal primary lower right", value: "T", system: ToothNumberingUniversal, want: 85},
+		{name: "Universal out of range", value: "33", system: ToothNumberingUniversal, err: ErrInvalidToothNumber},
+		{name: "Universal unknown letter", value: "U", system: ToothNumberingUniversal, err: ErrInvalidToothNumber},
+		{name: "unknown numbering", value: "11", system: "palmer", err: ErrUnsupportedToothNumbering},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTooth(tt.value, tt.system)
+			if err != tt.err {
+				t.Fatalf("ParseTooth(%q, %q) error = %v, want %v", tt.value, tt.system, err, tt.err)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("ParseTooth(%q, %q) = %d, want %d", tt.value, tt.system, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToothUniversalRoundTrip(t *testing.T) {
+	if len(universalTeeth) != 52 {
+		t.Fatalf("expected 32 adult and 20 primary teeth, got %d", len(universalTeeth))
+	}
+	for universal, tooth := range universalTeeth {
+		if tooth.Universal() != universal {
+			t.Errorf("tooth %d formats as %q, want %q", tooth, tooth.Universal(), universal)
+		}
+	}
+}
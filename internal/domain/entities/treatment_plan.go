@@ -0,0 +1,307 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TreatmentPlanStatus represents the lifecycle of a treatment plan
+type TreatmentPlanStatus string
+
+const (
+	TreatmentPlanStatusDraft     TreatmentPlanStatus = "draft"     // Being prepared by the dentist
+	TreatmentPlanStatusPresented TreatmentPlanStatus = "presented" // Quoted to the patient, awaiting a decision
+	TreatmentPlanStatusAccepted  TreatmentPlanStatus = "accepted"  // Patient accepted at least one procedure
+	TreatmentPlanStatusDeclined  TreatmentPlanStatus = "declined"  // Patient declined every procedure
+	TreatmentPlanStatusCompleted TreatmentPlanStatus = "completed" // Every accepted procedure was performed
+	TreatmentPlanStatusCancelled TreatmentPlanStatus = "cancelled"
+)
+
+// TreatmentPlanItemStatus represents the state of a planned procedure
+type TreatmentPlanItemStatus string
+
+const (
+	TreatmentPlanItemStatusProposed  TreatmentPlanItemStatus = "proposed"
+	TreatmentPlanItemStatusAccepted  TreatmentPlanItemStatus = "accepted"
+	TreatmentPlanItemStatusDeclined  TreatmentPlanItemStatus = "declined"
+	TreatmentPlanItemStatusScheduled TreatmentPlanItemStatus = "scheduled" // Linked to an upcoming appointment
+	TreatmentPlanItemStatusCompleted TreatmentPlanItemStatus = "completed"
+)
+
+// TreatmentPlan is a quote of procedures for a patient, performed over one or more
+// visits and grouped in phases (e.g. root canal, then post, then crown).
+type TreatmentPlan struct {
+	ID             uuid.UUID            `json:"id" db:"id"`
+	OrganizationID uuid.UUID            `json:"organization_id" db:"organization_id"`
+	PatientID      uuid.UUID            `json:"patient_id" db:"patient_id"`
+	DoctorID       *uuid.UUID           `json:"doctor_id,omitempty" db:"doctor_id"` // Proposing doctor
+	Title          string               `json:"title" db:"title"`
+	Notes          *string              `json:"notes,omitempty" db:"notes"`
+	Status         TreatmentPlanStatus  `json:"status" db:"status"`
+	PresentedAt    *time.Time           `json:"presented_at,omitempty" db:"presented_at"`
+	DecidedAt      *time.Time           `json:"decided_at,omitempty" db:"decided_at"`   // When the patient's decision was recorded
+	DecisionBy     *uuid.UUID           `json:"decision_by,omitempty" db:"decision_by"` // Staff member who recorded it
+	CompletedAt    *time.Time           `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedBy      *uuid.UUID           `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" db:"updated_at"`
+	Items          []*TreatmentPlanItem `json:"items"` // Ordered by phase, then position
+}
+
+// TreatmentPlanItem is a planned procedure. EstimatedCost is in minor currency units.
+type TreatmentPlanItem struct {
+	ID            uuid.UUID               `json:"id" db:"id"`
+	PlanID        uuid.UUID               `json:"plan_id" db:"plan_id"`
+	Phase         int                     `json:"phase" db:"phase"`
+	Position      int                     `json:"position" db:"position"`
+	ServiceID     string                  `json:"service_id" db:"service_id"`
+	Tooth         *Tooth                  `json:"tooth,omitempty" db:"tooth"`
+	Description   *string                 `json:"description,omitempty" db:"description"`
+	EstimatedCost int64                   `json:"estimated_cost" db:"estimated_cost"`
+	Status        TreatmentPlanItemStatus `json:"status" db:"status"`
+	AppointmentID *uuid.UUID              `json:"appointment_id,omitempty" db:"appointment_id"`
+	CompletedAt   *time.Time              `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt     time.Time               `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time               `json:"updated_at" db:"updated_at"`
+}
+
+// NewTreatmentPlan creates a draft plan for a patient
+func NewTreatmentPlan(organizationID, patientID uuid.UUID, title string, notes *string) (*TreatmentPlan, error) {
+	now := time.Now()
+	plan := &TreatmentPlan{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		PatientID:      patientID,
+		Title:          strings.TrimSpace(title),
+		Notes:          notes,
+		Status:         TreatmentPlanStatusDraft,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// Validate validates the plan fields
+func (p *TreatmentPlan) Validate() error {
+	if p.Title == "" {
+		return ErrTreatmentPlanTitleRequired
+	}
+	return nil
+}
+
+// NewTreatmentPlanItem creates a proposed procedure
+func NewTreatmentPlanItem(planID uuid.UUID, phase int, serviceID string, tooth *Tooth, description *string, estimatedCost int64) (*TreatmentPlanItem, error) {
+	now := time.Now()
+	item := &TreatmentPlanItem{
+		ID:            uuid.New(),
+		PlanID:        planID,
+		Phase:         phase,
+		ServiceID:     serviceID,
+		Tooth:         tooth,
+		Description:   description,
+		EstimatedCost: estimatedCost,
+		Status:        TreatmentPlanItemStatusProposed,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// Validate validates the item fields
+func (i *TreatmentPlanItem) Validate() error {
+	if i.Phase < 1 {
+		return ErrInvalidTreatmentPlanPhase
+	}
+	if strings.TrimSpace(i.ServiceID) == "" {
+		return ErrTreatmentPlanServiceRequired
+	}
+	if i.Tooth != nil && !i.Tooth.IsValid() {
+		return ErrInvalidToothNumber
+	}
+	if i.EstimatedCost < 0 {
+		return ErrInvalidEstimatedCost
+	}
+	return nil
+}
+
+// IsAccepted reports whether the patient accepted the procedure
+func (i *TreatmentPlanItem) IsAccepted() bool {
+	switch i.Status {
+	case TreatmentPlanItemStatusAccepted, TreatmentPlanItemStatusScheduled, TreatmentPlanItemStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// Schedule links an accepted procedure to the appointment it will be performed in
+func (i *TreatmentPlanItem) Schedule(appointmentID uuid.UUID) error {
+	if i.Status != TreatmentPlanItemStatusAccepted {
+		return ErrTreatmentPlanItemNotSchedulable
+	}
+	i.Status = TreatmentPlanItemStatusScheduled
+	i.AppointmentID = &appointmentID
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// Complete marks a scheduled procedure as performed
+func (i *TreatmentPlanItem) Complete(completedAt time.Time) {
+	if i.Status != TreatmentPlanItemStatusScheduled {
+		return
+	}
+	i.Status = TreatmentPlanItemStatusCompleted
+	i.CompletedAt = &completedAt
+	i.UpdatedAt = time.Now()
+}
+
+// Release unlinks a scheduled procedure whose appointment did not happen so it can be booked again
+func (i *TreatmentPlanItem) Release() {
+	if i.Status != TreatmentPlanItemStatusScheduled {
+		return
+	}
+	i.Status = TreatmentPlanItemStatusAccepted
+	i.AppointmentID = nil
+	i.UpdatedAt = time.Now()
+}
+
+// IsEditable reports whether procedures can still be added, changed or removed
+func (p *TreatmentPlan) IsEditable() bool {
+	return p.Status == TreatmentPlanStatusDraft || p.Status == TreatmentPlanStatusPresented
+}
+
+// Item finds a procedure of the plan
+func (p *TreatmentPlan) Item(itemID uuid.UUID) *TreatmentPlanItem {
+	for _, item := range p.Items {
+		if item.ID == itemID {
+			return item
+		}
+	}
+	return nil
+}
+
+// ItemByAppointment finds the procedure scheduled in an appointment
+func (p *TreatmentPlan) ItemByAppointment(appointmentID uuid.UUID) *TreatmentPlanItem {
+	for _, item := range p.Items {
+		if item.AppointmentID != nil && *item.AppointmentID == appointmentID {
+			return item
+		}
+	}
+	return nil
+}
+
+// AddItem appends a procedure at the end of its phase
+func (p *TreatmentPlan) AddItem(item *TreatmentPlanItem) error {
+	if !p.IsEditable() {
+		return ErrTreatmentPlanNotEditable
+	}
+	position := 0
+	for _, existing := range p.Items {
+		if existing.Phase == item.Phase && existing.Position >= position {
+			position = existing.Position + 1
+		}
+	}
+	item.PlanID = p.ID
+	item.Position = position
+	p.Items = append(p.Items, item)
+	return nil
+}
+
+// Present marks the plan as quoted to the patient
+func (p *TreatmentPlan) Present() error {
+	if p.Status != TreatmentPlanStatusDraft {
+		return ErrTreatmentPlanNotEditable
+	}
+	if len(p.Items) == 0 {
+		return ErrTreatmentPlanItemsRequired
+	}
+	now := time.Now()
+	p.Status = TreatmentPlanStatusPresented
+	p.PresentedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// RecordDecision records which procedures the patient accepted; the rest are declined.
+// The plan is accepted when at least one procedure was, and declined otherwise.
+func (p *TreatmentPlan) RecordDecision(acceptedItemIDs []uuid.UUID, recordedBy *uuid.UUID) error {
+	if !p.IsEditable() {
+		return ErrTreatmentPlanAlreadyDecided
+	}
+	if len(p.Items) == 0 {
+		return ErrTreatmentPlanItemsRequired
+	}
+
+	accepted := make(map[uuid.UUID]bool, len(acceptedItemIDs))
+	for _, id := range acceptedItemIDs {
+		if p.Item(id) == nil {
+			return ErrTreatmentPlanItemNotFound
+		}
+		accepted[id] = true
+	}
+
+	now := time.Now()
+	for _, item := range p.Items {
+		item.Status = TreatmentPlanItemStatusDeclined
+		if accepted[item.ID] {
+			item.Status = TreatmentPlanItemStatusAccepted
+		}
+		item.UpdatedAt = now
+	}
+
+	p.Status = TreatmentPlanStatusDeclined
+	if len(accepted) > 0 {
+		p.Status = TreatmentPlanStatusAccepted
+	}
+	if p.PresentedAt == nil {
+		p.PresentedAt = &now
+	}
+	p.DecidedAt = &now
+	p.DecisionBy = recordedBy
+	p.UpdatedAt = now
+	return nil
+}
+
+// RefreshCompletion completes an accepted plan once every accepted procedure was performed.
+// It reports whether the status changed.
+func (p *TreatmentPlan) RefreshCompletion() bool {
+	if p.Status != TreatmentPlanStatusAccepted {
+		return false
+	}
+	for _, item := range p.Items {
+		if item.IsAccepted() && item.Status != TreatmentPlanItemStatusCompleted {
+			return false
+		}
+	}
+	now := time.Now()
+	p.Status = TreatmentPlanStatusCompleted
+	p.CompletedAt = &now
+	p.UpdatedAt = now
+	return true
+}
+
+// Cancel abandons the plan; procedures booked in an appointment must be unscheduled first
+func (p *TreatmentPlan) Cancel() error {
+	if p.Status == TreatmentPlanStatusCompleted || p.Status == TreatmentPlanStatusCancelled {
+		return ErrTreatmentPlanNotCancellable
+	}
+	for _, item := range p.Items {
+		if item.Status == TreatmentPlanItemStatusScheduled {
+			return ErrTreatmentPlanHasScheduledItems
+		}
+	}
+	p.Status = TreatmentPlanStatusCancelled
+	p.UpdatedAt = time.Now()
+	return nil
+}
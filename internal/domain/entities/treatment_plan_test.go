@@ -0,0 +1,168 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestTreatmentPlan(t *testing.T, phases ...int) *TreatmentPlan {
+	t.Helper()
+	plan, err := NewTreatmentPlan(uuid.New(), uuid.New(), "Root canal and crown 36", nil)
+	if err != nil {
+		t.Fatalf("NewTreatmentPlan() error = %v", err)
+	}
+	tooth := Tooth(36)
+	for _, phase := range phases {
+		item, err := NewTreatmentPlanItem(plan.ID, phase, "srv_procedure", &tooth, nil, 100000)
+		if err != nil {
+			t.Fatalf("NewTreatmentPlanItem() error = %v", err)
+		}
+		if err := plan.AddItem(item); err != nil {
+			t.Fatalf("AddItem() error = %v", err)
+		}
+	}
+	return plan
+}
+
+func TestNewTreatmentPlanItemValidation(t *testing.T) {
+	valid, invalid := Tooth(11), Tooth(19)
+
+	tests := []struct {
+		name    string
+		phase   int
+		service string
+		tooth   *Tooth
+		cost    int64
+		err     error
+	}{
+		{name: "valid procedure", phase: 1, service: "srv_crown", tooth: &valid, cost: 500000},
+		{name: "procedure without tooth", phase: 2, service: "srv_cleaning"},
+		{name: "phase starts at 1", phase: 0, service: "srv_crown", err: ErrInvalidTreatmentPlanPhase},
+		{name: "service required", phase: 1, service: " ", err: ErrTreatmentPlanServiceRequired},
+		{name: "invalid tooth", phase: 1, service: "srv_crown", tooth: &invalid, err: ErrInvalidToothNumber},
+		{name: "negative cost", phase: 1, service: "srv_crown", cost: -1, err: ErrInvalidEstimatedCost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTreatmentPlanItem(uuid.New(), tt.phase, tt.service, tt.tooth, nil, tt.cost)
+			if err != tt.err {
+				t.Errorf("NewTreatmentPlanItem() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTreatmentPlanAddItemPositionsWithinPhase(t *testing.T) {
+	plan := newTestTreatmentPlan(t, 1, 2, 1, 3, 2)
+
+	want := []int{0, 0, 1, 0, 1}
+	for i, item := range plan.Items {
+		if item.Position != want[i] {
+			t.Errorf("item %d (phase %d) position = %d, want %d", i, item.Phase, item.Position, want[i])
+		}
+	}
+}
+
+func TestTreatmentPlanRecordDecision(t *testing.T) {
+	t.Run("partial acceptance", func(t *testing.T) {
+		plan := newTestTreatmentPlan(t, 1, 2, 3)
+		if err := plan.Present(); err != nil {
+			t.Fatalf("Present() error = %v", err)
+		}
+		if err := plan.RecordDecision([]uuid.UUID{plan.Items[0].ID, plan.Items[2].ID}, nil); err != nil {
+			t.Fatalf("RecordDecision() error = %v", err)
+		}
+
+		if plan.Status != TreatmentPlanStatusAccepted || plan.DecidedAt == nil {
+			t.Errorf("plan status = %q, decided_at = %v", plan.Status, plan.DecidedAt)
+		}
+		want := []TreatmentPlanItemStatus{TreatmentPlanItemStatusAccepted, TreatmentPlanItemStatusDeclined, TreatmentPlanItemStatusAccepted}
+		for i, item := range plan.Items {
+			if item.Status != want[i] {
+				t.Errorf("item %d status = %q, want %q", i, item.Status, want[i])
+			}
+		}
+		if err := plan.RecordDecision(nil, nil); err != ErrTreatmentPlanAlreadyDecided {
+			t.Errorf("second decision error = %v, want %v", err, ErrTreatmentPlanAlreadyDecided)
+		}
+	})
+
+	t.Run("nothing accepted declines the plan", func(t *testing.T) {
+		plan := newTestTreatmentPlan(t, 1)
+		if err := plan.RecordDecision(nil, nil); err != nil {
+			t.Fatalf("RecordDecision() error = %v", err)
+		}
+		if plan.Status != TreatmentPlanStatusDeclined {
+			t.Errorf("plan status = %q, want %q", plan.Status, TreatmentPlanStatusDeclined)
+		}
+	})
+
+	t.Run("unknown item", func(t *testing.T) {
+		plan := newTestTreatmentPlan(t, 1)
+		if err := plan.RecordDecision([]uuid.UUID{uuid.New()}, nil); err != ErrTreatmentPlanItemNotFound {
+			t.Errorf("RecordDecision() error = %v, want %v", err, ErrTreatmentPlanItemNotFound)
+		}
+	})
+
+	t.Run("empty plan", func(t *testing.T) {
+		plan := newTestTreatmentPlan(t)
+		if err := plan.RecordDecision(nil, nil); err != ErrTreatmentPlanItemsRequired {
+			t.Errorf("RecordDecision() error = %v, want %v", err, ErrTreatmentPlanItemsRequired)
+		}
+	})
+}
+
+func TestTreatmentPlanItemLifecycleCompletesPlan(t *testing.T) {
+	plan := newTestTreatmentPlan(t, 1, 2)
+	if err := plan.RecordDecision([]uuid.UUID{plan.Items[0].ID, plan.Items[1].ID}, nil); err != nil {
+		t.Fatalf("RecordDecision() error = %v", err)
+	}
+	first, second := plan.Items[0], plan.Items[1]
+
+	if err := first.Schedule(uuid.New()); err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	if err := first.Schedule(uuid.New()); err != ErrTreatmentPlanItemNotSchedulable {
+		t.Errorf("scheduling a booked item: error = %v, want %v", err, ErrTreatmentPlanItemNotSchedulable)
+	}
+	if err := plan.Cancel(); err != ErrTreatmentPlanHasScheduledItems {
+		t.Errorf("Cancel() error = %v, want %v", err, ErrTreatmentPlanHasScheduledItems)
+	}
+
+	first.Release()
+	if first.Status != TreatmentPlanItemStatusAccepted || first.AppointmentID != nil {
+		t.Errorf("released item: status = %q, appointment = %v", first.Status, first.AppointmentID)
+	}
+
+	appointmentID := uuid.New()
+	_ = first.Schedule(appointmentID)
+	if plan.ItemByAppointment(appointmentID) != first {
+		t.Errorf("ItemByAppointment() did not find the scheduled item")
+	}
+	first.Complete(time.Now())
+	if plan.RefreshCompletion() {
+		t.Errorf("plan completed with an accepted procedure left")
+	}
+
+	_ = second.Schedule(uuid.New())
+	second.Complete(time.Now())
+	if !plan.RefreshCompletion() || plan.Status != TreatmentPlanStatusCompleted {
+		t.Errorf("plan status = %q, want %q", plan.Status, TreatmentPlanStatusCompleted)
+	}
+	if err := plan.Cancel(); err != ErrTreatmentPlanNotCancellable {
+		t.Errorf("Cancel() error = %v, want %v", err, ErrTreatmentPlanNotCancellable)
+	}
+}
+
+func TestTreatmentPlanNotEditableAfterDecision(t *testing.T) {
+	plan := newTestTreatmentPlan(t, 1)
+	_ = plan.RecordDecision([]uuid.UUID{plan.Items[0].ID}, nil)
+
+	item, _ := NewTreatmentPlanItem(plan.ID, 1, "srv_cleaning", nil, nil, 0)
+	if err := plan.AddItem(item); err != ErrTreatmentPlanNotEditable {
+		t.Errorf("AddItem() error = %v, want %v", err, ErrTreatmentPlanNotEditable)
+	}
+}
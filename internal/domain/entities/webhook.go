@@ -0,0 +1,253 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WebhookEventType identifies the kind of change delivered to subscribers.
+// Webhook events are the subset of domain events exposed to integrations.
+type WebhookEventType string
+
+const (
+	WebhookEventAppointmentCreated     = WebhookEventType(EventAppointmentCreated)
+	WebhookEventAppointmentRescheduled = WebhookEventType(EventAppointmentRescheduled)
+	WebhookEventAppointmentCancelled   = WebhookEventType(EventAppointmentCancelled)
+	WebhookEventAppointmentCompleted   = WebhookEventType(EventAppointmentCompleted)
+	WebhookEventPatientCreated         = WebhookEventType(EventPatientCreated)
+	WebhookEventPatientUpdated         = WebhookEventType(EventPatientUpdated)
+	WebhookEventPatientMerged          = WebhookEventType(EventPatientMerged)
+	WebhookEventPatientArchived        = WebhookEventType(EventPatientArchived)
+	WebhookEventPatientRestored        = WebhookEventType(EventPatientRestored)
+	WebhookEventPatientDeleted         = WebhookEventType(EventPatientDeleted)
+)
+
+// IsValidWebhookEventType checks if the event type can be subscribed to
+func IsValidWebhookEventType(eventType WebhookEventType) bool {
+	switch eventType {
+	case WebhookEventAppointmentCreated, WebhookEventAppointmentRescheduled, WebhookEventAppointmentCancelled,
+		WebhookEventAppointmentCompleted, WebhookEventPatientCreated, WebhookEventPatientUpdated,
+		WebhookEventPatientMerged, WebhookEventPatientArchived, WebhookEventPatientRestored,
+		WebhookEventPatientDeleted:
+		return true
+	}
+	return false
+}
+
+// WebhookSubscription represents an organization endpoint receiving event notifications
+type WebhookSubscription struct {
+	ID             uuid.UUID      `json:"id" db:"id"`
+	OrganizationID uuid.UUID      `json:"organization_id" db:"organization_id"`
+	URL            string         `json:"url" db:"url"`
+	Description    *string        `json:"description,omitempty" db:"description"`
+	Secret         string         `json:"-" db:"secret"`                // Shared secret used to sign payloads
+	EventTypes     pq.StringArray `json:"event_types" db:"event_types"` // Empty means all events
+	IsActive       bool           `json:"is_active" db:"is_active"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// NewWebhookSubscription creates a new active subscription with a random signing secret
+func NewWebhookSubscription(organizationID uuid.UUID, endpoint string, eventTypes []WebhookEventType, description *string) (*WebhookSubscription, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	subscription := &WebhookSubscription{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		URL:            strings.TrimSpace(endpoint),
+		Description:    description,
+		Secret:         "whsec_" + secret,
+		IsActive:       true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	subscription.SetEventTypes(eventTypes)
+
+	return subscription, nil
+}
+
+// Validate checks if the subscription entity is valid
+func (s *WebhookSubscription) Validate() error {
+	if s.OrganizationID == uuid.Nil {
+		return ErrInvalidOrganizationID
+	}
+
+	parsed, err := url.Parse(s.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	for _, eventType := range s.EventTypes {
+		if !IsValidWebhookEventType(WebhookEventType(eventType)) {
+			return ErrInvalidWebhookEventType
+		}
+	}
+
+	return nil
+}
+
+// SetEventTypes replaces the event filter of the subscription
+func (s *WebhookSubscription) SetEventTypes(eventTypes []WebhookEventType) {
+	names := make(pq.StringArray, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		names = append(names, string(eventType))
+	}
+	s.EventTypes = names
+	s.UpdatedAt = time.Now()
+}
+
+// Accepts checks if the subscription should receive the given event type
+func (s *WebhookSubscription) Accepts(eventType WebhookEventType) bool {
+	if !s.IsActive {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if WebhookEventType(t) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // Gave up after the maximum attempts
+)
+
+// Webhook delivery retry policy
+const (
+	WebhookMaxAttempts    = 8
+	webhookInitialBackoff = 30 * time.Second
+	webhookMaxBackoff     = 6 * time.Hour
+)
+
+// WebhookDelivery is an outbox row holding one event payload for one subscription
+type WebhookDelivery struct {
+	ID                 uuid.UUID             `json:"id" db:"id"`
+	SubscriptionID     uuid.UUID             `json:"subscription_id" db:"subscription_id"`
+	OrganizationID     uuid.UUID             `json:"organization_id" db:"organization_id"`
+	EventID            uuid.UUID             `json:"event_id" db:"event_id"` // Shared by all deliveries of one event, lets receivers deduplicate
+	EventType          WebhookEventType      `json:"event_type" db:"event_type"`
+	Payload            json.RawMessage       `json:"payload" db:"payload"`
+	Status             WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts           int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt      time.Time             `json:"next_attempt_at" db:"next_attempt_at"`
+	LastAttemptAt      *time.Time            `json:"last_attempt_at,omitempty" db:"last_attempt_at"`
+	LastResponseStatus *int                  `json:"last_response_status,omitempty" db:"last_response_status"`
+	LastError          *string               `json:"last_error,omitempty" db:"last_error"`
+	DeliveredAt        *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt          time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookPayload is the JSON envelope posted to subscribers
+type WebhookPayload struct {
+	ID             uuid.UUID        `json:"id"`
+	Type           WebhookEventType `json:"type"`
+	OrganizationID uuid.UUID        `json:"organization_id"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Data           interface{}      `json:"data"`
+}
+
+// NewWebhookDelivery creates a pending delivery that is due immediately
+func NewWebhookDelivery(subscription *WebhookSubscription, eventID uuid.UUID, eventType WebhookEventType, payload json.RawMessage) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		OrganizationID: subscription.OrganizationID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// MarkSucceeded records a successful attempt
+func (d *WebhookDelivery) MarkSucceeded(now time.Time, responseStatus int) {
+	d.Attempts++
+	d.Status = WebhookDeliverySucceeded
+	d.LastAttemptAt = &now
+	d.LastResponseStatus = &responseStatus
+	d.LastError = nil
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkAttemptFailed records a failed attempt and schedules the next one with
+// exponential backoff, giving up after WebhookMaxAttempts
+func (d *WebhookDelivery) MarkAttemptFailed(now time.Time, responseStatus *int, reason string) {
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.LastResponseStatus = responseStatus
+	d.LastError = &reason
+	d.UpdatedAt = now
+
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookDeliveryFailed
+		return
+	}
+
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = now.Add(WebhookBackoff(d.Attempts))
+}
+
+// Abandon stops retrying a delivery that can no longer be sent
+func (d *WebhookDelivery) Abandon(now time.Time, reason string) {
+	d.Status = WebhookDeliveryFailed
+	d.LastError = &reason
+	d.UpdatedAt = now
+}
+
+// Replay schedules the delivery to be sent again immediately
+func (d *WebhookDelivery) Replay() {
+	now := time.Now()
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+}
+
+// WebhookBackoff returns the wait after the given number of failed attempts
+func WebhookBackoff(attempts int) time.Duration {
+	backoff := webhookInitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+// Receivers recompute it from the X-Webhook-Timestamp header and the raw body.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,24 @@
+package gateways
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BlobStorage defines the port for storing uploaded files by key
+type BlobStorage interface {
+	// Put stores size bytes read from body under the key, replacing any previous object
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Get opens the object stored under the key; entities.ErrBlobNotFound means it does not exist
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object stored under the key; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+
+	// PresignGet returns a URL that downloads the object without credentials until it expires,
+	// served with the given content type and download file name. It returns an empty URL when
+	// the backend cannot sign URLs and downloads must be streamed through the API.
+	PresignGet(ctx context.Context, key string, expires time.Duration, contentType, fileName string) (string, error)
+}
@@ -0,0 +1,17 @@
+package gateways
+
+import "context"
+
+// EmailMessage represents an outgoing email
+type EmailMessage struct {
+	To       string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Mailer defines the interface for sending transactional emails
+type Mailer interface {
+	// Send delivers a single email message
+	Send(ctx context.Context, message *EmailMessage) error
+}
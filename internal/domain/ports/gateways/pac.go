@@ -0,0 +1,52 @@
+package gateways
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// StampedCFDI is a CFDI certified by the PAC and registered with the SAT
+type StampedCFDI struct {
+	UUID      string // Folio fiscal
+	XML       []byte // Sealed XML with the TimbreFiscalDigital complement
+	StampedAt time.Time
+}
+
+// CFDICancellation is a request to cancel a stamped CFDI
+type CFDICancellation struct {
+	IssuerRFC       string
+	ReceiverRFC     string
+	UUID            string
+	Total           int64 // Minor units of MXN; small invoices are cancelled without the receiver's acceptance
+	Reason          entities.CFDICancellationReason
+	ReplacementUUID *string
+}
+
+// ErrPACNotConfigured is returned when no certification provider is configured to stamp or
+// cancel CFDI
+var ErrPACNotConfigured = errors.New("no PAC is configured to stamp CFDI")
+
+// PACRejection is an error returned when the PAC or the SAT refuse a CFDI or a cancellation
+type PACRejection struct {
+	Code    string
+	Message string
+}
+
+func (e *PACRejection) Error() string {
+	return "PAC rejected the request: " + e.Code + " " + e.Message
+}
+
+// PAC defines the port of the authorized certification provider (proveedor autorizado de
+// certificación) that seals CFDI with the issuer's CSD, stamps them and relays cancellations
+// to the SAT. The issuer's CSD is held by the provider.
+type PAC interface {
+	// Stamp seals and certifies an unsigned CFDI 4.0 XML
+	Stamp(ctx context.Context, xml []byte) (*StampedCFDI, error)
+
+	// Cancel requests the cancellation of a CFDI and returns its resulting status:
+	// cancelled, or cancellation pending while the receiver has to accept it
+	Cancel(ctx context.Context, cancellation *CFDICancellation) (entities.CFDIStatus, error)
+}
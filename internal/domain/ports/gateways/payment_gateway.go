@@ -0,0 +1,62 @@
+package gateways
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrPaymentGatewayNotConfigured is returned when no payment provider is configured to collect
+// deposits
+var ErrPaymentGatewayNotConfigured = errors.New("no payment gateway is configured to collect deposits")
+
+// PaymentLinkRequest asks the payment gateway for a hosted page where a patient pays an amount
+type PaymentLinkRequest struct {
+	Reference   uuid.UUID // Our identifier of what is paid, echoed back in notifications
+	Amount      int64     // Minor units of Currency
+	Currency    string
+	Description string
+	ExpiresAt   time.Time
+}
+
+// PaymentLink is a hosted payment page created by the gateway
+type PaymentLink struct {
+	ID        string
+	URL       string
+	ExpiresAt time.Time
+}
+
+// PaymentNotificationType identifies what a gateway notification reports
+type PaymentNotificationType string
+
+const (
+	PaymentSucceeded PaymentNotificationType = "payment.succeeded"
+	PaymentFailed    PaymentNotificationType = "payment.failed"
+)
+
+// PaymentNotification is a verified event the gateway sent to our webhook
+type PaymentNotification struct {
+	ID         string // Gateway identifier of the notification
+	Type       PaymentNotificationType
+	LinkID     string // Payment link the notification is about
+	PaymentID  string // Gateway identifier of the payment
+	Amount     int64  // Minor units of Currency
+	Currency   string
+	OccurredAt time.Time
+}
+
+// PaymentGateway defines the port of the online payment provider that collects deposits
+// through payment links and notifies their outcome to a webhook
+type PaymentGateway interface {
+	// CreatePaymentLink creates a payment page for the request
+	CreatePaymentLink(ctx context.Context, req *PaymentLinkRequest) (*PaymentLink, error)
+
+	// ExpirePaymentLink closes a payment link so it cannot be paid anymore
+	ExpirePaymentLink(ctx context.Context, linkID string) error
+
+	// ParseNotification verifies the signature of a webhook payload and decodes it. It returns
+	// entities.ErrInvalidPaymentNotification when the payload cannot be trusted.
+	ParseNotification(payload []byte, signature string) (*PaymentNotification, error)
+}
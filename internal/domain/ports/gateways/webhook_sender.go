@@ -0,0 +1,22 @@
+package gateways
+
+import "context"
+
+// WebhookRequest represents a signed HTTP POST to a subscriber endpoint
+type WebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// WebhookResponse holds the outcome of a webhook request that reached the receiver
+type WebhookResponse struct {
+	StatusCode int
+	Body       string // Truncated response body, kept for troubleshooting
+}
+
+// WebhookSender defines the port for posting webhook payloads
+type WebhookSender interface {
+	// Send posts the request; an error means the receiver could not be reached
+	Send(ctx context.Context, req *WebhookRequest) (*WebhookResponse, error)
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository defines the interface for API key data operations
+type APIKeyRepository interface {
+	// Create creates a new API key
+	Create(ctx context.Context, apiKey *entities.APIKey) error
+
+	// GetByID retrieves an API key by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+
+	// GetByPrefix retrieves an API key by its public prefix
+	GetByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error)
+
+	// GetByOrganizationID retrieves all API keys for an organization, newest first
+	GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.APIKey, error)
+
+	// Update updates an existing API key
+	Update(ctx context.Context, apiKey *entities.APIKey) error
+
+	// UpdateLastUsed records when an API key was last used
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
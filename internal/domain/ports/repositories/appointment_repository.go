@@ -39,12 +39,21 @@ type AppointmentWithDetails struct {
 	ServiceName *string
 }
 
+// ResourceBooking represents an appointment holding one resource, as shown on that resource's calendar
+type ResourceBooking struct {
+	Appointment  *entities.Appointment
+	Patient      *entities.Patient // Only the ID and name are filled
+	ResourceType entities.ResourceType
+	ResourceID   uuid.UUID
+	Role         *entities.AppointmentRole // Role of a staff member; doctor for the appointment's own doctor
+}
+
 // AppointmentRepository defines the interface for appointment data operations
 type AppointmentRepository interface {
-	// Create creates a new appointment
+	// Create creates a new appointment with its additional resources
 	Create(ctx context.Context, appointment *entities.Appointment) error
 
-	// GetByID retrieves an appointment by its ID
+	// GetByID retrieves an appointment by its ID with its additional resources
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Appointment, error)
 
 	// GetAll retrieves all appointments
@@ -71,12 +80,26 @@ type AppointmentRepository interface {
 	// Delete deletes an appointment by its ID
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// CheckConflict checks if an appointment conflicts with existing appointments
+	// CheckConflict checks if an appointment conflicts with existing appointments holding their
+	// slot (see entities.SlotHoldingStatuses), including the ones booking the doctor or unit as an
+	// additional resource
 	CheckConflict(ctx context.Context, doctorID, unitID uuid.UUID, startTime, endTime time.Time, excludeAppointmentID *uuid.UUID) (bool, error)
 
 	// GetConflictingAppointments returns appointments that conflict with the given time range
 	GetConflictingAppointments(ctx context.Context, doctorID, unitID uuid.UUID, startTime, endTime time.Time, excludeAppointmentID *uuid.UUID) ([]*entities.Appointment, error)
 
+	// ReplaceResources replaces the additional resources of an appointment
+	ReplaceResources(ctx context.Context, appointmentID uuid.UUID, resources []*entities.AppointmentResource) error
+
+	// CheckResourceConflict checks if a resource is held by another appointment holding its slot in
+	// the time range, either as its doctor or unit or as an additional resource
+	CheckResourceConflict(ctx context.Context, resourceType entities.ResourceType, resourceID uuid.UUID, startTime, endTime time.Time, excludeAppointmentID *uuid.UUID) (bool, error)
+
+	// GetResourceBookings retrieves the resources held by a clinic's appointments in the time range,
+	// one entry per appointment and resource, soonest first. Cancelled appointments and those
+	// waiting to be rescheduled are left out.
+	GetResourceBookings(ctx context.Context, clinicID uuid.UUID, startTime, endTime time.Time) ([]*ResourceBooking, error)
+
 	// GetByOrganizationAndDateRange retrieves appointments for an organization within a date range with filters
 	GetByOrganizationAndDateRange(ctx context.Context, orgID uuid.UUID, startDate, endDate time.Time, filters AppointmentFilters) ([]*AppointmentWithDetails, int, error)
 
@@ -84,8 +107,18 @@ type AppointmentRepository interface {
 	GetReschedulingQueue(ctx context.Context, filters ReschedulingQueueFilters) ([]*AppointmentWithDetails, int, error)
 
 	// CancelWithReason cancels an appointment and stores the cancellation reason
-	CancelWithReason(ctx context.Context, appointmentID uuid.UUID, reason string) error
+	CancelWithReason(ctx context.Context, appointmentID uuid.UUID, reasonID *uuid.UUID, reason string) error
 
 	// SnoozeAppointment temporarily hides an appointment from the rescheduling queue until specified time
 	SnoozeAppointment(ctx context.Context, appointmentID uuid.UUID, until time.Time) error
+
+	// UpdateDepositStatus stores the status of the appointment's latest deposit
+	UpdateDepositStatus(ctx context.Context, appointmentID uuid.UUID, status *entities.DepositStatus) error
+
+	// ReassignPatient moves all appointments of one patient to another and returns how many were moved
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) (int, error)
+
+	// GetPatientHistory retrieves a patient's appointments within an organization, newest first.
+	// Only the doctor, unit and clinic names and IDs are filled in the details.
+	GetPatientHistory(ctx context.Context, orgID, patientID uuid.UUID) ([]*AppointmentWithDetails, error)
 }
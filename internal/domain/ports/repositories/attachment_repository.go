@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentFilters selects a page of attachments of a patient or an appointment
+type AttachmentFilters struct {
+	OrganizationID uuid.UUID
+	PatientID      *uuid.UUID
+	AppointmentID  *uuid.UUID
+	Type           *entities.AttachmentType
+	Tooth          *entities.Tooth
+	Page           int
+	Limit          int
+}
+
+// AttachmentRepository defines the interface for attachment metadata
+type AttachmentRepository interface {
+	// Create stores the metadata of an uploaded file
+	Create(ctx context.Context, attachment *entities.Attachment) error
+
+	// GetByID retrieves an attachment of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.Attachment, error)
+
+	// List retrieves a page of attachments, most recently taken first, and the total count
+	List(ctx context.Context, filters AttachmentFilters) ([]*entities.Attachment, int, error)
+
+	// Update saves the descriptive metadata of an attachment
+	Update(ctx context.Context, attachment *entities.Attachment) error
+
+	// Delete removes the metadata of an attachment
+	Delete(ctx context.Context, orgID, id uuid.UUID) error
+
+	// ReassignPatient moves all attachments of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// CancellationFeeFilters selects a page of cancellation fees of an organization
+type CancellationFeeFilters struct {
+	OrganizationID uuid.UUID
+	PatientID      *uuid.UUID
+	Status         *entities.CancellationFeeStatus
+	Page           int
+	Limit          int
+}
+
+// CancellationRepository defines the interface for cancellation reasons, policies and late-cancellation fees
+type CancellationRepository interface {
+	// CreateReason stores a cancellation reason
+	CreateReason(ctx context.Context, reason *entities.CancellationReason) error
+
+	// GetReason retrieves a cancellation reason of the organization
+	GetReason(ctx context.Context, orgID, id uuid.UUID) (*entities.CancellationReason, error)
+
+	// ListReasons retrieves the organization's cancellation reasons by label
+	ListReasons(ctx context.Context, orgID uuid.UUID, includeInactive bool) ([]*entities.CancellationReason, error)
+
+	// UpdateReason saves the fields of a cancellation reason
+	UpdateReason(ctx context.Context, reason *entities.CancellationReason) error
+
+	// GetPolicy retrieves the organization's cancellation policy, nil when it has not set one
+	GetPolicy(ctx context.Context, orgID uuid.UUID) (*entities.CancellationPolicy, error)
+
+	// SavePolicy creates or replaces the organization's cancellation policy
+	SavePolicy(ctx context.Context, policy *entities.CancellationPolicy) error
+
+	// CreateFee stores a late-cancellation fee
+	CreateFee(ctx context.Context, fee *entities.CancellationFee) error
+
+	// GetFee retrieves a fee of the organization
+	GetFee(ctx context.Context, orgID, id uuid.UUID) (*entities.CancellationFee, error)
+
+	// GetFeeForUpdate retrieves a fee of the organization and locks it until the transaction in ctx ends
+	GetFeeForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.CancellationFee, error)
+
+	// ListFees retrieves a page of fees, most recently cancelled first, and the total count
+	ListFees(ctx context.Context, filters CancellationFeeFilters) ([]*entities.CancellationFee, int, error)
+
+	// UpdateFee saves the status, amount, override and invoice of a fee
+	UpdateFee(ctx context.Context, fee *entities.CancellationFee) error
+
+	// ReassignPatient moves all fees of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// CFDIRepository defines the interface for the stamped CFDI of invoices. The XML is only
+// loaded by GetByID.
+type CFDIRepository interface {
+	// Create stores a stamped CFDI
+	Create(ctx context.Context, cfdi *entities.CFDI) error
+
+	// GetByID retrieves a CFDI of an invoice, with its XML
+	GetByID(ctx context.Context, invoiceID, id uuid.UUID) (*entities.CFDI, error)
+
+	// GetActiveByInvoice retrieves the CFDI of an invoice that is not cancelled
+	GetActiveByInvoice(ctx context.Context, invoiceID uuid.UUID) (*entities.CFDI, error)
+
+	// ListByInvoice retrieves the CFDI of an invoice in the order they were stamped
+	ListByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entities.CFDI, error)
+
+	// Update saves the status and cancellation fields of a CFDI
+	Update(ctx context.Context, cfdi *entities.CFDI) error
+
+	// ReassignPatient moves all CFDI of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// ClinicalNoteTemplateRepository defines the interface for organization note templates
+type ClinicalNoteTemplateRepository interface {
+	// Create stores a template
+	Create(ctx context.Context, template *entities.ClinicalNoteTemplate) error
+
+	// GetByID retrieves a template of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.ClinicalNoteTemplate, error)
+
+	// GetByService retrieves the template of a service; a nil service selects the organization default
+	GetByService(ctx context.Context, orgID uuid.UUID, serviceID *string) (*entities.ClinicalNoteTemplate, error)
+
+	// List retrieves all templates of the organization
+	List(ctx context.Context, orgID uuid.UUID) ([]*entities.ClinicalNoteTemplate, error)
+
+	// Update saves the name and sections of a template
+	Update(ctx context.Context, template *entities.ClinicalNoteTemplate) error
+
+	// Delete removes a template
+	Delete(ctx context.Context, orgID, id uuid.UUID) error
+}
+
+// ClinicalNoteTimelineFilters selects a page of a patient's clinical notes
+type ClinicalNoteTimelineFilters struct {
+	OrganizationID uuid.UUID
+	PatientID      uuid.UUID
+	Page           int
+	Limit          int
+}
+
+// ClinicalNoteTimelineItem is a clinical note with the appointment it documents
+type ClinicalNoteTimelineItem struct {
+	Note             *entities.ClinicalNote
+	AppointmentStart time.Time
+	DoctorName       string
+	ServiceName      *string
+	Amendments       []*entities.ClinicalNoteAmendment
+}
+
+// ClinicalNoteRepository defines the interface for clinical notes and their amendments
+type ClinicalNoteRepository interface {
+	// Create stores a draft note
+	Create(ctx context.Context, note *entities.ClinicalNote) error
+
+	// GetByID retrieves a note of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.ClinicalNote, error)
+
+	// GetByAppointment retrieves the note documenting an appointment
+	GetByAppointment(ctx context.Context, orgID, appointmentID uuid.UUID) (*entities.ClinicalNote, error)
+
+	// Update saves the content and signature of a draft note
+	Update(ctx context.Context, note *entities.ClinicalNote) error
+
+	// CreateAmendment stores an amendment to a signed note
+	CreateAmendment(ctx context.Context, amendment *entities.ClinicalNoteAmendment) error
+
+	// GetAmendments retrieves the amendments of a note, oldest first
+	GetAmendments(ctx context.Context, noteID uuid.UUID) ([]*entities.ClinicalNoteAmendment, error)
+
+	// GetPatientTimeline retrieves a patient's notes, most recent appointment first
+	GetPatientTimeline(ctx context.Context, filters ClinicalNoteTimelineFilters) ([]*ClinicalNoteTimelineItem, int, error)
+
+	// ReassignPatient moves all notes of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
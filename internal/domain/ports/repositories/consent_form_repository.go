@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// ConsentTemplateRepository defines the interface for organization consent templates
+type ConsentTemplateRepository interface {
+	// Create stores a template
+	Create(ctx context.Context, template *entities.ConsentFormTemplate) error
+
+	// GetByID retrieves a template of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.ConsentFormTemplate, error)
+
+	// List retrieves all templates of the organization
+	List(ctx context.Context, orgID uuid.UUID) ([]*entities.ConsentFormTemplate, error)
+
+	// ListRequired retrieves the required templates that apply to appointments of a service
+	ListRequired(ctx context.Context, orgID uuid.UUID, serviceID *string) ([]*entities.ConsentFormTemplate, error)
+
+	// Update saves the service, name, body and required flag of a template
+	Update(ctx context.Context, template *entities.ConsentFormTemplate) error
+
+	// Delete removes a template; forms rendered from it are kept
+	Delete(ctx context.Context, orgID, id uuid.UUID) error
+}
+
+// ConsentFormFilters selects a page of a patient's consent forms
+type ConsentFormFilters struct {
+	OrganizationID uuid.UUID
+	PatientID      uuid.UUID
+	AppointmentID  *uuid.UUID
+	Status         *entities.ConsentFormStatus
+	Page           int
+	Limit          int
+}
+
+// ConsentFormRepository defines the interface for consent forms
+type ConsentFormRepository interface {
+	// Create stores a pending form
+	Create(ctx context.Context, form *entities.ConsentForm) error
+
+	// GetByID retrieves a form of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.ConsentForm, error)
+
+	// GetForSigning retrieves a form by the ID carried in its signing link
+	GetForSigning(ctx context.Context, id uuid.UUID) (*entities.ConsentForm, error)
+
+	// List retrieves a page of a patient's forms, newest first, and the total count
+	List(ctx context.Context, filters ConsentFormFilters) ([]*entities.ConsentForm, int, error)
+
+	// ListSigned retrieves the signed forms of a patient
+	ListSigned(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.ConsentForm, error)
+
+	// Sign records the signature of a pending form; a form signed concurrently
+	// returns ErrConsentFormAlreadySigned
+	Sign(ctx context.Context, form *entities.ConsentForm) error
+
+	// Update saves the link expiry and revocation of a form
+	Update(ctx context.Context, form *entities.ConsentForm) error
+
+	// ReassignPatient moves all forms of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// DentalChartEntryFilters narrows the charting entries of a patient
+type DentalChartEntryFilters struct {
+	OrganizationID uuid.UUID
+	PatientID      uuid.UUID
+	RecordedUntil  *time.Time      // Only entries observed at or before this time
+	Tooth          *entities.Tooth // Only entries of this tooth
+}
+
+// DentalChartRepository defines the interface for odontogram charting entries
+type DentalChartRepository interface {
+	// Create stores a charting entry
+	Create(ctx context.Context, entry *entities.DentalChartEntry) error
+
+	// GetEntries retrieves charting entries in the order they were observed
+	GetEntries(ctx context.Context, filters DentalChartEntryFilters) ([]*entities.DentalChartEntry, error)
+
+	// ReassignPatient moves all charting entries of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// DepositFilters selects a page of deposits of an organization
+type DepositFilters struct {
+	OrganizationID uuid.UUID
+	ClinicID       *uuid.UUID
+	PatientID      *uuid.UUID
+	Status         *entities.DepositStatus
+	Page           int
+	Limit          int
+}
+
+// DepositRepository defines the interface for deposit policies and appointment deposits
+type DepositRepository interface {
+	// GetPolicy retrieves the organization's deposit policy, nil when it has not set one
+	GetPolicy(ctx context.Context, orgID uuid.UUID) (*entities.DepositPolicy, error)
+
+	// SavePolicy creates or replaces the organization's deposit policy
+	SavePolicy(ctx context.Context, policy *entities.DepositPolicy) error
+
+	// Create stores a deposit
+	Create(ctx context.Context, deposit *entities.Deposit) error
+
+	// GetByID retrieves a deposit of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.Deposit, error)
+
+	// GetForUpdate retrieves a deposit of the organization and locks it until the transaction in ctx ends
+	GetForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.Deposit, error)
+
+	// GetByPaymentLinkForUpdate retrieves the deposit paid through a gateway link and locks it
+	// until the transaction in ctx ends
+	GetByPaymentLinkForUpdate(ctx context.Context, linkID string) (*entities.Deposit, error)
+
+	// GetActiveByAppointment retrieves the pending or paid deposit of an appointment, nil when none
+	GetActiveByAppointment(ctx context.Context, appointmentID uuid.UUID) (*entities.Deposit, error)
+
+	// List retrieves a page of deposits, most recent first, and the total count
+	List(ctx context.Context, filters DepositFilters) ([]*entities.Deposit, int, error)
+
+	// ListOverdue retrieves up to limit pending deposits due at or before now, oldest due first
+	ListOverdue(ctx context.Context, now time.Time, limit int) ([]*entities.Deposit, error)
+
+	// Update saves the status, payment link, payment, invoice and waiver of a deposit
+	Update(ctx context.Context, deposit *entities.Deposit) error
+
+	// MoveAppointment moves the pending or paid deposit of an appointment to the appointment that
+	// replaces it, booked in clinicID
+	MoveAppointment(ctx context.Context, fromAppointmentID, toAppointmentID, clinicID uuid.UUID) error
+
+	// ReassignPatient moves all deposits of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
@@ -41,4 +41,7 @@ type DoctorRepository interface {
 
 	// GetByOrganizationID retrieves doctors by organization ID with clinic info
 	GetByOrganizationID(ctx context.Context, orgID uuid.UUID, clinicID *uuid.UUID) ([]*DoctorWithOrgInfo, error)
+
+	// GetByUserID retrieves the doctor of an organization linked to a user account
+	GetByUserID(ctx context.Context, orgID, userID uuid.UUID) (*entities.Doctor, error)
 }
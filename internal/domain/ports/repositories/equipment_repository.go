@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// EquipmentRepository defines the interface for the movable equipment of clinics
+type EquipmentRepository interface {
+	// Create stores a piece of equipment
+	Create(ctx context.Context, equipment *entities.Equipment) error
+
+	// Get retrieves a piece of equipment of one of the organization's clinics
+	Get(ctx context.Context, orgID, id uuid.UUID) (*entities.Equipment, error)
+
+	// ListByClinic retrieves the equipment of a clinic of the organization by name
+	ListByClinic(ctx context.Context, orgID, clinicID uuid.UUID, includeInactive bool) ([]*entities.Equipment, error)
+
+	// Update saves the name, description and active flag of a piece of equipment
+	Update(ctx context.Context, equipment *entities.Equipment) error
+}
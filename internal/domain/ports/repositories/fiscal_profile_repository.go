@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// FiscalProfileRepository defines the interface for the fiscal data CFDI are issued with
+type FiscalProfileRepository interface {
+	// GetPatientProfile retrieves the fiscal data of a patient
+	GetPatientProfile(ctx context.Context, orgID, patientID uuid.UUID) (*entities.PatientFiscalProfile, error)
+
+	// UpsertPatientProfile creates or replaces the fiscal data of a patient
+	UpsertPatientProfile(ctx context.Context, profile *entities.PatientFiscalProfile) error
+
+	// DeletePatientProfile removes the fiscal data of a patient
+	DeletePatientProfile(ctx context.Context, orgID, patientID uuid.UUID) error
+
+	// GetIssuerProfile retrieves the issuer data of an organization
+	GetIssuerProfile(ctx context.Context, orgID uuid.UUID) (*entities.IssuerFiscalProfile, error)
+
+	// UpsertIssuerProfile creates or replaces the issuer data of an organization
+	UpsertIssuerProfile(ctx context.Context, profile *entities.IssuerFiscalProfile) error
+
+	// ReassignPatient moves the fiscal data of one patient to another unless the target
+	// already has its own
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
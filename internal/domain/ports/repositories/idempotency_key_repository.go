@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyRepository defines the interface for idempotency key data operations
+type IdempotencyKeyRepository interface {
+	// Reserve stores the record unless a live one exists for the same organization and key.
+	// Expired records, and in-progress records older than staleAfter, are replaced.
+	// It returns the stored record and whether it is the one passed in.
+	Reserve(ctx context.Context, record *entities.IdempotencyKey, staleAfter time.Duration) (*entities.IdempotencyKey, bool, error)
+
+	// Complete stores the response of a reserved record
+	Complete(ctx context.Context, record *entities.IdempotencyKey) error
+
+	// Release removes an in-progress record so the request can be retried
+	Release(ctx context.Context, orgID uuid.UUID, key string) error
+
+	// DeleteExpired removes records that expired before the given time
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
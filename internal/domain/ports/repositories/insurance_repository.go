@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// InsuranceClaimFilters selects a page of insurance claims of an organization
+type InsuranceClaimFilters struct {
+	OrganizationID uuid.UUID
+	PatientID      *uuid.UUID
+	PolicyID       *uuid.UUID
+	AppointmentID  *uuid.UUID
+	Status         *entities.InsuranceClaimStatus
+	Page           int
+	Limit          int
+}
+
+// InsuranceRepository defines the interface for insurance carriers, patient policies and claims
+type InsuranceRepository interface {
+	// CreateCarrier stores a carrier
+	CreateCarrier(ctx context.Context, carrier *entities.InsuranceCarrier) error
+
+	// GetCarrier retrieves a carrier of the organization
+	GetCarrier(ctx context.Context, orgID, id uuid.UUID) (*entities.InsuranceCarrier, error)
+
+	// ListCarriers retrieves the organization's carriers by name
+	ListCarriers(ctx context.Context, orgID uuid.UUID, includeInactive bool) ([]*entities.InsuranceCarrier, error)
+
+	// UpdateCarrier saves the fields of a carrier
+	UpdateCarrier(ctx context.Context, carrier *entities.InsuranceCarrier) error
+
+	// CreatePolicy stores a patient's policy
+	CreatePolicy(ctx context.Context, policy *entities.InsurancePolicy) error
+
+	// GetPolicy retrieves a policy of the organization
+	GetPolicy(ctx context.Context, orgID, id uuid.UUID) (*entities.InsurancePolicy, error)
+
+	// ListPoliciesByPatient retrieves a patient's policies, most recent first
+	ListPoliciesByPatient(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.InsurancePolicy, error)
+
+	// UpdatePolicy saves the fields of a policy
+	UpdatePolicy(ctx context.Context, policy *entities.InsurancePolicy) error
+
+	// DeletePolicy removes a policy
+	DeletePolicy(ctx context.Context, orgID, id uuid.UUID) error
+
+	// PolicyHasClaims checks if any claim was made under a policy
+	PolicyHasClaims(ctx context.Context, policyID uuid.UUID) (bool, error)
+
+	// CreateClaim stores a claim
+	CreateClaim(ctx context.Context, claim *entities.InsuranceClaim) error
+
+	// GetClaim retrieves a claim of the organization
+	GetClaim(ctx context.Context, orgID, id uuid.UUID) (*entities.InsuranceClaim, error)
+
+	// GetClaimForUpdate retrieves a claim of the organization and locks it until the
+	// transaction in ctx ends
+	GetClaimForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.InsuranceClaim, error)
+
+	// GetOpenClaim retrieves the claim of an appointment under a policy that is not rejected
+	GetOpenClaim(ctx context.Context, policyID, appointmentID uuid.UUID) (*entities.InsuranceClaim, error)
+
+	// ListClaims retrieves a page of claims, most recently submitted first, and the total count
+	ListClaims(ctx context.Context, filters InsuranceClaimFilters) ([]*entities.InsuranceClaim, int, error)
+
+	// ListClaimsByPolicy retrieves the claims of a policy for services within [from, to)
+	ListClaimsByPolicy(ctx context.Context, policyID uuid.UUID, from, to time.Time) ([]*entities.InsuranceClaim, error)
+
+	// UpdateClaim saves the status and carrier response of a claim
+	UpdateClaim(ctx context.Context, claim *entities.InsuranceClaim) error
+
+	// ReassignPatient moves all policies and claims of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
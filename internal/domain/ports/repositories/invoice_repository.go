@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceFilters selects a page of invoices of an organization
+type InvoiceFilters struct {
+	OrganizationID uuid.UUID
+	PatientID      *uuid.UUID
+	ClinicID       *uuid.UUID
+	Status         *entities.InvoiceStatus
+	IssuedFrom     *time.Time // Inclusive
+	IssuedTo       *time.Time // Exclusive
+	Page           int
+	Limit          int
+}
+
+// InvoiceRepository defines the interface for invoices and their payments ledger.
+// Invoices are always loaded with their lines ordered by position.
+type InvoiceRepository interface {
+	// NextNumber hands out the next sequential invoice number of the organization
+	NextNumber(ctx context.Context, orgID uuid.UUID) (string, error)
+
+	// Create stores an invoice and its lines
+	Create(ctx context.Context, invoice *entities.Invoice) error
+
+	// GetByID retrieves an invoice of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.Invoice, error)
+
+	// GetForUpdate retrieves an invoice of the organization and locks it until the
+	// transaction in ctx ends
+	GetForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.Invoice, error)
+
+	// GetActiveByAppointment retrieves the non-void invoice of an appointment
+	GetActiveByAppointment(ctx context.Context, appointmentID uuid.UUID) (*entities.Invoice, error)
+
+	// List retrieves a page of invoices, most recently issued first, and the total count
+	List(ctx context.Context, filters InvoiceFilters) ([]*entities.Invoice, int, error)
+
+	// ListByPatient retrieves all invoices of a patient, oldest first
+	ListByPatient(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.Invoice, error)
+
+	// Update saves the status, paid amounts and void fields of an invoice
+	Update(ctx context.Context, invoice *entities.Invoice) error
+
+	// CreatePayment appends an entry to the payments ledger
+	CreatePayment(ctx context.Context, payment *entities.Payment) error
+
+	// GetPayment retrieves a ledger entry of an invoice
+	GetPayment(ctx context.Context, invoiceID, paymentID uuid.UUID) (*entities.Payment, error)
+
+	// ListPayments retrieves the ledger of an invoice in the order money changed hands
+	ListPayments(ctx context.Context, invoiceID uuid.UUID) ([]*entities.Payment, error)
+
+	// RefundedAmount returns how much has been given back from a payment
+	RefundedAmount(ctx context.Context, paymentID uuid.UUID) (int64, error)
+
+	// ListPaymentsByClinic retrieves a clinic's ledger entries received within [from, to)
+	ListPaymentsByClinic(ctx context.Context, orgID, clinicID uuid.UUID, from, to time.Time) ([]*entities.Payment, error)
+
+	// ReassignPatient moves all invoices and payments of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
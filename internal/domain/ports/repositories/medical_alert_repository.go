@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// MedicalAlertRepository defines the interface for patient medical alerts and their audit trail
+type MedicalAlertRepository interface {
+	// Create stores a new alert
+	Create(ctx context.Context, alert *entities.MedicalAlert) error
+
+	// GetByID retrieves an alert of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.MedicalAlert, error)
+
+	// ListByPatient retrieves a patient's alerts, most severe first; resolved alerts are
+	// only included when includeResolved is set
+	ListByPatient(ctx context.Context, orgID, patientID uuid.UUID, includeResolved bool) ([]*entities.MedicalAlert, error)
+
+	// ListActiveByPatients retrieves the active alerts of several patients grouped by patient,
+	// most severe first
+	ListActiveByPatients(ctx context.Context, orgID uuid.UUID, patientIDs []uuid.UUID) (map[uuid.UUID][]*entities.MedicalAlert, error)
+
+	// Update saves the details, review and resolution of an alert
+	Update(ctx context.Context, alert *entities.MedicalAlert) error
+
+	// CreateChange stores the audit record of a change to an alert
+	CreateChange(ctx context.Context, change *entities.MedicalAlertChange) error
+
+	// ListChanges retrieves the audit trail of an alert, oldest first
+	ListChanges(ctx context.Context, orgID, alertID uuid.UUID) ([]*entities.MedicalAlertChange, error)
+
+	// ReassignPatient moves all alerts and their audit records of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
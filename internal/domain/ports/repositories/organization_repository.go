@@ -38,6 +38,8 @@ type AppointmentCalendarData struct {
 	Notes            *string    `json:"notes,omitempty"`
 	IsFirstVisit     bool       `json:"is_first_visit"`
 	ClinicTimezone   string     `json:"-"` // IANA timezone from clinic, not exposed in JSON (used internally for conversion)
+
+	MedicalAlerts []*entities.MedicalAlert `json:"medical_alerts,omitempty"` // Active alerts of the patient, most severe first
 }
 
 // OrganizationRepository defines the interface for organization data operations
@@ -50,4 +52,7 @@ type OrganizationRepository interface {
 
 	// Exists checks if an organization exists by its ID
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// UpdateSettings stores the organization's settings (default phone region)
+	UpdateSettings(ctx context.Context, org *entities.Organization) error
 }
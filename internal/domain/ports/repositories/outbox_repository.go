@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// OutboxRepository defines the interface for domain event outbox operations
+type OutboxRepository interface {
+	// Append records domain events; call it within the transaction that persists the change
+	Append(ctx context.Context, events ...*entities.DomainEvent) error
+
+	// ClaimDue locks up to limit pending events due at now and postpones them by lease
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.OutboxEvent, error)
+
+	// Update updates the dispatch state of an event
+	Update(ctx context.Context, event *entities.OutboxEvent) error
+
+	// DeleteProcessedBefore removes events processed before the given time
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// PatientMergeRepository defines the interface for patient merge audit records
+type PatientMergeRepository interface {
+	// Create stores a merge record
+	Create(ctx context.Context, merge *entities.PatientMerge) error
+
+	// GetByID retrieves a merge record by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PatientMerge, error)
+
+	// GetByOrganizationID retrieves merge records of an organization, newest first
+	GetByOrganizationID(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*entities.PatientMerge, int, error)
+}
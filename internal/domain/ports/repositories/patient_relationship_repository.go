@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// PatientRelative is a relationship of a patient together with the related patient
+type PatientRelative struct {
+	Relationship *entities.PatientRelationship
+	Patient      *entities.Patient // The related patient
+}
+
+// PatientHousehold is a group of patients linked directly or indirectly by relationships
+type PatientHousehold struct {
+	ID      uuid.UUID           // Smallest member ID, stable while the members do not change
+	Members []*entities.Patient // Ordered by name
+}
+
+// PatientRelationshipRepository defines the interface for family relationships between patients
+type PatientRelationshipRepository interface {
+	// CreatePair stores a relationship together with its inverse
+	CreatePair(ctx context.Context, relationship, inverse *entities.PatientRelationship) error
+
+	// Get retrieves the relationship of a patient with a related patient
+	Get(ctx context.Context, orgID, patientID, relatedPatientID uuid.UUID) (*entities.PatientRelationship, error)
+
+	// ListByPatient retrieves the relatives of a patient: the contact person first, then
+	// guardians, dependents and spouses, each by name
+	ListByPatient(ctx context.Context, orgID, patientID uuid.UUID) ([]*PatientRelative, error)
+
+	// DeletePair removes the relationship between two patients in both directions
+	DeletePair(ctx context.Context, orgID, patientID, relatedPatientID uuid.UUID) error
+
+	// SetContact makes a guardian the contact person of a patient, replacing the previous one
+	SetContact(ctx context.Context, orgID, patientID, guardianID uuid.UUID) error
+
+	// ListHouseholds retrieves the households of several patients keyed by patient; patients
+	// without relationships are left out
+	ListHouseholds(ctx context.Context, orgID uuid.UUID, patientIDs []uuid.UUID) (map[uuid.UUID]*PatientHousehold, error)
+
+	// ReassignPatient moves the relationships of one patient to another, dropping the ones
+	// between them and the ones the target already has
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
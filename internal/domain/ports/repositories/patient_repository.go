@@ -2,12 +2,67 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"dental-scheduler-backend/internal/domain/entities"
 
 	"github.com/google/uuid"
 )
 
+// PatientSearchFilters represents a tokenized patient search within an organization.
+// Every term and phone digit group has to match.
+type PatientSearchFilters struct {
+	OrganizationID uuid.UUID
+	Terms          []string // Lowercased tokens matched accent-insensitively against the name and email
+	PhoneDigits    []string // Digit groups matched against the phone with punctuation removed
+	After          *PatientSearchCursor
+	Limit          int
+}
+
+// PatientSearchCursor is the position of the last result of the previous page
+type PatientSearchCursor struct {
+	Rank      float64
+	PatientID uuid.UUID
+}
+
+// PatientSearchResult represents a patient search hit with its relevance and appointment dates
+type PatientSearchResult struct {
+	Patient           *entities.Patient
+	Rank              float64
+	LastAppointmentAt *time.Time
+	NextAppointmentAt *time.Time
+}
+
+// Patient list sort fields
+const (
+	PatientSortName            = "name"
+	PatientSortCreatedAt       = "created_at"
+	PatientSortLastVisit       = "last_visit"
+	PatientSortNextAppointment = "next_appointment"
+)
+
+// PatientListFilters represents filters for listing an organization's patients
+type PatientListFilters struct {
+	OrganizationID  uuid.UUID
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time // Exclusive
+	HasUpcoming     *bool      // Has (or lacks) a scheduled appointment in the future
+	LastVisitBefore *time.Time // Last past appointment started before this time
+	Archived        bool       // List archived patients instead of active ones
+	SortBy          string     // One of the PatientSort* fields
+	SortDesc        bool
+	Page            int
+	Limit           int
+}
+
+// PatientListItem represents a patient in an organization listing with its appointment dates
+type PatientListItem struct {
+	Patient           *entities.Patient
+	ArchivedAt        *time.Time
+	LastVisitAt       *time.Time
+	NextAppointmentAt *time.Time
+}
+
 // PatientRepository defines the interface for patient data operations
 type PatientRepository interface {
 	// Create creates a new patient
@@ -31,8 +86,9 @@ type PatientRepository interface {
 	// Exists checks if a patient exists by its ID
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
 
-	// SearchPatients searches for patients by name, phone, or email within an organization
-	SearchPatients(ctx context.Context, orgID uuid.UUID, query string, limit int) ([]*entities.Patient, error)
+	// SearchPatients searches for patients by name, phone, or email within an organization,
+	// most relevant first
+	SearchPatients(ctx context.Context, filters PatientSearchFilters) ([]*PatientSearchResult, error)
 
 	// AddPatientToOrganization links a patient to an organization
 	AddPatientToOrganization(ctx context.Context, patientID, orgID uuid.UUID) error
@@ -48,4 +104,34 @@ type PatientRepository interface {
 
 	// PatientBelongsToOrganization checks if a patient belongs to an organization
 	PatientBelongsToOrganization(ctx context.Context, patientID, orgID uuid.UUID) (bool, error)
+
+	// IsLinkedToOtherOrganizations checks if a patient is linked to any organization besides orgID
+	IsLinkedToOtherOrganizations(ctx context.Context, patientID, orgID uuid.UUID) (bool, error)
+
+	// GetByOrganizationID retrieves all patients linked to an organization
+	GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.Patient, error)
+
+	// MoveOrganizationLinks re-points the organization links of one patient to another
+	MoveOrganizationLinks(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+
+	// SetFirstAppointment overwrites the patient's first_appointment_id
+	SetFirstAppointment(ctx context.Context, patientID uuid.UUID, appointmentID *uuid.UUID) error
+
+	// ListByOrganization retrieves a page of an organization's patients with the total count
+	ListByOrganization(ctx context.Context, filters PatientListFilters) ([]*PatientListItem, int, error)
+
+	// GetArchivedAt returns when the organization archived the patient, nil while active
+	GetArchivedAt(ctx context.Context, patientID, orgID uuid.UUID) (*time.Time, error)
+
+	// SetArchived archives the patient in the organization, or restores it when archivedAt is nil
+	SetArchived(ctx context.Context, patientID, orgID uuid.UUID, archivedAt *time.Time, archivedBy *uuid.UUID) error
+
+	// RemoveFromOrganization unlinks a patient from an organization
+	RemoveFromOrganization(ctx context.Context, patientID, orgID uuid.UUID) error
+
+	// CountOrganizations counts the organizations a patient is linked to
+	CountOrganizations(ctx context.Context, patientID uuid.UUID) (int, error)
+
+	// HasClinicalRecords checks if the organization keeps clinical records of the patient
+	HasClinicalRecords(ctx context.Context, patientID, orgID uuid.UUID) (bool, error)
 }
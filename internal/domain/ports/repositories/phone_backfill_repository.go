@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// PhoneOwner names the kind of record a stored phone number belongs to
+type PhoneOwner string
+
+const (
+	PhoneOwnerPatient PhoneOwner = "patient"
+	PhoneOwnerDoctor  PhoneOwner = "doctor"
+	PhoneOwnerClinic  PhoneOwner = "clinic"
+)
+
+// PhoneOwners lists every kind of record with a phone number, in backfill order
+var PhoneOwners = []PhoneOwner{PhoneOwnerPatient, PhoneOwnerDoctor, PhoneOwnerClinic}
+
+// PhoneRecord is a stored phone number with the default region of its organization
+type PhoneRecord struct {
+	Owner     PhoneOwner
+	ID        uuid.UUID
+	Region    string // Default phone region of the owning organization
+	Phone     string
+	PhoneE164 *string
+}
+
+// PhoneBackfillRepository defines the data operations used to normalize stored phone numbers
+type PhoneBackfillRepository interface {
+	// GetPhoneRecords retrieves every non-blank phone of one kind of record
+	GetPhoneRecords(ctx context.Context, owner PhoneOwner) ([]*PhoneRecord, error)
+
+	// SetPhoneE164 stores the normalized phone of a record; nil clears it
+	SetPhoneE164(ctx context.Context, owner PhoneOwner, id uuid.UUID, e164 *string) error
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// RecallFilters selects a page of an organization's recalls
+type RecallFilters struct {
+	OrganizationID uuid.UUID
+	ClinicID       *uuid.UUID
+	PatientID      *uuid.UUID
+	Status         *entities.RecallStatus
+	OutreachStatus *entities.RecallOutreachStatus
+	DueFrom        *time.Time // Inclusive
+	DueTo          *time.Time // Inclusive
+	Page           int
+	Limit          int
+}
+
+// RecallWorklistItem represents a recall with the patient to contact
+type RecallWorklistItem struct {
+	Recall  *entities.Recall
+	Patient *entities.Patient
+}
+
+// RecallRepository defines the interface for recall rules, recalls and their outreach
+type RecallRepository interface {
+	// CreateRule stores a recall rule
+	CreateRule(ctx context.Context, rule *entities.RecallRule) error
+
+	// GetRule retrieves a recall rule of the organization
+	GetRule(ctx context.Context, orgID, id uuid.UUID) (*entities.RecallRule, error)
+
+	// GetRuleByService retrieves the organization's recall rule for a service, nil when it has none
+	GetRuleByService(ctx context.Context, orgID uuid.UUID, serviceID string) (*entities.RecallRule, error)
+
+	// ListRules retrieves the organization's recall rules by service
+	ListRules(ctx context.Context, orgID uuid.UUID, includeInactive bool) ([]*entities.RecallRule, error)
+
+	// UpdateRule saves the interval, qualifying services and active flag of a rule
+	UpdateRule(ctx context.Context, rule *entities.RecallRule) error
+
+	// Create stores a recall
+	Create(ctx context.Context, recall *entities.Recall) error
+
+	// Get retrieves a recall of the organization
+	Get(ctx context.Context, orgID, id uuid.UUID) (*entities.Recall, error)
+
+	// GetForUpdate retrieves a recall of the organization and locks it until the transaction in ctx ends
+	GetForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.Recall, error)
+
+	// List retrieves a page of recalls with their patients, soonest due first, and the total
+	// count. Patients archived by the organization are left out.
+	List(ctx context.Context, filters RecallFilters) ([]*RecallWorklistItem, int, error)
+
+	// ListOpenByPatientForUpdate retrieves the patient's open recalls in the organization and
+	// locks them until the transaction in ctx ends
+	ListOpenByPatientForUpdate(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.Recall, error)
+
+	// ListBookedByAppointmentForUpdate retrieves the recalls closed by booking an appointment
+	// and locks them until the transaction in ctx ends
+	ListBookedByAppointmentForUpdate(ctx context.Context, appointmentID uuid.UUID) ([]*entities.Recall, error)
+
+	// MoveAppointment re-points the recalls closed by booking one appointment to another (rescheduling)
+	MoveAppointment(ctx context.Context, fromAppointmentID, toAppointmentID uuid.UUID) error
+
+	// Update saves the status, outreach and closure of a recall
+	Update(ctx context.Context, recall *entities.Recall) error
+
+	// CreateOutreach stores an attempt to contact a recalled patient
+	CreateOutreach(ctx context.Context, outreach *entities.RecallOutreach) error
+
+	// ListOutreach retrieves the contact attempts of a recall, most recent first
+	ListOutreach(ctx context.Context, recallID uuid.UUID) ([]*entities.RecallOutreach, error)
+
+	// ReassignPatient moves all recalls of one patient to another. Open recalls of the first
+	// patient under a rule the second one is already recalled for are dismissed.
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// ServiceRepository defines the interface for the services an organization offers
+type ServiceRepository interface {
+	// GetByID retrieves a service of the organization
+	GetByID(ctx context.Context, orgID uuid.UUID, id string) (*entities.Service, error)
+
+	// UpdateCategory sets the insurance category of a service
+	UpdateCategory(ctx context.Context, service *entities.Service) error
+
+	// UpdateDeposit sets the deposit required to book a service
+	UpdateDeposit(ctx context.Context, service *entities.Service) error
+}
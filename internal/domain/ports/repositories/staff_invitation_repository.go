@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// StaffInvitationRepository defines the interface for staff invitation data operations
+type StaffInvitationRepository interface {
+	// Create creates a new invitation
+	Create(ctx context.Context, invitation *entities.StaffInvitation) error
+
+	// GetByID retrieves an invitation by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.StaffInvitation, error)
+
+	// GetByIDForUpdate retrieves an invitation and locks it until the transaction in ctx ends
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.StaffInvitation, error)
+
+	// GetPendingByEmail retrieves the pending invitation for an email within an organization
+	GetPendingByEmail(ctx context.Context, orgID uuid.UUID, email string) (*entities.StaffInvitation, error)
+
+	// GetByOrganizationID retrieves all invitations for an organization, newest first
+	GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.StaffInvitation, error)
+
+	// Update updates an existing invitation
+	Update(ctx context.Context, invitation *entities.StaffInvitation) error
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "context"
+
+// TransactionManager runs repository operations atomically
+type TransactionManager interface {
+	// WithinTransaction executes fn in a transaction carried by the context passed to it.
+	// Repository calls made with that context join the transaction; fn returning an error
+	// rolls everything back. Nested calls reuse the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
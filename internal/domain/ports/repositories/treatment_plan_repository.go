@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// TreatmentPlanRepository defines the interface for treatment plans and their procedures.
+// Plans are always loaded with their items ordered by phase and position.
+type TreatmentPlanRepository interface {
+	// Create stores a plan and its items
+	Create(ctx context.Context, plan *entities.TreatmentPlan) error
+
+	// GetByID retrieves a plan of the organization
+	GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.TreatmentPlan, error)
+
+	// GetByItemID retrieves the plan of the organization containing an item
+	GetByItemID(ctx context.Context, orgID, itemID uuid.UUID) (*entities.TreatmentPlan, error)
+
+	// GetByAppointmentID retrieves the plan with an item booked in the appointment
+	GetByAppointmentID(ctx context.Context, appointmentID uuid.UUID) (*entities.TreatmentPlan, error)
+
+	// ListByPatient retrieves the patient's plans, most recent first
+	ListByPatient(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.TreatmentPlan, error)
+
+	// Update saves the plan fields (not its items)
+	Update(ctx context.Context, plan *entities.TreatmentPlan) error
+
+	// CreateItem stores a new item of a plan
+	CreateItem(ctx context.Context, item *entities.TreatmentPlanItem) error
+
+	// UpdateItem saves an item
+	UpdateItem(ctx context.Context, item *entities.TreatmentPlanItem) error
+
+	// DeleteItem removes an item of a plan
+	DeleteItem(ctx context.Context, planID, itemID uuid.UUID) error
+
+	// MoveAppointment re-points the item booked in one appointment to another (rescheduling)
+	MoveAppointment(ctx context.Context, fromAppointmentID, toAppointmentID uuid.UUID) error
+
+	// ReassignPatient moves all plans of one patient to another
+	ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error
+}
@@ -26,4 +26,7 @@ type UserRepository interface {
 
 	// Update updates an existing profile
 	Update(ctx context.Context, profile *entities.Profile) error
+
+	// GetByOrganizationID retrieves all profiles that belong to an organization
+	GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.Profile, error)
 }
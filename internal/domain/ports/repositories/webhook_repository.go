@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription data operations
+type WebhookSubscriptionRepository interface {
+	// Create creates a new subscription
+	Create(ctx context.Context, subscription *entities.WebhookSubscription) error
+
+	// GetByID retrieves a subscription by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+
+	// GetByOrganizationID retrieves all subscriptions for an organization
+	GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.WebhookSubscription, error)
+
+	// GetActiveByOrganizationID retrieves the active subscriptions for an organization
+	GetActiveByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.WebhookSubscription, error)
+
+	// Update updates an existing subscription
+	Update(ctx context.Context, subscription *entities.WebhookSubscription) error
+
+	// Delete deletes a subscription and its deliveries
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// WebhookDeliveryFilters represents filters for listing deliveries
+type WebhookDeliveryFilters struct {
+	Status *entities.WebhookDeliveryStatus
+	Limit  int
+	Offset int
+}
+
+// WebhookDeliveryRepository defines the interface for webhook delivery (outbox) data operations
+type WebhookDeliveryRepository interface {
+	// Create creates a new delivery; a duplicate (subscription, event) pair is ignored
+	Create(ctx context.Context, delivery *entities.WebhookDelivery) error
+
+	// GetByID retrieves a delivery by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error)
+
+	// GetBySubscriptionID retrieves deliveries of a subscription, newest first
+	GetBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, filters WebhookDeliveryFilters) ([]*entities.WebhookDelivery, int, error)
+
+	// ClaimDue locks up to limit pending deliveries due at now and postpones them by lease,
+	// so concurrent workers do not send the same delivery twice
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error)
+
+	// Update updates the delivery state after an attempt or replay
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+}
@@ -75,6 +75,51 @@ func (acc *AppointmentConflictChecker) CheckForConflicts(
 		}
 	}
 
+	return acc.CheckResourceConflicts(ctx, appointment)
+}
+
+// CheckResourceConflicts checks the resources an appointment books besides its doctor and unit:
+// none may be held by another appointment, and staff members must be available like the doctor
+func (acc *AppointmentConflictChecker) CheckResourceConflicts(
+	ctx context.Context,
+	appointment *entities.Appointment,
+) error {
+	for _, resource := range appointment.Resources {
+		hasConflict, err := acc.appointmentRepo.CheckResourceConflict(
+			ctx,
+			resource.Type,
+			resource.ResourceID,
+			appointment.StartTime,
+			appointment.EndTime,
+			&appointment.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		if hasConflict {
+			return entities.ErrAppointmentResourceConflict
+		}
+
+		if resource.Type != entities.ResourceTypeStaff {
+			continue
+		}
+
+		isAvailable, err := acc.availabilityRepo.IsAvailable(
+			ctx,
+			resource.ResourceID,
+			appointment.StartTime,
+			appointment.EndTime,
+		)
+		if err != nil {
+			return err
+		}
+
+		if !isAvailable {
+			return entities.ErrStaffNotAvailable
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// memoryAppointmentRepo answers conflict checks from a list of appointments, applying the same
+// rules as the database: only appointments holding their slot block their doctor, unit and
+// additional resources
+type memoryAppointmentRepo struct {
+	repositories.AppointmentRepository
+	appointments []*entities.Appointment
+}
+
+func (r *memoryAppointmentRepo) holds(appointment *entities.Appointment, resourceType entities.ResourceType, resourceID uuid.UUID) bool {
+	switch {
+	case resourceType == entities.ResourceTypeStaff && appointment.DoctorID != nil && *appointment.DoctorID == resourceID:
+		return true
+	case resourceType == entities.ResourceTypeUnit && appointment.UnitID != nil && *appointment.UnitID == resourceID:
+		return true
+	}
+	for _, resource := range appointment.Resources {
+		if resource.Type == resourceType && resource.ResourceID == resourceID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryAppointmentRepo) CheckResourceConflict(ctx context.Context, resourceType entities.ResourceType, resourceID uuid.UUID, startTime, endTime time.Time, excludeAppointmentID *uuid.UUID) (bool, error) {
+	for _, appointment := range r.appointments {
+		if excludeAppointmentID != nil && appointment.ID == *excludeAppointmentID {
+			continue
+		}
+		if appointment.HoldsSlot() && appointment.StartTime.Before(endTime) && appointment.EndTime.After(startTime) &&
+			r.holds(appointment, resourceType, resourceID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryAppointmentRepo) CheckConflict(ctx context.Context, doctorID, unitID uuid.UUID, startTime, endTime time.Time, excludeAppointmentID *uuid.UUID) (bool, error) {
+	if conflict, err := r.CheckResourceConflict(ctx, entities.ResourceTypeStaff, doctorID, startTime, endTime, excludeAppointmentID); conflict || err != nil {
+		return conflict, err
+	}
+	return r.CheckResourceConflict(ctx, entities.ResourceTypeUnit, unitID, startTime, endTime, excludeAppointmentID)
+}
+
+// memoryAvailabilityRepo reports every doctor available except the unavailable ones
+type memoryAvailabilityRepo struct {
+	repositories.DoctorAvailabilityRepository
+	unavailable map[uuid.UUID]bool
+}
+
+func (r *memoryAvailabilityRepo) IsAvailable(ctx context.Context, doctorID uuid.UUID, startTime, endTime time.Time) (bool, error) {
+	return !r.unavailable[doctorID], nil
+}
+
+func TestAppointmentConflictCheckerResources(t *testing.T) {
+	start := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	doctorID, unitID := uuid.New(), uuid.New()
+	assistantID, offDutyID := uuid.New(), uuid.New()
+	laserID, microscopeID := uuid.New(), uuid.New()
+
+	booked := func(status entities.AppointmentStatus, resources ...*entities.AppointmentResource) *entities.Appointment {
+		otherDoctor, otherUnit := uuid.New(), uuid.New()
+		appointment := &entities.Appointment{
+			ID:        uuid.New(),
+			DoctorID:  &otherDoctor,
+			UnitID:    &otherUnit,
+			Status:    status,
+			StartTime: start.Add(30 * time.Minute),
+			EndTime:   start.Add(90 * time.Minute),
+		}
+		if err := appointment.SetResources(resources); err != nil {
+			t.Fatalf("SetResources() error = %v", err)
+		}
+		return appointment
+	}
+	// The original of an appointment rebooked from the queue, in the same slot with the same
+	// doctor and unit
+	rescheduledOriginal := func() *entities.Appointment {
+		replacementID := uuid.New()
+		appointment := &entities.Appointment{
+			ID:        uuid.New(),
+			DoctorID:  &doctorID,
+			UnitID:    &unitID,
+			Status:    entities.AppointmentStatusNeedsRescheduling,
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+		}
+		appointment.LinkToRescheduledAppointment(replacementID)
+		return appointment
+	}
+	resource := func(resourceType entities.ResourceType, id uuid.UUID) *entities.AppointmentResource {
+		var role *entities.AppointmentRole
+		if resourceType == entities.ResourceTypeStaff {
+			assistant := entities.AppointmentRoleAssistant
+			role = &assistant
+		}
+		r, err := entities.NewAppointmentResource(resourceType, id, role)
+		if err != nil {
+			t.Fatalf("NewAppointmentResource() error = %v", err)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		existing []*entities.Appointment
+		books    []*entities.AppointmentResource
+		want     error
+	}{
+		{
+			name:     "free equipment",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusScheduled, resource(entities.ResourceTypeEquipment, microscopeID))},
+			books:    []*entities.AppointmentResource{resource(entities.ResourceTypeEquipment, laserID)},
+			want:     nil,
+		},
+		{
+			name:     "equipment held by a scheduled appointment",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusScheduled, resource(entities.ResourceTypeEquipment, laserID))},
+			books:    []*entities.AppointmentResource{resource(entities.ResourceTypeEquipment, laserID)},
+			want:     entities.ErrAppointmentResourceConflict,
+		},
+		{
+			name:     "equipment held by a confirmed appointment",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusConfirmed, resource(entities.ResourceTypeEquipment, laserID))},
+			books:    []*entities.AppointmentResource{resource(entities.ResourceTypeEquipment, laserID)},
+			want:     entities.ErrAppointmentResourceConflict,
+		},
+		{
+			name:     "assistant held by a confirmed appointment",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusConfirmed, resource(entities.ResourceTypeStaff, assistantID))},
+			books:    []*entities.AppointmentResource{resource(entities.ResourceTypeStaff, assistantID)},
+			want:     entities.ErrAppointmentResourceConflict,
+		},
+		{
+			name:     "equipment released by a rescheduled original",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusRescheduled, resource(entities.ResourceTypeEquipment, laserID))},
+			books:    []*entities.AppointmentResource{resource(entities.ResourceTypeEquipment, laserID)},
+			want:     nil,
+		},
+		{
+			name:     "doctor and unit released by a rescheduled original",
+			existing: []*entities.Appointment{rescheduledOriginal()},
+			books:    []*entities.AppointmentResource{resource(entities.ResourceTypeEquipment, laserID)},
+			want:     nil,
+		},
+		{
+			name:     "equipment released by a cancelled appointment",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusCancelled, resource(entities.ResourceTypeEquipment, laserID))},
+			books:    []*entities.AppointmentResource{resource(entities.ResourceTypeEquipment, laserID)},
+			want:     nil,
+		},
+		{
+			name:     "equipment released by an appointment waiting to be rescheduled",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusNeedsRescheduling, resource(entities.ResourceTypeEquipment, laserID))},
+			books:    []*entities.AppointmentResource{resource(entities.ResourceTypeEquipment, laserID)},
+			want:     nil,
+		},
+		{
+			name:     "extra unit held as another appointment's own unit",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusConfirmed)},
+			want:     nil,
+		},
+		{
+			name:  "staff member off schedule",
+			books: []*entities.AppointmentResource{resource(entities.ResourceTypeStaff, offDutyID)},
+			want:  entities.ErrStaffNotAvailable,
+		},
+		{
+			name:     "own doctor booked as extra staff elsewhere",
+			existing: []*entities.Appointment{booked(entities.AppointmentStatusConfirmed, resource(entities.ResourceTypeStaff, doctorID))},
+			want:     entities.ErrAppointmentConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewAppointmentConflictChecker(
+				&memoryAppointmentRepo{appointments: tt.existing},
+				&memoryAvailabilityRepo{unavailable: map[uuid.UUID]bool{offDutyID: true}},
+			)
+			appointment := &entities.Appointment{
+				ID:        uuid.New(),
+				DoctorID:  &doctorID,
+				UnitID:    &unitID,
+				Status:    entities.AppointmentStatusScheduled,
+				StartTime: start,
+				EndTime:   start.Add(time.Hour),
+			}
+			if err := appointment.SetResources(tt.books); err != nil {
+				t.Fatalf("SetResources() error = %v", err)
+			}
+
+			if err := checker.CheckForConflicts(context.Background(), appointment); err != tt.want {
+				t.Errorf("CheckForConflicts() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/gif" // Register decoders for the formats thumbnails are generated from
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"strings"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// ContentSniffLength is how many leading bytes DetectAttachmentContentType looks at
+const ContentSniffLength = 512
+
+// dicomPreambleLength is the size of the DICOM Part 10 preamble that precedes the "DICM" prefix
+const dicomPreambleLength = 128
+
+// DetectAttachmentContentType identifies a file from its leading bytes. It recognizes the
+// formats of http.DetectContentType plus DICOM, which radiograph software exports.
+func DetectAttachmentContentType(head []byte) string {
+	if len(head) >= dicomPreambleLength+4 && string(head[dicomPreambleLength:dicomPreambleLength+4]) == "DICM" {
+		return entities.ContentTypeDICOM
+	}
+
+	contentType := http.DetectContentType(head)
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return mediaType
+}
+
+// Thumbnail limits
+const (
+	ThumbnailMaxDimension = 320        // Longest side of a thumbnail, in pixels
+	thumbnailMaxPixels    = 80_000_000 // Larger images are not decoded, to bound memory use
+	thumbnailSamples      = 4          // Samples per axis averaged into each thumbnail pixel
+	thumbnailQuality      = 80
+)
+
+// GenerateThumbnail scales a JPEG, PNG or GIF image down so that its longest side is at most
+// maxDimension pixels and encodes it as JPEG. Smaller images keep their size.
+func GenerateThumbnail(data []byte, maxDimension int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, entities.ErrThumbnailUnsupported
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > thumbnailMaxPixels {
+		return nil, entities.ErrThumbnailUnsupported
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, entities.ErrThumbnailUnsupported
+	}
+
+	width, height := thumbnailSize(config.Width, config.Height, maxDimension)
+	thumbnail := downscale(source, width, height)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, thumbnail, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// thumbnailSize fits width x height within maxDimension, keeping the aspect ratio
+func thumbnailSize(width, height, maxDimension int) (int, int) {
+	if width <= maxDimension && height <= maxDimension {
+		return width, height
+	}
+	if width >= height {
+		return maxDimension, max(1, height*maxDimension/width)
+	}
+	return max(1, width*maxDimension/height), maxDimension
+}
+
+// downscale resizes an image by averaging a grid of samples from the source area of each
+// target pixel. Transparent areas are flattened onto white.
+func downscale(source image.Image, width, height int) *image.RGBA {
+	bounds := source.Bounds()
+	target := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var r, g, b uint64
+			for sy := 0; sy < thumbnailSamples; sy++ {
+				srcY := bounds.Min.Y + ((2*y+1)*thumbnailSamples+2*sy-thumbnailSamples+1)*bounds.Dy()/(2*height*thumbnailSamples)
+				for sx := 0; sx < thumbnailSamples; sx++ {
+					srcX := bounds.Min.X + ((2*x+1)*thumbnailSamples+2*sx-thumbnailSamples+1)*bounds.Dx()/(2*width*thumbnailSamples)
+					cr, cg, cb, ca := source.At(srcX, srcY).RGBA()
+					// Premultiplied channels plus the white showing through
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					b += uint64(cb + 0xffff - ca)
+				}
+			}
+
+			const samples = thumbnailSamples * thumbnailSamples
+			target.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / samples >> 8),
+				G: uint8(g / samples >> 8),
+				B: uint8(b / samples >> 8),
+				A: 0xff,
+			})
+		}
+	}
+
+	return target
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+func encodePNG(t *testing.T, width, height int, fill color.Color) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetectAttachmentContentType(t *testing.T) {
+	dicom := append(make([]byte, dicomPreambleLength), []byte("DICM\x02\x00")...)
+
+	tests := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{name: "png", head: encodePNG(t, 2, 2, color.Black), want: entities.ContentTypePNG},
+		{name: "pdf", head: []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3"), want: entities.ContentTypePDF},
+		{name: "dicom", head: dicom, want: entities.ContentTypeDICOM},
+		{name: "html is not trusted as an image", head: []byte("<html><script>alert(1)</script>"), want: "text/html"},
+		{name: "parameters are dropped", head: []byte("plain notes"), want: "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectAttachmentContentType(tt.head); got != tt.want {
+				t.Errorf("DetectAttachmentContentType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	tests := []struct {
+		name                  string
+		width, height         int
+		wantWidth, wantHeight int
+	}{
+		{name: "landscape panoramic", width: 1200, height: 600, wantWidth: 320, wantHeight: 160},
+		{name: "portrait photo", width: 300, height: 900, wantWidth: 106, wantHeight: 320},
+		{name: "small image keeps its size", width: 100, height: 80, wantWidth: 100, wantHeight: 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := GenerateThumbnail(encodePNG(t, tt.width, tt.height, color.NRGBA{R: 200, G: 100, B: 50, A: 255}), ThumbnailMaxDimension)
+			if err != nil {
+				t.Fatalf("GenerateThumbnail() error = %v", err)
+			}
+
+			thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("thumbnail is not a JPEG: %v", err)
+			}
+			if size := thumbnail.Bounds().Size(); size.X != tt.wantWidth || size.Y != tt.wantHeight {
+				t.Errorf("thumbnail size = %dx%d, want %dx%d", size.X, size.Y, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestGenerateThumbnailFlattensTransparency(t *testing.T) {
+	data, err := GenerateThumbnail(encodePNG(t, 40, 40, color.NRGBA{}), ThumbnailMaxDimension)
+	if err != nil {
+		t.Fatalf("GenerateThumbnail() error = %v", err)
+	}
+	thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	if r, g, b, _ := thumbnail.At(20, 20).RGBA(); r < 0xf000 || g < 0xf000 || b < 0xf000 {
+		t.Errorf("transparent pixel = (%x, %x, %x), want white", r, g, b)
+	}
+}
+
+func TestGenerateThumbnailRejectsNonImages(t *testing.T) {
+	if _, err := GenerateThumbnail([]byte("%PDF-1.7"), ThumbnailMaxDimension); err != entities.ErrThumbnailUnsupported {
+		t.Errorf("GenerateThumbnail() error = %v, want %v", err, entities.ErrThumbnailUnsupported)
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// CashCloseMethodTotals are the money movements of one payment method
+type CashCloseMethodTotals struct {
+	Method   entities.PaymentMethod
+	Payments int64 // Received
+	Refunds  int64 // Given back
+	Net      int64 // Payments minus refunds: what should be in the drawer or account
+	Count    int   // Ledger entries
+}
+
+// CashCloseSummary totals a clinic's payments ledger over a business day
+type CashCloseSummary struct {
+	Methods  []CashCloseMethodTotals // Every method, in entities.PaymentMethods order
+	Payments int64
+	Refunds  int64
+	Net      int64
+	Count    int
+}
+
+// SummarizeCashClose totals ledger entries by payment method
+func SummarizeCashClose(entries []*entities.Payment) CashCloseSummary {
+	summary := CashCloseSummary{Methods: make([]CashCloseMethodTotals, len(entities.PaymentMethods))}
+	index := make(map[entities.PaymentMethod]int, len(entities.PaymentMethods))
+	for i, method := range entities.PaymentMethods {
+		summary.Methods[i].Method = method
+		index[method] = i
+	}
+
+	for _, entry := range entries {
+		i, ok := index[entry.Method]
+		if !ok {
+			continue
+		}
+		totals := &summary.Methods[i]
+		if entry.IsRefund() {
+			totals.Refunds += entry.Amount
+			summary.Refunds += entry.Amount
+		} else {
+			totals.Payments += entry.Amount
+			summary.Payments += entry.Amount
+		}
+		totals.Net = totals.Payments - totals.Refunds
+		totals.Count++
+		summary.Count++
+	}
+	summary.Net = summary.Payments - summary.Refunds
+
+	return summary
+}
+
+// PatientBalance summarizes what a patient was billed and still owes
+type PatientBalance struct {
+	Invoiced     int64               // Total of the non-void invoices
+	Paid         int64               // Received on them
+	Refunded     int64               // Given back from them
+	BalanceDue   int64               // Invoiced minus paid plus refunded
+	OpenInvoices []*entities.Invoice // Non-void invoices with a balance due, in the given order
+}
+
+// SummarizePatientBalance computes the balance of a patient's invoices
+func SummarizePatientBalance(invoices []*entities.Invoice) PatientBalance {
+	balance := PatientBalance{OpenInvoices: []*entities.Invoice{}}
+
+	for _, invoice := range invoices {
+		if invoice.IsVoid() {
+			continue
+		}
+		balance.Invoiced += invoice.Total
+		balance.Paid += invoice.AmountPaid
+		balance.Refunded += invoice.AmountRefunded
+		if invoice.BalanceDue() > 0 {
+			balance.OpenInvoices = append(balance.OpenInvoices, invoice)
+		}
+	}
+	balance.BalanceDue = balance.Invoiced - balance.Paid + balance.Refunded
+
+	return balance
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+func TestSummarizeCashClose(t *testing.T) {
+	entry := func(kind entities.PaymentKind, method entities.PaymentMethod, amount int64) *entities.Payment {
+		return &entities.Payment{Kind: kind, Method: method, Amount: amount}
+	}
+
+	summary := SummarizeCashClose([]*entities.Payment{
+		entry(entities.PaymentKindPayment, entities.PaymentMethodCash, 50000),
+		entry(entities.PaymentKindPayment, entities.PaymentMethodCash, 20000),
+		entry(entities.PaymentKindRefund, entities.PaymentMethodCash, 5000),
+		entry(entities.PaymentKindPayment, entities.PaymentMethodCard, 120000),
+	})
+
+	if summary.Payments != 190000 || summary.Refunds != 5000 || summary.Net != 185000 || summary.Count != 4 {
+		t.Errorf("unexpected totals: %+v", summary)
+	}
+	if len(summary.Methods) != 3 {
+		t.Fatalf("expected every method, got %d", len(summary.Methods))
+	}
+
+	cash, card, transfer := summary.Methods[0], summary.Methods[1], summary.Methods[2]
+	if cash.Method != entities.PaymentMethodCash || cash.Payments != 70000 || cash.Refunds != 5000 || cash.Net != 65000 || cash.Count != 3 {
+		t.Errorf("unexpected cash totals: %+v", cash)
+	}
+	if card.Net != 120000 || card.Count != 1 {
+		t.Errorf("unexpected card totals: %+v", card)
+	}
+	if transfer.Method != entities.PaymentMethodTransfer || transfer.Count != 0 || transfer.Net != 0 {
+		t.Errorf("expected an empty transfer row, got %+v", transfer)
+	}
+}
+
+func TestSummarizePatientBalance(t *testing.T) {
+	paid := &entities.Invoice{Status: entities.InvoiceStatusPaid, Total: 80000, AmountPaid: 80000}
+	partial := &entities.Invoice{Status: entities.InvoiceStatusPartiallyPaid, Total: 150000, AmountPaid: 100000, AmountRefunded: 20000}
+	void := &entities.Invoice{Status: entities.InvoiceStatusVoid, Total: 99999}
+
+	balance := SummarizePatientBalance([]*entities.Invoice{paid, partial, void})
+
+	if balance.Invoiced != 230000 || balance.Paid != 180000 || balance.Refunded != 20000 || balance.BalanceDue != 70000 {
+		t.Errorf("unexpected balance: %+v", balance)
+	}
+	if len(balance.OpenInvoices) != 1 || balance.OpenInvoices[0] != partial {
+		t.Errorf("expected only the partially paid invoice to be open, got %v", balance.OpenInvoices)
+	}
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// CancellationAssessment is the outcome of applying a cancellation policy to a cancellation
+type CancellationAssessment struct {
+	Late          bool  // Cancelled with less notice than the policy requires
+	Exempt        bool  // Late, but the reason's category is never charged
+	NoticeMinutes int   // Notice given; negative when cancelled after the start
+	Amount        int64 // Fee to charge in minor units; zero when there is nothing to charge
+}
+
+// AssessCancellation applies an organization's policy to an appointment cancelled at cancelledAt.
+// category is the category of the cancellation reason, nil when none was given; price is the
+// price of the appointment's procedure in minor units, nil when unknown, which a percentage fee
+// needs to charge anything.
+func AssessCancellation(policy *entities.CancellationPolicy, start, cancelledAt time.Time, category *entities.CancellationCategory, price *int64) CancellationAssessment {
+	notice := start.Sub(cancelledAt)
+	assessment := CancellationAssessment{NoticeMinutes: int(notice / time.Minute)}
+	if policy == nil || !policy.Enabled {
+		return assessment
+	}
+
+	assessment.Late = notice < time.Duration(policy.NoticeHours)*time.Hour
+	if !assessment.Late {
+		return assessment
+	}
+	if category != nil && policy.IsExempt(*category) {
+		assessment.Exempt = true
+		return assessment
+	}
+
+	switch policy.FeeType {
+	case entities.CancellationFeeTypeFixed:
+		assessment.Amount = policy.FeeAmount
+	case entities.CancellationFeeTypePercentage:
+		if price != nil && *price > 0 {
+			assessment.Amount = (*price*int64(policy.FeePercent) + 50) / 100
+		}
+	}
+	return assessment
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+func TestAssessCancellation(t *testing.T) {
+	start := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	patientRequest := entities.CancellationCategoryPatientRequest
+	emergency := entities.CancellationCategoryEmergency
+	price := int64(120050)
+
+	fixed := &entities.CancellationPolicy{
+		Enabled:          true,
+		NoticeHours:      24,
+		FeeType:          entities.CancellationFeeTypeFixed,
+		FeeAmount:        50000,
+		ExemptCategories: []entities.CancellationCategory{entities.CancellationCategoryEmergency},
+	}
+	percentage := *fixed
+	percentage.FeeType = entities.CancellationFeeTypePercentage
+	percentage.FeePercent = 25
+	disabled := *fixed
+	disabled.Enabled = false
+
+	tests := []struct {
+		name        string
+		policy      *entities.CancellationPolicy
+		cancelledAt time.Time
+		category    *entities.CancellationCategory
+		price       *int64
+		late        bool
+		exempt      bool
+		notice      int
+		amount      int64
+	}{
+		{"no policy", nil, start.Add(-time.Hour), &patientRequest, &price, false, false, 60, 0},
+		{"disabled policy", &disabled, start.Add(-time.Hour), &patientRequest, &price, false, false, 60, 0},
+		{"enough notice", fixed, start.Add(-24 * time.Hour), &patientRequest, &price, false, false, 1440, 0},
+		{"late fixed fee", fixed, start.Add(-23 * time.Hour), &patientRequest, &price, true, false, 1380, 50000},
+		{"after the start", fixed, start.Add(30 * time.Minute), &patientRequest, &price, true, false, -30, 50000},
+		{"no reason is not exempt", fixed, start.Add(-time.Hour), nil, nil, true, false, 60, 50000},
+		{"exempt category", fixed, start.Add(-time.Hour), &emergency, &price, true, true, 60, 0},
+		{"percentage rounds half up", &percentage, start.Add(-time.Hour), &patientRequest, &price, true, false, 60, 30013},
+		{"percentage without a price", &percentage, start.Add(-time.Hour), &patientRequest, nil, true, false, 60, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AssessCancellation(tt.policy, start, tt.cancelledAt, tt.category, tt.price)
+			if got.Late != tt.late || got.Exempt != tt.exempt || got.NoticeMinutes != tt.notice || got.Amount != tt.amount {
+				t.Errorf("AssessCancellation() = %+v, want late %v, exempt %v, notice %d, amount %d", got, tt.late, tt.exempt, tt.notice, tt.amount)
+			}
+		})
+	}
+}
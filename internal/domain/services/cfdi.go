@@ -0,0 +1,273 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// CFDI 4.0 catalog values used for invoices to patients
+const (
+	cfdiVersion         = "4.0"
+	cfdiIncome          = "I"   // TipoDeComprobante: ingreso
+	cfdiNoExport        = "01"  // Exportacion: no aplica
+	cfdiTaxObject       = "02"  // ObjetoImp: sí objeto de impuesto
+	cfdiIVA             = "002" // Impuesto: IVA
+	cfdiServiceUnit     = "E48" // ClaveUnidad: unidad de servicio
+	cfdiCurrency        = "MXN"
+	cfdiSinglePayment   = "PUE" // MetodoPago: pago en una sola exhibición
+	cfdiInstallments    = "PPD" // MetodoPago: pago en parcialidades o diferido
+	cfdiFormToBeDefined = "99"  // FormaPago: por definir
+)
+
+// cfdiPaymentForms maps payment methods to c_FormaPago codes. Card payments are reported as
+// credit card since the ledger does not tell credit from debit.
+var cfdiPaymentForms = map[entities.PaymentMethod]string{
+	entities.PaymentMethodCash:     "01",
+	entities.PaymentMethodTransfer: "03",
+	entities.PaymentMethodCard:     "04",
+}
+
+// CFDIInput is what the CFDI of an invoice is built from
+type CFDIInput struct {
+	Invoice  *entities.Invoice
+	Payments []*entities.Payment // Ledger of the invoice, for the payment form
+	Issuer   *entities.IssuerFiscalProfile
+	Receiver *entities.PatientFiscalProfile
+	IssuedAt time.Time // In the timezone of the place of issue
+}
+
+type cfdiComprobante struct {
+	XMLName           xml.Name       `xml:"cfdi:Comprobante"`
+	XMLNSCFDI         string         `xml:"xmlns:cfdi,attr"`
+	XMLNSXSI          string         `xml:"xmlns:xsi,attr"`
+	SchemaLocation    string         `xml:"xsi:schemaLocation,attr"`
+	Version           string         `xml:"Version,attr"`
+	Serie             string         `xml:"Serie,attr,omitempty"`
+	Folio             string         `xml:"Folio,attr"`
+	Fecha             string         `xml:"Fecha,attr"`
+	FormaPago         string         `xml:"FormaPago,attr"`
+	SubTotal          string         `xml:"SubTotal,attr"`
+	Descuento         string         `xml:"Descuento,attr,omitempty"`
+	Moneda            string         `xml:"Moneda,attr"`
+	Total             string         `xml:"Total,attr"`
+	TipoDeComprobante string         `xml:"TipoDeComprobante,attr"`
+	Exportacion       string         `xml:"Exportacion,attr"`
+	MetodoPago        string         `xml:"MetodoPago,attr"`
+	LugarExpedicion   string         `xml:"LugarExpedicion,attr"`
+	Emisor            cfdiEmisor     `xml:"cfdi:Emisor"`
+	Receptor          cfdiReceptor   `xml:"cfdi:Receptor"`
+	Conceptos         []cfdiConcepto `xml:"cfdi:Conceptos>cfdi:Concepto"`
+	Impuestos         cfdiImpuestos  `xml:"cfdi:Impuestos"`
+}
+
+type cfdiEmisor struct {
+	Rfc           string `xml:"Rfc,attr"`
+	Nombre        string `xml:"Nombre,attr"`
+	RegimenFiscal string `xml:"RegimenFiscal,attr"`
+}
+
+type cfdiReceptor struct {
+	Rfc                     string `xml:"Rfc,attr"`
+	Nombre                  string `xml:"Nombre,attr"`
+	DomicilioFiscalReceptor string `xml:"DomicilioFiscalReceptor,attr"`
+	RegimenFiscalReceptor   string `xml:"RegimenFiscalReceptor,attr"`
+	UsoCFDI                 string `xml:"UsoCFDI,attr"`
+}
+
+type cfdiConcepto struct {
+	ClaveProdServ    string         `xml:"ClaveProdServ,attr"`
+	NoIdentificacion string         `xml:"NoIdentificacion,attr,omitempty"`
+	Cantidad         string         `xml:"Cantidad,attr"`
+	ClaveUnidad      string         `xml:"ClaveUnidad,attr"`
+	Descripcion      string         `xml:"Descripcion,attr"`
+	ValorUnitario    string         `xml:"ValorUnitario,attr"`
+	Importe          string         `xml:"Importe,attr"`
+	Descuento        string         `xml:"Descuento,attr,omitempty"`
+	ObjetoImp        string         `xml:"ObjetoImp,attr"`
+	Traslados        []cfdiTraslado `xml:"cfdi:Impuestos>cfdi:Traslados>cfdi:Traslado"`
+}
+
+type cfdiImpuestos struct {
+	TotalImpuestosTrasladados string         `xml:"TotalImpuestosTrasladados,attr,omitempty"`
+	Traslados                 []cfdiTraslado `xml:"cfdi:Traslados>cfdi:Traslado"`
+}
+
+type cfdiTraslado struct {
+	Base       string `xml:"Base,attr"`
+	Impuesto   string `xml:"Impuesto,attr"`
+	TipoFactor string `xml:"TipoFactor,attr"`
+	TasaOCuota string `xml:"TasaOCuota,attr,omitempty"` // Not set for exempt lines
+	Importe    string `xml:"Importe,attr,omitempty"`
+}
+
+// BuildCFDI builds the unsigned CFDI 4.0 XML of an invoice, ready for a PAC to seal and stamp.
+// Lines with a tax rate carry IVA at that rate; lines without one are IVA-exempt, as
+// professional medical and dental services are.
+func BuildCFDI(input CFDIInput) ([]byte, error) {
+	invoice := input.Invoice
+	if invoice.IsVoid() {
+		return nil, entities.ErrInvoiceVoided
+	}
+	if invoice.Currency != cfdiCurrency {
+		return nil, entities.ErrCFDICurrencyNotSupported
+	}
+
+	form, method := CFDIPaymentTerms(invoice, input.Payments)
+	serie, folio := splitInvoiceNumber(invoice.Number)
+
+	comprobante := cfdiComprobante{
+		XMLNSCFDI:         "http://www.sat.gob.mx/cfd/4",
+		XMLNSXSI:          "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation:    "http://www.sat.gob.mx/cfd/4 http://www.sat.gob.mx/sitio_internet/cfd/4/cfdv40.xsd",
+		Version:           cfdiVersion,
+		Serie:             serie,
+		Folio:             folio,
+		Fecha:             input.IssuedAt.Format("2006-01-02T15:04:05"),
+		FormaPago:         form,
+		SubTotal:          formatCFDIAmount(invoice.Subtotal),
+		Descuento:         optionalCFDIAmount(invoice.DiscountTotal),
+		Moneda:            cfdiCurrency,
+		Total:             formatCFDIAmount(invoice.Total),
+		TipoDeComprobante: cfdiIncome,
+		Exportacion:       cfdiNoExport,
+		MetodoPago:        method,
+		LugarExpedicion:   input.Issuer.PostalCode,
+		Emisor: cfdiEmisor{
+			Rfc:           input.Issuer.RFC,
+			Nombre:        input.Issuer.LegalName,
+			RegimenFiscal: input.Issuer.TaxRegime,
+		},
+		Receptor: cfdiReceptor{
+			Rfc:                     input.Receiver.RFC,
+			Nombre:                  input.Receiver.LegalName,
+			DomicilioFiscalReceptor: input.Receiver.PostalCode,
+			RegimenFiscalReceptor:   input.Receiver.TaxRegime,
+			UsoCFDI:                 input.Receiver.CFDIUse,
+		},
+	}
+
+	type taxGroup struct {
+		rate      int
+		base, tax int64
+	}
+	groups := map[int]*taxGroup{}
+	var totalTax int64
+	taxed := false
+	for _, line := range invoice.Lines {
+		concepto := cfdiConcepto{
+			ClaveProdServ: input.Issuer.ProductCode,
+			Cantidad:      fmt.Sprint(line.Quantity),
+			ClaveUnidad:   cfdiServiceUnit,
+			Descripcion:   line.Description,
+			ValorUnitario: formatCFDIAmount(line.UnitPrice),
+			Importe:       formatCFDIAmount(line.Amount()),
+			Descuento:     optionalCFDIAmount(line.Discount),
+			ObjetoImp:     cfdiTaxObject,
+			Traslados:     []cfdiTraslado{cfdiLineTax(line.Amount()-line.Discount, line.TaxRate, line.Tax)},
+		}
+		if line.ServiceID != nil {
+			concepto.NoIdentificacion = *line.ServiceID
+		}
+		comprobante.Conceptos = append(comprobante.Conceptos, concepto)
+
+		group := groups[line.TaxRate]
+		if group == nil {
+			group = &taxGroup{rate: line.TaxRate}
+			groups[line.TaxRate] = group
+		}
+		group.base += line.Amount() - line.Discount
+		group.tax += line.Tax
+		if line.TaxRate > 0 {
+			taxed = true
+			totalTax += line.Tax
+		}
+	}
+
+	rates := make([]int, 0, len(groups))
+	for rate := range groups {
+		rates = append(rates, rate)
+	}
+	sort.Ints(rates)
+	for _, rate := range rates {
+		group := groups[rate]
+		comprobante.Impuestos.Traslados = append(comprobante.Impuestos.Traslados, cfdiLineTax(group.base, group.rate, group.tax))
+	}
+	if taxed {
+		comprobante.Impuestos.TotalImpuestosTrasladados = formatCFDIAmount(totalTax)
+	}
+
+	body, err := xml.Marshal(comprobante)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CFDI XML: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// CFDIPaymentTerms returns the payment form and method of an invoice: paid invoices are
+// single payments in the method that collected the most, the rest are paid in installments
+// with the form to be defined
+func CFDIPaymentTerms(invoice *entities.Invoice, payments []*entities.Payment) (form, method string) {
+	if invoice.Status != entities.InvoiceStatusPaid {
+		return cfdiFormToBeDefined, cfdiInstallments
+	}
+
+	net := map[entities.PaymentMethod]int64{}
+	for _, payment := range payments {
+		if payment.IsRefund() {
+			net[payment.Method] -= payment.Amount
+		} else {
+			net[payment.Method] += payment.Amount
+		}
+	}
+
+	form = cfdiFormToBeDefined
+	var best int64
+	for _, paymentMethod := range entities.PaymentMethods {
+		if net[paymentMethod] > best {
+			best = net[paymentMethod]
+			form = cfdiPaymentForms[paymentMethod]
+		}
+	}
+	return form, cfdiSinglePayment
+}
+
+// cfdiLineTax builds the IVA transfer of a taxable base: at the rate, or exempt without one
+func cfdiLineTax(base int64, rate int, tax int64) cfdiTraslado {
+	traslado := cfdiTraslado{
+		Base:     formatCFDIAmount(base),
+		Impuesto: cfdiIVA,
+	}
+	if rate == 0 {
+		traslado.TipoFactor = "Exento"
+		return traslado
+	}
+	traslado.TipoFactor = "Tasa"
+	traslado.TasaOCuota = fmt.Sprintf("%d.%06d", rate/entities.MaxTaxRate, rate%entities.MaxTaxRate*100)
+	traslado.Importe = formatCFDIAmount(tax)
+	return traslado
+}
+
+// splitInvoiceNumber splits INV-000042 into the series INV and the folio 000042
+func splitInvoiceNumber(number string) (serie, folio string) {
+	if i := strings.LastIndex(number, "-"); i > 0 {
+		return number[:i], number[i+1:]
+	}
+	return "", number
+}
+
+// formatCFDIAmount formats minor units of MXN with two decimals
+func formatCFDIAmount(amount int64) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+func optionalCFDIAmount(amount int64) string {
+	if amount == 0 {
+		return ""
+	}
+	return formatCFDIAmount(amount)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func cfdiTestInput(t *testing.T) CFDIInput {
+	t.Helper()
+
+	serviceID := "svc-cleaning"
+	cleaning, _ := entities.NewInvoiceLine("Limpieza dental", 1, 80000, 10000, 0)
+	cleaning.ServiceID = &serviceID
+	whitening, _ := entities.NewInvoiceLine("Blanqueamiento & pulido", 2, 50000, 0, 1600)
+
+	invoice, err := entities.NewInvoice(uuid.New(), uuid.New(), uuid.New(), nil, "INV-000042", "MXN", []*entities.InvoiceLine{cleaning, whitening}, nil)
+	if err != nil {
+		t.Fatalf("NewInvoice() error = %v", err)
+	}
+
+	issuer, err := entities.NewIssuerFiscalProfile(invoice.OrganizationID, entities.FiscalIdentity{RFC: "CDS150101AB1", LegalName: "Clínica Dental Sonrisa", TaxRegime: "601", PostalCode: "44100"}, "")
+	if err != nil {
+		t.Fatalf("NewIssuerFiscalProfile() error = %v", err)
+	}
+	receiver, err := entities.NewPatientFiscalProfile(invoice.OrganizationID, invoice.PatientID, entities.FiscalIdentity{RFC: "GODE561231GR8", LegalName: "Ernesto Gómez Díaz", TaxRegime: "612", PostalCode: "06600"}, "D01")
+	if err != nil {
+		t.Fatalf("NewPatientFiscalProfile() error = %v", err)
+	}
+
+	return CFDIInput{
+		Invoice:  invoice,
+		Issuer:   issuer,
+		Receiver: receiver,
+		IssuedAt: time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestBuildCFDI(t *testing.T) {
+	input := cfdiTestInput(t)
+
+	xmlBytes, err := BuildCFDI(input)
+	if err != nil {
+		t.Fatalf("BuildCFDI() error = %v", err)
+	}
+	doc := string(xmlBytes)
+
+	for _, want := range []string{
+		`<cfdi:Comprobante xmlns:cfdi="http://www.sat.gob.mx/cfd/4"`,
+		`Version="4.0" Serie="INV" Folio="000042" Fecha="2024-03-05T10:30:00" FormaPago="99"`,
+		`SubTotal="1800.00" Descuento="100.00" Moneda="MXN" Total="1860.00" TipoDeComprobante="I" Exportacion="01" MetodoPago="PPD" LugarExpedicion="44100"`,
+		`<cfdi:Emisor Rfc="CDS150101AB1" Nombre="CLÍNICA DENTAL SONRISA" RegimenFiscal="601">`,
+		`<cfdi:Receptor Rfc="GODE561231GR8" Nombre="ERNESTO GÓMEZ DÍAZ" DomicilioFiscalReceptor="06600" RegimenFiscalReceptor="612" UsoCFDI="D01">`,
+		`<cfdi:Concepto ClaveProdServ="85121600" NoIdentificacion="svc-cleaning" Cantidad="1" ClaveUnidad="E48" Descripcion="Limpieza dental" ValorUnitario="800.00" Importe="800.00" Descuento="100.00" ObjetoImp="02">`,
+		`<cfdi:Traslado Base="700.00" Impuesto="002" TipoFactor="Exento">`,
+		`Descripcion="Blanqueamiento &amp; pulido" ValorUnitario="500.00" Importe="1000.00" ObjetoImp="02">`,
+		`<cfdi:Traslado Base="1000.00" Impuesto="002" TipoFactor="Tasa" TasaOCuota="0.160000" Importe="160.00">`,
+		`<cfdi:Impuestos TotalImpuestosTrasladados="160.00">`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("CFDI is missing %s\n%s", want, doc)
+		}
+	}
+
+	input.Invoice.Currency = "USD"
+	if _, err := BuildCFDI(input); err != entities.ErrCFDICurrencyNotSupported {
+		t.Errorf("USD invoice error = %v, want %v", err, entities.ErrCFDICurrencyNotSupported)
+	}
+}
+
+func TestBuildCFDIExemptOnly(t *testing.T) {
+	input := cfdiTestInput(t)
+	input.Invoice.Lines = input.Invoice.Lines[:1]
+
+	xmlBytes, err := BuildCFDI(input)
+	if err != nil {
+		t.Fatalf("BuildCFDI() error = %v", err)
+	}
+	if strings.Contains(string(xmlBytes), "TotalImpuestosTrasladados") {
+		t.Error("exempt-only CFDI should not total transferred taxes")
+	}
+}
+
+func TestCFDIPaymentTerms(t *testing.T) {
+	entry := func(kind entities.PaymentKind, method entities.PaymentMethod, amount int64) *entities.Payment {
+		return &entities.Payment{Kind: kind, Method: method, Amount: amount}
+	}
+
+	unpaid := &entities.Invoice{Status: entities.InvoiceStatusPartiallyPaid}
+	if form, method := CFDIPaymentTerms(unpaid, nil); form != "99" || method != "PPD" {
+		t.Errorf("unpaid terms = %s %s, want 99 PPD", form, method)
+	}
+
+	paid := &entities.Invoice{Status: entities.InvoiceStatusPaid}
+	form, method := CFDIPaymentTerms(paid, []*entities.Payment{
+		entry(entities.PaymentKindPayment, entities.PaymentMethodCash, 60000),
+		entry(entities.PaymentKindRefund, entities.PaymentMethodCash, 30000),
+		entry(entities.PaymentKindPayment, entities.PaymentMethodTransfer, 50000),
+	})
+	if form != "03" || method != "PUE" {
+		t.Errorf("paid terms = %s %s, want 03 PUE", form, method)
+	}
+}
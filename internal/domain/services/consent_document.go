@@ -0,0 +1,186 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"regexp"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// Merge fields available in consent templates, written as {{patient.name}}
+const (
+	MergeFieldPatientName        = "patient.name"
+	MergeFieldPatientFirstName   = "patient.first_name"
+	MergeFieldPatientLastName    = "patient.last_name"
+	MergeFieldPatientDateOfBirth = "patient.date_of_birth"
+	MergeFieldDoctorName         = "doctor.name"
+	MergeFieldProcedureName      = "procedure.name"
+	MergeFieldAppointmentDate    = "appointment.date"
+	MergeFieldOrganizationName   = "organization.name"
+	MergeFieldToday              = "today"
+)
+
+// ConsentMergeFields lists every merge field a template may use
+var ConsentMergeFields = []string{
+	MergeFieldPatientName,
+	MergeFieldPatientFirstName,
+	MergeFieldPatientLastName,
+	MergeFieldPatientDateOfBirth,
+	MergeFieldDoctorName,
+	MergeFieldProcedureName,
+	MergeFieldAppointmentDate,
+	MergeFieldOrganizationName,
+	MergeFieldToday,
+}
+
+// blankMergeValue stands in for merge fields without a value, leaving a line to fill in
+const blankMergeValue = "__________"
+
+var mergeFieldPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_.]+)\s*\}\}`)
+
+// ValidateConsentTemplateBody checks that every merge field in the body is known
+func ValidateConsentTemplateBody(body string) error {
+	for _, match := range mergeFieldPattern.FindAllStringSubmatch(body, -1) {
+		if !isConsentMergeField(match[1]) {
+			return entities.ErrUnknownConsentMergeField
+		}
+	}
+	return nil
+}
+
+// RenderConsentBody fills in the merge fields of a template body; fields without a
+// value are left as a blank line
+func RenderConsentBody(body string, values map[string]string) string {
+	return mergeFieldPattern.ReplaceAllStringFunc(body, func(field string) string {
+		name := mergeFieldPattern.FindStringSubmatch(field)[1]
+		if !isConsentMergeField(name) {
+			return field
+		}
+		if value := strings.TrimSpace(values[name]); value != "" {
+			return value
+		}
+		return blankMergeValue
+	})
+}
+
+// ConsentDocument is the signed copy of a consent form kept in the patient's files
+type ConsentDocument struct {
+	Form             *entities.ConsentForm
+	OrganizationName string
+	PatientName      string
+}
+
+// RenderConsentDocument renders the signed copy of a form as a standalone HTML page and
+// returns it with its SHA-256 digest
+func RenderConsentDocument(document ConsentDocument) ([]byte, string, error) {
+	form := document.Form
+	if !form.IsSigned() || form.SignedAt == nil || form.SignatureType == nil {
+		return nil, "", entities.ErrConsentFormNotSigned
+	}
+
+	data := consentDocumentData{
+		OrganizationName: document.OrganizationName,
+		PatientName:      document.PatientName,
+		Title:            form.Title,
+		Paragraphs:       strings.Split(strings.ReplaceAll(form.Body, "\r\n", "\n"), "\n\n"),
+		FormID:           form.ID.String(),
+		SignedAt:         form.SignedAt.UTC().Format(time.RFC3339),
+		SignatureType:    string(*form.SignatureType),
+	}
+	if form.SignerName != nil {
+		data.SignerName = *form.SignerName
+	}
+	if form.SignatureData != nil {
+		if *form.SignatureType == entities.SignatureTypeDrawn && strings.HasPrefix(*form.SignatureData, entities.DrawnSignaturePrefix) {
+			// Validated as a base64 PNG when signed, so it is safe as an image source
+			data.SignatureImage = template.URL(*form.SignatureData)
+		} else {
+			data.SignatureText = *form.SignatureData
+		}
+	}
+	if form.SignedIP != nil {
+		data.SignedIP = *form.SignedIP
+	}
+	if form.SignedUserAgent != nil {
+		data.SignedUserAgent = *form.SignedUserAgent
+	}
+	bodyDigest := sha256.Sum256([]byte(form.Body))
+	data.BodySHA256 = hex.EncodeToString(bodyDigest[:])
+
+	var buf bytes.Buffer
+	if err := consentDocumentTemplate.Execute(&buf, data); err != nil {
+		return nil, "", err
+	}
+
+	digest := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), hex.EncodeToString(digest[:]), nil
+}
+
+type consentDocumentData struct {
+	OrganizationName string
+	PatientName      string
+	Title            string
+	Paragraphs       []string
+	FormID           string
+	SignerName       string
+	SignatureType    string
+	SignatureText    string
+	SignatureImage   template.URL
+	SignedAt         string
+	SignedIP         string
+	SignedUserAgent  string
+	BodySHA256       string
+}
+
+var consentDocumentTemplate = template.Must(template.New("consent").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 46rem; margin: 2rem auto; line-height: 1.5; color: #111; }
+p { white-space: pre-wrap; }
+.signature { margin-top: 2rem; border-top: 1px solid #999; padding-top: 1rem; }
+.signature img { max-height: 8rem; }
+.typed { font-family: "Brush Script MT", cursive; font-size: 2rem; }
+.audit { font-family: monospace; font-size: 0.8rem; color: #555; }
+</style>
+</head>
+<body>
+<header>
+<p><strong>{{.OrganizationName}}</strong></p>
+<h1>{{.Title}}</h1>
+<p>Patient: {{.PatientName}}</p>
+</header>
+<main>
+{{range .Paragraphs}}<p>{{.}}</p>
+{{end}}</main>
+<section class="signature">
+{{if .SignatureImage}}<img src="{{.SignatureImage}}" alt="Signature of {{.SignerName}}">{{else}}<p class="typed">{{.SignatureText}}</p>{{end}}
+<p>Signed by {{.SignerName}} on {{.SignedAt}}</p>
+</section>
+<footer class="audit">
+<p>Consent form {{.FormID}}<br>
+Signature: {{.SignatureType}}<br>
+IP address: {{.SignedIP}}<br>
+User agent: {{.SignedUserAgent}}<br>
+Text SHA-256: {{.BodySHA256}}</p>
+</footer>
+</body>
+</html>
+`))
+
+// isConsentMergeField checks if name is a known merge field
+func isConsentMergeField(name string) bool {
+	for _, field := range ConsentMergeFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateConsentTemplateBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+	}{
+		{name: "known fields", body: "I, {{patient.name}}, authorize {{ doctor.name }} to perform {{procedure.name}}."},
+		{name: "no fields", body: "I consent."},
+		{name: "unknown field", body: "Dear {{patient.nickname}}", err: entities.ErrUnknownConsentMergeField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateConsentTemplateBody(tt.body); err != tt.err {
+				t.Errorf("ValidateConsentTemplateBody() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRenderConsentBody(t *testing.T) {
+	body := "I, {{patient.name}}, authorize {{ doctor.name }} to perform {{procedure.name}} on {{appointment.date}}."
+	values := map[string]string{
+		MergeFieldPatientName:   "Ana López",
+		MergeFieldDoctorName:    "Dr. Ruiz",
+		MergeFieldProcedureName: "Extraction",
+	}
+
+	want := "I, Ana López, authorize Dr. Ruiz to perform Extraction on __________."
+	if got := RenderConsentBody(body, values); got != want {
+		t.Errorf("RenderConsentBody() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderConsentDocument(t *testing.T) {
+	form := &entities.ConsentForm{
+		ID:     uuid.New(),
+		Title:  "Extraction consent",
+		Body:   "I understand the <risks>.\n\nI consent.",
+		Status: entities.ConsentFormStatusPending,
+	}
+	document := ConsentDocument{Form: form, OrganizationName: "Smile Clinic", PatientName: "Ana López"}
+
+	if _, _, err := RenderConsentDocument(document); err != entities.ErrConsentFormNotSigned {
+		t.Fatalf("RenderConsentDocument() of a pending form error = %v, want %v", err, entities.ErrConsentFormNotSigned)
+	}
+
+	form.ExpiresAt = time.Now().Add(time.Hour)
+	signature := entities.ConsentSignature{SignerName: "Ana López", Type: entities.SignatureTypeTyped, Data: "Ana <b>López</b>"}
+	if err := form.Sign(signature, "203.0.113.7", "Mozilla/5.0", time.Now()); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	html, digest, err := RenderConsentDocument(document)
+	if err != nil {
+		t.Fatalf("RenderConsentDocument() error = %v", err)
+	}
+	page := string(html)
+
+	for _, want := range []string{"Smile Clinic", "Extraction consent", "I understand the &lt;risks&gt;.", "Ana &lt;b&gt;López&lt;/b&gt;", "203.0.113.7", form.ID.String()} {
+		if !strings.Contains(page, want) {
+			t.Errorf("document does not contain %q", want)
+		}
+	}
+	if strings.Contains(page, "<risks>") || strings.Contains(page, "<b>") {
+		t.Error("document content must be escaped")
+	}
+	if len(digest) != 64 {
+		t.Errorf("digest = %q, want a hex SHA-256", digest)
+	}
+
+	again, _, err := RenderConsentDocument(document)
+	if err != nil || string(again) != page {
+		t.Error("rendering the same signed form should be deterministic")
+	}
+}
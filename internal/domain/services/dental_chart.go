@@ -0,0 +1,69 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// ToothState is the charted state of one tooth at a point in time
+type ToothState struct {
+	Tooth       entities.Tooth
+	Condition   entities.ToothCondition                           // Last whole-tooth condition, empty when only surfaces were charted
+	Surfaces    map[entities.ToothSurface]entities.ToothCondition // Surface findings recorded after the last whole-tooth entry
+	LastEntryID uuid.UUID
+	UpdatedAt   time.Time
+}
+
+// BuildDentalChart replays charting entries recorded up to asOf, in the order they were
+// observed, and returns the resulting state of every charted tooth ordered by FDI number.
+// A whole-tooth entry replaces everything known about the tooth; a surface entry only
+// replaces its surfaces, and "sound" on a surface clears it.
+func BuildDentalChart(entries []*entities.DentalChartEntry, asOf time.Time) []*ToothState {
+	replay := make([]*entities.DentalChartEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.RecordedAt.After(asOf) {
+			replay = append(replay, entry)
+		}
+	}
+	sort.SliceStable(replay, func(i, j int) bool {
+		if !replay[i].RecordedAt.Equal(replay[j].RecordedAt) {
+			return replay[i].RecordedAt.Before(replay[j].RecordedAt)
+		}
+		return replay[i].CreatedAt.Before(replay[j].CreatedAt)
+	})
+
+	states := make(map[entities.Tooth]*ToothState)
+	for _, entry := range replay {
+		state, ok := states[entry.Tooth]
+		if !ok {
+			state = &ToothState{Tooth: entry.Tooth, Surfaces: make(map[entities.ToothSurface]entities.ToothCondition)}
+			states[entry.Tooth] = state
+		}
+
+		if len(entry.Surfaces) == 0 {
+			state.Condition = entry.Condition
+			state.Surfaces = make(map[entities.ToothSurface]entities.ToothCondition)
+		} else {
+			for _, surface := range entry.Surfaces {
+				if entry.Condition == entities.ToothConditionSound {
+					delete(state.Surfaces, surface)
+				} else {
+					state.Surfaces[surface] = entry.Condition
+				}
+			}
+		}
+		state.LastEntryID = entry.ID
+		state.UpdatedAt = entry.RecordedAt
+	}
+
+	chart := make([]*ToothState, 0, len(states))
+	for _, state := range states {
+		chart = append(chart, state)
+	}
+	sort.Slice(chart, func(i, j int) bool { return chart[i].Tooth < chart[j].Tooth })
+	return chart
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildDentalChart(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 10, 0, 0, 0, time.UTC) }
+	entry := func(d int, tooth entities.Tooth, condition entities.ToothCondition, surfaces ...entities.ToothSurface) *entities.DentalChartEntry {
+		return &entities.DentalChartEntry{ID: uuid.New(), Tooth: tooth, Surfaces: surfaces, Condition: condition, RecordedAt: day(d), CreatedAt: day(d)}
+	}
+
+	entries := []*entities.DentalChartEntry{
+		// Out of order on purpose: entries are replayed by the date they were observed
+		entry(10, 36, entities.ToothConditionFilling, entities.SurfaceOcclusal),
+		entry(1, 36, entities.ToothConditionCaries, entities.SurfaceOcclusal, entities.SurfaceDistal),
+		entry(1, 11, entities.ToothConditionCaries, entities.SurfaceMesial),
+		entry(5, 11, entities.ToothConditionSound, entities.SurfaceMesial),
+		entry(3, 46, entities.ToothConditionFilling, entities.SurfaceOcclusal),
+		entry(20, 46, entities.ToothConditionCrown),
+		entry(25, 48, entities.ToothConditionMissing),
+	}
+
+	tests := []struct {
+		name string
+		asOf time.Time
+		want map[entities.Tooth]string
+	}{
+		{
+			name: "before any entry",
+			asOf: day(1).Add(-time.Hour),
+			want: map[entities.Tooth]string{},
+		},
+		{
+			name: "initial exam",
+			asOf: day(2),
+			want: map[entities.Tooth]string{
+				11: "|M=caries",
+				36: "|O=caries,D=caries",
+			},
+		},
+		{
+			name: "after treatment",
+			asOf: day(15),
+			want: map[entities.Tooth]string{
+				11: "|",
+				36: "|O=filling,D=caries",
+				46: "|O=filling",
+			},
+		},
+		{
+			name: "whole-tooth entries replace surface findings",
+			asOf: day(30),
+			want: map[entities.Tooth]string{
+				11: "|",
+				36: "|O=filling,D=caries",
+				46: "crown|",
+				48: "missing|",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := BuildDentalChart(entries, tt.asOf)
+
+			got := make(map[entities.Tooth]string)
+			var order []entities.Tooth
+			for _, state := range chart {
+				got[state.Tooth] = describeToothState(state)
+				order = append(order, state.Tooth)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chart = %v, want %v", got, tt.want)
+			}
+			for i := 1; i < len(order); i++ {
+				if order[i-1] > order[i] {
+					t.Errorf("chart not ordered by tooth: %v", order)
+				}
+			}
+		})
+	}
+}
+
+// describeToothState renders a state as "condition|surface=condition,..." in surface order
+func describeToothState(state *ToothState) string {
+	description := string(state.Condition) + "|"
+	first := true
+	for _, surface := range []entities.ToothSurface{
+		entities.SurfaceMesial, entities.SurfaceOcclusal, entities.SurfaceIncisal,
+		entities.SurfaceDistal, entities.SurfaceBuccal, entities.SurfaceLingual,
+	} {
+		if condition, ok := state.Surfaces[surface]; ok {
+			if !first {
+				description += ","
+			}
+			description += string(surface) + "=" + string(condition)
+			first = false
+		}
+	}
+	return description
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// DepositRequirement is the outcome of applying a deposit policy to a booking
+type DepositRequirement struct {
+	Reason entities.DepositReason // Rule that asked for the larger deposit
+	Amount int64                  // Deposit to ask for in minor units; zero when none is required
+	DueAt  time.Time              // When the held slot is released if the deposit is not paid
+}
+
+// Required reports whether the booking has to be held until a deposit is paid
+func (r DepositRequirement) Required() bool {
+	return r.Amount > 0
+}
+
+// AssessDeposit applies an organization's policy to an appointment booked at bookedAt. service is
+// the appointment's service, nil when it has none; price is the price of its procedure in minor
+// units, nil when unknown, which percentage deposits need to ask for anything; noShows is the
+// number of the patient's no-shows counted by RecentNoShows. When both the service and the
+// patient's reliability call for a deposit, the larger one is asked for. Appointments that already
+// started never require one.
+func AssessDeposit(policy *entities.DepositPolicy, service *entities.Service, price *int64, noShows int, bookedAt, start time.Time) DepositRequirement {
+	var requirement DepositRequirement
+	if policy == nil || !policy.Enabled || !start.After(bookedAt) {
+		return requirement
+	}
+
+	if service != nil && service.DepositType != nil {
+		requirement.Amount = depositAmount(*service.DepositType, service.DepositAmount, service.DepositPercent, price)
+		requirement.Reason = entities.DepositReasonService
+	}
+	if policy.NoShowThreshold > 0 && noShows >= policy.NoShowThreshold {
+		if amount := depositAmount(policy.Type, policy.Amount, policy.Percent, price); amount > requirement.Amount {
+			requirement.Amount = amount
+			requirement.Reason = entities.DepositReasonReliability
+		}
+	}
+
+	if !requirement.Required() {
+		return DepositRequirement{}
+	}
+	requirement.DueAt = DepositDueAt(policy, bookedAt, start)
+	return requirement
+}
+
+// DepositDueAt returns when the slot of an appointment booked at bookedAt is released if its
+// deposit is not paid: HoldHours after booking, but no later than CutoffHours before the start.
+// Appointments booked inside the cutoff window are held for HoldHours, up to their start.
+func DepositDueAt(policy *entities.DepositPolicy, bookedAt, start time.Time) time.Time {
+	dueAt := bookedAt.Add(time.Duration(policy.HoldHours) * time.Hour)
+	if cutoff := start.Add(-time.Duration(policy.CutoffHours) * time.Hour); cutoff.After(bookedAt) {
+		if cutoff.Before(dueAt) {
+			dueAt = cutoff
+		}
+		return dueAt
+	}
+	if start.Before(dueAt) {
+		dueAt = start
+	}
+	return dueAt
+}
+
+// RecentNoShows counts the patient's no-shows that the policy looks back at as of now
+func RecentNoShows(policy *entities.DepositPolicy, appointments []*entities.Appointment, now time.Time) int {
+	since := time.Time{}
+	if policy.NoShowLookbackDays > 0 {
+		since = now.AddDate(0, 0, -policy.NoShowLookbackDays)
+	}
+
+	count := 0
+	for _, appointment := range appointments {
+		if appointment.Status == entities.AppointmentStatusNoShow && !appointment.StartTime.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// depositAmount computes the deposit a rule asks for; percentages round half up
+func depositAmount(depositType entities.DepositType, amount int64, percent int, price *int64) int64 {
+	switch depositType {
+	case entities.DepositTypeFixed:
+		return amount
+	case entities.DepositTypePercentage:
+		if price != nil && *price > 0 {
+			return (*price*int64(percent) + 50) / 100
+		}
+	}
+	return 0
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+func TestAssessDeposit(t *testing.T) {
+	bookedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	start := bookedAt.AddDate(0, 0, 14)
+	price := int64(1500050)
+	fixed := entities.DepositTypeFixed
+	percentage := entities.DepositTypePercentage
+
+	policy := &entities.DepositPolicy{
+		Enabled:         true,
+		HoldHours:       48,
+		CutoffHours:     24,
+		NoShowThreshold: 2,
+		Type:            entities.DepositTypeFixed,
+		Amount:          50000,
+	}
+	disabled := *policy
+	disabled.Enabled = false
+	servicesOnly := *policy
+	servicesOnly.NoShowThreshold = 0
+
+	implant := &entities.Service{DepositType: &percentage, DepositPercent: 30}
+	surgery := &entities.Service{DepositType: &fixed, DepositAmount: 20000}
+	cleaning := &entities.Service{}
+
+	tests := []struct {
+		name    string
+		policy  *entities.DepositPolicy
+		service *entities.Service
+		price   *int64
+		noShows int
+		start   time.Time
+		reason  entities.DepositReason
+		amount  int64
+	}{
+		{"no policy", nil, implant, &price, 5, start, "", 0},
+		{"disabled policy", &disabled, implant, &price, 5, start, "", 0},
+		{"service without deposit", policy, cleaning, &price, 0, start, "", 0},
+		{"no service", policy, nil, nil, 0, start, "", 0},
+		{"percentage rounds half up", policy, implant, &price, 0, start, entities.DepositReasonService, 450015},
+		{"percentage without a price", policy, implant, nil, 0, start, "", 0},
+		{"fixed service deposit", policy, surgery, nil, 0, start, entities.DepositReasonService, 20000},
+		{"unreliable patient", policy, cleaning, &price, 2, start, entities.DepositReasonReliability, 50000},
+		{"below the threshold", policy, cleaning, &price, 1, start, "", 0},
+		{"larger reliability deposit wins", policy, surgery, nil, 3, start, entities.DepositReasonReliability, 50000},
+		{"larger service deposit wins", policy, implant, &price, 3, start, entities.DepositReasonService, 450015},
+		{"reliability rule off", &servicesOnly, cleaning, &price, 10, start, "", 0},
+		{"already started", policy, implant, &price, 0, bookedAt, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AssessDeposit(tt.policy, tt.service, tt.price, tt.noShows, bookedAt, tt.start)
+			if got.Reason != tt.reason || got.Amount != tt.amount {
+				t.Errorf("AssessDeposit() = %+v, want reason %q, amount %d", got, tt.reason, tt.amount)
+			}
+			if got.Required() != (tt.amount > 0) {
+				t.Errorf("Required() = %v, want %v", got.Required(), tt.amount > 0)
+			}
+		})
+	}
+}
+
+func TestDepositDueAt(t *testing.T) {
+	bookedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	policy := &entities.DepositPolicy{HoldHours: 48, CutoffHours: 24}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  time.Time
+	}{
+		{"hold ends first", bookedAt.AddDate(0, 0, 14), bookedAt.Add(48 * time.Hour)},
+		{"cutoff ends first", bookedAt.Add(60 * time.Hour), bookedAt.Add(36 * time.Hour)},
+		{"booked inside the cutoff", bookedAt.Add(20 * time.Hour), bookedAt.Add(20 * time.Hour)},
+		{"booked at the cutoff", bookedAt.Add(24 * time.Hour), bookedAt.Add(24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DepositDueAt(policy, bookedAt, tt.start); !got.Equal(tt.want) {
+				t.Errorf("DepositDueAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecentNoShows(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	appointments := []*entities.Appointment{
+		{Status: entities.AppointmentStatusNoShow, StartTime: now.AddDate(0, -1, 0)},
+		{Status: entities.AppointmentStatusNoShow, StartTime: now.AddDate(0, -6, 0)},
+		{Status: entities.AppointmentStatusNoShow, StartTime: now.AddDate(-2, 0, 0)},
+		{Status: entities.AppointmentStatusCancelled, StartTime: now.AddDate(0, -1, 0)},
+		{Status: entities.AppointmentStatusCompleted, StartTime: now.AddDate(0, -2, 0)},
+	}
+
+	tests := []struct {
+		name     string
+		lookback int
+		want     int
+	}{
+		{"last year", 365, 2},
+		{"last 90 days", 90, 1},
+		{"whole history", 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &entities.DepositPolicy{NoShowLookbackDays: tt.lookback}
+			if got := RecentNoShows(policy, appointments, now); got != tt.want {
+				t.Errorf("RecentNoShows() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
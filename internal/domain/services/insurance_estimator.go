@@ -0,0 +1,95 @@
+package services
+
+import "dental-scheduler-backend/internal/domain/entities"
+
+// CoverageItem is a service whose insurance coverage is estimated. Amount is in minor units.
+type CoverageItem struct {
+	ServiceID   string
+	Description string
+	Category    entities.ServiceCategory
+	Amount      int64
+}
+
+// BenefitUsage is what a policy has already used in a benefit year
+type BenefitUsage struct {
+	DeductibleUsed int64
+	BenefitsUsed   int64 // Paid, approved or estimated by the carrier on open claims
+}
+
+// CoverageItemEstimate splits the amount of an item between the carrier and the patient
+type CoverageItemEstimate struct {
+	CoverageItem
+	CoveragePercent   int
+	DeductibleApplied int64
+	InsurerPortion    int64
+	PatientPortion    int64
+}
+
+// CoverageEstimate is the split of several items, in order, under a policy
+type CoverageEstimate struct {
+	Items               []CoverageItemEstimate
+	Total               int64
+	InsurerPortion      int64
+	PatientPortion      int64
+	DeductibleApplied   int64
+	DeductibleRemaining int64  // Left for the year after these items
+	MaximumRemaining    *int64 // Left for the year after these items; nil without an annual maximum
+}
+
+// EstimateCoverage splits each item between carrier and patient. The deductible is paid by
+// the patient first on covered items other than preventive care; the carrier then pays its
+// coverage percentage of the rest, rounded half up, until the annual maximum is reached.
+// Uncovered categories are paid by the patient in full and do not count towards the deductible.
+func EstimateCoverage(policy *entities.InsurancePolicy, usage BenefitUsage, items []CoverageItem) CoverageEstimate {
+	deductibleLeft := max(policy.Deductible-usage.DeductibleUsed, 0)
+	var maximumLeft *int64
+	if policy.AnnualMaximum != nil {
+		left := max(*policy.AnnualMaximum-usage.BenefitsUsed, 0)
+		maximumLeft = &left
+	}
+
+	estimate := CoverageEstimate{Items: make([]CoverageItemEstimate, 0, len(items))}
+	for _, item := range items {
+		line := CoverageItemEstimate{
+			CoverageItem:    item,
+			CoveragePercent: policy.CoveragePercent(item.Category),
+		}
+
+		if line.CoveragePercent > 0 {
+			if item.Category != entities.ServiceCategoryPreventive {
+				line.DeductibleApplied = min(deductibleLeft, item.Amount)
+				deductibleLeft -= line.DeductibleApplied
+			}
+			line.InsurerPortion = ((item.Amount-line.DeductibleApplied)*int64(line.CoveragePercent) + 50) / 100
+			if maximumLeft != nil {
+				line.InsurerPortion = min(line.InsurerPortion, *maximumLeft)
+				*maximumLeft -= line.InsurerPortion
+			}
+		}
+		line.PatientPortion = item.Amount - line.InsurerPortion
+
+		estimate.Items = append(estimate.Items, line)
+		estimate.Total += item.Amount
+		estimate.InsurerPortion += line.InsurerPortion
+		estimate.PatientPortion += line.PatientPortion
+		estimate.DeductibleApplied += line.DeductibleApplied
+	}
+
+	estimate.DeductibleRemaining = deductibleLeft
+	estimate.MaximumRemaining = maximumLeft
+	return estimate
+}
+
+// SummarizeBenefitUsage adds up what a policy's claims of a benefit year used; rejected
+// claims use nothing
+func SummarizeBenefitUsage(claims []*entities.InsuranceClaim) BenefitUsage {
+	var usage BenefitUsage
+	for _, claim := range claims {
+		if !claim.IsOpen() {
+			continue
+		}
+		usage.DeductibleUsed += claim.DeductibleApplied
+		usage.BenefitsUsed += claim.InsurerAmount()
+	}
+	return usage
+}
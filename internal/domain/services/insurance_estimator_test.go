@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+func TestEstimateCoverage(t *testing.T) {
+	maximum := int64(100000)
+	policy := &entities.InsurancePolicy{
+		Coverage: map[entities.ServiceCategory]int{
+			entities.ServiceCategoryPreventive: 100,
+			entities.ServiceCategoryBasic:      80,
+			entities.ServiceCategoryMajor:      50,
+		},
+		AnnualMaximum: &maximum,
+		Deductible:    5000,
+	}
+
+	estimate := EstimateCoverage(policy, BenefitUsage{DeductibleUsed: 2000, BenefitsUsed: 20000}, []CoverageItem{
+		{ServiceID: "cleaning", Category: entities.ServiceCategoryPreventive, Amount: 60000},
+		{ServiceID: "whitening", Category: entities.ServiceCategoryCosmetic, Amount: 40000},
+		{ServiceID: "filling", Category: entities.ServiceCategoryBasic, Amount: 15001},
+		{ServiceID: "crown", Category: entities.ServiceCategoryMajor, Amount: 80000},
+	})
+
+	want := []struct {
+		deductible, insurer, patient int64
+	}{
+		{0, 60000, 0},      // Preventive: no deductible, fully covered
+		{0, 0, 40000},      // Not covered, not towards the deductible
+		{3000, 9601, 5400}, // Remaining deductible first, then 80% rounded half up
+		{0, 10399, 69601},  // 50% capped by what is left of the maximum
+	}
+	for i, w := range want {
+		item := estimate.Items[i]
+		if item.DeductibleApplied != w.deductible || item.InsurerPortion != w.insurer || item.PatientPortion != w.patient {
+			t.Errorf("item %s = %d/%d/%d, want %d/%d/%d", item.ServiceID, item.DeductibleApplied, item.InsurerPortion, item.PatientPortion, w.deductible, w.insurer, w.patient)
+		}
+	}
+
+	if estimate.Total != 195001 || estimate.InsurerPortion != 80000 || estimate.PatientPortion != 115001 {
+		t.Errorf("unexpected totals: %+v", estimate)
+	}
+	if estimate.DeductibleRemaining != 0 || estimate.MaximumRemaining == nil || *estimate.MaximumRemaining != 0 {
+		t.Errorf("unexpected remaining benefits: deductible %d, maximum %v", estimate.DeductibleRemaining, estimate.MaximumRemaining)
+	}
+}
+
+func TestEstimateCoverageWithoutMaximum(t *testing.T) {
+	policy := &entities.InsurancePolicy{Coverage: map[entities.ServiceCategory]int{entities.ServiceCategoryOrthodontic: 50}}
+
+	estimate := EstimateCoverage(policy, BenefitUsage{}, []CoverageItem{{Category: entities.ServiceCategoryOrthodontic, Amount: 1000001}})
+	if estimate.InsurerPortion != 500001 || estimate.MaximumRemaining != nil {
+		t.Errorf("unexpected estimate: %+v", estimate)
+	}
+}
+
+func TestSummarizeBenefitUsage(t *testing.T) {
+	approved := int64(30000)
+	claims := []*entities.InsuranceClaim{
+		{Status: entities.InsuranceClaimStatusSubmitted, EstimatedAmount: 10000, DeductibleApplied: 2000},
+		{Status: entities.InsuranceClaimStatusApproved, EstimatedAmount: 40000, ApprovedAmount: &approved, DeductibleApplied: 1000},
+		{Status: entities.InsuranceClaimStatusRejected, EstimatedAmount: 50000, DeductibleApplied: 3000},
+	}
+
+	usage := SummarizeBenefitUsage(claims)
+	if usage.DeductibleUsed != 3000 || usage.BenefitsUsed != 40000 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
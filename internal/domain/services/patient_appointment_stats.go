@@ -0,0 +1,77 @@
+package services
+
+import (
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// PatientAppointmentStats summarizes a patient's appointment history. Visits and upcoming
+// appointments follow the same rules as the last/next appointment dates of patient listings.
+type PatientAppointmentStats struct {
+	Total             int
+	Completed         int
+	Cancelled         int
+	NoShows           int
+	Upcoming          int
+	FirstVisitAt      *time.Time
+	LastVisitAt       *time.Time
+	NextAppointmentAt *time.Time
+}
+
+// SummarizePatientAppointments computes the stats of a patient's appointments as of now
+func SummarizePatientAppointments(appointments []*entities.Appointment, now time.Time) PatientAppointmentStats {
+	stats := PatientAppointmentStats{Total: len(appointments)}
+
+	for _, appointment := range appointments {
+		switch appointment.Status {
+		case entities.AppointmentStatusCompleted:
+			stats.Completed++
+		case entities.AppointmentStatusCancelled:
+			stats.Cancelled++
+		case entities.AppointmentStatusNoShow:
+			stats.NoShows++
+		}
+
+		start := appointment.StartTime
+		switch {
+		case start.Before(now) && isVisitStatus(appointment.Status):
+			if stats.FirstVisitAt == nil || start.Before(*stats.FirstVisitAt) {
+				stats.FirstVisitAt = &start
+			}
+			if stats.LastVisitAt == nil || start.After(*stats.LastVisitAt) {
+				stats.LastVisitAt = &start
+			}
+		case IsUpcomingAppointment(appointment, now):
+			stats.Upcoming++
+			if stats.NextAppointmentAt == nil || start.Before(*stats.NextAppointmentAt) {
+				stats.NextAppointmentAt = &start
+			}
+		}
+	}
+
+	return stats
+}
+
+// isVisitStatus reports whether a past appointment with the status counts as a visit
+func isVisitStatus(status entities.AppointmentStatus) bool {
+	switch status {
+	case entities.AppointmentStatusCancelled, entities.AppointmentStatusNeedsRescheduling, entities.AppointmentStatusWithError:
+		return false
+	}
+	return true
+}
+
+// IsUpcomingAppointment reports whether the appointment has not started yet and is still expected to happen
+func IsUpcomingAppointment(appointment *entities.Appointment, now time.Time) bool {
+	return !appointment.StartTime.Before(now) && isUpcomingStatus(appointment.Status)
+}
+
+// isUpcomingStatus reports whether a future appointment with the status is still expected to happen
+func isUpcomingStatus(status entities.AppointmentStatus) bool {
+	switch status {
+	case entities.AppointmentStatusScheduled, entities.AppointmentStatusConfirmed, entities.AppointmentStatusRescheduled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+func TestSummarizePatientAppointments(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	at := func(days int) time.Time { return now.AddDate(0, 0, days) }
+	appointment := func(days int, status entities.AppointmentStatus) *entities.Appointment {
+		return &entities.Appointment{StartTime: at(days), EndTime: at(days).Add(time.Hour), Status: status}
+	}
+
+	stats := SummarizePatientAppointments([]*entities.Appointment{
+		appointment(-90, entities.AppointmentStatusCompleted),
+		appointment(-30, entities.AppointmentStatusNoShow),
+		appointment(-10, entities.AppointmentStatusCancelled),
+		appointment(-5, entities.AppointmentStatusNeedsRescheduling),
+		appointment(7, entities.AppointmentStatusConfirmed),
+		appointment(3, entities.AppointmentStatusScheduled),
+		appointment(14, entities.AppointmentStatusCancelled),
+	}, now)
+
+	if stats.Total != 7 || stats.Completed != 1 || stats.Cancelled != 2 || stats.NoShows != 1 || stats.Upcoming != 2 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+	if stats.FirstVisitAt == nil || !stats.FirstVisitAt.Equal(at(-90)) {
+		t.Errorf("FirstVisitAt = %v, want %v", stats.FirstVisitAt, at(-90))
+	}
+	// The no-show is the latest past appointment that was not cancelled or left pending
+	if stats.LastVisitAt == nil || !stats.LastVisitAt.Equal(at(-30)) {
+		t.Errorf("LastVisitAt = %v, want %v", stats.LastVisitAt, at(-30))
+	}
+	if stats.NextAppointmentAt == nil || !stats.NextAppointmentAt.Equal(at(3)) {
+		t.Errorf("NextAppointmentAt = %v, want %v", stats.NextAppointmentAt, at(3))
+	}
+}
+
+func TestSummarizePatientAppointmentsWithoutHistory(t *testing.T) {
+	stats := SummarizePatientAppointments(nil, time.Now())
+
+	if stats.Total != 0 || stats.FirstVisitAt != nil || stats.LastVisitAt != nil || stats.NextAppointmentAt != nil {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+}
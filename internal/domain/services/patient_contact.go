@@ -0,0 +1,59 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// ResolvePatientContact decides whose details are used to notify a patient. Minors are reached
+// through their first guardian with a usable email or phone, so guardians must be ordered with
+// the designated contact person first. Adults, patients without a known date of birth and minors
+// without a reachable guardian are contacted directly.
+func ResolvePatientContact(patient *entities.Patient, guardians []*entities.Patient, now time.Time) *entities.PatientContact {
+	if patient.IsMinor(now) {
+		for _, guardian := range guardians {
+			if guardian == nil || !isReachable(guardian) {
+				continue
+			}
+			contact := contactFor(guardian)
+			contact.PatientID = patient.ID
+			contact.Role = entities.ContactRoleGuardian
+			return contact
+		}
+	}
+
+	contact := contactFor(patient)
+	contact.Role = entities.ContactRoleSelf
+	return contact
+}
+
+// contactFor builds the contact details of a patient
+func contactFor(patient *entities.Patient) *entities.PatientContact {
+	name := patient.FirstName
+	if patient.LastName != nil && *patient.LastName != "" {
+		name += " " + *patient.LastName
+	}
+	return &entities.PatientContact{
+		PatientID:        patient.ID,
+		ContactPatientID: patient.ID,
+		Name:             name,
+		Email:            nonBlank(patient.Email),
+		Phone:            nonBlank(patient.Phone),
+		PhoneE164:        nonBlank(patient.PhoneE164),
+	}
+}
+
+// isReachable checks if the patient has an email or phone to be contacted on
+func isReachable(patient *entities.Patient) bool {
+	return nonBlank(patient.Email) != nil || nonBlank(patient.Phone) != nil
+}
+
+// nonBlank returns the value unless it is missing or blank
+func nonBlank(value *string) *string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil
+	}
+	return value
+}
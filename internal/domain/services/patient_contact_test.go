@@ -0,0 +1,76 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestResolvePatientContact(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	born := func(years int) *time.Time { d := now.AddDate(-years, 0, 0); return &d }
+
+	child := &entities.Patient{ID: uuid.New(), FirstName: "Ana", DateOfBirth: born(8), Phone: str("555 0100")}
+	adult := &entities.Patient{ID: uuid.New(), FirstName: "Luis", DateOfBirth: born(40), Email: str("luis@example.com")}
+	unknownAge := &entities.Patient{ID: uuid.New(), FirstName: "Eva"}
+	unreachable := &entities.Patient{ID: uuid.New(), FirstName: "Tomas", Email: str(" ")}
+	mother := &entities.Patient{ID: uuid.New(), FirstName: "Maria", LastName: str("Lopez"), Email: str("maria@example.com"), PhoneE164: str("+525550100")}
+
+	tests := []struct {
+		name      string
+		patient   *entities.Patient
+		guardians []*entities.Patient
+		wantID    uuid.UUID
+		wantRole  entities.ContactRole
+	}{
+		{"minor through guardian", child, []*entities.Patient{mother}, mother.ID, entities.ContactRoleGuardian},
+		{"skips unreachable guardian", child, []*entities.Patient{unreachable, mother}, mother.ID, entities.ContactRoleGuardian},
+		{"minor without reachable guardian", child, []*entities.Patient{unreachable}, child.ID, entities.ContactRoleSelf},
+		{"minor without guardian", child, nil, child.ID, entities.ContactRoleSelf},
+		{"adult with guardian", adult, []*entities.Patient{mother}, adult.ID, entities.ContactRoleSelf},
+		{"unknown date of birth", unknownAge, []*entities.Patient{mother}, unknownAge.ID, entities.ContactRoleSelf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact := ResolvePatientContact(tt.patient, tt.guardians, now)
+			if contact.PatientID != tt.patient.ID {
+				t.Errorf("PatientID = %v, want %v", contact.PatientID, tt.patient.ID)
+			}
+			if contact.ContactPatientID != tt.wantID || contact.Role != tt.wantRole {
+				t.Errorf("contact = %v (%s), want %v (%s)", contact.ContactPatientID, contact.Role, tt.wantID, tt.wantRole)
+			}
+		})
+	}
+
+	contact := ResolvePatientContact(child, []*entities.Patient{mother}, now)
+	if contact.Name != "Maria Lopez" || contact.Email == nil || *contact.Email != "maria@example.com" || contact.Phone != nil {
+		t.Errorf("unexpected guardian details: %+v", contact)
+	}
+}
+
+func TestIsUpcomingAppointment(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		start  time.Time
+		status entities.AppointmentStatus
+		want   bool
+	}{
+		{now.Add(time.Hour), entities.AppointmentStatusConfirmed, true},
+		{now, entities.AppointmentStatusScheduled, true},
+		{now.Add(time.Hour), entities.AppointmentStatusCancelled, false},
+		{now.Add(-time.Hour), entities.AppointmentStatusScheduled, false},
+	}
+
+	for _, tt := range tests {
+		appointment := &entities.Appointment{StartTime: tt.start, Status: tt.status}
+		if got := IsUpcomingAppointment(appointment, now); got != tt.want {
+			t.Errorf("IsUpcomingAppointment(%v, %s) = %v, want %v", tt.start, tt.status, got, tt.want)
+		}
+	}
+}
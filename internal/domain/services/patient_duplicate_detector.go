@@ -0,0 +1,308 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// DuplicateReason names a signal that contributed to a duplicate score
+type DuplicateReason string
+
+const (
+	DuplicateReasonEmail       DuplicateReason = "email"
+	DuplicateReasonPhone       DuplicateReason = "phone"
+	DuplicateReasonName        DuplicateReason = "name"
+	DuplicateReasonDateOfBirth DuplicateReason = "date_of_birth"
+)
+
+// Duplicate scoring weights. A name match alone is not enough to flag a pair
+// (common names are shared by different people); it has to be backed by a
+// matching contact detail or date of birth.
+const (
+	DefaultDuplicateMinScore = 50
+
+	duplicateEmailWeight       = 40
+	duplicatePhoneWeight       = 35
+	duplicateNameWeight        = 25
+	duplicateDateOfBirthWeight = 25
+	duplicateDOBMismatchWeight = -30 // Different birth dates usually mean relatives sharing contact details
+
+	duplicateNameThreshold = 0.88
+	phoneSignificantDigits = 10 // Compare national numbers so "+52 55 1234 5678" matches "55-1234-5678"
+)
+
+// DuplicateCandidate is a scored pair of patients that may be the same person
+type DuplicateCandidate struct {
+	Patient *entities.Patient
+	Match   *entities.Patient
+	Score   int
+	Reasons []DuplicateReason
+}
+
+// ScorePatientDuplicate scores how likely two patient records describe the same person (0-100)
+func ScorePatientDuplicate(a, b *entities.Patient) (int, []DuplicateReason) {
+	score := 0
+	var reasons []DuplicateReason
+
+	if email := normalizeEmail(a.Email); email != "" && email == normalizeEmail(b.Email) {
+		score += duplicateEmailWeight
+		reasons = append(reasons, DuplicateReasonEmail)
+	}
+
+	if phone := patientPhoneKey(a); phone != "" && phone == patientPhoneKey(b) {
+		score += duplicatePhoneWeight
+		reasons = append(reasons, DuplicateReasonPhone)
+	}
+
+	if similarity := nameSimilarity(a, b); similarity >= duplicateNameThreshold {
+		score += int(float64(duplicateNameWeight)*similarity + 0.5)
+		reasons = append(reasons, DuplicateReasonName)
+	}
+
+	if a.DateOfBirth != nil && b.DateOfBirth != nil {
+		if sameDate(a, b) {
+			score += duplicateDateOfBirthWeight
+			reasons = append(reasons, DuplicateReasonDateOfBirth)
+		} else {
+			score += duplicateDOBMismatchWeight
+		}
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	if score > 100 {
+		score = 100
+	}
+	return score, reasons
+}
+
+// FindDuplicatesOf returns the patients scoring at least minScore against patient, best first
+func FindDuplicatesOf(patient *entities.Patient, others []*entities.Patient, minScore int) []DuplicateCandidate {
+	var candidates []DuplicateCandidate
+	for _, other := range others {
+		if other.ID == patient.ID {
+			continue
+		}
+		if score, reasons := ScorePatientDuplicate(patient, other); score >= minScore && score > 0 {
+			candidates = append(candidates, DuplicateCandidate{Patient: patient, Match: other, Score: score, Reasons: reasons})
+		}
+	}
+	sortCandidates(candidates)
+	return candidates
+}
+
+// FindDuplicatePairs returns every pair of patients scoring at least minScore, best first.
+// Pairs are only compared when they share a normalized email, phone or date of birth,
+// which keeps the scan near-linear; a name match alone never reaches the default threshold.
+func FindDuplicatePairs(patients []*entities.Patient, minScore int) []DuplicateCandidate {
+	blocks := make(map[string][]int)
+	for i, patient := range patients {
+		for _, key := range blockingKeys(patient) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	type pairKey struct{ a, b int }
+	seen := make(map[pairKey]bool)
+	var candidates []DuplicateCandidate
+	for _, members := range blocks {
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				key := pairKey{members[x], members[y]}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				a, b := patients[key.a], patients[key.b]
+				if score, reasons := ScorePatientDuplicate(a, b); score >= minScore && score > 0 {
+					candidates = append(candidates, DuplicateCandidate{Patient: a, Match: b, Score: score, Reasons: reasons})
+				}
+			}
+		}
+	}
+	sortCandidates(candidates)
+	return candidates
+}
+
+// blockingKeys returns the exact-match keys used to group comparable patients
+func blockingKeys(patient *entities.Patient) []string {
+	var keys []string
+	if email := normalizeEmail(patient.Email); email != "" {
+		keys = append(keys, "email:"+email)
+	}
+	if phone := patientPhoneKey(patient); phone != "" {
+		keys = append(keys, "phone:"+phone)
+	}
+	if patient.DateOfBirth != nil {
+		keys = append(keys, "dob:"+patient.DateOfBirth.Format("2006-01-02"))
+	}
+	return keys
+}
+
+// sortCandidates orders candidates by score, then by the older record first for stable output
+func sortCandidates(candidates []DuplicateCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Match.CreatedAt.Before(candidates[j].Match.CreatedAt)
+	})
+}
+
+// sameDate compares dates of birth by calendar day, ignoring time and zone
+func sameDate(a, b *entities.Patient) bool {
+	return a.DateOfBirth.Format("2006-01-02") == b.DateOfBirth.Format("2006-01-02")
+}
+
+// normalizeEmail lowercases and trims an email address
+func normalizeEmail(email *string) string {
+	if email == nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(*email))
+}
+
+// patientPhoneKey normalizes the patient's E.164 phone, falling back to the phone as typed
+// for records that have not been normalized yet
+func patientPhoneKey(patient *entities.Patient) string {
+	if patient.PhoneE164 != nil {
+		return normalizePhone(patient.PhoneE164)
+	}
+	return normalizePhone(patient.Phone)
+}
+
+// normalizePhone keeps the last significant digits of a phone number, ignoring
+// punctuation and country prefixes. Numbers too short to be meaningful are ignored.
+func normalizePhone(phone *string) string {
+	if phone == nil {
+		return ""
+	}
+	var digits strings.Builder
+	for _, r := range *phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	normalized := digits.String()
+	if len(normalized) < 7 {
+		return ""
+	}
+	if len(normalized) > phoneSignificantDigits {
+		normalized = normalized[len(normalized)-phoneSignificantDigits:]
+	}
+	return normalized
+}
+
+// nameSimilarity compares full names, also in sorted token order so that
+// "Perez Juan" matches "Juan Perez"
+func nameSimilarity(a, b *entities.Patient) float64 {
+	tokensA := nameTokens(a)
+	tokensB := nameTokens(b)
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+
+	similarity := jaroWinkler(strings.Join(tokensA, " "), strings.Join(tokensB, " "))
+
+	sort.Strings(tokensA)
+	sort.Strings(tokensB)
+	if sorted := jaroWinkler(strings.Join(tokensA, " "), strings.Join(tokensB, " ")); sorted > similarity {
+		similarity = sorted
+	}
+	return similarity
+}
+
+// nameTokens lowercases the full name, strips accents and punctuation and splits it into words
+func nameTokens(patient *entities.Patient) []string {
+	name := patient.FirstName
+	if patient.LastName != nil {
+		name += " " + *patient.LastName
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if folded, ok := accentFolding[r]; ok {
+			r = folded
+		}
+		if unicode.IsLetter(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Fields(b.String())
+}
+
+// accentFolding maps the accented letters common in patient names to their base letter
+var accentFolding = map[rune]rune{
+	'á': 'a', 'à': 'a', 'ä': 'a', 'â': 'a', 'ã': 'a',
+	'é': 'e', 'è': 'e', 'ë': 'e', 'ê': 'e',
+	'í': 'i', 'ì': 'i', 'ï': 'i', 'î': 'i',
+	'ó': 'o', 'ò': 'o', 'ö': 'o', 'ô': 'o', 'õ': 'o',
+	'ú': 'u', 'ù': 'u', 'ü': 'u', 'û': 'u',
+	'ñ': 'n', 'ç': 'c',
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of two strings (0-1)
+func jaroWinkler(s1, s2 string) float64 {
+	a, b := []rune(s1), []rune(s2)
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if s1 == s2 {
+		return 1
+	}
+
+	window := max(len(a), len(b))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo := max(0, i-window)
+		hi := min(len(b), i+window+1)
+		for j := lo; j < hi; j++ {
+			if matchedB[j] || a[i] != b[j] {
+				continue
+			}
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, min(len(a), len(b))) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
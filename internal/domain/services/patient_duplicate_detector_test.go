@@ -0,0 +1,177 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+func newTestPatient(firstName, lastName, phone, email string, dob *time.Time) *entities.Patient {
+	patient := &entities.Patient{ID: uuid.New(), FirstName: firstName, DateOfBirth: dob, CreatedAt: time.Now()}
+	if lastName != "" {
+		patient.LastName = &lastName
+	}
+	if phone != "" {
+		patient.Phone = &phone
+	}
+	if email != "" {
+		patient.Email = &email
+	}
+	return patient
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func hasReason(reasons []DuplicateReason, reason DuplicateReason) bool {
+	for _, r := range reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func TestScorePatientDuplicate(t *testing.T) {
+	dob := date(1985, time.March, 4)
+
+	tests := []struct {
+		name      string
+		a, b      *entities.Patient
+		flagged   bool
+		reasons   []DuplicateReason
+		notReason DuplicateReason
+	}{
+		{
+			name:    "same name and birth date with different phones",
+			a:       newTestPatient("Juan", "Pérez", "55 1234 5678", "", dob),
+			b:       newTestPatient("juan", "Perez", "55 8765 4321", "", dob),
+			flagged: true,
+			reasons: []DuplicateReason{DuplicateReasonName, DuplicateReasonDateOfBirth},
+		},
+		{
+			name:    "phone formatted differently with country code",
+			a:       newTestPatient("Maria", "Lopez", "+52 (55) 1234-5678", "", nil),
+			b:       newTestPatient("María", "López", "5512345678", "", nil),
+			flagged: true,
+			reasons: []DuplicateReason{DuplicateReasonPhone, DuplicateReasonName},
+		},
+		{
+			name: "legacy trunk prefix against a normalized phone",
+			a: func() *entities.Patient {
+				p := newTestPatient("Maria", "Lopez", "+52 1 55 1234 5678", "", nil)
+				e164 := "+525512345678"
+				p.PhoneE164 = &e164
+				return p
+			}(),
+			b:       newTestPatient("María", "López", "044 55 1234 5678", "", nil),
+			flagged: true,
+			reasons: []DuplicateReason{DuplicateReasonPhone, DuplicateReasonName},
+		},
+		{
+			name:    "email differs only by case",
+			a:       newTestPatient("Ana", "", "", "Ana@Example.com", nil),
+			b:       newTestPatient("Anna", "Ruiz", "", " ana@example.com", nil),
+			flagged: false,
+			reasons: []DuplicateReason{DuplicateReasonEmail},
+		},
+		{
+			name:    "swapped name order",
+			a:       newTestPatient("Perez", "Juan", "5512345678", "", nil),
+			b:       newTestPatient("Juan", "Perez", "5512345678", "", nil),
+			flagged: true,
+			reasons: []DuplicateReason{DuplicateReasonPhone, DuplicateReasonName},
+		},
+		{
+			name:      "relatives sharing a phone",
+			a:         newTestPatient("Juan", "Perez", "5512345678", "", date(1960, time.January, 1)),
+			b:         newTestPatient("Juana", "Perez", "5512345678", "", date(1992, time.June, 9)),
+			flagged:   false,
+			notReason: DuplicateReasonDateOfBirth,
+		},
+		{
+			name:    "common name only",
+			a:       newTestPatient("Juan", "Perez", "", "", nil),
+			b:       newTestPatient("Juan", "Perez", "", "", nil),
+			flagged: false,
+			reasons: []DuplicateReason{DuplicateReasonName},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := ScorePatientDuplicate(tt.a, tt.b)
+			if flagged := score >= DefaultDuplicateMinScore; flagged != tt.flagged {
+				t.Errorf("score %d (reasons %v): flagged = %v, want %v", score, reasons, flagged, tt.flagged)
+			}
+			for _, reason := range tt.reasons {
+				if !hasReason(reasons, reason) {
+					t.Errorf("reasons %v missing %q", reasons, reason)
+				}
+			}
+			if tt.notReason != "" && hasReason(reasons, tt.notReason) {
+				t.Errorf("reasons %v unexpectedly contain %q", reasons, tt.notReason)
+			}
+
+			reverse, _ := ScorePatientDuplicate(tt.b, tt.a)
+			if reverse != score {
+				t.Errorf("score is not symmetric: %d vs %d", score, reverse)
+			}
+		})
+	}
+}
+
+func TestFindDuplicatePairs(t *testing.T) {
+	dob := date(1985, time.March, 4)
+	juan1 := newTestPatient("Juan", "Perez", "5511111111", "", dob)
+	juan2 := newTestPatient("Juan", "Pérez", "5522222222", "", dob)
+	juan3 := newTestPatient("Juan Carlos", "Perez", "55-2222-2222", "juan@example.com", nil)
+	unrelated := newTestPatient("Lucia", "Gomez", "5533333333", "lucia@example.com", dob)
+
+	pairs := FindDuplicatePairs([]*entities.Patient{juan1, juan2, juan3, unrelated}, DefaultDuplicateMinScore)
+
+	found := make(map[[2]uuid.UUID]int)
+	for _, pair := range pairs {
+		found[[2]uuid.UUID{pair.Patient.ID, pair.Match.ID}] = pair.Score
+		found[[2]uuid.UUID{pair.Match.ID, pair.Patient.ID}] = pair.Score
+	}
+
+	if _, ok := found[[2]uuid.UUID{juan1.ID, juan2.ID}]; !ok {
+		t.Error("expected juan1/juan2 to be paired by name and date of birth")
+	}
+	if _, ok := found[[2]uuid.UUID{juan2.ID, juan3.ID}]; !ok {
+		t.Error("expected juan2/juan3 to be paired by phone and name")
+	}
+	for _, p := range []*entities.Patient{juan1, juan2, juan3} {
+		if _, ok := found[[2]uuid.UUID{p.ID, unrelated.ID}]; ok {
+			t.Errorf("unrelated patient paired with %s", p.FirstName)
+		}
+	}
+	for i := 1; i < len(pairs); i++ {
+		if pairs[i].Score > pairs[i-1].Score {
+			t.Fatalf("pairs not sorted by score: %d before %d", pairs[i-1].Score, pairs[i].Score)
+		}
+	}
+}
+
+func TestFindDuplicatesOf(t *testing.T) {
+	patient := newTestPatient("Juan", "Perez", "5511111111", "", nil)
+	others := []*entities.Patient{
+		patient,
+		newTestPatient("Juan", "Perez", "(55) 1111-1111", "", nil),
+		newTestPatient("Pedro", "Sanchez", "5599999999", "", nil),
+	}
+
+	candidates := FindDuplicatesOf(patient, others, DefaultDuplicateMinScore)
+	if len(candidates) != 1 {
+		t.Fatalf("got %d candidates, want 1", len(candidates))
+	}
+	if candidates[0].Match.ID != others[1].ID {
+		t.Errorf("matched %s, want the record with the same phone", candidates[0].Match.FirstName)
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"strings"
+)
+
+// maxPatientSearchTokens caps how many tokens of a query are matched; every token adds a
+// condition to the search, so a pasted paragraph should not turn into a huge query
+const maxPatientSearchTokens = 6
+
+// PatientSearchQuery is a free-text patient search split into tokens. A patient matches
+// when every token matches, so "perez 5512" finds Pérez patients whose phone contains 5512.
+type PatientSearchQuery struct {
+	Terms       []string // Lowercased tokens matched against the name and email
+	PhoneDigits []string // Tokens made of digits and phone punctuation, reduced to their digits
+}
+
+// ParsePatientSearchQuery splits a search query into name/email terms and phone digit groups
+func ParsePatientSearchQuery(query string) PatientSearchQuery {
+	var parsed PatientSearchQuery
+	seen := make(map[string]bool)
+
+	for _, token := range strings.Fields(strings.ToLower(query)) {
+		if len(parsed.Terms)+len(parsed.PhoneDigits) == maxPatientSearchTokens {
+			break
+		}
+
+		if digits, ok := phoneDigitsToken(token); ok {
+			if digits != "" && !seen["#"+digits] {
+				seen["#"+digits] = true
+				parsed.PhoneDigits = append(parsed.PhoneDigits, digits)
+			}
+			continue
+		}
+
+		if !seen[token] {
+			seen[token] = true
+			parsed.Terms = append(parsed.Terms, token)
+		}
+	}
+
+	return parsed
+}
+
+// IsEmpty reports whether the query has nothing to match on
+func (q PatientSearchQuery) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.PhoneDigits) == 0
+}
+
+// phoneDigitsToken reduces tokens such as "(55)", "+52" or "1234-5678" to their digits.
+// ok is false when the token contains anything other than digits and phone punctuation.
+func phoneDigitsToken(token string) (string, bool) {
+	var digits strings.Builder
+	for _, r := range token {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case strings.ContainsRune("+-().", r):
+		default:
+			return "", false
+		}
+	}
+	return digits.String(), true
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePatientSearchQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		terms       []string
+		phoneDigits []string
+	}{
+		{
+			name:  "name only",
+			query: "José Pérez",
+			terms: []string{"josé", "pérez"},
+		},
+		{
+			name:        "name and phone fragment",
+			query:       "perez 5512",
+			terms:       []string{"perez"},
+			phoneDigits: []string{"5512"},
+		},
+		{
+			name:        "phone punctuation is dropped",
+			query:       "+52 (55) 1234-5678",
+			phoneDigits: []string{"52", "55", "12345678"},
+		},
+		{
+			name:  "email keeps its digits",
+			query: "juan85@mail.com",
+			terms: []string{"juan85@mail.com"},
+		},
+		{
+			name:  "repeated tokens and bare punctuation are ignored",
+			query: "ana  ANA - ()",
+			terms: []string{"ana"},
+		},
+		{
+			name:  "token count is capped",
+			query: "a b c d e f g h",
+			terms: []string{"a", "b", "c", "d", "e", "f"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := ParsePatientSearchQuery(tt.query)
+			if !reflect.DeepEqual(parsed.Terms, tt.terms) {
+				t.Errorf("terms = %q, want %q", parsed.Terms, tt.terms)
+			}
+			if !reflect.DeepEqual(parsed.PhoneDigits, tt.phoneDigits) {
+				t.Errorf("phone digits = %q, want %q", parsed.PhoneDigits, tt.phoneDigits)
+			}
+		})
+	}
+}
+
+func TestPatientSearchQueryIsEmpty(t *testing.T) {
+	if !ParsePatientSearchQuery("  - () ").IsEmpty() {
+		t.Error("expected punctuation-only query to be empty")
+	}
+	if ParsePatientSearchQuery("ana").IsEmpty() {
+		t.Error("expected query with a term not to be empty")
+	}
+}
@@ -111,6 +111,14 @@ func (ss *SchedulingService) RescheduleAppointment(
 	return ss.appointmentRepo.Update(ctx, appointment)
 }
 
+// CheckConflicts checks every resource of an appointment for conflicts and availability
+func (ss *SchedulingService) CheckConflicts(
+	ctx context.Context,
+	appointment *entities.Appointment,
+) error {
+	return ss.conflictChecker.CheckForConflicts(ctx, appointment)
+}
+
 // GetAvailableSlots returns available time slots for a doctor on a specific date
 func (ss *SchedulingService) GetAvailableSlots(
 	ctx context.Context,
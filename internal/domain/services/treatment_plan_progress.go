@@ -0,0 +1,55 @@
+package services
+
+import (
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+// TreatmentPlanProgress summarizes how far a plan has been carried out. Values are in minor
+// currency units; declined procedures count towards neither the plan nor its value.
+type TreatmentPlanProgress struct {
+	Procedures       int   // Proposed or accepted procedures
+	Accepted         int   // Accepted procedures, including scheduled and completed ones
+	Scheduled        int   // Accepted procedures booked in an appointment
+	Completed        int   // Performed procedures
+	PercentComplete  int   // Completed share of the accepted procedures, 0-100
+	EstimatedValue   int64 // Value of proposed and accepted procedures
+	AcceptedValue    int64 // Value of accepted procedures
+	CompletedValue   int64 // Value of performed procedures
+	OutstandingValue int64 // Value of accepted procedures still to perform
+}
+
+// SummarizeTreatmentPlan computes the progress of a plan; cancelled plans have nothing outstanding
+func SummarizeTreatmentPlan(plan *entities.TreatmentPlan) TreatmentPlanProgress {
+	var progress TreatmentPlanProgress
+
+	for _, item := range plan.Items {
+		if item.Status == entities.TreatmentPlanItemStatusDeclined {
+			continue
+		}
+		progress.Procedures++
+		progress.EstimatedValue += item.EstimatedCost
+
+		if !item.IsAccepted() {
+			continue
+		}
+		progress.Accepted++
+		progress.AcceptedValue += item.EstimatedCost
+
+		switch item.Status {
+		case entities.TreatmentPlanItemStatusScheduled:
+			progress.Scheduled++
+		case entities.TreatmentPlanItemStatusCompleted:
+			progress.Completed++
+			progress.CompletedValue += item.EstimatedCost
+		}
+	}
+
+	if progress.Accepted > 0 {
+		progress.PercentComplete = progress.Completed * 100 / progress.Accepted
+	}
+	if plan.Status != entities.TreatmentPlanStatusCancelled {
+		progress.OutstandingValue = progress.AcceptedValue - progress.CompletedValue
+	}
+
+	return progress
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"dental-scheduler-backend/internal/domain/entities"
+)
+
+func TestSummarizeTreatmentPlan(t *testing.T) {
+	item := func(status entities.TreatmentPlanItemStatus, cost int64) *entities.TreatmentPlanItem {
+		return &entities.TreatmentPlanItem{Status: status, EstimatedCost: cost}
+	}
+
+	tests := []struct {
+		name   string
+		status entities.TreatmentPlanStatus
+		items  []*entities.TreatmentPlanItem
+		want   TreatmentPlanProgress
+	}{
+		{
+			name:   "presented plan has nothing accepted yet",
+			status: entities.TreatmentPlanStatusPresented,
+			items:  []*entities.TreatmentPlanItem{item(entities.TreatmentPlanItemStatusProposed, 150000), item(entities.TreatmentPlanItemStatusProposed, 50000)},
+			want:   TreatmentPlanProgress{Procedures: 2, EstimatedValue: 200000},
+		},
+		{
+			name:   "root canal done, post scheduled, crown accepted, whitening declined",
+			status: entities.TreatmentPlanStatusAccepted,
+			items: []*entities.TreatmentPlanItem{
+				item(entities.TreatmentPlanItemStatusCompleted, 400000),
+				item(entities.TreatmentPlanItemStatusScheduled, 100000),
+				item(entities.TreatmentPlanItemStatusAccepted, 500000),
+				item(entities.TreatmentPlanItemStatusDeclined, 300000),
+			},
+			want: TreatmentPlanProgress{
+				Procedures: 3, Accepted: 3, Scheduled: 1, Completed: 1, PercentComplete: 33,
+				EstimatedValue: 1000000, AcceptedValue: 1000000, CompletedValue: 400000, OutstandingValue: 600000,
+			},
+		},
+		{
+			name:   "cancelled plan has nothing outstanding",
+			status: entities.TreatmentPlanStatusCancelled,
+			items:  []*entities.TreatmentPlanItem{item(entities.TreatmentPlanItemStatusCompleted, 100), item(entities.TreatmentPlanItemStatusAccepted, 200)},
+			want: TreatmentPlanProgress{
+				Procedures: 2, Accepted: 2, Completed: 1, PercentComplete: 50,
+				EstimatedValue: 300, AcceptedValue: 300, CompletedValue: 100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &entities.TreatmentPlan{Status: tt.status, Items: tt.items}
+			if got := SummarizeTreatmentPlan(plan); got != tt.want {
+				t.Errorf("SummarizeTreatmentPlan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles API key management HTTP requests
+type APIKeyHandler struct {
+	apiKeyUseCase *usecases.APIKeyUseCase
+	logger        *logger.Logger
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance
+func NewAPIKeyHandler(apiKeyUseCase *usecases.APIKeyUseCase, logger *logger.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+		logger:        logger,
+	}
+}
+
+// CreateAPIKey handles POST /admin/api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateAPIKey")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	apiKey, err := h.apiKeyUseCase.CreateAPIKey(c.Request.Context(), orgID, userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create API key")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"api_key_id":      apiKey.ID,
+		"prefix":          apiKey.Prefix,
+	}).Info("API key created")
+
+	respondSuccess(c, http.StatusCreated, apiKey)
+}
+
+// ListAPIKeys handles GET /admin/api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	apiKeys, err := h.apiKeyUseCase.ListAPIKeys(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list API keys")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, apiKeys)
+}
+
+// RevokeAPIKey handles DELETE /admin/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	keyID, ok := uuidParam(c, "id", "API key")
+	if !ok {
+		return
+	}
+
+	apiKey, err := h.apiKeyUseCase.RevokeAPIKey(c.Request.Context(), orgID, keyID)
+	if err != nil {
+		h.handleError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"api_key_id":      keyID,
+	}).Info("API key revoked")
+
+	respondSuccess(c, http.StatusOK, apiKey)
+}
+
+// handleError maps API key errors to HTTP responses
+func (h *APIKeyHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidAPIKeyName, entities.ErrInvalidAPIKeyScope, entities.ErrInvalidAPIKeyExpiry:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrAPIKeyNotFound:
+		respondError(c, http.StatusNotFound, "API_KEY_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"dental-scheduler-backend/internal/app/dto"
 	"dental-scheduler-backend/internal/app/usecases"
 	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
 	"dental-scheduler-backend/internal/http/middleware"
 	"dental-scheduler-backend/internal/infra/logger"
 
@@ -187,6 +188,23 @@ func (h *AppointmentHandler) CreateAppointment(c *gin.Context) {
 		h.logger.Logger.WithError(err).Error("Failed to create appointment")
 
 		// Handle specific error types
+		switch err {
+		case entities.ErrTreatmentPlanItemNotFound:
+			respondError(c, http.StatusNotFound, "TREATMENT_PLAN_ITEM_NOT_FOUND", err.Error())
+			return
+		case entities.ErrTreatmentPlanItemNotSchedulable, entities.ErrTreatmentPlanPatientMismatch:
+			respondError(c, http.StatusConflict, "TREATMENT_PLAN_ITEM_NOT_SCHEDULABLE", err.Error())
+			return
+		case gateways.ErrPaymentGatewayNotConfigured:
+			respondError(c, http.StatusServiceUnavailable, "PAYMENT_GATEWAY_NOT_CONFIGURED", err.Error())
+			return
+		case entities.ErrAppointmentConflict, entities.ErrAppointmentResourceConflict, entities.ErrStaffNotAvailable, entities.ErrDoctorNotAvailable,
+			entities.ErrAppointmentResourceNotFound, entities.ErrAppointmentResourceInactive, entities.ErrResourceClinicMismatch,
+			entities.ErrInvalidResourceType, entities.ErrInvalidAppointmentRole, entities.ErrAppointmentRoleRequired,
+			entities.ErrResourceRoleNotAllowed, entities.ErrDuplicateAppointmentResource:
+			respondAppointmentResourceError(c, err)
+			return
+		}
 		if err.Error() == "schedule conflict detected" {
 			c.JSON(http.StatusConflict, gin.H{
 				"success": false,
@@ -210,6 +228,7 @@ func (h *AppointmentHandler) CreateAppointment(c *gin.Context) {
 
 	h.logger.Logger.WithField("appointment_id", response.ID).Info("Successfully created appointment")
 
+	setETag(c, response.Version)
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
 		"data":    response,
@@ -344,6 +363,12 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
 		return
 	}
 
+	version, ok := expectedVersion(c, req.Version)
+	if !ok {
+		return
+	}
+	req.Version = version
+
 	// Log the request
 	logFields := map[string]interface{}{
 		"appointment_id":  appointmentID,
@@ -361,7 +386,7 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
 	h.logger.Logger.WithFields(logFields).Info("Updating appointment")
 
 	// Execute use case
-	result, err := h.appointmentUseCase.UpdateAppointment(c.Request.Context(), appointmentID, &req)
+	result, err := h.appointmentUseCase.UpdateAppointment(c.Request.Context(), appointmentID, orgID, actingUserID(c), &req)
 	if err != nil {
 		h.logger.Logger.WithError(err).Error("Failed to update appointment")
 
@@ -375,6 +400,13 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
 					"message": "Appointment not found",
 				},
 			})
+		case entities.ErrAppointmentVersionConflict:
+			current, getErr := h.appointmentUseCase.GetAppointmentByID(c.Request.Context(), appointmentID)
+			if getErr != nil || current == nil {
+				respondError(c, http.StatusPreconditionFailed, "VERSION_CONFLICT", "Appointment was modified by another request")
+				return
+			}
+			respondVersionConflict(c, current.Version, current, "Appointment was modified by another request")
 		case entities.ErrPatientNotFound:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"success": false,
@@ -399,6 +431,16 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
 					"message": "Unit not found",
 				},
 			})
+		case entities.ErrCancellationReasonNotFound, entities.ErrCancellationReasonInactive,
+			entities.ErrFeeOverrideRequiresStaff, entities.ErrFeeOverrideJustificationRequired, entities.ErrInvalidFeeOverride:
+			respondCancellationError(c, err)
+		case entities.ErrDepositPending:
+			respondError(c, http.StatusConflict, "DEPOSIT_PENDING", err.Error())
+		case entities.ErrAppointmentConflict, entities.ErrAppointmentResourceConflict, entities.ErrStaffNotAvailable, entities.ErrDoctorNotAvailable,
+			entities.ErrAppointmentResourceNotFound, entities.ErrAppointmentResourceInactive, entities.ErrResourceClinicMismatch,
+			entities.ErrInvalidResourceType, entities.ErrInvalidAppointmentRole, entities.ErrAppointmentRoleRequired,
+			entities.ErrResourceRoleNotAllowed, entities.ErrDuplicateAppointmentResource:
+			respondAppointmentResourceError(c, err)
 		default:
 			// Handle validation errors and other errors
 			c.JSON(http.StatusBadRequest, gin.H{
@@ -421,6 +463,7 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
 	}).Info("Successfully updated appointment")
 
 	// Return successful response
+	setETag(c, result.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    result,
@@ -498,7 +541,7 @@ func (h *AppointmentHandler) GetReschedulingQueue(c *gin.Context) {
 	}
 
 	// Execute use case
-	response, err := h.appointmentUseCase.GetReschedulingQueue(c.Request.Context(), orgUUID, &req)
+	response, err := h.appointmentUseCase.GetReschedulingQueue(c.Request.Context(), orgUUID, &req, isStaffSession(c))
 	if err != nil {
 		h.logger.Logger.WithError(err).Error("Failed to get rescheduling queue")
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -523,7 +566,7 @@ func (h *AppointmentHandler) GetReschedulingQueue(c *gin.Context) {
 // @Produce json
 // @Param appointment_id path string true "Appointment ID"
 // @Param request body dto.CancelAppointmentRequest true "Cancellation details"
-// @Success 200 {object} map[string]interface{} "Success response"
+// @Success 200 {object} map[string]interface{} "Success response with the cancelled appointment and any late-cancellation fee"
 // @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 403 {object} ErrorResponse "No organization access"
 // @Failure 404 {object} ErrorResponse "Appointment not found"
@@ -590,11 +633,11 @@ func (h *AppointmentHandler) CancelFromQueue(c *gin.Context) {
 	h.logger.Logger.WithFields(map[string]interface{}{
 		"appointment_id": appointmentID,
 		"org_id":         orgID,
-		"reason":         req.Reason,
+		"reason_id":      req.ReasonID,
 	}).Info("Cancelling appointment from queue")
 
 	// Execute use case
-	err = h.appointmentUseCase.CancelFromQueue(c.Request.Context(), appointmentID, orgUUID, &req)
+	result, err := h.appointmentUseCase.CancelFromQueue(c.Request.Context(), appointmentID, orgUUID, actingUserID(c), &req)
 	if err != nil {
 		h.logger.Logger.WithError(err).Error("Failed to cancel appointment from queue")
 
@@ -608,6 +651,13 @@ func (h *AppointmentHandler) CancelFromQueue(c *gin.Context) {
 					"message": "Appointment not found",
 				},
 			})
+		case entities.ErrAppointmentVersionConflict:
+			current, getErr := h.appointmentUseCase.GetAppointmentByID(c.Request.Context(), appointmentID)
+			if getErr != nil || current == nil {
+				respondError(c, http.StatusPreconditionFailed, "VERSION_CONFLICT", "Appointment was modified by another request")
+				return
+			}
+			respondVersionConflict(c, current.Version, current, "Appointment was modified by another request")
 		case entities.ErrAppointmentNotInQueue:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"success": false,
@@ -616,6 +666,9 @@ func (h *AppointmentHandler) CancelFromQueue(c *gin.Context) {
 					"message": "Appointment is not in rescheduling queue",
 				},
 			})
+		case entities.ErrCancellationReasonNotFound, entities.ErrCancellationReasonInactive,
+			entities.ErrFeeOverrideRequiresStaff, entities.ErrFeeOverrideJustificationRequired, entities.ErrInvalidFeeOverride:
+			respondCancellationError(c, err)
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"success": false,
@@ -631,10 +684,11 @@ func (h *AppointmentHandler) CancelFromQueue(c *gin.Context) {
 	// Log success
 	h.logger.Logger.WithField("appointment_id", appointmentID).Info("Successfully cancelled appointment from queue")
 
-	// Return success response
+	// Return success response with any late-cancellation fee charged
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Appointment cancelled successfully",
+		"data":    result,
 	})
 }
 
@@ -735,6 +789,13 @@ func (h *AppointmentHandler) RescheduleFromQueue(c *gin.Context) {
 					"message": "Appointment not found",
 				},
 			})
+		case entities.ErrAppointmentVersionConflict:
+			current, getErr := h.appointmentUseCase.GetAppointmentByID(c.Request.Context(), appointmentID)
+			if getErr != nil || current == nil {
+				respondError(c, http.StatusPreconditionFailed, "VERSION_CONFLICT", "Appointment was modified by another request")
+				return
+			}
+			respondVersionConflict(c, current.Version, current, "Appointment was modified by another request")
 		case entities.ErrAppointmentNotInQueue:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"success": false,
@@ -767,6 +828,11 @@ func (h *AppointmentHandler) RescheduleFromQueue(c *gin.Context) {
 					"message": "Unit not found",
 				},
 			})
+		case entities.ErrAppointmentResourceConflict, entities.ErrStaffNotAvailable, entities.ErrDoctorNotAvailable,
+			entities.ErrAppointmentResourceNotFound, entities.ErrAppointmentResourceInactive, entities.ErrResourceClinicMismatch,
+			entities.ErrInvalidResourceType, entities.ErrInvalidAppointmentRole, entities.ErrAppointmentRoleRequired,
+			entities.ErrResourceRoleNotAllowed, entities.ErrDuplicateAppointmentResource:
+			respondAppointmentResourceError(c, err)
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"success": false,
@@ -873,6 +939,13 @@ func (h *AppointmentHandler) SnoozeFromQueue(c *gin.Context) {
 					"message": "Appointment not found",
 				},
 			})
+		case entities.ErrAppointmentVersionConflict:
+			current, getErr := h.appointmentUseCase.GetAppointmentByID(c.Request.Context(), appointmentID)
+			if getErr != nil || current == nil {
+				respondError(c, http.StatusPreconditionFailed, "VERSION_CONFLICT", "Appointment was modified by another request")
+				return
+			}
+			respondVersionConflict(c, current.Version, current, "Appointment was modified by another request")
 		case entities.ErrAppointmentNotInQueue:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"success": false,
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// versionedAppointmentRepo stores a single appointment and bumps its version on every update,
+// rejecting updates based on a stale version like the database does
+type versionedAppointmentRepo struct {
+	repositories.AppointmentRepository
+	appointment entities.Appointment
+	updates     int
+}
+
+func (r *versionedAppointmentRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities.Appointment, error) {
+	if id != r.appointment.ID {
+		return nil, nil
+	}
+	appointment := r.appointment
+	return &appointment, nil
+}
+
+func (r *versionedAppointmentRepo) Update(ctx context.Context, appointment *entities.Appointment) error {
+	if appointment.Version != r.appointment.Version {
+		return entities.ErrAppointmentVersionConflict
+	}
+	appointment.Version++
+	r.appointment = *appointment
+	r.updates++
+	return nil
+}
+
+// passthroughTxManager runs the function without a transaction
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestUpdateAppointmentOptimisticConcurrency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		body        string
+		wantStatus  int
+		wantETag    string
+		wantUpdated bool
+	}{
+		{
+			name:       "missing precondition",
+			body:       `{"notes":"bring x-rays"}`,
+			wantStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "stale If-Match",
+			ifMatch:    `"1"`,
+			body:       `{"notes":"bring x-rays"}`,
+			wantStatus: http.StatusPreconditionFailed,
+			wantETag:   `"2"`,
+		},
+		{
+			name:       "stale body version",
+			body:       `{"notes":"bring x-rays","version":1}`,
+			wantStatus: http.StatusPreconditionFailed,
+			wantETag:   `"2"`,
+		},
+		{
+			name:        "current If-Match",
+			ifMatch:     `"2"`,
+			body:        `{"notes":"bring x-rays"}`,
+			wantStatus:  http.StatusOK,
+			wantETag:    `"3"`,
+			wantUpdated: true,
+		},
+		{
+			name:        "current body version",
+			body:        `{"notes":"bring x-rays","version":2}`,
+			wantStatus:  http.StatusOK,
+			wantETag:    `"3"`,
+			wantUpdated: true,
+		},
+		{
+			name:        "unconditional If-Match",
+			ifMatch:     "*",
+			body:        `{"notes":"bring x-rays"}`,
+			wantStatus:  http.StatusOK,
+			wantETag:    `"3"`,
+			wantUpdated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+			repo := &versionedAppointmentRepo{appointment: entities.Appointment{
+				ID:        uuid.New(),
+				Status:    entities.AppointmentStatusScheduled,
+				StartTime: start,
+				EndTime:   start.Add(time.Hour),
+				Version:   2,
+			}}
+			useCase := usecases.NewAppointmentUseCase(
+				repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				passthroughTxManager{}, nil, logger.NewLogger("error"),
+			)
+			handler := NewAppointmentHandler(useCase, logger.NewLogger("error"))
+
+			router := gin.New()
+			router.PUT("/appointments/:appointment_id", func(c *gin.Context) {
+				c.Set("organization_id", uuid.NewString())
+				c.Next()
+			}, handler.UpdateAppointment)
+
+			req := httptest.NewRequest(http.MethodPut, "/appointments/"+repo.appointment.ID.String(), strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if updated := repo.updates > 0; updated != tt.wantUpdated {
+				t.Errorf("updated = %v, want %v", updated, tt.wantUpdated)
+			}
+
+			if tt.wantETag != "" {
+				var body struct {
+					Data struct {
+						Version int `json:"version"`
+					} `json:"data"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if got := `"` + strconv.Itoa(body.Data.Version) + `"`; got != tt.wantETag {
+					t.Errorf("data.version = %d, want it to match ETag %s", body.Data.Version, tt.wantETag)
+				}
+			}
+		})
+	}
+}
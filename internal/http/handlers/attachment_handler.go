@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// multipartOverhead is the room left in upload requests for form fields and part headers
+const multipartOverhead = 1 << 20
+
+// AttachmentHandler handles patient file HTTP requests
+type AttachmentHandler struct {
+	attachmentUseCase *usecases.AttachmentUseCase
+	logger            *logger.Logger
+}
+
+// NewAttachmentHandler creates a new AttachmentHandler instance
+func NewAttachmentHandler(attachmentUseCase *usecases.AttachmentUseCase, logger *logger.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentUseCase: attachmentUseCase,
+		logger:            logger,
+	}
+}
+
+// UploadAttachment handles POST /patients/:id/attachments (multipart/form-data with a file part)
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	// Reject oversized uploads while reading the body instead of after buffering them
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachmentUseCase.MaxUploadBytes()+multipartOverhead)
+
+	var req dto.UploadAttachmentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		if h.respondTooLarge(c, err) {
+			return
+		}
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UploadAttachment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if req.AppointmentIDStr != "" {
+		appointmentID, err := uuid.Parse(req.AppointmentIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid appointment ID format")
+			return
+		}
+		req.AppointmentID = &appointmentID
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		if h.respondTooLarge(c, err) {
+			return
+		}
+		h.handleError(c, entities.ErrAttachmentFileRequired, "Failed to upload attachment")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.handleError(c, err, "Failed to read uploaded file")
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentUseCase.UploadAttachment(c.Request.Context(), orgID, patientID, &userID, &req, dto.AttachmentFile{
+		FileName: fileHeader.Filename,
+		Content:  file,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to upload attachment")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"patient_id":      patientID,
+		"attachment_id":   attachment.ID,
+		"type":            attachment.Type,
+		"content_type":    attachment.ContentType,
+		"size_bytes":      attachment.SizeBytes,
+	}).Info("Attachment uploaded")
+
+	respondSuccess(c, http.StatusCreated, attachment)
+}
+
+// ListPatientAttachments handles GET /patients/:id/attachments
+func (h *AttachmentHandler) ListPatientAttachments(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	req, ok := h.bindListRequest(c, "ListPatientAttachments")
+	if !ok {
+		return
+	}
+
+	attachments, err := h.attachmentUseCase.ListPatientAttachments(c.Request.Context(), orgID, patientID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list attachments")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, attachments)
+}
+
+// ListAppointmentAttachments handles GET /appointments/:id/attachments
+func (h *AttachmentHandler) ListAppointmentAttachments(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	appointmentID, ok := uuidParam(c, "id", "appointment")
+	if !ok {
+		return
+	}
+
+	req, ok := h.bindListRequest(c, "ListAppointmentAttachments")
+	if !ok {
+		return
+	}
+
+	attachments, err := h.attachmentUseCase.ListAppointmentAttachments(c.Request.Context(), orgID, appointmentID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list attachments")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, attachments)
+}
+
+// GetAttachment handles GET /attachments/:id
+func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	attachmentID, ok := uuidParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+
+	var req dto.AttachmentQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for GetAttachment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	attachment, err := h.attachmentUseCase.GetAttachment(c.Request.Context(), orgID, attachmentID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get attachment")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, attachment)
+}
+
+// UpdateAttachment handles PATCH /attachments/:id
+func (h *AttachmentHandler) UpdateAttachment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	attachmentID, ok := uuidParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAttachmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateAttachment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	attachment, err := h.attachmentUseCase.UpdateAttachment(c.Request.Context(), orgID, attachmentID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update attachment")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, attachment)
+}
+
+// DeleteAttachment handles DELETE /attachments/:id
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	attachmentID, ok := uuidParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentUseCase.DeleteAttachment(c.Request.Context(), orgID, attachmentID); err != nil {
+		h.handleError(c, err, "Failed to delete attachment")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"attachment_id":   attachmentID,
+	}).Info("Attachment deleted")
+
+	respondSuccess(c, http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
+
+// DownloadAttachment handles GET /attachments/:id/download: a redirect to a pre-signed URL,
+// or the file streamed through the API (?stream=true, or storage without pre-signing)
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	attachmentID, ok := uuidParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+
+	var req dto.AttachmentDownloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for DownloadAttachment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	content, err := h.attachmentUseCase.OpenAttachment(c.Request.Context(), orgID, attachmentID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to download attachment")
+		return
+	}
+
+	sendContent(c, content, req.Inline)
+}
+
+// DownloadThumbnail handles GET /attachments/:id/thumbnail
+func (h *AttachmentHandler) DownloadThumbnail(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	attachmentID, ok := uuidParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+
+	var req dto.AttachmentDownloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for DownloadThumbnail")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	content, err := h.attachmentUseCase.OpenThumbnail(c.Request.Context(), orgID, attachmentID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to download attachment thumbnail")
+		return
+	}
+
+	sendContent(c, content, true)
+}
+
+// bindListRequest binds the filters of an attachment listing
+func (h *AttachmentHandler) bindListRequest(c *gin.Context, operation string) (*dto.AttachmentListRequest, bool) {
+	var req dto.AttachmentListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for " + operation)
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return nil, false
+	}
+	if req.AppointmentIDStr != "" {
+		appointmentID, err := uuid.Parse(req.AppointmentIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid appointment ID format")
+			return nil, false
+		}
+		req.AppointmentID = &appointmentID
+	}
+	return &req, true
+}
+
+// sendContent redirects to a pre-signed URL or streams the stored file. Patient files are
+// never cached by shared caches and browsers must not guess another content type.
+func sendContent(c *gin.Context, content *dto.AttachmentContent, inline bool) {
+	c.Header("Cache-Control", "private, no-store")
+	if content.RedirectURL != "" {
+		c.Redirect(http.StatusFound, content.RedirectURL)
+		return
+	}
+	defer content.Body.Close()
+
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+	if header := mime.FormatMediaType(disposition, map[string]string{"filename": content.FileName}); header != "" {
+		disposition = header
+	}
+
+	size := content.Size
+	if size == 0 {
+		size = -1
+	}
+	c.DataFromReader(http.StatusOK, size, content.ContentType, content.Body, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// respondTooLarge writes a 413 when the request body exceeded the upload limit
+func (h *AttachmentHandler) respondTooLarge(c *gin.Context, err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(err, &maxBytesErr) {
+		return false
+	}
+	h.handleError(c, entities.ErrAttachmentTooLarge, "Failed to upload attachment")
+	return true
+}
+
+// handleError maps attachment errors to HTTP responses
+func (h *AttachmentHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidAttachmentType, entities.ErrAttachmentFileRequired, entities.ErrAttachmentFileNameRequired,
+		entities.ErrAttachmentEmpty, entities.ErrAttachmentTakenInFuture, entities.ErrInvalidAttachmentDate,
+		entities.ErrAttachmentAppointmentMismatch, entities.ErrInvalidToothNumber, entities.ErrUnsupportedToothNumbering:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrAttachmentTooLarge:
+		respondError(c, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", err.Error())
+	case entities.ErrUnsupportedAttachmentType:
+		respondError(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_FILE_TYPE", err.Error())
+	case entities.ErrAttachmentNotFound:
+		respondError(c, http.StatusNotFound, "ATTACHMENT_NOT_FOUND", err.Error())
+	case entities.ErrAttachmentNoThumbnail:
+		respondError(c, http.StatusNotFound, "THUMBNAIL_NOT_FOUND", err.Error())
+	case entities.ErrBlobNotFound:
+		h.logger.Logger.WithError(err).Error("Attachment file missing from storage")
+		respondError(c, http.StatusNotFound, "FILE_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentNotFound:
+		respondError(c, http.StatusNotFound, "APPOINTMENT_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
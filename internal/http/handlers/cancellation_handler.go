@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CancellationHandler handles cancellation reason, cancellation policy and late-cancellation fee HTTP requests
+type CancellationHandler struct {
+	cancellationUseCase *usecases.CancellationUseCase
+	logger              *logger.Logger
+}
+
+// NewCancellationHandler creates a new CancellationHandler instance
+func NewCancellationHandler(cancellationUseCase *usecases.CancellationUseCase, logger *logger.Logger) *CancellationHandler {
+	return &CancellationHandler{
+		cancellationUseCase: cancellationUseCase,
+		logger:              logger,
+	}
+}
+
+// ListReasons handles GET /cancellation-reasons
+func (h *CancellationHandler) ListReasons(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CancellationReasonListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListReasons")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	reasons, err := h.cancellationUseCase.ListReasons(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list cancellation reasons")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, reasons)
+}
+
+// CreateReason handles POST /cancellation-reasons
+func (h *CancellationHandler) CreateReason(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CancellationReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateReason")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	reason, err := h.cancellationUseCase.CreateReason(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create cancellation reason")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, reason)
+}
+
+// UpdateReason handles PUT /cancellation-reasons/:id
+func (h *CancellationHandler) UpdateReason(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	reasonID, ok := uuidParam(c, "id", "cancellation reason")
+	if !ok {
+		return
+	}
+
+	var req dto.CancellationReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateReason")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	reason, err := h.cancellationUseCase.UpdateReason(c.Request.Context(), orgID, reasonID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update cancellation reason")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, reason)
+}
+
+// GetPolicy handles GET /admin/cancellation-policy
+func (h *CancellationHandler) GetPolicy(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.cancellationUseCase.GetPolicy(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get cancellation policy")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, policy)
+}
+
+// UpdatePolicy handles PUT /admin/cancellation-policy
+func (h *CancellationHandler) UpdatePolicy(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CancellationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdatePolicy")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	policy, err := h.cancellationUseCase.UpdatePolicy(c.Request.Context(), orgID, actingUserID(c), &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update cancellation policy")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, policy)
+}
+
+// ListFees handles GET /cancellation-fees
+func (h *CancellationHandler) ListFees(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CancellationFeeListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListFees")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if req.PatientIDStr != "" {
+		patientID, err := uuid.Parse(req.PatientIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid patient ID format")
+			return
+		}
+		req.PatientID = &patientID
+	}
+
+	fees, err := h.cancellationUseCase.ListFees(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list cancellation fees")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, fees)
+}
+
+// ListPatientFees handles GET /patients/:id/cancellation-fees
+func (h *CancellationHandler) ListPatientFees(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.CancellationFeeListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListPatientFees")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	fees, err := h.cancellationUseCase.ListPatientFees(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list patient cancellation fees")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, fees)
+}
+
+// GetFee handles GET /cancellation-fees/:id
+func (h *CancellationHandler) GetFee(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	feeID, ok := uuidParam(c, "id", "cancellation fee")
+	if !ok {
+		return
+	}
+
+	fee, err := h.cancellationUseCase.GetFee(c.Request.Context(), orgID, feeID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get cancellation fee")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, fee)
+}
+
+// OverrideFee handles POST /cancellation-fees/:id/override
+func (h *CancellationHandler) OverrideFee(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	feeID, ok := uuidParam(c, "id", "cancellation fee")
+	if !ok {
+		return
+	}
+
+	var req dto.CancellationFeeOverride
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for OverrideFee")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	fee, err := h.cancellationUseCase.OverrideFee(c.Request.Context(), orgID, feeID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to override cancellation fee")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, fee)
+}
+
+// InvoiceFee handles POST /cancellation-fees/:id/invoice
+func (h *CancellationHandler) InvoiceFee(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	feeID, ok := uuidParam(c, "id", "cancellation fee")
+	if !ok {
+		return
+	}
+
+	var req dto.InvoiceCancellationFeeRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Logger.WithError(err).Warn("Invalid request body for InvoiceFee")
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+	}
+
+	invoice, err := h.cancellationUseCase.InvoiceFee(c.Request.Context(), orgID, feeID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to invoice cancellation fee")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, invoice)
+}
+
+func (h *CancellationHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrCancellationReasonLabelRequired, entities.ErrInvalidCancellationCategory, entities.ErrInvalidCancellationNotice,
+		entities.ErrInvalidCancellationFeeType, entities.ErrInvalidCancellationFee, entities.ErrInvalidCancellationFeeStatus,
+		entities.ErrInvalidTaxRate:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrCancellationReasonExists:
+		respondError(c, http.StatusConflict, "CANCELLATION_REASON_EXISTS", err.Error())
+	case entities.ErrCancellationFeeNotPending:
+		respondError(c, http.StatusConflict, "CANCELLATION_FEE_NOT_PENDING", err.Error())
+	case entities.ErrCancellationFeeNotFound:
+		respondError(c, http.StatusNotFound, "CANCELLATION_FEE_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	case entities.ErrOrganizationNotFound:
+		respondError(c, http.StatusNotFound, "ORGANIZATION_NOT_FOUND", err.Error())
+	case entities.ErrCancellationReasonNotFound, entities.ErrCancellationReasonInactive,
+		entities.ErrFeeOverrideRequiresStaff, entities.ErrFeeOverrideJustificationRequired, entities.ErrInvalidFeeOverride:
+		respondCancellationError(c, err)
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
+
+// respondCancellationError writes the response for the errors of choosing a cancellation reason
+// and overriding a late-cancellation fee, shared by every path that cancels an appointment
+func respondCancellationError(c *gin.Context, err error) {
+	switch err {
+	case entities.ErrCancellationReasonNotFound:
+		respondError(c, http.StatusNotFound, "CANCELLATION_REASON_NOT_FOUND", err.Error())
+	case entities.ErrCancellationReasonInactive:
+		respondError(c, http.StatusConflict, "CANCELLATION_REASON_INACTIVE", err.Error())
+	case entities.ErrFeeOverrideRequiresStaff:
+		respondError(c, http.StatusForbidden, "FEE_OVERRIDE_REQUIRES_STAFF", err.Error())
+	default:
+		respondError(c, http.StatusBadRequest, "INVALID_FEE_OVERRIDE", err.Error())
+	}
+}
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CFDIHandler handles fiscal data and CFDI electronic invoicing HTTP requests
+type CFDIHandler struct {
+	cfdiUseCase *usecases.CFDIUseCase
+	logger      *logger.Logger
+}
+
+// NewCFDIHandler creates a new CFDIHandler instance
+func NewCFDIHandler(cfdiUseCase *usecases.CFDIUseCase, logger *logger.Logger) *CFDIHandler {
+	return &CFDIHandler{
+		cfdiUseCase: cfdiUseCase,
+		logger:      logger,
+	}
+}
+
+// GetPatientFiscalData handles GET /patients/:id/fiscal-data
+func (h *CFDIHandler) GetPatientFiscalData(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	profile, err := h.cfdiUseCase.GetPatientFiscalData(c.Request.Context(), orgID, patientID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get patient fiscal data")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, profile)
+}
+
+// SetPatientFiscalData handles PUT /patients/:id/fiscal-data
+func (h *CFDIHandler) SetPatientFiscalData(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.PatientFiscalDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for SetPatientFiscalData")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	profile, err := h.cfdiUseCase.SetPatientFiscalData(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to save patient fiscal data")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, profile)
+}
+
+// DeletePatientFiscalData handles DELETE /patients/:id/fiscal-data
+func (h *CFDIHandler) DeletePatientFiscalData(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	if err := h.cfdiUseCase.DeletePatientFiscalData(c.Request.Context(), orgID, patientID); err != nil {
+		h.handleError(c, err, "Failed to delete patient fiscal data")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetIssuerProfile handles GET /admin/fiscal-profile
+func (h *CFDIHandler) GetIssuerProfile(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.cfdiUseCase.GetIssuerProfile(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get issuer fiscal profile")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, profile)
+}
+
+// SetIssuerProfile handles PUT /admin/fiscal-profile
+func (h *CFDIHandler) SetIssuerProfile(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.IssuerFiscalProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for SetIssuerProfile")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	profile, err := h.cfdiUseCase.SetIssuerProfile(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to save issuer fiscal profile")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"rfc":             profile.RFC,
+	}).Info("Issuer fiscal profile updated")
+
+	respondSuccess(c, http.StatusOK, profile)
+}
+
+// StampInvoice handles POST /invoices/:id/cfdis
+func (h *CFDIHandler) StampInvoice(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	invoiceID, ok := uuidParam(c, "id", "invoice")
+	if !ok {
+		return
+	}
+
+	cfdi, err := h.cfdiUseCase.StampInvoice(c.Request.Context(), orgID, invoiceID, &userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to stamp invoice")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"invoice_id":      invoiceID,
+		"cfdi_uuid":       cfdi.UUID,
+	}).Info("Invoice stamped")
+
+	respondSuccess(c, http.StatusCreated, cfdi)
+}
+
+// ListCFDIs handles GET /invoices/:id/cfdis
+func (h *CFDIHandler) ListCFDIs(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	invoiceID, ok := uuidParam(c, "id", "invoice")
+	if !ok {
+		return
+	}
+
+	cfdis, err := h.cfdiUseCase.ListCFDIs(c.Request.Context(), orgID, invoiceID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list CFDI")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, cfdis)
+}
+
+// DownloadCFDIXML handles GET /invoices/:id/cfdis/:cfdi_id/xml
+func (h *CFDIHandler) DownloadCFDIXML(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	invoiceID, ok := uuidParam(c, "id", "invoice")
+	if !ok {
+		return
+	}
+	cfdiID, ok := uuidParam(c, "cfdi_id", "CFDI")
+	if !ok {
+		return
+	}
+
+	document, err := h.cfdiUseCase.GetCFDIXML(c.Request.Context(), orgID, invoiceID, cfdiID)
+	if err != nil {
+		h.handleError(c, err, "Failed to download CFDI")
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}))
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", document.Content)
+}
+
+// CancelCFDI handles POST /invoices/:id/cfdis/:cfdi_id/cancel
+func (h *CFDIHandler) CancelCFDI(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	invoiceID, ok := uuidParam(c, "id", "invoice")
+	if !ok {
+		return
+	}
+	cfdiID, ok := uuidParam(c, "cfdi_id", "CFDI")
+	if !ok {
+		return
+	}
+
+	var req dto.CancelCFDIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CancelCFDI")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	cfdi, err := h.cfdiUseCase.CancelCFDI(c.Request.Context(), orgID, invoiceID, cfdiID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to cancel CFDI")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"invoice_id":      invoiceID,
+		"cfdi_uuid":       cfdi.UUID,
+		"reason":          req.Reason,
+		"status":          cfdi.Status,
+	}).Info("CFDI cancellation requested")
+
+	respondSuccess(c, http.StatusOK, cfdi)
+}
+
+// handleError maps fiscal data and CFDI errors to HTTP responses. Rejections from the PAC
+// or the SAT are returned with their code for staff to fix the fiscal data.
+func (h *CFDIHandler) handleError(c *gin.Context, err error, message string) {
+	var rejection *gateways.PACRejection
+	if errors.As(err, &rejection) {
+		respondError(c, http.StatusUnprocessableEntity, "PAC_REJECTED", rejection.Error())
+		return
+	}
+
+	switch err {
+	case gateways.ErrPACNotConfigured:
+		respondError(c, http.StatusServiceUnavailable, "PAC_NOT_CONFIGURED", err.Error())
+	case entities.ErrInvalidRFC, entities.ErrGenericRFC, entities.ErrFiscalNameRequired, entities.ErrInvalidFiscalPostalCode,
+		entities.ErrInvalidTaxRegime, entities.ErrInvalidCFDIUse, entities.ErrCFDIUseNotAllowed, entities.ErrInvalidCFDIProductCode,
+		entities.ErrInvalidCFDICancellationReason, entities.ErrInvalidReplacementUUID:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrPatientFiscalDataNotFound:
+		respondError(c, http.StatusNotFound, "FISCAL_DATA_NOT_FOUND", err.Error())
+	case entities.ErrIssuerFiscalDataNotFound:
+		respondError(c, http.StatusConflict, "ISSUER_FISCAL_DATA_MISSING", err.Error())
+	case entities.ErrCFDICurrencyNotSupported:
+		respondError(c, http.StatusConflict, "CURRENCY_NOT_SUPPORTED", err.Error())
+	case entities.ErrInvoiceAlreadyStamped:
+		respondError(c, http.StatusConflict, "INVOICE_ALREADY_STAMPED", err.Error())
+	case entities.ErrInvoiceVoided:
+		respondError(c, http.StatusConflict, "INVOICE_VOID", err.Error())
+	case entities.ErrCFDICancelled:
+		respondError(c, http.StatusConflict, "CFDI_CANCELLED", err.Error())
+	case entities.ErrCFDINotFound:
+		respondError(c, http.StatusNotFound, "CFDI_NOT_FOUND", err.Error())
+	case entities.ErrInvoiceNotFound:
+		respondError(c, http.StatusNotFound, "INVOICE_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
@@ -63,7 +63,7 @@ func (h *ClinicHandler) CreateClinic(c *gin.Context) {
 	clinic, err := h.clinicUseCase.CreateClinic(c.Request.Context(), &req)
 	if err != nil {
 		h.logger.Logger.WithError(err).Error("Failed to create clinic")
-		if err == entities.ErrInvalidClinicName {
+		if err == entities.ErrInvalidClinicName || err == entities.ErrInvalidPhoneNumber {
 			badRequest(c, err.Error())
 			return
 		}
@@ -132,7 +132,7 @@ func (h *ClinicHandler) UpdateClinic(c *gin.Context) {
 			notFound(c, "Clinic not found")
 			return
 		}
-		if err == entities.ErrInvalidClinicName {
+		if err == entities.ErrInvalidClinicName || err == entities.ErrInvalidPhoneNumber {
 			badRequest(c, err.Error())
 			return
 		}
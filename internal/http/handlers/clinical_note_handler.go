@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ClinicalNoteHandler handles clinical note and note template HTTP requests
+type ClinicalNoteHandler struct {
+	noteUseCase *usecases.ClinicalNoteUseCase
+	logger      *logger.Logger
+}
+
+// NewClinicalNoteHandler creates a new ClinicalNoteHandler instance
+func NewClinicalNoteHandler(noteUseCase *usecases.ClinicalNoteUseCase, logger *logger.Logger) *ClinicalNoteHandler {
+	return &ClinicalNoteHandler{
+		noteUseCase: noteUseCase,
+		logger:      logger,
+	}
+}
+
+// ListTemplates handles GET /clinical-note-templates
+func (h *ClinicalNoteHandler) ListTemplates(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.noteUseCase.ListTemplates(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list clinical note templates")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, templates)
+}
+
+// CreateTemplate handles POST /clinical-note-templates
+func (h *ClinicalNoteHandler) CreateTemplate(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateClinicalNoteTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateTemplate")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	template, err := h.noteUseCase.CreateTemplate(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create clinical note template")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, template)
+}
+
+// UpdateTemplate handles PUT /clinical-note-templates/:id
+func (h *ClinicalNoteHandler) UpdateTemplate(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	templateID, ok := uuidParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateClinicalNoteTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateTemplate")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	template, err := h.noteUseCase.UpdateTemplate(c.Request.Context(), orgID, templateID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update clinical note template")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, template)
+}
+
+// DeleteTemplate handles DELETE /clinical-note-templates/:id
+func (h *ClinicalNoteHandler) DeleteTemplate(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	templateID, ok := uuidParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	if err := h.noteUseCase.DeleteTemplate(c.Request.Context(), orgID, templateID); err != nil {
+		h.handleError(c, err, "Failed to delete clinical note template")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateNote handles POST /clinical-notes
+func (h *ClinicalNoteHandler) CreateNote(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateClinicalNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateNote")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	note, err := h.noteUseCase.CreateNote(c.Request.Context(), orgID, userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create clinical note")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"note_id":         note.ID,
+		"appointment_id":  note.AppointmentID,
+	}).Info("Clinical note created")
+
+	respondSuccess(c, http.StatusCreated, note)
+}
+
+// GetNoteByAppointment handles GET /clinical-notes?appointment_id=
+func (h *ClinicalNoteHandler) GetNoteByAppointment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ClinicalNoteQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for GetNoteByAppointment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	appointmentID, err := uuid.Parse(req.AppointmentID)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid appointment ID format")
+		return
+	}
+
+	note, err := h.noteUseCase.GetNoteByAppointment(c.Request.Context(), orgID, appointmentID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get clinical note")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, note)
+}
+
+// GetNote handles GET /clinical-notes/:id
+func (h *ClinicalNoteHandler) GetNote(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	noteID, ok := uuidParam(c, "id", "clinical note")
+	if !ok {
+		return
+	}
+
+	note, err := h.noteUseCase.GetNote(c.Request.Context(), orgID, noteID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get clinical note")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, note)
+}
+
+// UpdateNote handles PATCH /clinical-notes/:id
+func (h *ClinicalNoteHandler) UpdateNote(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	noteID, ok := uuidParam(c, "id", "clinical note")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateClinicalNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateNote")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	note, err := h.noteUseCase.UpdateNote(c.Request.Context(), orgID, userID, noteID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update clinical note")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, note)
+}
+
+// SignNote handles POST /clinical-notes/:id/sign
+func (h *ClinicalNoteHandler) SignNote(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	noteID, ok := uuidParam(c, "id", "clinical note")
+	if !ok {
+		return
+	}
+
+	note, err := h.noteUseCase.SignNote(c.Request.Context(), orgID, userID, noteID)
+	if err != nil {
+		h.handleError(c, err, "Failed to sign clinical note")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"note_id":         noteID,
+	}).Info("Clinical note signed")
+
+	respondSuccess(c, http.StatusOK, note)
+}
+
+// AmendNote handles POST /clinical-notes/:id/amendments
+func (h *ClinicalNoteHandler) AmendNote(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	noteID, ok := uuidParam(c, "id", "clinical note")
+	if !ok {
+		return
+	}
+
+	var req dto.AmendClinicalNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for AmendNote")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	note, err := h.noteUseCase.AmendNote(c.Request.Context(), orgID, userID, noteID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to amend clinical note")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, note)
+}
+
+// GetPatientTimeline handles GET /patients/:id/clinical-notes
+func (h *ClinicalNoteHandler) GetPatientTimeline(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.ClinicalNoteTimelineRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for GetPatientTimeline")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	timeline, err := h.noteUseCase.GetPatientTimeline(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get clinical note timeline")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, timeline)
+}
+
+// handleError maps clinical note errors to HTTP responses
+func (h *ClinicalNoteHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrClinicalNoteTemplateNameRequired, entities.ErrClinicalNoteEmpty, entities.ErrClinicalNoteAmendmentEmpty,
+		entities.ErrClinicalNoteNotSigned:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrNotTreatingDoctor, entities.ErrUserNotLinkedToDoctor, entities.ErrClinicalNoteNotAuthor:
+		respondError(c, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case entities.ErrClinicalNoteSigned:
+		respondError(c, http.StatusConflict, "CLINICAL_NOTE_SIGNED", err.Error())
+	case entities.ErrClinicalNoteExists:
+		respondError(c, http.StatusConflict, "CLINICAL_NOTE_EXISTS", err.Error())
+	case entities.ErrClinicalNoteTemplateExists:
+		respondError(c, http.StatusConflict, "TEMPLATE_EXISTS", err.Error())
+	case entities.ErrClinicalNoteNotFound:
+		respondError(c, http.StatusNotFound, "CLINICAL_NOTE_NOT_FOUND", err.Error())
+	case entities.ErrClinicalNoteTemplateNotFound:
+		respondError(c, http.StatusNotFound, "TEMPLATE_NOT_FOUND", err.Error())
+	case entities.ErrServiceNotFound:
+		respondError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentNotFound:
+		respondError(c, http.StatusNotFound, "APPOINTMENT_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
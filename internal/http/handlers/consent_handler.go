@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// consentDocumentPolicy confines signed copies opened in a browser to their own inline
+// styles and the embedded signature image
+const consentDocumentPolicy = "default-src 'none'; style-src 'unsafe-inline'; img-src data:"
+
+// ConsentHandler handles consent template, consent form and public signing HTTP requests
+type ConsentHandler struct {
+	consentUseCase *usecases.ConsentUseCase
+	logger         *logger.Logger
+}
+
+// NewConsentHandler creates a new ConsentHandler instance
+func NewConsentHandler(consentUseCase *usecases.ConsentUseCase, logger *logger.Logger) *ConsentHandler {
+	return &ConsentHandler{
+		consentUseCase: consentUseCase,
+		logger:         logger,
+	}
+}
+
+// ListTemplates handles GET /consent-templates
+func (h *ConsentHandler) ListTemplates(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.consentUseCase.ListTemplates(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list consent templates")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, templates)
+}
+
+// CreateTemplate handles POST /consent-templates
+func (h *ConsentHandler) CreateTemplate(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateConsentTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateConsentTemplate")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	template, err := h.consentUseCase.CreateTemplate(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create consent template")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, template)
+}
+
+// UpdateTemplate handles PUT /consent-templates/:id
+func (h *ConsentHandler) UpdateTemplate(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	templateID, ok := uuidParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateConsentTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateConsentTemplate")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	template, err := h.consentUseCase.UpdateTemplate(c.Request.Context(), orgID, templateID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update consent template")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, template)
+}
+
+// DeleteTemplate handles DELETE /consent-templates/:id
+func (h *ConsentHandler) DeleteTemplate(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	templateID, ok := uuidParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	if err := h.consentUseCase.DeleteTemplate(c.Request.Context(), orgID, templateID); err != nil {
+		h.handleError(c, err, "Failed to delete consent template")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RequestConsent handles POST /patients/:id/consent-forms
+func (h *ConsentHandler) RequestConsent(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.RequestConsentFormRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for RequestConsent")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	form, err := h.consentUseCase.RequestConsent(c.Request.Context(), orgID, patientID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to request consent")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"patient_id":      patientID,
+		"consent_form_id": form.ID,
+	}).Info("Consent form requested")
+
+	respondSuccess(c, http.StatusCreated, form)
+}
+
+// ListPatientConsentForms handles GET /patients/:id/consent-forms
+func (h *ConsentHandler) ListPatientConsentForms(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.ConsentFormListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListPatientConsentForms")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if req.AppointmentIDStr != "" {
+		appointmentID, err := uuid.Parse(req.AppointmentIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid appointment ID format")
+			return
+		}
+		req.AppointmentID = &appointmentID
+	}
+
+	forms, err := h.consentUseCase.ListPatientConsentForms(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list consent forms")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, forms)
+}
+
+// GetConsentForm handles GET /consent-forms/:id
+func (h *ConsentHandler) GetConsentForm(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	formID, ok := uuidParam(c, "id", "consent form")
+	if !ok {
+		return
+	}
+
+	form, err := h.consentUseCase.GetConsentForm(c.Request.Context(), orgID, formID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get consent form")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, form)
+}
+
+// RenewSigningLink handles POST /consent-forms/:id/link
+func (h *ConsentHandler) RenewSigningLink(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	formID, ok := uuidParam(c, "id", "consent form")
+	if !ok {
+		return
+	}
+
+	form, err := h.consentUseCase.RenewSigningLink(c.Request.Context(), orgID, formID)
+	if err != nil {
+		h.handleError(c, err, "Failed to renew consent signing link")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, form)
+}
+
+// RevokeConsentForm handles POST /consent-forms/:id/revoke
+func (h *ConsentHandler) RevokeConsentForm(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	formID, ok := uuidParam(c, "id", "consent form")
+	if !ok {
+		return
+	}
+
+	var req dto.RevokeConsentFormRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Logger.WithError(err).Warn("Invalid request body for RevokeConsentForm")
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+	}
+
+	form, err := h.consentUseCase.RevokeConsentForm(c.Request.Context(), orgID, formID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to revoke consent form")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"consent_form_id": formID,
+		"revoked_by":      userID,
+	}).Info("Consent form revoked")
+
+	respondSuccess(c, http.StatusOK, form)
+}
+
+// DownloadDocument handles GET /consent-forms/:id/document, the signed copy of a form
+func (h *ConsentHandler) DownloadDocument(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	formID, ok := uuidParam(c, "id", "consent form")
+	if !ok {
+		return
+	}
+
+	var req dto.AttachmentDownloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for DownloadConsentDocument")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	content, err := h.consentUseCase.OpenDocument(c.Request.Context(), orgID, formID)
+	if err != nil {
+		h.handleError(c, err, "Failed to download consent form")
+		return
+	}
+
+	c.Header("Content-Security-Policy", consentDocumentPolicy)
+	sendContent(c, content, req.Inline)
+}
+
+// GetAppointmentConsents handles GET /appointments/:id/consents
+func (h *ConsentHandler) GetAppointmentConsents(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	appointmentID, ok := uuidParam(c, "id", "appointment")
+	if !ok {
+		return
+	}
+
+	consents, err := h.consentUseCase.GetAppointmentConsents(c.Request.Context(), orgID, appointmentID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get appointment consents")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, consents)
+}
+
+// GetSigningForm handles GET /public/consent-forms/:token, the form a patient is asked to sign
+func (h *ConsentHandler) GetSigningForm(c *gin.Context) {
+	form, err := h.consentUseCase.GetSigningForm(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.handleError(c, err, "Failed to get consent form")
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	respondSuccess(c, http.StatusOK, form)
+}
+
+// SignConsentForm handles POST /public/consent-forms/:token/sign
+func (h *ConsentHandler) SignConsentForm(c *gin.Context) {
+	var req dto.SignConsentFormRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for SignConsentForm")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	form, err := h.consentUseCase.SignConsentForm(c.Request.Context(), c.Param("token"), &req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		h.handleError(c, err, "Failed to sign consent form")
+		return
+	}
+
+	h.logger.Logger.WithField("client_ip", c.ClientIP()).Info("Consent form signed")
+
+	c.Header("Cache-Control", "private, no-store")
+	respondSuccess(c, http.StatusOK, form)
+}
+
+// handleError maps consent errors to HTTP responses
+func (h *ConsentHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrConsentTemplateNameRequired, entities.ErrConsentTemplateBodyRequired, entities.ErrUnknownConsentMergeField,
+		entities.ErrConsentAppointmentMismatch, entities.ErrInvalidConsentFormStatus, entities.ErrConsentAgreementRequired,
+		entities.ErrInvalidSignatureType, entities.ErrSignerNameRequired, entities.ErrSignerNameTooLong,
+		entities.ErrSignatureRequired, entities.ErrInvalidDrawnSignature, entities.ErrSignatureTooLarge:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrConsentFormAlreadySigned, entities.ErrConsentFormRevoked:
+		respondError(c, http.StatusConflict, "CONSENT_FORM_NOT_PENDING", err.Error())
+	case entities.ErrConsentFormExpired:
+		respondError(c, http.StatusGone, "CONSENT_LINK_EXPIRED", err.Error())
+	case entities.ErrInvalidConsentToken:
+		respondError(c, http.StatusNotFound, "INVALID_CONSENT_LINK", err.Error())
+	case entities.ErrConsentFormNotSigned:
+		respondError(c, http.StatusNotFound, "DOCUMENT_NOT_FOUND", err.Error())
+	case entities.ErrConsentTemplateNotFound:
+		respondError(c, http.StatusNotFound, "CONSENT_TEMPLATE_NOT_FOUND", err.Error())
+	case entities.ErrConsentFormNotFound:
+		respondError(c, http.StatusNotFound, "CONSENT_FORM_NOT_FOUND", err.Error())
+	case entities.ErrBlobNotFound:
+		h.logger.Logger.WithError(err).Error("Signed consent form missing from storage")
+		respondError(c, http.StatusNotFound, "FILE_NOT_FOUND", err.Error())
+	case entities.ErrServiceNotFound:
+		respondError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", err.Error())
+	case entities.ErrDoctorNotFound:
+		respondError(c, http.StatusNotFound, "DOCTOR_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentNotFound:
+		respondError(c, http.StatusNotFound, "APPOINTMENT_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
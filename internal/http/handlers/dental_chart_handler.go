@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DentalChartHandler handles patient odontogram HTTP requests
+type DentalChartHandler struct {
+	chartUseCase *usecases.DentalChartUseCase
+	logger       *logger.Logger
+}
+
+// NewDentalChartHandler creates a new DentalChartHandler instance
+func NewDentalChartHandler(chartUseCase *usecases.DentalChartUseCase, logger *logger.Logger) *DentalChartHandler {
+	return &DentalChartHandler{
+		chartUseCase: chartUseCase,
+		logger:       logger,
+	}
+}
+
+// GetChart handles GET /patients/:id/chart
+func (h *DentalChartHandler) GetChart(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.DentalChartRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for GetChart")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	chart, err := h.chartUseCase.GetChart(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get dental chart")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, chart)
+}
+
+// ListEntries handles GET /patients/:id/chart/entries
+func (h *DentalChartHandler) ListEntries(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.DentalChartEntriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListEntries")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	entries, err := h.chartUseCase.ListEntries(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list dental chart entries")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, entries)
+}
+
+// RecordEntries handles POST /patients/:id/chart/entries
+func (h *DentalChartHandler) RecordEntries(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.RecordDentalChartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for RecordEntries")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	entries, err := h.chartUseCase.RecordEntries(c.Request.Context(), orgID, patientID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to record dental chart entries")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"patient_id":      patientID,
+		"entries":         len(entries.Entries),
+	}).Info("Dental chart entries recorded")
+
+	respondSuccess(c, http.StatusCreated, entries)
+}
+
+// handleError maps dental chart errors to HTTP responses
+func (h *DentalChartHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidToothNumber, entities.ErrUnsupportedToothNumbering, entities.ErrInvalidToothSurface,
+		entities.ErrInvalidToothCondition, entities.ErrToothConditionNotOnSurfaces, entities.ErrImplantOnPrimaryTooth,
+		entities.ErrDentalChartEntryInFuture, entities.ErrDentalChartEntriesRequired, entities.ErrInvalidDentalChartDate,
+		entities.ErrChartAppointmentMismatch:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentNotFound:
+		respondError(c, http.StatusNotFound, "APPOINTMENT_NOT_FOUND", err.Error())
+	case entities.ErrDoctorNotFound:
+		respondError(c, http.StatusNotFound, "DOCTOR_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// paymentSignatureHeader carries the payment gateway's signature of a webhook payload
+const paymentSignatureHeader = "X-Payment-Signature"
+
+// DepositHandler handles deposit policy, appointment deposit and payment webhook HTTP requests
+type DepositHandler struct {
+	depositUseCase *usecases.DepositUseCase
+	logger         *logger.Logger
+}
+
+// NewDepositHandler creates a new DepositHandler instance
+func NewDepositHandler(depositUseCase *usecases.DepositUseCase, logger *logger.Logger) *DepositHandler {
+	return &DepositHandler{
+		depositUseCase: depositUseCase,
+		logger:         logger,
+	}
+}
+
+// GetPolicy handles GET /admin/deposit-policy
+func (h *DepositHandler) GetPolicy(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.depositUseCase.GetPolicy(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get deposit policy")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, policy)
+}
+
+// UpdatePolicy handles PUT /admin/deposit-policy
+func (h *DepositHandler) UpdatePolicy(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.DepositPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdatePolicy")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	policy, err := h.depositUseCase.UpdatePolicy(c.Request.Context(), orgID, actingUserID(c), &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update deposit policy")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, policy)
+}
+
+// SetServiceDeposit handles PUT /admin/services/:id/deposit
+func (h *DepositHandler) SetServiceDeposit(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ServiceDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for SetServiceDeposit")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	service, err := h.depositUseCase.SetServiceDeposit(c.Request.Context(), orgID, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to set service deposit")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, service)
+}
+
+// ListDeposits handles GET /deposits
+func (h *DepositHandler) ListDeposits(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.DepositListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListDeposits")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if req.ClinicIDStr != "" {
+		clinicID, err := uuid.Parse(req.ClinicIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid clinic ID format")
+			return
+		}
+		req.ClinicID = &clinicID
+	}
+	if req.PatientIDStr != "" {
+		patientID, err := uuid.Parse(req.PatientIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid patient ID format")
+			return
+		}
+		req.PatientID = &patientID
+	}
+
+	deposits, err := h.depositUseCase.ListDeposits(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list deposits")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, deposits)
+}
+
+// GetDeposit handles GET /deposits/:id
+func (h *DepositHandler) GetDeposit(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	depositID, ok := uuidParam(c, "id", "deposit")
+	if !ok {
+		return
+	}
+
+	deposit, err := h.depositUseCase.GetDeposit(c.Request.Context(), orgID, depositID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get deposit")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, deposit)
+}
+
+// WaiveDeposit handles POST /deposits/:id/waive
+func (h *DepositHandler) WaiveDeposit(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	depositID, ok := uuidParam(c, "id", "deposit")
+	if !ok {
+		return
+	}
+
+	var req dto.WaiveDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for WaiveDeposit")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	deposit, err := h.depositUseCase.WaiveDeposit(c.Request.Context(), orgID, depositID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to waive deposit")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, deposit)
+}
+
+// GetAppointmentDeposit handles GET /appointments/:id/deposit
+func (h *DepositHandler) GetAppointmentDeposit(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	appointmentID, ok := uuidParam(c, "id", "appointment")
+	if !ok {
+		return
+	}
+
+	deposit, err := h.depositUseCase.GetAppointmentDeposit(c.Request.Context(), orgID, appointmentID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get appointment deposit")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, deposit)
+}
+
+// RequestDeposit handles POST /appointments/:appointment_id/deposit
+func (h *DepositHandler) RequestDeposit(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	appointmentID, ok := uuidParam(c, "appointment_id", "appointment")
+	if !ok {
+		return
+	}
+
+	var req dto.RequestDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for RequestDeposit")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	deposit, err := h.depositUseCase.RequestDeposit(c.Request.Context(), orgID, appointmentID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to request deposit")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, deposit)
+}
+
+// PaymentWebhook handles POST /public/payments/webhook, the payment gateway's notifications.
+// It is not authenticated: the payload is trusted only when its signature checks out.
+func (h *DepositHandler) PaymentWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Failed to read request body")
+		return
+	}
+
+	if err := h.depositUseCase.HandlePaymentNotification(c.Request.Context(), payload, c.GetHeader(paymentSignatureHeader)); err != nil {
+		h.handleError(c, err, "Failed to handle payment notification")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, gin.H{"received": true})
+}
+
+func (h *DepositHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidDepositType, entities.ErrInvalidDepositAmount, entities.ErrInvalidDepositHold,
+		entities.ErrInvalidDepositReliability, entities.ErrInvalidDepositStatus, entities.ErrInvalidDepositDueDate,
+		entities.ErrDepositWaiveReasonRequired:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case gateways.ErrPaymentGatewayNotConfigured:
+		respondError(c, http.StatusServiceUnavailable, "PAYMENT_GATEWAY_NOT_CONFIGURED", err.Error())
+	case entities.ErrInvalidPaymentNotification:
+		respondError(c, http.StatusUnauthorized, "INVALID_PAYMENT_NOTIFICATION", err.Error())
+	case entities.ErrPaymentNotificationMismatch:
+		h.logger.Logger.WithError(err).Warn(message)
+		respondError(c, http.StatusUnprocessableEntity, "PAYMENT_NOTIFICATION_MISMATCH", err.Error())
+	case entities.ErrDepositWaiveRequiresStaff:
+		respondError(c, http.StatusForbidden, "DEPOSIT_WAIVE_REQUIRES_STAFF", err.Error())
+	case entities.ErrDepositNotPending:
+		respondError(c, http.StatusConflict, "DEPOSIT_NOT_PENDING", err.Error())
+	case entities.ErrDepositAlreadyRequested:
+		respondError(c, http.StatusConflict, "DEPOSIT_ALREADY_REQUESTED", err.Error())
+	case entities.ErrDepositNotAllowed:
+		respondError(c, http.StatusConflict, "DEPOSIT_NOT_ALLOWED", err.Error())
+	case entities.ErrDepositNotFound:
+		respondError(c, http.StatusNotFound, "DEPOSIT_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentNotFound:
+		respondError(c, http.StatusNotFound, "APPOINTMENT_NOT_FOUND", err.Error())
+	case entities.ErrServiceNotFound:
+		respondError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", err.Error())
+	case entities.ErrOrganizationNotFound:
+		respondError(c, http.StatusNotFound, "ORGANIZATION_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
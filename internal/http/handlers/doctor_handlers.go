@@ -167,6 +167,11 @@ func (h *PatientHandler) CreatePatient(c *gin.Context) {
 			return
 		}
 
+		if err == entities.ErrInvalidPhoneNumber {
+			respondError(c, http.StatusBadRequest, "INVALID_PHONE", "Phone number is not valid for the organization's region")
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
@@ -177,6 +182,7 @@ func (h *PatientHandler) CreatePatient(c *gin.Context) {
 		return
 	}
 
+	setETag(c, response.Version)
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
 		"data":    response,
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondError writes the standard error envelope used by all handlers
+func respondError(c *gin.Context, status int, code, message string) {
+	c.JSON(status, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+// respondSuccess writes the standard success envelope used by all handlers
+func respondSuccess(c *gin.Context, status int, data interface{}) {
+	c.JSON(status, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// organizationIDFromContext extracts the organization ID set by the auth middleware,
+// writing an error response when it is missing or malformed
+func organizationIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	orgID, exists := middleware.GetOrganizationIDFromContext(c)
+	if !exists {
+		respondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Organization context required")
+		return uuid.Nil, false
+	}
+
+	orgUUID, err := uuid.Parse(orgID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "INVALID_CONTEXT", "Invalid organization context")
+		return uuid.Nil, false
+	}
+
+	return orgUUID, true
+}
+
+// userIDFromContext extracts the authenticated user ID set by the auth middleware,
+// writing an error response when it is missing or malformed
+func userIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		respondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "INVALID_CONTEXT", "Invalid user context")
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
+
+// actingUserID returns the authenticated user making the request, or nil when the request
+// is made with an API key or the user context is malformed
+func actingUserID(c *gin.Context) *uuid.UUID {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		return nil
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil
+	}
+	return &userUUID
+}
+
+// uuidParam parses a UUID path parameter, writing a 400 response when it is malformed
+func uuidParam(c *gin.Context, name, label string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid "+label+" ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// isStaffSession checks if the request was made by a staff member's session rather than an
+// integration API key, which must not see clinical data such as medical alerts
+func isStaffSession(c *gin.Context) bool {
+	return middleware.HasOrganizationRole(c, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist)
+}
+
+// setETag exposes a resource version as a strong ETag for optimistic concurrency
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// expectedVersion resolves the version an update was based on, preferring the
+// If-Match header over the version field of the body. It returns nil for
+// If-Match: * (unconditional update) and writes an error response when no
+// precondition was sent or the header is malformed.
+func expectedVersion(c *gin.Context, bodyVersion *int) (*int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		if bodyVersion == nil {
+			respondError(c, http.StatusPreconditionRequired, "PRECONDITION_REQUIRED", "If-Match header or version field is required")
+			return nil, false
+		}
+		return bodyVersion, true
+	}
+	if header == "*" {
+		return nil, true
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		respondError(c, http.StatusBadRequest, "INVALID_IF_MATCH", "If-Match must be the ETag returned for the resource")
+		return nil, false
+	}
+	return &version, true
+}
+
+// respondVersionConflict writes a 412 carrying the current representation and
+// its ETag so the client can merge its changes and retry
+func respondVersionConflict(c *gin.Context, version int, current interface{}, message string) {
+	setETag(c, version)
+	c.JSON(http.StatusPreconditionFailed, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    "VERSION_CONFLICT",
+			"message": message,
+		},
+		"data": current,
+	})
+}
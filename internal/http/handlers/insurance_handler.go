@@ -0,0 +1,522 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// InsuranceHandler handles insurance carrier, policy, estimate and claim HTTP requests
+type InsuranceHandler struct {
+	insuranceUseCase *usecases.InsuranceUseCase
+	logger           *logger.Logger
+}
+
+// NewInsuranceHandler creates a new InsuranceHandler instance
+func NewInsuranceHandler(insuranceUseCase *usecases.InsuranceUseCase, logger *logger.Logger) *InsuranceHandler {
+	return &InsuranceHandler{
+		insuranceUseCase: insuranceUseCase,
+		logger:           logger,
+	}
+}
+
+// ListCarriers handles GET /insurance-carriers
+func (h *InsuranceHandler) ListCarriers(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InsuranceCarrierListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListCarriers")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	carriers, err := h.insuranceUseCase.ListCarriers(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list insurance carriers")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, carriers)
+}
+
+// CreateCarrier handles POST /insurance-carriers
+func (h *InsuranceHandler) CreateCarrier(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InsuranceCarrierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateCarrier")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	carrier, err := h.insuranceUseCase.CreateCarrier(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create insurance carrier")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, carrier)
+}
+
+// UpdateCarrier handles PUT /insurance-carriers/:id
+func (h *InsuranceHandler) UpdateCarrier(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	carrierID, ok := uuidParam(c, "id", "carrier")
+	if !ok {
+		return
+	}
+
+	var req dto.InsuranceCarrierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateCarrier")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	carrier, err := h.insuranceUseCase.UpdateCarrier(c.Request.Context(), orgID, carrierID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update insurance carrier")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, carrier)
+}
+
+// ListPatientPolicies handles GET /patients/:id/insurance-policies
+func (h *InsuranceHandler) ListPatientPolicies(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	policies, err := h.insuranceUseCase.ListPatientPolicies(c.Request.Context(), orgID, patientID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list insurance policies")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, policies)
+}
+
+// CreatePolicy handles POST /patients/:id/insurance-policies
+func (h *InsuranceHandler) CreatePolicy(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.InsurancePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreatePolicy")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	policy, err := h.insuranceUseCase.CreatePolicy(c.Request.Context(), orgID, patientID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create insurance policy")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, policy)
+}
+
+// GetPolicy handles GET /insurance-policies/:id
+func (h *InsuranceHandler) GetPolicy(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	policyID, ok := uuidParam(c, "id", "policy")
+	if !ok {
+		return
+	}
+
+	policy, err := h.insuranceUseCase.GetPolicy(c.Request.Context(), orgID, policyID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get insurance policy")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, policy)
+}
+
+// UpdatePolicy handles PUT /insurance-policies/:id
+func (h *InsuranceHandler) UpdatePolicy(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	policyID, ok := uuidParam(c, "id", "policy")
+	if !ok {
+		return
+	}
+
+	var req dto.InsurancePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdatePolicy")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	policy, err := h.insuranceUseCase.UpdatePolicy(c.Request.Context(), orgID, policyID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update insurance policy")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, policy)
+}
+
+// DeletePolicy handles DELETE /insurance-policies/:id
+func (h *InsuranceHandler) DeletePolicy(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	policyID, ok := uuidParam(c, "id", "policy")
+	if !ok {
+		return
+	}
+
+	if err := h.insuranceUseCase.DeletePolicy(c.Request.Context(), orgID, policyID); err != nil {
+		h.handleError(c, err, "Failed to delete insurance policy")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// EstimateCoverage handles POST /insurance-policies/:id/estimate
+func (h *InsuranceHandler) EstimateCoverage(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	policyID, ok := uuidParam(c, "id", "policy")
+	if !ok {
+		return
+	}
+
+	var req dto.CoverageEstimateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for EstimateCoverage")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	estimate, err := h.insuranceUseCase.EstimateCoverage(c.Request.Context(), orgID, policyID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to estimate insurance coverage")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, estimate)
+}
+
+// CreateClaim handles POST /insurance-claims
+func (h *InsuranceHandler) CreateClaim(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateInsuranceClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateClaim")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	claim, err := h.insuranceUseCase.CreateClaim(c.Request.Context(), orgID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create insurance claim")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, claim)
+}
+
+// ListClaims handles GET /insurance-claims
+func (h *InsuranceHandler) ListClaims(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InsuranceClaimListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListClaims")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if req.PatientIDStr != "" {
+		patientID, err := uuid.Parse(req.PatientIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid patient ID format")
+			return
+		}
+		req.PatientID = &patientID
+	}
+	if req.PolicyIDStr != "" {
+		policyID, err := uuid.Parse(req.PolicyIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid policy ID format")
+			return
+		}
+		req.PolicyID = &policyID
+	}
+	if req.AppointmentIDStr != "" {
+		appointmentID, err := uuid.Parse(req.AppointmentIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid appointment ID format")
+			return
+		}
+		req.AppointmentID = &appointmentID
+	}
+
+	claims, err := h.insuranceUseCase.ListClaims(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list insurance claims")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, claims)
+}
+
+// GetClaim handles GET /insurance-claims/:id
+func (h *InsuranceHandler) GetClaim(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	claimID, ok := uuidParam(c, "id", "claim")
+	if !ok {
+		return
+	}
+
+	claim, err := h.insuranceUseCase.GetClaim(c.Request.Context(), orgID, claimID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get insurance claim")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, claim)
+}
+
+// ApproveClaim handles POST /insurance-claims/:id/approve
+func (h *InsuranceHandler) ApproveClaim(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	claimID, ok := uuidParam(c, "id", "claim")
+	if !ok {
+		return
+	}
+
+	var req dto.ApproveInsuranceClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for ApproveClaim")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	claim, err := h.insuranceUseCase.ApproveClaim(c.Request.Context(), orgID, claimID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to approve insurance claim")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, claim)
+}
+
+// RejectClaim handles POST /insurance-claims/:id/reject
+func (h *InsuranceHandler) RejectClaim(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	claimID, ok := uuidParam(c, "id", "claim")
+	if !ok {
+		return
+	}
+
+	var req dto.RejectInsuranceClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for RejectClaim")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	claim, err := h.insuranceUseCase.RejectClaim(c.Request.Context(), orgID, claimID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to reject insurance claim")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, claim)
+}
+
+// PayClaim handles POST /insurance-claims/:id/pay
+func (h *InsuranceHandler) PayClaim(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	claimID, ok := uuidParam(c, "id", "claim")
+	if !ok {
+		return
+	}
+
+	var req dto.PayInsuranceClaimRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Logger.WithError(err).Warn("Invalid request body for PayClaim")
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+	}
+
+	claim, err := h.insuranceUseCase.PayClaim(c.Request.Context(), orgID, claimID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to record insurance claim payment")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, claim)
+}
+
+// ResubmitClaim handles POST /insurance-claims/:id/resubmit
+func (h *InsuranceHandler) ResubmitClaim(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	claimID, ok := uuidParam(c, "id", "claim")
+	if !ok {
+		return
+	}
+
+	var req dto.ResubmitInsuranceClaimRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Logger.WithError(err).Warn("Invalid request body for ResubmitClaim")
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+	}
+
+	claim, err := h.insuranceUseCase.ResubmitClaim(c.Request.Context(), orgID, claimID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to resubmit insurance claim")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, claim)
+}
+
+// SetServiceCategory handles PUT /admin/services/:id/category
+func (h *InsuranceHandler) SetServiceCategory(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	serviceID := strings.TrimSpace(c.Param("id"))
+	if serviceID == "" {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "Service ID is required")
+		return
+	}
+
+	var req dto.UpdateServiceCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for SetServiceCategory")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	service, err := h.insuranceUseCase.SetServiceCategory(c.Request.Context(), orgID, serviceID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update service category")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, service)
+}
+
+// handleError maps insurance errors to HTTP responses
+func (h *InsuranceHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInsuranceCarrierNameRequired, entities.ErrInsuranceMemberIDRequired, entities.ErrInvalidServiceCategory,
+		entities.ErrInvalidCoveragePercent, entities.ErrInvalidInsuranceAmount, entities.ErrInvalidPolicyPeriod,
+		entities.ErrInvalidInsuranceDate, entities.ErrInvalidInsuranceClaimStatus, entities.ErrClaimRejectionReasonRequired,
+		entities.ErrClaimAmountRequired, entities.ErrEstimateItemsRequired, entities.ErrInvoiceLinePriceRequired,
+		entities.ErrClaimPatientMismatch:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrInsuranceCarrierInactive:
+		respondError(c, http.StatusConflict, "CARRIER_INACTIVE", err.Error())
+	case entities.ErrPolicyNotInEffect:
+		respondError(c, http.StatusConflict, "POLICY_NOT_IN_EFFECT", err.Error())
+	case entities.ErrPolicyHasClaims:
+		respondError(c, http.StatusConflict, "POLICY_HAS_CLAIMS", err.Error())
+	case entities.ErrAppointmentNotClaimable:
+		respondError(c, http.StatusConflict, "APPOINTMENT_NOT_COMPLETED", err.Error())
+	case entities.ErrAppointmentAlreadyClaimed:
+		respondError(c, http.StatusConflict, "APPOINTMENT_ALREADY_CLAIMED", err.Error())
+	case entities.ErrInvalidClaimTransition:
+		respondError(c, http.StatusConflict, "INVALID_CLAIM_TRANSITION", err.Error())
+	case entities.ErrInsuranceCarrierNotFound:
+		respondError(c, http.StatusNotFound, "CARRIER_NOT_FOUND", err.Error())
+	case entities.ErrInsurancePolicyNotFound:
+		respondError(c, http.StatusNotFound, "POLICY_NOT_FOUND", err.Error())
+	case entities.ErrInsuranceClaimNotFound:
+		respondError(c, http.StatusNotFound, "CLAIM_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentNotFound:
+		respondError(c, http.StatusNotFound, "APPOINTMENT_NOT_FOUND", err.Error())
+	case entities.ErrTreatmentPlanNotFound:
+		respondError(c, http.StatusNotFound, "TREATMENT_PLAN_NOT_FOUND", err.Error())
+	case entities.ErrServiceNotFound:
+		respondError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	case entities.ErrOrganizationNotFound:
+		respondError(c, http.StatusNotFound, "ORGANIZATION_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
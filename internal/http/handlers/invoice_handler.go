@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// InvoiceHandler handles invoicing and payments HTTP requests
+type InvoiceHandler struct {
+	invoiceUseCase *usecases.InvoiceUseCase
+	logger         *logger.Logger
+}
+
+// NewInvoiceHandler creates a new InvoiceHandler instance
+func NewInvoiceHandler(invoiceUseCase *usecases.InvoiceUseCase, logger *logger.Logger) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceUseCase: invoiceUseCase,
+		logger:         logger,
+	}
+}
+
+// CreateInvoice handles POST /invoices
+func (h *InvoiceHandler) CreateInvoice(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateInvoice")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.CreateInvoice(c.Request.Context(), orgID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create invoice")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"invoice_id":      invoice.ID,
+		"appointment_id":  req.AppointmentID,
+		"total":           invoice.Total,
+	}).Info("Invoice created")
+
+	respondSuccess(c, http.StatusCreated, invoice)
+}
+
+// ListInvoices handles GET /invoices
+func (h *InvoiceHandler) ListInvoices(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InvoiceListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListInvoices")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if req.PatientIDStr != "" {
+		patientID, err := uuid.Parse(req.PatientIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid patient ID format")
+			return
+		}
+		req.PatientID = &patientID
+	}
+	if req.ClinicIDStr != "" {
+		clinicID, err := uuid.Parse(req.ClinicIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid clinic ID format")
+			return
+		}
+		req.ClinicID = &clinicID
+	}
+
+	invoices, err := h.invoiceUseCase.ListInvoices(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list invoices")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, invoices)
+}
+
+// GetInvoice handles GET /invoices/:id
+func (h *InvoiceHandler) GetInvoice(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	invoiceID, ok := uuidParam(c, "id", "invoice")
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.GetInvoice(c.Request.Context(), orgID, invoiceID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get invoice")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, invoice)
+}
+
+// RecordPayment handles POST /invoices/:id/payments
+func (h *InvoiceHandler) RecordPayment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	invoiceID, ok := uuidParam(c, "id", "invoice")
+	if !ok {
+		return
+	}
+
+	var req dto.RecordPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for RecordPayment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.RecordPayment(c.Request.Context(), orgID, invoiceID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to record payment")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"invoice_id":      invoiceID,
+		"method":          req.Method,
+		"amount":          req.Amount,
+	}).Info("Payment recorded")
+
+	respondSuccess(c, http.StatusCreated, invoice)
+}
+
+// RefundPayment handles POST /invoices/:id/payments/:payment_id/refund
+func (h *InvoiceHandler) RefundPayment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	invoiceID, ok := uuidParam(c, "id", "invoice")
+	if !ok {
+		return
+	}
+	paymentID, ok := uuidParam(c, "payment_id", "payment")
+	if !ok {
+		return
+	}
+
+	var req dto.RefundPaymentRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Logger.WithError(err).Warn("Invalid request body for RefundPayment")
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+	}
+
+	invoice, err := h.invoiceUseCase.RefundPayment(c.Request.Context(), orgID, invoiceID, paymentID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to refund payment")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"invoice_id":      invoiceID,
+		"payment_id":      paymentID,
+	}).Info("Payment refunded")
+
+	respondSuccess(c, http.StatusCreated, invoice)
+}
+
+// VoidInvoice handles POST /invoices/:id/void
+func (h *InvoiceHandler) VoidInvoice(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	invoiceID, ok := uuidParam(c, "id", "invoice")
+	if !ok {
+		return
+	}
+
+	var req dto.VoidInvoiceRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Logger.WithError(err).Warn("Invalid request body for VoidInvoice")
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+	}
+
+	invoice, err := h.invoiceUseCase.VoidInvoice(c.Request.Context(), orgID, invoiceID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to void invoice")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"invoice_id":      invoiceID,
+	}).Info("Invoice voided")
+
+	respondSuccess(c, http.StatusOK, invoice)
+}
+
+// GetPatientBalance handles GET /patients/:id/balance
+func (h *InvoiceHandler) GetPatientBalance(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	balance, err := h.invoiceUseCase.GetPatientBalance(c.Request.Context(), orgID, patientID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get patient balance")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, balance)
+}
+
+// GetCashClose handles GET /clinics/:id/cash-close
+func (h *InvoiceHandler) GetCashClose(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	clinicID, ok := uuidParam(c, "id", "clinic")
+	if !ok {
+		return
+	}
+
+	var req dto.CashCloseRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for GetCashClose")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	report, err := h.invoiceUseCase.GetCashClose(c.Request.Context(), orgID, clinicID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get cash close")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, report)
+}
+
+// handleError maps invoicing errors to HTTP responses
+func (h *InvoiceHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvoiceLinesRequired, entities.ErrInvoiceLineDescriptionRequired, entities.ErrInvalidInvoiceQuantity,
+		entities.ErrInvalidInvoiceAmount, entities.ErrInvalidInvoiceDiscount, entities.ErrInvalidTaxRate,
+		entities.ErrInvoiceLinePriceRequired, entities.ErrInvoiceAppointmentNoService, entities.ErrInvalidPaymentMethod,
+		entities.ErrInvalidPaymentAmount, entities.ErrInvalidInvoiceStatus, entities.ErrInvalidBillingDate:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrAppointmentNotCompleted:
+		respondError(c, http.StatusConflict, "APPOINTMENT_NOT_COMPLETED", err.Error())
+	case entities.ErrAppointmentAlreadyInvoiced:
+		respondError(c, http.StatusConflict, "APPOINTMENT_ALREADY_INVOICED", err.Error())
+	case entities.ErrPaymentExceedsBalance:
+		respondError(c, http.StatusConflict, "PAYMENT_EXCEEDS_BALANCE", err.Error())
+	case entities.ErrRefundExceedsPayment:
+		respondError(c, http.StatusConflict, "REFUND_EXCEEDS_PAYMENT", err.Error())
+	case entities.ErrInvoiceVoided:
+		respondError(c, http.StatusConflict, "INVOICE_VOID", err.Error())
+	case entities.ErrInvoiceHasPayments:
+		respondError(c, http.StatusConflict, "INVOICE_HAS_PAYMENTS", err.Error())
+	case entities.ErrInvoiceHasActiveCFDI:
+		respondError(c, http.StatusConflict, "INVOICE_HAS_CFDI", err.Error())
+	case entities.ErrInvoiceNotFound:
+		respondError(c, http.StatusNotFound, "INVOICE_NOT_FOUND", err.Error())
+	case entities.ErrPaymentNotFound:
+		respondError(c, http.StatusNotFound, "PAYMENT_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentNotFound:
+		respondError(c, http.StatusNotFound, "APPOINTMENT_NOT_FOUND", err.Error())
+	case entities.ErrServiceNotFound:
+		respondError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	case entities.ErrClinicNotFound:
+		respondError(c, http.StatusNotFound, "CLINIC_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
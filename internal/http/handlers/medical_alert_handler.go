@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MedicalAlertHandler handles patient medical alert HTTP requests
+type MedicalAlertHandler struct {
+	medicalAlertUseCase *usecases.MedicalAlertUseCase
+	logger              *logger.Logger
+}
+
+// NewMedicalAlertHandler creates a new MedicalAlertHandler instance
+func NewMedicalAlertHandler(medicalAlertUseCase *usecases.MedicalAlertUseCase, logger *logger.Logger) *MedicalAlertHandler {
+	return &MedicalAlertHandler{
+		medicalAlertUseCase: medicalAlertUseCase,
+		logger:              logger,
+	}
+}
+
+// ListPatientAlerts handles GET /patients/:id/medical-alerts
+func (h *MedicalAlertHandler) ListPatientAlerts(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.MedicalAlertListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListPatientAlerts")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	alerts, err := h.medicalAlertUseCase.ListPatientAlerts(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list medical alerts")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, alerts)
+}
+
+// CreateAlert handles POST /patients/:id/medical-alerts
+func (h *MedicalAlertHandler) CreateAlert(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateMedicalAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateAlert")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	alert, err := h.medicalAlertUseCase.CreateAlert(c.Request.Context(), orgID, patientID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create medical alert")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"patient_id":      patientID,
+		"alert_id":        alert.ID,
+		"severity":        alert.Severity,
+	}).Info("Medical alert created")
+
+	respondSuccess(c, http.StatusCreated, alert)
+}
+
+// GetAlert handles GET /medical-alerts/:id
+func (h *MedicalAlertHandler) GetAlert(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	alertID, ok := uuidParam(c, "id", "medical alert")
+	if !ok {
+		return
+	}
+
+	alert, err := h.medicalAlertUseCase.GetAlert(c.Request.Context(), orgID, alertID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get medical alert")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, alert)
+}
+
+// UpdateAlert handles PATCH /medical-alerts/:id
+func (h *MedicalAlertHandler) UpdateAlert(c *gin.Context) {
+	orgID, userID, alertID, ok := h.alertContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMedicalAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateAlert")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	alert, err := h.medicalAlertUseCase.UpdateAlert(c.Request.Context(), orgID, alertID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update medical alert")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, alert)
+}
+
+// ReviewAlert handles POST /medical-alerts/:id/review
+func (h *MedicalAlertHandler) ReviewAlert(c *gin.Context) {
+	orgID, userID, alertID, ok := h.alertContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewMedicalAlertRequest
+	if !h.bindOptionalJSON(c, &req, "ReviewAlert") {
+		return
+	}
+
+	alert, err := h.medicalAlertUseCase.ReviewAlert(c.Request.Context(), orgID, alertID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to review medical alert")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, alert)
+}
+
+// ResolveAlert handles POST /medical-alerts/:id/resolve
+func (h *MedicalAlertHandler) ResolveAlert(c *gin.Context) {
+	orgID, userID, alertID, ok := h.alertContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MedicalAlertStatusRequest
+	if !h.bindOptionalJSON(c, &req, "ResolveAlert") {
+		return
+	}
+
+	alert, err := h.medicalAlertUseCase.ResolveAlert(c.Request.Context(), orgID, alertID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to resolve medical alert")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, alert)
+}
+
+// ReactivateAlert handles POST /medical-alerts/:id/reactivate
+func (h *MedicalAlertHandler) ReactivateAlert(c *gin.Context) {
+	orgID, userID, alertID, ok := h.alertContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MedicalAlertStatusRequest
+	if !h.bindOptionalJSON(c, &req, "ReactivateAlert") {
+		return
+	}
+
+	alert, err := h.medicalAlertUseCase.ReactivateAlert(c.Request.Context(), orgID, alertID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to reactivate medical alert")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, alert)
+}
+
+// GetAlertHistory handles GET /medical-alerts/:id/history
+func (h *MedicalAlertHandler) GetAlertHistory(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	alertID, ok := uuidParam(c, "id", "medical alert")
+	if !ok {
+		return
+	}
+
+	history, err := h.medicalAlertUseCase.GetAlertHistory(c.Request.Context(), orgID, alertID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get medical alert history")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, history)
+}
+
+// alertContext extracts the organization, user and alert ID of a change to an alert
+func (h *MedicalAlertHandler) alertContext(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	alertID, ok := uuidParam(c, "id", "medical alert")
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	return orgID, userID, alertID, true
+}
+
+// bindOptionalJSON binds a request body that may be omitted
+func (h *MedicalAlertHandler) bindOptionalJSON(c *gin.Context, req interface{}, operation string) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for " + operation)
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return false
+	}
+	return true
+}
+
+// handleError maps medical alert errors to HTTP responses
+func (h *MedicalAlertHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidMedicalAlertCategory, entities.ErrInvalidMedicalAlertSeverity, entities.ErrMedicalAlertTitleRequired,
+		entities.ErrMedicalAlertTitleTooLong, entities.ErrInvalidMedicalAlertDate, entities.ErrMedicalAlertNoChanges:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrMedicalAlertResolved, entities.ErrMedicalAlertActive:
+		respondError(c, http.StatusConflict, "INVALID_ALERT_STATUS", err.Error())
+	case entities.ErrMedicalAlertNotFound:
+		respondError(c, http.StatusNotFound, "MEDICAL_ALERT_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
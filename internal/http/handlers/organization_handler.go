@@ -80,7 +80,7 @@ func (h *OrganizationHandler) GetOrganizationData(c *gin.Context) {
 	}).Info("Getting organization data")
 
 	// Execute use case
-	result, err := h.getOrgDataUseCase.Execute(c.Request.Context(), orgID, &req)
+	result, err := h.getOrgDataUseCase.Execute(c.Request.Context(), orgID, &req, isStaffSession(c))
 	if err != nil {
 		h.logger.Logger.WithError(err).Error("Failed to get organization data")
 
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// calendarOrganizationRepo returns one appointment of a patient for any calendar range
+type calendarOrganizationRepo struct {
+	repositories.OrganizationRepository
+	patientID uuid.UUID
+}
+
+func (r *calendarOrganizationRepo) GetOrganizationData(ctx context.Context, orgID uuid.UUID, startDate, endDate time.Time, limit int) (*repositories.OrganizationData, error) {
+	return &repositories.OrganizationData{
+		Organization: &entities.Organization{ID: orgID, Name: "Sonrisas"},
+		Appointments: []*repositories.AppointmentCalendarData{{
+			ID:        uuid.New(),
+			PatientID: &r.patientID,
+			StartTime: startDate.Add(9 * time.Hour),
+			EndTime:   startDate.Add(10 * time.Hour),
+			Status:    string(entities.AppointmentStatusScheduled),
+		}},
+	}, nil
+}
+
+// patientAlertRepo returns a high severity allergy for the patient and counts the lookups
+type patientAlertRepo struct {
+	repositories.MedicalAlertRepository
+	alert   *entities.MedicalAlert
+	lookups int
+}
+
+func (r *patientAlertRepo) ListActiveByPatients(ctx context.Context, orgID uuid.UUID, patientIDs []uuid.UUID) (map[uuid.UUID][]*entities.MedicalAlert, error) {
+	r.lookups++
+	return map[uuid.UUID][]*entities.MedicalAlert{r.alert.PatientID: {r.alert}}, nil
+}
+
+func TestGetOrganizationDataMedicalAlerts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		authorize  func(c *gin.Context)
+		wantAlerts bool
+	}{
+		{
+			name: "staff session",
+			authorize: func(c *gin.Context) {
+				c.Set("user_profile", &entities.UserProfile{Profile: &entities.Profile{ID: uuid.New(), Roles: []string{string(entities.RoleReceptionist)}}})
+			},
+			wantAlerts: true,
+		},
+		{
+			name: "API key",
+			authorize: func(c *gin.Context) {
+				c.Set("api_key", &entities.APIKey{ID: uuid.New(), Scopes: []string{string(entities.APIKeyScopeOrganization)}})
+			},
+			wantAlerts: false,
+		},
+		{
+			name: "API key with a staff profile",
+			authorize: func(c *gin.Context) {
+				c.Set("api_key", &entities.APIKey{ID: uuid.New(), Scopes: []string{string(entities.APIKeyScopeOrganization)}})
+				c.Set("user_profile", &entities.UserProfile{Profile: &entities.Profile{ID: uuid.New(), Roles: []string{string(entities.RoleAdmin)}}})
+			},
+			wantAlerts: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgID, patientID := uuid.New(), uuid.New()
+			alert, err := entities.NewMedicalAlert(orgID, patientID, entities.MedicalAlertCategoryAllergy, "Penicillin", entities.MedicalAlertSeverityHigh, nil)
+			if err != nil {
+				t.Fatalf("NewMedicalAlert() error = %v", err)
+			}
+			alertRepo := &patientAlertRepo{alert: alert}
+			useCase := usecases.NewGetOrganizationDataUseCase(&calendarOrganizationRepo{patientID: patientID}, alertRepo)
+			handler := NewOrganizationHandler(useCase, logger.NewLogger("error"))
+
+			router := gin.New()
+			router.GET("/organization", func(c *gin.Context) {
+				c.Set("organization_id", orgID.String())
+				tt.authorize(c)
+				c.Next()
+			}, handler.GetOrganizationData)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/organization?start_date=2025-06-02&end_date=2025-06-02", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+			}
+
+			var body struct {
+				Data struct {
+					Appointments []struct {
+						MedicalAlerts []json.RawMessage `json:"medical_alerts"`
+					} `json:"appointments"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(body.Data.Appointments) != 1 {
+				t.Fatalf("got %d appointments, want 1", len(body.Data.Appointments))
+			}
+
+			gotAlerts := len(body.Data.Appointments[0].MedicalAlerts) > 0
+			if gotAlerts != tt.wantAlerts {
+				t.Errorf("medical alerts returned = %v, want %v", gotAlerts, tt.wantAlerts)
+			}
+			if !tt.wantAlerts && alertRepo.lookups > 0 {
+				t.Errorf("medical alerts were looked up %d times for a request that must not see them", alertRepo.lookups)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationSettingsHandler handles organization settings HTTP requests
+type OrganizationSettingsHandler struct {
+	settingsUseCase *usecases.OrganizationSettingsUseCase
+	logger          *logger.Logger
+}
+
+// NewOrganizationSettingsHandler creates a new OrganizationSettingsHandler instance
+func NewOrganizationSettingsHandler(settingsUseCase *usecases.OrganizationSettingsUseCase, logger *logger.Logger) *OrganizationSettingsHandler {
+	return &OrganizationSettingsHandler{
+		settingsUseCase: settingsUseCase,
+		logger:          logger,
+	}
+}
+
+// GetSettings handles GET /admin/settings
+func (h *OrganizationSettingsHandler) GetSettings(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.settingsUseCase.GetSettings(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get organization settings")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, settings)
+}
+
+// UpdateSettings handles PATCH /admin/settings
+func (h *OrganizationSettingsHandler) UpdateSettings(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateOrganizationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateSettings")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	settings, err := h.settingsUseCase.UpdateSettings(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update organization settings")
+		return
+	}
+
+	h.logger.Logger.WithField("organization_id", orgID).Info("Organization settings updated")
+	respondSuccess(c, http.StatusOK, settings)
+}
+
+// handleError maps organization settings errors to HTTP responses
+func (h *OrganizationSettingsHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrUnsupportedPhoneRegion:
+		respondError(c, http.StatusBadRequest, "UNSUPPORTED_PHONE_REGION", err.Error())
+	case entities.ErrUnsupportedCurrency:
+		respondError(c, http.StatusBadRequest, "UNSUPPORTED_CURRENCY", err.Error())
+	case entities.ErrOrganizationNotFound:
+		respondError(c, http.StatusNotFound, "ORGANIZATION_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
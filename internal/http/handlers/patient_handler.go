@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/domain/entities"
 	"dental-scheduler-backend/internal/http/middleware"
 	"net/http"
 
@@ -72,6 +73,16 @@ func (h *PatientHandler) SearchPatients(c *gin.Context) {
 
 	// Call use case
 	result, err := h.patientUseCase.SearchPatients(c.Request.Context(), orgUUID, &req)
+	if err == entities.ErrInvalidPatientSearchCursor {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INVALID_CURSOR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Logger.WithError(err).Error("Failed to search patients")
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -148,6 +159,12 @@ func (h *PatientHandler) UpdatePatient(c *gin.Context) {
 		return
 	}
 
+	version, ok := expectedVersion(c, req.Version)
+	if !ok {
+		return
+	}
+	req.Version = version
+
 	h.logger.Logger.WithFields(map[string]interface{}{
 		"patient_id":      patientID,
 		"organization_id": orgUUID,
@@ -168,6 +185,15 @@ func (h *PatientHandler) UpdatePatient(c *gin.Context) {
 					"message": "Patient not found",
 				},
 			})
+		case entities.ErrPatientVersionConflict.Error():
+			current, getErr := h.patientUseCase.GetPatientByID(c.Request.Context(), patientID)
+			if getErr != nil || current == nil {
+				respondError(c, http.StatusPreconditionFailed, "VERSION_CONFLICT", "Patient was modified by another request")
+				return
+			}
+			respondVersionConflict(c, current.Version, current, "Patient was modified by another request")
+		case entities.ErrInvalidPhoneNumber.Error():
+			respondError(c, http.StatusBadRequest, "INVALID_PHONE", "Phone number is not valid for the organization's region")
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"success": false,
@@ -180,8 +206,117 @@ func (h *PatientHandler) UpdatePatient(c *gin.Context) {
 		return
 	}
 
+	setETag(c, result.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    result,
 	})
 }
+
+// ListPatients handles GET /patients
+func (h *PatientHandler) ListPatients(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PatientListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListPatients")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	result, err := h.patientUseCase.ListPatients(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list patients")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, result)
+}
+
+// GetPatient handles GET /patients/:id, returning the patient with its appointment history and stats
+func (h *PatientHandler) GetPatient(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	result, err := h.patientUseCase.GetPatientDetail(c.Request.Context(), orgID, patientID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get patient")
+		return
+	}
+
+	setETag(c, result.Patient.Version)
+	respondSuccess(c, http.StatusOK, result)
+}
+
+// DeletePatient handles DELETE /patients/:id. Patients with appointments are archived instead of deleted.
+func (h *PatientHandler) DeletePatient(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	result, err := h.patientUseCase.RemovePatient(c.Request.Context(), orgID, patientID, &userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to delete patient")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"patient_id":      patientID,
+		"archived":        result.Archived,
+	}).Info("Patient removed from organization")
+
+	respondSuccess(c, http.StatusOK, result)
+}
+
+// RestorePatient handles POST /patients/:id/restore
+func (h *PatientHandler) RestorePatient(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	result, err := h.patientUseCase.RestorePatient(c.Request.Context(), orgID, patientID)
+	if err != nil {
+		h.handleError(c, err, "Failed to restore patient")
+		return
+	}
+
+	setETag(c, result.Version)
+	respondSuccess(c, http.StatusOK, result)
+}
+
+// handleError maps patient listing and archiving errors to HTTP responses
+func (h *PatientHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidPatientListDate, entities.ErrInvalidPatientListSort, entities.ErrInvalidPatientListStatus:
+		respondError(c, http.StatusBadRequest, "INVALID_FILTER", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", "Patient not found")
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PatientMergeHandler handles duplicate detection and patient merge HTTP requests
+type PatientMergeHandler struct {
+	mergeUseCase *usecases.PatientMergeUseCase
+	logger       *logger.Logger
+}
+
+// NewPatientMergeHandler creates a new PatientMergeHandler instance
+func NewPatientMergeHandler(mergeUseCase *usecases.PatientMergeUseCase, logger *logger.Logger) *PatientMergeHandler {
+	return &PatientMergeHandler{
+		mergeUseCase: mergeUseCase,
+		logger:       logger,
+	}
+}
+
+// ListDuplicates handles GET /patients/duplicates
+func (h *PatientMergeHandler) ListDuplicates(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.DuplicateCandidatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListDuplicates")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	candidates, err := h.mergeUseCase.ListDuplicates(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list duplicate patients")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, candidates)
+}
+
+// GetPatientDuplicates handles GET /patients/:id/duplicates
+func (h *PatientMergeHandler) GetPatientDuplicates(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.DuplicateCandidatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for GetPatientDuplicates")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	candidates, err := h.mergeUseCase.GetPatientDuplicates(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to find duplicate patients")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, candidates)
+}
+
+// MergePatients handles POST /patients/:id/merge, folding merged_patient_id into the patient in the path
+func (h *PatientMergeHandler) MergePatients(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	survivorID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.MergePatientsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for MergePatients")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	merge, err := h.mergeUseCase.MergePatients(c.Request.Context(), orgID, survivorID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to merge patients")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id":     orgID,
+		"merge_id":            merge.ID,
+		"survivor_patient_id": merge.SurvivorPatientID,
+		"merged_patient_id":   merge.MergedPatientID,
+		"appointments_moved":  merge.AppointmentsMoved,
+	}).Info("Patients merged")
+
+	setETag(c, merge.Survivor.Version)
+	respondSuccess(c, http.StatusOK, merge)
+}
+
+// ListMerges handles GET /patients/merges
+func (h *PatientMergeHandler) ListMerges(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PatientMergeListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListMerges")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	merges, err := h.mergeUseCase.ListMerges(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list patient merges")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, merges)
+}
+
+// GetMerge handles GET /patients/merges/:merge_id
+func (h *PatientMergeHandler) GetMerge(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	mergeID, ok := uuidParam(c, "merge_id", "patient merge")
+	if !ok {
+		return
+	}
+
+	merge, err := h.mergeUseCase.GetMerge(c.Request.Context(), orgID, mergeID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get patient merge")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, merge)
+}
+
+// handleError maps duplicate and merge errors to HTTP responses
+func (h *PatientMergeHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrCannotMergePatientIntoItself:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	case entities.ErrPatientMergeNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_MERGE_NOT_FOUND", err.Error())
+	case entities.ErrMergedPatientShared:
+		respondError(c, http.StatusConflict, "PATIENT_SHARED", err.Error())
+	case entities.ErrPatientVersionConflict:
+		respondError(c, http.StatusConflict, "VERSION_CONFLICT", "Patient was modified during the merge, please retry")
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
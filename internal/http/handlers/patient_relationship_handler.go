@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PatientRelationshipHandler handles patient family relationship HTTP requests
+type PatientRelationshipHandler struct {
+	relationshipUseCase *usecases.PatientRelationshipUseCase
+	logger              *logger.Logger
+}
+
+// NewPatientRelationshipHandler creates a new PatientRelationshipHandler instance
+func NewPatientRelationshipHandler(relationshipUseCase *usecases.PatientRelationshipUseCase, logger *logger.Logger) *PatientRelationshipHandler {
+	return &PatientRelationshipHandler{
+		relationshipUseCase: relationshipUseCase,
+		logger:              logger,
+	}
+}
+
+// ListRelationships handles GET /patients/:id/relationships
+func (h *PatientRelationshipHandler) ListRelationships(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	relationships, err := h.relationshipUseCase.ListRelationships(c.Request.Context(), orgID, patientID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list patient relationships")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, relationships)
+}
+
+// CreateRelationship handles POST /patients/:id/relationships
+func (h *PatientRelationshipHandler) CreateRelationship(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.CreatePatientRelationshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateRelationship")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	relationships, err := h.relationshipUseCase.CreateRelationship(c.Request.Context(), orgID, patientID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create patient relationship")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id":    orgID,
+		"patient_id":         patientID,
+		"related_patient_id": req.RelatedPatientID,
+		"type":               req.Type,
+	}).Info("Patient relationship created")
+
+	respondSuccess(c, http.StatusCreated, relationships)
+}
+
+// DeleteRelationship handles DELETE /patients/:id/relationships/:related_id
+func (h *PatientRelationshipHandler) DeleteRelationship(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+	relatedPatientID, ok := uuidParam(c, "related_id", "related patient")
+	if !ok {
+		return
+	}
+
+	if err := h.relationshipUseCase.DeleteRelationship(c.Request.Context(), orgID, patientID, relatedPatientID); err != nil {
+		h.handleError(c, err, "Failed to delete patient relationship")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetContactPerson handles PUT /patients/:id/contact-person
+func (h *PatientRelationshipHandler) SetContactPerson(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.SetContactPersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for SetContactPerson")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	relationships, err := h.relationshipUseCase.SetContactPerson(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to set patient contact person")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, relationships)
+}
+
+// GetFamily handles GET /patients/:id/family
+func (h *PatientRelationshipHandler) GetFamily(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	family, err := h.relationshipUseCase.GetFamily(c.Request.Context(), orgID, patientID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get patient family")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, family)
+}
+
+// handleError maps patient relationship errors to HTTP responses
+func (h *PatientRelationshipHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidPatientRelationshipType, entities.ErrPatientRelatedToItself, entities.ErrContactPersonNotGuardian:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrPatientRelationshipExists:
+		respondError(c, http.StatusConflict, "RELATIONSHIP_EXISTS", err.Error())
+	case entities.ErrPatientRelationshipNotFound:
+		respondError(c, http.StatusNotFound, "RELATIONSHIP_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
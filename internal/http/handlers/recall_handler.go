@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RecallHandler handles recall rule, recall worklist and outreach HTTP requests
+type RecallHandler struct {
+	recallUseCase *usecases.RecallUseCase
+	logger        *logger.Logger
+}
+
+// NewRecallHandler creates a new RecallHandler instance
+func NewRecallHandler(recallUseCase *usecases.RecallUseCase, logger *logger.Logger) *RecallHandler {
+	return &RecallHandler{
+		recallUseCase: recallUseCase,
+		logger:        logger,
+	}
+}
+
+// ListRules handles GET /recall-rules
+func (h *RecallHandler) ListRules(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RecallRuleListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListRules")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	rules, err := h.recallUseCase.ListRules(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list recall rules")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, rules)
+}
+
+// CreateRule handles POST /recall-rules
+func (h *RecallHandler) CreateRule(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RecallRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateRule")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	rule, err := h.recallUseCase.CreateRule(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create recall rule")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, rule)
+}
+
+// UpdateRule handles PUT /recall-rules/:id
+func (h *RecallHandler) UpdateRule(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	ruleID, ok := uuidParam(c, "id", "recall rule")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateRecallRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateRule")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	rule, err := h.recallUseCase.UpdateRule(c.Request.Context(), orgID, ruleID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update recall rule")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, rule)
+}
+
+// ListRecalls handles GET /recalls
+func (h *RecallHandler) ListRecalls(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	req, ok := h.bindListRequest(c, "ListRecalls")
+	if !ok {
+		return
+	}
+
+	recalls, err := h.recallUseCase.ListRecalls(c.Request.Context(), orgID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list recalls")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, recalls)
+}
+
+// ListPatientRecalls handles GET /patients/:id/recalls
+func (h *RecallHandler) ListPatientRecalls(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	req, ok := h.bindListRequest(c, "ListPatientRecalls")
+	if !ok {
+		return
+	}
+
+	recalls, err := h.recallUseCase.ListPatientRecalls(c.Request.Context(), orgID, patientID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list patient recalls")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, recalls)
+}
+
+// GetRecall handles GET /recalls/:id
+func (h *RecallHandler) GetRecall(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	recallID, ok := uuidParam(c, "id", "recall")
+	if !ok {
+		return
+	}
+
+	recall, err := h.recallUseCase.GetRecall(c.Request.Context(), orgID, recallID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get recall")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, recall)
+}
+
+// RecordOutreach handles POST /recalls/:id/outreach
+func (h *RecallHandler) RecordOutreach(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	recallID, ok := uuidParam(c, "id", "recall")
+	if !ok {
+		return
+	}
+
+	var req dto.RecallOutreachRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for RecordOutreach")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	recall, err := h.recallUseCase.RecordOutreach(c.Request.Context(), orgID, recallID, actingUserID(c), &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to record recall outreach")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, recall)
+}
+
+// DismissRecall handles POST /recalls/:id/dismiss
+func (h *RecallHandler) DismissRecall(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	recallID, ok := uuidParam(c, "id", "recall")
+	if !ok {
+		return
+	}
+
+	var req dto.DismissRecallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for DismissRecall")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	recall, err := h.recallUseCase.DismissRecall(c.Request.Context(), orgID, recallID, actingUserID(c), &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to dismiss recall")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, recall)
+}
+
+func (h *RecallHandler) bindListRequest(c *gin.Context, operation string) (*dto.RecallListRequest, bool) {
+	var req dto.RecallListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warnf("Invalid query parameters for %s", operation)
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return nil, false
+	}
+	if req.ClinicIDStr != "" {
+		clinicID, err := uuid.Parse(req.ClinicIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid clinic ID format")
+			return nil, false
+		}
+		req.ClinicID = &clinicID
+	}
+	if req.PatientIDStr != "" {
+		patientID, err := uuid.Parse(req.PatientIDStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid patient ID format")
+			return nil, false
+		}
+		req.PatientID = &patientID
+	}
+	return &req, true
+}
+
+func (h *RecallHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrRecallServiceRequired, entities.ErrInvalidRecallInterval, entities.ErrInvalidRecallStatus,
+		entities.ErrInvalidRecallOutreachStatus, entities.ErrInvalidRecallOutreachChannel, entities.ErrInvalidRecallOutreachOutcome,
+		entities.ErrInvalidRecallOutreachTime, entities.ErrInvalidRecallDueWindow, entities.ErrRecallDismissReasonRequired:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrRecallRuleExists:
+		respondError(c, http.StatusConflict, "RECALL_RULE_EXISTS", err.Error())
+	case entities.ErrRecallNotOpen:
+		respondError(c, http.StatusConflict, "RECALL_NOT_OPEN", err.Error())
+	case entities.ErrRecallNotFound:
+		respondError(c, http.StatusNotFound, "RECALL_NOT_FOUND", err.Error())
+	case entities.ErrRecallRuleNotFound:
+		respondError(c, http.StatusNotFound, "RECALL_RULE_NOT_FOUND", err.Error())
+	case entities.ErrServiceNotFound:
+		respondError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
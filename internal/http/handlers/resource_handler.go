@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResourceHandler handles equipment and resource calendar HTTP requests
+type ResourceHandler struct {
+	resourceUseCase *usecases.ResourceUseCase
+	logger          *logger.Logger
+}
+
+// NewResourceHandler creates a new ResourceHandler instance
+func NewResourceHandler(resourceUseCase *usecases.ResourceUseCase, logger *logger.Logger) *ResourceHandler {
+	return &ResourceHandler{
+		resourceUseCase: resourceUseCase,
+		logger:          logger,
+	}
+}
+
+// ListEquipment handles GET /equipment
+func (h *ResourceHandler) ListEquipment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.EquipmentListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListEquipment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	equipment, err := h.resourceUseCase.ListEquipment(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list equipment")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, equipment)
+}
+
+// GetEquipment handles GET /equipment/:id
+func (h *ResourceHandler) GetEquipment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	equipmentID, ok := uuidParam(c, "id", "equipment")
+	if !ok {
+		return
+	}
+
+	equipment, err := h.resourceUseCase.GetEquipment(c.Request.Context(), orgID, equipmentID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get equipment")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, equipment)
+}
+
+// CreateEquipment handles POST /equipment
+func (h *ResourceHandler) CreateEquipment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateEquipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateEquipment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	equipment, err := h.resourceUseCase.CreateEquipment(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create equipment")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, equipment)
+}
+
+// UpdateEquipment handles PUT /equipment/:id
+func (h *ResourceHandler) UpdateEquipment(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	equipmentID, ok := uuidParam(c, "id", "equipment")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateEquipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateEquipment")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	equipment, err := h.resourceUseCase.UpdateEquipment(c.Request.Context(), orgID, equipmentID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update equipment")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, equipment)
+}
+
+// GetResourceCalendar handles GET /calendar/resources
+func (h *ResourceHandler) GetResourceCalendar(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ResourceCalendarRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for GetResourceCalendar")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	calendar, err := h.resourceUseCase.GetResourceCalendar(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get resource calendar")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, calendar)
+}
+
+func (h *ResourceHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidEquipmentName, entities.ErrInvalidClinicID, entities.ErrInvalidResourceType, entities.ErrInvalidCalendarRange:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrEquipmentNotFound:
+		respondError(c, http.StatusNotFound, "EQUIPMENT_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentResourceNotFound:
+		respondError(c, http.StatusNotFound, "RESOURCE_NOT_FOUND", err.Error())
+	case entities.ErrClinicNotFound:
+		respondError(c, http.StatusNotFound, "CLINIC_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
+
+// respondAppointmentResourceError responds to an appointment whose resources cannot be booked
+func respondAppointmentResourceError(c *gin.Context, err error) {
+	switch err {
+	case entities.ErrAppointmentConflict:
+		respondError(c, http.StatusConflict, "TIME_SLOT_CONFLICT", "The selected time slot conflicts with an existing appointment")
+	case entities.ErrAppointmentResourceConflict:
+		respondError(c, http.StatusConflict, "RESOURCE_CONFLICT", err.Error())
+	case entities.ErrDoctorNotAvailable:
+		respondError(c, http.StatusConflict, "DOCTOR_NOT_AVAILABLE", err.Error())
+	case entities.ErrStaffNotAvailable:
+		respondError(c, http.StatusConflict, "STAFF_NOT_AVAILABLE", err.Error())
+	case entities.ErrAppointmentResourceNotFound:
+		respondError(c, http.StatusBadRequest, "RESOURCE_NOT_FOUND", err.Error())
+	case entities.ErrAppointmentResourceInactive:
+		respondError(c, http.StatusBadRequest, "RESOURCE_INACTIVE", err.Error())
+	case entities.ErrResourceClinicMismatch:
+		respondError(c, http.StatusBadRequest, "RESOURCE_CLINIC_MISMATCH", err.Error())
+	default:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	}
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StaffHandler handles staff invitation and user management HTTP requests
+type StaffHandler struct {
+	staffUseCase *usecases.StaffUseCase
+	logger       *logger.Logger
+}
+
+// NewStaffHandler creates a new StaffHandler instance
+func NewStaffHandler(staffUseCase *usecases.StaffUseCase, logger *logger.Logger) *StaffHandler {
+	return &StaffHandler{
+		staffUseCase: staffUseCase,
+		logger:       logger,
+	}
+}
+
+// InviteUser handles POST /admin/invitations
+func (h *StaffHandler) InviteUser(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	inviterID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InviteStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for InviteUser")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	invitation, err := h.staffUseCase.InviteUser(c.Request.Context(), orgID, inviterID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to invite user")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"invitation_id":   invitation.ID,
+	}).Info("Staff invitation created")
+
+	respondSuccess(c, http.StatusCreated, invitation)
+}
+
+// ListInvitations handles GET /admin/invitations
+func (h *StaffHandler) ListInvitations(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.staffUseCase.ListInvitations(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list invitations")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, invitations)
+}
+
+// RevokeInvitation handles DELETE /admin/invitations/:id
+func (h *StaffHandler) RevokeInvitation(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := uuidParam(c, "id", "invitation")
+	if !ok {
+		return
+	}
+
+	if err := h.staffUseCase.RevokeInvitation(c.Request.Context(), orgID, invitationID); err != nil {
+		h.handleError(c, err, "Failed to revoke invitation")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, gin.H{"id": invitationID, "status": entities.InvitationStatusRevoked})
+}
+
+// AcceptInvitation handles POST /invitations/accept
+func (h *StaffHandler) AcceptInvitation(c *gin.Context) {
+	profileID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for AcceptInvitation")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	member, err := h.staffUseCase.AcceptInvitation(c.Request.Context(), profileID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, member)
+}
+
+// ListMembers handles GET /admin/users
+func (h *StaffHandler) ListMembers(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.staffUseCase.ListMembers(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list organization users")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, members)
+}
+
+// UpdateMemberRoles handles PUT /admin/users/:id/roles
+func (h *StaffHandler) UpdateMemberRoles(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	actorID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	memberID, ok := uuidParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMemberRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateMemberRoles")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	member, err := h.staffUseCase.UpdateMemberRoles(c.Request.Context(), orgID, actorID, memberID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update user roles")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, member)
+}
+
+// LinkMemberToDoctor handles PUT /admin/users/:id/doctor
+func (h *StaffHandler) LinkMemberToDoctor(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	memberID, ok := uuidParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	var req dto.LinkMemberDoctorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for LinkMemberToDoctor")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	member, err := h.staffUseCase.LinkMemberToDoctor(c.Request.Context(), orgID, memberID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to link user to doctor")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, member)
+}
+
+// DeactivateMember handles POST /admin/users/:id/deactivate
+func (h *StaffHandler) DeactivateMember(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	actorID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	memberID, ok := uuidParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	member, err := h.staffUseCase.DeactivateMember(c.Request.Context(), orgID, actorID, memberID)
+	if err != nil {
+		h.handleError(c, err, "Failed to deactivate user")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, member)
+}
+
+// ReactivateMember handles POST /admin/users/:id/activate
+func (h *StaffHandler) ReactivateMember(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	memberID, ok := uuidParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	member, err := h.staffUseCase.ReactivateMember(c.Request.Context(), orgID, memberID)
+	if err != nil {
+		h.handleError(c, err, "Failed to activate user")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, member)
+}
+
+// handleError maps staff management errors to HTTP responses
+func (h *StaffHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidEmail, entities.ErrInvalidStaffRole:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrInvalidInvitationToken:
+		respondError(c, http.StatusBadRequest, "INVALID_TOKEN", err.Error())
+	case entities.ErrInvitationExpired:
+		respondError(c, http.StatusGone, "INVITATION_EXPIRED", err.Error())
+	case entities.ErrInvitationNotFound:
+		respondError(c, http.StatusNotFound, "INVITATION_NOT_FOUND", err.Error())
+	case entities.ErrProfileNotFound:
+		respondError(c, http.StatusNotFound, "USER_NOT_FOUND", err.Error())
+	case entities.ErrDoctorNotFound:
+		respondError(c, http.StatusNotFound, "DOCTOR_NOT_FOUND", err.Error())
+	case entities.ErrInvitationNotPending, entities.ErrUserAlreadyMember,
+		entities.ErrProfileInAnotherOrganization, entities.ErrDoctorAlreadyLinked:
+		respondError(c, http.StatusConflict, "CONFLICT", err.Error())
+	case entities.ErrInvitationEmailMismatch, entities.ErrCannotModifyOwnAccess:
+		respondError(c, http.StatusForbidden, "FORBIDDEN", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
@@ -0,0 +1,377 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TreatmentPlanHandler handles treatment plan HTTP requests
+type TreatmentPlanHandler struct {
+	planUseCase        *usecases.TreatmentPlanUseCase
+	appointmentUseCase *usecases.AppointmentUseCase
+	logger             *logger.Logger
+}
+
+// NewTreatmentPlanHandler creates a new TreatmentPlanHandler instance
+func NewTreatmentPlanHandler(planUseCase *usecases.TreatmentPlanUseCase, appointmentUseCase *usecases.AppointmentUseCase, logger *logger.Logger) *TreatmentPlanHandler {
+	return &TreatmentPlanHandler{
+		planUseCase:        planUseCase,
+		appointmentUseCase: appointmentUseCase,
+		logger:             logger,
+	}
+}
+
+// CreatePlan handles POST /treatment-plans
+func (h *TreatmentPlanHandler) CreatePlan(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateTreatmentPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreatePlan")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	plan, err := h.planUseCase.CreatePlan(c.Request.Context(), orgID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create treatment plan")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"plan_id":         plan.ID,
+		"patient_id":      plan.PatientID,
+	}).Info("Treatment plan created")
+
+	respondSuccess(c, http.StatusCreated, plan)
+}
+
+// GetPlan handles GET /treatment-plans/:id
+func (h *TreatmentPlanHandler) GetPlan(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+
+	var req dto.TreatmentPlanQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for GetPlan")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	plan, err := h.planUseCase.GetPlan(c.Request.Context(), orgID, planID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get treatment plan")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, plan)
+}
+
+// ListPatientPlans handles GET /patients/:id/treatment-plans
+func (h *TreatmentPlanHandler) ListPatientPlans(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	patientID, ok := uuidParam(c, "id", "patient")
+	if !ok {
+		return
+	}
+
+	var req dto.TreatmentPlanQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid query parameters for ListPatientPlans")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	plans, err := h.planUseCase.ListPatientPlans(c.Request.Context(), orgID, patientID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list treatment plans")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, plans)
+}
+
+// UpdatePlan handles PATCH /treatment-plans/:id
+func (h *TreatmentPlanHandler) UpdatePlan(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTreatmentPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdatePlan")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	plan, err := h.planUseCase.UpdatePlan(c.Request.Context(), orgID, planID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update treatment plan")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, plan)
+}
+
+// AddItem handles POST /treatment-plans/:id/items
+func (h *TreatmentPlanHandler) AddItem(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+
+	var req dto.AddTreatmentPlanItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for AddItem")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	plan, err := h.planUseCase.AddItem(c.Request.Context(), orgID, planID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to add treatment plan item")
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, plan)
+}
+
+// UpdateItem handles PATCH /treatment-plans/:id/items/:item_id
+func (h *TreatmentPlanHandler) UpdateItem(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+	itemID, ok := uuidParam(c, "item_id", "treatment plan item")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTreatmentPlanItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateItem")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	plan, err := h.planUseCase.UpdateItem(c.Request.Context(), orgID, planID, itemID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update treatment plan item")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, plan)
+}
+
+// RemoveItem handles DELETE /treatment-plans/:id/items/:item_id
+func (h *TreatmentPlanHandler) RemoveItem(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+	itemID, ok := uuidParam(c, "item_id", "treatment plan item")
+	if !ok {
+		return
+	}
+
+	plan, err := h.planUseCase.RemoveItem(c.Request.Context(), orgID, planID, itemID)
+	if err != nil {
+		h.handleError(c, err, "Failed to remove treatment plan item")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, plan)
+}
+
+// PresentPlan handles POST /treatment-plans/:id/present
+func (h *TreatmentPlanHandler) PresentPlan(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+
+	plan, err := h.planUseCase.PresentPlan(c.Request.Context(), orgID, planID)
+	if err != nil {
+		h.handleError(c, err, "Failed to present treatment plan")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, plan)
+}
+
+// RecordDecision handles POST /treatment-plans/:id/decision
+func (h *TreatmentPlanHandler) RecordDecision(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+
+	var req dto.TreatmentPlanDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for RecordDecision")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	plan, err := h.planUseCase.RecordDecision(c.Request.Context(), orgID, planID, &userID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to record treatment plan decision")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"plan_id":         planID,
+		"status":          plan.Status,
+	}).Info("Treatment plan decision recorded")
+
+	respondSuccess(c, http.StatusOK, plan)
+}
+
+// CancelPlan handles POST /treatment-plans/:id/cancel
+func (h *TreatmentPlanHandler) CancelPlan(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+
+	plan, err := h.planUseCase.CancelPlan(c.Request.Context(), orgID, planID)
+	if err != nil {
+		h.handleError(c, err, "Failed to cancel treatment plan")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, plan)
+}
+
+// ScheduleItem handles POST /treatment-plans/:id/items/:item_id/appointments by booking
+// the procedure through the regular appointment creation
+func (h *TreatmentPlanHandler) ScheduleItem(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	planID, ok := uuidParam(c, "id", "treatment plan")
+	if !ok {
+		return
+	}
+	itemID, ok := uuidParam(c, "item_id", "treatment plan item")
+	if !ok {
+		return
+	}
+
+	var req dto.ScheduleTreatmentPlanItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for ScheduleItem")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	appointmentReq, err := h.planUseCase.AppointmentRequestForItem(c.Request.Context(), orgID, planID, itemID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to schedule treatment plan item")
+		return
+	}
+
+	appointment, err := h.appointmentUseCase.CreateAppointment(c.Request.Context(), orgID, appointmentReq)
+	if err != nil {
+		h.handleError(c, err, "Failed to schedule treatment plan item")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"plan_id":         planID,
+		"item_id":         itemID,
+		"appointment_id":  appointment.ID,
+	}).Info("Treatment plan item scheduled")
+
+	setETag(c, appointment.Version)
+	respondSuccess(c, http.StatusCreated, appointment)
+}
+
+// handleError maps treatment plan errors to HTTP responses
+func (h *TreatmentPlanHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrTreatmentPlanTitleRequired, entities.ErrTreatmentPlanItemsRequired, entities.ErrTreatmentPlanServiceRequired,
+		entities.ErrInvalidTreatmentPlanPhase, entities.ErrInvalidEstimatedCost, entities.ErrInvalidToothNumber,
+		entities.ErrUnsupportedToothNumbering:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrTreatmentPlanNotEditable, entities.ErrTreatmentPlanAlreadyDecided, entities.ErrTreatmentPlanNotCancellable,
+		entities.ErrTreatmentPlanHasScheduledItems:
+		respondError(c, http.StatusConflict, "INVALID_PLAN_STATE", err.Error())
+	case entities.ErrTreatmentPlanItemNotSchedulable, entities.ErrTreatmentPlanPatientMismatch:
+		respondError(c, http.StatusConflict, "TREATMENT_PLAN_ITEM_NOT_SCHEDULABLE", err.Error())
+	case entities.ErrTreatmentPlanNotFound:
+		respondError(c, http.StatusNotFound, "TREATMENT_PLAN_NOT_FOUND", err.Error())
+	case entities.ErrTreatmentPlanItemNotFound:
+		respondError(c, http.StatusNotFound, "TREATMENT_PLAN_ITEM_NOT_FOUND", err.Error())
+	case entities.ErrPatientNotFound:
+		respondError(c, http.StatusNotFound, "PATIENT_NOT_FOUND", err.Error())
+	case entities.ErrDoctorNotFound:
+		respondError(c, http.StatusNotFound, "DOCTOR_NOT_FOUND", err.Error())
+	case entities.ErrServiceNotFound:
+		respondError(c, http.StatusNotFound, "SERVICE_NOT_FOUND", err.Error())
+	case entities.ErrUnitNotFound:
+		respondError(c, http.StatusNotFound, "UNIT_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"net/http"
+
+	"dental-scheduler-backend/internal/app/dto"
+	"dental-scheduler-backend/internal/app/usecases"
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles webhook subscription and delivery HTTP requests
+type WebhookHandler struct {
+	webhookUseCase *usecases.WebhookUseCase
+	logger         *logger.Logger
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance
+func NewWebhookHandler(webhookUseCase *usecases.WebhookUseCase, logger *logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+		logger:         logger,
+	}
+}
+
+// CreateSubscription handles POST /admin/webhooks
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for CreateSubscription")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	subscription, err := h.webhookUseCase.CreateSubscription(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create webhook subscription")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"subscription_id": subscription.ID,
+	}).Info("Webhook subscription created")
+
+	respondSuccess(c, http.StatusCreated, subscription)
+}
+
+// ListSubscriptions handles GET /admin/webhooks
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.webhookUseCase.ListSubscriptions(c.Request.Context(), orgID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list webhook subscriptions")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, subscriptions)
+}
+
+// GetSubscription handles GET /admin/webhooks/:id
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	subscriptionID, ok := uuidParam(c, "id", "webhook subscription")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookUseCase.GetSubscription(c.Request.Context(), orgID, subscriptionID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get webhook subscription")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, subscription)
+}
+
+// UpdateSubscription handles PUT /admin/webhooks/:id
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	subscriptionID, ok := uuidParam(c, "id", "webhook subscription")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Logger.WithError(err).Warn("Invalid request body for UpdateSubscription")
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	subscription, err := h.webhookUseCase.UpdateSubscription(c.Request.Context(), orgID, subscriptionID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update webhook subscription")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, subscription)
+}
+
+// DeleteSubscription handles DELETE /admin/webhooks/:id
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	subscriptionID, ok := uuidParam(c, "id", "webhook subscription")
+	if !ok {
+		return
+	}
+
+	if err := h.webhookUseCase.DeleteSubscription(c.Request.Context(), orgID, subscriptionID); err != nil {
+		h.handleError(c, err, "Failed to delete webhook subscription")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"subscription_id": subscriptionID,
+	}).Info("Webhook subscription deleted")
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /admin/webhooks/:id/deliveries
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	subscriptionID, ok := uuidParam(c, "id", "webhook subscription")
+	if !ok {
+		return
+	}
+
+	var req dto.WebhookDeliveryListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(c.Request.Context(), orgID, subscriptionID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list webhook deliveries")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, deliveries)
+}
+
+// GetDelivery handles GET /admin/webhook-deliveries/:id
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	deliveryID, ok := uuidParam(c, "id", "webhook delivery")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookUseCase.GetDelivery(c.Request.Context(), orgID, deliveryID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get webhook delivery")
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, delivery)
+}
+
+// ReplayDelivery handles POST /admin/webhook-deliveries/:id/replay
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	orgID, ok := organizationIDFromContext(c)
+	if !ok {
+		return
+	}
+	deliveryID, ok := uuidParam(c, "id", "webhook delivery")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookUseCase.ReplayDelivery(c.Request.Context(), orgID, deliveryID)
+	if err != nil {
+		h.handleError(c, err, "Failed to replay webhook delivery")
+		return
+	}
+
+	h.logger.Logger.WithFields(map[string]interface{}{
+		"organization_id": orgID,
+		"delivery_id":     deliveryID,
+	}).Info("Webhook delivery scheduled for replay")
+
+	respondSuccess(c, http.StatusAccepted, delivery)
+}
+
+// handleError maps webhook errors to HTTP responses
+func (h *WebhookHandler) handleError(c *gin.Context, err error, message string) {
+	switch err {
+	case entities.ErrInvalidWebhookURL, entities.ErrInvalidWebhookEventType:
+		respondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case entities.ErrWebhookSubscriptionNotFound:
+		respondError(c, http.StatusNotFound, "WEBHOOK_NOT_FOUND", err.Error())
+	case entities.ErrWebhookDeliveryNotFound:
+		respondError(c, http.StatusNotFound, "WEBHOOK_DELIVERY_NOT_FOUND", err.Error())
+	default:
+		h.logger.Logger.WithError(err).Error(message)
+		respondError(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	}
+}
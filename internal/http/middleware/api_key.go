@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the header integrations use to send their API key
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves plaintext API keys
+type APIKeyAuthenticator interface {
+	// Authenticate returns the stored key for a plaintext API key
+	Authenticate(ctx context.Context, plaintext string) (*entities.APIKey, error)
+}
+
+// APIKeyAuth creates a middleware that authenticates requests carrying an API key
+// and hands every other request to next (typically SupabaseAuth)
+func APIKeyAuth(logger *logger.Logger, authenticator APIKeyAuthenticator, next gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		plaintext := extractAPIKey(c)
+		if plaintext == "" {
+			next(c)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		apiKey, err := authenticator.Authenticate(ctx, plaintext)
+		if err != nil {
+			logger.Logger.WithError(err).Debug("API key authentication failed")
+			message := "Invalid API key"
+			switch err {
+			case entities.ErrAPIKeyRevoked, entities.ErrAPIKeyExpired:
+				message = err.Error()
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": message,
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("api_key", apiKey)
+		c.Set("organization_id", apiKey.OrganizationID.String())
+
+		c.Next()
+	}
+}
+
+// RequireAPIKeyScope creates a middleware that restricts API key requests to keys
+// granted the given scope; requests authenticated with a user session pass through
+func RequireAPIKeyScope(logger *logger.Logger, scope entities.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, exists := GetAPIKeyFromContext(c)
+		if !exists || apiKey.HasScope(scope) {
+			c.Next()
+			return
+		}
+
+		logger.Logger.WithFields(map[string]interface{}{
+			"api_key_id":     apiKey.ID,
+			"required_scope": scope,
+		}).Debug("API key missing required scope")
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "FORBIDDEN",
+				"message": "API key is not allowed to access this resource",
+			},
+		})
+		c.Abort()
+	}
+}
+
+// GetAPIKeyFromContext retrieves the API key used to authenticate the request
+func GetAPIKeyFromContext(c *gin.Context) (*entities.APIKey, bool) {
+	if key, exists := c.Get("api_key"); exists {
+		if apiKey, ok := key.(*entities.APIKey); ok {
+			return apiKey, true
+		}
+	}
+	return nil, false
+}
+
+// extractAPIKey reads the key from X-API-Key or from a Bearer token with the key prefix
+func extractAPIKey(c *gin.Context) string {
+	if key := strings.TrimSpace(c.GetHeader(APIKeyHeader)); key != "" {
+		return key
+	}
+
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if strings.HasPrefix(token, entities.APIKeyPrefix+"_") {
+		return token
+	}
+
+	return ""
+}
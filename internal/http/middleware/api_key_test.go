@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	infraLogger "dental-scheduler-backend/internal/infra/logger"
+)
+
+type stubAPIKeyAuthenticator struct {
+	keys map[string]*entities.APIKey
+}
+
+func (s *stubAPIKeyAuthenticator) Authenticate(ctx context.Context, plaintext string) (*entities.APIKey, error) {
+	if key, ok := s.keys[plaintext]; ok {
+		return key, nil
+	}
+	return nil, entities.ErrInvalidAPIKey
+}
+
+func newAPIKeyTestRouter(t *testing.T) (*gin.Engine, string, uuid.UUID) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	logger := infraLogger.NewLogger("debug")
+
+	orgID := uuid.New()
+	apiKey, plaintext, err := entities.NewAPIKey(orgID, "lab partner", []entities.APIKeyScope{entities.APIKeyScopePatients}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create API key: %v", err)
+	}
+	authenticator := &stubAPIKeyAuthenticator{keys: map[string]*entities.APIKey{plaintext: apiKey}}
+
+	fallback := func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusTeapot)
+	}
+
+	router := gin.New()
+	protected := router.Group("/", APIKeyAuth(logger, authenticator, fallback))
+	handler := func(c *gin.Context) {
+		orgID, _ := GetOrganizationIDFromContext(c)
+		c.String(http.StatusOK, orgID)
+	}
+	protected.GET("/patients", RequireAPIKeyScope(logger, entities.APIKeyScopePatients), handler)
+	protected.GET("/appointments", RequireAPIKeyScope(logger, entities.APIKeyScopeAppointments), handler)
+
+	return router, plaintext, orgID
+}
+
+func performRequest(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestAPIKeyAuthSetsOrganizationForScopedRoutes(t *testing.T) {
+	router, plaintext, orgID := newAPIKeyTestRouter(t)
+
+	recorder := performRequest(router, "/patients", map[string]string{APIKeyHeader: plaintext})
+	if recorder.Code != http.StatusOK || recorder.Body.String() != orgID.String() {
+		t.Fatalf("expected key organization in context, got %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = performRequest(router, "/patients", map[string]string{"Authorization": "Bearer " + plaintext})
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected bearer API key to be accepted, got %d", recorder.Code)
+	}
+}
+
+func TestAPIKeyAuthRejectsMissingScopeAndUnknownKeys(t *testing.T) {
+	router, plaintext, _ := newAPIKeyTestRouter(t)
+
+	if recorder := performRequest(router, "/appointments", map[string]string{APIKeyHeader: plaintext}); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected key without scope to be forbidden, got %d", recorder.Code)
+	}
+
+	if recorder := performRequest(router, "/patients", map[string]string{APIKeyHeader: "dsk_unknown_secret"}); recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected unknown key to be unauthorized, got %d", recorder.Code)
+	}
+}
+
+func TestAPIKeyAuthDelegatesSessionRequests(t *testing.T) {
+	router, _, _ := newAPIKeyTestRouter(t)
+
+	// Supabase JWTs are handed to the fallback authenticator untouched
+	if recorder := performRequest(router, "/patients", map[string]string{"Authorization": "Bearer eyJhbGciOi"}); recorder.Code != http.StatusTeapot {
+		t.Fatalf("expected session request to reach fallback, got %d", recorder.Code)
+	}
+}
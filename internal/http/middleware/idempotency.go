@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// IdempotencyKeyHeader is the header clients use to make a request safe to retry
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on responses replayed from a previous request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// idempotencyStaleAfter is how long an in-progress key blocks retries; it must exceed
+	// the server write timeout so only requests that died mid-flight are taken over
+	idempotencyStaleAfter = time.Minute
+)
+
+// idempotencyResponseWriter captures the response body so it can be stored for replay
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyResponseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency creates a middleware that honors the Idempotency-Key header. The first
+// request with a key runs normally and its response is stored per organization for the
+// retention window; a retry with the same key and body receives the stored response,
+// while reusing the key with a different body is rejected with 422. Server errors are
+// not stored so the request can be retried. It must run after authentication.
+func Idempotency(logger *logger.Logger, repo repositories.IdempotencyKeyRepository, retention time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if !entities.IsValidIdempotencyKey(key) {
+			abortIdempotency(c, http.StatusBadRequest, "INVALID_IDEMPOTENCY_KEY", "Idempotency-Key must be 1-255 printable ASCII characters")
+			return
+		}
+
+		orgIDStr, exists := GetOrganizationIDFromContext(c)
+		if !exists {
+			c.Next()
+			return
+		}
+		orgID, err := uuid.Parse(orgIDStr)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortIdempotency(c, http.StatusBadRequest, "INVALID_REQUEST", "Failed to read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		requestHash := entities.IdempotencyRequestHash(c.Request.Method, c.Request.URL.Path, body)
+		record, reserved, err := repo.Reserve(c.Request.Context(), entities.NewIdempotencyKey(orgID, key, requestHash, retention), idempotencyStaleAfter)
+		if err != nil {
+			logger.Logger.WithError(err).Error("Failed to reserve idempotency key")
+			abortIdempotency(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to process idempotency key")
+			return
+		}
+
+		if !reserved {
+			switch {
+			case !record.Matches(requestHash):
+				abortIdempotency(c, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used with a different request")
+			case !record.IsCompleted():
+				c.Header("Retry-After", "1")
+				abortIdempotency(c, http.StatusConflict, "IDEMPOTENCY_REQUEST_IN_PROGRESS", "A request with this Idempotency-Key is still being processed")
+			default:
+				replayIdempotentResponse(c, record)
+			}
+			return
+		}
+
+		writer := &idempotencyResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			// The handler failed or panicked: free the key so the client can retry
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := repo.Release(ctx, orgID, key); err != nil {
+				logger.Logger.WithError(err).Error("Failed to release idempotency key")
+			}
+		}()
+
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		record.Complete(status, writer.Header().Get("Content-Type"), writer.Header().Get("ETag"), writer.body.Bytes())
+		if err := repo.Complete(c.Request.Context(), record); err != nil {
+			logger.Logger.WithError(err).WithField("idempotency_key", key).Error("Failed to store idempotent response")
+			return
+		}
+		completed = true
+	}
+}
+
+// replayIdempotentResponse writes the stored response of a previous request
+func replayIdempotentResponse(c *gin.Context, record *entities.IdempotencyKey) {
+	contentType := "application/json; charset=utf-8"
+	if record.ContentType != nil {
+		contentType = *record.ContentType
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	if record.ETag != nil {
+		c.Header("ETag", *record.ETag)
+	}
+	c.Data(*record.ResponseStatus, contentType, record.ResponseBody)
+	c.Abort()
+}
+
+// abortIdempotency aborts the request with an error response
+func abortIdempotency(c *gin.Context, status int, code, message string) {
+	c.JSON(status, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    code,
+			"message": message,
+		},
+	})
+	c.Abort()
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	infraLogger "dental-scheduler-backend/internal/infra/logger"
+)
+
+type memoryIdempotencyKeyRepo struct {
+	mu      sync.Mutex
+	records map[string]*entities.IdempotencyKey
+}
+
+func (r *memoryIdempotencyKeyRepo) Reserve(ctx context.Context, record *entities.IdempotencyKey, staleAfter time.Duration) (*entities.IdempotencyKey, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := record.OrganizationID.String() + "/" + record.Key
+	if existing, ok := r.records[id]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		copied := *existing
+		return &copied, false, nil
+	}
+	copied := *record
+	r.records[id] = &copied
+	return record, true, nil
+}
+
+func (r *memoryIdempotencyKeyRepo) Complete(ctx context.Context, record *entities.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *record
+	r.records[record.OrganizationID.String()+"/"+record.Key] = &copied
+	return nil
+}
+
+func (r *memoryIdempotencyKeyRepo) Release(ctx context.Context, orgID uuid.UUID, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, orgID.String()+"/"+key)
+	return nil
+}
+
+func (r *memoryIdempotencyKeyRepo) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// newIdempotencyTestRouter returns a router whose handler counts executions and
+// answers with the given status
+func newIdempotencyTestRouter(status int) (*gin.Engine, *int) {
+	gin.SetMode(gin.TestMode)
+	logger := infraLogger.NewLogger("error")
+	repo := &memoryIdempotencyKeyRepo{records: map[string]*entities.IdempotencyKey{}}
+	orgID := uuid.New().String()
+
+	calls := 0
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("organization_id", orgID)
+		c.Next()
+	})
+	router.POST("/appointments", Idempotency(logger, repo, time.Hour), func(c *gin.Context) {
+		calls++
+		c.Header("ETag", fmt.Sprintf(`"%d"`, calls))
+		c.JSON(status, gin.H{"success": status < 400, "call": calls})
+	})
+
+	return router, &calls
+}
+
+func postIdempotent(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/appointments", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	router, calls := newIdempotencyTestRouter(http.StatusCreated)
+
+	first := postIdempotent(router, "booking-1", `{"patient_id":"p1"}`)
+	second := postIdempotent(router, "booking-1", `{"patient_id":"p1"}`)
+
+	if *calls != 1 {
+		t.Fatalf("expected the handler to run once, ran %d times", *calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("expected replay of %d %s, got %d %s", first.Code, first.Body, second.Code, second.Body)
+	}
+	if etag := second.Header().Get("ETag"); etag != `"1"` || etag != first.Header().Get("ETag") {
+		t.Errorf("expected the replay to keep ETag %s, got %q", first.Header().Get("ETag"), etag)
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("expected replayed response to be flagged")
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Error("original response must not be flagged as replayed")
+	}
+}
+
+func TestIdempotency_RejectsKeyReusedWithDifferentBody(t *testing.T) {
+	router, calls := newIdempotencyTestRouter(http.StatusCreated)
+
+	postIdempotent(router, "booking-1", `{"patient_id":"p1"}`)
+	reused := postIdempotent(router, "booking-1", `{"patient_id":"p2"}`)
+
+	if reused.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d", reused.Code)
+	}
+	if *calls != 1 {
+		t.Errorf("expected the handler to run once, ran %d times", *calls)
+	}
+}
+
+func TestIdempotency_DoesNotStoreServerErrors(t *testing.T) {
+	router, calls := newIdempotencyTestRouter(http.StatusInternalServerError)
+
+	postIdempotent(router, "booking-1", `{}`)
+	postIdempotent(router, "booking-1", `{}`)
+
+	if *calls != 2 {
+		t.Errorf("expected a retry after a server error to run the handler again, ran %d times", *calls)
+	}
+}
+
+func TestIdempotency_RequestsWithoutKeyAreNotDeduplicated(t *testing.T) {
+	router, calls := newIdempotencyTestRouter(http.StatusCreated)
+
+	postIdempotent(router, "", `{}`)
+	postIdempotent(router, "", `{}`)
+
+	if *calls != 2 {
+		t.Errorf("expected the handler to run twice, ran %d times", *calls)
+	}
+}
+
+func TestIdempotency_RejectsInvalidKey(t *testing.T) {
+	router, calls := newIdempotencyTestRouter(http.StatusCreated)
+
+	recorder := postIdempotent(router, strings.Repeat("k", entities.IdempotencyKeyMaxLength+1), `{}`)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", recorder.Code)
+	}
+	if *calls != 0 {
+		t.Errorf("expected the handler not to run, ran %d times", *calls)
+	}
+}
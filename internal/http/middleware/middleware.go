@@ -2,10 +2,8 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net/http"
-	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -82,7 +80,8 @@ func CORS(allowedOrigins []string) gin.HandlerFunc {
 		}
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, If-Match, "+IdempotencyKeyHeader)
+		c.Header("Access-Control-Expose-Headers", "ETag, "+IdempotentReplayedHeader)
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Max-Age", "86400")
 
@@ -132,7 +131,7 @@ const supabaseTokenTimeLeeway = 5 * time.Second
 
 // SupabaseAuth creates a middleware that validates Supabase JWT tokens
 // and enriches the context with full user profile from database
-func SupabaseAuth(logger *logger.Logger, userRepo repositories.UserRepository) gin.HandlerFunc {
+func SupabaseAuth(logger *logger.Logger, validator *TokenValidator, userRepo repositories.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -165,7 +164,7 @@ func SupabaseAuth(logger *logger.Logger, userRepo repositories.UserRepository) g
 		}
 
 		// Validate and parse the JWT token
-		jwtUser, err := validateSupabaseToken(tokenString, logger)
+		jwtUser, err := validator.Validate(c.Request.Context(), tokenString)
 		if err != nil {
 			logger.Logger.WithError(err).Debug("Token validation failed")
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -189,6 +188,20 @@ func SupabaseAuth(logger *logger.Logger, userRepo repositories.UserRepository) g
 				logger.Logger.WithError(err).Warn("Failed to fetch user profile from database, using JWT data only")
 				// Continue with JWT data only, don't abort
 			} else {
+				// Deactivated staff keep their Supabase account but lose access to the organization
+				if userProfile.Profile != nil && !userProfile.Profile.IsActive {
+					logger.Logger.WithField("user_id", jwtUser.ID).Debug("Deactivated profile attempted access")
+					c.JSON(http.StatusForbidden, gin.H{
+						"success": false,
+						"error": gin.H{
+							"code":    "ACCOUNT_DEACTIVATED",
+							"message": "User account has been deactivated",
+						},
+					})
+					c.Abort()
+					return
+				}
+
 				// Use database data and set additional context
 				c.Set("user_profile", userProfile)
 				c.Set("organization", userProfile.Organization)
@@ -210,96 +223,8 @@ func SupabaseAuth(logger *logger.Logger, userRepo repositories.UserRepository) g
 
 // SupabaseAuthSimple creates a basic middleware that only validates JWT tokens
 // without database lookup for organization data
-func SupabaseAuthSimple(logger *logger.Logger) gin.HandlerFunc {
-	return SupabaseAuth(logger, nil)
-}
-
-// validateSupabaseToken validates a Supabase JWT token
-func validateSupabaseToken(tokenString string, logger *logger.Logger) (*SupabaseUser, error) {
-	// Get JWT secret from environment
-	jwtSecret := os.Getenv("SUPABASE_JWT_SECRET")
-	if jwtSecret == "" {
-		return nil, fmt.Errorf("SUPABASE_JWT_SECRET not configured")
-	}
-
-	logger.Logger.WithFields(map[string]interface{}{
-		"token_length":  len(tokenString),
-		"secret_length": len(jwtSecret),
-		"token_prefix":  tokenString[:min(20, len(tokenString))],
-	}).Debug("Validating JWT token")
-
-	parser := jwt.NewParser(jwt.WithLeeway(supabaseTokenTimeLeeway))
-
-	// Parse and validate the token using ParseWithClaims and raw JWT secret
-	token, err := parser.ParseWithClaims(tokenString, &SupabaseClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			logger.Logger.WithField("signing_method", token.Header["alg"]).Error("Unexpected signing method")
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		// Use raw JWT secret as bytes (no base64 decoding)
-		return []byte(jwtSecret), nil
-	})
-
-	if err != nil {
-		logger.Logger.WithError(err).Error("Failed to parse JWT token")
-		return nil, fmt.Errorf("failed to parse token: %w", err)
-	}
-
-	if !token.Valid {
-		logger.Logger.Error("JWT token is invalid")
-		return nil, fmt.Errorf("invalid token")
-	}
-
-	// Extract claims
-	claims, ok := token.Claims.(*SupabaseClaims)
-	if !ok {
-		logger.Logger.Error("Failed to parse token claims")
-		return nil, fmt.Errorf("failed to parse token claims")
-	}
-
-	logger.Logger.WithField("subject", claims.Subject).Debug("Successfully parsed JWT claims")
-
-	now := time.Now()
-
-	// Check if token is expired using time comparison
-	if claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(now) {
-		logger.Logger.WithField("exp_time", claims.ExpiresAt.Time).Error("JWT token has expired")
-		return nil, fmt.Errorf("token is expired")
-	}
-
-	// Check if token is issued in the future
-	if claims.IssuedAt != nil && claims.IssuedAt.Time.After(now.Add(supabaseTokenTimeLeeway)) {
-		logger.Logger.WithField("issued_at", claims.IssuedAt.Time).Error("JWT token used before issued")
-		return nil, fmt.Errorf("token used before issued")
-	}
-
-	// Check if token is not valid yet
-	if claims.NotBefore != nil && claims.NotBefore.Time.After(now.Add(supabaseTokenTimeLeeway)) {
-		logger.Logger.WithField("not_before", claims.NotBefore.Time).Error("JWT token used before valid")
-		return nil, fmt.Errorf("token used before valid")
-	}
-
-	// Create user from claims
-	user := &SupabaseUser{
-		ID:    claims.Subject,
-		Email: claims.Email,
-		Roles: claims.Roles,
-	}
-
-	// Set default roles if not present
-	if len(user.Roles) == 0 {
-		user.Roles = []string{"authenticated"}
-		logger.Logger.Debug("No roles claim found, using default 'authenticated'")
-	}
-
-	logger.Logger.WithFields(map[string]interface{}{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"roles":   user.Roles,
-	}).Info("Successfully validated JWT token")
-
-	return user, nil
+func SupabaseAuthSimple(logger *logger.Logger, validator *TokenValidator) gin.HandlerFunc {
+	return SupabaseAuth(logger, validator, nil)
 }
 
 // Helper function to get minimum of two integers
@@ -313,7 +238,7 @@ func min(a, b int) int {
 // OptionalAuth creates a middleware that optionally validates Supabase JWT tokens
 // If a token is present, it validates it and sets user context
 // If no token is present, it continues without setting user context
-func OptionalAuth(logger *logger.Logger) gin.HandlerFunc {
+func OptionalAuth(logger *logger.Logger, validator *TokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -331,7 +256,7 @@ func OptionalAuth(logger *logger.Logger) gin.HandlerFunc {
 		}
 
 		// Try to validate the token
-		user, err := validateSupabaseToken(tokenString, logger)
+		user, err := validator.Validate(c.Request.Context(), tokenString)
 		if err != nil {
 			logger.Logger.WithError(err).Debug("Optional auth token validation failed")
 			// Continue without authentication
@@ -410,6 +335,47 @@ func RequireRole(role string, logger *logger.Logger) gin.HandlerFunc {
 	}
 }
 
+// RequireOrganizationRole creates a middleware that requires one of the given
+// organization roles stored in the user's profile
+// This should be used after SupabaseAuth middleware
+func RequireOrganizationRole(logger *logger.Logger, roles ...entities.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userProfile, exists := GetUserProfileFromContext(c)
+		if !exists || userProfile.Profile == nil {
+			logger.Logger.Debug("No user profile found in context")
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "FORBIDDEN",
+					"message": "User profile not found",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		for _, role := range roles {
+			if userProfile.Profile.HasRole(role) {
+				c.Next()
+				return
+			}
+		}
+
+		logger.Logger.WithFields(map[string]interface{}{
+			"required_roles": roles,
+			"user_roles":     userProfile.Profile.Roles,
+		}).Debug("Insufficient organization permissions")
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "FORBIDDEN",
+				"message": "Insufficient permissions",
+			},
+		})
+		c.Abort()
+	}
+}
+
 // GetUserFromContext retrieves the authenticated user from the Gin context
 func GetUserFromContext(c *gin.Context) (*SupabaseUser, bool) {
 	if user, exists := c.Get("user"); exists {
@@ -455,6 +421,24 @@ func HasRole(c *gin.Context, role string) bool {
 	return false
 }
 
+// HasOrganizationRole checks if the request was made by a user session whose profile holds one
+// of the given organization roles; requests authenticated with an API key never match
+func HasOrganizationRole(c *gin.Context, roles ...entities.Role) bool {
+	if _, isAPIKey := GetAPIKeyFromContext(c); isAPIKey {
+		return false
+	}
+	userProfile, exists := GetUserProfileFromContext(c)
+	if !exists || userProfile.Profile == nil {
+		return false
+	}
+	for _, role := range roles {
+		if userProfile.Profile.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetOrganizationIDFromContext retrieves the organization ID from the Gin context
 func GetOrganizationIDFromContext(c *gin.Context) (string, bool) {
 	if orgID, exists := c.Get("organization_id"); exists {
@@ -515,7 +499,8 @@ func CustomCORS(allowedOrigins []string) gin.HandlerFunc {
 
 		// Set other CORS headers
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, "+IdempotencyKeyHeader)
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, "+IdempotentReplayedHeader)
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		// Handle preflight requests
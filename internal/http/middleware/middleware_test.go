@@ -1,16 +1,32 @@
 package middleware
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"dental-scheduler-backend/internal/infra/auth"
 	infraLogger "dental-scheduler-backend/internal/infra/logger"
 )
 
 const testJWTSecret = "test-secret"
 
+func newTestTokenValidator(cfg TokenValidatorConfig) *TokenValidator {
+	return NewTokenValidator(cfg, infraLogger.NewLogger("debug"))
+}
+
 func signSupabaseToken(t *testing.T, claims *SupabaseClaims) string {
 	t.Helper()
 
@@ -24,8 +40,7 @@ func signSupabaseToken(t *testing.T, claims *SupabaseClaims) string {
 }
 
 func TestValidateSupabaseTokenAllowsIssuedAtLeeway(t *testing.T) {
-	t.Setenv("SUPABASE_JWT_SECRET", testJWTSecret)
-	logger := infraLogger.NewLogger("debug")
+	validator := newTestTokenValidator(TokenValidatorConfig{HMACSecret: testJWTSecret})
 
 	now := time.Now()
 	claims := &SupabaseClaims{
@@ -39,7 +54,7 @@ func TestValidateSupabaseTokenAllowsIssuedAtLeeway(t *testing.T) {
 
 	tokenString := signSupabaseToken(t, claims)
 
-	user, err := validateSupabaseToken(tokenString, logger)
+	user, err := validator.Validate(context.Background(), tokenString)
 	if err != nil {
 		t.Fatalf("expected token to be valid within leeway, got error: %v", err)
 	}
@@ -50,8 +65,7 @@ func TestValidateSupabaseTokenAllowsIssuedAtLeeway(t *testing.T) {
 }
 
 func TestValidateSupabaseTokenRejectsIssuedAtBeyondLeeway(t *testing.T) {
-	t.Setenv("SUPABASE_JWT_SECRET", testJWTSecret)
-	logger := infraLogger.NewLogger("debug")
+	validator := newTestTokenValidator(TokenValidatorConfig{HMACSecret: testJWTSecret})
 
 	now := time.Now()
 	claims := &SupabaseClaims{
@@ -64,14 +78,13 @@ func TestValidateSupabaseTokenRejectsIssuedAtBeyondLeeway(t *testing.T) {
 
 	tokenString := signSupabaseToken(t, claims)
 
-	if _, err := validateSupabaseToken(tokenString, logger); err == nil {
+	if _, err := validator.Validate(context.Background(), tokenString); err == nil {
 		t.Fatal("expected token to be rejected when issued-at exceeds leeway")
 	}
 }
 
 func TestValidateSupabaseTokenAllowsNotBeforeLeeway(t *testing.T) {
-	t.Setenv("SUPABASE_JWT_SECRET", testJWTSecret)
-	logger := infraLogger.NewLogger("debug")
+	validator := newTestTokenValidator(TokenValidatorConfig{HMACSecret: testJWTSecret})
 
 	now := time.Now()
 	claims := &SupabaseClaims{
@@ -85,14 +98,13 @@ func TestValidateSupabaseTokenAllowsNotBeforeLeeway(t *testing.T) {
 
 	tokenString := signSupabaseToken(t, claims)
 
-	if _, err := validateSupabaseToken(tokenString, logger); err != nil {
+	if _, err := validator.Validate(context.Background(), tokenString); err != nil {
 		t.Fatalf("expected token to be valid within not-before leeway, got error: %v", err)
 	}
 }
 
 func TestValidateSupabaseTokenRejectsNotBeforeBeyondLeeway(t *testing.T) {
-	t.Setenv("SUPABASE_JWT_SECRET", testJWTSecret)
-	logger := infraLogger.NewLogger("debug")
+	validator := newTestTokenValidator(TokenValidatorConfig{HMACSecret: testJWTSecret})
 
 	now := time.Now()
 	claims := &SupabaseClaims{
@@ -106,7 +118,208 @@ func TestValidateSupabaseTokenRejectsNotBeforeBeyondLeeway(t *testing.T) {
 
 	tokenString := signSupabaseToken(t, claims)
 
-	if _, err := validateSupabaseToken(tokenString, logger); err == nil {
+	if _, err := validator.Validate(context.Background(), tokenString); err == nil {
 		t.Fatal("expected token to be rejected when not-before exceeds leeway")
 	}
 }
+
+// testJWKSServer serves a JWKS document whose keys can be swapped to simulate rotation
+type testJWKSServer struct {
+	*httptest.Server
+	keys     atomic.Value
+	requests int32
+}
+
+func newTestJWKSServer(t *testing.T, keys ...map[string]string) *testJWKSServer {
+	t.Helper()
+
+	server := &testJWKSServer{}
+	server.setKeys(keys...)
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&server.requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": server.keys.Load()})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *testJWKSServer) setKeys(keys ...map[string]string) {
+	s.keys.Store(keys)
+}
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "RSA",
+		"alg": "RS256",
+		"use": "sig",
+		"n":   encodeBigInt(key.N),
+		"e":   encodeBigInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "EC",
+		"alg": "ES256",
+		"use": "sig",
+		"crv": "P-256",
+		"x":   encodeBigInt(key.X),
+		"y":   encodeBigInt(key.Y),
+	}
+}
+
+func signWithKey(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims *SupabaseClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return signed
+}
+
+func newJWKSValidator(t *testing.T, server *testJWKSServer, cfg TokenValidatorConfig) *TokenValidator {
+	t.Helper()
+
+	logger := infraLogger.NewLogger("debug")
+	keySet := auth.NewJWKSKeySet(server.URL, "", 0, logger)
+	if err := keySet.Refresh(context.Background()); err != nil {
+		t.Fatalf("failed to load JWKS: %v", err)
+	}
+	cfg.Keys = keySet
+
+	return NewTokenValidator(cfg, logger)
+}
+
+func validClaims(subject string) *SupabaseClaims {
+	now := time.Now()
+	return &SupabaseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    "https://example.supabase.co/auth/v1",
+			Audience:  jwt.ClaimStrings{"authenticated"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+}
+
+func TestValidateSupabaseTokenSelectsJWKSKeyByKid(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	server := newTestJWKSServer(t, rsaJWK("rsa-key", &rsaKey.PublicKey), ecJWK("ec-key", &ecKey.PublicKey))
+	validator := newJWKSValidator(t, server, TokenValidatorConfig{})
+
+	rsaToken := signWithKey(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, validClaims("user-rs256"))
+	if user, err := validator.Validate(context.Background(), rsaToken); err != nil || user.ID != "user-rs256" {
+		t.Fatalf("expected RS256 token to be valid, got user %+v, error: %v", user, err)
+	}
+
+	ecToken := signWithKey(t, jwt.SigningMethodES256, "ec-key", ecKey, validClaims("user-es256"))
+	if user, err := validator.Validate(context.Background(), ecToken); err != nil || user.ID != "user-es256" {
+		t.Fatalf("expected ES256 token to be valid, got user %+v, error: %v", user, err)
+	}
+
+	// A token whose kid points at a different key must not verify
+	mismatchedToken := signWithKey(t, jwt.SigningMethodRS256, "ec-key", rsaKey, validClaims("user-mismatch"))
+	if _, err := validator.Validate(context.Background(), mismatchedToken); err == nil {
+		t.Fatal("expected token signed with a different key than its kid to be rejected")
+	}
+}
+
+func TestValidateSupabaseTokenRefreshesJWKSOnUnknownKid(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	server := newTestJWKSServer(t, rsaJWK("old-key", &oldKey.PublicKey))
+	logger := infraLogger.NewLogger("debug")
+	keySet := auth.NewJWKSKeySet(server.URL, "", 0, logger)
+	validator := NewTokenValidator(TokenValidatorConfig{Keys: keySet}, logger)
+
+	// The first token loads the key set on demand
+	oldToken := signWithKey(t, jwt.SigningMethodRS256, "old-key", oldKey, validClaims("user-old-key"))
+	if _, err := validator.Validate(context.Background(), oldToken); err != nil {
+		t.Fatalf("expected token to be valid, got error: %v", err)
+	}
+
+	// A freshly rotated key is not fetched again until the throttle window passes
+	server.setKeys(rsaJWK("old-key", &oldKey.PublicKey), rsaJWK("new-key", &newKey.PublicKey))
+	newToken := signWithKey(t, jwt.SigningMethodRS256, "new-key", newKey, validClaims("user-new-key"))
+	if _, err := validator.Validate(context.Background(), newToken); err == nil {
+		t.Fatal("expected unknown kid to be rejected within the refresh throttle window")
+	}
+	if requests := atomic.LoadInt32(&server.requests); requests != 1 {
+		t.Fatalf("expected a single JWKS request, got %d", requests)
+	}
+
+	if err := keySet.Refresh(context.Background()); err != nil {
+		t.Fatalf("failed to refresh JWKS: %v", err)
+	}
+	if _, err := validator.Validate(context.Background(), newToken); err != nil {
+		t.Fatalf("expected rotated key to be accepted after refresh, got error: %v", err)
+	}
+}
+
+func TestValidateSupabaseTokenRejectsHMACTokenSignedWithPublicKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	server := newTestJWKSServer(t, rsaJWK("rsa-key", &rsaKey.PublicKey))
+	validator := newJWKSValidator(t, server, TokenValidatorConfig{})
+
+	// Algorithm confusion: HS256 keyed with the published RSA modulus
+	token := signWithKey(t, jwt.SigningMethodHS256, "rsa-key", rsaKey.PublicKey.N.Bytes(), validClaims("user-confused"))
+	if _, err := validator.Validate(context.Background(), token); err == nil {
+		t.Fatal("expected HS256 token to be rejected when only asymmetric keys are configured")
+	}
+}
+
+func TestValidateSupabaseTokenChecksIssuerAndAudience(t *testing.T) {
+	validator := newTestTokenValidator(TokenValidatorConfig{
+		HMACSecret: testJWTSecret,
+		Issuer:     "https://example.supabase.co/auth/v1",
+		Audience:   "authenticated",
+	})
+
+	if _, err := validator.Validate(context.Background(), signSupabaseToken(t, validClaims("user-valid"))); err != nil {
+		t.Fatalf("expected token with matching issuer and audience to be valid, got error: %v", err)
+	}
+
+	wrongIssuer := validClaims("user-wrong-issuer")
+	wrongIssuer.Issuer = "https://other.supabase.co/auth/v1"
+	if _, err := validator.Validate(context.Background(), signSupabaseToken(t, wrongIssuer)); err == nil {
+		t.Fatal("expected token with unexpected issuer to be rejected")
+	}
+
+	wrongAudience := validClaims("user-wrong-audience")
+	wrongAudience.Audience = jwt.ClaimStrings{"anon"}
+	if _, err := validator.Validate(context.Background(), signSupabaseToken(t, wrongAudience)); err == nil {
+		t.Fatal("expected token with unexpected audience to be rejected")
+	}
+}
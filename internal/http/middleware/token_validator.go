@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyProvider resolves asymmetric verification keys by key ID (e.g. a cached JWKS)
+type KeyProvider interface {
+	// Key returns the verification key for the given kid
+	Key(ctx context.Context, kid string) (interface{}, error)
+}
+
+// TokenValidatorConfig holds the settings used to validate Supabase JWTs
+type TokenValidatorConfig struct {
+	HMACSecret string      // Legacy shared secret for HS256 tokens (optional)
+	Keys       KeyProvider // Key set for RS256/ES256 tokens (optional)
+	Issuer     string      // Expected iss claim, not checked when empty
+	Audience   string      // Expected aud claim, not checked when empty
+}
+
+// TokenValidator validates Supabase JWTs signed with either the shared secret or a JWKS key
+type TokenValidator struct {
+	hmacSecret []byte
+	keys       KeyProvider
+	issuer     string
+	audience   string
+	logger     *logger.Logger
+}
+
+// NewTokenValidator creates a new TokenValidator instance
+func NewTokenValidator(cfg TokenValidatorConfig, logger *logger.Logger) *TokenValidator {
+	return &TokenValidator{
+		hmacSecret: []byte(cfg.HMACSecret),
+		keys:       cfg.Keys,
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		logger:     logger,
+	}
+}
+
+// Validate parses and validates a Supabase JWT token
+func (v *TokenValidator) Validate(ctx context.Context, tokenString string) (*SupabaseUser, error) {
+	v.logger.Logger.WithFields(map[string]interface{}{
+		"token_length": len(tokenString),
+		"token_prefix": tokenString[:min(20, len(tokenString))],
+	}).Debug("Validating JWT token")
+
+	options := []jwt.ParserOption{
+		jwt.WithLeeway(supabaseTokenTimeLeeway),
+		jwt.WithValidMethods([]string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+	}
+	if v.issuer != "" {
+		options = append(options, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		options = append(options, jwt.WithAudience(v.audience))
+	}
+	parser := jwt.NewParser(options...)
+
+	token, err := parser.ParseWithClaims(tokenString, &SupabaseClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return v.verificationKey(ctx, token)
+	})
+
+	if err != nil {
+		v.logger.Logger.WithError(err).Error("Failed to parse JWT token")
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if !token.Valid {
+		v.logger.Logger.Error("JWT token is invalid")
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	// Extract claims
+	claims, ok := token.Claims.(*SupabaseClaims)
+	if !ok {
+		v.logger.Logger.Error("Failed to parse token claims")
+		return nil, fmt.Errorf("failed to parse token claims")
+	}
+
+	v.logger.Logger.WithField("subject", claims.Subject).Debug("Successfully parsed JWT claims")
+
+	now := time.Now()
+
+	// Check if token is expired using time comparison
+	if claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(now) {
+		v.logger.Logger.WithField("exp_time", claims.ExpiresAt.Time).Error("JWT token has expired")
+		return nil, fmt.Errorf("token is expired")
+	}
+
+	// Check if token is issued in the future
+	if claims.IssuedAt != nil && claims.IssuedAt.Time.After(now.Add(supabaseTokenTimeLeeway)) {
+		v.logger.Logger.WithField("issued_at", claims.IssuedAt.Time).Error("JWT token used before issued")
+		return nil, fmt.Errorf("token used before issued")
+	}
+
+	// Check if token is not valid yet
+	if claims.NotBefore != nil && claims.NotBefore.Time.After(now.Add(supabaseTokenTimeLeeway)) {
+		v.logger.Logger.WithField("not_before", claims.NotBefore.Time).Error("JWT token used before valid")
+		return nil, fmt.Errorf("token used before valid")
+	}
+
+	// Create user from claims
+	user := &SupabaseUser{
+		ID:    claims.Subject,
+		Email: claims.Email,
+		Roles: claims.Roles,
+	}
+
+	// Set default roles if not present
+	if len(user.Roles) == 0 {
+		user.Roles = []string{"authenticated"}
+		v.logger.Logger.Debug("No roles claim found, using default 'authenticated'")
+	}
+
+	v.logger.Logger.WithFields(map[string]interface{}{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"roles":   user.Roles,
+	}).Info("Successfully validated JWT token")
+
+	return user, nil
+}
+
+// verificationKey selects the key matching the token's signing method and kid
+func (v *TokenValidator) verificationKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(v.hmacSecret) > 0 {
+			// Use raw JWT secret as bytes (no base64 decoding)
+			return v.hmacSecret, nil
+		}
+		if v.keys == nil {
+			return nil, fmt.Errorf("no HMAC secret configured")
+		}
+		key, err := v.keys.Key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := key.([]byte); !ok {
+			return nil, fmt.Errorf("key %q is not a symmetric key", kid)
+		}
+		return key, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if v.keys == nil {
+			return nil, fmt.Errorf("no JWKS configured for %v tokens", token.Header["alg"])
+		}
+		// golang-jwt rejects keys whose type does not match the signing method
+		return v.keys.Key(ctx, kid)
+	default:
+		v.logger.Logger.WithField("signing_method", token.Header["alg"]).Error("Unexpected signing method")
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+}
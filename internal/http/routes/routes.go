@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"dental-scheduler-backend/internal/domain/entities"
 	"dental-scheduler-backend/internal/domain/ports/repositories"
 	"dental-scheduler-backend/internal/http/handlers"
 	"dental-scheduler-backend/internal/http/middleware"
@@ -17,9 +18,31 @@ func SetupRoutes(
 	unitHandler *handlers.UnitHandler,
 	doctorHandler *handlers.DoctorHandler,
 	patientHandler *handlers.PatientHandler,
+	patientMergeHandler *handlers.PatientMergeHandler,
+	dentalChartHandler *handlers.DentalChartHandler,
+	clinicalNoteHandler *handlers.ClinicalNoteHandler,
+	treatmentPlanHandler *handlers.TreatmentPlanHandler,
+	attachmentHandler *handlers.AttachmentHandler,
+	consentHandler *handlers.ConsentHandler,
+	medicalAlertHandler *handlers.MedicalAlertHandler,
+	patientRelationshipHandler *handlers.PatientRelationshipHandler,
+	invoiceHandler *handlers.InvoiceHandler,
+	cfdiHandler *handlers.CFDIHandler,
+	insuranceHandler *handlers.InsuranceHandler,
+	cancellationHandler *handlers.CancellationHandler,
+	depositHandler *handlers.DepositHandler,
+	recallHandler *handlers.RecallHandler,
+	resourceHandler *handlers.ResourceHandler,
 	appointmentHandler *handlers.AppointmentHandler,
 	organizationHandler *handlers.OrganizationHandler,
+	organizationSettingsHandler *handlers.OrganizationSettingsHandler,
 	doctorAvailabilityHandler *handlers.DoctorAvailabilityHandler,
+	staffHandler *handlers.StaffHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	webhookHandler *handlers.WebhookHandler,
+	idempotency gin.HandlerFunc,
+	tokenValidator *middleware.TokenValidator,
+	apiKeyAuthenticator middleware.APIKeyAuthenticator,
 	userRepo repositories.UserRepository,
 	logger *logger.Logger,
 ) {
@@ -29,22 +52,23 @@ func SetupRoutes(
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
-		// Protected routes (authentication required)
+		// Protected routes (Supabase session or organization API key required)
 		protected := v1.Group("/")
-		protected.Use(middleware.SupabaseAuth(logger, userRepo))
+		protected.Use(middleware.APIKeyAuth(logger, apiKeyAuthenticator, middleware.SupabaseAuth(logger, tokenValidator, userRepo)))
 		{
 			// Clinic routes
-			clinics := protected.Group("/clinics")
+			clinics := protected.Group("/clinics", middleware.RequireAPIKeyScope(logger, entities.APIKeyScopeClinics))
 			{
 				clinics.POST("", clinicHandler.CreateClinic)
 				clinics.GET("", clinicHandler.GetClinics)
 				clinics.GET("/:id", clinicHandler.GetClinic)
 				clinics.PUT("/:id", clinicHandler.UpdateClinic)
 				clinics.DELETE("/:id", clinicHandler.DeleteClinic)
+				clinics.GET("/:id/cash-close", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist), invoiceHandler.GetCashClose) // Payments by method over ?date= in the clinic's timezone
 			}
 
 			// Unit routes
-			units := protected.Group("/units")
+			units := protected.Group("/units", middleware.RequireAPIKeyScope(logger, entities.APIKeyScopeUnits))
 			{
 				units.POST("", unitHandler.CreateUnit)
 				units.GET("", unitHandler.GetUnits) // Supports ?clinic_id=uuid query param
@@ -54,7 +78,7 @@ func SetupRoutes(
 			}
 
 			// Doctor routes
-			doctors := protected.Group("/doctors")
+			doctors := protected.Group("/doctors", middleware.RequireAPIKeyScope(logger, entities.APIKeyScopeDoctors))
 			{
 				doctors.POST("", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
 				doctors.GET("", doctorHandler.GetDoctorsByOrganization) // Implemented: GET /doctors?orgId=...&clinicId=...
@@ -65,35 +89,276 @@ func SetupRoutes(
 			}
 
 			// Patient routes
-			patients := protected.Group("/patients")
+			patients := protected.Group("/patients", middleware.RequireAPIKeyScope(logger, entities.APIKeyScopePatients))
 			{
-				patients.GET("/search", patientHandler.SearchPatients) // Patient search for autocomplete
-				patients.POST("", patientHandler.CreatePatient)        // Create patient and link to organization from auth context
-				patients.PATCH("/:id", patientHandler.UpdatePatient)   // Update patient
-				patients.GET("", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
-				patients.GET("/:id", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
-				patients.PUT("/:id", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
-				patients.DELETE("/:id", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
+				patients.GET("/search", patientHandler.SearchPatients)                    // Patient search for autocomplete
+				patients.POST("", idempotency, patientHandler.CreatePatient)              // Create patient and link to organization from auth context
+				patients.PATCH("/:id", patientHandler.UpdatePatient)                      // Update patient
+				patients.GET("/duplicates", patientMergeHandler.ListDuplicates)           // Scored duplicate pairs in the organization
+				patients.GET("/:id/duplicates", patientMergeHandler.GetPatientDuplicates) // Scored duplicates of one patient
+				patients.GET("/merges", patientMergeHandler.ListMerges)                   // Merge audit trail
+				patients.GET("/merges/:merge_id", patientMergeHandler.GetMerge)           // Merge record with the merged patient snapshot
+				// Merging deletes the duplicate, so it needs a staff session (API keys are rejected: no user profile)
+				patients.POST("/:id/merge", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist), idempotency, patientMergeHandler.MergePatients)
+				patients.GET("", patientHandler.ListPatients)      // Filtered, sorted and paginated patient listing
+				patients.GET("/:id", patientHandler.GetPatient)    // Patient with appointment history and stats
+				patients.PUT("/:id", patientHandler.UpdatePatient) // Same partial update as PATCH
+				// Archiving is recorded against the staff member, so it needs a staff session like merging
+				patients.DELETE("/:id", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist), patientHandler.DeletePatient)
+				patients.POST("/:id/restore", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist), patientHandler.RestorePatient)
+				// The odontogram is clinical data, only visible to staff and never to API keys
+				patients.GET("/:id/chart", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), dentalChartHandler.GetChart)            // Odontogram as of ?as_of=
+				patients.GET("/:id/chart/entries", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), dentalChartHandler.ListEntries) // Charting history
+				// Charting is clinical work recorded against the staff member
+				patients.POST("/:id/chart/entries", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor), idempotency, dentalChartHandler.RecordEntries)
+				// Clinical notes are only visible to clinical staff, never to API keys
+				patients.GET("/:id/clinical-notes", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor), clinicalNoteHandler.GetPatientTimeline)
+				patients.GET("/:id/treatment-plans", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), treatmentPlanHandler.ListPatientPlans)
+				// Patient files are staff only; uploads skip idempotency, which would buffer the whole body
+				patients.POST("/:id/attachments", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), attachmentHandler.UploadAttachment)
+				patients.GET("/:id/attachments", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), attachmentHandler.ListPatientAttachments)
+				// Consent requests are sent by staff; the patient signs through the public link
+				patients.POST("/:id/consent-forms", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), idempotency, consentHandler.RequestConsent)
+				patients.GET("/:id/consent-forms", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), consentHandler.ListPatientConsentForms)
+				patients.GET("/:id/medical-alerts", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), medicalAlertHandler.ListPatientAlerts)
+				patients.POST("/:id/medical-alerts", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), idempotency, medicalAlertHandler.CreateAlert)
+				// Family links expose guardian contacts and household appointments, so they are staff only and
+				// recorded against the staff member
+				patients.GET("/:id/relationships", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), patientRelationshipHandler.ListRelationships) // Relatives and who is notified for the patient
+				patients.GET("/:id/family", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), patientRelationshipHandler.GetFamily)                // Household members with their upcoming appointments
+				patients.POST("/:id/relationships", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), idempotency, patientRelationshipHandler.CreateRelationship)
+				patients.DELETE("/:id/relationships/:related_id", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), patientRelationshipHandler.DeleteRelationship)
+				patients.PUT("/:id/contact-person", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), patientRelationshipHandler.SetContactPerson)
+				patients.GET("/:id/balance", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), invoiceHandler.GetPatientBalance)
+				patients.GET("/:id/fiscal-data", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), cfdiHandler.GetPatientFiscalData) // RFC, regime and CFDI use for electronic invoices
+				patients.PUT("/:id/fiscal-data", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist), cfdiHandler.SetPatientFiscalData)
+				patients.DELETE("/:id/fiscal-data", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist), cfdiHandler.DeletePatientFiscalData)
+				patients.GET("/:id/insurance-policies", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), insuranceHandler.ListPatientPolicies)
+				patients.POST("/:id/insurance-policies", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist), idempotency, insuranceHandler.CreatePolicy)
+				patients.GET("/:id/cancellation-fees", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), cancellationHandler.ListPatientFees)
+				patients.GET("/:id/recalls", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), recallHandler.ListPatientRecalls) // ?status= filters; all statuses by default
+			}
+
+			// Treatment plan routes (staff only; dentists build the plan, the front desk records the decision and books visits)
+			plans := protected.Group("/treatment-plans")
+			plans.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				clinical := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor)
+				plans.POST("", clinical, idempotency, treatmentPlanHandler.CreatePlan)
+				plans.GET("/:id", treatmentPlanHandler.GetPlan)
+				plans.PATCH("/:id", clinical, treatmentPlanHandler.UpdatePlan)
+				plans.POST("/:id/items", clinical, treatmentPlanHandler.AddItem)
+				plans.PATCH("/:id/items/:item_id", clinical, treatmentPlanHandler.UpdateItem)
+				plans.DELETE("/:id/items/:item_id", clinical, treatmentPlanHandler.RemoveItem)
+				plans.POST("/:id/present", clinical, treatmentPlanHandler.PresentPlan)
+				plans.POST("/:id/decision", treatmentPlanHandler.RecordDecision) // Patient acceptance of some or all procedures
+				plans.POST("/:id/cancel", clinical, treatmentPlanHandler.CancelPlan)
+				plans.POST("/:id/items/:item_id/appointments", idempotency, treatmentPlanHandler.ScheduleItem) // Book an accepted procedure
+			}
+
+			// Attachment routes (staff only; deleting patient files is reserved to admins)
+			attachments := protected.Group("/attachments")
+			attachments.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				attachments.GET("/:id", attachmentHandler.GetAttachment)
+				attachments.PATCH("/:id", attachmentHandler.UpdateAttachment)
+				attachments.DELETE("/:id", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), attachmentHandler.DeleteAttachment)
+				attachments.GET("/:id/download", attachmentHandler.DownloadAttachment) // Redirect to a pre-signed URL, or ?stream=true
+				attachments.GET("/:id/thumbnail", attachmentHandler.DownloadThumbnail) // JPEG preview of images
+			}
+
+			// Consent form routes (staff only; signed forms are immutable and can only be revoked)
+			consentForms := protected.Group("/consent-forms")
+			consentForms.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				consentForms.GET("/:id", consentHandler.GetConsentForm)
+				consentForms.POST("/:id/link", consentHandler.RenewSigningLink) // Extend the signing link of a pending form
+				consentForms.POST("/:id/revoke", consentHandler.RevokeConsentForm)
+				consentForms.GET("/:id/document", consentHandler.DownloadDocument) // Signed copy, ?inline=true to display it
+			}
+
+			// Medical alert routes (staff only; every change is audited, resolving requires a clinical role)
+			medicalAlerts := protected.Group("/medical-alerts")
+			medicalAlerts.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				clinical := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor)
+				medicalAlerts.GET("/:id", medicalAlertHandler.GetAlert)
+				medicalAlerts.GET("/:id/history", medicalAlertHandler.GetAlertHistory)
+				medicalAlerts.POST("/:id/review", medicalAlertHandler.ReviewAlert) // Confirmed with the patient; sets the next review date
+				medicalAlerts.PATCH("/:id", clinical, medicalAlertHandler.UpdateAlert)
+				medicalAlerts.POST("/:id/resolve", clinical, medicalAlertHandler.ResolveAlert)
+				medicalAlerts.POST("/:id/reactivate", clinical, medicalAlertHandler.ReactivateAlert)
+			}
+
+			// Invoice routes (staff only; the front desk bills and takes payments, voiding is reserved to admins)
+			invoices := protected.Group("/invoices")
+			invoices.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				billing := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist)
+				invoices.POST("", billing, idempotency, invoiceHandler.CreateInvoice) // Bill a completed appointment
+				invoices.GET("", invoiceHandler.ListInvoices)
+				invoices.GET("/:id", invoiceHandler.GetInvoice) // Invoice with its payments ledger
+				invoices.POST("/:id/payments", billing, idempotency, invoiceHandler.RecordPayment)
+				invoices.POST("/:id/payments/:payment_id/refund", billing, idempotency, invoiceHandler.RefundPayment)
+				invoices.POST("/:id/void", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), invoiceHandler.VoidInvoice)
+
+				invoices.POST("/:id/cfdis", billing, idempotency, cfdiHandler.StampInvoice) // Stamp the invoice as a CFDI through the PAC
+				invoices.GET("/:id/cfdis", cfdiHandler.ListCFDIs)
+				invoices.GET("/:id/cfdis/:cfdi_id/xml", cfdiHandler.DownloadCFDIXML)
+				invoices.POST("/:id/cfdis/:cfdi_id/cancel", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), cfdiHandler.CancelCFDI)
+			}
+
+			// Insurance routes (staff only; the front desk manages carriers, policies and claims)
+			insuranceCarriers := protected.Group("/insurance-carriers")
+			insuranceCarriers.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				billing := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist)
+				insuranceCarriers.GET("", insuranceHandler.ListCarriers) // Active carriers; ?include_inactive=true for all
+				insuranceCarriers.POST("", billing, idempotency, insuranceHandler.CreateCarrier)
+				insuranceCarriers.PUT("/:id", billing, insuranceHandler.UpdateCarrier)
+			}
+
+			insurancePolicies := protected.Group("/insurance-policies")
+			insurancePolicies.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				billing := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist)
+				insurancePolicies.GET("/:id", insuranceHandler.GetPolicy)
+				insurancePolicies.PUT("/:id", billing, insuranceHandler.UpdatePolicy)
+				insurancePolicies.DELETE("/:id", billing, insuranceHandler.DeletePolicy)   // Only policies without claims
+				insurancePolicies.POST("/:id/estimate", insuranceHandler.EstimateCoverage) // Patient vs insurer portion of services or a treatment plan
+			}
+
+			insuranceClaims := protected.Group("/insurance-claims")
+			insuranceClaims.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				billing := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist)
+				insuranceClaims.POST("", billing, idempotency, insuranceHandler.CreateClaim) // Claim a completed appointment under a policy
+				insuranceClaims.GET("", insuranceHandler.ListClaims)
+				insuranceClaims.GET("/:id", insuranceHandler.GetClaim)
+				insuranceClaims.POST("/:id/approve", billing, insuranceHandler.ApproveClaim)
+				insuranceClaims.POST("/:id/reject", billing, insuranceHandler.RejectClaim)
+				insuranceClaims.POST("/:id/pay", billing, insuranceHandler.PayClaim)
+				insuranceClaims.POST("/:id/resubmit", billing, insuranceHandler.ResubmitClaim)
+			}
+
+			// Cancellation routes (staff only; admins manage the reasons, the front desk settles late-cancellation fees)
+			cancellationReasons := protected.Group("/cancellation-reasons")
+			cancellationReasons.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				manage := middleware.RequireOrganizationRole(logger, entities.RoleAdmin)
+				cancellationReasons.GET("", cancellationHandler.ListReasons) // Active reasons; ?include_inactive=true for all
+				cancellationReasons.POST("", manage, idempotency, cancellationHandler.CreateReason)
+				cancellationReasons.PUT("/:id", manage, cancellationHandler.UpdateReason)
+			}
+
+			cancellationFees := protected.Group("/cancellation-fees")
+			cancellationFees.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				billing := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist)
+				cancellationFees.GET("", cancellationHandler.ListFees)
+				cancellationFees.GET("/:id", cancellationHandler.GetFee)
+				cancellationFees.POST("/:id/override", billing, cancellationHandler.OverrideFee)            // Lower or waive a pending fee with a justification
+				cancellationFees.POST("/:id/invoice", billing, idempotency, cancellationHandler.InvoiceFee) // Bill a pending fee on an invoice of its own
+			}
+
+			// Deposit routes (appointment deposits and the payments against them)
+			deposits := protected.Group("/deposits")
+			deposits.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				billing := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist)
+				deposits.GET("", depositHandler.ListDeposits)
+				deposits.GET("/:id", depositHandler.GetDeposit)
+				deposits.POST("/:id/waive", billing, depositHandler.WaiveDeposit) // Keep the appointment without its deposit
+			}
+
+			// Recall routes (staff only; admins set the interval per service, the front desk works the list)
+			recallRules := protected.Group("/recall-rules")
+			recallRules.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				manage := middleware.RequireOrganizationRole(logger, entities.RoleAdmin)
+				recallRules.GET("", recallHandler.ListRules) // Active rules; ?include_inactive=true for all
+				recallRules.POST("", manage, idempotency, recallHandler.CreateRule)
+				recallRules.PUT("/:id", manage, recallHandler.UpdateRule)
+			}
+
+			recalls := protected.Group("/recalls")
+			recalls.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				recalls.GET("", recallHandler.ListRecalls) // Open recalls soonest due first; ?due_from=&due_to=&outreach_status=&clinic_id=
+				recalls.GET("/:id", recallHandler.GetRecall)
+				recalls.POST("/:id/outreach", recallHandler.RecordOutreach) // Log a call, message or visit and its outcome
+				recalls.POST("/:id/dismiss", recallHandler.DismissRecall)   // Close a recall that will not be followed up
+			}
+
+			// Equipment routes (staff only; admins manage each clinic's equipment)
+			equipment := protected.Group("/equipment")
+			equipment.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				manage := middleware.RequireOrganizationRole(logger, entities.RoleAdmin)
+				equipment.GET("", resourceHandler.ListEquipment) // ?clinic_id= required; ?include_inactive=true for all
+				equipment.GET("/:id", resourceHandler.GetEquipment)
+				equipment.POST("", manage, idempotency, resourceHandler.CreateEquipment)
+				equipment.PUT("/:id", manage, resourceHandler.UpdateEquipment) // is_active=false keeps existing bookings
+			}
+
+			// Calendar per staff member, unit and piece of equipment of a clinic (staff only)
+			protected.GET("/calendar/resources", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), resourceHandler.GetResourceCalendar) // ?clinic_id=&start_date=&end_date=, at most 31 days; ?resource_type=&resource_id= for one lane
+
+			// Consent template routes (managed by admins)
+			consentTemplates := protected.Group("/consent-templates")
+			consentTemplates.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist))
+			{
+				consentTemplates.GET("", consentHandler.ListTemplates)
+				consentTemplates.POST("", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), consentHandler.CreateTemplate)
+				consentTemplates.PUT("/:id", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), consentHandler.UpdateTemplate)
+				consentTemplates.DELETE("/:id", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), consentHandler.DeleteTemplate)
+			}
+
+			// Clinical note routes (clinical roles only; writing also requires being the linked treating doctor)
+			clinicalNotes := protected.Group("/clinical-notes")
+			clinicalNotes.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor))
+			{
+				clinicalNotes.POST("", idempotency, clinicalNoteHandler.CreateNote)               // Start the note of an appointment from its template
+				clinicalNotes.GET("", clinicalNoteHandler.GetNoteByAppointment)                   // Note of ?appointment_id=
+				clinicalNotes.GET("/:id", clinicalNoteHandler.GetNote)                            // Note with amendments
+				clinicalNotes.PATCH("/:id", clinicalNoteHandler.UpdateNote)                       // Edit a draft (author only)
+				clinicalNotes.POST("/:id/sign", clinicalNoteHandler.SignNote)                     // Sign; the note becomes immutable
+				clinicalNotes.POST("/:id/amendments", idempotency, clinicalNoteHandler.AmendNote) // Addendum to a signed note
+			}
+
+			// Clinical note template routes
+			noteTemplates := protected.Group("/clinical-note-templates")
+			noteTemplates.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor))
+			{
+				noteTemplates.GET("", clinicalNoteHandler.ListTemplates)
+				noteTemplates.POST("", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), clinicalNoteHandler.CreateTemplate)
+				noteTemplates.PUT("/:id", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), clinicalNoteHandler.UpdateTemplate)
+				noteTemplates.DELETE("/:id", middleware.RequireOrganizationRole(logger, entities.RoleAdmin), clinicalNoteHandler.DeleteTemplate)
 			}
 
 			// Appointment routes
-			appointments := protected.Group("/appointments")
-			{
-				appointments.GET("/rescheduling-queue", appointmentHandler.GetReschedulingQueue)         // Get rescheduling queue
-				appointments.POST("", appointmentHandler.CreateAppointment)                              // This needs to be implemented for conflict detection
-				appointments.GET("", appointmentHandler.GetAppointments)                                 // Get appointments by organization with filters
-				appointments.PATCH("/:appointment_id", appointmentHandler.UpdateAppointment)             // Update appointment
-				appointments.POST("/:appointment_id/cancel", appointmentHandler.CancelFromQueue)         // Cancel from queue
-				appointments.POST("/:appointment_id/reschedule", appointmentHandler.RescheduleFromQueue) // Reschedule from queue
-				appointments.POST("/:appointment_id/snooze", appointmentHandler.SnoozeFromQueue)         // Snooze from queue
+			appointments := protected.Group("/appointments", middleware.RequireAPIKeyScope(logger, entities.APIKeyScopeAppointments))
+			{
+				billing := middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleReceptionist)
+				appointments.GET("/rescheduling-queue", appointmentHandler.GetReschedulingQueue)                      // Get rescheduling queue
+				appointments.POST("", idempotency, appointmentHandler.CreateAppointment)                              // This needs to be implemented for conflict detection
+				appointments.GET("", appointmentHandler.GetAppointments)                                              // Get appointments by organization with filters
+				appointments.PATCH("/:appointment_id", appointmentHandler.UpdateAppointment)                          // Update appointment
+				appointments.POST("/:appointment_id/cancel", idempotency, appointmentHandler.CancelFromQueue)         // Cancel from queue
+				appointments.POST("/:appointment_id/reschedule", idempotency, appointmentHandler.RescheduleFromQueue) // Reschedule from queue
+				appointments.POST("/:appointment_id/snooze", idempotency, appointmentHandler.SnoozeFromQueue)         // Snooze from queue
+				appointments.POST("/:appointment_id/deposit", billing, idempotency, depositHandler.RequestDeposit)    // Ask for a deposit on a booked appointment
 				appointments.GET("/upcoming", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
 				appointments.GET("/:id", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
+				appointments.GET("/:id/attachments", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), attachmentHandler.ListAppointmentAttachments)
+				appointments.GET("/:id/consents", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), consentHandler.GetAppointmentConsents) // Required consents and whether each is signed; staff only like /consent-forms
+				appointments.GET("/:id/deposit", middleware.RequireOrganizationRole(logger, entities.RoleAdmin, entities.RoleDoctor, entities.RoleReceptionist), depositHandler.GetAppointmentDeposit)   // Active deposit of an appointment; staff only like /deposits
 				appointments.PUT("/:id", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
 				appointments.DELETE("/:id", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
 			}
 
 			// Doctor availability routes
-			availability := protected.Group("/doctor-availability")
+			availability := protected.Group("/doctor-availability", middleware.RequireAPIKeyScope(logger, entities.APIKeyScopeDoctorAvailability))
 			{
 				availability.POST("", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
 				availability.GET("", func(c *gin.Context) { c.JSON(501, gin.H{"error": "Not implemented"}) })
@@ -103,12 +368,63 @@ func SetupRoutes(
 			}
 
 			// Organization data route for calendar loading
-			protected.GET("/organization", organizationHandler.GetOrganizationData)
+			protected.GET("/organization", middleware.RequireAPIKeyScope(logger, entities.APIKeyScopeOrganization), organizationHandler.GetOrganizationData)
+
+			// Invitation acceptance (the invited user may not belong to an organization yet)
+			protected.POST("/invitations/accept", staffHandler.AcceptInvitation)
+
+			// Organization administration routes (API keys are rejected: no user profile)
+			admin := protected.Group("/admin")
+			admin.Use(middleware.RequireOrganizationRole(logger, entities.RoleAdmin))
+			{
+				admin.POST("/invitations", staffHandler.InviteUser)
+				admin.GET("/invitations", staffHandler.ListInvitations)
+				admin.DELETE("/invitations/:id", staffHandler.RevokeInvitation)
+				admin.GET("/settings", organizationSettingsHandler.GetSettings)
+				admin.PATCH("/settings", organizationSettingsHandler.UpdateSettings)
+				admin.GET("/fiscal-profile", cfdiHandler.GetIssuerProfile) // Issuer data of the organization's CFDI
+				admin.PUT("/fiscal-profile", cfdiHandler.SetIssuerProfile)
+				admin.PUT("/services/:id/category", insuranceHandler.SetServiceCategory) // Insurance category the service is covered under
+				admin.GET("/cancellation-policy", cancellationHandler.GetPolicy)
+				admin.PUT("/cancellation-policy", cancellationHandler.UpdatePolicy)
+				admin.GET("/deposit-policy", depositHandler.GetPolicy)
+				admin.PUT("/deposit-policy", depositHandler.UpdatePolicy)
+				admin.PUT("/services/:id/deposit", depositHandler.SetServiceDeposit) // Deposit required to book the service
+
+				admin.GET("/users", staffHandler.ListMembers)
+				admin.PUT("/users/:id/roles", staffHandler.UpdateMemberRoles)
+				admin.PUT("/users/:id/doctor", staffHandler.LinkMemberToDoctor)
+				admin.POST("/users/:id/deactivate", staffHandler.DeactivateMember)
+				admin.POST("/users/:id/activate", staffHandler.ReactivateMember)
+
+				admin.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+				admin.GET("/api-keys", apiKeyHandler.ListAPIKeys)
+				admin.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+
+				admin.POST("/webhooks", webhookHandler.CreateSubscription)
+				admin.GET("/webhooks", webhookHandler.ListSubscriptions)
+				admin.GET("/webhooks/:id", webhookHandler.GetSubscription)
+				admin.PUT("/webhooks/:id", webhookHandler.UpdateSubscription)
+				admin.DELETE("/webhooks/:id", webhookHandler.DeleteSubscription)
+				admin.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
+				admin.GET("/webhook-deliveries/:id", webhookHandler.GetDelivery)
+				admin.POST("/webhook-deliveries/:id/replay", webhookHandler.ReplayDelivery)
+			}
+		}
+
+		// Payment gateway notifications (authenticated by their signature)
+		v1.POST("/public/payments/webhook", depositHandler.PaymentWebhook)
+
+		// Public consent signing (the signed link in the path is the only credential)
+		publicConsents := v1.Group("/public/consent-forms")
+		{
+			publicConsents.GET("/:token", consentHandler.GetSigningForm)
+			publicConsents.POST("/:token/sign", consentHandler.SignConsentForm)
 		}
 
 		// Optional authentication routes (user info is available if authenticated)
 		optionalAuth := v1.Group("/")
-		optionalAuth.Use(middleware.OptionalAuth(logger))
+		optionalAuth.Use(middleware.OptionalAuth(logger, tokenValidator))
 		{
 			// Add routes here that benefit from user context but don't require authentication
 		}
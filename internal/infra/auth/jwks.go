@@ -0,0 +1,292 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// ErrKeyNotFound is returned when no key in the set matches the requested kid
+var ErrKeyNotFound = errors.New("signing key not found in JWKS")
+
+// minRefreshInterval throttles on-demand refreshes triggered by unknown kids
+const minRefreshInterval = 30 * time.Second
+
+// jsonWebKey is a single entry of a JSON Web Key Set (RFC 7517)
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// Symmetric
+	K string `json:"k"`
+}
+
+// JWKSKeySet caches the verification keys of a JWKS document loaded from a URL or a local file
+type JWKSKeySet struct {
+	url             string
+	file            string
+	refreshInterval time.Duration
+	client          *http.Client
+	logger          *logger.Logger
+
+	// refreshMu serializes refreshes so concurrent misses share a single fetch
+	refreshMu   sync.Mutex
+	mu          sync.RWMutex
+	keys        map[string]interface{}
+	lastAttempt time.Time
+}
+
+// NewJWKSKeySet creates a key set; url takes precedence over file when both are set
+func NewJWKSKeySet(url, file string, refreshInterval time.Duration, logger *logger.Logger) *JWKSKeySet {
+	return &JWKSKeySet{
+		url:             url,
+		file:            file,
+		refreshInterval: refreshInterval,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		logger:          logger,
+		keys:            make(map[string]interface{}),
+	}
+}
+
+// Refresh reloads the key set from its source
+func (s *JWKSKeySet) Refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	return s.refresh(ctx)
+}
+
+// refresh reloads the key set; callers must hold refreshMu. Failed attempts count towards the
+// throttle too, so an unreachable source is not hammered by tokens with unknown kids.
+func (s *JWKSKeySet) refresh(ctx context.Context) error {
+	s.mu.Lock()
+	s.lastAttempt = time.Now()
+	s.mu.Unlock()
+
+	data, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	s.logger.Logger.WithField("keys", len(keys)).Debug("JWKS refreshed")
+	return nil
+}
+
+// Start refreshes the key set periodically until ctx is cancelled
+func (s *JWKSKeySet) Start(ctx context.Context) {
+	if s.refreshInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Refresh(ctx); err != nil {
+					// Keep serving the previously cached keys
+					s.logger.Logger.WithError(err).Warn("Failed to refresh JWKS")
+				}
+			}
+		}
+	}()
+}
+
+// Key returns the verification key for kid, refreshing once if the kid is unknown
+func (s *JWKSKeySet) Key(ctx context.Context, kid string) (interface{}, error) {
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	// Another caller may have loaded the key while this one waited for the refresh
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	// Keys may have been rotated since the last refresh attempt
+	s.mu.RLock()
+	stale := time.Since(s.lastAttempt) > minRefreshInterval
+	s.mu.RUnlock()
+
+	if stale {
+		if err := s.refresh(ctx); err != nil {
+			return nil, fmt.Errorf("failed to refresh JWKS: %w", err)
+		}
+		if key, ok := s.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// lookup finds a cached key; tokens without kid match only a single-key set
+func (s *JWKSKeySet) lookup(kid string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" {
+		if len(s.keys) == 1 {
+			for _, key := range s.keys {
+				return key, true
+			}
+		}
+		return nil, false
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// load reads the raw JWKS document from the configured source
+func (s *JWKSKeySet) load(ctx context.Context) ([]byte, error) {
+	if s.url == "" {
+		data, err := os.ReadFile(s.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS response: %w", err)
+	}
+	return data, nil
+}
+
+// ParseJWKS decodes a JWKS document into verification keys indexed by kid.
+// Keys not meant for signatures and unsupported key types are skipped.
+func ParseJWKS(data []byte) (map[string]interface{}, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(document.Keys))
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key %q: %w", jwk.Kid, err)
+		}
+		if key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+
+	return keys, nil
+}
+
+// publicKey converts the JWK into a key usable by golang-jwt
+func (k *jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URL(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URL(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URL(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return key, nil
+	case "oct":
+		return decodeBase64URL(k.K)
+	default:
+		return nil, nil
+	}
+}
+
+// decodeBase64URL decodes unpadded base64url values used by JWK fields
+func decodeBase64URL(value string) ([]byte, error) {
+	if value == "" {
+		return nil, errors.New("missing key parameter")
+	}
+	return base64.RawURLEncoding.DecodeString(value)
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/google/uuid"
+)
+
+func TestJWKSKeySetThrottlesRefreshWhileSourceFails(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	keySet := NewJWKSKeySet(server.URL, "", 0, logger.NewLogger("error"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := keySet.Key(context.Background(), uuid.NewString()); err == nil {
+				t.Error("Key() returned a key for an unknown kid")
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 5; i++ {
+		if _, err := keySet.Key(context.Background(), uuid.NewString()); err != ErrKeyNotFound {
+			t.Errorf("Key() error = %v, want %v", err, ErrKeyNotFound)
+		}
+	}
+
+	if got := atomic.LoadInt32(&fetches); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", got)
+	}
+}
+
+func TestJWKSKeySetRefetchesUnknownKidAfterInterval(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	keySet := NewJWKSKeySet(server.URL, "", 0, logger.NewLogger("error"))
+
+	if _, err := keySet.Key(context.Background(), "rotated"); err == nil || err == ErrKeyNotFound {
+		t.Fatalf("Key() error = %v, want a refresh error", err)
+	}
+
+	// Pretend the failed attempt happened before the throttle window
+	keySet.mu.Lock()
+	keySet.lastAttempt = time.Now().Add(-2 * minRefreshInterval)
+	keySet.mu.Unlock()
+
+	if _, err := keySet.Key(context.Background(), "rotated"); err == nil || err == ErrKeyNotFound {
+		t.Fatalf("Key() error = %v, want a refresh error", err)
+	}
+
+	if got := atomic.LoadInt32(&fetches); got != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", got)
+	}
+}
@@ -9,10 +9,19 @@ import (
 
 // Config holds all configuration values
 type Config struct {
-	Database DatabaseConfig `mapstructure:"database"`
-	Server   ServerConfig   `mapstructure:"server"`
-	Log      LogConfig      `mapstructure:"log"`
-	CORS     CORSConfig     `mapstructure:"cors"`
+	Database    DatabaseConfig    `mapstructure:"database"`
+	Server      ServerConfig      `mapstructure:"server"`
+	Log         LogConfig         `mapstructure:"log"`
+	CORS        CORSConfig        `mapstructure:"cors"`
+	Staff       StaffConfig       `mapstructure:"staff"`
+	Auth        AuthConfig        `mapstructure:"auth"`
+	Webhooks    WebhookConfig     `mapstructure:"webhooks"`
+	Outbox      OutboxConfig      `mapstructure:"outbox"`
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+	Storage     StorageConfig     `mapstructure:"storage"`
+	Consent     ConsentConfig     `mapstructure:"consent"`
+	Payments    PaymentsConfig    `mapstructure:"payments"`
+	CFDI        CFDIConfig        `mapstructure:"cfdi"`
 }
 
 // DatabaseConfig holds database configuration
@@ -41,6 +50,80 @@ type CORSConfig struct {
 	AllowedOrigins []string `mapstructure:"allowed_origins"`
 }
 
+// StaffConfig holds staff invitation configuration
+type StaffConfig struct {
+	InvitationSecret   string `mapstructure:"invitation_secret"`
+	InvitationTTLHours int    `mapstructure:"invitation_ttl_hours"`
+	InvitationURL      string `mapstructure:"invitation_url"` // Frontend page that accepts ?token=...
+}
+
+// ConsentConfig holds patient consent signing link configuration
+type ConsentConfig struct {
+	SigningSecret string `mapstructure:"signing_secret"`
+	LinkTTLHours  int    `mapstructure:"link_ttl_hours"`
+	SigningURL    string `mapstructure:"signing_url"` // Frontend page that signs ?token=...
+}
+
+// PaymentsConfig holds online payment gateway configuration for appointment deposits
+type PaymentsConfig struct {
+	Driver                 string `mapstructure:"driver"`                   // none refuses deposits; fake charges nothing, for development only
+	CheckoutURL            string `mapstructure:"checkout_url"`             // Frontend page the local gateway's payment links open
+	WebhookSecret          string `mapstructure:"webhook_secret"`           // Signs gateway notifications; all are rejected when empty
+	ReleaseIntervalSeconds int    `mapstructure:"release_interval_seconds"` // How often holds with overdue deposits are released
+	ReleaseBatchSize       int    `mapstructure:"release_batch_size"`       // Overdue deposits released per batch
+}
+
+// CFDIConfig holds electronic invoice stamping configuration
+type CFDIConfig struct {
+	PACDriver string `mapstructure:"pac_driver"` // none refuses to stamp; fake stamps locally without the SAT, for development only
+}
+
+// AuthConfig holds Supabase JWT validation configuration
+type AuthConfig struct {
+	JWTSecret          string `mapstructure:"jwt_secret"`           // Legacy HS256 shared secret
+	JWKSURL            string `mapstructure:"jwks_url"`             // Takes precedence over JWKSFile
+	JWKSFile           string `mapstructure:"jwks_file"`            // Local JWKS document for offline use
+	JWKSRefreshMinutes int    `mapstructure:"jwks_refresh_minutes"` // Background refresh interval, 0 disables it
+	Issuer             string `mapstructure:"issuer"`               // Expected iss claim, not checked when empty
+	Audience           string `mapstructure:"audience"`             // Expected aud claim, not checked when empty
+}
+
+// WebhookConfig holds outbound webhook delivery configuration
+type WebhookConfig struct {
+	WorkerEnabled       bool `mapstructure:"worker_enabled"`        // Disable to run delivery in a separate process
+	PollIntervalSeconds int  `mapstructure:"poll_interval_seconds"` // How often due deliveries are claimed
+	BatchSize           int  `mapstructure:"batch_size"`            // Deliveries claimed per poll
+	TimeoutSeconds      int  `mapstructure:"timeout_seconds"`       // Per-request HTTP timeout
+}
+
+// OutboxConfig holds domain event relay configuration
+type OutboxConfig struct {
+	RelayEnabled        bool `mapstructure:"relay_enabled"`         // Disable to run the relay in a separate process
+	PollIntervalSeconds int  `mapstructure:"poll_interval_seconds"` // How often due events are claimed
+	BatchSize           int  `mapstructure:"batch_size"`            // Events claimed per poll
+	RetentionHours      int  `mapstructure:"retention_hours"`       // How long processed events are kept, 0 keeps them
+}
+
+// IdempotencyConfig holds Idempotency-Key header configuration
+type IdempotencyConfig struct {
+	RetentionHours int `mapstructure:"retention_hours"` // How long stored responses can be replayed
+}
+
+// StorageConfig holds attachment file storage configuration
+type StorageConfig struct {
+	Driver            string `mapstructure:"driver"`              // local or s3
+	LocalPath         string `mapstructure:"local_path"`          // Root directory of the local driver
+	MaxUploadMB       int    `mapstructure:"max_upload_mb"`       // Largest accepted attachment
+	PresignTTLMinutes int    `mapstructure:"presign_ttl_minutes"` // Lifetime of pre-signed download URLs
+	S3Endpoint        string `mapstructure:"s3_endpoint"`         // Defaults to AWS S3 in the region; set for MinIO, R2, ...
+	S3Region          string `mapstructure:"s3_region"`
+	S3Bucket          string `mapstructure:"s3_bucket"`
+	S3AccessKeyID     string `mapstructure:"s3_access_key_id"`
+	S3SecretAccessKey string `mapstructure:"s3_secret_access_key"`
+	S3UsePathStyle    bool   `mapstructure:"s3_use_path_style"`  // Bucket in the path instead of the host name
+	S3TimeoutSeconds  int    `mapstructure:"s3_timeout_seconds"` // Per-request HTTP timeout
+}
+
 // Load loads configuration from environment variables and config files
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
@@ -98,6 +181,49 @@ func setDefaults() {
 	// CORS defaults
 	viper.SetDefault("cors.allowed_origins", []string{"http://localhost:3000", "http://localhost:5173"})
 
+	// Staff invitation defaults
+	viper.SetDefault("staff.invitation_ttl_hours", 72)
+	viper.SetDefault("staff.invitation_url", "http://localhost:5173/accept-invitation")
+
+	// Consent signing defaults
+	viper.SetDefault("consent.link_ttl_hours", 72)
+	viper.SetDefault("consent.signing_url", "http://localhost:5173/sign-consent")
+
+	// Payments defaults
+	viper.SetDefault("payments.driver", "none")
+	viper.SetDefault("payments.checkout_url", "http://localhost:5173/pay")
+	viper.SetDefault("payments.release_interval_seconds", 60)
+	viper.SetDefault("payments.release_batch_size", 50)
+
+	// CFDI defaults
+	viper.SetDefault("cfdi.pac_driver", "none")
+
+	// Auth defaults
+	viper.SetDefault("auth.jwks_refresh_minutes", 10)
+
+	// Webhook defaults
+	viper.SetDefault("webhooks.worker_enabled", true)
+	viper.SetDefault("webhooks.poll_interval_seconds", 5)
+	viper.SetDefault("webhooks.batch_size", 20)
+	viper.SetDefault("webhooks.timeout_seconds", 10)
+
+	// Outbox defaults
+	viper.SetDefault("outbox.relay_enabled", true)
+	viper.SetDefault("outbox.poll_interval_seconds", 2)
+	viper.SetDefault("outbox.batch_size", 50)
+	viper.SetDefault("outbox.retention_hours", 168)
+
+	// Idempotency defaults
+	viper.SetDefault("idempotency.retention_hours", 24)
+
+	// Storage defaults
+	viper.SetDefault("storage.driver", "local")
+	viper.SetDefault("storage.local_path", "./data/attachments")
+	viper.SetDefault("storage.max_upload_mb", 25)
+	viper.SetDefault("storage.presign_ttl_minutes", 15)
+	viper.SetDefault("storage.s3_region", "us-east-1")
+	viper.SetDefault("storage.s3_timeout_seconds", 60)
+
 	// Environment variable mappings
 	viper.BindEnv("database.host", "DB_HOST")
 	viper.BindEnv("database.port", "DB_PORT")
@@ -108,6 +234,44 @@ func setDefaults() {
 	viper.BindEnv("server.host", "SERVER_HOST")
 	viper.BindEnv("server.port", "SERVER_PORT")
 	viper.BindEnv("log.level", "LOG_LEVEL")
+	viper.BindEnv("staff.invitation_secret", "STAFF_INVITATION_SECRET")
+	viper.BindEnv("staff.invitation_ttl_hours", "STAFF_INVITATION_TTL_HOURS")
+	viper.BindEnv("staff.invitation_url", "STAFF_INVITATION_URL")
+	viper.BindEnv("consent.signing_secret", "CONSENT_SIGNING_SECRET")
+	viper.BindEnv("consent.link_ttl_hours", "CONSENT_LINK_TTL_HOURS")
+	viper.BindEnv("consent.signing_url", "CONSENT_SIGNING_URL")
+	viper.BindEnv("payments.driver", "PAYMENTS_DRIVER")
+	viper.BindEnv("payments.checkout_url", "PAYMENTS_CHECKOUT_URL")
+	viper.BindEnv("payments.webhook_secret", "PAYMENTS_WEBHOOK_SECRET")
+	viper.BindEnv("payments.release_interval_seconds", "PAYMENTS_RELEASE_INTERVAL_SECONDS")
+	viper.BindEnv("payments.release_batch_size", "PAYMENTS_RELEASE_BATCH_SIZE")
+	viper.BindEnv("cfdi.pac_driver", "CFDI_PAC_DRIVER")
+	viper.BindEnv("auth.jwt_secret", "SUPABASE_JWT_SECRET")
+	viper.BindEnv("auth.jwks_url", "SUPABASE_JWKS_URL")
+	viper.BindEnv("auth.jwks_file", "SUPABASE_JWKS_FILE")
+	viper.BindEnv("auth.jwks_refresh_minutes", "SUPABASE_JWKS_REFRESH_MINUTES")
+	viper.BindEnv("auth.issuer", "SUPABASE_JWT_ISSUER")
+	viper.BindEnv("auth.audience", "SUPABASE_JWT_AUDIENCE")
+	viper.BindEnv("webhooks.worker_enabled", "WEBHOOK_WORKER_ENABLED")
+	viper.BindEnv("webhooks.poll_interval_seconds", "WEBHOOK_POLL_INTERVAL_SECONDS")
+	viper.BindEnv("webhooks.batch_size", "WEBHOOK_BATCH_SIZE")
+	viper.BindEnv("webhooks.timeout_seconds", "WEBHOOK_TIMEOUT_SECONDS")
+	viper.BindEnv("outbox.relay_enabled", "OUTBOX_RELAY_ENABLED")
+	viper.BindEnv("outbox.poll_interval_seconds", "OUTBOX_POLL_INTERVAL_SECONDS")
+	viper.BindEnv("outbox.batch_size", "OUTBOX_BATCH_SIZE")
+	viper.BindEnv("outbox.retention_hours", "OUTBOX_RETENTION_HOURS")
+	viper.BindEnv("idempotency.retention_hours", "IDEMPOTENCY_RETENTION_HOURS")
+	viper.BindEnv("storage.driver", "STORAGE_DRIVER")
+	viper.BindEnv("storage.local_path", "STORAGE_LOCAL_PATH")
+	viper.BindEnv("storage.max_upload_mb", "STORAGE_MAX_UPLOAD_MB")
+	viper.BindEnv("storage.presign_ttl_minutes", "STORAGE_PRESIGN_TTL_MINUTES")
+	viper.BindEnv("storage.s3_endpoint", "STORAGE_S3_ENDPOINT")
+	viper.BindEnv("storage.s3_region", "STORAGE_S3_REGION")
+	viper.BindEnv("storage.s3_bucket", "STORAGE_S3_BUCKET")
+	viper.BindEnv("storage.s3_access_key_id", "STORAGE_S3_ACCESS_KEY_ID")
+	viper.BindEnv("storage.s3_secret_access_key", "STORAGE_S3_SECRET_ACCESS_KEY")
+	viper.BindEnv("storage.s3_use_path_style", "STORAGE_S3_USE_PATH_STYLE")
+	viper.BindEnv("storage.s3_timeout_seconds", "STORAGE_S3_TIMEOUT_SECONDS")
 }
 
 // GetDSN returns the database connection string
@@ -0,0 +1,8 @@
+-- Rollback: Remove staff invitations and profile activation fields
+DROP TRIGGER IF EXISTS update_staff_invitations_updated_at ON staff_invitations;
+DROP INDEX IF EXISTS idx_staff_invitations_organization_id;
+DROP INDEX IF EXISTS idx_staff_invitations_pending_email;
+DROP TABLE IF EXISTS staff_invitations;
+
+ALTER TABLE profiles DROP COLUMN IF EXISTS deactivated_at;
+ALTER TABLE profiles DROP COLUMN IF EXISTS is_active;
@@ -0,0 +1,42 @@
+-- Allow organization admins to deactivate staff without deleting their auth user
+ALTER TABLE profiles ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE profiles ADD COLUMN deactivated_at TIMESTAMPTZ NULL;
+
+-- Create staff_invitations table to onboard users into an organization by email
+CREATE TABLE staff_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    roles TEXT[] NOT NULL DEFAULT ARRAY['receptionist'],
+    doctor_id UUID NULL REFERENCES doctors(id) ON DELETE SET NULL,
+    invited_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    accepted_at TIMESTAMPTZ NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT staff_invitations_status_check
+        CHECK (status IN ('pending', 'accepted', 'revoked'))
+);
+
+-- Only one pending invitation per email within an organization
+CREATE UNIQUE INDEX idx_staff_invitations_pending_email
+ON staff_invitations (organization_id, LOWER(email))
+WHERE status = 'pending';
+
+CREATE INDEX idx_staff_invitations_organization_id ON staff_invitations(organization_id);
+
+CREATE TRIGGER update_staff_invitations_updated_at
+    BEFORE UPDATE ON staff_invitations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE staff_invitations IS 'Email invitations for staff members to join an organization';
+COMMENT ON COLUMN staff_invitations.roles IS 'Roles granted to the profile when the invitation is accepted';
+COMMENT ON COLUMN staff_invitations.doctor_id IS 'Optional doctor record linked to the accepting profile';
+COMMENT ON COLUMN staff_invitations.expires_at IS 'Invitation tokens are rejected after this timestamp';
+COMMENT ON COLUMN profiles.is_active IS 'Inactive profiles are rejected by the API even with a valid Supabase session';
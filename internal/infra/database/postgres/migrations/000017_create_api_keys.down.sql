@@ -0,0 +1,5 @@
+-- Rollback: Remove api_keys table
+DROP TRIGGER IF EXISTS update_api_keys_updated_at ON api_keys;
+DROP INDEX IF EXISTS idx_api_keys_organization_id;
+DROP INDEX IF EXISTS idx_api_keys_prefix;
+DROP TABLE IF EXISTS api_keys;
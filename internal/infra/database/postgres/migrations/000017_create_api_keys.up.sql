@@ -0,0 +1,31 @@
+-- Create api_keys table for organization-scoped server-to-server credentials
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Keys are looked up by prefix on every request
+CREATE UNIQUE INDEX idx_api_keys_prefix ON api_keys(prefix);
+CREATE INDEX idx_api_keys_organization_id ON api_keys(organization_id);
+
+CREATE TRIGGER update_api_keys_updated_at
+    BEFORE UPDATE ON api_keys
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE api_keys IS 'Organization-scoped API keys for integrations without a Supabase session';
+COMMENT ON COLUMN api_keys.prefix IS 'Public identifier embedded in the key (dsk_<prefix>_<secret>)';
+COMMENT ON COLUMN api_keys.key_hash IS 'Hex-encoded SHA-256 of the full key; the plaintext is only shown at creation';
+COMMENT ON COLUMN api_keys.scopes IS 'Route groups the key may call (patients, appointments, ...)';
+COMMENT ON COLUMN api_keys.last_used_at IS 'Updated at most once per minute to limit write load';
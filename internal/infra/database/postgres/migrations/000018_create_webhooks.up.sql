@@ -0,0 +1,65 @@
+-- Create webhook_subscriptions table for per-organization outbound webhooks
+CREATE TABLE webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    description VARCHAR(255) NULL,
+    secret VARCHAR(100) NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_subscriptions_organization_id ON webhook_subscriptions(organization_id);
+
+CREATE TRIGGER update_webhook_subscriptions_updated_at
+    BEFORE UPDATE ON webhook_subscriptions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create webhook_deliveries table; rows are written in the same transaction as the
+-- appointment/patient change (transactional outbox) and drained by the delivery worker
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_attempt_at TIMESTAMPTZ NULL,
+    last_response_status INTEGER NULL,
+    last_error TEXT NULL,
+    delivered_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT webhook_deliveries_status_check
+        CHECK (status IN ('pending', 'succeeded', 'failed')),
+    -- An event is delivered at most once per subscription
+    CONSTRAINT webhook_deliveries_subscription_event_unique
+        UNIQUE (subscription_id, event_id)
+);
+
+-- The worker polls pending deliveries ordered by due time
+CREATE INDEX idx_webhook_deliveries_due
+ON webhook_deliveries (next_attempt_at)
+WHERE status = 'pending';
+
+CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries(subscription_id, created_at DESC);
+
+CREATE TRIGGER update_webhook_deliveries_updated_at
+    BEFORE UPDATE ON webhook_deliveries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE webhook_subscriptions IS 'Organization endpoints notified about appointment and patient events';
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'Shared secret used to HMAC-sign delivered payloads';
+COMMENT ON COLUMN webhook_subscriptions.event_types IS 'Event type filter; an empty array subscribes to all events';
+COMMENT ON TABLE webhook_deliveries IS 'Transactional outbox of webhook payloads with delivery attempts';
+COMMENT ON COLUMN webhook_deliveries.event_id IS 'Identifier shared by all deliveries of one event, sent to receivers for deduplication';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'When the worker may try again (exponential backoff after failures)';
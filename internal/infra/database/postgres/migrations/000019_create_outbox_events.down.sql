@@ -0,0 +1,6 @@
+-- Rollback: Remove outbox events
+DROP TRIGGER IF EXISTS update_outbox_events_updated_at ON outbox_events;
+DROP INDEX IF EXISTS idx_outbox_events_aggregate;
+DROP INDEX IF EXISTS idx_outbox_events_processed_at;
+DROP INDEX IF EXISTS idx_outbox_events_due;
+DROP TABLE IF EXISTS outbox_events;
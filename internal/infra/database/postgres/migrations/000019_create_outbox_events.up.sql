@@ -0,0 +1,45 @@
+-- Create outbox_events table; domain events are written in the same transaction as
+-- the change that raised them and dispatched to in-process subscribers by the relay
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    handled_by TEXT[] NOT NULL DEFAULT '{}',
+    last_error TEXT NULL,
+    processed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT outbox_events_status_check
+        CHECK (status IN ('pending', 'processed', 'failed'))
+);
+
+-- The relay polls pending events ordered by due time
+CREATE INDEX idx_outbox_events_due
+ON outbox_events (next_attempt_at)
+WHERE status = 'pending';
+
+-- Processed events are purged after the retention window
+CREATE INDEX idx_outbox_events_processed_at
+ON outbox_events (processed_at)
+WHERE status = 'processed';
+
+CREATE INDEX idx_outbox_events_aggregate ON outbox_events(aggregate_type, aggregate_id, occurred_at);
+
+CREATE TRIGGER update_outbox_events_updated_at
+    BEFORE UPDATE ON outbox_events
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE outbox_events IS 'Transactional outbox of domain events dispatched to in-process subscribers';
+COMMENT ON COLUMN outbox_events.id IS 'Event identifier, used by subscribers as an idempotency key';
+COMMENT ON COLUMN outbox_events.handled_by IS 'Subscribers that already processed the event; retries skip them';
+COMMENT ON COLUMN outbox_events.next_attempt_at IS 'When the relay may try again (exponential backoff after failures)';
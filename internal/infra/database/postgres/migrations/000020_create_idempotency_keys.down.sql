@@ -0,0 +1,3 @@
+-- Rollback: Remove idempotency keys
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+DROP TABLE IF EXISTS idempotency_keys;
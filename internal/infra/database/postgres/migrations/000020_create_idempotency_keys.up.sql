@@ -0,0 +1,23 @@
+-- Create idempotency_keys table storing responses of requests made with an Idempotency-Key header
+CREATE TABLE idempotency_keys (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'in_progress',
+    response_status INTEGER NULL,
+    response_body BYTEA NULL,
+    content_type VARCHAR(100) NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (organization_id, key),
+    CONSTRAINT idempotency_keys_status_check
+        CHECK (status IN ('in_progress', 'completed'))
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE idempotency_keys IS 'Responses replayed when a client retries a request with the same Idempotency-Key';
+COMMENT ON COLUMN idempotency_keys.request_hash IS 'SHA-256 of method, route and body; a reused key with another fingerprint is rejected';
+COMMENT ON COLUMN idempotency_keys.expires_at IS 'End of the retention window, after which the key may be reused';
@@ -0,0 +1,7 @@
+-- Rollback: Remove version columns
+DROP TRIGGER IF EXISTS increment_patients_version ON patients;
+DROP TRIGGER IF EXISTS increment_appointments_version ON appointments;
+DROP FUNCTION IF EXISTS increment_version_column();
+
+ALTER TABLE patients DROP COLUMN IF EXISTS version;
+ALTER TABLE appointments DROP COLUMN IF EXISTS version;
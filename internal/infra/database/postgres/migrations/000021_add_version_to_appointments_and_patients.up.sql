@@ -0,0 +1,27 @@
+-- Add version columns for optimistic concurrency control on appointments and patients
+ALTER TABLE appointments ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE patients ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Every update bumps the version, including queue actions and backfills that
+-- don't go through the versioned repository Update
+CREATE OR REPLACE FUNCTION increment_version_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER increment_appointments_version
+    BEFORE UPDATE ON appointments
+    FOR EACH ROW
+    EXECUTE FUNCTION increment_version_column();
+
+CREATE TRIGGER increment_patients_version
+    BEFORE UPDATE ON patients
+    FOR EACH ROW
+    EXECUTE FUNCTION increment_version_column();
+
+-- Add comments for documentation
+COMMENT ON COLUMN appointments.version IS 'Incremented on every update; sent as ETag and checked against If-Match';
+COMMENT ON COLUMN patients.version IS 'Incremented on every update; sent as ETag and checked against If-Match';
@@ -0,0 +1,5 @@
+-- Rollback: Drop patient_merges table
+DROP INDEX IF EXISTS idx_patient_merges_merged;
+DROP INDEX IF EXISTS idx_patient_merges_survivor;
+DROP INDEX IF EXISTS idx_patient_merges_organization_created;
+DROP TABLE IF EXISTS patient_merges;
@@ -0,0 +1,23 @@
+-- Create patient_merges table auditing duplicate patients folded into a surviving record
+CREATE TABLE patient_merges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    survivor_patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    merged_patient_id UUID NOT NULL,
+    merged_patient_snapshot JSONB NOT NULL,
+    appointments_moved INTEGER NOT NULL DEFAULT 0,
+    score INTEGER NOT NULL,
+    reason TEXT NULL,
+    merged_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_patient_merges_organization_created ON patient_merges(organization_id, created_at DESC);
+CREATE INDEX idx_patient_merges_survivor ON patient_merges(survivor_patient_id);
+CREATE INDEX idx_patient_merges_merged ON patient_merges(merged_patient_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE patient_merges IS 'Audit trail of duplicate patients merged into a surviving record';
+COMMENT ON COLUMN patient_merges.merged_patient_id IS 'ID of the deleted duplicate; no foreign key because the row no longer exists';
+COMMENT ON COLUMN patient_merges.merged_patient_snapshot IS 'Last state of the merged patient before deletion';
+COMMENT ON COLUMN patient_merges.score IS 'Duplicate score between the two records at merge time (0-100)';
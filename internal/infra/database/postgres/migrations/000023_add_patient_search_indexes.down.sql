@@ -0,0 +1,9 @@
+-- Rollback: Drop patient search columns and indexes
+DROP INDEX IF EXISTS idx_patients_email_trgm;
+DROP INDEX IF EXISTS idx_patients_phone_digits_trgm;
+DROP INDEX IF EXISTS idx_patients_search_name_trgm;
+ALTER TABLE patients DROP COLUMN IF EXISTS phone_digits;
+ALTER TABLE patients DROP COLUMN IF EXISTS search_name;
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
+DROP EXTENSION IF EXISTS unaccent;
+DROP EXTENSION IF EXISTS pg_trgm;
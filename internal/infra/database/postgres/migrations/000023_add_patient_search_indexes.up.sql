@@ -0,0 +1,27 @@
+-- Fuzzy patient search: accent-insensitive trigram matching on names and emails,
+-- and phone matching on digits only regardless of how the number was typed
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE because its dictionary can change; pinning the dictionary
+-- makes it safe to use in generated columns and indexes
+CREATE OR REPLACE FUNCTION immutable_unaccent(text)
+RETURNS text AS $$
+    SELECT public.unaccent('public.unaccent'::regdictionary, $1)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+ALTER TABLE patients
+    ADD COLUMN search_name TEXT GENERATED ALWAYS AS (
+        lower(immutable_unaccent(first_name || ' ' || COALESCE(last_name, '')))
+    ) STORED,
+    ADD COLUMN phone_digits TEXT GENERATED ALWAYS AS (
+        regexp_replace(COALESCE(phone, ''), '[^0-9]', '', 'g')
+    ) STORED;
+
+CREATE INDEX idx_patients_search_name_trgm ON patients USING GIN (search_name gin_trgm_ops);
+CREATE INDEX idx_patients_phone_digits_trgm ON patients USING GIN (phone_digits gin_trgm_ops);
+CREATE INDEX idx_patients_email_trgm ON patients USING GIN (lower(email) gin_trgm_ops);
+
+-- Add comments for documentation
+COMMENT ON COLUMN patients.search_name IS 'Lowercased, unaccented full name used by patient search';
+COMMENT ON COLUMN patients.phone_digits IS 'Phone number with every non-digit removed, used by patient search';
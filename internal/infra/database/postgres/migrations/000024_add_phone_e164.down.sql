@@ -0,0 +1,14 @@
+-- Rollback: Drop E.164 phone columns and restore search on the phone as typed
+DROP INDEX IF EXISTS idx_patients_phone_digits_trgm;
+ALTER TABLE patients DROP COLUMN IF EXISTS phone_digits;
+ALTER TABLE patients
+    ADD COLUMN phone_digits TEXT GENERATED ALWAYS AS (
+        regexp_replace(COALESCE(phone, ''), '[^0-9]', '', 'g')
+    ) STORED;
+CREATE INDEX idx_patients_phone_digits_trgm ON patients USING GIN (phone_digits gin_trgm_ops);
+
+DROP INDEX IF EXISTS idx_patients_phone_e164;
+ALTER TABLE clinics DROP COLUMN IF EXISTS phone_e164;
+ALTER TABLE doctors DROP COLUMN IF EXISTS phone_e164;
+ALTER TABLE patients DROP COLUMN IF EXISTS phone_e164;
+ALTER TABLE organizations DROP COLUMN IF EXISTS default_phone_region;
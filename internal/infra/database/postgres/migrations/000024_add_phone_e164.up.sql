@@ -0,0 +1,28 @@
+-- Default region used to read national phone numbers, per organization
+ALTER TABLE organizations
+    ADD COLUMN default_phone_region VARCHAR(2) NOT NULL DEFAULT 'MX'
+    CHECK (default_phone_region ~ '^[A-Z]{2}$');
+
+-- E.164 form stored alongside the phone as typed; filled by the API on write and by
+-- the phone backfill command for existing rows
+ALTER TABLE patients ADD COLUMN phone_e164 VARCHAR(16) NULL;
+ALTER TABLE doctors ADD COLUMN phone_e164 VARCHAR(16) NULL;
+ALTER TABLE clinics ADD COLUMN phone_e164 VARCHAR(16) NULL;
+
+CREATE INDEX idx_patients_phone_e164 ON patients(phone_e164);
+
+-- Patient search matches phone digits on the normalized number when there is one
+DROP INDEX IF EXISTS idx_patients_phone_digits_trgm;
+ALTER TABLE patients DROP COLUMN phone_digits;
+ALTER TABLE patients
+    ADD COLUMN phone_digits TEXT GENERATED ALWAYS AS (
+        regexp_replace(COALESCE(phone_e164, phone, ''), '[^0-9]', '', 'g')
+    ) STORED;
+CREATE INDEX idx_patients_phone_digits_trgm ON patients USING GIN (phone_digits gin_trgm_ops);
+
+-- Add comments for documentation
+COMMENT ON COLUMN organizations.default_phone_region IS 'ISO 3166-1 alpha-2 region used to read phone numbers without a country code';
+COMMENT ON COLUMN patients.phone_e164 IS 'Phone in E.164 format (e.g. +525512345678); NULL when blank or unparseable';
+COMMENT ON COLUMN doctors.phone_e164 IS 'Phone in E.164 format (e.g. +525512345678); NULL when blank or unparseable';
+COMMENT ON COLUMN clinics.phone_e164 IS 'Phone in E.164 format (e.g. +525512345678); NULL when blank or unparseable';
+COMMENT ON COLUMN patients.phone_digits IS 'Digits of the E.164 phone (or the phone as typed), used by patient search';
@@ -0,0 +1,6 @@
+-- Rollback: Drop patient archiving
+DROP INDEX IF EXISTS idx_patient_organizations_active;
+
+ALTER TABLE patient_organizations
+    DROP COLUMN IF EXISTS archived_by,
+    DROP COLUMN IF EXISTS archived_at;
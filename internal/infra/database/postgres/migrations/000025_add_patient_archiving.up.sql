@@ -0,0 +1,13 @@
+-- Patients are archived per organization: the link is kept so appointment history
+-- stays intact while the patient disappears from listings and search
+ALTER TABLE patient_organizations
+    ADD COLUMN archived_at TIMESTAMPTZ NULL,
+    ADD COLUMN archived_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL;
+
+-- Listings only look at active patients of an organization
+CREATE INDEX idx_patient_organizations_active
+    ON patient_organizations(organization_id, patient_id)
+    WHERE archived_at IS NULL;
+
+COMMENT ON COLUMN patient_organizations.archived_at IS 'When the organization archived the patient; NULL while active';
+COMMENT ON COLUMN patient_organizations.archived_by IS 'Profile that archived the patient';
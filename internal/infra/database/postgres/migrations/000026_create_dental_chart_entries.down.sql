@@ -0,0 +1,4 @@
+-- Rollback: Drop dental_chart_entries table
+DROP INDEX IF EXISTS idx_dental_chart_entries_appointment;
+DROP INDEX IF EXISTS idx_dental_chart_entries_patient;
+DROP TABLE IF EXISTS dental_chart_entries;
@@ -0,0 +1,28 @@
+-- Create dental_chart_entries table holding the append-only odontogram of each patient
+CREATE TABLE dental_chart_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    tooth SMALLINT NOT NULL CHECK (
+        (tooth / 10 BETWEEN 1 AND 4 AND tooth % 10 BETWEEN 1 AND 8) OR
+        (tooth / 10 BETWEEN 5 AND 8 AND tooth % 10 BETWEEN 1 AND 5)
+    ),
+    surfaces TEXT[] NOT NULL DEFAULT '{}',
+    condition VARCHAR(20) NOT NULL CHECK (condition IN ('sound', 'caries', 'filling', 'crown', 'missing', 'implant')),
+    notes TEXT NULL,
+    appointment_id UUID NULL REFERENCES appointments(id) ON DELETE SET NULL,
+    doctor_id UUID NULL REFERENCES doctors(id) ON DELETE SET NULL,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    recorded_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (surfaces <@ ARRAY['M', 'D', 'O', 'I', 'B', 'L']::TEXT[])
+);
+
+CREATE INDEX idx_dental_chart_entries_patient ON dental_chart_entries(organization_id, patient_id, recorded_at);
+CREATE INDEX idx_dental_chart_entries_appointment ON dental_chart_entries(appointment_id) WHERE appointment_id IS NOT NULL;
+
+-- Add comments for documentation
+COMMENT ON TABLE dental_chart_entries IS 'Odontogram charting entries; never updated, later entries supersede earlier ones';
+COMMENT ON COLUMN dental_chart_entries.tooth IS 'Tooth in FDI notation (11-48 permanent, 51-85 primary)';
+COMMENT ON COLUMN dental_chart_entries.surfaces IS 'Charted surfaces (M, D, O, I, B, L); empty when the entry applies to the whole tooth';
+COMMENT ON COLUMN dental_chart_entries.recorded_at IS 'When the condition was observed; the chart as of a date replays entries up to it';
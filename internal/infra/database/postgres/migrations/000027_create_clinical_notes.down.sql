@@ -0,0 +1,8 @@
+-- Rollback: Drop clinical notes, amendments and templates
+DROP TRIGGER IF EXISTS prevent_signed_clinical_note_update ON clinical_notes;
+DROP FUNCTION IF EXISTS prevent_signed_clinical_note_update();
+DROP TRIGGER IF EXISTS update_clinical_notes_updated_at ON clinical_notes;
+DROP TRIGGER IF EXISTS update_clinical_note_templates_updated_at ON clinical_note_templates;
+DROP TABLE IF EXISTS clinical_note_amendments;
+DROP TABLE IF EXISTS clinical_notes;
+DROP TABLE IF EXISTS clinical_note_templates;
@@ -0,0 +1,89 @@
+-- Create clinical note templates, SOAP clinical notes and their amendments
+CREATE TABLE clinical_note_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    service_id VARCHAR(255) NULL REFERENCES services(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    subjective TEXT NOT NULL DEFAULT '',
+    objective TEXT NOT NULL DEFAULT '',
+    assessment TEXT NOT NULL DEFAULT '',
+    plan TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One template per service, plus a single organization default (service_id NULL)
+CREATE UNIQUE INDEX idx_clinical_note_templates_service ON clinical_note_templates(organization_id, COALESCE(service_id, ''));
+
+CREATE TABLE clinical_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    doctor_id UUID NOT NULL REFERENCES doctors(id),
+    author_id UUID NOT NULL REFERENCES profiles(id),
+    template_id UUID NULL REFERENCES clinical_note_templates(id) ON DELETE SET NULL,
+    subjective TEXT NOT NULL DEFAULT '',
+    objective TEXT NOT NULL DEFAULT '',
+    assessment TEXT NOT NULL DEFAULT '',
+    plan TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'signed')),
+    signed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((status = 'signed') = (signed_at IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX idx_clinical_notes_appointment ON clinical_notes(appointment_id);
+CREATE INDEX idx_clinical_notes_patient ON clinical_notes(organization_id, patient_id);
+
+CREATE TABLE clinical_note_amendments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    note_id UUID NOT NULL REFERENCES clinical_notes(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES profiles(id),
+    doctor_id UUID NULL REFERENCES doctors(id) ON DELETE SET NULL,
+    content TEXT NOT NULL,
+    reason TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_clinical_note_amendments_note ON clinical_note_amendments(note_id, created_at);
+
+CREATE TRIGGER update_clinical_note_templates_updated_at
+    BEFORE UPDATE ON clinical_note_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_clinical_notes_updated_at
+    BEFORE UPDATE ON clinical_notes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Signed notes are immutable; only the patient link may change (patient merges)
+CREATE OR REPLACE FUNCTION prevent_signed_clinical_note_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status = 'signed' AND (
+        NEW.subjective IS DISTINCT FROM OLD.subjective OR
+        NEW.objective IS DISTINCT FROM OLD.objective OR
+        NEW.assessment IS DISTINCT FROM OLD.assessment OR
+        NEW.plan IS DISTINCT FROM OLD.plan OR
+        NEW.status IS DISTINCT FROM OLD.status OR
+        NEW.signed_at IS DISTINCT FROM OLD.signed_at
+    ) THEN
+        RAISE EXCEPTION 'signed clinical note % cannot be modified', OLD.id;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER prevent_signed_clinical_note_update
+    BEFORE UPDATE ON clinical_notes
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_signed_clinical_note_update();
+
+-- Add comments for documentation
+COMMENT ON TABLE clinical_note_templates IS 'Organization-defined SOAP templates; service_id NULL is the organization default';
+COMMENT ON TABLE clinical_notes IS 'SOAP clinical notes, one per appointment, written by the treating doctor';
+COMMENT ON COLUMN clinical_notes.status IS 'draft notes are edited by their author; signed notes are immutable';
+COMMENT ON TABLE clinical_note_amendments IS 'Append-only addenda to signed clinical notes';
@@ -0,0 +1,5 @@
+-- Rollback: Drop treatment plans and their procedures
+DROP TRIGGER IF EXISTS update_treatment_plan_items_updated_at ON treatment_plan_items;
+DROP TRIGGER IF EXISTS update_treatment_plans_updated_at ON treatment_plans;
+DROP TABLE IF EXISTS treatment_plan_items;
+DROP TABLE IF EXISTS treatment_plans;
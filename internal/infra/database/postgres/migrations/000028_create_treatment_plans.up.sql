@@ -0,0 +1,56 @@
+-- Create treatment plans and their phased procedures
+CREATE TABLE treatment_plans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    doctor_id UUID NULL REFERENCES doctors(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    notes TEXT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'presented', 'accepted', 'declined', 'completed', 'cancelled')),
+    presented_at TIMESTAMPTZ NULL,
+    decided_at TIMESTAMPTZ NULL,
+    decision_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ NULL,
+    created_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_treatment_plans_patient ON treatment_plans(organization_id, patient_id);
+
+CREATE TABLE treatment_plan_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    plan_id UUID NOT NULL REFERENCES treatment_plans(id) ON DELETE CASCADE,
+    phase SMALLINT NOT NULL CHECK (phase >= 1),
+    position SMALLINT NOT NULL DEFAULT 0,
+    service_id VARCHAR(255) NOT NULL REFERENCES services(id),
+    tooth SMALLINT NULL,
+    description TEXT NULL,
+    estimated_cost BIGINT NOT NULL DEFAULT 0 CHECK (estimated_cost >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'proposed' CHECK (status IN ('proposed', 'accepted', 'declined', 'scheduled', 'completed')),
+    appointment_id UUID NULL REFERENCES appointments(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_treatment_plan_items_plan ON treatment_plan_items(plan_id, phase, position);
+CREATE UNIQUE INDEX idx_treatment_plan_items_appointment ON treatment_plan_items(appointment_id) WHERE appointment_id IS NOT NULL;
+
+CREATE TRIGGER update_treatment_plans_updated_at
+    BEFORE UPDATE ON treatment_plans
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_treatment_plan_items_updated_at
+    BEFORE UPDATE ON treatment_plan_items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE treatment_plans IS 'Multi-visit treatment quotes per patient';
+COMMENT ON COLUMN treatment_plans.decided_at IS 'When the patient''s acceptance or refusal was recorded';
+COMMENT ON TABLE treatment_plan_items IS 'Planned procedures, ordered by phase and position';
+COMMENT ON COLUMN treatment_plan_items.tooth IS 'Tooth in FDI notation, NULL for procedures not tied to a tooth';
+COMMENT ON COLUMN treatment_plan_items.estimated_cost IS 'Quoted cost in minor currency units';
+COMMENT ON COLUMN treatment_plan_items.appointment_id IS 'Appointment the procedure is booked in; its completion completes the procedure';
@@ -0,0 +1,3 @@
+-- Rollback: Drop patient attachments (stored files are not removed)
+DROP TRIGGER IF EXISTS update_attachments_updated_at ON attachments;
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,34 @@
+-- Create patient attachments (radiographs, photos, consent forms, referral letters)
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    appointment_id UUID NULL REFERENCES appointments(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('radiograph', 'photo', 'consent', 'referral', 'other')),
+    tooth SMALLINT NULL,
+    taken_at TIMESTAMPTZ NULL,
+    description TEXT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    storage_key TEXT NOT NULL UNIQUE,
+    thumbnail_key TEXT NULL,
+    uploaded_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_patient ON attachments(organization_id, patient_id, created_at DESC);
+CREATE INDEX idx_attachments_appointment ON attachments(appointment_id) WHERE appointment_id IS NOT NULL;
+
+CREATE TRIGGER update_attachments_updated_at
+    BEFORE UPDATE ON attachments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE attachments IS 'Metadata of patient files kept in blob storage';
+COMMENT ON COLUMN attachments.tooth IS 'FDI tooth number the image or document refers to';
+COMMENT ON COLUMN attachments.content_type IS 'Detected from the file contents at upload, not declared by the client';
+COMMENT ON COLUMN attachments.storage_key IS 'Object key in the configured blob storage';
+COMMENT ON COLUMN attachments.thumbnail_key IS 'Object key of the JPEG preview generated for images';
@@ -0,0 +1,7 @@
+-- Rollback: Drop consent forms and templates (stored signed copies are not removed)
+DROP TRIGGER IF EXISTS prevent_signed_consent_form_update ON consent_forms;
+DROP FUNCTION IF EXISTS prevent_signed_consent_form_update();
+DROP TRIGGER IF EXISTS update_consent_forms_updated_at ON consent_forms;
+DROP TRIGGER IF EXISTS update_consent_form_templates_updated_at ON consent_form_templates;
+DROP TABLE IF EXISTS consent_forms;
+DROP TABLE IF EXISTS consent_form_templates;
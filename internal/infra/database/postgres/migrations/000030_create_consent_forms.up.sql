@@ -0,0 +1,99 @@
+-- Create consent form templates and the consent forms patients sign through a link
+CREATE TABLE consent_form_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    service_id VARCHAR(255) NULL REFERENCES services(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_consent_form_templates_organization ON consent_form_templates(organization_id, service_id);
+
+CREATE TABLE consent_forms (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    template_id UUID NULL REFERENCES consent_form_templates(id) ON DELETE SET NULL,
+    appointment_id UUID NULL REFERENCES appointments(id) ON DELETE SET NULL,
+    doctor_id UUID NULL REFERENCES doctors(id) ON DELETE SET NULL,
+    service_id VARCHAR(255) NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'signed', 'revoked')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    requested_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    signer_name VARCHAR(255) NULL,
+    signature_type VARCHAR(10) NULL CHECK (signature_type IN ('typed', 'drawn')),
+    signature_data TEXT NULL,
+    signed_ip VARCHAR(64) NULL,
+    signed_user_agent TEXT NULL,
+    signed_at TIMESTAMPTZ NULL,
+    document_key TEXT NULL UNIQUE,
+    document_sha256 CHAR(64) NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    revoked_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    revocation_reason TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (status <> 'signed' OR (signed_at IS NOT NULL AND signature_type IS NOT NULL AND signer_name IS NOT NULL AND document_key IS NOT NULL)),
+    CHECK ((status = 'revoked') = (revoked_at IS NOT NULL))
+);
+
+CREATE INDEX idx_consent_forms_patient ON consent_forms(organization_id, patient_id, created_at DESC);
+CREATE INDEX idx_consent_forms_appointment ON consent_forms(appointment_id) WHERE appointment_id IS NOT NULL;
+
+CREATE TRIGGER update_consent_form_templates_updated_at
+    BEFORE UPDATE ON consent_form_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_consent_forms_updated_at
+    BEFORE UPDATE ON consent_forms
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- The wording of a form never changes, and neither does a signature once recorded;
+-- a signed form may only be revoked (or moved to another patient by a merge)
+CREATE OR REPLACE FUNCTION prevent_signed_consent_form_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.title IS DISTINCT FROM OLD.title OR NEW.body IS DISTINCT FROM OLD.body THEN
+        RAISE EXCEPTION 'consent form % wording cannot be modified', OLD.id;
+    END IF;
+    IF OLD.signed_at IS NOT NULL AND (
+        NEW.signer_name IS DISTINCT FROM OLD.signer_name OR
+        NEW.signature_type IS DISTINCT FROM OLD.signature_type OR
+        NEW.signature_data IS DISTINCT FROM OLD.signature_data OR
+        NEW.signed_ip IS DISTINCT FROM OLD.signed_ip OR
+        NEW.signed_user_agent IS DISTINCT FROM OLD.signed_user_agent OR
+        NEW.signed_at IS DISTINCT FROM OLD.signed_at OR
+        NEW.document_key IS DISTINCT FROM OLD.document_key OR
+        (OLD.document_sha256 IS NOT NULL AND NEW.document_sha256 IS DISTINCT FROM OLD.document_sha256) OR
+        NEW.status NOT IN ('signed', 'revoked')
+    ) THEN
+        RAISE EXCEPTION 'signed consent form % cannot be modified', OLD.id;
+    END IF;
+    IF OLD.status = 'revoked' AND NEW.status <> 'revoked' THEN
+        RAISE EXCEPTION 'revoked consent form % cannot be reinstated', OLD.id;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER prevent_signed_consent_form_update
+    BEFORE UPDATE ON consent_forms
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_signed_consent_form_update();
+
+-- Add comments for documentation
+COMMENT ON TABLE consent_form_templates IS 'Organization-defined informed consent wording; service_id NULL applies to every service';
+COMMENT ON COLUMN consent_form_templates.body IS 'Text with merge fields such as {{patient.name}}, {{doctor.name}} and {{procedure.name}}';
+COMMENT ON COLUMN consent_form_templates.required IS 'Appointments of the service warn until the patient has signed it';
+COMMENT ON TABLE consent_forms IS 'Consent forms rendered for a patient and signed through a public signed link';
+COMMENT ON COLUMN consent_forms.body IS 'Template text with merge fields filled in when the form was requested';
+COMMENT ON COLUMN consent_forms.appointment_id IS 'Appointment the consent is for; NULL covers every visit';
+COMMENT ON COLUMN consent_forms.signature_data IS 'Typed name, or the drawn signature as a PNG data URL';
+COMMENT ON COLUMN consent_forms.document_key IS 'Object key of the immutable signed HTML copy in blob storage';
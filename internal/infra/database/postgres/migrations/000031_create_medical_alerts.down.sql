@@ -0,0 +1,4 @@
+-- Rollback: Drop patient medical alerts and their audit trail
+DROP TABLE IF EXISTS medical_alert_changes;
+DROP TRIGGER IF EXISTS update_medical_alerts_updated_at ON medical_alerts;
+DROP TABLE IF EXISTS medical_alerts;
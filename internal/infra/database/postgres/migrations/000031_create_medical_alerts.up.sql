@@ -0,0 +1,50 @@
+-- Create patient medical alerts (allergies, medications, conditions, pregnancy) and their audit trail
+CREATE TABLE medical_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('allergy', 'medication', 'condition', 'pregnancy', 'other')),
+    title VARCHAR(255) NOT NULL,
+    severity VARCHAR(20) NOT NULL CHECK (severity IN ('low', 'moderate', 'high')),
+    notes TEXT NULL,
+    review_date DATE NULL,
+    last_reviewed_at TIMESTAMPTZ NULL,
+    last_reviewed_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ NULL,
+    resolved_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    updated_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_medical_alerts_patient ON medical_alerts(organization_id, patient_id);
+CREATE INDEX idx_medical_alerts_active ON medical_alerts(organization_id, patient_id) WHERE resolved_at IS NULL;
+
+CREATE TRIGGER update_medical_alerts_updated_at
+    BEFORE UPDATE ON medical_alerts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE medical_alert_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    alert_id UUID NOT NULL REFERENCES medical_alerts(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('created', 'updated', 'reviewed', 'resolved', 'reactivated')),
+    previous JSONB NULL,
+    current JSONB NOT NULL,
+    reason TEXT NULL,
+    changed_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_medical_alert_changes_alert ON medical_alert_changes(alert_id, created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE medical_alerts IS 'Structured medical warnings shown to staff before treating a patient';
+COMMENT ON COLUMN medical_alerts.review_date IS 'Date by which the alert should be confirmed with the patient again';
+COMMENT ON COLUMN medical_alerts.resolved_at IS 'Set when the alert no longer applies; alerts are never deleted';
+COMMENT ON TABLE medical_alert_changes IS 'Audit trail of every change to a medical alert';
+COMMENT ON COLUMN medical_alert_changes.previous IS 'Alert state before the change, NULL on creation';
+COMMENT ON COLUMN medical_alert_changes.current IS 'Alert state after the change';
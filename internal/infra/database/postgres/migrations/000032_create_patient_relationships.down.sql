@@ -0,0 +1,3 @@
+-- Rollback: Drop family relationships between patients
+DROP TRIGGER IF EXISTS update_patient_relationships_updated_at ON patient_relationships;
+DROP TABLE IF EXISTS patient_relationships;
@@ -0,0 +1,28 @@
+-- Create family relationships between patients (guardians, dependents, spouses)
+CREATE TABLE patient_relationships (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    related_patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('guardian', 'dependent', 'spouse')),
+    is_contact BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (patient_id <> related_patient_id),
+    CHECK (NOT is_contact OR type = 'guardian')
+);
+
+CREATE UNIQUE INDEX idx_patient_relationships_pair ON patient_relationships(organization_id, patient_id, related_patient_id);
+CREATE UNIQUE INDEX idx_patient_relationships_contact ON patient_relationships(organization_id, patient_id) WHERE is_contact;
+CREATE INDEX idx_patient_relationships_related ON patient_relationships(related_patient_id);
+
+CREATE TRIGGER update_patient_relationships_updated_at
+    BEFORE UPDATE ON patient_relationships
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE patient_relationships IS 'Family links between patients, stored once in each direction';
+COMMENT ON COLUMN patient_relationships.type IS 'What the related patient is to the patient, e.g. guardian means related_patient_id is the guardian of patient_id';
+COMMENT ON COLUMN patient_relationships.is_contact IS 'Guardian through whom a minor patient is notified; at most one per patient';
@@ -0,0 +1,7 @@
+-- Rollback: Drop invoices, their lines and the payments ledger
+DROP TRIGGER IF EXISTS update_invoices_updated_at ON invoices;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS invoice_lines;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS invoice_sequences;
+ALTER TABLE organizations DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,98 @@
+-- Currency invoices are issued in, per organization
+ALTER TABLE organizations
+    ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'MXN'
+    CHECK (currency ~ '^[A-Z]{3}$');
+
+-- Last invoice number handed out per organization
+CREATE TABLE invoice_sequences (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    last_number BIGINT NOT NULL DEFAULT 0
+);
+
+-- Invoices billing patients for the services of an appointment; amounts in minor units
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    clinic_id UUID NOT NULL REFERENCES clinics(id),
+    patient_id UUID NOT NULL REFERENCES patients(id),
+    appointment_id UUID NULL REFERENCES appointments(id) ON DELETE SET NULL,
+    number VARCHAR(32) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'issued' CHECK (status IN ('issued', 'partially_paid', 'paid', 'void')),
+    subtotal BIGINT NOT NULL CHECK (subtotal >= 0),
+    discount_total BIGINT NOT NULL DEFAULT 0 CHECK (discount_total >= 0),
+    tax_total BIGINT NOT NULL DEFAULT 0 CHECK (tax_total >= 0),
+    total BIGINT NOT NULL CHECK (total >= 0),
+    amount_paid BIGINT NOT NULL DEFAULT 0 CHECK (amount_paid >= 0),
+    amount_refunded BIGINT NOT NULL DEFAULT 0 CHECK (amount_refunded >= 0 AND amount_refunded <= amount_paid),
+    notes TEXT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    voided_at TIMESTAMPTZ NULL,
+    voided_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    void_reason TEXT NULL,
+    created_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_invoices_number ON invoices(organization_id, number);
+CREATE UNIQUE INDEX idx_invoices_appointment ON invoices(appointment_id) WHERE appointment_id IS NOT NULL AND status <> 'void';
+CREATE INDEX idx_invoices_patient ON invoices(organization_id, patient_id, issued_at DESC);
+CREATE INDEX idx_invoices_clinic ON invoices(clinic_id, issued_at DESC);
+
+CREATE TABLE invoice_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    service_id VARCHAR(255) NULL REFERENCES services(id) ON DELETE SET NULL,
+    treatment_plan_item_id UUID NULL REFERENCES treatment_plan_items(id) ON DELETE SET NULL,
+    description TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity >= 1),
+    unit_price BIGINT NOT NULL CHECK (unit_price >= 0),
+    discount BIGINT NOT NULL DEFAULT 0 CHECK (discount >= 0),
+    tax_rate INTEGER NOT NULL DEFAULT 0 CHECK (tax_rate BETWEEN 0 AND 10000),
+    tax BIGINT NOT NULL DEFAULT 0 CHECK (tax >= 0),
+    total BIGINT NOT NULL CHECK (total >= 0)
+);
+
+CREATE INDEX idx_invoice_lines_invoice ON invoice_lines(invoice_id, position);
+
+-- Append-only payments ledger; refunds point to the payment they give money back from
+CREATE TABLE payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    clinic_id UUID NOT NULL REFERENCES clinics(id),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id),
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('payment', 'refund')),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('cash', 'card', 'transfer')),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    refund_of_id UUID NULL REFERENCES payments(id),
+    reference VARCHAR(255) NULL,
+    reason TEXT NULL,
+    received_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((kind = 'refund') = (refund_of_id IS NOT NULL))
+);
+
+CREATE INDEX idx_payments_invoice ON payments(invoice_id, received_at);
+CREATE INDEX idx_payments_clinic_received ON payments(clinic_id, received_at);
+CREATE INDEX idx_payments_refund_of ON payments(refund_of_id) WHERE refund_of_id IS NOT NULL;
+CREATE INDEX idx_payments_patient ON payments(patient_id);
+
+CREATE TRIGGER update_invoices_updated_at
+    BEFORE UPDATE ON invoices
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON COLUMN organizations.currency IS 'ISO 4217 currency code invoices are issued in';
+COMMENT ON TABLE invoices IS 'Patient invoices; amounts are in minor units of currency';
+COMMENT ON COLUMN invoices.number IS 'Sequential per organization, handed out from invoice_sequences';
+COMMENT ON COLUMN invoices.amount_paid IS 'Sum of the payments on the invoice, kept in sync with the payments ledger';
+COMMENT ON COLUMN invoices.amount_refunded IS 'Sum of the refunds on the invoice, kept in sync with the payments ledger';
+COMMENT ON COLUMN invoice_lines.tax_rate IS 'Tax in basis points of the discounted line amount, e.g. 1600 = 16%';
+COMMENT ON TABLE payments IS 'Append-only ledger of money received and given back per invoice';
+COMMENT ON COLUMN payments.received_at IS 'When the money changed hands; the daily cash close groups by it';
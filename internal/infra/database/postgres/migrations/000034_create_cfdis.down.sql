@@ -0,0 +1,7 @@
+-- Rollback: Drop CFDI and the fiscal data of patients and organizations
+DROP TRIGGER IF EXISTS update_cfdis_updated_at ON cfdis;
+DROP TRIGGER IF EXISTS update_organization_fiscal_profiles_updated_at ON organization_fiscal_profiles;
+DROP TRIGGER IF EXISTS update_patient_fiscal_profiles_updated_at ON patient_fiscal_profiles;
+DROP TABLE IF EXISTS cfdis;
+DROP TABLE IF EXISTS organization_fiscal_profiles;
+DROP TABLE IF EXISTS patient_fiscal_profiles;
@@ -0,0 +1,78 @@
+-- Fiscal data patients' CFDI are issued to, per organization
+CREATE TABLE patient_fiscal_profiles (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    rfc VARCHAR(13) NOT NULL,
+    legal_name VARCHAR(300) NOT NULL,
+    tax_regime VARCHAR(3) NOT NULL,
+    postal_code VARCHAR(5) NOT NULL,
+    cfdi_use VARCHAR(3) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, patient_id)
+);
+
+-- Fiscal data organizations issue their CFDI with
+CREATE TABLE organization_fiscal_profiles (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    rfc VARCHAR(13) NOT NULL,
+    legal_name VARCHAR(300) NOT NULL,
+    tax_regime VARCHAR(3) NOT NULL,
+    postal_code VARCHAR(5) NOT NULL,
+    product_code VARCHAR(8) NOT NULL DEFAULT '85121600',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Invoices stamped as CFDI 4.0, with the XML certified by the PAC
+CREATE TABLE cfdis (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id),
+    uuid VARCHAR(36) NOT NULL,
+    status VARCHAR(25) NOT NULL DEFAULT 'stamped' CHECK (status IN ('stamped', 'cancellation_pending', 'cancelled')),
+    issuer_rfc VARCHAR(13) NOT NULL,
+    receiver_rfc VARCHAR(13) NOT NULL,
+    total BIGINT NOT NULL CHECK (total >= 0),
+    xml TEXT NOT NULL,
+    stamped_at TIMESTAMPTZ NOT NULL,
+    cancellation_reason VARCHAR(2) NULL CHECK (cancellation_reason IN ('01', '02', '03', '04')),
+    replacement_uuid VARCHAR(36) NULL,
+    cancellation_requested_at TIMESTAMPTZ NULL,
+    cancelled_at TIMESTAMPTZ NULL,
+    cancelled_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_cfdis_uuid ON cfdis(uuid);
+CREATE UNIQUE INDEX idx_cfdis_invoice_active ON cfdis(invoice_id) WHERE status <> 'cancelled';
+CREATE INDEX idx_cfdis_invoice ON cfdis(invoice_id, stamped_at);
+CREATE INDEX idx_cfdis_patient ON cfdis(patient_id);
+
+CREATE TRIGGER update_patient_fiscal_profiles_updated_at
+    BEFORE UPDATE ON patient_fiscal_profiles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_organization_fiscal_profiles_updated_at
+    BEFORE UPDATE ON organization_fiscal_profiles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_cfdis_updated_at
+    BEFORE UPDATE ON cfdis
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE patient_fiscal_profiles IS 'RFC, legal name, regime, postal code and CFDI use patients are invoiced to';
+COMMENT ON COLUMN patient_fiscal_profiles.tax_regime IS 'SAT c_RegimenFiscal code';
+COMMENT ON COLUMN patient_fiscal_profiles.cfdi_use IS 'SAT c_UsoCFDI code, e.g. D01 for medical expenses';
+COMMENT ON TABLE organization_fiscal_profiles IS 'Issuer data of the organization; its postal code is the place of issue';
+COMMENT ON COLUMN organization_fiscal_profiles.product_code IS 'SAT c_ClaveProdServ code billed on every concept';
+COMMENT ON TABLE cfdis IS 'Stamped CFDI of invoices; an invoice has at most one that is not cancelled';
+COMMENT ON COLUMN cfdis.uuid IS 'Folio fiscal assigned when the PAC stamped the CFDI';
+COMMENT ON COLUMN cfdis.xml IS 'XML as stamped, with the TimbreFiscalDigital complement';
@@ -0,0 +1,8 @@
+-- Rollback: Drop insurance carriers, policies and claims
+DROP TRIGGER IF EXISTS update_insurance_claims_updated_at ON insurance_claims;
+DROP TRIGGER IF EXISTS update_insurance_policies_updated_at ON insurance_policies;
+DROP TRIGGER IF EXISTS update_insurance_carriers_updated_at ON insurance_carriers;
+DROP TABLE IF EXISTS insurance_claims;
+DROP TABLE IF EXISTS insurance_policies;
+DROP TABLE IF EXISTS insurance_carriers;
+ALTER TABLE services DROP COLUMN IF EXISTS category;
@@ -0,0 +1,98 @@
+-- Category insurance plans cover each service under
+ALTER TABLE services
+    ADD COLUMN category VARCHAR(20) NOT NULL DEFAULT 'basic'
+    CHECK (category IN ('preventive', 'basic', 'major', 'orthodontic', 'cosmetic'));
+
+CREATE TABLE insurance_carriers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    payer_id VARCHAR(50) NULL,
+    phone VARCHAR(50) NULL,
+    email VARCHAR(255) NULL,
+    notes TEXT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_insurance_carriers_organization ON insurance_carriers(organization_id, name);
+
+-- Patients' coverage with a carrier; amounts in minor units of the organization's currency
+CREATE TABLE insurance_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    carrier_id UUID NOT NULL REFERENCES insurance_carriers(id),
+    member_id VARCHAR(100) NOT NULL,
+    group_number VARCHAR(100) NULL,
+    subscriber_name VARCHAR(255) NULL,
+    coverage JSONB NOT NULL DEFAULT '{}',
+    annual_maximum BIGINT NULL CHECK (annual_maximum >= 0),
+    deductible BIGINT NOT NULL DEFAULT 0 CHECK (deductible >= 0),
+    effective_from DATE NOT NULL,
+    effective_to DATE NULL CHECK (effective_to >= effective_from),
+    created_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_insurance_policies_patient ON insurance_policies(organization_id, patient_id);
+CREATE INDEX idx_insurance_policies_carrier ON insurance_policies(carrier_id);
+
+CREATE TABLE insurance_claims (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    patient_id UUID NOT NULL REFERENCES patients(id),
+    policy_id UUID NOT NULL REFERENCES insurance_policies(id),
+    appointment_id UUID NOT NULL REFERENCES appointments(id),
+    invoice_id UUID NULL REFERENCES invoices(id) ON DELETE SET NULL,
+    claim_number VARCHAR(100) NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'submitted' CHECK (status IN ('submitted', 'approved', 'paid', 'rejected')),
+    currency VARCHAR(3) NOT NULL,
+    billed_amount BIGINT NOT NULL CHECK (billed_amount > 0),
+    estimated_amount BIGINT NOT NULL CHECK (estimated_amount >= 0),
+    deductible_applied BIGINT NOT NULL DEFAULT 0 CHECK (deductible_applied >= 0),
+    approved_amount BIGINT NULL CHECK (approved_amount >= 0),
+    paid_amount BIGINT NULL CHECK (paid_amount >= 0),
+    rejection_reason TEXT NULL,
+    service_date DATE NOT NULL,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    decided_at TIMESTAMPTZ NULL,
+    paid_at TIMESTAMPTZ NULL,
+    notes TEXT NULL,
+    created_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_insurance_claims_appointment ON insurance_claims(policy_id, appointment_id) WHERE status <> 'rejected';
+CREATE INDEX idx_insurance_claims_organization ON insurance_claims(organization_id, status, submitted_at DESC);
+CREATE INDEX idx_insurance_claims_policy_date ON insurance_claims(policy_id, service_date);
+CREATE INDEX idx_insurance_claims_patient ON insurance_claims(patient_id);
+CREATE INDEX idx_insurance_claims_appointment_lookup ON insurance_claims(appointment_id);
+
+CREATE TRIGGER update_insurance_carriers_updated_at
+    BEFORE UPDATE ON insurance_carriers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_insurance_policies_updated_at
+    BEFORE UPDATE ON insurance_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_insurance_claims_updated_at
+    BEFORE UPDATE ON insurance_claims
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON COLUMN services.category IS 'Insurance category: preventive, basic, major, orthodontic or cosmetic';
+COMMENT ON TABLE insurance_carriers IS 'Dental insurance companies patients of the organization hold policies with';
+COMMENT ON TABLE insurance_policies IS 'Patient coverage with a carrier; benefits renew every calendar year';
+COMMENT ON COLUMN insurance_policies.coverage IS 'Percentage the carrier pays per service category, e.g. {"preventive": 100, "basic": 80}';
+COMMENT ON COLUMN insurance_policies.annual_maximum IS 'Most the carrier pays per calendar year; NULL for no maximum';
+COMMENT ON COLUMN insurance_policies.deductible IS 'Paid by the patient each year before coverage applies; preventive care is exempt';
+COMMENT ON TABLE insurance_claims IS 'Claims to carriers for the services of an appointment';
+COMMENT ON COLUMN insurance_claims.estimated_amount IS 'Insurer portion estimated when the claim was submitted';
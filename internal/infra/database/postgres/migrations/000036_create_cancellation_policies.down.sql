@@ -0,0 +1,10 @@
+-- Rollback: Drop cancellation reasons, policies and fees
+DROP TRIGGER IF EXISTS update_cancellation_fees_updated_at ON cancellation_fees;
+DROP TRIGGER IF EXISTS update_cancellation_policies_updated_at ON cancellation_policies;
+DROP TRIGGER IF EXISTS update_cancellation_reasons_updated_at ON cancellation_reasons;
+DROP TABLE IF EXISTS cancellation_fees;
+DROP TABLE IF EXISTS cancellation_policies;
+ALTER TABLE appointments DROP COLUMN IF EXISTS cancellation_reason_id;
+DROP TRIGGER IF EXISTS seed_organization_cancellation_reasons ON organizations;
+DROP FUNCTION IF EXISTS seed_cancellation_reasons();
+DROP TABLE IF EXISTS cancellation_reasons;
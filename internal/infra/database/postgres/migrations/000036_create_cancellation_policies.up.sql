@@ -0,0 +1,121 @@
+-- Managed list of reasons staff pick from when cancelling an appointment
+CREATE TABLE cancellation_reasons (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('patient_request', 'illness', 'emergency', 'weather', 'clinic', 'other')),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_cancellation_reasons_label ON cancellation_reasons(organization_id, LOWER(label));
+
+-- Every organization starts with a default list of reasons
+CREATE OR REPLACE FUNCTION seed_cancellation_reasons()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO cancellation_reasons (organization_id, label, category)
+    VALUES
+        (NEW.id, 'Patient request', 'patient_request'),
+        (NEW.id, 'Schedule conflict', 'patient_request'),
+        (NEW.id, 'Patient illness', 'illness'),
+        (NEW.id, 'Family emergency', 'emergency'),
+        (NEW.id, 'Severe weather', 'weather'),
+        (NEW.id, 'Doctor unavailable', 'clinic'),
+        (NEW.id, 'Clinic closure', 'clinic'),
+        (NEW.id, 'Other', 'other');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER seed_organization_cancellation_reasons
+    AFTER INSERT ON organizations
+    FOR EACH ROW
+    EXECUTE FUNCTION seed_cancellation_reasons();
+
+INSERT INTO cancellation_reasons (organization_id, label, category)
+SELECT o.id, r.label, r.category
+FROM organizations o
+CROSS JOIN (VALUES
+    ('Patient request', 'patient_request'),
+    ('Schedule conflict', 'patient_request'),
+    ('Patient illness', 'illness'),
+    ('Family emergency', 'emergency'),
+    ('Severe weather', 'weather'),
+    ('Doctor unavailable', 'clinic'),
+    ('Clinic closure', 'clinic'),
+    ('Other', 'other')
+) AS r(label, category);
+
+ALTER TABLE appointments
+    ADD COLUMN cancellation_reason_id UUID NULL REFERENCES cancellation_reasons(id) ON DELETE SET NULL;
+
+-- One late-cancellation policy per organization; without a row no fees are charged
+CREATE TABLE cancellation_policies (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    notice_hours INTEGER NOT NULL DEFAULT 24 CHECK (notice_hours BETWEEN 1 AND 720),
+    fee_type VARCHAR(20) NOT NULL DEFAULT 'fixed' CHECK (fee_type IN ('fixed', 'percentage')),
+    fee_amount BIGINT NOT NULL DEFAULT 0 CHECK (fee_amount >= 0),
+    fee_percent INTEGER NOT NULL DEFAULT 0 CHECK (fee_percent BETWEEN 0 AND 100),
+    exempt_categories TEXT[] NOT NULL DEFAULT '{clinic,emergency}',
+    updated_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Charges for late cancellations; amounts in minor units
+CREATE TABLE cancellation_fees (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    clinic_id UUID NOT NULL REFERENCES clinics(id),
+    patient_id UUID NOT NULL REFERENCES patients(id),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    reason_id UUID NULL REFERENCES cancellation_reasons(id) ON DELETE SET NULL,
+    reason TEXT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'waived', 'invoiced')),
+    currency VARCHAR(3) NOT NULL,
+    policy_amount BIGINT NOT NULL CHECK (policy_amount > 0),
+    amount BIGINT NOT NULL CHECK (amount >= 0 AND amount <= policy_amount),
+    notice_minutes INTEGER NOT NULL,
+    appointment_start TIMESTAMPTZ NOT NULL,
+    cancelled_at TIMESTAMPTZ NOT NULL,
+    cancelled_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    override_justification TEXT NULL,
+    overridden_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    overridden_at TIMESTAMPTZ NULL,
+    invoice_id UUID NULL REFERENCES invoices(id) ON DELETE SET NULL,
+    invoiced_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_cancellation_fees_organization ON cancellation_fees(organization_id, cancelled_at DESC);
+CREATE INDEX idx_cancellation_fees_patient ON cancellation_fees(patient_id, status);
+CREATE INDEX idx_cancellation_fees_appointment ON cancellation_fees(appointment_id);
+
+CREATE TRIGGER update_cancellation_reasons_updated_at
+    BEFORE UPDATE ON cancellation_reasons
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_cancellation_policies_updated_at
+    BEFORE UPDATE ON cancellation_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_cancellation_fees_updated_at
+    BEFORE UPDATE ON cancellation_fees
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE cancellation_reasons IS 'Reasons staff pick from when cancelling; the category decides policy exemptions';
+COMMENT ON COLUMN appointments.cancellation_reason_id IS 'Managed reason the appointment was cancelled for; cancellation_reason keeps its label and notes';
+COMMENT ON COLUMN cancellation_policies.notice_hours IS 'Cancellations with less notice than this before the start are late';
+COMMENT ON COLUMN cancellation_policies.fee_amount IS 'Fixed fee in minor units of the organization currency';
+COMMENT ON COLUMN cancellation_policies.fee_percent IS 'Fee as a percentage of the price of the appointment''s procedure';
+COMMENT ON COLUMN cancellation_policies.exempt_categories IS 'Reason categories that never incur a fee';
+COMMENT ON COLUMN cancellation_fees.policy_amount IS 'Fee the policy charged; amount may be lowered by a staff override';
+COMMENT ON COLUMN cancellation_fees.notice_minutes IS 'Notice given before the appointment start; negative when cancelled after it';
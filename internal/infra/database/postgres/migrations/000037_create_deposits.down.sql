@@ -0,0 +1,10 @@
+-- Rollback: Drop deposit policies and appointment deposits
+DROP TRIGGER IF EXISTS update_appointment_deposits_updated_at ON appointment_deposits;
+DROP TRIGGER IF EXISTS update_deposit_policies_updated_at ON deposit_policies;
+ALTER TABLE appointments DROP COLUMN IF EXISTS deposit_status;
+DROP TABLE IF EXISTS appointment_deposits;
+DROP TABLE IF EXISTS deposit_policies;
+ALTER TABLE services
+    DROP COLUMN IF EXISTS deposit_percent,
+    DROP COLUMN IF EXISTS deposit_amount,
+    DROP COLUMN IF EXISTS deposit_type;
@@ -0,0 +1,80 @@
+-- Deposit required to book a service; NULL deposit_type means none
+ALTER TABLE services
+    ADD COLUMN deposit_type VARCHAR(20) NULL CHECK (deposit_type IN ('fixed', 'percentage')),
+    ADD COLUMN deposit_amount BIGINT NOT NULL DEFAULT 0 CHECK (deposit_amount >= 0),
+    ADD COLUMN deposit_percent INTEGER NOT NULL DEFAULT 0 CHECK (deposit_percent BETWEEN 0 AND 100);
+
+-- One deposit policy per organization; without a row no deposits are asked
+CREATE TABLE deposit_policies (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    hold_hours INTEGER NOT NULL DEFAULT 48 CHECK (hold_hours BETWEEN 1 AND 720),
+    cutoff_hours INTEGER NOT NULL DEFAULT 24 CHECK (cutoff_hours BETWEEN 0 AND 720),
+    no_show_threshold INTEGER NOT NULL DEFAULT 2 CHECK (no_show_threshold >= 0),
+    no_show_lookback_days INTEGER NOT NULL DEFAULT 365 CHECK (no_show_lookback_days >= 0),
+    deposit_type VARCHAR(20) NOT NULL DEFAULT 'fixed' CHECK (deposit_type IN ('fixed', 'percentage')),
+    deposit_amount BIGINT NOT NULL DEFAULT 0 CHECK (deposit_amount >= 0),
+    deposit_percent INTEGER NOT NULL DEFAULT 0 CHECK (deposit_percent BETWEEN 0 AND 100),
+    updated_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Prepayments asked before appointments can be confirmed; amounts in minor units
+CREATE TABLE appointment_deposits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    clinic_id UUID NOT NULL REFERENCES clinics(id),
+    patient_id UUID NOT NULL REFERENCES patients(id),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('service', 'reliability', 'manual')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'waived', 'expired', 'cancelled')),
+    currency VARCHAR(3) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    due_at TIMESTAMPTZ NOT NULL,
+    payment_link_id VARCHAR(255) NULL,
+    payment_url TEXT NULL,
+    payment_reference VARCHAR(255) NULL,
+    paid_at TIMESTAMPTZ NULL,
+    invoice_id UUID NULL REFERENCES invoices(id) ON DELETE SET NULL,
+    applied_at TIMESTAMPTZ NULL,
+    waive_reason TEXT NULL,
+    waived_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    waived_at TIMESTAMPTZ NULL,
+    released_at TIMESTAMPTZ NULL,
+    requested_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_appointment_deposits_organization ON appointment_deposits(organization_id, created_at DESC);
+CREATE INDEX idx_appointment_deposits_appointment ON appointment_deposits(appointment_id);
+CREATE INDEX idx_appointment_deposits_patient ON appointment_deposits(patient_id);
+CREATE INDEX idx_appointment_deposits_due ON appointment_deposits(due_at) WHERE status = 'pending';
+CREATE UNIQUE INDEX idx_appointment_deposits_payment_link ON appointment_deposits(payment_link_id) WHERE payment_link_id IS NOT NULL;
+-- An appointment is held for at most one deposit at a time
+CREATE UNIQUE INDEX idx_appointment_deposits_active ON appointment_deposits(appointment_id) WHERE status IN ('pending', 'paid');
+
+ALTER TABLE appointments
+    ADD COLUMN deposit_status VARCHAR(20) NULL CHECK (deposit_status IN ('pending', 'paid', 'waived', 'expired', 'cancelled'));
+
+CREATE TRIGGER update_deposit_policies_updated_at
+    BEFORE UPDATE ON deposit_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_appointment_deposits_updated_at
+    BEFORE UPDATE ON appointment_deposits
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON COLUMN services.deposit_amount IS 'Fixed deposit in minor units of the organization currency';
+COMMENT ON COLUMN services.deposit_percent IS 'Deposit as a percentage of the price of the appointment''s procedure';
+COMMENT ON COLUMN deposit_policies.hold_hours IS 'How long a slot is held after booking while its deposit is unpaid';
+COMMENT ON COLUMN deposit_policies.cutoff_hours IS 'Unpaid holds are released at the latest this long before the appointment starts';
+COMMENT ON COLUMN deposit_policies.no_show_threshold IS 'No-shows after which patients are asked for a deposit; 0 disables the rule';
+COMMENT ON COLUMN deposit_policies.no_show_lookback_days IS 'Window no-shows are counted in; 0 counts the whole history';
+COMMENT ON COLUMN appointment_deposits.due_at IS 'When the held slot is released if the deposit is not paid';
+COMMENT ON COLUMN appointment_deposits.invoice_id IS 'Invoice the paid deposit was applied to as a payment';
+COMMENT ON COLUMN appointments.deposit_status IS 'Status of the appointment''s latest deposit; NULL when none was asked';
@@ -0,0 +1,6 @@
+-- Rollback: Drop recall rules, recalls and their outreach
+DROP TRIGGER IF EXISTS update_recalls_updated_at ON recalls;
+DROP TRIGGER IF EXISTS update_recall_rules_updated_at ON recall_rules;
+DROP TABLE IF EXISTS recall_outreach;
+DROP TABLE IF EXISTS recalls;
+DROP TABLE IF EXISTS recall_rules;
@@ -0,0 +1,73 @@
+-- Recall rules: completing an appointment for the service schedules the patient's next visit
+CREATE TABLE recall_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    service_id VARCHAR(255) NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    interval_months INTEGER NOT NULL CHECK (interval_months BETWEEN 1 AND 60),
+    qualifying_service_ids TEXT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One rule per service
+CREATE UNIQUE INDEX idx_recall_rules_service ON recall_rules(organization_id, service_id);
+
+-- Patients due back for a service under a recall rule
+CREATE TABLE recalls (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    clinic_id UUID NULL REFERENCES clinics(id) ON DELETE SET NULL,
+    patient_id UUID NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    rule_id UUID NOT NULL REFERENCES recall_rules(id) ON DELETE CASCADE,
+    service_id VARCHAR(255) NOT NULL,
+    source_appointment_id UUID NULL REFERENCES appointments(id) ON DELETE SET NULL,
+    due_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'booked', 'dismissed')),
+    outreach_status VARCHAR(20) NOT NULL DEFAULT 'not_contacted' CHECK (outreach_status IN ('not_contacted', 'contacted', 'left_message', 'no_answer', 'declined')),
+    outreach_attempts INTEGER NOT NULL DEFAULT 0,
+    last_contacted_at TIMESTAMPTZ NULL,
+    booked_appointment_id UUID NULL REFERENCES appointments(id) ON DELETE SET NULL,
+    dismiss_reason TEXT NULL,
+    closed_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    closed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_recalls_worklist ON recalls(organization_id, due_date) WHERE status = 'open';
+CREATE INDEX idx_recalls_patient ON recalls(patient_id, status);
+CREATE INDEX idx_recalls_booked_appointment ON recalls(booked_appointment_id) WHERE booked_appointment_id IS NOT NULL;
+-- A patient is recalled at most once at a time under each rule
+CREATE UNIQUE INDEX idx_recalls_open ON recalls(patient_id, rule_id) WHERE status = 'open';
+
+-- Contact attempts made to bring recalled patients back
+CREATE TABLE recall_outreach (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recall_id UUID NOT NULL REFERENCES recalls(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('phone', 'sms', 'email', 'whatsapp', 'in_person')),
+    outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('contacted', 'left_message', 'no_answer', 'declined')),
+    notes TEXT NULL,
+    contacted_by UUID NULL REFERENCES profiles(id) ON DELETE SET NULL,
+    contacted_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_recall_outreach_recall ON recall_outreach(recall_id, contacted_at DESC);
+
+CREATE TRIGGER update_recall_rules_updated_at
+    BEFORE UPDATE ON recall_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_recalls_updated_at
+    BEFORE UPDATE ON recalls
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON COLUMN recall_rules.interval_months IS 'Months after the completed appointment the patient is due back';
+COMMENT ON COLUMN recall_rules.qualifying_service_ids IS 'Other services whose booking also closes the recall; the rule''s own service always does';
+COMMENT ON COLUMN recalls.source_appointment_id IS 'Completed appointment the recall was scheduled from';
+COMMENT ON COLUMN recalls.booked_appointment_id IS 'Appointment that closed the recall; cancelling it reopens the recall';
+COMMENT ON COLUMN recalls.outreach_status IS 'Outcome of the latest contact attempt';
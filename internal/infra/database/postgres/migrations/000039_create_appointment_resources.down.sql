@@ -0,0 +1,4 @@
+-- Rollback: Drop appointment resources and equipment
+DROP TRIGGER IF EXISTS update_equipment_updated_at ON equipment;
+DROP TABLE IF EXISTS appointment_resources;
+DROP TABLE IF EXISTS equipment;
@@ -0,0 +1,46 @@
+-- Movable equipment of a clinic (intraoral scanners, surgical motors, sedation units)
+CREATE TABLE equipment (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    clinic_id UUID NOT NULL REFERENCES clinics(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_equipment_clinic ON equipment(clinic_id);
+
+-- Staff, units and equipment an appointment books besides its doctor and unit
+CREATE TABLE appointment_resources (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    resource_type VARCHAR(20) NOT NULL CHECK (resource_type IN ('staff', 'unit', 'equipment')),
+    staff_id UUID NULL REFERENCES doctors(id) ON DELETE CASCADE,
+    unit_id UUID NULL REFERENCES units(id) ON DELETE CASCADE,
+    equipment_id UUID NULL REFERENCES equipment(id) ON DELETE CASCADE,
+    role VARCHAR(20) NULL CHECK (role IN ('doctor', 'assistant', 'hygienist')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (
+        (resource_type = 'staff' AND staff_id IS NOT NULL AND unit_id IS NULL AND equipment_id IS NULL AND role IS NOT NULL) OR
+        (resource_type = 'unit' AND unit_id IS NOT NULL AND staff_id IS NULL AND equipment_id IS NULL AND role IS NULL) OR
+        (resource_type = 'equipment' AND equipment_id IS NOT NULL AND staff_id IS NULL AND unit_id IS NULL AND role IS NULL)
+    )
+);
+
+CREATE INDEX idx_appointment_resources_appointment ON appointment_resources(appointment_id);
+CREATE INDEX idx_appointment_resources_staff ON appointment_resources(staff_id) WHERE staff_id IS NOT NULL;
+CREATE INDEX idx_appointment_resources_unit ON appointment_resources(unit_id) WHERE unit_id IS NOT NULL;
+CREATE INDEX idx_appointment_resources_equipment ON appointment_resources(equipment_id) WHERE equipment_id IS NOT NULL;
+-- A resource is booked once per appointment
+CREATE UNIQUE INDEX idx_appointment_resources_unique ON appointment_resources(appointment_id, COALESCE(staff_id, unit_id, equipment_id));
+
+CREATE TRIGGER update_equipment_updated_at
+    BEFORE UPDATE ON equipment
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON TABLE appointment_resources IS 'Additional resources of an appointment; its doctor_id and unit_id stay the primary doctor and chair';
+COMMENT ON COLUMN appointment_resources.staff_id IS 'Member of the organization''s staff roster (doctors table)';
+COMMENT ON COLUMN appointment_resources.role IS 'Role the staff member plays in the appointment';
@@ -0,0 +1,3 @@
+-- Rollback: Drop the stored ETag of idempotent responses
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS etag;
@@ -0,0 +1,6 @@
+-- Replayed responses keep the ETag of the original, so clients can send If-Match
+-- on their next write without fetching the resource again
+ALTER TABLE idempotency_keys
+    ADD COLUMN etag TEXT NULL;
+
+COMMENT ON COLUMN idempotency_keys.etag IS 'ETag header of the stored response, if any';
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// APIKeyPostgresRepository implements the APIKeyRepository interface
+type APIKeyPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyPostgresRepository creates a new instance of APIKeyPostgresRepository
+func NewAPIKeyPostgresRepository(db *sql.DB) repositories.APIKeyRepository {
+	return &APIKeyPostgresRepository{db: db}
+}
+
+const apiKeyColumns = `id, organization_id, name, prefix, key_hash, scopes, created_by, expires_at, last_used_at, revoked_at, created_at, updated_at`
+
+// Create creates a new API key
+func (r *APIKeyPostgresRepository) Create(ctx context.Context, apiKey *entities.APIKey) error {
+	query := `
+		INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		apiKey.ID,
+		apiKey.OrganizationID,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.KeyHash,
+		pq.Array(apiKey.Scopes),
+		apiKey.CreatedBy,
+		apiKey.ExpiresAt,
+		apiKey.LastUsedAt,
+		apiKey.RevokedAt,
+		apiKey.CreatedAt,
+		apiKey.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an API key by its ID
+func (r *APIKeyPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	apiKey, err := r.scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// GetByPrefix retrieves an API key by its public prefix
+func (r *APIKeyPostgresRepository) GetByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE prefix = $1`
+
+	apiKey, err := r.scanAPIKey(r.db.QueryRowContext(ctx, query, prefix))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key by prefix: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// GetByOrganizationID retrieves all API keys for an organization, newest first
+func (r *APIKeyPostgresRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE organization_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var apiKeys []*entities.APIKey
+	for rows.Next() {
+		apiKey, err := r.scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over API key rows: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// Update updates an existing API key
+func (r *APIKeyPostgresRepository) Update(ctx context.Context, apiKey *entities.APIKey) error {
+	query := `
+		UPDATE api_keys
+		SET name = $2, scopes = $3, expires_at = $4, revoked_at = $5, updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		apiKey.ID,
+		apiKey.Name,
+		pq.Array(apiKey.Scopes),
+		apiKey.ExpiresAt,
+		apiKey.RevokedAt,
+		apiKey.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update API key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastUsed records when an API key was last used
+func (r *APIKeyPostgresRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, usedAt); err != nil {
+		return fmt.Errorf("failed to update API key last used: %w", err)
+	}
+
+	return nil
+}
+
+// scanAPIKey scans a single API key from a row
+func (r *APIKeyPostgresRepository) scanAPIKey(row interface{ Scan(...interface{}) error }) (*entities.APIKey, error) {
+	var apiKey entities.APIKey
+
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.OrganizationID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.KeyHash,
+		&apiKey.Scopes,
+		&apiKey.CreatedBy,
+		&apiKey.ExpiresAt,
+		&apiKey.LastUsedAt,
+		&apiKey.RevokedAt,
+		&apiKey.CreatedAt,
+		&apiKey.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
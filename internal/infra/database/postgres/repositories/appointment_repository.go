@@ -10,6 +10,7 @@ import (
 	"dental-scheduler-backend/internal/domain/ports/repositories"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // AppointmentPostgresRepository implements the AppointmentRepository interface
@@ -25,10 +26,11 @@ func NewAppointmentPostgresRepository(db *sql.DB) repositories.AppointmentReposi
 // Create creates a new appointment
 func (r *AppointmentPostgresRepository) Create(ctx context.Context, appointment *entities.Appointment) error {
 	query := `
-		INSERT INTO appointments (id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+		INSERT INTO appointments (id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, deposit_status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING version`
 
-	_, err := r.db.ExecContext(ctx, query,
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
 		appointment.ID,
 		appointment.PatientID,
 		appointment.DoctorID,
@@ -38,29 +40,30 @@ func (r *AppointmentPostgresRepository) Create(ctx context.Context, appointment
 		appointment.StartTime,
 		appointment.EndTime,
 		appointment.Notes,
+		appointment.DepositStatus,
 		appointment.CreatedAt,
 		appointment.UpdatedAt,
-	)
+	).Scan(&appointment.Version)
 
 	if err != nil {
 		return fmt.Errorf("failed to create appointment: %w", err)
 	}
 
-	return nil
+	return r.insertResources(ctx, appointment.Resources)
 }
 
 // GetByID retrieves an appointment by its ID
 func (r *AppointmentPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Appointment, error) {
 	query := `
-		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at
+		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, cancellation_reason, cancellation_reason_id, deposit_status, created_at, updated_at, version
 		FROM appointments
 		WHERE id = $1`
 
 	var appointment entities.Appointment
 	var status string
-	var patientID, doctorID, unitID sql.NullString
+	var patientID, doctorID, unitID, depositStatus sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&appointment.ID,
 		&patientID,
 		&doctorID,
@@ -70,8 +73,12 @@ func (r *AppointmentPostgresRepository) GetByID(ctx context.Context, id uuid.UUI
 		&appointment.StartTime,
 		&appointment.EndTime,
 		&appointment.Notes,
+		&appointment.CancellationReason,
+		&appointment.CancellationReasonID,
+		&depositStatus,
 		&appointment.CreatedAt,
 		&appointment.UpdatedAt,
+		&appointment.Version,
 	)
 
 	if err != nil {
@@ -97,19 +104,27 @@ func (r *AppointmentPostgresRepository) GetByID(ctx context.Context, id uuid.UUI
 			appointment.UnitID = &parsedID
 		}
 	}
+	if depositStatus.Valid {
+		deposit := entities.DepositStatus(depositStatus.String)
+		appointment.DepositStatus = &deposit
+	}
 
 	appointment.Status = entities.AppointmentStatus(status)
+
+	if appointment.Resources, err = r.getResources(ctx, appointment.ID); err != nil {
+		return nil, err
+	}
 	return &appointment, nil
 }
 
 // GetAll retrieves all appointments
 func (r *AppointmentPostgresRepository) GetAll(ctx context.Context) ([]*entities.Appointment, error) {
 	query := `
-		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at
+		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, cancellation_reason, cancellation_reason_id, deposit_status, created_at, updated_at, version
 		FROM appointments
 		ORDER BY start_time`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get appointments: %w", err)
 	}
@@ -121,12 +136,12 @@ func (r *AppointmentPostgresRepository) GetAll(ctx context.Context) ([]*entities
 // GetByPatientID retrieves all appointments for a patient
 func (r *AppointmentPostgresRepository) GetByPatientID(ctx context.Context, patientID uuid.UUID) ([]*entities.Appointment, error) {
 	query := `
-		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at
+		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, cancellation_reason, cancellation_reason_id, deposit_status, created_at, updated_at, version
 		FROM appointments
 		WHERE patient_id = $1
 		ORDER BY start_time`
 
-	rows, err := r.db.QueryContext(ctx, query, patientID)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, patientID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get appointments by patient ID: %w", err)
 	}
@@ -138,12 +153,12 @@ func (r *AppointmentPostgresRepository) GetByPatientID(ctx context.Context, pati
 // GetByDoctorID retrieves all appointments for a doctor
 func (r *AppointmentPostgresRepository) GetByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]*entities.Appointment, error) {
 	query := `
-		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at
+		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, cancellation_reason, cancellation_reason_id, deposit_status, created_at, updated_at, version
 		FROM appointments
 		WHERE doctor_id = $1
 		ORDER BY start_time`
 
-	rows, err := r.db.QueryContext(ctx, query, doctorID)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, doctorID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get appointments by doctor ID: %w", err)
 	}
@@ -155,12 +170,12 @@ func (r *AppointmentPostgresRepository) GetByDoctorID(ctx context.Context, docto
 // GetByUnitID retrieves all appointments for a unit
 func (r *AppointmentPostgresRepository) GetByUnitID(ctx context.Context, unitID uuid.UUID) ([]*entities.Appointment, error) {
 	query := `
-		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at
+		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, cancellation_reason, cancellation_reason_id, deposit_status, created_at, updated_at, version
 		FROM appointments
 		WHERE unit_id = $1
 		ORDER BY start_time`
 
-	rows, err := r.db.QueryContext(ctx, query, unitID)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, unitID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get appointments by unit ID: %w", err)
 	}
@@ -176,12 +191,12 @@ func (r *AppointmentPostgresRepository) GetByDoctorIDAndDate(ctx context.Context
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
-		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at
+		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, cancellation_reason, cancellation_reason_id, deposit_status, created_at, updated_at, version
 		FROM appointments
 		WHERE doctor_id = $1 AND start_time >= $2 AND start_time < $3
 		ORDER BY start_time`
 
-	rows, err := r.db.QueryContext(ctx, query, doctorID, startOfDay, endOfDay)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, doctorID, startOfDay, endOfDay)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get appointments by doctor ID and date: %w", err)
 	}
@@ -193,12 +208,12 @@ func (r *AppointmentPostgresRepository) GetByDoctorIDAndDate(ctx context.Context
 // GetUpcoming retrieves all upcoming appointments
 func (r *AppointmentPostgresRepository) GetUpcoming(ctx context.Context) ([]*entities.Appointment, error) {
 	query := `
-		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at
+		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, cancellation_reason, cancellation_reason_id, deposit_status, created_at, updated_at, version
 		FROM appointments
 		WHERE start_time > NOW() AND status = 'scheduled'
 		ORDER BY start_time`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get upcoming appointments: %w", err)
 	}
@@ -207,17 +222,20 @@ func (r *AppointmentPostgresRepository) GetUpcoming(ctx context.Context) ([]*ent
 	return r.scanAppointments(rows)
 }
 
-// Update updates an existing appointment
+// Update updates an existing appointment if its stored version still matches
+// appointment.Version, and sets appointment.Version to the new version
 func (r *AppointmentPostgresRepository) Update(ctx context.Context, appointment *entities.Appointment) error {
 	query := `
 		UPDATE appointments
 		SET patient_id = $2, doctor_id = $3, unit_id = $4, service_id = $5, status = $6, 
 		    start_time = $7, end_time = $8, notes = $9, 
 		    moved_to_needs_rescheduling_at = $10, rescheduled_to_appointment_id = $11, 
-		    cancellation_reason = $12, snoozed_until = $13, updated_at = $14
-		WHERE id = $1`
+		    cancellation_reason = $12, snoozed_until = $13, updated_at = $14, cancellation_reason_id = $16,
+		    deposit_status = $17
+		WHERE id = $1 AND version = $15
+		RETURNING version`
 
-	result, err := r.db.ExecContext(ctx, query,
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
 		appointment.ID,
 		appointment.PatientID,
 		appointment.DoctorID,
@@ -232,19 +250,24 @@ func (r *AppointmentPostgresRepository) Update(ctx context.Context, appointment
 		appointment.CancellationReason,
 		appointment.SnoozedUntil,
 		appointment.UpdatedAt,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to update appointment: %w", err)
+		appointment.Version,
+		appointment.CancellationReasonID,
+		appointment.DepositStatus,
+	).Scan(&appointment.Version)
+
+	if err == sql.ErrNoRows {
+		var exists bool
+		existsQuery := `SELECT EXISTS (SELECT 1 FROM appointments WHERE id = $1)`
+		if err := executor(ctx, r.db).QueryRowContext(ctx, existsQuery, appointment.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check appointment existence: %w", err)
+		}
+		if exists {
+			return entities.ErrAppointmentVersionConflict
+		}
+		return entities.ErrAppointmentNotFound
 	}
-
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return entities.ErrAppointmentNotFound
+		return fmt.Errorf("failed to update appointment: %w", err)
 	}
 
 	return nil
@@ -254,7 +277,7 @@ func (r *AppointmentPostgresRepository) Update(ctx context.Context, appointment
 func (r *AppointmentPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM appointments WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete appointment: %w", err)
 	}
@@ -276,20 +299,22 @@ func (r *AppointmentPostgresRepository) CheckConflict(ctx context.Context, docto
 	query := `
 		SELECT COUNT(*)
 		FROM appointments
-		WHERE status = 'scheduled'
-		  AND (doctor_id = $1 OR unit_id = $2)
+		WHERE status = ANY($5)
+		  AND (doctor_id = $1 OR unit_id = $2 OR id IN (
+			SELECT appointment_id FROM appointment_resources WHERE staff_id = $1 OR unit_id = $2
+		  ))
 		  AND start_time < $4
 		  AND end_time > $3`
 
-	args := []interface{}{doctorID, unitID, startTime, endTime}
+	args := []interface{}{doctorID, unitID, startTime, endTime, pq.Array(entities.SlotHoldingStatuses())}
 
 	if excludeAppointmentID != nil {
-		query += " AND id != $5"
+		query += " AND id != $6"
 		args = append(args, *excludeAppointmentID)
 	}
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check appointment conflict: %w", err)
 	}
@@ -300,23 +325,25 @@ func (r *AppointmentPostgresRepository) CheckConflict(ctx context.Context, docto
 // GetConflictingAppointments returns appointments that conflict with the given time range
 func (r *AppointmentPostgresRepository) GetConflictingAppointments(ctx context.Context, doctorID, unitID uuid.UUID, startTime, endTime time.Time, excludeAppointmentID *uuid.UUID) ([]*entities.Appointment, error) {
 	query := `
-		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, created_at, updated_at
+		SELECT id, patient_id, doctor_id, unit_id, service_id, status, start_time, end_time, notes, cancellation_reason, cancellation_reason_id, deposit_status, created_at, updated_at, version
 		FROM appointments
-		WHERE status = 'scheduled'
-		  AND (doctor_id = $1 OR unit_id = $2)
+		WHERE status = ANY($5)
+		  AND (doctor_id = $1 OR unit_id = $2 OR id IN (
+			SELECT appointment_id FROM appointment_resources WHERE staff_id = $1 OR unit_id = $2
+		  ))
 		  AND start_time < $4
 		  AND end_time > $3`
 
-	args := []interface{}{doctorID, unitID, startTime, endTime}
+	args := []interface{}{doctorID, unitID, startTime, endTime, pq.Array(entities.SlotHoldingStatuses())}
 
 	if excludeAppointmentID != nil {
-		query += " AND id != $5"
+		query += " AND id != $6"
 		args = append(args, *excludeAppointmentID)
 	}
 
 	query += " ORDER BY start_time"
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get conflicting appointments: %w", err)
 	}
@@ -331,6 +358,7 @@ func (r *AppointmentPostgresRepository) scanAppointments(rows *sql.Rows) ([]*ent
 	for rows.Next() {
 		var appointment entities.Appointment
 		var status string
+		var depositStatus sql.NullString
 		err := rows.Scan(
 			&appointment.ID,
 			&appointment.PatientID,
@@ -341,13 +369,21 @@ func (r *AppointmentPostgresRepository) scanAppointments(rows *sql.Rows) ([]*ent
 			&appointment.StartTime,
 			&appointment.EndTime,
 			&appointment.Notes,
+			&appointment.CancellationReason,
+			&appointment.CancellationReasonID,
+			&depositStatus,
 			&appointment.CreatedAt,
 			&appointment.UpdatedAt,
+			&appointment.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan appointment: %w", err)
 		}
 		appointment.Status = entities.AppointmentStatus(status)
+		if depositStatus.Valid {
+			deposit := entities.DepositStatus(depositStatus.String)
+			appointment.DepositStatus = &deposit
+		}
 		appointments = append(appointments, &appointment)
 	}
 
@@ -358,6 +394,217 @@ func (r *AppointmentPostgresRepository) scanAppointments(rows *sql.Rows) ([]*ent
 	return appointments, nil
 }
 
+// ReplaceResources replaces the additional resources of an appointment
+func (r *AppointmentPostgresRepository) ReplaceResources(ctx context.Context, appointmentID uuid.UUID, resources []*entities.AppointmentResource) error {
+	if _, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM appointment_resources WHERE appointment_id = $1`, appointmentID); err != nil {
+		return fmt.Errorf("failed to clear appointment resources: %w", err)
+	}
+	return r.insertResources(ctx, resources)
+}
+
+// CheckResourceConflict checks if a resource is held by another appointment in the time range
+func (r *AppointmentPostgresRepository) CheckResourceConflict(ctx context.Context, resourceType entities.ResourceType, resourceID uuid.UUID, startTime, endTime time.Time, excludeAppointmentID *uuid.UUID) (bool, error) {
+	var held string
+	switch resourceType {
+	case entities.ResourceTypeStaff:
+		held = "(doctor_id = $1 OR id IN (SELECT appointment_id FROM appointment_resources WHERE staff_id = $1))"
+	case entities.ResourceTypeUnit:
+		held = "(unit_id = $1 OR id IN (SELECT appointment_id FROM appointment_resources WHERE unit_id = $1))"
+	case entities.ResourceTypeEquipment:
+		held = "id IN (SELECT appointment_id FROM appointment_resources WHERE equipment_id = $1)"
+	default:
+		return false, entities.ErrInvalidResourceType
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM appointments
+		WHERE status = ANY($4)
+		  AND ` + held + `
+		  AND start_time < $3
+		  AND end_time > $2`
+
+	args := []interface{}{resourceID, startTime, endTime, pq.Array(entities.SlotHoldingStatuses())}
+
+	if excludeAppointmentID != nil {
+		query += " AND id != $5"
+		args = append(args, *excludeAppointmentID)
+	}
+
+	var count int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check resource conflict: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// GetResourceBookings retrieves the resources held by a clinic's appointments in the time range
+func (r *AppointmentPostgresRepository) GetResourceBookings(ctx context.Context, clinicID uuid.UUID, startTime, endTime time.Time) ([]*repositories.ResourceBooking, error) {
+	query := `
+		WITH clinic_appointments AS (
+			SELECT a.*
+			FROM appointments a
+			JOIN units u ON u.id = a.unit_id
+			WHERE u.clinic_id = $1
+			  AND a.start_time < $3
+			  AND a.end_time > $2
+			  AND a.status NOT IN ('cancelled', 'needs-rescheduling')
+			  AND a.rescheduled_to_appointment_id IS NULL
+		), held AS (
+			SELECT id AS appointment_id, 'staff' AS resource_type, doctor_id AS resource_id, 'doctor' AS role
+			FROM clinic_appointments
+			WHERE doctor_id IS NOT NULL
+			UNION ALL
+			SELECT id, 'unit', unit_id, NULL
+			FROM clinic_appointments
+			UNION ALL
+			SELECT ar.appointment_id, ar.resource_type, COALESCE(ar.staff_id, ar.unit_id, ar.equipment_id), ar.role
+			FROM appointment_resources ar
+			JOIN clinic_appointments ca ON ca.id = ar.appointment_id
+		)
+		SELECT a.id, a.patient_id, a.doctor_id, a.unit_id, a.service_id, a.status, a.start_time, a.end_time, a.notes,
+			a.cancellation_reason, a.cancellation_reason_id, a.deposit_status, a.created_at, a.updated_at, a.version,
+			p.first_name, p.last_name, h.resource_type, h.resource_id, h.role
+		FROM held h
+		JOIN clinic_appointments a ON a.id = h.appointment_id
+		LEFT JOIN patients p ON p.id = a.patient_id
+		ORDER BY a.start_time, h.resource_type, h.resource_id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, clinicID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*repositories.ResourceBooking
+	for rows.Next() {
+		var appointment entities.Appointment
+		var status, resourceType string
+		var depositStatus, firstName, role sql.NullString
+		var lastName *string
+		var resourceID uuid.UUID
+		if err := rows.Scan(
+			&appointment.ID,
+			&appointment.PatientID,
+			&appointment.DoctorID,
+			&appointment.UnitID,
+			&appointment.ServiceID,
+			&status,
+			&appointment.StartTime,
+			&appointment.EndTime,
+			&appointment.Notes,
+			&appointment.CancellationReason,
+			&appointment.CancellationReasonID,
+			&depositStatus,
+			&appointment.CreatedAt,
+			&appointment.UpdatedAt,
+			&appointment.Version,
+			&firstName,
+			&lastName,
+			&resourceType,
+			&resourceID,
+			&role,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan resource booking: %w", err)
+		}
+		appointment.Status = entities.AppointmentStatus(status)
+		if depositStatus.Valid {
+			deposit := entities.DepositStatus(depositStatus.String)
+			appointment.DepositStatus = &deposit
+		}
+
+		booking := &repositories.ResourceBooking{
+			Appointment:  &appointment,
+			ResourceType: entities.ResourceType(resourceType),
+			ResourceID:   resourceID,
+		}
+		if appointment.PatientID != nil && firstName.Valid {
+			booking.Patient = &entities.Patient{ID: *appointment.PatientID, FirstName: firstName.String, LastName: lastName}
+		}
+		if role.Valid {
+			appointmentRole := entities.AppointmentRole(role.String)
+			booking.Role = &appointmentRole
+		}
+		bookings = append(bookings, booking)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate resource bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// getResources retrieves the additional resources of an appointment in the order they were booked
+func (r *AppointmentPostgresRepository) getResources(ctx context.Context, appointmentID uuid.UUID) ([]*entities.AppointmentResource, error) {
+	query := `
+		SELECT id, appointment_id, resource_type, COALESCE(staff_id, unit_id, equipment_id), role, created_at
+		FROM appointment_resources
+		WHERE appointment_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, appointmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get appointment resources: %w", err)
+	}
+	defer rows.Close()
+
+	var resources []*entities.AppointmentResource
+	for rows.Next() {
+		var resource entities.AppointmentResource
+		var resourceType string
+		var role sql.NullString
+		if err := rows.Scan(&resource.ID, &resource.AppointmentID, &resourceType, &resource.ResourceID, &role, &resource.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan appointment resource: %w", err)
+		}
+		resource.Type = entities.ResourceType(resourceType)
+		if role.Valid {
+			appointmentRole := entities.AppointmentRole(role.String)
+			resource.Role = &appointmentRole
+		}
+		resources = append(resources, &resource)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate appointment resources: %w", err)
+	}
+
+	return resources, nil
+}
+
+// insertResources stores additional resources, each in the column of its type
+func (r *AppointmentPostgresRepository) insertResources(ctx context.Context, resources []*entities.AppointmentResource) error {
+	query := `
+		INSERT INTO appointment_resources (id, appointment_id, resource_type, staff_id, unit_id, equipment_id, role, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	for _, resource := range resources {
+		var staffID, unitID, equipmentID *uuid.UUID
+		switch resource.Type {
+		case entities.ResourceTypeStaff:
+			staffID = &resource.ResourceID
+		case entities.ResourceTypeUnit:
+			unitID = &resource.ResourceID
+		case entities.ResourceTypeEquipment:
+			equipmentID = &resource.ResourceID
+		}
+
+		if _, err := executor(ctx, r.db).ExecContext(ctx, query,
+			resource.ID,
+			resource.AppointmentID,
+			resource.Type,
+			staffID,
+			unitID,
+			equipmentID,
+			resource.Role,
+			resource.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to create appointment resource: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // GetByOrganizationAndDateRange retrieves appointments for an organization within a date range with filters
 func (r *AppointmentPostgresRepository) GetByOrganizationAndDateRange(ctx context.Context, orgID uuid.UUID, startDate, endDate time.Time, filters repositories.AppointmentFilters) ([]*repositories.AppointmentWithDetails, int, error) {
 	// Build the base query with joins
@@ -400,7 +647,7 @@ func (r *AppointmentPostgresRepository) GetByOrganizationAndDateRange(ctx contex
 	countQuery := "SELECT COUNT(*) " + baseQuery + whereConditions
 
 	var totalCount int
-	err := r.db.QueryRowContext(ctx, countQuery, params...).Scan(&totalCount)
+	err := executor(ctx, r.db).QueryRowContext(ctx, countQuery, params...).Scan(&totalCount)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count appointments: %w", err)
 	}
@@ -409,7 +656,7 @@ func (r *AppointmentPostgresRepository) GetByOrganizationAndDateRange(ctx contex
 	selectFields := `
 		SELECT 
 			a.id, a.patient_id, a.doctor_id, a.unit_id, a.service_id, a.status, 
-			a.start_time, a.end_time, a.notes, a.created_at, a.updated_at,
+			a.start_time, a.end_time, a.notes, a.created_at, a.updated_at, a.version, a.deposit_status,
 			s.name as service_name,
 			p.id, p.first_name, p.last_name, p.phone, p.email, p.first_appointment_id, p.created_at, p.updated_at,
 			d.id, d.organization_id, d.user_id, d.name, d.specialty, d.email, d.phone, d.is_active, d.created_at, d.updated_at,
@@ -429,7 +676,7 @@ func (r *AppointmentPostgresRepository) GetByOrganizationAndDateRange(ctx contex
 
 	fullQuery := selectFields + " " + baseQuery + whereConditions + orderBy
 
-	rows, err := r.db.QueryContext(ctx, fullQuery, params...)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, fullQuery, params...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query appointments: %w", err)
 	}
@@ -440,7 +687,7 @@ func (r *AppointmentPostgresRepository) GetByOrganizationAndDateRange(ctx contex
 	for rows.Next() {
 		var appointment entities.Appointment
 		var status string
-		var serviceName sql.NullString
+		var serviceName, depositStatus sql.NullString
 
 		// Nullable appointment foreign keys
 		var patientID, doctorID, unitID sql.NullString
@@ -476,6 +723,8 @@ func (r *AppointmentPostgresRepository) GetByOrganizationAndDateRange(ctx contex
 			&appointment.Notes,
 			&appointment.CreatedAt,
 			&appointment.UpdatedAt,
+			&appointment.Version,
+			&depositStatus,
 			// Service name
 			&serviceName,
 			// Patient fields
@@ -539,6 +788,10 @@ func (r *AppointmentPostgresRepository) GetByOrganizationAndDateRange(ctx contex
 
 		// Set appointment status
 		appointment.Status = entities.AppointmentStatus(status)
+		if depositStatus.Valid {
+			deposit := entities.DepositStatus(depositStatus.String)
+			appointment.DepositStatus = &deposit
+		}
 
 		// Convert serviceName to pointer
 		var serviceNamePtr *string
@@ -735,7 +988,7 @@ func (r *AppointmentPostgresRepository) GetReschedulingQueue(ctx context.Context
 	// Count query
 	countQuery := "SELECT COUNT(*) " + baseQuery + whereConditions
 	var totalCount int
-	err := r.db.QueryRowContext(ctx, countQuery, params...).Scan(&totalCount)
+	err := executor(ctx, r.db).QueryRowContext(ctx, countQuery, params...).Scan(&totalCount)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count rescheduling queue appointments: %w", err)
 	}
@@ -745,7 +998,7 @@ func (r *AppointmentPostgresRepository) GetReschedulingQueue(ctx context.Context
 		SELECT 
 			a.id, a.patient_id, a.doctor_id, a.unit_id, a.service_id, a.status, 
 			a.start_time, a.end_time, a.notes, a.moved_to_needs_rescheduling_at,
-			a.rescheduled_to_appointment_id, a.cancellation_reason, a.created_at, a.updated_at,
+			a.rescheduled_to_appointment_id, a.cancellation_reason, a.created_at, a.updated_at, a.version,
 			s.name as service_name,
 			p.id, p.first_name, p.last_name, p.phone, p.email, p.first_appointment_id, p.created_at, p.updated_at,
 			d.id, d.organization_id, d.user_id, d.name, d.specialty, d.email, d.phone, d.is_active, d.created_at, d.updated_at,
@@ -771,7 +1024,7 @@ func (r *AppointmentPostgresRepository) GetReschedulingQueue(ctx context.Context
 
 	fullQuery := selectFields + " " + baseQuery + whereConditions + orderBy
 
-	rows, err := r.db.QueryContext(ctx, fullQuery, params...)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, fullQuery, params...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query rescheduling queue: %w", err)
 	}
@@ -826,6 +1079,7 @@ func (r *AppointmentPostgresRepository) GetReschedulingQueue(ctx context.Context
 			&cancellationReason,
 			&appointment.CreatedAt,
 			&appointment.UpdatedAt,
+			&appointment.Version,
 			// Service name
 			&serviceName,
 			// Patient fields
@@ -1061,15 +1315,16 @@ func (r *AppointmentPostgresRepository) GetReschedulingQueue(ctx context.Context
 }
 
 // CancelWithReason cancels an appointment and stores the cancellation reason
-func (r *AppointmentPostgresRepository) CancelWithReason(ctx context.Context, appointmentID uuid.UUID, reason string) error {
+func (r *AppointmentPostgresRepository) CancelWithReason(ctx context.Context, appointmentID uuid.UUID, reasonID *uuid.UUID, reason string) error {
 	query := `
 		UPDATE appointments
 		SET status = 'cancelled',
 		    cancellation_reason = $1,
+		    cancellation_reason_id = $3,
 		    updated_at = NOW()
 		WHERE id = $2 AND status = 'needs-rescheduling'`
 
-	result, err := r.db.ExecContext(ctx, query, reason, appointmentID)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, reason, appointmentID, reasonID)
 	if err != nil {
 		return fmt.Errorf("failed to cancel appointment: %w", err)
 	}
@@ -1094,7 +1349,7 @@ func (r *AppointmentPostgresRepository) SnoozeAppointment(ctx context.Context, a
 		    updated_at = NOW()
 		WHERE id = $2 AND status = 'needs-rescheduling'`
 
-	result, err := r.db.ExecContext(ctx, query, until, appointmentID)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, until, appointmentID)
 	if err != nil {
 		return fmt.Errorf("failed to snooze appointment: %w", err)
 	}
@@ -1110,3 +1365,124 @@ func (r *AppointmentPostgresRepository) SnoozeAppointment(ctx context.Context, a
 
 	return nil
 }
+
+// UpdateDepositStatus stores the status of the appointment's latest deposit
+func (r *AppointmentPostgresRepository) UpdateDepositStatus(ctx context.Context, appointmentID uuid.UUID, status *entities.DepositStatus) error {
+	query := `
+		UPDATE appointments
+		SET deposit_status = $1,
+		    updated_at = NOW()
+		WHERE id = $2`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, status, appointmentID)
+	if err != nil {
+		return fmt.Errorf("failed to update appointment deposit status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrAppointmentNotFound
+	}
+
+	return nil
+}
+
+// ReassignPatient moves all appointments of one patient to another and returns how many were moved
+func (r *AppointmentPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) (int, error) {
+	query := `
+		UPDATE appointments
+		SET patient_id = $2,
+		    updated_at = NOW()
+		WHERE patient_id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign appointments: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetPatientHistory retrieves a patient's appointments within an organization, newest first.
+// Only the doctor, unit and clinic names and IDs are filled in the details.
+func (r *AppointmentPostgresRepository) GetPatientHistory(ctx context.Context, orgID, patientID uuid.UUID) ([]*repositories.AppointmentWithDetails, error) {
+	query := `
+		SELECT a.id, a.patient_id, a.doctor_id, a.unit_id, a.service_id, a.status, a.start_time, a.end_time, a.notes, a.created_at, a.updated_at, a.version,
+			s.name, d.name, u.name, c.id, c.name
+		FROM appointments a
+		LEFT JOIN units u ON a.unit_id = u.id
+		LEFT JOIN clinics c ON u.clinic_id = c.id
+		LEFT JOIN doctors d ON a.doctor_id = d.id
+		LEFT JOIN services s ON a.service_id = s.id
+		WHERE a.patient_id = $2
+		AND (c.organization_id = $1 OR (a.unit_id IS NULL AND d.organization_id = $1))
+		ORDER BY a.start_time DESC`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, patientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get patient appointment history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*repositories.AppointmentWithDetails
+	for rows.Next() {
+		var appointment entities.Appointment
+		var status string
+		var serviceName, doctorName, unitName, clinicName sql.NullString
+		var clinicID uuid.NullUUID
+
+		err := rows.Scan(
+			&appointment.ID,
+			&appointment.PatientID,
+			&appointment.DoctorID,
+			&appointment.UnitID,
+			&appointment.ServiceID,
+			&status,
+			&appointment.StartTime,
+			&appointment.EndTime,
+			&appointment.Notes,
+			&appointment.CreatedAt,
+			&appointment.UpdatedAt,
+			&appointment.Version,
+			&serviceName,
+			&doctorName,
+			&unitName,
+			&clinicID,
+			&clinicName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan appointment: %w", err)
+		}
+		appointment.Status = entities.AppointmentStatus(status)
+
+		details := &repositories.AppointmentWithDetails{Appointment: &appointment}
+		if serviceName.Valid {
+			details.ServiceName = &serviceName.String
+		}
+		if appointment.DoctorID != nil && doctorName.Valid {
+			details.Doctor = &entities.Doctor{ID: *appointment.DoctorID, Name: doctorName.String}
+		}
+		if appointment.UnitID != nil && unitName.Valid {
+			details.Unit = &entities.Unit{ID: *appointment.UnitID, Name: unitName.String}
+		}
+		if clinicID.Valid {
+			details.Clinic = &entities.Clinic{ID: clinicID.UUID, Name: clinicName.String}
+		}
+		history = append(history, details)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over appointment rows: %w", err)
+	}
+
+	return history, nil
+}
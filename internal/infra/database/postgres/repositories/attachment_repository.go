@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentPostgresRepository implements the AttachmentRepository interface
+type AttachmentPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewAttachmentPostgresRepository creates a new instance of AttachmentPostgresRepository
+func NewAttachmentPostgresRepository(db *sql.DB) repositories.AttachmentRepository {
+	return &AttachmentPostgresRepository{db: db}
+}
+
+const attachmentColumns = `id, organization_id, patient_id, appointment_id, type, tooth, taken_at, description, file_name, content_type, size_bytes, storage_key, thumbnail_key, uploaded_by, created_at, updated_at`
+
+// Create stores the metadata of an uploaded file
+func (r *AttachmentPostgresRepository) Create(ctx context.Context, attachment *entities.Attachment) error {
+	query := `
+		INSERT INTO attachments (` + attachmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		attachment.ID,
+		attachment.OrganizationID,
+		attachment.PatientID,
+		attachment.AppointmentID,
+		string(attachment.Type),
+		toothValue(attachment.Tooth),
+		attachment.TakenAt,
+		attachment.Description,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.StorageKey,
+		attachment.ThumbnailKey,
+		attachment.UploadedBy,
+		attachment.CreatedAt,
+		attachment.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an attachment of the organization
+func (r *AttachmentPostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE organization_id = $1 AND id = $2`
+
+	attachment, err := r.scanAttachment(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// List retrieves a page of attachments, most recently taken first, and the total count
+func (r *AttachmentPostgresRepository) List(ctx context.Context, filters repositories.AttachmentFilters) ([]*entities.Attachment, int, error) {
+	params := []interface{}{filters.OrganizationID}
+	conditions := []string{"organization_id = $1"}
+
+	if filters.PatientID != nil {
+		params = append(params, *filters.PatientID)
+		conditions = append(conditions, fmt.Sprintf("patient_id = $%d", len(params)))
+	}
+	if filters.AppointmentID != nil {
+		params = append(params, *filters.AppointmentID)
+		conditions = append(conditions, fmt.Sprintf("appointment_id = $%d", len(params)))
+	}
+	if filters.Type != nil {
+		params = append(params, string(*filters.Type))
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(params)))
+	}
+	if filters.Tooth != nil {
+		params = append(params, int(*filters.Tooth))
+		conditions = append(conditions, fmt.Sprintf("tooth = $%d", len(params)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM attachments WHERE "+where, params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count attachments: %w", err)
+	}
+
+	params = append(params, filters.Limit, (filters.Page-1)*filters.Limit)
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE ` + where + `
+		ORDER BY COALESCE(taken_at, created_at) DESC, id
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(params)-1, len(params))
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []*entities.Attachment
+	for rows.Next() {
+		attachment, err := r.scanAttachment(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over attachment rows: %w", err)
+	}
+
+	return attachments, total, nil
+}
+
+// Update saves the descriptive metadata of an attachment
+func (r *AttachmentPostgresRepository) Update(ctx context.Context, attachment *entities.Attachment) error {
+	query := `
+		UPDATE attachments
+		SET appointment_id = $3, type = $4, tooth = $5, taken_at = $6, description = $7
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		attachment.OrganizationID,
+		attachment.ID,
+		attachment.AppointmentID,
+		string(attachment.Type),
+		toothValue(attachment.Tooth),
+		attachment.TakenAt,
+		attachment.Description,
+	).Scan(&attachment.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update attachment: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the metadata of an attachment
+func (r *AttachmentPostgresRepository) Delete(ctx context.Context, orgID, id uuid.UUID) error {
+	query := `DELETE FROM attachments WHERE organization_id = $1 AND id = $2`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, orgID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// ReassignPatient moves all attachments of one patient to another
+func (r *AttachmentPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	query := `UPDATE attachments SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign attachments: %w", err)
+	}
+
+	return nil
+}
+
+// scanAttachment scans a single attachment from a row
+func (r *AttachmentPostgresRepository) scanAttachment(row interface{ Scan(...interface{}) error }) (*entities.Attachment, error) {
+	var attachment entities.Attachment
+	var attachmentType string
+	var tooth sql.NullInt16
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.OrganizationID,
+		&attachment.PatientID,
+		&attachment.AppointmentID,
+		&attachmentType,
+		&tooth,
+		&attachment.TakenAt,
+		&attachment.Description,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.SizeBytes,
+		&attachment.StorageKey,
+		&attachment.ThumbnailKey,
+		&attachment.UploadedBy,
+		&attachment.CreatedAt,
+		&attachment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.Type = entities.AttachmentType(attachmentType)
+	if tooth.Valid {
+		t := entities.Tooth(tooth.Int16)
+		attachment.Tooth = &t
+	}
+
+	return &attachment, nil
+}
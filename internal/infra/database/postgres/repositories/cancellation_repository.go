@@ -0,0 +1,402 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// CancellationPostgresRepository implements the CancellationRepository interface
+type CancellationPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewCancellationPostgresRepository creates a new instance of CancellationPostgresRepository
+func NewCancellationPostgresRepository(db *sql.DB) repositories.CancellationRepository {
+	return &CancellationPostgresRepository{db: db}
+}
+
+const cancellationReasonColumns = `id, organization_id, label, category, active, created_at, updated_at`
+
+const cancellationPolicyColumns = `organization_id, enabled, notice_hours, fee_type, fee_amount, fee_percent, exempt_categories, updated_by, created_at, updated_at`
+
+const cancellationFeeColumns = `id, organization_id, clinic_id, patient_id, appointment_id, reason_id, reason, status, currency, policy_amount, amount, notice_minutes, appointment_start, cancelled_at, cancelled_by, override_justification, overridden_by, overridden_at, invoice_id, invoiced_at, created_at, updated_at`
+
+// CreateReason stores a cancellation reason
+func (r *CancellationPostgresRepository) CreateReason(ctx context.Context, reason *entities.CancellationReason) error {
+	query := `
+		INSERT INTO cancellation_reasons (` + cancellationReasonColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		reason.ID,
+		reason.OrganizationID,
+		reason.Label,
+		string(reason.Category),
+		reason.Active,
+		reason.CreatedAt,
+		reason.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create cancellation reason: %w", err)
+	}
+
+	return nil
+}
+
+// GetReason retrieves a cancellation reason of the organization
+func (r *CancellationPostgresRepository) GetReason(ctx context.Context, orgID, id uuid.UUID) (*entities.CancellationReason, error) {
+	query := `SELECT ` + cancellationReasonColumns + ` FROM cancellation_reasons WHERE organization_id = $1 AND id = $2`
+
+	reason, err := r.scanReason(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation reason: %w", err)
+	}
+
+	return reason, nil
+}
+
+// ListReasons retrieves the organization's cancellation reasons by label
+func (r *CancellationPostgresRepository) ListReasons(ctx context.Context, orgID uuid.UUID, includeInactive bool) ([]*entities.CancellationReason, error) {
+	query := `
+		SELECT ` + cancellationReasonColumns + `
+		FROM cancellation_reasons
+		WHERE organization_id = $1 AND (active OR $2)
+		ORDER BY label, id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cancellation reasons: %w", err)
+	}
+	defer rows.Close()
+
+	var reasons []*entities.CancellationReason
+	for rows.Next() {
+		reason, err := r.scanReason(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cancellation reason: %w", err)
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over cancellation reason rows: %w", err)
+	}
+
+	return reasons, nil
+}
+
+// UpdateReason saves the fields of a cancellation reason
+func (r *CancellationPostgresRepository) UpdateReason(ctx context.Context, reason *entities.CancellationReason) error {
+	query := `
+		UPDATE cancellation_reasons
+		SET label = $3, category = $4, active = $5
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		reason.OrganizationID,
+		reason.ID,
+		reason.Label,
+		string(reason.Category),
+		reason.Active,
+	).Scan(&reason.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrCancellationReasonNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update cancellation reason: %w", err)
+	}
+
+	return nil
+}
+
+// GetPolicy retrieves the organization's cancellation policy, nil when it has not set one
+func (r *CancellationPostgresRepository) GetPolicy(ctx context.Context, orgID uuid.UUID) (*entities.CancellationPolicy, error) {
+	query := `SELECT ` + cancellationPolicyColumns + ` FROM cancellation_policies WHERE organization_id = $1`
+
+	var policy entities.CancellationPolicy
+	var feeType string
+	var exempt pq.StringArray
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID).Scan(
+		&policy.OrganizationID,
+		&policy.Enabled,
+		&policy.NoticeHours,
+		&feeType,
+		&policy.FeeAmount,
+		&policy.FeePercent,
+		&exempt,
+		&policy.UpdatedBy,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation policy: %w", err)
+	}
+
+	policy.FeeType = entities.CancellationFeeType(feeType)
+	policy.ExemptCategories = make([]entities.CancellationCategory, len(exempt))
+	for i, category := range exempt {
+		policy.ExemptCategories[i] = entities.CancellationCategory(category)
+	}
+	return &policy, nil
+}
+
+// SavePolicy creates or replaces the organization's cancellation policy
+func (r *CancellationPostgresRepository) SavePolicy(ctx context.Context, policy *entities.CancellationPolicy) error {
+	exempt := make(pq.StringArray, len(policy.ExemptCategories))
+	for i, category := range policy.ExemptCategories {
+		exempt[i] = string(category)
+	}
+
+	query := `
+		INSERT INTO cancellation_policies (organization_id, enabled, notice_hours, fee_type, fee_amount, fee_percent, exempt_categories, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (organization_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled, notice_hours = EXCLUDED.notice_hours, fee_type = EXCLUDED.fee_type,
+			fee_amount = EXCLUDED.fee_amount, fee_percent = EXCLUDED.fee_percent,
+			exempt_categories = EXCLUDED.exempt_categories, updated_by = EXCLUDED.updated_by
+		RETURNING created_at, updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		policy.OrganizationID,
+		policy.Enabled,
+		policy.NoticeHours,
+		string(policy.FeeType),
+		policy.FeeAmount,
+		policy.FeePercent,
+		exempt,
+		policy.UpdatedBy,
+	).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save cancellation policy: %w", err)
+	}
+
+	return nil
+}
+
+// CreateFee stores a late-cancellation fee
+func (r *CancellationPostgresRepository) CreateFee(ctx context.Context, fee *entities.CancellationFee) error {
+	query := `
+		INSERT INTO cancellation_fees (` + cancellationFeeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		fee.ID,
+		fee.OrganizationID,
+		fee.ClinicID,
+		fee.PatientID,
+		fee.AppointmentID,
+		fee.ReasonID,
+		fee.Reason,
+		string(fee.Status),
+		fee.Currency,
+		fee.PolicyAmount,
+		fee.Amount,
+		fee.NoticeMinutes,
+		fee.AppointmentStart,
+		fee.CancelledAt,
+		fee.CancelledBy,
+		fee.OverrideJustification,
+		fee.OverriddenBy,
+		fee.OverriddenAt,
+		fee.InvoiceID,
+		fee.InvoicedAt,
+		fee.CreatedAt,
+		fee.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create cancellation fee: %w", err)
+	}
+
+	return nil
+}
+
+// GetFee retrieves a fee of the organization
+func (r *CancellationPostgresRepository) GetFee(ctx context.Context, orgID, id uuid.UUID) (*entities.CancellationFee, error) {
+	query := `SELECT ` + cancellationFeeColumns + ` FROM cancellation_fees WHERE organization_id = $1 AND id = $2`
+	return r.getFee(ctx, query, orgID, id)
+}
+
+// GetFeeForUpdate retrieves a fee of the organization and locks it until the transaction in ctx ends
+func (r *CancellationPostgresRepository) GetFeeForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.CancellationFee, error) {
+	query := `SELECT ` + cancellationFeeColumns + ` FROM cancellation_fees WHERE organization_id = $1 AND id = $2 FOR UPDATE`
+	return r.getFee(ctx, query, orgID, id)
+}
+
+// ListFees retrieves a page of fees, most recently cancelled first, and the total count
+func (r *CancellationPostgresRepository) ListFees(ctx context.Context, filters repositories.CancellationFeeFilters) ([]*entities.CancellationFee, int, error) {
+	params := []interface{}{filters.OrganizationID}
+	conditions := []string{"organization_id = $1"}
+
+	if filters.PatientID != nil {
+		params = append(params, *filters.PatientID)
+		conditions = append(conditions, fmt.Sprintf("patient_id = $%d", len(params)))
+	}
+	if filters.Status != nil {
+		params = append(params, string(*filters.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(params)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM cancellation_fees WHERE "+where, params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count cancellation fees: %w", err)
+	}
+
+	params = append(params, filters.Limit, (filters.Page-1)*filters.Limit)
+	query := `
+		SELECT ` + cancellationFeeColumns + `
+		FROM cancellation_fees
+		WHERE ` + where + `
+		ORDER BY cancelled_at DESC, id
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(params)-1, len(params))
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list cancellation fees: %w", err)
+	}
+	defer rows.Close()
+
+	var fees []*entities.CancellationFee
+	for rows.Next() {
+		fee, err := r.scanFee(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan cancellation fee: %w", err)
+		}
+		fees = append(fees, fee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over cancellation fee rows: %w", err)
+	}
+
+	return fees, total, nil
+}
+
+// UpdateFee saves the status, amount, override and invoice of a fee
+func (r *CancellationPostgresRepository) UpdateFee(ctx context.Context, fee *entities.CancellationFee) error {
+	query := `
+		UPDATE cancellation_fees
+		SET status = $3, amount = $4, override_justification = $5, overridden_by = $6, overridden_at = $7,
+			invoice_id = $8, invoiced_at = $9
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		fee.OrganizationID,
+		fee.ID,
+		string(fee.Status),
+		fee.Amount,
+		fee.OverrideJustification,
+		fee.OverriddenBy,
+		fee.OverriddenAt,
+		fee.InvoiceID,
+		fee.InvoicedAt,
+	).Scan(&fee.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrCancellationFeeNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update cancellation fee: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignPatient moves all fees of one patient to another
+func (r *CancellationPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	query := `UPDATE cancellation_fees SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign cancellation fees: %w", err)
+	}
+
+	return nil
+}
+
+// getFee retrieves a single fee
+func (r *CancellationPostgresRepository) getFee(ctx context.Context, query string, args ...interface{}) (*entities.CancellationFee, error) {
+	fee, err := r.scanFee(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation fee: %w", err)
+	}
+
+	return fee, nil
+}
+
+// scanReason scans a cancellation reason row
+func (r *CancellationPostgresRepository) scanReason(row interface{ Scan(...interface{}) error }) (*entities.CancellationReason, error) {
+	var reason entities.CancellationReason
+	var category string
+
+	err := row.Scan(
+		&reason.ID,
+		&reason.OrganizationID,
+		&reason.Label,
+		&category,
+		&reason.Active,
+		&reason.CreatedAt,
+		&reason.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reason.Category = entities.CancellationCategory(category)
+	return &reason, nil
+}
+
+// scanFee scans a cancellation fee row
+func (r *CancellationPostgresRepository) scanFee(row interface{ Scan(...interface{}) error }) (*entities.CancellationFee, error) {
+	var fee entities.CancellationFee
+	var status string
+
+	err := row.Scan(
+		&fee.ID,
+		&fee.OrganizationID,
+		&fee.ClinicID,
+		&fee.PatientID,
+		&fee.AppointmentID,
+		&fee.ReasonID,
+		&fee.Reason,
+		&status,
+		&fee.Currency,
+		&fee.PolicyAmount,
+		&fee.Amount,
+		&fee.NoticeMinutes,
+		&fee.AppointmentStart,
+		&fee.CancelledAt,
+		&fee.CancelledBy,
+		&fee.OverrideJustification,
+		&fee.OverriddenBy,
+		&fee.OverriddenAt,
+		&fee.InvoiceID,
+		&fee.InvoicedAt,
+		&fee.CreatedAt,
+		&fee.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	fee.Status = entities.CancellationFeeStatus(status)
+	return &fee, nil
+}
@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// CFDIPostgresRepository implements the CFDIRepository interface
+type CFDIPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewCFDIPostgresRepository creates a new instance of CFDIPostgresRepository
+func NewCFDIPostgresRepository(db *sql.DB) repositories.CFDIRepository {
+	return &CFDIPostgresRepository{db: db}
+}
+
+// cfdiColumns leaves out the XML, which only GetByID loads
+const cfdiColumns = `id, organization_id, invoice_id, patient_id, uuid, status, issuer_rfc, receiver_rfc, total, stamped_at, cancellation_reason, replacement_uuid, cancellation_requested_at, cancelled_at, cancelled_by, created_by, created_at, updated_at`
+
+// Create stores a stamped CFDI
+func (r *CFDIPostgresRepository) Create(ctx context.Context, cfdi *entities.CFDI) error {
+	query := `
+		INSERT INTO cfdis (` + cfdiColumns + `, xml)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		cfdi.ID,
+		cfdi.OrganizationID,
+		cfdi.InvoiceID,
+		cfdi.PatientID,
+		cfdi.UUID,
+		string(cfdi.Status),
+		cfdi.IssuerRFC,
+		cfdi.ReceiverRFC,
+		cfdi.Total,
+		cfdi.StampedAt,
+		cfdi.CancellationReason,
+		cfdi.ReplacementUUID,
+		cfdi.CancellationRequestedAt,
+		cfdi.CancelledAt,
+		cfdi.CancelledBy,
+		cfdi.CreatedBy,
+		cfdi.CreatedAt,
+		cfdi.UpdatedAt,
+		string(cfdi.XML),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CFDI: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a CFDI of an invoice, with its XML
+func (r *CFDIPostgresRepository) GetByID(ctx context.Context, invoiceID, id uuid.UUID) (*entities.CFDI, error) {
+	query := `SELECT ` + cfdiColumns + `, xml FROM cfdis WHERE invoice_id = $1 AND id = $2`
+
+	var xml string
+	cfdi, err := r.scanCFDI(executor(ctx, r.db).QueryRowContext(ctx, query, invoiceID, id), &xml)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CFDI: %w", err)
+	}
+
+	cfdi.XML = []byte(xml)
+	return cfdi, nil
+}
+
+// GetActiveByInvoice retrieves the CFDI of an invoice that is not cancelled
+func (r *CFDIPostgresRepository) GetActiveByInvoice(ctx context.Context, invoiceID uuid.UUID) (*entities.CFDI, error) {
+	query := `SELECT ` + cfdiColumns + ` FROM cfdis WHERE invoice_id = $1 AND status <> 'cancelled'`
+
+	cfdi, err := r.scanCFDI(executor(ctx, r.db).QueryRowContext(ctx, query, invoiceID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active CFDI: %w", err)
+	}
+
+	return cfdi, nil
+}
+
+// ListByInvoice retrieves the CFDI of an invoice in the order they were stamped
+func (r *CFDIPostgresRepository) ListByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]*entities.CFDI, error) {
+	query := `
+		SELECT ` + cfdiColumns + `
+		FROM cfdis
+		WHERE invoice_id = $1
+		ORDER BY stamped_at, created_at`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CFDI: %w", err)
+	}
+	defer rows.Close()
+
+	var cfdis []*entities.CFDI
+	for rows.Next() {
+		cfdi, err := r.scanCFDI(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan CFDI: %w", err)
+		}
+		cfdis = append(cfdis, cfdi)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over CFDI rows: %w", err)
+	}
+
+	return cfdis, nil
+}
+
+// Update saves the status and cancellation fields of a CFDI
+func (r *CFDIPostgresRepository) Update(ctx context.Context, cfdi *entities.CFDI) error {
+	query := `
+		UPDATE cfdis
+		SET status = $3, cancellation_reason = $4, replacement_uuid = $5, cancellation_requested_at = $6,
+			cancelled_at = $7, cancelled_by = $8
+		WHERE invoice_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		cfdi.InvoiceID,
+		cfdi.ID,
+		string(cfdi.Status),
+		cfdi.CancellationReason,
+		cfdi.ReplacementUUID,
+		cfdi.CancellationRequestedAt,
+		cfdi.CancelledAt,
+		cfdi.CancelledBy,
+	).Scan(&cfdi.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrCFDINotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update CFDI: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignPatient moves all CFDI of one patient to another
+func (r *CFDIPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	query := `UPDATE cfdis SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign CFDI: %w", err)
+	}
+
+	return nil
+}
+
+// scanCFDI scans a single CFDI from a row, followed by any extra columns
+func (r *CFDIPostgresRepository) scanCFDI(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*entities.CFDI, error) {
+	var cfdi entities.CFDI
+	var status string
+	var reason sql.NullString
+
+	dest := []interface{}{
+		&cfdi.ID,
+		&cfdi.OrganizationID,
+		&cfdi.InvoiceID,
+		&cfdi.PatientID,
+		&cfdi.UUID,
+		&status,
+		&cfdi.IssuerRFC,
+		&cfdi.ReceiverRFC,
+		&cfdi.Total,
+		&cfdi.StampedAt,
+		&reason,
+		&cfdi.ReplacementUUID,
+		&cfdi.CancellationRequestedAt,
+		&cfdi.CancelledAt,
+		&cfdi.CancelledBy,
+		&cfdi.CreatedBy,
+		&cfdi.CreatedAt,
+		&cfdi.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	cfdi.Status = entities.CFDIStatus(status)
+	if reason.Valid {
+		cancellationReason := entities.CFDICancellationReason(reason.String)
+		cfdi.CancellationReason = &cancellationReason
+	}
+	return &cfdi, nil
+}
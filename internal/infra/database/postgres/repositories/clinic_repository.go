@@ -24,14 +24,15 @@ func NewClinicPostgresRepository(db *sql.DB) repositories.ClinicRepository {
 // Create creates a new clinic
 func (r *ClinicPostgresRepository) Create(ctx context.Context, clinic *entities.Clinic) error {
 	query := `
-		INSERT INTO clinics (id, name, address, phone, timezone, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		INSERT INTO clinics (id, name, address, phone, phone_e164, timezone, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		clinic.ID,
 		clinic.Name,
 		clinic.Address,
 		clinic.Phone,
+		clinic.PhoneE164,
 		clinic.Timezone,
 		clinic.CreatedAt,
 		clinic.UpdatedAt,
@@ -47,7 +48,7 @@ func (r *ClinicPostgresRepository) Create(ctx context.Context, clinic *entities.
 // GetByID retrieves a clinic by its ID
 func (r *ClinicPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Clinic, error) {
 	query := `
-		SELECT id, name, address, phone, timezone, created_at, updated_at
+		SELECT id, name, address, phone, phone_e164, timezone, created_at, updated_at
 		FROM clinics
 		WHERE id = $1`
 
@@ -57,6 +58,7 @@ func (r *ClinicPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 		&clinic.Name,
 		&clinic.Address,
 		&clinic.Phone,
+		&clinic.PhoneE164,
 		&clinic.Timezone,
 		&clinic.CreatedAt,
 		&clinic.UpdatedAt,
@@ -75,7 +77,7 @@ func (r *ClinicPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 // GetAll retrieves all clinics
 func (r *ClinicPostgresRepository) GetAll(ctx context.Context) ([]*entities.Clinic, error) {
 	query := `
-		SELECT id, name, address, phone, timezone, created_at, updated_at
+		SELECT id, name, address, phone, phone_e164, timezone, created_at, updated_at
 		FROM clinics
 		ORDER BY name`
 
@@ -93,6 +95,7 @@ func (r *ClinicPostgresRepository) GetAll(ctx context.Context) ([]*entities.Clin
 			&clinic.Name,
 			&clinic.Address,
 			&clinic.Phone,
+			&clinic.PhoneE164,
 			&clinic.Timezone,
 			&clinic.CreatedAt,
 			&clinic.UpdatedAt,
@@ -114,7 +117,7 @@ func (r *ClinicPostgresRepository) GetAll(ctx context.Context) ([]*entities.Clin
 func (r *ClinicPostgresRepository) Update(ctx context.Context, clinic *entities.Clinic) error {
 	query := `
 		UPDATE clinics
-		SET name = $2, address = $3, phone = $4, timezone = $5, updated_at = $6
+		SET name = $2, address = $3, phone = $4, phone_e164 = $5, timezone = $6, updated_at = $7
 		WHERE id = $1`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -122,6 +125,7 @@ func (r *ClinicPostgresRepository) Update(ctx context.Context, clinic *entities.
 		clinic.Name,
 		clinic.Address,
 		clinic.Phone,
+		clinic.PhoneE164,
 		clinic.Timezone,
 		clinic.UpdatedAt,
 	)
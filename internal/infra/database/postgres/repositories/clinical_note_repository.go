@@ -0,0 +1,487 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ClinicalNoteTemplatePostgresRepository implements the ClinicalNoteTemplateRepository interface
+type ClinicalNoteTemplatePostgresRepository struct {
+	db *sql.DB
+}
+
+// NewClinicalNoteTemplatePostgresRepository creates a new instance of ClinicalNoteTemplatePostgresRepository
+func NewClinicalNoteTemplatePostgresRepository(db *sql.DB) repositories.ClinicalNoteTemplateRepository {
+	return &ClinicalNoteTemplatePostgresRepository{db: db}
+}
+
+const clinicalNoteTemplateColumns = `id, organization_id, service_id, name, subjective, objective, assessment, plan, created_at, updated_at`
+
+// Create stores a template
+func (r *ClinicalNoteTemplatePostgresRepository) Create(ctx context.Context, template *entities.ClinicalNoteTemplate) error {
+	query := `
+		INSERT INTO clinical_note_templates (` + clinicalNoteTemplateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		template.ID,
+		template.OrganizationID,
+		template.ServiceID,
+		template.Name,
+		template.Subjective,
+		template.Objective,
+		template.Assessment,
+		template.Plan,
+		template.CreatedAt,
+		template.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create clinical note template: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a template of the organization
+func (r *ClinicalNoteTemplatePostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.ClinicalNoteTemplate, error) {
+	query := `SELECT ` + clinicalNoteTemplateColumns + ` FROM clinical_note_templates WHERE organization_id = $1 AND id = $2`
+
+	template, err := r.scanTemplate(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clinical note template: %w", err)
+	}
+
+	return template, nil
+}
+
+// GetByService retrieves the template of a service; a nil service selects the organization default
+func (r *ClinicalNoteTemplatePostgresRepository) GetByService(ctx context.Context, orgID uuid.UUID, serviceID *string) (*entities.ClinicalNoteTemplate, error) {
+	query := `
+		SELECT ` + clinicalNoteTemplateColumns + `
+		FROM clinical_note_templates
+		WHERE organization_id = $1 AND service_id IS NOT DISTINCT FROM $2`
+
+	template, err := r.scanTemplate(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, serviceID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clinical note template for service: %w", err)
+	}
+
+	return template, nil
+}
+
+// List retrieves all templates of the organization
+func (r *ClinicalNoteTemplatePostgresRepository) List(ctx context.Context, orgID uuid.UUID) ([]*entities.ClinicalNoteTemplate, error) {
+	query := `
+		SELECT ` + clinicalNoteTemplateColumns + `
+		FROM clinical_note_templates
+		WHERE organization_id = $1
+		ORDER BY service_id NULLS FIRST, name`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clinical note templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*entities.ClinicalNoteTemplate
+	for rows.Next() {
+		template, err := r.scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan clinical note template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over clinical note template rows: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Update saves the name and sections of a template
+func (r *ClinicalNoteTemplatePostgresRepository) Update(ctx context.Context, template *entities.ClinicalNoteTemplate) error {
+	query := `
+		UPDATE clinical_note_templates
+		SET name = $3, subjective = $4, objective = $5, assessment = $6, plan = $7
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		template.OrganizationID,
+		template.ID,
+		template.Name,
+		template.Subjective,
+		template.Objective,
+		template.Assessment,
+		template.Plan,
+	).Scan(&template.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrClinicalNoteTemplateNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update clinical note template: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a template
+func (r *ClinicalNoteTemplatePostgresRepository) Delete(ctx context.Context, orgID, id uuid.UUID) error {
+	query := `DELETE FROM clinical_note_templates WHERE organization_id = $1 AND id = $2`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, orgID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete clinical note template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrClinicalNoteTemplateNotFound
+	}
+
+	return nil
+}
+
+// scanTemplate scans a single template from a row
+func (r *ClinicalNoteTemplatePostgresRepository) scanTemplate(row interface{ Scan(...interface{}) error }) (*entities.ClinicalNoteTemplate, error) {
+	var template entities.ClinicalNoteTemplate
+
+	err := row.Scan(
+		&template.ID,
+		&template.OrganizationID,
+		&template.ServiceID,
+		&template.Name,
+		&template.Subjective,
+		&template.Objective,
+		&template.Assessment,
+		&template.Plan,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// ClinicalNotePostgresRepository implements the ClinicalNoteRepository interface
+type ClinicalNotePostgresRepository struct {
+	db *sql.DB
+}
+
+// NewClinicalNotePostgresRepository creates a new instance of ClinicalNotePostgresRepository
+func NewClinicalNotePostgresRepository(db *sql.DB) repositories.ClinicalNoteRepository {
+	return &ClinicalNotePostgresRepository{db: db}
+}
+
+const clinicalNoteColumns = `id, organization_id, patient_id, appointment_id, doctor_id, author_id, template_id, subjective, objective, assessment, plan, status, signed_at, created_at, updated_at`
+
+const clinicalNoteAmendmentColumns = `id, note_id, author_id, doctor_id, content, reason, created_at`
+
+// Create stores a draft note
+func (r *ClinicalNotePostgresRepository) Create(ctx context.Context, note *entities.ClinicalNote) error {
+	query := `
+		INSERT INTO clinical_notes (` + clinicalNoteColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		note.ID,
+		note.OrganizationID,
+		note.PatientID,
+		note.AppointmentID,
+		note.DoctorID,
+		note.AuthorID,
+		note.TemplateID,
+		note.Subjective,
+		note.Objective,
+		note.Assessment,
+		note.Plan,
+		string(note.Status),
+		note.SignedAt,
+		note.CreatedAt,
+		note.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create clinical note: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a note of the organization
+func (r *ClinicalNotePostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.ClinicalNote, error) {
+	query := `SELECT ` + clinicalNoteColumns + ` FROM clinical_notes WHERE organization_id = $1 AND id = $2`
+
+	note, err := r.scanNote(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clinical note: %w", err)
+	}
+
+	return note, nil
+}
+
+// GetByAppointment retrieves the note documenting an appointment
+func (r *ClinicalNotePostgresRepository) GetByAppointment(ctx context.Context, orgID, appointmentID uuid.UUID) (*entities.ClinicalNote, error) {
+	query := `SELECT ` + clinicalNoteColumns + ` FROM clinical_notes WHERE organization_id = $1 AND appointment_id = $2`
+
+	note, err := r.scanNote(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, appointmentID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clinical note by appointment: %w", err)
+	}
+
+	return note, nil
+}
+
+// Update saves the content and signature of a draft note
+func (r *ClinicalNotePostgresRepository) Update(ctx context.Context, note *entities.ClinicalNote) error {
+	query := `
+		UPDATE clinical_notes
+		SET subjective = $3, objective = $4, assessment = $5, plan = $6, status = $7, signed_at = $8
+		WHERE organization_id = $1 AND id = $2 AND status = 'draft'
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		note.OrganizationID,
+		note.ID,
+		note.Subjective,
+		note.Objective,
+		note.Assessment,
+		note.Plan,
+		string(note.Status),
+		note.SignedAt,
+	).Scan(&note.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrClinicalNoteSigned
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update clinical note: %w", err)
+	}
+
+	return nil
+}
+
+// CreateAmendment stores an amendment to a signed note
+func (r *ClinicalNotePostgresRepository) CreateAmendment(ctx context.Context, amendment *entities.ClinicalNoteAmendment) error {
+	query := `
+		INSERT INTO clinical_note_amendments (` + clinicalNoteAmendmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		amendment.ID,
+		amendment.NoteID,
+		amendment.AuthorID,
+		amendment.DoctorID,
+		amendment.Content,
+		amendment.Reason,
+		amendment.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create clinical note amendment: %w", err)
+	}
+
+	return nil
+}
+
+// GetAmendments retrieves the amendments of a note, oldest first
+func (r *ClinicalNotePostgresRepository) GetAmendments(ctx context.Context, noteID uuid.UUID) ([]*entities.ClinicalNoteAmendment, error) {
+	amendments, err := r.getAmendments(ctx, []uuid.UUID{noteID})
+	if err != nil {
+		return nil, err
+	}
+	return amendments[noteID], nil
+}
+
+// GetPatientTimeline retrieves a patient's notes, most recent appointment first
+func (r *ClinicalNotePostgresRepository) GetPatientTimeline(ctx context.Context, filters repositories.ClinicalNoteTimelineFilters) ([]*repositories.ClinicalNoteTimelineItem, int, error) {
+	countQuery := `SELECT COUNT(*) FROM clinical_notes WHERE organization_id = $1 AND patient_id = $2`
+
+	var total int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, countQuery, filters.OrganizationID, filters.PatientID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count clinical notes: %w", err)
+	}
+
+	query := `
+		SELECT cn.id, cn.organization_id, cn.patient_id, cn.appointment_id, cn.doctor_id, cn.author_id, cn.template_id,
+		       cn.subjective, cn.objective, cn.assessment, cn.plan, cn.status, cn.signed_at, cn.created_at, cn.updated_at,
+		       a.start_time, d.name, s.name
+		FROM clinical_notes cn
+		JOIN appointments a ON a.id = cn.appointment_id
+		JOIN doctors d ON d.id = cn.doctor_id
+		LEFT JOIN services s ON s.id = a.service_id
+		WHERE cn.organization_id = $1 AND cn.patient_id = $2
+		ORDER BY a.start_time DESC, cn.created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	offset := (filters.Page - 1) * filters.Limit
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, filters.OrganizationID, filters.PatientID, filters.Limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get clinical note timeline: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*repositories.ClinicalNoteTimelineItem
+	var noteIDs []uuid.UUID
+	for rows.Next() {
+		var item repositories.ClinicalNoteTimelineItem
+		var note entities.ClinicalNote
+		var status string
+
+		err := rows.Scan(
+			&note.ID,
+			&note.OrganizationID,
+			&note.PatientID,
+			&note.AppointmentID,
+			&note.DoctorID,
+			&note.AuthorID,
+			&note.TemplateID,
+			&note.Subjective,
+			&note.Objective,
+			&note.Assessment,
+			&note.Plan,
+			&status,
+			&note.SignedAt,
+			&note.CreatedAt,
+			&note.UpdatedAt,
+			&item.AppointmentStart,
+			&item.DoctorName,
+			&item.ServiceName,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan clinical note: %w", err)
+		}
+
+		note.Status = entities.ClinicalNoteStatus(status)
+		item.Note = &note
+		items = append(items, &item)
+		noteIDs = append(noteIDs, note.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over clinical note rows: %w", err)
+	}
+
+	if len(noteIDs) > 0 {
+		amendments, err := r.getAmendments(ctx, noteIDs)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, item := range items {
+			item.Amendments = amendments[item.Note.ID]
+		}
+	}
+
+	return items, total, nil
+}
+
+// ReassignPatient moves all notes of one patient to another
+func (r *ClinicalNotePostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	query := `UPDATE clinical_notes SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign clinical notes: %w", err)
+	}
+
+	return nil
+}
+
+// getAmendments retrieves the amendments of several notes grouped by note
+func (r *ClinicalNotePostgresRepository) getAmendments(ctx context.Context, noteIDs []uuid.UUID) (map[uuid.UUID][]*entities.ClinicalNoteAmendment, error) {
+	query := `
+		SELECT ` + clinicalNoteAmendmentColumns + `
+		FROM clinical_note_amendments
+		WHERE note_id = ANY($1)
+		ORDER BY created_at`
+
+	ids := make(pq.StringArray, len(noteIDs))
+	for i, id := range noteIDs {
+		ids[i] = id.String()
+	}
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clinical note amendments: %w", err)
+	}
+	defer rows.Close()
+
+	amendments := make(map[uuid.UUID][]*entities.ClinicalNoteAmendment)
+	for rows.Next() {
+		var amendment entities.ClinicalNoteAmendment
+		err := rows.Scan(
+			&amendment.ID,
+			&amendment.NoteID,
+			&amendment.AuthorID,
+			&amendment.DoctorID,
+			&amendment.Content,
+			&amendment.Reason,
+			&amendment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan clinical note amendment: %w", err)
+		}
+		amendments[amendment.NoteID] = append(amendments[amendment.NoteID], &amendment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over clinical note amendment rows: %w", err)
+	}
+
+	return amendments, nil
+}
+
+// scanNote scans a single note from a row
+func (r *ClinicalNotePostgresRepository) scanNote(row interface{ Scan(...interface{}) error }) (*entities.ClinicalNote, error) {
+	var note entities.ClinicalNote
+	var status string
+
+	err := row.Scan(
+		&note.ID,
+		&note.OrganizationID,
+		&note.PatientID,
+		&note.AppointmentID,
+		&note.DoctorID,
+		&note.AuthorID,
+		&note.TemplateID,
+		&note.Subjective,
+		&note.Objective,
+		&note.Assessment,
+		&note.Plan,
+		&status,
+		&note.SignedAt,
+		&note.CreatedAt,
+		&note.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	note.Status = entities.ClinicalNoteStatus(status)
+	return &note, nil
+}
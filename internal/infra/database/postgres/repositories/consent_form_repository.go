@@ -0,0 +1,443 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// ConsentTemplatePostgresRepository implements the ConsentTemplateRepository interface
+type ConsentTemplatePostgresRepository struct {
+	db *sql.DB
+}
+
+// NewConsentTemplatePostgresRepository creates a new instance of ConsentTemplatePostgresRepository
+func NewConsentTemplatePostgresRepository(db *sql.DB) repositories.ConsentTemplateRepository {
+	return &ConsentTemplatePostgresRepository{db: db}
+}
+
+const consentTemplateColumns = `id, organization_id, service_id, name, body, required, created_at, updated_at`
+
+// Create stores a template
+func (r *ConsentTemplatePostgresRepository) Create(ctx context.Context, template *entities.ConsentFormTemplate) error {
+	query := `
+		INSERT INTO consent_form_templates (` + consentTemplateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		template.ID,
+		template.OrganizationID,
+		template.ServiceID,
+		template.Name,
+		template.Body,
+		template.Required,
+		template.CreatedAt,
+		template.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create consent template: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a template of the organization
+func (r *ConsentTemplatePostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.ConsentFormTemplate, error) {
+	query := `SELECT ` + consentTemplateColumns + ` FROM consent_form_templates WHERE organization_id = $1 AND id = $2`
+
+	template, err := r.scanTemplate(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent template: %w", err)
+	}
+
+	return template, nil
+}
+
+// List retrieves all templates of the organization
+func (r *ConsentTemplatePostgresRepository) List(ctx context.Context, orgID uuid.UUID) ([]*entities.ConsentFormTemplate, error) {
+	query := `
+		SELECT ` + consentTemplateColumns + `
+		FROM consent_form_templates
+		WHERE organization_id = $1
+		ORDER BY service_id NULLS FIRST, name`
+
+	return r.queryTemplates(ctx, query, orgID)
+}
+
+// ListRequired retrieves the required templates that apply to appointments of a service
+func (r *ConsentTemplatePostgresRepository) ListRequired(ctx context.Context, orgID uuid.UUID, serviceID *string) ([]*entities.ConsentFormTemplate, error) {
+	query := `
+		SELECT ` + consentTemplateColumns + `
+		FROM consent_form_templates
+		WHERE organization_id = $1 AND required AND (service_id IS NULL OR service_id = $2)
+		ORDER BY service_id NULLS FIRST, name`
+
+	return r.queryTemplates(ctx, query, orgID, serviceID)
+}
+
+// Update saves the service, name, body and required flag of a template
+func (r *ConsentTemplatePostgresRepository) Update(ctx context.Context, template *entities.ConsentFormTemplate) error {
+	query := `
+		UPDATE consent_form_templates
+		SET service_id = $3, name = $4, body = $5, required = $6
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		template.OrganizationID,
+		template.ID,
+		template.ServiceID,
+		template.Name,
+		template.Body,
+		template.Required,
+	).Scan(&template.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrConsentTemplateNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update consent template: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a template; forms rendered from it are kept
+func (r *ConsentTemplatePostgresRepository) Delete(ctx context.Context, orgID, id uuid.UUID) error {
+	query := `DELETE FROM consent_form_templates WHERE organization_id = $1 AND id = $2`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, orgID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete consent template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrConsentTemplateNotFound
+	}
+
+	return nil
+}
+
+// queryTemplates runs a template query and scans every row
+func (r *ConsentTemplatePostgresRepository) queryTemplates(ctx context.Context, query string, args ...interface{}) ([]*entities.ConsentFormTemplate, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consent templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*entities.ConsentFormTemplate
+	for rows.Next() {
+		template, err := r.scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan consent template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over consent template rows: %w", err)
+	}
+
+	return templates, nil
+}
+
+// scanTemplate scans a single template from a row
+func (r *ConsentTemplatePostgresRepository) scanTemplate(row interface{ Scan(...interface{}) error }) (*entities.ConsentFormTemplate, error) {
+	var template entities.ConsentFormTemplate
+
+	err := row.Scan(
+		&template.ID,
+		&template.OrganizationID,
+		&template.ServiceID,
+		&template.Name,
+		&template.Body,
+		&template.Required,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// ConsentFormPostgresRepository implements the ConsentFormRepository interface
+type ConsentFormPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewConsentFormPostgresRepository creates a new instance of ConsentFormPostgresRepository
+func NewConsentFormPostgresRepository(db *sql.DB) repositories.ConsentFormRepository {
+	return &ConsentFormPostgresRepository{db: db}
+}
+
+const consentFormColumns = `id, organization_id, patient_id, template_id, appointment_id, doctor_id, service_id, title, body, status, expires_at, requested_by,
+	signer_name, signature_type, signature_data, signed_ip, signed_user_agent, signed_at, document_key, document_sha256,
+	revoked_at, revoked_by, revocation_reason, created_at, updated_at`
+
+// Create stores a pending form
+func (r *ConsentFormPostgresRepository) Create(ctx context.Context, form *entities.ConsentForm) error {
+	query := `
+		INSERT INTO consent_forms (id, organization_id, patient_id, template_id, appointment_id, doctor_id, service_id, title, body, status, expires_at, requested_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		form.ID,
+		form.OrganizationID,
+		form.PatientID,
+		form.TemplateID,
+		form.AppointmentID,
+		form.DoctorID,
+		form.ServiceID,
+		form.Title,
+		form.Body,
+		string(form.Status),
+		form.ExpiresAt,
+		form.RequestedBy,
+		form.CreatedAt,
+		form.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create consent form: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a form of the organization
+func (r *ConsentFormPostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.ConsentForm, error) {
+	query := `SELECT ` + consentFormColumns + ` FROM consent_forms WHERE organization_id = $1 AND id = $2`
+
+	form, err := r.scanForm(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent form: %w", err)
+	}
+
+	return form, nil
+}
+
+// GetForSigning retrieves a form by the ID carried in its signing link
+func (r *ConsentFormPostgresRepository) GetForSigning(ctx context.Context, id uuid.UUID) (*entities.ConsentForm, error) {
+	query := `SELECT ` + consentFormColumns + ` FROM consent_forms WHERE id = $1`
+
+	form, err := r.scanForm(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent form: %w", err)
+	}
+
+	return form, nil
+}
+
+// List retrieves a page of a patient's forms, newest first, and the total count
+func (r *ConsentFormPostgresRepository) List(ctx context.Context, filters repositories.ConsentFormFilters) ([]*entities.ConsentForm, int, error) {
+	params := []interface{}{filters.OrganizationID, filters.PatientID}
+	conditions := []string{"organization_id = $1", "patient_id = $2"}
+
+	if filters.AppointmentID != nil {
+		params = append(params, *filters.AppointmentID)
+		conditions = append(conditions, fmt.Sprintf("appointment_id = $%d", len(params)))
+	}
+	if filters.Status != nil {
+		params = append(params, string(*filters.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(params)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM consent_forms WHERE "+where, params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count consent forms: %w", err)
+	}
+
+	params = append(params, filters.Limit, (filters.Page-1)*filters.Limit)
+	query := `
+		SELECT ` + consentFormColumns + `
+		FROM consent_forms
+		WHERE ` + where + `
+		ORDER BY created_at DESC, id
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(params)-1, len(params))
+
+	forms, err := r.queryForms(ctx, query, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return forms, total, nil
+}
+
+// ListSigned retrieves the signed forms of a patient
+func (r *ConsentFormPostgresRepository) ListSigned(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.ConsentForm, error) {
+	query := `
+		SELECT ` + consentFormColumns + `
+		FROM consent_forms
+		WHERE organization_id = $1 AND patient_id = $2 AND status = 'signed'
+		ORDER BY signed_at DESC`
+
+	return r.queryForms(ctx, query, orgID, patientID)
+}
+
+// Sign records the signature of a pending form; a form signed concurrently
+// returns ErrConsentFormAlreadySigned
+func (r *ConsentFormPostgresRepository) Sign(ctx context.Context, form *entities.ConsentForm) error {
+	query := `
+		UPDATE consent_forms
+		SET status = $2, signer_name = $3, signature_type = $4, signature_data = $5, signed_ip = $6,
+			signed_user_agent = $7, signed_at = $8, document_key = $9, document_sha256 = $10
+		WHERE id = $1 AND status = 'pending'
+		RETURNING updated_at`
+
+	var signatureType *string
+	if form.SignatureType != nil {
+		value := string(*form.SignatureType)
+		signatureType = &value
+	}
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		form.ID,
+		string(form.Status),
+		form.SignerName,
+		signatureType,
+		form.SignatureData,
+		form.SignedIP,
+		form.SignedUserAgent,
+		form.SignedAt,
+		form.DocumentKey,
+		form.DocumentSHA256,
+	).Scan(&form.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrConsentFormAlreadySigned
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sign consent form: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves the link expiry and revocation of a form
+func (r *ConsentFormPostgresRepository) Update(ctx context.Context, form *entities.ConsentForm) error {
+	query := `
+		UPDATE consent_forms
+		SET status = $3, expires_at = $4, revoked_at = $5, revoked_by = $6, revocation_reason = $7
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		form.OrganizationID,
+		form.ID,
+		string(form.Status),
+		form.ExpiresAt,
+		form.RevokedAt,
+		form.RevokedBy,
+		form.RevocationReason,
+	).Scan(&form.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrConsentFormNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update consent form: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignPatient moves all forms of one patient to another
+func (r *ConsentFormPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	query := `UPDATE consent_forms SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign consent forms: %w", err)
+	}
+
+	return nil
+}
+
+// queryForms runs a form query and scans every row
+func (r *ConsentFormPostgresRepository) queryForms(ctx context.Context, query string, args ...interface{}) ([]*entities.ConsentForm, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consent forms: %w", err)
+	}
+	defer rows.Close()
+
+	var forms []*entities.ConsentForm
+	for rows.Next() {
+		form, err := r.scanForm(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan consent form: %w", err)
+		}
+		forms = append(forms, form)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over consent form rows: %w", err)
+	}
+
+	return forms, nil
+}
+
+// scanForm scans a single form from a row
+func (r *ConsentFormPostgresRepository) scanForm(row interface{ Scan(...interface{}) error }) (*entities.ConsentForm, error) {
+	var form entities.ConsentForm
+	var status string
+	var signatureType sql.NullString
+
+	err := row.Scan(
+		&form.ID,
+		&form.OrganizationID,
+		&form.PatientID,
+		&form.TemplateID,
+		&form.AppointmentID,
+		&form.DoctorID,
+		&form.ServiceID,
+		&form.Title,
+		&form.Body,
+		&status,
+		&form.ExpiresAt,
+		&form.RequestedBy,
+		&form.SignerName,
+		&signatureType,
+		&form.SignatureData,
+		&form.SignedIP,
+		&form.SignedUserAgent,
+		&form.SignedAt,
+		&form.DocumentKey,
+		&form.DocumentSHA256,
+		&form.RevokedAt,
+		&form.RevokedBy,
+		&form.RevocationReason,
+		&form.CreatedAt,
+		&form.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	form.Status = entities.ConsentFormStatus(status)
+	if signatureType.Valid {
+		value := entities.SignatureType(signatureType.String)
+		form.SignatureType = &value
+	}
+
+	return &form, nil
+}
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// DentalChartPostgresRepository implements the DentalChartRepository interface
+type DentalChartPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewDentalChartPostgresRepository creates a new instance of DentalChartPostgresRepository
+func NewDentalChartPostgresRepository(db *sql.DB) repositories.DentalChartRepository {
+	return &DentalChartPostgresRepository{db: db}
+}
+
+const dentalChartEntryColumns = `id, organization_id, patient_id, tooth, surfaces, condition, notes, appointment_id, doctor_id, recorded_at, recorded_by, created_at`
+
+// Create stores a charting entry
+func (r *DentalChartPostgresRepository) Create(ctx context.Context, entry *entities.DentalChartEntry) error {
+	query := `
+		INSERT INTO dental_chart_entries (` + dentalChartEntryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	surfaces := make(pq.StringArray, len(entry.Surfaces))
+	for i, surface := range entry.Surfaces {
+		surfaces[i] = string(surface)
+	}
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		entry.ID,
+		entry.OrganizationID,
+		entry.PatientID,
+		int(entry.Tooth),
+		surfaces,
+		string(entry.Condition),
+		entry.Notes,
+		entry.AppointmentID,
+		entry.DoctorID,
+		entry.RecordedAt,
+		entry.RecordedBy,
+		entry.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create dental chart entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetEntries retrieves charting entries in the order they were observed
+func (r *DentalChartPostgresRepository) GetEntries(ctx context.Context, filters repositories.DentalChartEntryFilters) ([]*entities.DentalChartEntry, error) {
+	query := `
+		SELECT ` + dentalChartEntryColumns + `
+		FROM dental_chart_entries
+		WHERE organization_id = $1 AND patient_id = $2`
+	params := []interface{}{filters.OrganizationID, filters.PatientID}
+
+	if filters.RecordedUntil != nil {
+		params = append(params, *filters.RecordedUntil)
+		query += fmt.Sprintf(" AND recorded_at <= $%d", len(params))
+	}
+	if filters.Tooth != nil {
+		params = append(params, int(*filters.Tooth))
+		query += fmt.Sprintf(" AND tooth = $%d", len(params))
+	}
+	query += " ORDER BY recorded_at, created_at"
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dental chart entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entities.DentalChartEntry
+	for rows.Next() {
+		entry, err := r.scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dental chart entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over dental chart entry rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// ReassignPatient moves all charting entries of one patient to another
+func (r *DentalChartPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	query := `UPDATE dental_chart_entries SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign dental chart entries: %w", err)
+	}
+
+	return nil
+}
+
+// scanEntry scans a single charting entry from a row
+func (r *DentalChartPostgresRepository) scanEntry(row interface{ Scan(...interface{}) error }) (*entities.DentalChartEntry, error) {
+	var entry entities.DentalChartEntry
+	var tooth int
+	var surfaces pq.StringArray
+	var condition string
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.OrganizationID,
+		&entry.PatientID,
+		&tooth,
+		&surfaces,
+		&condition,
+		&entry.Notes,
+		&entry.AppointmentID,
+		&entry.DoctorID,
+		&entry.RecordedAt,
+		&entry.RecordedBy,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Tooth = entities.Tooth(tooth)
+	entry.Condition = entities.ToothCondition(condition)
+	entry.Surfaces = make([]entities.ToothSurface, len(surfaces))
+	for i, surface := range surfaces {
+		entry.Surfaces[i] = entities.ToothSurface(surface)
+	}
+
+	return &entry, nil
+}
@@ -0,0 +1,343 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// DepositPostgresRepository implements the DepositRepository interface
+type DepositPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewDepositPostgresRepository creates a new instance of DepositPostgresRepository
+func NewDepositPostgresRepository(db *sql.DB) repositories.DepositRepository {
+	return &DepositPostgresRepository{db: db}
+}
+
+const depositPolicyColumns = `organization_id, enabled, hold_hours, cutoff_hours, no_show_threshold, no_show_lookback_days, deposit_type, deposit_amount, deposit_percent, updated_by, created_at, updated_at`
+
+const depositColumns = `id, organization_id, clinic_id, patient_id, appointment_id, reason, status, currency, amount, due_at, payment_link_id, payment_url, payment_reference, paid_at, invoice_id, applied_at, waive_reason, waived_by, waived_at, released_at, requested_by, created_at, updated_at`
+
+// GetPolicy retrieves the organization's deposit policy, nil when it has not set one
+func (r *DepositPostgresRepository) GetPolicy(ctx context.Context, orgID uuid.UUID) (*entities.DepositPolicy, error) {
+	query := `SELECT ` + depositPolicyColumns + ` FROM deposit_policies WHERE organization_id = $1`
+
+	var policy entities.DepositPolicy
+	var depositType string
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID).Scan(
+		&policy.OrganizationID,
+		&policy.Enabled,
+		&policy.HoldHours,
+		&policy.CutoffHours,
+		&policy.NoShowThreshold,
+		&policy.NoShowLookbackDays,
+		&depositType,
+		&policy.Amount,
+		&policy.Percent,
+		&policy.UpdatedBy,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deposit policy: %w", err)
+	}
+
+	policy.Type = entities.DepositType(depositType)
+	return &policy, nil
+}
+
+// SavePolicy creates or replaces the organization's deposit policy
+func (r *DepositPostgresRepository) SavePolicy(ctx context.Context, policy *entities.DepositPolicy) error {
+	query := `
+		INSERT INTO deposit_policies (organization_id, enabled, hold_hours, cutoff_hours, no_show_threshold, no_show_lookback_days, deposit_type, deposit_amount, deposit_percent, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (organization_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled, hold_hours = EXCLUDED.hold_hours, cutoff_hours = EXCLUDED.cutoff_hours,
+			no_show_threshold = EXCLUDED.no_show_threshold, no_show_lookback_days = EXCLUDED.no_show_lookback_days,
+			deposit_type = EXCLUDED.deposit_type, deposit_amount = EXCLUDED.deposit_amount,
+			deposit_percent = EXCLUDED.deposit_percent, updated_by = EXCLUDED.updated_by
+		RETURNING created_at, updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		policy.OrganizationID,
+		policy.Enabled,
+		policy.HoldHours,
+		policy.CutoffHours,
+		policy.NoShowThreshold,
+		policy.NoShowLookbackDays,
+		string(policy.Type),
+		policy.Amount,
+		policy.Percent,
+		policy.UpdatedBy,
+	).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save deposit policy: %w", err)
+	}
+
+	return nil
+}
+
+// Create stores a deposit
+func (r *DepositPostgresRepository) Create(ctx context.Context, deposit *entities.Deposit) error {
+	query := `
+		INSERT INTO appointment_deposits (` + depositColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		deposit.ID,
+		deposit.OrganizationID,
+		deposit.ClinicID,
+		deposit.PatientID,
+		deposit.AppointmentID,
+		string(deposit.Reason),
+		string(deposit.Status),
+		deposit.Currency,
+		deposit.Amount,
+		deposit.DueAt,
+		deposit.PaymentLinkID,
+		deposit.PaymentURL,
+		deposit.PaymentReference,
+		deposit.PaidAt,
+		deposit.InvoiceID,
+		deposit.AppliedAt,
+		deposit.WaiveReason,
+		deposit.WaivedBy,
+		deposit.WaivedAt,
+		deposit.ReleasedAt,
+		deposit.RequestedBy,
+		deposit.CreatedAt,
+		deposit.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create deposit: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a deposit of the organization
+func (r *DepositPostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.Deposit, error) {
+	query := `SELECT ` + depositColumns + ` FROM appointment_deposits WHERE organization_id = $1 AND id = $2`
+	return r.getDeposit(ctx, query, orgID, id)
+}
+
+// GetForUpdate retrieves a deposit of the organization and locks it until the transaction in ctx ends
+func (r *DepositPostgresRepository) GetForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.Deposit, error) {
+	query := `SELECT ` + depositColumns + ` FROM appointment_deposits WHERE organization_id = $1 AND id = $2 FOR UPDATE`
+	return r.getDeposit(ctx, query, orgID, id)
+}
+
+// GetByPaymentLinkForUpdate retrieves the deposit paid through a gateway link and locks it
+// until the transaction in ctx ends
+func (r *DepositPostgresRepository) GetByPaymentLinkForUpdate(ctx context.Context, linkID string) (*entities.Deposit, error) {
+	query := `SELECT ` + depositColumns + ` FROM appointment_deposits WHERE payment_link_id = $1 FOR UPDATE`
+	return r.getDeposit(ctx, query, linkID)
+}
+
+// GetActiveByAppointment retrieves the pending or paid deposit of an appointment, nil when none
+func (r *DepositPostgresRepository) GetActiveByAppointment(ctx context.Context, appointmentID uuid.UUID) (*entities.Deposit, error) {
+	query := `
+		SELECT ` + depositColumns + `
+		FROM appointment_deposits
+		WHERE appointment_id = $1 AND status IN ('pending', 'paid')`
+	return r.getDeposit(ctx, query, appointmentID)
+}
+
+// List retrieves a page of deposits, most recent first, and the total count
+func (r *DepositPostgresRepository) List(ctx context.Context, filters repositories.DepositFilters) ([]*entities.Deposit, int, error) {
+	params := []interface{}{filters.OrganizationID}
+	conditions := []string{"organization_id = $1"}
+
+	if filters.ClinicID != nil {
+		params = append(params, *filters.ClinicID)
+		conditions = append(conditions, fmt.Sprintf("clinic_id = $%d", len(params)))
+	}
+	if filters.PatientID != nil {
+		params = append(params, *filters.PatientID)
+		conditions = append(conditions, fmt.Sprintf("patient_id = $%d", len(params)))
+	}
+	if filters.Status != nil {
+		params = append(params, string(*filters.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(params)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM appointment_deposits WHERE "+where, params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count deposits: %w", err)
+	}
+
+	params = append(params, filters.Limit, (filters.Page-1)*filters.Limit)
+	query := `
+		SELECT ` + depositColumns + `
+		FROM appointment_deposits
+		WHERE ` + where + `
+		ORDER BY created_at DESC, id
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(params)-1, len(params))
+
+	deposits, err := r.queryDeposits(ctx, query, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deposits, total, nil
+}
+
+// ListOverdue retrieves up to limit pending deposits due at or before now, oldest due first
+func (r *DepositPostgresRepository) ListOverdue(ctx context.Context, now time.Time, limit int) ([]*entities.Deposit, error) {
+	query := `
+		SELECT ` + depositColumns + `
+		FROM appointment_deposits
+		WHERE status = 'pending' AND due_at <= $1
+		ORDER BY due_at, id
+		LIMIT $2`
+
+	return r.queryDeposits(ctx, query, now, limit)
+}
+
+// Update saves the status, payment link, payment, invoice and waiver of a deposit
+func (r *DepositPostgresRepository) Update(ctx context.Context, deposit *entities.Deposit) error {
+	query := `
+		UPDATE appointment_deposits
+		SET status = $3, payment_link_id = $4, payment_url = $5, payment_reference = $6, paid_at = $7,
+			invoice_id = $8, applied_at = $9, waive_reason = $10, waived_by = $11, waived_at = $12, released_at = $13
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		deposit.OrganizationID,
+		deposit.ID,
+		string(deposit.Status),
+		deposit.PaymentLinkID,
+		deposit.PaymentURL,
+		deposit.PaymentReference,
+		deposit.PaidAt,
+		deposit.InvoiceID,
+		deposit.AppliedAt,
+		deposit.WaiveReason,
+		deposit.WaivedBy,
+		deposit.WaivedAt,
+		deposit.ReleasedAt,
+	).Scan(&deposit.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrDepositNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update deposit: %w", err)
+	}
+
+	return nil
+}
+
+// MoveAppointment moves the pending or paid deposit of an appointment to the appointment that
+// replaces it, booked in clinicID
+func (r *DepositPostgresRepository) MoveAppointment(ctx context.Context, fromAppointmentID, toAppointmentID, clinicID uuid.UUID) error {
+	query := `
+		UPDATE appointment_deposits
+		SET appointment_id = $2, clinic_id = $3
+		WHERE appointment_id = $1 AND status IN ('pending', 'paid')`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromAppointmentID, toAppointmentID, clinicID); err != nil {
+		return fmt.Errorf("failed to move deposit: %w", err)
+	}
+	return nil
+}
+
+// ReassignPatient moves all deposits of one patient to another
+func (r *DepositPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	query := `UPDATE appointment_deposits SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign deposits: %w", err)
+	}
+
+	return nil
+}
+
+// getDeposit retrieves a single deposit
+func (r *DepositPostgresRepository) getDeposit(ctx context.Context, query string, args ...interface{}) (*entities.Deposit, error) {
+	deposit, err := r.scanDeposit(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deposit: %w", err)
+	}
+
+	return deposit, nil
+}
+
+// queryDeposits retrieves the deposits a query selects
+func (r *DepositPostgresRepository) queryDeposits(ctx context.Context, query string, args ...interface{}) ([]*entities.Deposit, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deposits: %w", err)
+	}
+	defer rows.Close()
+
+	var deposits []*entities.Deposit
+	for rows.Next() {
+		deposit, err := r.scanDeposit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deposit: %w", err)
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over deposit rows: %w", err)
+	}
+
+	return deposits, nil
+}
+
+// scanDeposit scans a deposit row
+func (r *DepositPostgresRepository) scanDeposit(row interface{ Scan(...interface{}) error }) (*entities.Deposit, error) {
+	var deposit entities.Deposit
+	var reason, status string
+
+	err := row.Scan(
+		&deposit.ID,
+		&deposit.OrganizationID,
+		&deposit.ClinicID,
+		&deposit.PatientID,
+		&deposit.AppointmentID,
+		&reason,
+		&status,
+		&deposit.Currency,
+		&deposit.Amount,
+		&deposit.DueAt,
+		&deposit.PaymentLinkID,
+		&deposit.PaymentURL,
+		&deposit.PaymentReference,
+		&deposit.PaidAt,
+		&deposit.InvoiceID,
+		&deposit.AppliedAt,
+		&deposit.WaiveReason,
+		&deposit.WaivedBy,
+		&deposit.WaivedAt,
+		&deposit.ReleasedAt,
+		&deposit.RequestedBy,
+		&deposit.CreatedAt,
+		&deposit.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deposit.Reason = entities.DepositReason(reason)
+	deposit.Status = entities.DepositStatus(status)
+	return &deposit, nil
+}
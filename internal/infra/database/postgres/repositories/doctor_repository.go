@@ -24,8 +24,8 @@ func NewDoctorPostgresRepository(db *sql.DB) repositories.DoctorRepository {
 // Create creates a new doctor
 func (r *DoctorPostgresRepository) Create(ctx context.Context, doctor *entities.Doctor) error {
 	query := `
-		INSERT INTO doctors (id, organization_id, user_id, name, specialty, email, phone, default_unit_id, color, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+		INSERT INTO doctors (id, organization_id, user_id, name, specialty, email, phone, phone_e164, default_unit_id, color, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		doctor.ID,
@@ -35,6 +35,7 @@ func (r *DoctorPostgresRepository) Create(ctx context.Context, doctor *entities.
 		doctor.Specialty,
 		doctor.Email,
 		doctor.Phone,
+		doctor.PhoneE164,
 		doctor.DefaultUnitID,
 		doctor.Color,
 		doctor.IsActive,
@@ -52,7 +53,7 @@ func (r *DoctorPostgresRepository) Create(ctx context.Context, doctor *entities.
 // GetByID retrieves a doctor by its ID
 func (r *DoctorPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Doctor, error) {
 	query := `
-		SELECT id, organization_id, user_id, name, specialty, email, phone, default_unit_id, color, is_active, created_at, updated_at
+		SELECT id, organization_id, user_id, name, specialty, email, phone, phone_e164, default_unit_id, color, is_active, created_at, updated_at
 		FROM doctors
 		WHERE id = $1`
 
@@ -65,6 +66,7 @@ func (r *DoctorPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 		&doctor.Specialty,
 		&doctor.Email,
 		&doctor.Phone,
+		&doctor.PhoneE164,
 		&doctor.DefaultUnitID,
 		&doctor.Color,
 		&doctor.IsActive,
@@ -85,7 +87,7 @@ func (r *DoctorPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 // GetAll retrieves all doctors
 func (r *DoctorPostgresRepository) GetAll(ctx context.Context) ([]*entities.Doctor, error) {
 	query := `
-		SELECT id, organization_id, user_id, name, specialty, email, phone, default_unit_id, color, is_active, created_at, updated_at
+		SELECT id, organization_id, user_id, name, specialty, email, phone, phone_e164, default_unit_id, color, is_active, created_at, updated_at
 		FROM doctors
 		ORDER BY name`
 
@@ -106,6 +108,7 @@ func (r *DoctorPostgresRepository) GetAll(ctx context.Context) ([]*entities.Doct
 			&doctor.Specialty,
 			&doctor.Email,
 			&doctor.Phone,
+			&doctor.PhoneE164,
 			&doctor.DefaultUnitID,
 			&doctor.Color,
 			&doctor.IsActive,
@@ -128,7 +131,7 @@ func (r *DoctorPostgresRepository) GetAll(ctx context.Context) ([]*entities.Doct
 // GetByEmail retrieves a doctor by email
 func (r *DoctorPostgresRepository) GetByEmail(ctx context.Context, email string) (*entities.Doctor, error) {
 	query := `
-		SELECT id, organization_id, user_id, name, specialty, email, phone, default_unit_id, color, is_active, created_at, updated_at
+		SELECT id, organization_id, user_id, name, specialty, email, phone, phone_e164, default_unit_id, color, is_active, created_at, updated_at
 		FROM doctors
 		WHERE email = $1`
 
@@ -141,6 +144,7 @@ func (r *DoctorPostgresRepository) GetByEmail(ctx context.Context, email string)
 		&doctor.Specialty,
 		&doctor.Email,
 		&doctor.Phone,
+		&doctor.PhoneE164,
 		&doctor.DefaultUnitID,
 		&doctor.Color,
 		&doctor.IsActive,
@@ -162,10 +166,10 @@ func (r *DoctorPostgresRepository) GetByEmail(ctx context.Context, email string)
 func (r *DoctorPostgresRepository) Update(ctx context.Context, doctor *entities.Doctor) error {
 	query := `
 		UPDATE doctors
-		SET organization_id = $2, user_id = $3, name = $4, specialty = $5, email = $6, phone = $7, default_unit_id = $8, color = $9, is_active = $10, updated_at = $11
+		SET organization_id = $2, user_id = $3, name = $4, specialty = $5, email = $6, phone = $7, phone_e164 = $8, default_unit_id = $9, color = $10, is_active = $11, updated_at = $12
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
 		doctor.ID,
 		doctor.OrganizationID,
 		doctor.UserID,
@@ -173,13 +177,9 @@ func (r *DoctorPostgresRepository) Update(ctx context.Context, doctor *entities.
 		doctor.Specialty,
 		doctor.Email,
 		doctor.Phone,
+		doctor.PhoneE164,
 		doctor.DefaultUnitID,
 		doctor.Color,
-		doctor.Name,
-		doctor.Specialty,
-		doctor.Email,
-		doctor.Phone,
-		doctor.DefaultUnitID,
 		doctor.IsActive,
 		doctor.UpdatedAt,
 	)
@@ -238,7 +238,7 @@ func (r *DoctorPostgresRepository) Exists(ctx context.Context, id uuid.UUID) (bo
 func (r *DoctorPostgresRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID, clinicID *uuid.UUID) ([]*repositories.DoctorWithOrgInfo, error) {
 	query := `
 		SELECT 
-			d.id, d.organization_id, d.user_id, d.name, d.specialty, d.email, d.phone, 
+			d.id, d.organization_id, d.user_id, d.name, d.specialty, d.email, d.phone, d.phone_e164,
 			d.default_unit_id, d.color, d.is_active, d.created_at, d.updated_at,
 			c.id as clinic_id, c.name as clinic_name, o.name as org_name,
 			CASE WHEN $2::UUID IS NOT NULL AND c.id = $2 THEN 0 ELSE 1 END as sort_priority
@@ -271,6 +271,7 @@ func (r *DoctorPostgresRepository) GetByOrganizationID(ctx context.Context, orgI
 			&doctor.Specialty,
 			&doctor.Email,
 			&doctor.Phone,
+			&doctor.PhoneE164,
 			&doctor.DefaultUnitID,
 			&doctor.Color,
 			&doctor.IsActive,
@@ -301,3 +302,37 @@ func (r *DoctorPostgresRepository) GetByOrganizationID(ctx context.Context, orgI
 
 	return doctors, nil
 }
+
+// GetByUserID retrieves the doctor of an organization linked to a user account
+func (r *DoctorPostgresRepository) GetByUserID(ctx context.Context, orgID, userID uuid.UUID) (*entities.Doctor, error) {
+	query := `
+		SELECT id, organization_id, user_id, name, specialty, email, phone, phone_e164, default_unit_id, color, is_active, created_at, updated_at
+		FROM doctors
+		WHERE organization_id = $1 AND user_id = $2`
+
+	var doctor entities.Doctor
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID, userID).Scan(
+		&doctor.ID,
+		&doctor.OrganizationID,
+		&doctor.UserID,
+		&doctor.Name,
+		&doctor.Specialty,
+		&doctor.Email,
+		&doctor.Phone,
+		&doctor.PhoneE164,
+		&doctor.DefaultUnitID,
+		&doctor.Color,
+		&doctor.IsActive,
+		&doctor.CreatedAt,
+		&doctor.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get doctor by user: %w", err)
+	}
+
+	return &doctor, nil
+}
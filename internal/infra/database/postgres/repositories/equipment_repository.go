@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// EquipmentPostgresRepository implements the EquipmentRepository interface
+type EquipmentPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewEquipmentPostgresRepository creates a new instance of EquipmentPostgresRepository
+func NewEquipmentPostgresRepository(db *sql.DB) repositories.EquipmentRepository {
+	return &EquipmentPostgresRepository{db: db}
+}
+
+const equipmentColumns = `e.id, e.clinic_id, e.name, e.description, e.is_active, e.created_at, e.updated_at`
+
+// Create stores a piece of equipment
+func (r *EquipmentPostgresRepository) Create(ctx context.Context, equipment *entities.Equipment) error {
+	query := `
+		INSERT INTO equipment (id, clinic_id, name, description, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		equipment.ID,
+		equipment.ClinicID,
+		equipment.Name,
+		equipment.Description,
+		equipment.IsActive,
+		equipment.CreatedAt,
+		equipment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create equipment: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves a piece of equipment of one of the organization's clinics
+func (r *EquipmentPostgresRepository) Get(ctx context.Context, orgID, id uuid.UUID) (*entities.Equipment, error) {
+	query := `
+		SELECT ` + equipmentColumns + `
+		FROM equipment e
+		JOIN clinics c ON c.id = e.clinic_id
+		WHERE c.organization_id = $1 AND e.id = $2`
+
+	equipment, err := r.scanEquipment(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment: %w", err)
+	}
+
+	return equipment, nil
+}
+
+// ListByClinic retrieves the equipment of a clinic of the organization by name
+func (r *EquipmentPostgresRepository) ListByClinic(ctx context.Context, orgID, clinicID uuid.UUID, includeInactive bool) ([]*entities.Equipment, error) {
+	query := `
+		SELECT ` + equipmentColumns + `
+		FROM equipment e
+		JOIN clinics c ON c.id = e.clinic_id
+		WHERE c.organization_id = $1 AND e.clinic_id = $2 AND (e.is_active OR $3)
+		ORDER BY e.name, e.id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, clinicID, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equipment: %w", err)
+	}
+	defer rows.Close()
+
+	var list []*entities.Equipment
+	for rows.Next() {
+		equipment, err := r.scanEquipment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan equipment: %w", err)
+		}
+		list = append(list, equipment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate equipment: %w", err)
+	}
+
+	return list, nil
+}
+
+// Update saves the name, description and active flag of a piece of equipment
+func (r *EquipmentPostgresRepository) Update(ctx context.Context, equipment *entities.Equipment) error {
+	query := `
+		UPDATE equipment
+		SET name = $2, description = $3, is_active = $4, updated_at = $5
+		WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
+		equipment.ID,
+		equipment.Name,
+		equipment.Description,
+		equipment.IsActive,
+		equipment.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update equipment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entities.ErrEquipmentNotFound
+	}
+
+	return nil
+}
+
+// scanEquipment scans an equipment row
+func (r *EquipmentPostgresRepository) scanEquipment(row interface{ Scan(...interface{}) error }) (*entities.Equipment, error) {
+	var equipment entities.Equipment
+	err := row.Scan(
+		&equipment.ID,
+		&equipment.ClinicID,
+		&equipment.Name,
+		&equipment.Description,
+		&equipment.IsActive,
+		&equipment.CreatedAt,
+		&equipment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &equipment, nil
+}
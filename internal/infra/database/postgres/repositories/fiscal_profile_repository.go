@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// FiscalProfilePostgresRepository implements the FiscalProfileRepository interface
+type FiscalProfilePostgresRepository struct {
+	db *sql.DB
+}
+
+// NewFiscalProfilePostgresRepository creates a new instance of FiscalProfilePostgresRepository
+func NewFiscalProfilePostgresRepository(db *sql.DB) repositories.FiscalProfileRepository {
+	return &FiscalProfilePostgresRepository{db: db}
+}
+
+const patientFiscalProfileColumns = `organization_id, patient_id, rfc, legal_name, tax_regime, postal_code, cfdi_use, created_at, updated_at`
+
+const issuerFiscalProfileColumns = `organization_id, rfc, legal_name, tax_regime, postal_code, product_code, created_at, updated_at`
+
+// GetPatientProfile retrieves the fiscal data of a patient
+func (r *FiscalProfilePostgresRepository) GetPatientProfile(ctx context.Context, orgID, patientID uuid.UUID) (*entities.PatientFiscalProfile, error) {
+	query := `SELECT ` + patientFiscalProfileColumns + ` FROM patient_fiscal_profiles WHERE organization_id = $1 AND patient_id = $2`
+
+	var profile entities.PatientFiscalProfile
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID, patientID).Scan(
+		&profile.OrganizationID,
+		&profile.PatientID,
+		&profile.RFC,
+		&profile.LegalName,
+		&profile.TaxRegime,
+		&profile.PostalCode,
+		&profile.CFDIUse,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get patient fiscal profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// UpsertPatientProfile creates or replaces the fiscal data of a patient
+func (r *FiscalProfilePostgresRepository) UpsertPatientProfile(ctx context.Context, profile *entities.PatientFiscalProfile) error {
+	query := `
+		INSERT INTO patient_fiscal_profiles (` + patientFiscalProfileColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (organization_id, patient_id) DO UPDATE
+		SET rfc = EXCLUDED.rfc, legal_name = EXCLUDED.legal_name, tax_regime = EXCLUDED.tax_regime,
+			postal_code = EXCLUDED.postal_code, cfdi_use = EXCLUDED.cfdi_use
+		RETURNING created_at, updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		profile.OrganizationID,
+		profile.PatientID,
+		profile.RFC,
+		profile.LegalName,
+		profile.TaxRegime,
+		profile.PostalCode,
+		profile.CFDIUse,
+		profile.CreatedAt,
+		profile.UpdatedAt,
+	).Scan(&profile.CreatedAt, &profile.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save patient fiscal profile: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePatientProfile removes the fiscal data of a patient
+func (r *FiscalProfilePostgresRepository) DeletePatientProfile(ctx context.Context, orgID, patientID uuid.UUID) error {
+	query := `DELETE FROM patient_fiscal_profiles WHERE organization_id = $1 AND patient_id = $2`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, orgID, patientID)
+	if err != nil {
+		return fmt.Errorf("failed to delete patient fiscal profile: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrPatientFiscalDataNotFound
+	}
+
+	return nil
+}
+
+// GetIssuerProfile retrieves the issuer data of an organization
+func (r *FiscalProfilePostgresRepository) GetIssuerProfile(ctx context.Context, orgID uuid.UUID) (*entities.IssuerFiscalProfile, error) {
+	query := `SELECT ` + issuerFiscalProfileColumns + ` FROM organization_fiscal_profiles WHERE organization_id = $1`
+
+	var profile entities.IssuerFiscalProfile
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID).Scan(
+		&profile.OrganizationID,
+		&profile.RFC,
+		&profile.LegalName,
+		&profile.TaxRegime,
+		&profile.PostalCode,
+		&profile.ProductCode,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issuer fiscal profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// UpsertIssuerProfile creates or replaces the issuer data of an organization
+func (r *FiscalProfilePostgresRepository) UpsertIssuerProfile(ctx context.Context, profile *entities.IssuerFiscalProfile) error {
+	query := `
+		INSERT INTO organization_fiscal_profiles (` + issuerFiscalProfileColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (organization_id) DO UPDATE
+		SET rfc = EXCLUDED.rfc, legal_name = EXCLUDED.legal_name, tax_regime = EXCLUDED.tax_regime,
+			postal_code = EXCLUDED.postal_code, product_code = EXCLUDED.product_code
+		RETURNING created_at, updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		profile.OrganizationID,
+		profile.RFC,
+		profile.LegalName,
+		profile.TaxRegime,
+		profile.PostalCode,
+		profile.ProductCode,
+		profile.CreatedAt,
+		profile.UpdatedAt,
+	).Scan(&profile.CreatedAt, &profile.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save issuer fiscal profile: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignPatient moves the fiscal data of one patient to another unless the target
+// already has its own
+func (r *FiscalProfilePostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	return runInTransaction(ctx, r.db, func(ctx context.Context) error {
+		deleteQuery := `
+			DELETE FROM patient_fiscal_profiles a
+			WHERE a.patient_id = $1 AND EXISTS (
+				SELECT 1 FROM patient_fiscal_profiles b
+				WHERE b.organization_id = a.organization_id AND b.patient_id = $2)`
+		if _, err := executor(ctx, r.db).ExecContext(ctx, deleteQuery, fromPatientID, toPatientID); err != nil {
+			return fmt.Errorf("failed to drop duplicated fiscal profiles: %w", err)
+		}
+
+		updateQuery := `UPDATE patient_fiscal_profiles SET patient_id = $2 WHERE patient_id = $1`
+		if _, err := executor(ctx, r.db).ExecContext(ctx, updateQuery, fromPatientID, toPatientID); err != nil {
+			return fmt.Errorf("failed to reassign fiscal profiles: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyPostgresRepository implements the IdempotencyKeyRepository interface
+type IdempotencyKeyPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyKeyPostgresRepository creates a new instance of IdempotencyKeyPostgresRepository
+func NewIdempotencyKeyPostgresRepository(db *sql.DB) repositories.IdempotencyKeyRepository {
+	return &IdempotencyKeyPostgresRepository{db: db}
+}
+
+const idempotencyKeyColumns = `organization_id, key, request_hash, status, response_status, response_body, content_type, etag, created_at, expires_at`
+
+// Reserve stores the record unless a live one exists for the same organization and key.
+// Expired records, and in-progress records older than staleAfter, are replaced.
+func (r *IdempotencyKeyPostgresRepository) Reserve(ctx context.Context, record *entities.IdempotencyKey, staleAfter time.Duration) (*entities.IdempotencyKey, bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (` + idempotencyKeyColumns + `)
+		VALUES ($1, $2, $3, $4, NULL, NULL, NULL, NULL, $5, $6)
+		ON CONFLICT (organization_id, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status = EXCLUDED.status, response_status = NULL,
+			response_body = NULL, content_type = NULL, etag = NULL, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= $5
+			OR (idempotency_keys.status = 'in_progress' AND idempotency_keys.created_at <= $7)
+		RETURNING organization_id`
+
+	selectQuery := `SELECT ` + idempotencyKeyColumns + ` FROM idempotency_keys WHERE organization_id = $1 AND key = $2`
+
+	// A concurrent purge can remove the conflicting row between both statements, so try twice
+	for attempt := 0; attempt < 2; attempt++ {
+		var orgID uuid.UUID
+		err := executor(ctx, r.db).QueryRowContext(ctx, query,
+			record.OrganizationID,
+			record.Key,
+			record.RequestHash,
+			record.Status,
+			record.CreatedAt,
+			record.ExpiresAt,
+			record.CreatedAt.Add(-staleAfter),
+		).Scan(&orgID)
+		if err == nil {
+			return record, true, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		existing, err := r.scanKey(executor(ctx, r.db).QueryRowContext(ctx, selectQuery, record.OrganizationID, record.Key))
+		if err == nil {
+			return existing, false, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, false, fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+	}
+
+	return nil, false, fmt.Errorf("failed to reserve idempotency key: concurrent modification")
+}
+
+// Complete stores the response of a reserved record
+func (r *IdempotencyKeyPostgresRepository) Complete(ctx context.Context, record *entities.IdempotencyKey) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = $3, response_status = $4, response_body = $5, content_type = $6, etag = $8
+		WHERE organization_id = $1 AND key = $2 AND request_hash = $7`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
+		record.OrganizationID,
+		record.Key,
+		record.Status,
+		record.ResponseStatus,
+		record.ResponseBody,
+		record.ContentType,
+		record.RequestHash,
+		record.ETag,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+// Release removes an in-progress record so the request can be retried
+func (r *IdempotencyKeyPostgresRepository) Release(ctx context.Context, orgID uuid.UUID, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE organization_id = $1 AND key = $2 AND status = 'in_progress'`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, orgID, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes records that expired before the given time
+func (r *IdempotencyKeyPostgresRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at < $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// scanKey scans a single idempotency key from a row
+func (r *IdempotencyKeyPostgresRepository) scanKey(row interface{ Scan(...interface{}) error }) (*entities.IdempotencyKey, error) {
+	var record entities.IdempotencyKey
+	var status string
+	var responseStatus sql.NullInt64
+
+	err := row.Scan(
+		&record.OrganizationID,
+		&record.Key,
+		&record.RequestHash,
+		&status,
+		&responseStatus,
+		&record.ResponseBody,
+		&record.ContentType,
+		&record.ETag,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Status = entities.IdempotencyStatus(status)
+	if responseStatus.Valid {
+		code := int(responseStatus.Int64)
+		record.ResponseStatus = &code
+	}
+
+	return &record, nil
+}
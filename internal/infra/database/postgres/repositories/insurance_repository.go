@@ -0,0 +1,567 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// InsurancePostgresRepository implements the InsuranceRepository interface
+type InsurancePostgresRepository struct {
+	db *sql.DB
+}
+
+// NewInsurancePostgresRepository creates a new instance of InsurancePostgresRepository
+func NewInsurancePostgresRepository(db *sql.DB) repositories.InsuranceRepository {
+	return &InsurancePostgresRepository{db: db}
+}
+
+const insuranceCarrierColumns = `id, organization_id, name, payer_id, phone, email, notes, active, created_at, updated_at`
+
+const insurancePolicyColumns = `id, organization_id, patient_id, carrier_id, member_id, group_number, subscriber_name, coverage, annual_maximum, deductible, effective_from, effective_to, created_by, created_at, updated_at`
+
+const insuranceClaimColumns = `id, organization_id, patient_id, policy_id, appointment_id, invoice_id, claim_number, status, currency, billed_amount, estimated_amount, deductible_applied, approved_amount, paid_amount, rejection_reason, service_date, submitted_at, decided_at, paid_at, notes, created_by, created_at, updated_at`
+
+// CreateCarrier stores a carrier
+func (r *InsurancePostgresRepository) CreateCarrier(ctx context.Context, carrier *entities.InsuranceCarrier) error {
+	query := `
+		INSERT INTO insurance_carriers (` + insuranceCarrierColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		carrier.ID,
+		carrier.OrganizationID,
+		carrier.Name,
+		carrier.PayerID,
+		carrier.Phone,
+		carrier.Email,
+		carrier.Notes,
+		carrier.Active,
+		carrier.CreatedAt,
+		carrier.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create insurance carrier: %w", err)
+	}
+
+	return nil
+}
+
+// GetCarrier retrieves a carrier of the organization
+func (r *InsurancePostgresRepository) GetCarrier(ctx context.Context, orgID, id uuid.UUID) (*entities.InsuranceCarrier, error) {
+	query := `SELECT ` + insuranceCarrierColumns + ` FROM insurance_carriers WHERE organization_id = $1 AND id = $2`
+
+	carrier, err := r.scanCarrier(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get insurance carrier: %w", err)
+	}
+
+	return carrier, nil
+}
+
+// ListCarriers retrieves the organization's carriers by name
+func (r *InsurancePostgresRepository) ListCarriers(ctx context.Context, orgID uuid.UUID, includeInactive bool) ([]*entities.InsuranceCarrier, error) {
+	query := `
+		SELECT ` + insuranceCarrierColumns + `
+		FROM insurance_carriers
+		WHERE organization_id = $1 AND (active OR $2)
+		ORDER BY name, id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list insurance carriers: %w", err)
+	}
+	defer rows.Close()
+
+	var carriers []*entities.InsuranceCarrier
+	for rows.Next() {
+		carrier, err := r.scanCarrier(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan insurance carrier: %w", err)
+		}
+		carriers = append(carriers, carrier)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over insurance carrier rows: %w", err)
+	}
+
+	return carriers, nil
+}
+
+// UpdateCarrier saves the fields of a carrier
+func (r *InsurancePostgresRepository) UpdateCarrier(ctx context.Context, carrier *entities.InsuranceCarrier) error {
+	query := `
+		UPDATE insurance_carriers
+		SET name = $3, payer_id = $4, phone = $5, email = $6, notes = $7, active = $8
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		carrier.OrganizationID,
+		carrier.ID,
+		carrier.Name,
+		carrier.PayerID,
+		carrier.Phone,
+		carrier.Email,
+		carrier.Notes,
+		carrier.Active,
+	).Scan(&carrier.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrInsuranceCarrierNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update insurance carrier: %w", err)
+	}
+
+	return nil
+}
+
+// CreatePolicy stores a patient's policy
+func (r *InsurancePostgresRepository) CreatePolicy(ctx context.Context, policy *entities.InsurancePolicy) error {
+	coverage, err := json.Marshal(policy.Coverage)
+	if err != nil {
+		return fmt.Errorf("failed to encode policy coverage: %w", err)
+	}
+
+	query := `
+		INSERT INTO insurance_policies (` + insurancePolicyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = executor(ctx, r.db).ExecContext(ctx, query,
+		policy.ID,
+		policy.OrganizationID,
+		policy.PatientID,
+		policy.CarrierID,
+		policy.MemberID,
+		policy.GroupNumber,
+		policy.SubscriberName,
+		coverage,
+		policy.AnnualMaximum,
+		policy.Deductible,
+		policy.EffectiveFrom,
+		policy.EffectiveTo,
+		policy.CreatedBy,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create insurance policy: %w", err)
+	}
+
+	return nil
+}
+
+// GetPolicy retrieves a policy of the organization
+func (r *InsurancePostgresRepository) GetPolicy(ctx context.Context, orgID, id uuid.UUID) (*entities.InsurancePolicy, error) {
+	query := `SELECT ` + insurancePolicyColumns + ` FROM insurance_policies WHERE organization_id = $1 AND id = $2`
+
+	policy, err := r.scanPolicy(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get insurance policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// ListPoliciesByPatient retrieves a patient's policies, most recent first
+func (r *InsurancePostgresRepository) ListPoliciesByPatient(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.InsurancePolicy, error) {
+	query := `
+		SELECT ` + insurancePolicyColumns + `
+		FROM insurance_policies
+		WHERE organization_id = $1 AND patient_id = $2
+		ORDER BY effective_from DESC, created_at DESC`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, patientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list insurance policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*entities.InsurancePolicy
+	for rows.Next() {
+		policy, err := r.scanPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan insurance policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over insurance policy rows: %w", err)
+	}
+
+	return policies, nil
+}
+
+// UpdatePolicy saves the fields of a policy
+func (r *InsurancePostgresRepository) UpdatePolicy(ctx context.Context, policy *entities.InsurancePolicy) error {
+	coverage, err := json.Marshal(policy.Coverage)
+	if err != nil {
+		return fmt.Errorf("failed to encode policy coverage: %w", err)
+	}
+
+	query := `
+		UPDATE insurance_policies
+		SET carrier_id = $3, member_id = $4, group_number = $5, subscriber_name = $6, coverage = $7,
+			annual_maximum = $8, deductible = $9, effective_from = $10, effective_to = $11
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err = executor(ctx, r.db).QueryRowContext(ctx, query,
+		policy.OrganizationID,
+		policy.ID,
+		policy.CarrierID,
+		policy.MemberID,
+		policy.GroupNumber,
+		policy.SubscriberName,
+		coverage,
+		policy.AnnualMaximum,
+		policy.Deductible,
+		policy.EffectiveFrom,
+		policy.EffectiveTo,
+	).Scan(&policy.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrInsurancePolicyNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update insurance policy: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePolicy removes a policy
+func (r *InsurancePostgresRepository) DeletePolicy(ctx context.Context, orgID, id uuid.UUID) error {
+	query := `DELETE FROM insurance_policies WHERE organization_id = $1 AND id = $2`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, orgID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete insurance policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrInsurancePolicyNotFound
+	}
+
+	return nil
+}
+
+// PolicyHasClaims checks if any claim was made under a policy
+func (r *InsurancePostgresRepository) PolicyHasClaims(ctx context.Context, policyID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM insurance_claims WHERE policy_id = $1)`
+
+	var exists bool
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, policyID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check insurance policy claims: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CreateClaim stores a claim
+func (r *InsurancePostgresRepository) CreateClaim(ctx context.Context, claim *entities.InsuranceClaim) error {
+	query := `
+		INSERT INTO insurance_claims (` + insuranceClaimColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		claim.ID,
+		claim.OrganizationID,
+		claim.PatientID,
+		claim.PolicyID,
+		claim.AppointmentID,
+		claim.InvoiceID,
+		claim.ClaimNumber,
+		string(claim.Status),
+		claim.Currency,
+		claim.BilledAmount,
+		claim.EstimatedAmount,
+		claim.DeductibleApplied,
+		claim.ApprovedAmount,
+		claim.PaidAmount,
+		claim.RejectionReason,
+		claim.ServiceDate,
+		claim.SubmittedAt,
+		claim.DecidedAt,
+		claim.PaidAt,
+		claim.Notes,
+		claim.CreatedBy,
+		claim.CreatedAt,
+		claim.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create insurance claim: %w", err)
+	}
+
+	return nil
+}
+
+// GetClaim retrieves a claim of the organization
+func (r *InsurancePostgresRepository) GetClaim(ctx context.Context, orgID, id uuid.UUID) (*entities.InsuranceClaim, error) {
+	query := `SELECT ` + insuranceClaimColumns + ` FROM insurance_claims WHERE organization_id = $1 AND id = $2`
+	return r.getClaim(ctx, query, orgID, id)
+}
+
+// GetClaimForUpdate retrieves a claim of the organization and locks it until the
+// transaction in ctx ends
+func (r *InsurancePostgresRepository) GetClaimForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.InsuranceClaim, error) {
+	query := `SELECT ` + insuranceClaimColumns + ` FROM insurance_claims WHERE organization_id = $1 AND id = $2 FOR UPDATE`
+	return r.getClaim(ctx, query, orgID, id)
+}
+
+// GetOpenClaim retrieves the claim of an appointment under a policy that is not rejected
+func (r *InsurancePostgresRepository) GetOpenClaim(ctx context.Context, policyID, appointmentID uuid.UUID) (*entities.InsuranceClaim, error) {
+	query := `SELECT ` + insuranceClaimColumns + ` FROM insurance_claims WHERE policy_id = $1 AND appointment_id = $2 AND status <> 'rejected'`
+	return r.getClaim(ctx, query, policyID, appointmentID)
+}
+
+// ListClaims retrieves a page of claims, most recently submitted first, and the total count
+func (r *InsurancePostgresRepository) ListClaims(ctx context.Context, filters repositories.InsuranceClaimFilters) ([]*entities.InsuranceClaim, int, error) {
+	params := []interface{}{filters.OrganizationID}
+	conditions := []string{"organization_id = $1"}
+
+	if filters.PatientID != nil {
+		params = append(params, *filters.PatientID)
+		conditions = append(conditions, fmt.Sprintf("patient_id = $%d", len(params)))
+	}
+	if filters.PolicyID != nil {
+		params = append(params, *filters.PolicyID)
+		conditions = append(conditions, fmt.Sprintf("policy_id = $%d", len(params)))
+	}
+	if filters.AppointmentID != nil {
+		params = append(params, *filters.AppointmentID)
+		conditions = append(conditions, fmt.Sprintf("appointment_id = $%d", len(params)))
+	}
+	if filters.Status != nil {
+		params = append(params, string(*filters.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(params)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM insurance_claims WHERE "+where, params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count insurance claims: %w", err)
+	}
+
+	params = append(params, filters.Limit, (filters.Page-1)*filters.Limit)
+	query := `
+		SELECT ` + insuranceClaimColumns + `
+		FROM insurance_claims
+		WHERE ` + where + `
+		ORDER BY submitted_at DESC, id
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(params)-1, len(params))
+
+	claims, err := r.listClaims(ctx, query, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return claims, total, nil
+}
+
+// ListClaimsByPolicy retrieves the claims of a policy for services within [from, to)
+func (r *InsurancePostgresRepository) ListClaimsByPolicy(ctx context.Context, policyID uuid.UUID, from, to time.Time) ([]*entities.InsuranceClaim, error) {
+	query := `
+		SELECT ` + insuranceClaimColumns + `
+		FROM insurance_claims
+		WHERE policy_id = $1 AND service_date >= $2 AND service_date < $3
+		ORDER BY service_date, submitted_at`
+
+	return r.listClaims(ctx, query, policyID, from, to)
+}
+
+// UpdateClaim saves the status and carrier response of a claim
+func (r *InsurancePostgresRepository) UpdateClaim(ctx context.Context, claim *entities.InsuranceClaim) error {
+	query := `
+		UPDATE insurance_claims
+		SET claim_number = $3, status = $4, deductible_applied = $5, approved_amount = $6, paid_amount = $7,
+			rejection_reason = $8, submitted_at = $9, decided_at = $10, paid_at = $11, notes = $12
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		claim.OrganizationID,
+		claim.ID,
+		claim.ClaimNumber,
+		string(claim.Status),
+		claim.DeductibleApplied,
+		claim.ApprovedAmount,
+		claim.PaidAmount,
+		claim.RejectionReason,
+		claim.SubmittedAt,
+		claim.DecidedAt,
+		claim.PaidAt,
+		claim.Notes,
+	).Scan(&claim.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrInsuranceClaimNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update insurance claim: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignPatient moves all policies and claims of one patient to another
+func (r *InsurancePostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	return runInTransaction(ctx, r.db, func(ctx context.Context) error {
+		if _, err := executor(ctx, r.db).ExecContext(ctx, `UPDATE insurance_policies SET patient_id = $2 WHERE patient_id = $1`, fromPatientID, toPatientID); err != nil {
+			return fmt.Errorf("failed to reassign insurance policies: %w", err)
+		}
+		if _, err := executor(ctx, r.db).ExecContext(ctx, `UPDATE insurance_claims SET patient_id = $2 WHERE patient_id = $1`, fromPatientID, toPatientID); err != nil {
+			return fmt.Errorf("failed to reassign insurance claims: %w", err)
+		}
+		return nil
+	})
+}
+
+// getClaim retrieves a single claim
+func (r *InsurancePostgresRepository) getClaim(ctx context.Context, query string, args ...interface{}) (*entities.InsuranceClaim, error) {
+	claim, err := r.scanClaim(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get insurance claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+// listClaims retrieves claims
+func (r *InsurancePostgresRepository) listClaims(ctx context.Context, query string, args ...interface{}) ([]*entities.InsuranceClaim, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list insurance claims: %w", err)
+	}
+	defer rows.Close()
+
+	var claims []*entities.InsuranceClaim
+	for rows.Next() {
+		claim, err := r.scanClaim(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan insurance claim: %w", err)
+		}
+		claims = append(claims, claim)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over insurance claim rows: %w", err)
+	}
+
+	return claims, nil
+}
+
+// scanCarrier scans a single carrier from a row
+func (r *InsurancePostgresRepository) scanCarrier(row interface{ Scan(...interface{}) error }) (*entities.InsuranceCarrier, error) {
+	var carrier entities.InsuranceCarrier
+
+	err := row.Scan(
+		&carrier.ID,
+		&carrier.OrganizationID,
+		&carrier.Name,
+		&carrier.PayerID,
+		&carrier.Phone,
+		&carrier.Email,
+		&carrier.Notes,
+		&carrier.Active,
+		&carrier.CreatedAt,
+		&carrier.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &carrier, nil
+}
+
+// scanPolicy scans a single policy from a row
+func (r *InsurancePostgresRepository) scanPolicy(row interface{ Scan(...interface{}) error }) (*entities.InsurancePolicy, error) {
+	var policy entities.InsurancePolicy
+	var coverage []byte
+
+	err := row.Scan(
+		&policy.ID,
+		&policy.OrganizationID,
+		&policy.PatientID,
+		&policy.CarrierID,
+		&policy.MemberID,
+		&policy.GroupNumber,
+		&policy.SubscriberName,
+		&coverage,
+		&policy.AnnualMaximum,
+		&policy.Deductible,
+		&policy.EffectiveFrom,
+		&policy.EffectiveTo,
+		&policy.CreatedBy,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(coverage, &policy.Coverage); err != nil {
+		return nil, fmt.Errorf("failed to decode policy coverage: %w", err)
+	}
+	return &policy, nil
+}
+
+// scanClaim scans a single claim from a row
+func (r *InsurancePostgresRepository) scanClaim(row interface{ Scan(...interface{}) error }) (*entities.InsuranceClaim, error) {
+	var claim entities.InsuranceClaim
+	var status string
+
+	err := row.Scan(
+		&claim.ID,
+		&claim.OrganizationID,
+		&claim.PatientID,
+		&claim.PolicyID,
+		&claim.AppointmentID,
+		&claim.InvoiceID,
+		&claim.ClaimNumber,
+		&status,
+		&claim.Currency,
+		&claim.BilledAmount,
+		&claim.EstimatedAmount,
+		&claim.DeductibleApplied,
+		&claim.ApprovedAmount,
+		&claim.PaidAmount,
+		&claim.RejectionReason,
+		&claim.ServiceDate,
+		&claim.SubmittedAt,
+		&claim.DecidedAt,
+		&claim.PaidAt,
+		&claim.Notes,
+		&claim.CreatedBy,
+		&claim.CreatedAt,
+		&claim.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claim.Status = entities.InsuranceClaimStatus(status)
+	return &claim, nil
+}
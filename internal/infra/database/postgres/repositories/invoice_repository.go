@@ -0,0 +1,504 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// InvoicePostgresRepository implements the InvoiceRepository interface
+type InvoicePostgresRepository struct {
+	db *sql.DB
+}
+
+// NewInvoicePostgresRepository creates a new instance of InvoicePostgresRepository
+func NewInvoicePostgresRepository(db *sql.DB) repositories.InvoiceRepository {
+	return &InvoicePostgresRepository{db: db}
+}
+
+const invoiceColumns = `id, organization_id, clinic_id, patient_id, appointment_id, number, currency, status, subtotal, discount_total, tax_total, total, amount_paid, amount_refunded, notes, issued_at, voided_at, voided_by, void_reason, created_by, created_at, updated_at`
+
+const invoiceLineColumns = `id, invoice_id, position, service_id, treatment_plan_item_id, description, quantity, unit_price, discount, tax_rate, tax, total`
+
+const paymentColumns = `id, organization_id, clinic_id, invoice_id, patient_id, kind, method, amount, currency, refund_of_id, reference, reason, received_by, received_at, created_at`
+
+// NextNumber hands out the next sequential invoice number of the organization
+func (r *InvoicePostgresRepository) NextNumber(ctx context.Context, orgID uuid.UUID) (string, error) {
+	query := `
+		INSERT INTO invoice_sequences (organization_id, last_number)
+		VALUES ($1, 1)
+		ON CONFLICT (organization_id) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING last_number`
+
+	var number int64
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID).Scan(&number); err != nil {
+		return "", fmt.Errorf("failed to get next invoice number: %w", err)
+	}
+
+	return fmt.Sprintf("INV-%06d", number), nil
+}
+
+// Create stores an invoice and its lines
+func (r *InvoicePostgresRepository) Create(ctx context.Context, invoice *entities.Invoice) error {
+	return runInTransaction(ctx, r.db, func(ctx context.Context) error {
+		query := `
+			INSERT INTO invoices (` + invoiceColumns + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`
+
+		_, err := executor(ctx, r.db).ExecContext(ctx, query,
+			invoice.ID,
+			invoice.OrganizationID,
+			invoice.ClinicID,
+			invoice.PatientID,
+			invoice.AppointmentID,
+			invoice.Number,
+			invoice.Currency,
+			string(invoice.Status),
+			invoice.Subtotal,
+			invoice.DiscountTotal,
+			invoice.TaxTotal,
+			invoice.Total,
+			invoice.AmountPaid,
+			invoice.AmountRefunded,
+			invoice.Notes,
+			invoice.IssuedAt,
+			invoice.VoidedAt,
+			invoice.VoidedBy,
+			invoice.VoidReason,
+			invoice.CreatedBy,
+			invoice.CreatedAt,
+			invoice.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create invoice: %w", err)
+		}
+
+		lineQuery := `
+			INSERT INTO invoice_lines (` + invoiceLineColumns + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+		for _, line := range invoice.Lines {
+			_, err := executor(ctx, r.db).ExecContext(ctx, lineQuery,
+				line.ID,
+				line.InvoiceID,
+				line.Position,
+				line.ServiceID,
+				line.TreatmentPlanItemID,
+				line.Description,
+				line.Quantity,
+				line.UnitPrice,
+				line.Discount,
+				line.TaxRate,
+				line.Tax,
+				line.Total,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create invoice line: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetByID retrieves an invoice of the organization
+func (r *InvoicePostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE organization_id = $1 AND id = $2`
+	return r.getOne(ctx, query, orgID, id)
+}
+
+// GetForUpdate retrieves an invoice of the organization and locks it until the
+// transaction in ctx ends
+func (r *InvoicePostgresRepository) GetForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE organization_id = $1 AND id = $2 FOR UPDATE`
+	return r.getOne(ctx, query, orgID, id)
+}
+
+// GetActiveByAppointment retrieves the non-void invoice of an appointment
+func (r *InvoicePostgresRepository) GetActiveByAppointment(ctx context.Context, appointmentID uuid.UUID) (*entities.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE appointment_id = $1 AND status <> 'void'`
+	return r.getOne(ctx, query, appointmentID)
+}
+
+// List retrieves a page of invoices, most recently issued first, and the total count
+func (r *InvoicePostgresRepository) List(ctx context.Context, filters repositories.InvoiceFilters) ([]*entities.Invoice, int, error) {
+	params := []interface{}{filters.OrganizationID}
+	conditions := []string{"organization_id = $1"}
+
+	if filters.PatientID != nil {
+		params = append(params, *filters.PatientID)
+		conditions = append(conditions, fmt.Sprintf("patient_id = $%d", len(params)))
+	}
+	if filters.ClinicID != nil {
+		params = append(params, *filters.ClinicID)
+		conditions = append(conditions, fmt.Sprintf("clinic_id = $%d", len(params)))
+	}
+	if filters.Status != nil {
+		params = append(params, string(*filters.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(params)))
+	}
+	if filters.IssuedFrom != nil {
+		params = append(params, *filters.IssuedFrom)
+		conditions = append(conditions, fmt.Sprintf("issued_at >= $%d", len(params)))
+	}
+	if filters.IssuedTo != nil {
+		params = append(params, *filters.IssuedTo)
+		conditions = append(conditions, fmt.Sprintf("issued_at < $%d", len(params)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM invoices WHERE "+where, params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count invoices: %w", err)
+	}
+
+	params = append(params, filters.Limit, (filters.Page-1)*filters.Limit)
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE ` + where + `
+		ORDER BY issued_at DESC, id
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(params)-1, len(params))
+
+	invoices, err := r.list(ctx, query, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return invoices, total, nil
+}
+
+// ListByPatient retrieves all invoices of a patient, oldest first
+func (r *InvoicePostgresRepository) ListByPatient(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE organization_id = $1 AND patient_id = $2
+		ORDER BY issued_at, id`
+
+	return r.list(ctx, query, orgID, patientID)
+}
+
+// Update saves the status, paid amounts and void fields of an invoice
+func (r *InvoicePostgresRepository) Update(ctx context.Context, invoice *entities.Invoice) error {
+	query := `
+		UPDATE invoices
+		SET status = $3, amount_paid = $4, amount_refunded = $5, voided_at = $6, voided_by = $7, void_reason = $8
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		invoice.OrganizationID,
+		invoice.ID,
+		string(invoice.Status),
+		invoice.AmountPaid,
+		invoice.AmountRefunded,
+		invoice.VoidedAt,
+		invoice.VoidedBy,
+		invoice.VoidReason,
+	).Scan(&invoice.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrInvoiceNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update invoice: %w", err)
+	}
+
+	return nil
+}
+
+// CreatePayment appends an entry to the payments ledger
+func (r *InvoicePostgresRepository) CreatePayment(ctx context.Context, payment *entities.Payment) error {
+	query := `
+		INSERT INTO payments (` + paymentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		payment.ID,
+		payment.OrganizationID,
+		payment.ClinicID,
+		payment.InvoiceID,
+		payment.PatientID,
+		string(payment.Kind),
+		string(payment.Method),
+		payment.Amount,
+		payment.Currency,
+		payment.RefundOfID,
+		payment.Reference,
+		payment.Reason,
+		payment.ReceivedBy,
+		payment.ReceivedAt,
+		payment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	return nil
+}
+
+// GetPayment retrieves a ledger entry of an invoice
+func (r *InvoicePostgresRepository) GetPayment(ctx context.Context, invoiceID, paymentID uuid.UUID) (*entities.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE invoice_id = $1 AND id = $2`
+
+	payment, err := r.scanPayment(executor(ctx, r.db).QueryRowContext(ctx, query, invoiceID, paymentID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// ListPayments retrieves the ledger of an invoice in the order money changed hands
+func (r *InvoicePostgresRepository) ListPayments(ctx context.Context, invoiceID uuid.UUID) ([]*entities.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE invoice_id = $1
+		ORDER BY received_at, created_at`
+
+	return r.listPayments(ctx, query, invoiceID)
+}
+
+// RefundedAmount returns how much has been given back from a payment
+func (r *InvoicePostgresRepository) RefundedAmount(ctx context.Context, paymentID uuid.UUID) (int64, error) {
+	query := `SELECT COALESCE(SUM(amount), 0) FROM payments WHERE refund_of_id = $1`
+
+	var refunded int64
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, paymentID).Scan(&refunded); err != nil {
+		return 0, fmt.Errorf("failed to get refunded amount: %w", err)
+	}
+
+	return refunded, nil
+}
+
+// ListPaymentsByClinic retrieves a clinic's ledger entries received within [from, to)
+func (r *InvoicePostgresRepository) ListPaymentsByClinic(ctx context.Context, orgID, clinicID uuid.UUID, from, to time.Time) ([]*entities.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE organization_id = $1 AND clinic_id = $2 AND received_at >= $3 AND received_at < $4
+		ORDER BY received_at, created_at`
+
+	return r.listPayments(ctx, query, orgID, clinicID, from, to)
+}
+
+// ReassignPatient moves all invoices and payments of one patient to another
+func (r *InvoicePostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	return runInTransaction(ctx, r.db, func(ctx context.Context) error {
+		if _, err := executor(ctx, r.db).ExecContext(ctx, `UPDATE invoices SET patient_id = $2 WHERE patient_id = $1`, fromPatientID, toPatientID); err != nil {
+			return fmt.Errorf("failed to reassign invoices: %w", err)
+		}
+		if _, err := executor(ctx, r.db).ExecContext(ctx, `UPDATE payments SET patient_id = $2 WHERE patient_id = $1`, fromPatientID, toPatientID); err != nil {
+			return fmt.Errorf("failed to reassign payments: %w", err)
+		}
+		return nil
+	})
+}
+
+// getOne retrieves a single invoice with its lines
+func (r *InvoicePostgresRepository) getOne(ctx context.Context, query string, args ...interface{}) (*entities.Invoice, error) {
+	invoice, err := r.scanInvoice(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if err := r.loadLines(ctx, []*entities.Invoice{invoice}); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// list retrieves invoices with their lines
+func (r *InvoicePostgresRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Invoice, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	defer rows.Close()
+
+	var invoices []*entities.Invoice
+	for rows.Next() {
+		invoice, err := r.scanInvoice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice: %w", err)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over invoice rows: %w", err)
+	}
+
+	if err := r.loadLines(ctx, invoices); err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+// loadLines attaches the lines of each invoice, ordered by position
+func (r *InvoicePostgresRepository) loadLines(ctx context.Context, invoices []*entities.Invoice) error {
+	if len(invoices) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*entities.Invoice, len(invoices))
+	ids := make(pq.StringArray, len(invoices))
+	for i, invoice := range invoices {
+		invoice.Lines = []*entities.InvoiceLine{}
+		byID[invoice.ID] = invoice
+		ids[i] = invoice.ID.String()
+	}
+
+	query := `
+		SELECT ` + invoiceLineColumns + `
+		FROM invoice_lines
+		WHERE invoice_id = ANY($1)
+		ORDER BY position`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.InvoiceLine
+
+		err := rows.Scan(
+			&line.ID,
+			&line.InvoiceID,
+			&line.Position,
+			&line.ServiceID,
+			&line.TreatmentPlanItemID,
+			&line.Description,
+			&line.Quantity,
+			&line.UnitPrice,
+			&line.Discount,
+			&line.TaxRate,
+			&line.Tax,
+			&line.Total,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan invoice line: %w", err)
+		}
+
+		if invoice := byID[line.InvoiceID]; invoice != nil {
+			invoice.Lines = append(invoice.Lines, &line)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over invoice line rows: %w", err)
+	}
+
+	return nil
+}
+
+// listPayments retrieves ledger entries
+func (r *InvoicePostgresRepository) listPayments(ctx context.Context, query string, args ...interface{}) ([]*entities.Payment, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	defer rows.Close()
+
+	var payments []*entities.Payment
+	for rows.Next() {
+		payment, err := r.scanPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over payment rows: %w", err)
+	}
+
+	return payments, nil
+}
+
+// scanInvoice scans a single invoice from a row
+func (r *InvoicePostgresRepository) scanInvoice(row interface{ Scan(...interface{}) error }) (*entities.Invoice, error) {
+	var invoice entities.Invoice
+	var status string
+
+	err := row.Scan(
+		&invoice.ID,
+		&invoice.OrganizationID,
+		&invoice.ClinicID,
+		&invoice.PatientID,
+		&invoice.AppointmentID,
+		&invoice.Number,
+		&invoice.Currency,
+		&status,
+		&invoice.Subtotal,
+		&invoice.DiscountTotal,
+		&invoice.TaxTotal,
+		&invoice.Total,
+		&invoice.AmountPaid,
+		&invoice.AmountRefunded,
+		&invoice.Notes,
+		&invoice.IssuedAt,
+		&invoice.VoidedAt,
+		&invoice.VoidedBy,
+		&invoice.VoidReason,
+		&invoice.CreatedBy,
+		&invoice.CreatedAt,
+		&invoice.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice.Status = entities.InvoiceStatus(status)
+	return &invoice, nil
+}
+
+// scanPayment scans a single ledger entry from a row
+func (r *InvoicePostgresRepository) scanPayment(row interface{ Scan(...interface{}) error }) (*entities.Payment, error) {
+	var payment entities.Payment
+	var kind, method string
+
+	err := row.Scan(
+		&payment.ID,
+		&payment.OrganizationID,
+		&payment.ClinicID,
+		&payment.InvoiceID,
+		&payment.PatientID,
+		&kind,
+		&method,
+		&payment.Amount,
+		&payment.Currency,
+		&payment.RefundOfID,
+		&payment.Reference,
+		&payment.Reason,
+		&payment.ReceivedBy,
+		&payment.ReceivedAt,
+		&payment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	payment.Kind = entities.PaymentKind(kind)
+	payment.Method = entities.PaymentMethod(method)
+	return &payment, nil
+}
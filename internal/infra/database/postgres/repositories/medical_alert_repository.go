@@ -0,0 +1,304 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// MedicalAlertPostgresRepository implements the MedicalAlertRepository interface
+type MedicalAlertPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewMedicalAlertPostgresRepository creates a new instance of MedicalAlertPostgresRepository
+func NewMedicalAlertPostgresRepository(db *sql.DB) repositories.MedicalAlertRepository {
+	return &MedicalAlertPostgresRepository{db: db}
+}
+
+const medicalAlertColumns = `id, organization_id, patient_id, category, title, severity, notes, review_date, last_reviewed_at, last_reviewed_by, resolved_at, resolved_by, created_by, updated_by, created_at, updated_at`
+
+const medicalAlertChangeColumns = `id, organization_id, patient_id, alert_id, action, previous, current, reason, changed_by, created_at`
+
+// medicalAlertOrder lists the most severe alerts first, then the most recent
+const medicalAlertOrder = `CASE severity WHEN 'high' THEN 3 WHEN 'moderate' THEN 2 ELSE 1 END DESC, created_at DESC`
+
+// Create stores a new alert
+func (r *MedicalAlertPostgresRepository) Create(ctx context.Context, alert *entities.MedicalAlert) error {
+	query := `
+		INSERT INTO medical_alerts (` + medicalAlertColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		alert.ID,
+		alert.OrganizationID,
+		alert.PatientID,
+		string(alert.Category),
+		alert.Title,
+		string(alert.Severity),
+		alert.Notes,
+		alert.ReviewDate,
+		alert.LastReviewedAt,
+		alert.LastReviewedBy,
+		alert.ResolvedAt,
+		alert.ResolvedBy,
+		alert.CreatedBy,
+		alert.UpdatedBy,
+		alert.CreatedAt,
+		alert.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create medical alert: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an alert of the organization
+func (r *MedicalAlertPostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.MedicalAlert, error) {
+	query := `SELECT ` + medicalAlertColumns + ` FROM medical_alerts WHERE organization_id = $1 AND id = $2`
+
+	alert, err := r.scanMedicalAlert(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get medical alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// ListByPatient retrieves a patient's alerts, most severe first
+func (r *MedicalAlertPostgresRepository) ListByPatient(ctx context.Context, orgID, patientID uuid.UUID, includeResolved bool) ([]*entities.MedicalAlert, error) {
+	query := `
+		SELECT ` + medicalAlertColumns + `
+		FROM medical_alerts
+		WHERE organization_id = $1 AND patient_id = $2 AND ($3 OR resolved_at IS NULL)
+		ORDER BY resolved_at IS NOT NULL, ` + medicalAlertOrder
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, patientID, includeResolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list medical alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []*entities.MedicalAlert{}
+	for rows.Next() {
+		alert, err := r.scanMedicalAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan medical alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over medical alert rows: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// ListActiveByPatients retrieves the active alerts of several patients grouped by patient
+func (r *MedicalAlertPostgresRepository) ListActiveByPatients(ctx context.Context, orgID uuid.UUID, patientIDs []uuid.UUID) (map[uuid.UUID][]*entities.MedicalAlert, error) {
+	alerts := make(map[uuid.UUID][]*entities.MedicalAlert)
+	if len(patientIDs) == 0 {
+		return alerts, nil
+	}
+
+	query := `
+		SELECT ` + medicalAlertColumns + `
+		FROM medical_alerts
+		WHERE organization_id = $1 AND patient_id = ANY($2) AND resolved_at IS NULL
+		ORDER BY ` + medicalAlertOrder
+
+	ids := make(pq.StringArray, len(patientIDs))
+	for i, id := range patientIDs {
+		ids[i] = id.String()
+	}
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active medical alerts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		alert, err := r.scanMedicalAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan medical alert: %w", err)
+		}
+		alerts[alert.PatientID] = append(alerts[alert.PatientID], alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over medical alert rows: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// Update saves the details, review and resolution of an alert
+func (r *MedicalAlertPostgresRepository) Update(ctx context.Context, alert *entities.MedicalAlert) error {
+	query := `
+		UPDATE medical_alerts
+		SET category = $3, title = $4, severity = $5, notes = $6, review_date = $7,
+			last_reviewed_at = $8, last_reviewed_by = $9, resolved_at = $10, resolved_by = $11, updated_by = $12
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		alert.OrganizationID,
+		alert.ID,
+		string(alert.Category),
+		alert.Title,
+		string(alert.Severity),
+		alert.Notes,
+		alert.ReviewDate,
+		alert.LastReviewedAt,
+		alert.LastReviewedBy,
+		alert.ResolvedAt,
+		alert.ResolvedBy,
+		alert.UpdatedBy,
+	).Scan(&alert.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrMedicalAlertNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update medical alert: %w", err)
+	}
+
+	return nil
+}
+
+// CreateChange stores the audit record of a change to an alert
+func (r *MedicalAlertPostgresRepository) CreateChange(ctx context.Context, change *entities.MedicalAlertChange) error {
+	query := `
+		INSERT INTO medical_alert_changes (` + medicalAlertChangeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	var previous interface{}
+	if change.Previous != nil {
+		previous = []byte(change.Previous)
+	}
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		change.ID,
+		change.OrganizationID,
+		change.PatientID,
+		change.AlertID,
+		string(change.Action),
+		previous,
+		[]byte(change.Current),
+		change.Reason,
+		change.ChangedBy,
+		change.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create medical alert change: %w", err)
+	}
+
+	return nil
+}
+
+// ListChanges retrieves the audit trail of an alert, oldest first
+func (r *MedicalAlertPostgresRepository) ListChanges(ctx context.Context, orgID, alertID uuid.UUID) ([]*entities.MedicalAlertChange, error) {
+	query := `
+		SELECT ` + medicalAlertChangeColumns + `
+		FROM medical_alert_changes
+		WHERE organization_id = $1 AND alert_id = $2
+		ORDER BY created_at, id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, alertID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list medical alert changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*entities.MedicalAlertChange{}
+	for rows.Next() {
+		var change entities.MedicalAlertChange
+		var action string
+		var previous, current []byte
+
+		err := rows.Scan(
+			&change.ID,
+			&change.OrganizationID,
+			&change.PatientID,
+			&change.AlertID,
+			&action,
+			&previous,
+			&current,
+			&change.Reason,
+			&change.ChangedBy,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan medical alert change: %w", err)
+		}
+
+		change.Action = entities.MedicalAlertAction(action)
+		change.Previous = previous
+		change.Current = current
+		changes = append(changes, &change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over medical alert change rows: %w", err)
+	}
+
+	return changes, nil
+}
+
+// ReassignPatient moves all alerts and their audit records of one patient to another
+func (r *MedicalAlertPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	if _, err := executor(ctx, r.db).ExecContext(ctx, `UPDATE medical_alerts SET patient_id = $2 WHERE patient_id = $1`, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign medical alerts: %w", err)
+	}
+	if _, err := executor(ctx, r.db).ExecContext(ctx, `UPDATE medical_alert_changes SET patient_id = $2 WHERE patient_id = $1`, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign medical alert changes: %w", err)
+	}
+
+	return nil
+}
+
+// scanMedicalAlert scans a single alert from a row
+func (r *MedicalAlertPostgresRepository) scanMedicalAlert(row interface{ Scan(...interface{}) error }) (*entities.MedicalAlert, error) {
+	var alert entities.MedicalAlert
+	var category, severity string
+
+	err := row.Scan(
+		&alert.ID,
+		&alert.OrganizationID,
+		&alert.PatientID,
+		&category,
+		&alert.Title,
+		&severity,
+		&alert.Notes,
+		&alert.ReviewDate,
+		&alert.LastReviewedAt,
+		&alert.LastReviewedBy,
+		&alert.ResolvedAt,
+		&alert.ResolvedBy,
+		&alert.CreatedBy,
+		&alert.UpdatedBy,
+		&alert.CreatedAt,
+		&alert.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	alert.Category = entities.MedicalAlertCategory(category)
+	alert.Severity = entities.MedicalAlertSeverity(severity)
+
+	return &alert, nil
+}
@@ -25,7 +25,7 @@ func NewOrganizationPostgresRepository(db *sql.DB) repositories.OrganizationRepo
 // GetByID retrieves an organization by its ID
 func (r *OrganizationPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Organization, error) {
 	query := `
-		SELECT id, name, description, address, phone, email, website, is_active, created_at, updated_at
+		SELECT id, name, description, address, phone, email, website, is_active, default_phone_region, currency, created_at, updated_at
 		FROM organizations
 		WHERE id = $1`
 
@@ -39,6 +39,8 @@ func (r *OrganizationPostgresRepository) GetByID(ctx context.Context, id uuid.UU
 		&org.Email,
 		&org.Website,
 		&org.IsActive,
+		&org.DefaultPhoneRegion,
+		&org.Currency,
 		&org.CreatedAt,
 		&org.UpdatedAt,
 	)
@@ -66,6 +68,29 @@ func (r *OrganizationPostgresRepository) Exists(ctx context.Context, id uuid.UUI
 	return exists, nil
 }
 
+// UpdateSettings stores the organization's settings
+func (r *OrganizationPostgresRepository) UpdateSettings(ctx context.Context, org *entities.Organization) error {
+	query := `
+		UPDATE organizations
+		SET default_phone_region = $2, currency = $3, updated_at = $4
+		WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, org.ID, org.DefaultPhoneRegion, org.Currency, org.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update organization settings: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entities.ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
 // GetOrganizationData retrieves complete organization data for calendar loading
 func (r *OrganizationPostgresRepository) GetOrganizationData(ctx context.Context, orgID uuid.UUID, startDate, endDate time.Time, limit int) (*repositories.OrganizationData, error) {
 	// Get organization
@@ -357,7 +382,7 @@ func (r *OrganizationPostgresRepository) getAppointmentsByOrganization(ctx conte
 // getServicesByOrganization retrieves all services for an organization
 func (r *OrganizationPostgresRepository) getServicesByOrganization(ctx context.Context, orgID uuid.UUID) ([]*entities.Service, error) {
 	query := `
-		SELECT id, name, base_price, organization_id, created_at, updated_at
+		SELECT id, name, base_price, category, deposit_type, deposit_amount, deposit_percent, organization_id, created_at, updated_at
 		FROM services
 		WHERE organization_id = $1
 		ORDER BY name`
@@ -371,10 +396,16 @@ func (r *OrganizationPostgresRepository) getServicesByOrganization(ctx context.C
 	var services []*entities.Service
 	for rows.Next() {
 		var service entities.Service
+		var category string
+		var depositType *string
 		err := rows.Scan(
 			&service.ID,
 			&service.Name,
 			&service.BasePrice,
+			&category,
+			&depositType,
+			&service.DepositAmount,
+			&service.DepositPercent,
 			&service.OrganizationID,
 			&service.CreatedAt,
 			&service.UpdatedAt,
@@ -382,6 +413,11 @@ func (r *OrganizationPostgresRepository) getServicesByOrganization(ctx context.C
 		if err != nil {
 			return nil, err
 		}
+		service.Category = entities.ServiceCategory(category)
+		if depositType != nil {
+			t := entities.DepositType(*depositType)
+			service.DepositType = &t
+		}
 		services = append(services, &service)
 	}
 
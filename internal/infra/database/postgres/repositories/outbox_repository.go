@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+)
+
+// OutboxPostgresRepository implements the OutboxRepository interface
+type OutboxPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxPostgresRepository creates a new instance of OutboxPostgresRepository
+func NewOutboxPostgresRepository(db *sql.DB) repositories.OutboxRepository {
+	return &OutboxPostgresRepository{db: db}
+}
+
+const outboxEventColumns = `id, organization_id, event_type, aggregate_type, aggregate_id, payload, occurred_at, status, attempts, next_attempt_at, handled_by, last_error, processed_at, created_at, updated_at`
+
+// Append records domain events; call it within the transaction that persists the change
+func (r *OutboxPostgresRepository) Append(ctx context.Context, events ...*entities.DomainEvent) error {
+	query := `
+		INSERT INTO outbox_events (` + outboxEventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	for _, domainEvent := range events {
+		event := entities.NewOutboxEvent(domainEvent)
+		_, err := executor(ctx, r.db).ExecContext(ctx, query,
+			event.ID,
+			event.OrganizationID,
+			event.Type,
+			event.AggregateType,
+			event.AggregateID,
+			[]byte(event.Payload),
+			event.OccurredAt,
+			event.Status,
+			event.Attempts,
+			event.NextAttemptAt,
+			event.HandledBy,
+			event.LastError,
+			event.ProcessedAt,
+			event.CreatedAt,
+			event.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to append %s event to outbox: %w", event.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimDue locks up to limit pending events due at now and postpones them by lease
+func (r *OutboxPostgresRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.OutboxEvent, error) {
+	query := `
+		UPDATE outbox_events
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, occurred_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + outboxEventColumns
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entities.OutboxEvent
+	for rows.Next() {
+		event, err := r.scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over outbox event rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// Update updates the dispatch state of an event
+func (r *OutboxPostgresRepository) Update(ctx context.Context, event *entities.OutboxEvent) error {
+	query := `
+		UPDATE outbox_events
+		SET status = $2, attempts = $3, next_attempt_at = $4, handled_by = $5,
+			last_error = $6, processed_at = $7, updated_at = $8
+		WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
+		event.ID,
+		event.Status,
+		event.Attempts,
+		event.NextAttemptAt,
+		event.HandledBy,
+		event.LastError,
+		event.ProcessedAt,
+		event.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update outbox event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrOutboxEventNotFound
+	}
+
+	return nil
+}
+
+// DeleteProcessedBefore removes events processed before the given time
+func (r *OutboxPostgresRepository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM outbox_events WHERE status = 'processed' AND processed_at < $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox events: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// scanEvent scans a single outbox event from a row
+func (r *OutboxPostgresRepository) scanEvent(row interface{ Scan(...interface{}) error }) (*entities.OutboxEvent, error) {
+	var event entities.OutboxEvent
+	var eventType, status string
+	var payload []byte
+
+	err := row.Scan(
+		&event.ID,
+		&event.OrganizationID,
+		&eventType,
+		&event.AggregateType,
+		&event.AggregateID,
+		&payload,
+		&event.OccurredAt,
+		&status,
+		&event.Attempts,
+		&event.NextAttemptAt,
+		&event.HandledBy,
+		&event.LastError,
+		&event.ProcessedAt,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Type = entities.DomainEventType(eventType)
+	event.Status = entities.OutboxStatus(status)
+	event.Payload = json.RawMessage(payload)
+
+	return &event, nil
+}
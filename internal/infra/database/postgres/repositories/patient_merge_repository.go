@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// PatientMergePostgresRepository implements the PatientMergeRepository interface
+type PatientMergePostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPatientMergePostgresRepository creates a new instance of PatientMergePostgresRepository
+func NewPatientMergePostgresRepository(db *sql.DB) repositories.PatientMergeRepository {
+	return &PatientMergePostgresRepository{db: db}
+}
+
+const patientMergeColumns = `id, organization_id, survivor_patient_id, merged_patient_id, merged_patient_snapshot, appointments_moved, score, reason, merged_by, created_at`
+
+// Create stores a merge record
+func (r *PatientMergePostgresRepository) Create(ctx context.Context, merge *entities.PatientMerge) error {
+	query := `
+		INSERT INTO patient_merges (` + patientMergeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		merge.ID,
+		merge.OrganizationID,
+		merge.SurvivorPatientID,
+		merge.MergedPatientID,
+		[]byte(merge.MergedPatientSnapshot),
+		merge.AppointmentsMoved,
+		merge.Score,
+		merge.Reason,
+		merge.MergedBy,
+		merge.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create patient merge: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a merge record by its ID
+func (r *PatientMergePostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PatientMerge, error) {
+	query := `SELECT ` + patientMergeColumns + ` FROM patient_merges WHERE id = $1`
+
+	merge, err := r.scanMerge(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get patient merge: %w", err)
+	}
+
+	return merge, nil
+}
+
+// GetByOrganizationID retrieves merge records of an organization, newest first
+func (r *PatientMergePostgresRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*entities.PatientMerge, int, error) {
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM patient_merges WHERE organization_id = $1`
+	if err := executor(ctx, r.db).QueryRowContext(ctx, countQuery, orgID).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count patient merges: %w", err)
+	}
+
+	query := `
+		SELECT ` + patientMergeColumns + `
+		FROM patient_merges
+		WHERE organization_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get patient merges: %w", err)
+	}
+	defer rows.Close()
+
+	var merges []*entities.PatientMerge
+	for rows.Next() {
+		merge, err := r.scanMerge(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan patient merge: %w", err)
+		}
+		merges = append(merges, merge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over patient merge rows: %w", err)
+	}
+
+	return merges, totalCount, nil
+}
+
+// scanMerge scans a single merge record from a row
+func (r *PatientMergePostgresRepository) scanMerge(row interface{ Scan(...interface{}) error }) (*entities.PatientMerge, error) {
+	var merge entities.PatientMerge
+	var snapshot []byte
+
+	err := row.Scan(
+		&merge.ID,
+		&merge.OrganizationID,
+		&merge.SurvivorPatientID,
+		&merge.MergedPatientID,
+		&snapshot,
+		&merge.AppointmentsMoved,
+		&merge.Score,
+		&merge.Reason,
+		&merge.MergedBy,
+		&merge.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	merge.MergedPatientSnapshot = json.RawMessage(snapshot)
+
+	return &merge, nil
+}
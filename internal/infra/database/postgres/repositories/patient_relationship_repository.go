@@ -0,0 +1,328 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PatientRelationshipPostgresRepository implements the PatientRelationshipRepository interface
+type PatientRelationshipPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPatientRelationshipPostgresRepository creates a new instance of PatientRelationshipPostgresRepository
+func NewPatientRelationshipPostgresRepository(db *sql.DB) repositories.PatientRelationshipRepository {
+	return &PatientRelationshipPostgresRepository{db: db}
+}
+
+const patientRelationshipColumns = `id, organization_id, patient_id, related_patient_id, type, is_contact, created_by, created_at, updated_at`
+
+// CreatePair stores a relationship together with its inverse
+func (r *PatientRelationshipPostgresRepository) CreatePair(ctx context.Context, relationship, inverse *entities.PatientRelationship) error {
+	query := `
+		INSERT INTO patient_relationships (` + patientRelationshipColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9), ($10, $11, $12, $13, $14, $15, $16, $17, $18)`
+
+	params := make([]interface{}, 0, 18)
+	for _, rel := range []*entities.PatientRelationship{relationship, inverse} {
+		params = append(params,
+			rel.ID,
+			rel.OrganizationID,
+			rel.PatientID,
+			rel.RelatedPatientID,
+			string(rel.Type),
+			rel.IsContact,
+			rel.CreatedBy,
+			rel.CreatedAt,
+			rel.UpdatedAt,
+		)
+	}
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, params...); err != nil {
+		return fmt.Errorf("failed to create patient relationship: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves the relationship of a patient with a related patient
+func (r *PatientRelationshipPostgresRepository) Get(ctx context.Context, orgID, patientID, relatedPatientID uuid.UUID) (*entities.PatientRelationship, error) {
+	query := `
+		SELECT ` + patientRelationshipColumns + `
+		FROM patient_relationships
+		WHERE organization_id = $1 AND patient_id = $2 AND related_patient_id = $3`
+
+	var rel entities.PatientRelationship
+	var relationshipType string
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID, patientID, relatedPatientID).Scan(
+		&rel.ID,
+		&rel.OrganizationID,
+		&rel.PatientID,
+		&rel.RelatedPatientID,
+		&relationshipType,
+		&rel.IsContact,
+		&rel.CreatedBy,
+		&rel.CreatedAt,
+		&rel.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get patient relationship: %w", err)
+	}
+
+	rel.Type = entities.PatientRelationshipType(relationshipType)
+	return &rel, nil
+}
+
+// ListByPatient retrieves the relatives of a patient, contact person first
+func (r *PatientRelationshipPostgresRepository) ListByPatient(ctx context.Context, orgID, patientID uuid.UUID) ([]*repositories.PatientRelative, error) {
+	query := `
+		SELECT pr.id, pr.organization_id, pr.patient_id, pr.related_patient_id, pr.type, pr.is_contact, pr.created_by, pr.created_at, pr.updated_at,
+			p.id, p.first_name, p.last_name, p.email, p.phone, p.phone_e164, p.date_of_birth, p.medical_history, p.first_appointment_id, p.created_at, p.updated_at, p.version
+		FROM patient_relationships pr
+		INNER JOIN patients p ON p.id = pr.related_patient_id
+		WHERE pr.organization_id = $1 AND pr.patient_id = $2
+		ORDER BY pr.is_contact DESC,
+			CASE pr.type WHEN 'guardian' THEN 1 WHEN 'dependent' THEN 2 ELSE 3 END,
+			p.first_name, p.last_name, p.id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, patientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list patient relationships: %w", err)
+	}
+	defer rows.Close()
+
+	relatives := []*repositories.PatientRelative{}
+	for rows.Next() {
+		var rel entities.PatientRelationship
+		var patient entities.Patient
+		var relationshipType string
+
+		err := rows.Scan(
+			&rel.ID,
+			&rel.OrganizationID,
+			&rel.PatientID,
+			&rel.RelatedPatientID,
+			&relationshipType,
+			&rel.IsContact,
+			&rel.CreatedBy,
+			&rel.CreatedAt,
+			&rel.UpdatedAt,
+			&patient.ID,
+			&patient.FirstName,
+			&patient.LastName,
+			&patient.Email,
+			&patient.Phone,
+			&patient.PhoneE164,
+			&patient.DateOfBirth,
+			&patient.MedicalHistory,
+			&patient.FirstAppointmentID,
+			&patient.CreatedAt,
+			&patient.UpdatedAt,
+			&patient.Version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan patient relationship: %w", err)
+		}
+
+		rel.Type = entities.PatientRelationshipType(relationshipType)
+		relatives = append(relatives, &repositories.PatientRelative{Relationship: &rel, Patient: &patient})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over patient relationship rows: %w", err)
+	}
+
+	return relatives, nil
+}
+
+// DeletePair removes the relationship between two patients in both directions
+func (r *PatientRelationshipPostgresRepository) DeletePair(ctx context.Context, orgID, patientID, relatedPatientID uuid.UUID) error {
+	query := `
+		DELETE FROM patient_relationships
+		WHERE organization_id = $1
+			AND ((patient_id = $2 AND related_patient_id = $3) OR (patient_id = $3 AND related_patient_id = $2))`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, orgID, patientID, relatedPatientID)
+	if err != nil {
+		return fmt.Errorf("failed to delete patient relationship: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrPatientRelationshipNotFound
+	}
+
+	return nil
+}
+
+// SetContact makes a guardian the contact person of a patient. The previous contact is cleared
+// first so the one-contact-per-patient index is never violated mid-statement.
+func (r *PatientRelationshipPostgresRepository) SetContact(ctx context.Context, orgID, patientID, guardianID uuid.UUID) error {
+	return runInTransaction(ctx, r.db, func(ctx context.Context) error {
+		clearQuery := `
+			UPDATE patient_relationships SET is_contact = FALSE
+			WHERE organization_id = $1 AND patient_id = $2 AND is_contact AND related_patient_id <> $3`
+
+		if _, err := executor(ctx, r.db).ExecContext(ctx, clearQuery, orgID, patientID, guardianID); err != nil {
+			return fmt.Errorf("failed to clear patient contact person: %w", err)
+		}
+
+		setQuery := `
+			UPDATE patient_relationships SET is_contact = TRUE
+			WHERE organization_id = $1 AND patient_id = $2 AND related_patient_id = $3 AND type = 'guardian'`
+
+		result, err := executor(ctx, r.db).ExecContext(ctx, setQuery, orgID, patientID, guardianID)
+		if err != nil {
+			return fmt.Errorf("failed to set patient contact person: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return entities.ErrContactPersonNotGuardian
+		}
+
+		return nil
+	})
+}
+
+// ListHouseholds retrieves the households of several patients by walking their relationships
+func (r *PatientRelationshipPostgresRepository) ListHouseholds(ctx context.Context, orgID uuid.UUID, patientIDs []uuid.UUID) (map[uuid.UUID]*repositories.PatientHousehold, error) {
+	households := make(map[uuid.UUID]*repositories.PatientHousehold)
+	if len(patientIDs) == 0 {
+		return households, nil
+	}
+
+	// UNION (not UNION ALL) drops already visited members, so cycles end the recursion
+	query := `
+		WITH RECURSIVE household(root_id, patient_id) AS (
+			SELECT root.id, root.id FROM unnest($2::uuid[]) AS root(id)
+			UNION
+			SELECT h.root_id, pr.related_patient_id
+			FROM household h
+			INNER JOIN patient_relationships pr ON pr.organization_id = $1 AND pr.patient_id = h.patient_id
+		)
+		SELECT h.root_id, p.id, p.first_name, p.last_name, p.email, p.phone, p.phone_e164, p.date_of_birth, p.medical_history, p.first_appointment_id, p.created_at, p.updated_at, p.version
+		FROM household h
+		INNER JOIN patients p ON p.id = h.patient_id
+		ORDER BY h.root_id, p.first_name, p.last_name, p.id`
+
+	ids := make(pq.StringArray, len(patientIDs))
+	for i, id := range patientIDs {
+		ids[i] = id.String()
+	}
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list patient households: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rootID uuid.UUID
+		var patient entities.Patient
+
+		err := rows.Scan(
+			&rootID,
+			&patient.ID,
+			&patient.FirstName,
+			&patient.LastName,
+			&patient.Email,
+			&patient.Phone,
+			&patient.PhoneE164,
+			&patient.DateOfBirth,
+			&patient.MedicalHistory,
+			&patient.FirstAppointmentID,
+			&patient.CreatedAt,
+			&patient.UpdatedAt,
+			&patient.Version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan household member: %w", err)
+		}
+
+		household, ok := households[rootID]
+		if !ok {
+			household = &repositories.PatientHousehold{ID: patient.ID}
+			households[rootID] = household
+		}
+		if patient.ID.String() < household.ID.String() {
+			household.ID = patient.ID
+		}
+		household.Members = append(household.Members, &patient)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over household rows: %w", err)
+	}
+
+	for patientID, household := range households {
+		if len(household.Members) < 2 {
+			delete(households, patientID)
+		}
+	}
+
+	return households, nil
+}
+
+// ReassignPatient moves the relationships of one patient to another
+func (r *PatientRelationshipPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	statements := []struct {
+		query       string
+		description string
+	}{
+		{
+			`DELETE FROM patient_relationships
+			WHERE (patient_id = $1 AND related_patient_id = $2) OR (patient_id = $2 AND related_patient_id = $1)`,
+			"drop relationships between merged patients",
+		},
+		{
+			`DELETE FROM patient_relationships a
+			WHERE a.patient_id = $1 AND EXISTS (
+				SELECT 1 FROM patient_relationships b
+				WHERE b.organization_id = a.organization_id AND b.patient_id = $2 AND b.related_patient_id = a.related_patient_id)`,
+			"drop duplicated relationships",
+		},
+		{
+			`DELETE FROM patient_relationships a
+			WHERE a.related_patient_id = $1 AND EXISTS (
+				SELECT 1 FROM patient_relationships b
+				WHERE b.organization_id = a.organization_id AND b.patient_id = a.patient_id AND b.related_patient_id = $2)`,
+			"drop duplicated inverse relationships",
+		},
+		{
+			`UPDATE patient_relationships a SET is_contact = FALSE
+			WHERE a.patient_id = $1 AND a.is_contact AND EXISTS (
+				SELECT 1 FROM patient_relationships b
+				WHERE b.organization_id = a.organization_id AND b.patient_id = $2 AND b.is_contact)`,
+			"clear duplicated contact person",
+		},
+		{`UPDATE patient_relationships SET patient_id = $2 WHERE patient_id = $1`, "reassign patient relationships"},
+		{`UPDATE patient_relationships SET related_patient_id = $2 WHERE related_patient_id = $1`, "reassign inverse patient relationships"},
+	}
+
+	for _, statement := range statements {
+		if _, err := executor(ctx, r.db).ExecContext(ctx, statement.query, fromPatientID, toPatientID); err != nil {
+			return fmt.Errorf("failed to %s: %w", statement.description, err)
+		}
+	}
+
+	return nil
+}
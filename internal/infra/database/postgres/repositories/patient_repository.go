@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"dental-scheduler-backend/internal/domain/entities"
 	"dental-scheduler-backend/internal/domain/ports/repositories"
@@ -24,20 +26,22 @@ func NewPatientPostgresRepository(db *sql.DB) repositories.PatientRepository {
 // Create creates a new patient
 func (r *PatientPostgresRepository) Create(ctx context.Context, patient *entities.Patient) error {
 	query := `
-		INSERT INTO patients (id, first_name, last_name, email, phone, date_of_birth, medical_history, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+		INSERT INTO patients (id, first_name, last_name, email, phone, phone_e164, date_of_birth, medical_history, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING version`
 
-	_, err := r.db.ExecContext(ctx, query,
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
 		patient.ID,
 		patient.FirstName,
 		patient.LastName,
 		patient.Email,
 		patient.Phone,
+		patient.PhoneE164,
 		patient.DateOfBirth,
 		patient.MedicalHistory,
 		patient.CreatedAt,
 		patient.UpdatedAt,
-	)
+	).Scan(&patient.Version)
 
 	if err != nil {
 		return fmt.Errorf("failed to create patient: %w", err)
@@ -49,22 +53,24 @@ func (r *PatientPostgresRepository) Create(ctx context.Context, patient *entitie
 // GetByID retrieves a patient by its ID
 func (r *PatientPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Patient, error) {
 	query := `
-		SELECT id, first_name, last_name, email, phone, date_of_birth, medical_history, first_appointment_id, created_at, updated_at
+		SELECT id, first_name, last_name, email, phone, phone_e164, date_of_birth, medical_history, first_appointment_id, created_at, updated_at, version
 		FROM patients
 		WHERE id = $1`
 
 	var patient entities.Patient
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&patient.ID,
 		&patient.FirstName,
 		&patient.LastName,
 		&patient.Email,
 		&patient.Phone,
+		&patient.PhoneE164,
 		&patient.DateOfBirth,
 		&patient.MedicalHistory,
 		&patient.FirstAppointmentID,
 		&patient.CreatedAt,
 		&patient.UpdatedAt,
+		&patient.Version,
 	)
 
 	if err != nil {
@@ -80,11 +86,11 @@ func (r *PatientPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (
 // GetAll retrieves all patients
 func (r *PatientPostgresRepository) GetAll(ctx context.Context) ([]*entities.Patient, error) {
 	query := `
-		SELECT id, first_name, last_name, email, phone, date_of_birth, medical_history, first_appointment_id, created_at, updated_at
+		SELECT id, first_name, last_name, email, phone, phone_e164, date_of_birth, medical_history, first_appointment_id, created_at, updated_at, version
 		FROM patients
 		ORDER BY first_name, last_name`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get patients: %w", err)
 	}
@@ -99,11 +105,13 @@ func (r *PatientPostgresRepository) GetAll(ctx context.Context) ([]*entities.Pat
 			&patient.LastName,
 			&patient.Email,
 			&patient.Phone,
+			&patient.PhoneE164,
 			&patient.DateOfBirth,
 			&patient.MedicalHistory,
 			&patient.FirstAppointmentID,
 			&patient.CreatedAt,
 			&patient.UpdatedAt,
+			&patient.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan patient: %w", err)
@@ -121,22 +129,24 @@ func (r *PatientPostgresRepository) GetAll(ctx context.Context) ([]*entities.Pat
 // GetByEmail retrieves a patient by email
 func (r *PatientPostgresRepository) GetByEmail(ctx context.Context, email string) (*entities.Patient, error) {
 	query := `
-		SELECT id, first_name, last_name, email, phone, date_of_birth, medical_history, first_appointment_id, created_at, updated_at
+		SELECT id, first_name, last_name, email, phone, phone_e164, date_of_birth, medical_history, first_appointment_id, created_at, updated_at, version
 		FROM patients
 		WHERE email = $1`
 
 	var patient entities.Patient
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, email).Scan(
 		&patient.ID,
 		&patient.FirstName,
 		&patient.LastName,
 		&patient.Email,
 		&patient.Phone,
+		&patient.PhoneE164,
 		&patient.DateOfBirth,
 		&patient.MedicalHistory,
 		&patient.FirstAppointmentID,
 		&patient.CreatedAt,
 		&patient.UpdatedAt,
+		&patient.Version,
 	)
 
 	if err != nil {
@@ -149,35 +159,40 @@ func (r *PatientPostgresRepository) GetByEmail(ctx context.Context, email string
 	return &patient, nil
 }
 
-// Update updates an existing patient
+// Update updates an existing patient if its stored version still matches
+// patient.Version, and sets patient.Version to the new version
 func (r *PatientPostgresRepository) Update(ctx context.Context, patient *entities.Patient) error {
 	query := `
 		UPDATE patients
-		SET first_name = $2, last_name = $3, email = $4, phone = $5, date_of_birth = $6, medical_history = $7, updated_at = $8
-		WHERE id = $1`
+		SET first_name = $2, last_name = $3, email = $4, phone = $5, phone_e164 = $6, date_of_birth = $7, medical_history = $8, updated_at = $9
+		WHERE id = $1 AND version = $10
+		RETURNING version`
 
-	result, err := r.db.ExecContext(ctx, query,
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
 		patient.ID,
 		patient.FirstName,
 		patient.LastName,
 		patient.Email,
 		patient.Phone,
+		patient.PhoneE164,
 		patient.DateOfBirth,
 		patient.MedicalHistory,
 		patient.UpdatedAt,
-	)
+		patient.Version,
+	).Scan(&patient.Version)
 
-	if err != nil {
-		return fmt.Errorf("failed to update patient: %w", err)
+	if err == sql.ErrNoRows {
+		exists, err := r.Exists(ctx, patient.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return entities.ErrPatientVersionConflict
+		}
+		return entities.ErrPatientNotFound
 	}
-
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return entities.ErrPatientNotFound
+		return fmt.Errorf("failed to update patient: %w", err)
 	}
 
 	return nil
@@ -187,7 +202,7 @@ func (r *PatientPostgresRepository) Update(ctx context.Context, patient *entitie
 func (r *PatientPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM patients WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete patient: %w", err)
 	}
@@ -209,7 +224,7 @@ func (r *PatientPostgresRepository) Exists(ctx context.Context, id uuid.UUID) (b
 	query := `SELECT EXISTS(SELECT 1 FROM patients WHERE id = $1)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&exists)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check patient existence: %w", err)
 	}
@@ -217,58 +232,144 @@ func (r *PatientPostgresRepository) Exists(ctx context.Context, id uuid.UUID) (b
 	return exists, nil
 }
 
-// SearchPatients searches for patients by name, phone, or email within an organization
-func (r *PatientPostgresRepository) SearchPatients(ctx context.Context, orgID uuid.UUID, query string, limit int) ([]*entities.Patient, error) {
-	searchQuery := `
-		SELECT DISTINCT p.id, p.first_name, p.last_name, p.email, p.phone, p.date_of_birth, p.medical_history, p.first_appointment_id, p.created_at, p.updated_at
-		FROM patients p
-		INNER JOIN patient_organizations po ON p.id = po.patient_id
-		WHERE po.organization_id = $1
-		AND (
-			LOWER(COALESCE(p.first_name, '')) LIKE LOWER($2) OR
-			LOWER(COALESCE(p.last_name, '')) LIKE LOWER($2) OR
-			LOWER(CONCAT(p.first_name, ' ', COALESCE(p.last_name, ''))) LIKE LOWER($2) OR
-			LOWER(COALESCE(p.phone, '')) LIKE LOWER($2) OR
-			LOWER(COALESCE(p.email, '')) LIKE LOWER($2)
-		)
-		ORDER BY p.first_name, p.last_name
-		LIMIT $3`
+// SearchPatients searches for patients by name, phone, or email within an organization.
+// Terms are matched accent-insensitively with typo tolerance (pg_trgm word similarity on
+// the generated search_name column), phone digit groups against phone_digits, and results
+// are ordered by relevance and paginated with a (rank, id) keyset.
+func (r *PatientPostgresRepository) SearchPatients(ctx context.Context, filters repositories.PatientSearchFilters) ([]*repositories.PatientSearchResult, error) {
+	params := []interface{}{filters.OrganizationID}
+	conditions := []string{"po.organization_id = $1", "po.archived_at IS NULL"}
+	rankParts := []string{"0"}
+
+	for _, term := range filters.Terms {
+		params = append(params, term, escapeLikePattern(term))
+		raw := fmt.Sprintf("immutable_unaccent($%d)", len(params)-1)
+		like := fmt.Sprintf("immutable_unaccent($%d)", len(params))
+
+		conditions = append(conditions, fmt.Sprintf(
+			`(p.search_name LIKE '%%' || %[2]s || '%%' OR %[1]s <%% p.search_name OR lower(p.email) LIKE '%%' || %[2]s || '%%')`,
+			raw, like))
+		// Word-prefix hits ("per" in "jose perez") outrank fuzzy ones
+		rankParts = append(rankParts, fmt.Sprintf(
+			`CASE WHEN ' ' || p.search_name LIKE '%% ' || %[2]s || '%%' THEN 1 ELSE 0 END + GREATEST(word_similarity(%[1]s, p.search_name), similarity(%[1]s, COALESCE(lower(p.email), '')))`,
+			raw, like))
+	}
 
-	searchTerm := "%" + query + "%"
-	rows, err := r.db.QueryContext(ctx, searchQuery, orgID, searchTerm, limit)
+	for _, digits := range filters.PhoneDigits {
+		params = append(params, digits)
+		n := len(params)
+
+		conditions = append(conditions, fmt.Sprintf(
+			`(p.phone_digits LIKE '%%' || $%[1]d || '%%' OR lower(p.email) LIKE '%%' || $%[1]d || '%%')`, n))
+		// People type the end of a number more often than the middle of it
+		rankParts = append(rankParts, fmt.Sprintf(
+			`CASE WHEN p.phone_digits LIKE '%%' || $%[1]d THEN 1 WHEN p.phone_digits LIKE '%%' || $%[1]d || '%%' THEN 0.5 ELSE 0.25 END`, n))
+	}
+
+	cursorCondition := ""
+	if filters.After != nil {
+		params = append(params, filters.After.Rank, filters.After.PatientID)
+		cursorCondition = fmt.Sprintf("WHERE ranked.rank < $%[1]d OR (ranked.rank = $%[1]d AND ranked.id > $%[2]d)", len(params)-1, len(params))
+	}
+
+	params = append(params, filters.Limit)
+	limitParam := len(params)
+
+	searchQuery := fmt.Sprintf(`
+		SELECT page.id, page.first_name, page.last_name, page.email, page.phone, page.phone_e164, page.date_of_birth, page.medical_history, page.first_appointment_id, page.created_at, page.updated_at, page.version,
+			page.rank, last_appointment.start_time, next_appointment.start_time
+		FROM (
+			SELECT ranked.*
+			FROM (
+				SELECT p.id, p.first_name, p.last_name, p.email, p.phone, p.phone_e164, p.date_of_birth, p.medical_history, p.first_appointment_id, p.created_at, p.updated_at, p.version,
+					(%s)::float8 AS rank
+				FROM patients p
+				INNER JOIN patient_organizations po ON p.id = po.patient_id
+				WHERE %s
+			) ranked
+			%s
+			ORDER BY ranked.rank DESC, ranked.id
+			LIMIT $%d
+		) page
+		%s
+		ORDER BY page.rank DESC, page.id`,
+		strings.Join(rankParts, " + "), strings.Join(conditions, " AND "), cursorCondition, limitParam,
+		patientAppointmentDatesJoin("page.id"))
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, searchQuery, params...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search patients: %w", err)
 	}
 	defer rows.Close()
 
-	var patients []*entities.Patient
+	var results []*repositories.PatientSearchResult
 	for rows.Next() {
 		var patient entities.Patient
+		result := repositories.PatientSearchResult{Patient: &patient}
 		err := rows.Scan(
 			&patient.ID,
 			&patient.FirstName,
 			&patient.LastName,
 			&patient.Email,
 			&patient.Phone,
+			&patient.PhoneE164,
 			&patient.DateOfBirth,
 			&patient.MedicalHistory,
 			&patient.FirstAppointmentID,
 			&patient.CreatedAt,
 			&patient.UpdatedAt,
+			&patient.Version,
+			&result.Rank,
+			&result.LastAppointmentAt,
+			&result.NextAppointmentAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan patient: %w", err)
 		}
-		patients = append(patients, &patient)
+		results = append(results, &result)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating over patient rows: %w", err)
 	}
 
-	return patients, nil
+	return results, nil
+}
+
+// patientAppointmentDatesJoin joins the last visit (last_appointment) and next scheduled
+// appointment (next_appointment) of the patient in patientColumn within the organization in $1
+func patientAppointmentDatesJoin(patientColumn string) string {
+	return fmt.Sprintf(`LEFT JOIN LATERAL (
+			SELECT MAX(a.start_time) AS start_time
+			FROM appointments a
+			LEFT JOIN units u ON a.unit_id = u.id
+			LEFT JOIN clinics c ON u.clinic_id = c.id
+			LEFT JOIN doctors d ON a.doctor_id = d.id
+			WHERE a.patient_id = %[1]s
+			AND (c.organization_id = $1 OR (a.unit_id IS NULL AND d.organization_id = $1))
+			AND a.start_time < NOW()
+			AND a.status NOT IN ('cancelled', 'needs-rescheduling', 'with-error')
+		) last_appointment ON true
+		LEFT JOIN LATERAL (
+			SELECT MIN(a.start_time) AS start_time
+			FROM appointments a
+			LEFT JOIN units u ON a.unit_id = u.id
+			LEFT JOIN clinics c ON u.clinic_id = c.id
+			LEFT JOIN doctors d ON a.doctor_id = d.id
+			WHERE a.patient_id = %[1]s
+			AND (c.organization_id = $1 OR (a.unit_id IS NULL AND d.organization_id = $1))
+			AND a.start_time >= NOW()
+			AND a.status IN ('scheduled', 'confirmed', 'rescheduled')
+		) next_appointment ON true`, patientColumn)
 }
 
+// escapeLikePattern escapes LIKE wildcards so user input is matched literally
+func escapeLikePattern(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 // AddPatientToOrganization links a patient to an organization
 func (r *PatientPostgresRepository) AddPatientToOrganization(ctx context.Context, patientID, orgID uuid.UUID) error {
 	query := `
@@ -276,7 +377,7 @@ func (r *PatientPostgresRepository) AddPatientToOrganization(ctx context.Context
 		VALUES ($1, $2, NOW(), NOW())
 		ON CONFLICT (patient_id, organization_id) DO NOTHING`
 
-	_, err := r.db.ExecContext(ctx, query, patientID, orgID)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, patientID, orgID)
 	if err != nil {
 		return fmt.Errorf("failed to add patient to organization: %w", err)
 	}
@@ -289,7 +390,7 @@ func (r *PatientPostgresRepository) OrganizationExists(ctx context.Context, orgI
 	query := `SELECT EXISTS(SELECT 1 FROM organizations WHERE id = $1)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, orgID).Scan(&exists)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check organization existence: %w", err)
 	}
@@ -299,49 +400,43 @@ func (r *PatientPostgresRepository) OrganizationExists(ctx context.Context, orgI
 
 // CreatePatientWithOrganization creates a patient and links to organization in a transaction
 func (r *PatientPostgresRepository) CreatePatientWithOrganization(ctx context.Context, patient *entities.Patient, orgID uuid.UUID) error {
-	// Start transaction
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	// Create patient
-	patientQuery := `
-		INSERT INTO patients (id, first_name, last_name, email, phone, date_of_birth, medical_history, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-
-	_, err = tx.ExecContext(ctx, patientQuery,
-		patient.ID,
-		patient.FirstName,
-		patient.LastName,
-		patient.Email,
-		patient.Phone,
-		patient.DateOfBirth,
-		patient.MedicalHistory,
-		patient.CreatedAt,
-		patient.UpdatedAt,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create patient: %w", err)
-	}
+	return runInTransaction(ctx, r.db, func(ctx context.Context) error {
+		tx := executor(ctx, r.db)
+
+		// Create patient
+		patientQuery := `
+		INSERT INTO patients (id, first_name, last_name, email, phone, phone_e164, date_of_birth, medical_history, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING version`
+
+		err := tx.QueryRowContext(ctx, patientQuery,
+			patient.ID,
+			patient.FirstName,
+			patient.LastName,
+			patient.Email,
+			patient.Phone,
+			patient.PhoneE164,
+			patient.DateOfBirth,
+			patient.MedicalHistory,
+			patient.CreatedAt,
+			patient.UpdatedAt,
+		).Scan(&patient.Version)
+		if err != nil {
+			return fmt.Errorf("failed to create patient: %w", err)
+		}
 
-	// Link patient to organization
-	linkQuery := `
+		// Link patient to organization
+		linkQuery := `
 		INSERT INTO patient_organizations (patient_id, organization_id, created_at, updated_at)
 		VALUES ($1, $2, NOW(), NOW())`
 
-	_, err = tx.ExecContext(ctx, linkQuery, patient.ID, orgID)
-	if err != nil {
-		return fmt.Errorf("failed to link patient to organization: %w", err)
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
+		_, err = tx.ExecContext(ctx, linkQuery, patient.ID, orgID)
+		if err != nil {
+			return fmt.Errorf("failed to link patient to organization: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // UpdateFirstAppointmentIfNil sets the patient's first_appointment_id if it's currently NULL
@@ -351,7 +446,7 @@ func (r *PatientPostgresRepository) UpdateFirstAppointmentIfNil(ctx context.Cont
 		SET first_appointment_id = $1
 		WHERE id = $2 AND first_appointment_id IS NULL`
 
-	_, err := r.db.ExecContext(ctx, query, appointmentID, patientID)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, appointmentID, patientID)
 	if err != nil {
 		return fmt.Errorf("failed to update first_appointment_id: %w", err)
 	}
@@ -369,10 +464,316 @@ func (r *PatientPostgresRepository) PatientBelongsToOrganization(ctx context.Con
 		)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, patientID, orgID).Scan(&exists)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, patientID, orgID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check patient-organization relationship: %w", err)
 	}
 
 	return exists, nil
 }
+
+// IsLinkedToOtherOrganizations checks if a patient is linked to any organization besides orgID
+func (r *PatientPostgresRepository) IsLinkedToOtherOrganizations(ctx context.Context, patientID, orgID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM patient_organizations
+			WHERE patient_id = $1 AND organization_id <> $2
+		)`
+
+	var exists bool
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, patientID, orgID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check other patient-organization relationships: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetByOrganizationID retrieves all patients linked to an organization
+func (r *PatientPostgresRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.Patient, error) {
+	query := `
+		SELECT p.id, p.first_name, p.last_name, p.email, p.phone, p.phone_e164, p.date_of_birth, p.medical_history, p.first_appointment_id, p.created_at, p.updated_at, p.version
+		FROM patients p
+		INNER JOIN patient_organizations po ON p.id = po.patient_id
+		WHERE po.organization_id = $1
+		ORDER BY p.created_at`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get patients by organization: %w", err)
+	}
+	defer rows.Close()
+
+	var patients []*entities.Patient
+	for rows.Next() {
+		var patient entities.Patient
+		err := rows.Scan(
+			&patient.ID,
+			&patient.FirstName,
+			&patient.LastName,
+			&patient.Email,
+			&patient.Phone,
+			&patient.PhoneE164,
+			&patient.DateOfBirth,
+			&patient.MedicalHistory,
+			&patient.FirstAppointmentID,
+			&patient.CreatedAt,
+			&patient.UpdatedAt,
+			&patient.Version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan patient: %w", err)
+		}
+		patients = append(patients, &patient)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over patient rows: %w", err)
+	}
+
+	return patients, nil
+}
+
+// MoveOrganizationLinks re-points the organization links of one patient to another,
+// skipping organizations the target is already linked to
+func (r *PatientPostgresRepository) MoveOrganizationLinks(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	insertQuery := `
+		INSERT INTO patient_organizations (patient_id, organization_id, created_at, updated_at)
+		SELECT $2, organization_id, created_at, NOW()
+		FROM patient_organizations
+		WHERE patient_id = $1
+		ON CONFLICT (patient_id, organization_id) DO NOTHING`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, insertQuery, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to copy organization links: %w", err)
+	}
+
+	deleteQuery := `DELETE FROM patient_organizations WHERE patient_id = $1`
+	if _, err := executor(ctx, r.db).ExecContext(ctx, deleteQuery, fromPatientID); err != nil {
+		return fmt.Errorf("failed to remove organization links: %w", err)
+	}
+
+	return nil
+}
+
+// SetFirstAppointment overwrites the patient's first_appointment_id
+func (r *PatientPostgresRepository) SetFirstAppointment(ctx context.Context, patientID uuid.UUID, appointmentID *uuid.UUID) error {
+	query := `
+		UPDATE patients
+		SET first_appointment_id = $1
+		WHERE id = $2`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, appointmentID, patientID)
+	if err != nil {
+		return fmt.Errorf("failed to update first_appointment_id: %w", err)
+	}
+
+	return nil
+}
+
+// patientListOrderBy maps list sort fields to ORDER BY clauses; patients without
+// appointment dates always sort last
+var patientListOrderBy = map[string]string{
+	repositories.PatientSortName:            "lower(p.first_name) %[1]s, lower(COALESCE(p.last_name, '')) %[1]s",
+	repositories.PatientSortCreatedAt:       "p.created_at %[1]s",
+	repositories.PatientSortLastVisit:       "last_appointment.start_time %[1]s NULLS LAST",
+	repositories.PatientSortNextAppointment: "next_appointment.start_time %[1]s NULLS LAST",
+}
+
+// ListByOrganization retrieves a page of an organization's patients with their last visit
+// and next appointment, along with the total count matching the filters
+func (r *PatientPostgresRepository) ListByOrganization(ctx context.Context, filters repositories.PatientListFilters) ([]*repositories.PatientListItem, int, error) {
+	params := []interface{}{filters.OrganizationID}
+	conditions := []string{"po.organization_id = $1"}
+
+	if filters.Archived {
+		conditions = append(conditions, "po.archived_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "po.archived_at IS NULL")
+	}
+	if filters.CreatedFrom != nil {
+		params = append(params, *filters.CreatedFrom)
+		conditions = append(conditions, fmt.Sprintf("p.created_at >= $%d", len(params)))
+	}
+	if filters.CreatedTo != nil {
+		params = append(params, *filters.CreatedTo)
+		conditions = append(conditions, fmt.Sprintf("p.created_at < $%d", len(params)))
+	}
+	if filters.HasUpcoming != nil {
+		if *filters.HasUpcoming {
+			conditions = append(conditions, "next_appointment.start_time IS NOT NULL")
+		} else {
+			conditions = append(conditions, "next_appointment.start_time IS NULL")
+		}
+	}
+	if filters.LastVisitBefore != nil {
+		params = append(params, *filters.LastVisitBefore)
+		conditions = append(conditions, fmt.Sprintf("last_appointment.start_time < $%d", len(params)))
+	}
+
+	baseQuery := `
+		FROM patients p
+		INNER JOIN patient_organizations po ON p.id = po.patient_id
+		` + patientAppointmentDatesJoin("p.id") + `
+		WHERE ` + strings.Join(conditions, " AND ")
+
+	var totalCount int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) "+baseQuery, params...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count patients: %w", err)
+	}
+
+	orderBy, ok := patientListOrderBy[filters.SortBy]
+	if !ok {
+		orderBy = patientListOrderBy[repositories.PatientSortName]
+	}
+	direction := "ASC"
+	if filters.SortDesc {
+		direction = "DESC"
+	}
+
+	params = append(params, filters.Limit, (filters.Page-1)*filters.Limit)
+	query := `
+		SELECT p.id, p.first_name, p.last_name, p.email, p.phone, p.phone_e164, p.date_of_birth, p.medical_history, p.first_appointment_id, p.created_at, p.updated_at, p.version,
+			po.archived_at, last_appointment.start_time, next_appointment.start_time
+		` + baseQuery + `
+		ORDER BY ` + fmt.Sprintf(orderBy, direction) + `, p.id
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(params)-1, len(params))
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list patients: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*repositories.PatientListItem
+	for rows.Next() {
+		var patient entities.Patient
+		item := repositories.PatientListItem{Patient: &patient}
+		err := rows.Scan(
+			&patient.ID,
+			&patient.FirstName,
+			&patient.LastName,
+			&patient.Email,
+			&patient.Phone,
+			&patient.PhoneE164,
+			&patient.DateOfBirth,
+			&patient.MedicalHistory,
+			&patient.FirstAppointmentID,
+			&patient.CreatedAt,
+			&patient.UpdatedAt,
+			&patient.Version,
+			&item.ArchivedAt,
+			&item.LastVisitAt,
+			&item.NextAppointmentAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan patient: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over patient rows: %w", err)
+	}
+
+	return items, totalCount, nil
+}
+
+// GetArchivedAt returns when the organization archived the patient, nil while active
+func (r *PatientPostgresRepository) GetArchivedAt(ctx context.Context, patientID, orgID uuid.UUID) (*time.Time, error) {
+	query := `
+		SELECT archived_at
+		FROM patient_organizations
+		WHERE patient_id = $1 AND organization_id = $2`
+
+	var archivedAt *time.Time
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, patientID, orgID).Scan(&archivedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get patient archive status: %w", err)
+	}
+
+	return archivedAt, nil
+}
+
+// SetArchived archives the patient in the organization, or restores it when archivedAt is nil
+func (r *PatientPostgresRepository) SetArchived(ctx context.Context, patientID, orgID uuid.UUID, archivedAt *time.Time, archivedBy *uuid.UUID) error {
+	query := `
+		UPDATE patient_organizations
+		SET archived_at = $3, archived_by = $4, updated_at = NOW()
+		WHERE patient_id = $1 AND organization_id = $2`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, patientID, orgID, archivedAt, archivedBy)
+	if err != nil {
+		return fmt.Errorf("failed to update patient archive status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entities.ErrPatientNotFound
+	}
+
+	return nil
+}
+
+// RemoveFromOrganization unlinks a patient from an organization
+func (r *PatientPostgresRepository) RemoveFromOrganization(ctx context.Context, patientID, orgID uuid.UUID) error {
+	query := `DELETE FROM patient_organizations WHERE patient_id = $1 AND organization_id = $2`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, patientID, orgID); err != nil {
+		return fmt.Errorf("failed to remove patient from organization: %w", err)
+	}
+
+	return nil
+}
+
+// CountOrganizations counts the organizations a patient is linked to
+func (r *PatientPostgresRepository) CountOrganizations(ctx context.Context, patientID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM patient_organizations WHERE patient_id = $1`
+
+	var count int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, patientID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count patient organizations: %w", err)
+	}
+
+	return count, nil
+}
+
+// HasClinicalRecords checks if the organization keeps clinical records (charting entries, clinical notes, treatment plans, attachments, consent forms, medical alerts, invoices, insurance claims, cancellation fees) of the patient
+func (r *PatientPostgresRepository) HasClinicalRecords(ctx context.Context, patientID, orgID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM dental_chart_entries WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM clinical_notes WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM treatment_plans WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM attachments WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM consent_forms WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM medical_alerts WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM invoices WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM insurance_claims WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM cancellation_fees WHERE patient_id = $1 AND organization_id = $2
+		) OR EXISTS (
+			SELECT 1 FROM appointment_deposits WHERE patient_id = $1 AND organization_id = $2
+		)`
+
+	var exists bool
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, patientID, orgID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check patient clinical records: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// PhoneBackfillPostgresRepository implements the PhoneBackfillRepository interface
+type PhoneBackfillPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPhoneBackfillPostgresRepository creates a new instance of PhoneBackfillPostgresRepository
+func NewPhoneBackfillPostgresRepository(db *sql.DB) repositories.PhoneBackfillRepository {
+	return &PhoneBackfillPostgresRepository{db: db}
+}
+
+// phoneRecordQueries select the phones of each kind of record with their organization's
+// region. Patients can belong to several organizations; the first one they joined wins.
+var phoneRecordQueries = map[repositories.PhoneOwner]string{
+	repositories.PhoneOwnerPatient: `
+		SELECT p.id, COALESCE((
+			SELECT o.default_phone_region
+			FROM patient_organizations po
+			INNER JOIN organizations o ON po.organization_id = o.id
+			WHERE po.patient_id = p.id
+			ORDER BY po.created_at
+			LIMIT 1
+		), $1), p.phone, p.phone_e164
+		FROM patients p
+		WHERE TRIM(COALESCE(p.phone, '')) <> ''
+		ORDER BY p.created_at`,
+	repositories.PhoneOwnerDoctor: `
+		SELECT d.id, COALESCE(o.default_phone_region, $1), d.phone, d.phone_e164
+		FROM doctors d
+		LEFT JOIN organizations o ON d.organization_id = o.id
+		WHERE TRIM(COALESCE(d.phone, '')) <> ''
+		ORDER BY d.created_at`,
+	repositories.PhoneOwnerClinic: `
+		SELECT c.id, COALESCE(o.default_phone_region, $1), c.phone, c.phone_e164
+		FROM clinics c
+		LEFT JOIN organizations o ON c.organization_id = o.id
+		WHERE TRIM(COALESCE(c.phone, '')) <> ''
+		ORDER BY c.created_at`,
+}
+
+// phoneTables maps each kind of record to its table
+var phoneTables = map[repositories.PhoneOwner]string{
+	repositories.PhoneOwnerPatient: "patients",
+	repositories.PhoneOwnerDoctor:  "doctors",
+	repositories.PhoneOwnerClinic:  "clinics",
+}
+
+// GetPhoneRecords retrieves every non-blank phone of one kind of record
+func (r *PhoneBackfillPostgresRepository) GetPhoneRecords(ctx context.Context, owner repositories.PhoneOwner) ([]*repositories.PhoneRecord, error) {
+	query, ok := phoneRecordQueries[owner]
+	if !ok {
+		return nil, fmt.Errorf("unknown phone owner %q", owner)
+	}
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, entities.DefaultPhoneRegion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s phones: %w", owner, err)
+	}
+	defer rows.Close()
+
+	var records []*repositories.PhoneRecord
+	for rows.Next() {
+		record := repositories.PhoneRecord{Owner: owner}
+		if err := rows.Scan(&record.ID, &record.Region, &record.Phone, &record.PhoneE164); err != nil {
+			return nil, fmt.Errorf("failed to scan %s phone: %w", owner, err)
+		}
+		records = append(records, &record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over %s phone rows: %w", owner, err)
+	}
+
+	return records, nil
+}
+
+// SetPhoneE164 stores the normalized phone of a record; nil clears it
+func (r *PhoneBackfillPostgresRepository) SetPhoneE164(ctx context.Context, owner repositories.PhoneOwner, id uuid.UUID, e164 *string) error {
+	table, ok := phoneTables[owner]
+	if !ok {
+		return fmt.Errorf("unknown phone owner %q", owner)
+	}
+
+	query := `UPDATE ` + table + ` SET phone_e164 = $2 WHERE id = $1`
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, id, e164); err != nil {
+		return fmt.Errorf("failed to set %s phone: %w", owner, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,549 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// RecallPostgresRepository implements the RecallRepository interface
+type RecallPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewRecallPostgresRepository creates a new instance of RecallPostgresRepository
+func NewRecallPostgresRepository(db *sql.DB) repositories.RecallRepository {
+	return &RecallPostgresRepository{db: db}
+}
+
+const recallRuleColumns = `id, organization_id, service_id, interval_months, qualifying_service_ids, active, created_at, updated_at`
+
+const recallColumns = `id, organization_id, clinic_id, patient_id, rule_id, service_id, source_appointment_id, due_date, status, outreach_status, outreach_attempts, last_contacted_at, booked_appointment_id, dismiss_reason, closed_by, closed_at, created_at, updated_at`
+
+const recallOutreachColumns = `id, recall_id, channel, outcome, notes, contacted_by, contacted_at, created_at`
+
+// CreateRule stores a recall rule
+func (r *RecallPostgresRepository) CreateRule(ctx context.Context, rule *entities.RecallRule) error {
+	query := `
+		INSERT INTO recall_rules (` + recallRuleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		rule.ID,
+		rule.OrganizationID,
+		rule.ServiceID,
+		rule.IntervalMonths,
+		pq.StringArray(rule.QualifyingServiceIDs),
+		rule.Active,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recall rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetRule retrieves a recall rule of the organization
+func (r *RecallPostgresRepository) GetRule(ctx context.Context, orgID, id uuid.UUID) (*entities.RecallRule, error) {
+	query := `SELECT ` + recallRuleColumns + ` FROM recall_rules WHERE organization_id = $1 AND id = $2`
+	return r.getRule(ctx, query, orgID, id)
+}
+
+// GetRuleByService retrieves the organization's recall rule for a service, nil when it has none
+func (r *RecallPostgresRepository) GetRuleByService(ctx context.Context, orgID uuid.UUID, serviceID string) (*entities.RecallRule, error) {
+	query := `SELECT ` + recallRuleColumns + ` FROM recall_rules WHERE organization_id = $1 AND service_id = $2`
+	return r.getRule(ctx, query, orgID, serviceID)
+}
+
+// ListRules retrieves the organization's recall rules by service
+func (r *RecallPostgresRepository) ListRules(ctx context.Context, orgID uuid.UUID, includeInactive bool) ([]*entities.RecallRule, error) {
+	query := `
+		SELECT ` + recallRuleColumns + `
+		FROM recall_rules
+		WHERE organization_id = $1 AND (active OR $2)
+		ORDER BY service_id, id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recall rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.RecallRule
+	for rows.Next() {
+		rule, err := r.scanRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recall rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recall rule rows: %w", err)
+	}
+
+	return rules, nil
+}
+
+// UpdateRule saves the interval, qualifying services and active flag of a rule
+func (r *RecallPostgresRepository) UpdateRule(ctx context.Context, rule *entities.RecallRule) error {
+	query := `
+		UPDATE recall_rules
+		SET interval_months = $3, qualifying_service_ids = $4, active = $5
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		rule.OrganizationID,
+		rule.ID,
+		rule.IntervalMonths,
+		pq.StringArray(rule.QualifyingServiceIDs),
+		rule.Active,
+	).Scan(&rule.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrRecallRuleNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update recall rule: %w", err)
+	}
+
+	return nil
+}
+
+// Create stores a recall
+func (r *RecallPostgresRepository) Create(ctx context.Context, recall *entities.Recall) error {
+	query := `
+		INSERT INTO recalls (` + recallColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		recall.ID,
+		recall.OrganizationID,
+		recall.ClinicID,
+		recall.PatientID,
+		recall.RuleID,
+		recall.ServiceID,
+		recall.SourceAppointmentID,
+		recall.DueDate,
+		string(recall.Status),
+		string(recall.OutreachStatus),
+		recall.OutreachAttempts,
+		recall.LastContactedAt,
+		recall.BookedAppointmentID,
+		recall.DismissReason,
+		recall.ClosedBy,
+		recall.ClosedAt,
+		recall.CreatedAt,
+		recall.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recall: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves a recall of the organization
+func (r *RecallPostgresRepository) Get(ctx context.Context, orgID, id uuid.UUID) (*entities.Recall, error) {
+	query := `SELECT ` + recallColumns + ` FROM recalls WHERE organization_id = $1 AND id = $2`
+	return r.getRecall(ctx, query, orgID, id)
+}
+
+// GetForUpdate retrieves a recall of the organization and locks it until the transaction in ctx ends
+func (r *RecallPostgresRepository) GetForUpdate(ctx context.Context, orgID, id uuid.UUID) (*entities.Recall, error) {
+	query := `SELECT ` + recallColumns + ` FROM recalls WHERE organization_id = $1 AND id = $2 FOR UPDATE`
+	return r.getRecall(ctx, query, orgID, id)
+}
+
+// List retrieves a page of recalls with their patients, soonest due first, and the total
+// count. Patients archived by the organization are left out.
+func (r *RecallPostgresRepository) List(ctx context.Context, filters repositories.RecallFilters) ([]*repositories.RecallWorklistItem, int, error) {
+	params := []interface{}{filters.OrganizationID}
+	conditions := []string{"rc.organization_id = $1", "po.archived_at IS NULL"}
+
+	if filters.ClinicID != nil {
+		params = append(params, *filters.ClinicID)
+		conditions = append(conditions, fmt.Sprintf("rc.clinic_id = $%d", len(params)))
+	}
+	if filters.PatientID != nil {
+		params = append(params, *filters.PatientID)
+		conditions = append(conditions, fmt.Sprintf("rc.patient_id = $%d", len(params)))
+	}
+	if filters.Status != nil {
+		params = append(params, string(*filters.Status))
+		conditions = append(conditions, fmt.Sprintf("rc.status = $%d", len(params)))
+	}
+	if filters.OutreachStatus != nil {
+		params = append(params, string(*filters.OutreachStatus))
+		conditions = append(conditions, fmt.Sprintf("rc.outreach_status = $%d", len(params)))
+	}
+	if filters.DueFrom != nil {
+		params = append(params, *filters.DueFrom)
+		conditions = append(conditions, fmt.Sprintf("rc.due_date >= $%d", len(params)))
+	}
+	if filters.DueTo != nil {
+		params = append(params, *filters.DueTo)
+		conditions = append(conditions, fmt.Sprintf("rc.due_date <= $%d", len(params)))
+	}
+	from := `
+		FROM recalls rc
+		INNER JOIN patients p ON p.id = rc.patient_id
+		INNER JOIN patient_organizations po ON po.patient_id = rc.patient_id AND po.organization_id = rc.organization_id
+		WHERE ` + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*)"+from, params...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count recalls: %w", err)
+	}
+
+	params = append(params, filters.Limit, (filters.Page-1)*filters.Limit)
+	query := `
+		SELECT rc.id, rc.organization_id, rc.clinic_id, rc.patient_id, rc.rule_id, rc.service_id, rc.source_appointment_id,
+			rc.due_date, rc.status, rc.outreach_status, rc.outreach_attempts, rc.last_contacted_at, rc.booked_appointment_id,
+			rc.dismiss_reason, rc.closed_by, rc.closed_at, rc.created_at, rc.updated_at,
+			p.id, p.first_name, p.last_name, p.email, p.phone, p.phone_e164, p.date_of_birth, p.medical_history, p.first_appointment_id, p.created_at, p.updated_at, p.version
+		` + from + `
+		ORDER BY rc.due_date, p.first_name, p.last_name, rc.id
+		` + fmt.Sprintf("LIMIT $%d OFFSET $%d", len(params)-1, len(params))
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list recalls: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*repositories.RecallWorklistItem{}
+	for rows.Next() {
+		var recall entities.Recall
+		var patient entities.Patient
+		var status, outreachStatus string
+
+		err := rows.Scan(
+			&recall.ID,
+			&recall.OrganizationID,
+			&recall.ClinicID,
+			&recall.PatientID,
+			&recall.RuleID,
+			&recall.ServiceID,
+			&recall.SourceAppointmentID,
+			&recall.DueDate,
+			&status,
+			&outreachStatus,
+			&recall.OutreachAttempts,
+			&recall.LastContactedAt,
+			&recall.BookedAppointmentID,
+			&recall.DismissReason,
+			&recall.ClosedBy,
+			&recall.ClosedAt,
+			&recall.CreatedAt,
+			&recall.UpdatedAt,
+			&patient.ID,
+			&patient.FirstName,
+			&patient.LastName,
+			&patient.Email,
+			&patient.Phone,
+			&patient.PhoneE164,
+			&patient.DateOfBirth,
+			&patient.MedicalHistory,
+			&patient.FirstAppointmentID,
+			&patient.CreatedAt,
+			&patient.UpdatedAt,
+			&patient.Version,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan recall: %w", err)
+		}
+
+		recall.Status = entities.RecallStatus(status)
+		recall.OutreachStatus = entities.RecallOutreachStatus(outreachStatus)
+		items = append(items, &repositories.RecallWorklistItem{Recall: &recall, Patient: &patient})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over recall rows: %w", err)
+	}
+
+	return items, total, nil
+}
+
+// ListOpenByPatientForUpdate retrieves the patient's open recalls in the organization and
+// locks them until the transaction in ctx ends
+func (r *RecallPostgresRepository) ListOpenByPatientForUpdate(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.Recall, error) {
+	query := `
+		SELECT ` + recallColumns + `
+		FROM recalls
+		WHERE organization_id = $1 AND patient_id = $2 AND status = 'open'
+		ORDER BY due_date, id
+		FOR UPDATE`
+
+	return r.listRecalls(ctx, query, orgID, patientID)
+}
+
+// ListBookedByAppointmentForUpdate retrieves the recalls closed by booking an appointment
+// and locks them until the transaction in ctx ends
+func (r *RecallPostgresRepository) ListBookedByAppointmentForUpdate(ctx context.Context, appointmentID uuid.UUID) ([]*entities.Recall, error) {
+	query := `
+		SELECT ` + recallColumns + `
+		FROM recalls
+		WHERE booked_appointment_id = $1 AND status = 'booked'
+		ORDER BY due_date, id
+		FOR UPDATE`
+
+	return r.listRecalls(ctx, query, appointmentID)
+}
+
+// MoveAppointment re-points the recalls closed by booking one appointment to another (rescheduling)
+func (r *RecallPostgresRepository) MoveAppointment(ctx context.Context, fromAppointmentID, toAppointmentID uuid.UUID) error {
+	query := `
+		UPDATE recalls
+		SET booked_appointment_id = $2, updated_at = NOW()
+		WHERE booked_appointment_id = $1 AND status = 'booked'`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromAppointmentID, toAppointmentID); err != nil {
+		return fmt.Errorf("failed to move recall appointment: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves the status, outreach and closure of a recall
+func (r *RecallPostgresRepository) Update(ctx context.Context, recall *entities.Recall) error {
+	query := `
+		UPDATE recalls
+		SET status = $3, outreach_status = $4, outreach_attempts = $5, last_contacted_at = $6,
+			booked_appointment_id = $7, dismiss_reason = $8, closed_by = $9, closed_at = $10
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		recall.OrganizationID,
+		recall.ID,
+		string(recall.Status),
+		string(recall.OutreachStatus),
+		recall.OutreachAttempts,
+		recall.LastContactedAt,
+		recall.BookedAppointmentID,
+		recall.DismissReason,
+		recall.ClosedBy,
+		recall.ClosedAt,
+	).Scan(&recall.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrRecallNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update recall: %w", err)
+	}
+
+	return nil
+}
+
+// CreateOutreach stores an attempt to contact a recalled patient
+func (r *RecallPostgresRepository) CreateOutreach(ctx context.Context, outreach *entities.RecallOutreach) error {
+	query := `
+		INSERT INTO recall_outreach (` + recallOutreachColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		outreach.ID,
+		outreach.RecallID,
+		string(outreach.Channel),
+		string(outreach.Outcome),
+		outreach.Notes,
+		outreach.ContactedBy,
+		outreach.ContactedAt,
+		outreach.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recall outreach: %w", err)
+	}
+
+	return nil
+}
+
+// ListOutreach retrieves the contact attempts of a recall, most recent first
+func (r *RecallPostgresRepository) ListOutreach(ctx context.Context, recallID uuid.UUID) ([]*entities.RecallOutreach, error) {
+	query := `
+		SELECT ` + recallOutreachColumns + `
+		FROM recall_outreach
+		WHERE recall_id = $1
+		ORDER BY contacted_at DESC, id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, recallID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recall outreach: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []*entities.RecallOutreach{}
+	for rows.Next() {
+		var outreach entities.RecallOutreach
+		var channel, outcome string
+
+		err := rows.Scan(
+			&outreach.ID,
+			&outreach.RecallID,
+			&channel,
+			&outcome,
+			&outreach.Notes,
+			&outreach.ContactedBy,
+			&outreach.ContactedAt,
+			&outreach.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recall outreach: %w", err)
+		}
+
+		outreach.Channel = entities.RecallOutreachChannel(channel)
+		outreach.Outcome = entities.RecallOutreachStatus(outcome)
+		attempts = append(attempts, &outreach)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recall outreach rows: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// ReassignPatient moves all recalls of one patient to another. Open recalls of the first
+// patient under a rule the second one is already recalled for are dismissed.
+func (r *RecallPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	dismiss := `
+		UPDATE recalls rc
+		SET status = 'dismissed', dismiss_reason = 'Patient merged', closed_at = NOW()
+		WHERE rc.patient_id = $1 AND rc.status = 'open' AND EXISTS (
+			SELECT 1 FROM recalls other
+			WHERE other.patient_id = $2 AND other.rule_id = rc.rule_id AND other.status = 'open'
+		)`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, dismiss, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to dismiss duplicate recalls: %w", err)
+	}
+
+	query := `UPDATE recalls SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign recalls: %w", err)
+	}
+
+	return nil
+}
+
+// getRule retrieves a single recall rule
+func (r *RecallPostgresRepository) getRule(ctx context.Context, query string, args ...interface{}) (*entities.RecallRule, error) {
+	rule, err := r.scanRule(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recall rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// getRecall retrieves a single recall
+func (r *RecallPostgresRepository) getRecall(ctx context.Context, query string, args ...interface{}) (*entities.Recall, error) {
+	recall, err := r.scanRecall(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recall: %w", err)
+	}
+
+	return recall, nil
+}
+
+// listRecalls retrieves the recalls selected by a query
+func (r *RecallPostgresRepository) listRecalls(ctx context.Context, query string, args ...interface{}) ([]*entities.Recall, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recalls: %w", err)
+	}
+	defer rows.Close()
+
+	var recalls []*entities.Recall
+	for rows.Next() {
+		recall, err := r.scanRecall(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recall: %w", err)
+		}
+		recalls = append(recalls, recall)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recall rows: %w", err)
+	}
+
+	return recalls, nil
+}
+
+// scanRule scans a recall rule row
+func (r *RecallPostgresRepository) scanRule(row interface{ Scan(...interface{}) error }) (*entities.RecallRule, error) {
+	var rule entities.RecallRule
+	var qualifying pq.StringArray
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.OrganizationID,
+		&rule.ServiceID,
+		&rule.IntervalMonths,
+		&qualifying,
+		&rule.Active,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.QualifyingServiceIDs = []string(qualifying)
+	if rule.QualifyingServiceIDs == nil {
+		rule.QualifyingServiceIDs = []string{}
+	}
+	return &rule, nil
+}
+
+// scanRecall scans a recall row
+func (r *RecallPostgresRepository) scanRecall(row interface{ Scan(...interface{}) error }) (*entities.Recall, error) {
+	var recall entities.Recall
+	var status, outreachStatus string
+
+	err := row.Scan(
+		&recall.ID,
+		&recall.OrganizationID,
+		&recall.ClinicID,
+		&recall.PatientID,
+		&recall.RuleID,
+		&recall.ServiceID,
+		&recall.SourceAppointmentID,
+		&recall.DueDate,
+		&status,
+		&outreachStatus,
+		&recall.OutreachAttempts,
+		&recall.LastContactedAt,
+		&recall.BookedAppointmentID,
+		&recall.DismissReason,
+		&recall.ClosedBy,
+		&recall.ClosedAt,
+		&recall.CreatedAt,
+		&recall.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recall.Status = entities.RecallStatus(status)
+	recall.OutreachStatus = entities.RecallOutreachStatus(outreachStatus)
+	return &recall, nil
+}
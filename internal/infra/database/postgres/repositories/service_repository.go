@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// ServicePostgresRepository implements the ServiceRepository interface
+type ServicePostgresRepository struct {
+	db *sql.DB
+}
+
+// NewServicePostgresRepository creates a new instance of ServicePostgresRepository
+func NewServicePostgresRepository(db *sql.DB) repositories.ServiceRepository {
+	return &ServicePostgresRepository{db: db}
+}
+
+// GetByID retrieves a service of the organization
+func (r *ServicePostgresRepository) GetByID(ctx context.Context, orgID uuid.UUID, id string) (*entities.Service, error) {
+	query := `
+		SELECT id, name, base_price, category, deposit_type, deposit_amount, deposit_percent, organization_id, created_at, updated_at
+		FROM services
+		WHERE organization_id = $1 AND id = $2`
+
+	var service entities.Service
+	var category string
+	var depositType *string
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, orgID, id).Scan(
+		&service.ID,
+		&service.Name,
+		&service.BasePrice,
+		&category,
+		&depositType,
+		&service.DepositAmount,
+		&service.DepositPercent,
+		&service.OrganizationID,
+		&service.CreatedAt,
+		&service.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	service.Category = entities.ServiceCategory(category)
+	if depositType != nil {
+		t := entities.DepositType(*depositType)
+		service.DepositType = &t
+	}
+	return &service, nil
+}
+
+// UpdateCategory sets the insurance category of a service
+func (r *ServicePostgresRepository) UpdateCategory(ctx context.Context, service *entities.Service) error {
+	query := `
+		UPDATE services
+		SET category = $3, updated_at = NOW()
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, service.OrganizationID, service.ID, string(service.Category)).Scan(&service.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrServiceNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update service category: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateDeposit sets the deposit required to book a service
+func (r *ServicePostgresRepository) UpdateDeposit(ctx context.Context, service *entities.Service) error {
+	query := `
+		UPDATE services
+		SET deposit_type = $3, deposit_amount = $4, deposit_percent = $5, updated_at = NOW()
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	var depositType *string
+	if service.DepositType != nil {
+		t := string(*service.DepositType)
+		depositType = &t
+	}
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		service.OrganizationID,
+		service.ID,
+		depositType,
+		service.DepositAmount,
+		service.DepositPercent,
+	).Scan(&service.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrServiceNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update service deposit: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// StaffInvitationPostgresRepository implements the StaffInvitationRepository interface
+type StaffInvitationPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewStaffInvitationPostgresRepository creates a new instance of StaffInvitationPostgresRepository
+func NewStaffInvitationPostgresRepository(db *sql.DB) repositories.StaffInvitationRepository {
+	return &StaffInvitationPostgresRepository{db: db}
+}
+
+const staffInvitationColumns = `id, organization_id, email, roles, doctor_id, invited_by, status, expires_at, accepted_by, accepted_at, revoked_at, created_at, updated_at`
+
+// Create creates a new invitation
+func (r *StaffInvitationPostgresRepository) Create(ctx context.Context, invitation *entities.StaffInvitation) error {
+	query := `
+		INSERT INTO staff_invitations (` + staffInvitationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		invitation.ID,
+		invitation.OrganizationID,
+		invitation.Email,
+		pq.Array(invitation.Roles),
+		invitation.DoctorID,
+		invitation.InvitedBy,
+		invitation.Status,
+		invitation.ExpiresAt,
+		invitation.AcceptedBy,
+		invitation.AcceptedAt,
+		invitation.RevokedAt,
+		invitation.CreatedAt,
+		invitation.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create staff invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an invitation by its ID
+func (r *StaffInvitationPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.StaffInvitation, error) {
+	query := `SELECT ` + staffInvitationColumns + ` FROM staff_invitations WHERE id = $1`
+
+	invitation, err := r.scanInvitation(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get staff invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// GetByIDForUpdate retrieves an invitation and locks it until the transaction in ctx ends
+func (r *StaffInvitationPostgresRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.StaffInvitation, error) {
+	query := `SELECT ` + staffInvitationColumns + ` FROM staff_invitations WHERE id = $1 FOR UPDATE`
+
+	invitation, err := r.scanInvitation(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get staff invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// GetPendingByEmail retrieves the pending invitation for an email within an organization
+func (r *StaffInvitationPostgresRepository) GetPendingByEmail(ctx context.Context, orgID uuid.UUID, email string) (*entities.StaffInvitation, error) {
+	query := `
+		SELECT ` + staffInvitationColumns + `
+		FROM staff_invitations
+		WHERE organization_id = $1 AND LOWER(email) = LOWER($2) AND status = 'pending'`
+
+	invitation, err := r.scanInvitation(executor(ctx, r.db).QueryRowContext(ctx, query, orgID, email))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pending staff invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// GetByOrganizationID retrieves all invitations for an organization, newest first
+func (r *StaffInvitationPostgresRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.StaffInvitation, error) {
+	query := `
+		SELECT ` + staffInvitationColumns + `
+		FROM staff_invitations
+		WHERE organization_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staff invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*entities.StaffInvitation
+	for rows.Next() {
+		invitation, err := r.scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan staff invitation: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over staff invitation rows: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// Update updates an existing invitation
+func (r *StaffInvitationPostgresRepository) Update(ctx context.Context, invitation *entities.StaffInvitation) error {
+	query := `
+		UPDATE staff_invitations
+		SET roles = $2, doctor_id = $3, status = $4, expires_at = $5, accepted_by = $6, accepted_at = $7, revoked_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
+		invitation.ID,
+		pq.Array(invitation.Roles),
+		invitation.DoctorID,
+		invitation.Status,
+		invitation.ExpiresAt,
+		invitation.AcceptedBy,
+		invitation.AcceptedAt,
+		invitation.RevokedAt,
+		invitation.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update staff invitation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+// scanInvitation scans a single invitation from a row
+func (r *StaffInvitationPostgresRepository) scanInvitation(row interface{ Scan(...interface{}) error }) (*entities.StaffInvitation, error) {
+	var invitation entities.StaffInvitation
+	var status string
+
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.Email,
+		&invitation.Roles,
+		&invitation.DoctorID,
+		&invitation.InvitedBy,
+		&status,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedBy,
+		&invitation.AcceptedAt,
+		&invitation.RevokedAt,
+		&invitation.CreatedAt,
+		&invitation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.Status = entities.InvitationStatus(status)
+	return &invitation, nil
+}
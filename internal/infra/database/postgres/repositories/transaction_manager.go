@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+)
+
+// txContextKey stores the active *sql.Tx in a context
+type txContextKey struct{}
+
+// dbExecutor is implemented by both *sql.DB and *sql.Tx
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// executor returns the transaction carried by ctx, or db when there is none
+func executor(ctx context.Context, db *sql.DB) dbExecutor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// runInTransaction executes fn in a transaction, joining the one in ctx if present
+func runInTransaction(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// TransactionPostgresManager implements the TransactionManager interface
+type TransactionPostgresManager struct {
+	db *sql.DB
+}
+
+// NewTransactionPostgresManager creates a new instance of TransactionPostgresManager
+func NewTransactionPostgresManager(db *sql.DB) repositories.TransactionManager {
+	return &TransactionPostgresManager{db: db}
+}
+
+// WithinTransaction executes fn in a transaction carried by its context
+func (m *TransactionPostgresManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return runInTransaction(ctx, m.db, fn)
+}
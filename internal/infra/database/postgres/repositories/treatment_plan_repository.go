@@ -0,0 +1,374 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// TreatmentPlanPostgresRepository implements the TreatmentPlanRepository interface
+type TreatmentPlanPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewTreatmentPlanPostgresRepository creates a new instance of TreatmentPlanPostgresRepository
+func NewTreatmentPlanPostgresRepository(db *sql.DB) repositories.TreatmentPlanRepository {
+	return &TreatmentPlanPostgresRepository{db: db}
+}
+
+const treatmentPlanColumns = `id, organization_id, patient_id, doctor_id, title, notes, status, presented_at, decided_at, decision_by, completed_at, created_by, created_at, updated_at`
+
+const treatmentPlanItemColumns = `id, plan_id, phase, position, service_id, tooth, description, estimated_cost, status, appointment_id, completed_at, created_at, updated_at`
+
+// Create stores a plan and its items
+func (r *TreatmentPlanPostgresRepository) Create(ctx context.Context, plan *entities.TreatmentPlan) error {
+	query := `
+		INSERT INTO treatment_plans (` + treatmentPlanColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		plan.ID,
+		plan.OrganizationID,
+		plan.PatientID,
+		plan.DoctorID,
+		plan.Title,
+		plan.Notes,
+		string(plan.Status),
+		plan.PresentedAt,
+		plan.DecidedAt,
+		plan.DecisionBy,
+		plan.CompletedAt,
+		plan.CreatedBy,
+		plan.CreatedAt,
+		plan.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create treatment plan: %w", err)
+	}
+
+	for _, item := range plan.Items {
+		if err := r.CreateItem(ctx, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByID retrieves a plan of the organization
+func (r *TreatmentPlanPostgresRepository) GetByID(ctx context.Context, orgID, id uuid.UUID) (*entities.TreatmentPlan, error) {
+	query := `SELECT ` + treatmentPlanColumns + ` FROM treatment_plans WHERE organization_id = $1 AND id = $2`
+	return r.getOne(ctx, query, orgID, id)
+}
+
+// GetByItemID retrieves the plan of the organization containing an item
+func (r *TreatmentPlanPostgresRepository) GetByItemID(ctx context.Context, orgID, itemID uuid.UUID) (*entities.TreatmentPlan, error) {
+	query := `
+		SELECT ` + treatmentPlanColumns + `
+		FROM treatment_plans
+		WHERE organization_id = $1 AND id = (SELECT plan_id FROM treatment_plan_items WHERE id = $2)`
+	return r.getOne(ctx, query, orgID, itemID)
+}
+
+// GetByAppointmentID retrieves the plan with an item booked in the appointment
+func (r *TreatmentPlanPostgresRepository) GetByAppointmentID(ctx context.Context, appointmentID uuid.UUID) (*entities.TreatmentPlan, error) {
+	query := `
+		SELECT ` + treatmentPlanColumns + `
+		FROM treatment_plans
+		WHERE id = (SELECT plan_id FROM treatment_plan_items WHERE appointment_id = $1)`
+	return r.getOne(ctx, query, appointmentID)
+}
+
+// ListByPatient retrieves the patient's plans, most recent first
+func (r *TreatmentPlanPostgresRepository) ListByPatient(ctx context.Context, orgID, patientID uuid.UUID) ([]*entities.TreatmentPlan, error) {
+	query := `
+		SELECT ` + treatmentPlanColumns + `
+		FROM treatment_plans
+		WHERE organization_id = $1 AND patient_id = $2
+		ORDER BY created_at DESC`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, orgID, patientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list treatment plans: %w", err)
+	}
+	defer rows.Close()
+
+	var plans []*entities.TreatmentPlan
+	for rows.Next() {
+		plan, err := r.scanPlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan treatment plan: %w", err)
+		}
+		plans = append(plans, plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over treatment plan rows: %w", err)
+	}
+
+	if err := r.loadItems(ctx, plans); err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+// Update saves the plan fields (not its items)
+func (r *TreatmentPlanPostgresRepository) Update(ctx context.Context, plan *entities.TreatmentPlan) error {
+	query := `
+		UPDATE treatment_plans
+		SET doctor_id = $3, title = $4, notes = $5, status = $6, presented_at = $7, decided_at = $8,
+		    decision_by = $9, completed_at = $10
+		WHERE organization_id = $1 AND id = $2
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		plan.OrganizationID,
+		plan.ID,
+		plan.DoctorID,
+		plan.Title,
+		plan.Notes,
+		string(plan.Status),
+		plan.PresentedAt,
+		plan.DecidedAt,
+		plan.DecisionBy,
+		plan.CompletedAt,
+	).Scan(&plan.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrTreatmentPlanNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update treatment plan: %w", err)
+	}
+
+	return nil
+}
+
+// CreateItem stores a new item of a plan
+func (r *TreatmentPlanPostgresRepository) CreateItem(ctx context.Context, item *entities.TreatmentPlanItem) error {
+	query := `
+		INSERT INTO treatment_plan_items (` + treatmentPlanItemColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		item.ID,
+		item.PlanID,
+		item.Phase,
+		item.Position,
+		item.ServiceID,
+		toothValue(item.Tooth),
+		item.Description,
+		item.EstimatedCost,
+		string(item.Status),
+		item.AppointmentID,
+		item.CompletedAt,
+		item.CreatedAt,
+		item.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create treatment plan item: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateItem saves an item
+func (r *TreatmentPlanPostgresRepository) UpdateItem(ctx context.Context, item *entities.TreatmentPlanItem) error {
+	query := `
+		UPDATE treatment_plan_items
+		SET phase = $2, position = $3, service_id = $4, tooth = $5, description = $6, estimated_cost = $7,
+		    status = $8, appointment_id = $9, completed_at = $10
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		item.ID,
+		item.Phase,
+		item.Position,
+		item.ServiceID,
+		toothValue(item.Tooth),
+		item.Description,
+		item.EstimatedCost,
+		string(item.Status),
+		item.AppointmentID,
+		item.CompletedAt,
+	).Scan(&item.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return entities.ErrTreatmentPlanItemNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update treatment plan item: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteItem removes an item of a plan
+func (r *TreatmentPlanPostgresRepository) DeleteItem(ctx context.Context, planID, itemID uuid.UUID) error {
+	query := `DELETE FROM treatment_plan_items WHERE plan_id = $1 AND id = $2`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, planID, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to delete treatment plan item: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrTreatmentPlanItemNotFound
+	}
+
+	return nil
+}
+
+// MoveAppointment re-points the item booked in one appointment to another (rescheduling)
+func (r *TreatmentPlanPostgresRepository) MoveAppointment(ctx context.Context, fromAppointmentID, toAppointmentID uuid.UUID) error {
+	query := `UPDATE treatment_plan_items SET appointment_id = $2 WHERE appointment_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromAppointmentID, toAppointmentID); err != nil {
+		return fmt.Errorf("failed to move treatment plan item appointment: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignPatient moves all plans of one patient to another
+func (r *TreatmentPlanPostgresRepository) ReassignPatient(ctx context.Context, fromPatientID, toPatientID uuid.UUID) error {
+	query := `UPDATE treatment_plans SET patient_id = $2 WHERE patient_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, fromPatientID, toPatientID); err != nil {
+		return fmt.Errorf("failed to reassign treatment plans: %w", err)
+	}
+
+	return nil
+}
+
+// getOne retrieves a single plan with its items
+func (r *TreatmentPlanPostgresRepository) getOne(ctx context.Context, query string, args ...interface{}) (*entities.TreatmentPlan, error) {
+	plan, err := r.scanPlan(executor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get treatment plan: %w", err)
+	}
+
+	if err := r.loadItems(ctx, []*entities.TreatmentPlan{plan}); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// loadItems attaches the items of each plan, ordered by phase and position
+func (r *TreatmentPlanPostgresRepository) loadItems(ctx context.Context, plans []*entities.TreatmentPlan) error {
+	if len(plans) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*entities.TreatmentPlan, len(plans))
+	ids := make(pq.StringArray, len(plans))
+	for i, plan := range plans {
+		plan.Items = []*entities.TreatmentPlanItem{}
+		byID[plan.ID] = plan
+		ids[i] = plan.ID.String()
+	}
+
+	query := `
+		SELECT ` + treatmentPlanItemColumns + `
+		FROM treatment_plan_items
+		WHERE plan_id = ANY($1)
+		ORDER BY phase, position, created_at`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get treatment plan items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entities.TreatmentPlanItem
+		var tooth sql.NullInt16
+		var status string
+
+		err := rows.Scan(
+			&item.ID,
+			&item.PlanID,
+			&item.Phase,
+			&item.Position,
+			&item.ServiceID,
+			&tooth,
+			&item.Description,
+			&item.EstimatedCost,
+			&status,
+			&item.AppointmentID,
+			&item.CompletedAt,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan treatment plan item: %w", err)
+		}
+
+		if tooth.Valid {
+			t := entities.Tooth(tooth.Int16)
+			item.Tooth = &t
+		}
+		item.Status = entities.TreatmentPlanItemStatus(status)
+		if plan := byID[item.PlanID]; plan != nil {
+			plan.Items = append(plan.Items, &item)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over treatment plan item rows: %w", err)
+	}
+
+	return nil
+}
+
+// scanPlan scans a single plan from a row
+func (r *TreatmentPlanPostgresRepository) scanPlan(row interface{ Scan(...interface{}) error }) (*entities.TreatmentPlan, error) {
+	var plan entities.TreatmentPlan
+	var status string
+
+	err := row.Scan(
+		&plan.ID,
+		&plan.OrganizationID,
+		&plan.PatientID,
+		&plan.DoctorID,
+		&plan.Title,
+		&plan.Notes,
+		&status,
+		&plan.PresentedAt,
+		&plan.DecidedAt,
+		&plan.DecisionBy,
+		&plan.CompletedAt,
+		&plan.CreatedBy,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Status = entities.TreatmentPlanStatus(status)
+	return &plan, nil
+}
+
+// toothValue converts an optional tooth to its FDI column value
+func toothValue(tooth *entities.Tooth) interface{} {
+	if tooth == nil {
+		return nil
+	}
+	return int(*tooth)
+}
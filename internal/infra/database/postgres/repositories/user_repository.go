@@ -26,7 +26,7 @@ func NewUserPostgresRepository(db *sql.DB) repositories.UserRepository {
 // GetByID retrieves a profile by ID
 func (r *UserPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Profile, error) {
 	query := `
-		SELECT id, email, full_name, roles, organization_id, avatar_url, created_at, updated_at
+		SELECT id, email, full_name, roles, organization_id, avatar_url, is_active, deactivated_at, created_at, updated_at
 		FROM profiles
 		WHERE id = $1`
 
@@ -42,6 +42,8 @@ func (r *UserPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*en
 		&roles,
 		&organizationID,
 		&avatarURL,
+		&profile.IsActive,
+		&profile.DeactivatedAt,
 		&profile.CreatedAt,
 		&profile.UpdatedAt,
 	)
@@ -77,7 +79,7 @@ func (r *UserPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*en
 // GetByEmail retrieves a profile by email
 func (r *UserPostgresRepository) GetByEmail(ctx context.Context, email string) (*entities.Profile, error) {
 	query := `
-		SELECT id, email, full_name, roles, organization_id, avatar_url, created_at, updated_at
+		SELECT id, email, full_name, roles, organization_id, avatar_url, is_active, deactivated_at, created_at, updated_at
 		FROM profiles
 		WHERE email = $1`
 
@@ -92,6 +94,8 @@ func (r *UserPostgresRepository) GetByEmail(ctx context.Context, email string) (
 		pq.Array(&profile.Roles),
 		&organizationID,
 		&avatarURL,
+		&profile.IsActive,
+		&profile.DeactivatedAt,
 		&profile.CreatedAt,
 		&profile.UpdatedAt,
 	)
@@ -136,7 +140,7 @@ func (r *UserPostgresRepository) GetBySupabaseID(ctx context.Context, supabaseID
 func (r *UserPostgresRepository) GetProfileBySupabaseID(ctx context.Context, supabaseID string) (*entities.UserProfile, error) {
 	query := `
 		SELECT 
-			p.id, p.email, p.full_name, p.roles, p.organization_id, p.avatar_url, p.created_at, p.updated_at,
+			p.id, p.email, p.full_name, p.roles, p.organization_id, p.avatar_url, p.is_active, p.deactivated_at, p.created_at, p.updated_at,
 			o.id, o.name, o.description, o.address, o.phone, o.email, o.is_active, o.created_at, o.updated_at
 		FROM profiles p
 		LEFT JOIN organizations o ON p.organization_id = o.id
@@ -162,6 +166,8 @@ func (r *UserPostgresRepository) GetProfileBySupabaseID(ctx context.Context, sup
 		&roles,
 		&profileOrgID,
 		&avatarURL,
+		&profile.IsActive,
+		&profile.DeactivatedAt,
 		&profile.CreatedAt,
 		&profile.UpdatedAt,
 		&orgID,
@@ -246,8 +252,8 @@ func (r *UserPostgresRepository) GetProfileBySupabaseID(ctx context.Context, sup
 // Create creates a new profile
 func (r *UserPostgresRepository) Create(ctx context.Context, profile *entities.Profile) error {
 	query := `
-		INSERT INTO profiles (id, email, full_name, roles, organization_id, avatar_url, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		INSERT INTO profiles (id, email, full_name, roles, organization_id, avatar_url, is_active, deactivated_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		profile.ID,
@@ -256,6 +262,8 @@ func (r *UserPostgresRepository) Create(ctx context.Context, profile *entities.P
 		pq.Array(profile.Roles),
 		profile.OrganizationID,
 		profile.AvatarURL,
+		profile.IsActive,
+		profile.DeactivatedAt,
 		profile.CreatedAt,
 		profile.UpdatedAt,
 	)
@@ -271,16 +279,18 @@ func (r *UserPostgresRepository) Create(ctx context.Context, profile *entities.P
 func (r *UserPostgresRepository) Update(ctx context.Context, profile *entities.Profile) error {
 	query := `
 		UPDATE profiles 
-		SET email = $2, full_name = $3, roles = $4, organization_id = $5, avatar_url = $6, updated_at = $7
+		SET email = $2, full_name = $3, roles = $4, organization_id = $5, avatar_url = $6, is_active = $7, deactivated_at = $8, updated_at = $9
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
 		profile.ID,
 		profile.Email,
 		profile.FullName,
 		pq.Array(profile.Roles),
 		profile.OrganizationID,
 		profile.AvatarURL,
+		profile.IsActive,
+		profile.DeactivatedAt,
 		time.Now(),
 	)
 
@@ -299,3 +309,61 @@ func (r *UserPostgresRepository) Update(ctx context.Context, profile *entities.P
 
 	return nil
 }
+
+// GetByOrganizationID retrieves all profiles that belong to an organization
+func (r *UserPostgresRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.Profile, error) {
+	query := `
+		SELECT id, email, full_name, roles, organization_id, avatar_url, is_active, deactivated_at, created_at, updated_at
+		FROM profiles
+		WHERE organization_id = $1
+		ORDER BY is_active DESC, email`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profiles by organization: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*entities.Profile
+	for rows.Next() {
+		profile := &entities.Profile{}
+		var fullName, avatarURL sql.NullString
+		var organizationID uuid.NullUUID
+		var roles pq.StringArray
+
+		err := rows.Scan(
+			&profile.ID,
+			&profile.Email,
+			&fullName,
+			&roles,
+			&organizationID,
+			&avatarURL,
+			&profile.IsActive,
+			&profile.DeactivatedAt,
+			&profile.CreatedAt,
+			&profile.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+
+		profile.Roles = roles
+		if fullName.Valid {
+			profile.FullName = &fullName.String
+		}
+		if avatarURL.Valid {
+			profile.AvatarURL = &avatarURL.String
+		}
+		if organizationID.Valid {
+			profile.OrganizationID = &organizationID.UUID
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over profile rows: %w", err)
+	}
+
+	return profiles, nil
+}
@@ -0,0 +1,395 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WebhookSubscriptionPostgresRepository implements the WebhookSubscriptionRepository interface
+type WebhookSubscriptionPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookSubscriptionPostgresRepository creates a new instance of WebhookSubscriptionPostgresRepository
+func NewWebhookSubscriptionPostgresRepository(db *sql.DB) repositories.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionPostgresRepository{db: db}
+}
+
+const webhookSubscriptionColumns = `id, organization_id, url, description, secret, event_types, is_active, created_at, updated_at`
+
+// Create creates a new subscription
+func (r *WebhookSubscriptionPostgresRepository) Create(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (` + webhookSubscriptionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		subscription.ID,
+		subscription.OrganizationID,
+		subscription.URL,
+		subscription.Description,
+		subscription.Secret,
+		pq.Array(subscription.EventTypes),
+		subscription.IsActive,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a subscription by its ID
+func (r *WebhookSubscriptionPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	subscription, err := r.scanSubscription(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// GetByOrganizationID retrieves all subscriptions for an organization
+func (r *WebhookSubscriptionPostgresRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.WebhookSubscription, error) {
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE organization_id = $1
+		ORDER BY created_at DESC`
+
+	return r.querySubscriptions(ctx, query, orgID)
+}
+
+// GetActiveByOrganizationID retrieves the active subscriptions for an organization
+func (r *WebhookSubscriptionPostgresRepository) GetActiveByOrganizationID(ctx context.Context, orgID uuid.UUID) ([]*entities.WebhookSubscription, error) {
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE organization_id = $1 AND is_active = true
+		ORDER BY created_at`
+
+	return r.querySubscriptions(ctx, query, orgID)
+}
+
+// Update updates an existing subscription
+func (r *WebhookSubscriptionPostgresRepository) Update(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = $2, description = $3, secret = $4, event_types = $5, is_active = $6, updated_at = $7
+		WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
+		subscription.ID,
+		subscription.URL,
+		subscription.Description,
+		subscription.Secret,
+		pq.Array(subscription.EventTypes),
+		subscription.IsActive,
+		subscription.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a subscription and its deliveries
+func (r *WebhookSubscriptionPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// querySubscriptions runs a subscription query and scans all rows
+func (r *WebhookSubscriptionPostgresRepository) querySubscriptions(ctx context.Context, query string, args ...interface{}) ([]*entities.WebhookSubscription, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*entities.WebhookSubscription
+	for rows.Next() {
+		subscription, err := r.scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over webhook subscription rows: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// scanSubscription scans a single subscription from a row
+func (r *WebhookSubscriptionPostgresRepository) scanSubscription(row interface{ Scan(...interface{}) error }) (*entities.WebhookSubscription, error) {
+	var subscription entities.WebhookSubscription
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.OrganizationID,
+		&subscription.URL,
+		&subscription.Description,
+		&subscription.Secret,
+		&subscription.EventTypes,
+		&subscription.IsActive,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// WebhookDeliveryPostgresRepository implements the WebhookDeliveryRepository interface
+type WebhookDeliveryPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookDeliveryPostgresRepository creates a new instance of WebhookDeliveryPostgresRepository
+func NewWebhookDeliveryPostgresRepository(db *sql.DB) repositories.WebhookDeliveryRepository {
+	return &WebhookDeliveryPostgresRepository{db: db}
+}
+
+const webhookDeliveryColumns = `id, subscription_id, organization_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_attempt_at, last_response_status, last_error, delivered_at, created_at, updated_at`
+
+// Create creates a new delivery; a duplicate (subscription, event) pair is ignored
+func (r *WebhookDeliveryPostgresRepository) Create(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.OrganizationID,
+		delivery.EventID,
+		delivery.EventType,
+		[]byte(delivery.Payload),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.LastResponseStatus,
+		delivery.LastError,
+		delivery.DeliveredAt,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a delivery by its ID
+func (r *WebhookDeliveryPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	delivery, err := r.scanDelivery(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// GetBySubscriptionID retrieves deliveries of a subscription, newest first
+func (r *WebhookDeliveryPostgresRepository) GetBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, filters repositories.WebhookDeliveryFilters) ([]*entities.WebhookDelivery, int, error) {
+	where := `WHERE subscription_id = $1`
+	params := []interface{}{subscriptionID}
+	if filters.Status != nil {
+		params = append(params, *filters.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(params))
+	}
+
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM webhook_deliveries ` + where
+	if err := executor(ctx, r.db).QueryRowContext(ctx, countQuery, params...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	params = append(params, filters.Limit, filters.Offset)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM webhook_deliveries
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, webhookDeliveryColumns, where, len(params)-1, len(params))
+
+	deliveries, err := r.queryDeliveries(ctx, query, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, totalCount, nil
+}
+
+// ClaimDue locks up to limit pending deliveries due at now and postpones them by lease
+func (r *WebhookDeliveryPostgresRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	return r.queryDeliveries(ctx, query, now, now.Add(lease), limit)
+}
+
+// Update updates the delivery state after an attempt or replay
+func (r *WebhookDeliveryPostgresRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5,
+			last_response_status = $6, last_error = $7, delivered_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.LastResponseStatus,
+		delivery.LastError,
+		delivery.DeliveredAt,
+		delivery.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// queryDeliveries runs a delivery query and scans all rows
+func (r *WebhookDeliveryPostgresRepository) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]*entities.WebhookDelivery, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.WebhookDelivery
+	for rows.Next() {
+		delivery, err := r.scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over webhook delivery rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// scanDelivery scans a single delivery from a row
+func (r *WebhookDeliveryPostgresRepository) scanDelivery(row interface{ Scan(...interface{}) error }) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	var eventType, status string
+	var payload []byte
+	var lastResponseStatus sql.NullInt64
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.OrganizationID,
+		&delivery.EventID,
+		&eventType,
+		&payload,
+		&status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastAttemptAt,
+		&lastResponseStatus,
+		&delivery.LastError,
+		&delivery.DeliveredAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.EventType = entities.WebhookEventType(eventType)
+	delivery.Status = entities.WebhookDeliveryStatus(status)
+	delivery.Payload = json.RawMessage(payload)
+	if lastResponseStatus.Valid {
+		code := int(lastResponseStatus.Int64)
+		delivery.LastResponseStatus = &code
+	}
+
+	return &delivery, nil
+}
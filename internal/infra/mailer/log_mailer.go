@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// LogMailer is a local stand-in for an email provider that writes messages to the log
+type LogMailer struct {
+	logger *logger.Logger
+}
+
+// NewLogMailer creates a new instance of LogMailer
+func NewLogMailer(logger *logger.Logger) gateways.Mailer {
+	return &LogMailer{logger: logger}
+}
+
+// Send logs the email instead of delivering it
+func (m *LogMailer) Send(ctx context.Context, message *gateways.EmailMessage) error {
+	m.logger.Logger.WithFields(map[string]interface{}{
+		"to":      message.To,
+		"subject": message.Subject,
+		"body":    message.TextBody,
+	}).Info("Email message (local mailer, not delivered)")
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package pac
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+)
+
+// DisabledPAC is used when no certification provider is configured. It refuses to stamp and
+// cancel, so no CFDI is ever shown as valid without being registered with the SAT.
+type DisabledPAC struct{}
+
+// NewDisabledPAC creates a new instance of DisabledPAC
+func NewDisabledPAC() gateways.PAC {
+	return &DisabledPAC{}
+}
+
+// Stamp refuses to stamp the CFDI
+func (p *DisabledPAC) Stamp(ctx context.Context, xml []byte) (*gateways.StampedCFDI, error) {
+	return nil, gateways.ErrPACNotConfigured
+}
+
+// Cancel refuses to cancel the CFDI
+func (p *DisabledPAC) Cancel(ctx context.Context, cancellation *gateways.CFDICancellation) (entities.CFDIStatus, error) {
+	return "", gateways.ErrPACNotConfigured
+}
@@ -0,0 +1,69 @@
+package pac
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/google/uuid"
+)
+
+// fakeProviderRFC identifies the fake provider in the stamps it adds
+const fakeProviderRFC = "AAA010101AAA"
+
+// FakePAC is a local stand-in for a certification provider. It stamps every CFDI with a
+// random UUID and an unsigned TimbreFiscalDigital, and cancels right away. Its CFDI are
+// not registered with the SAT.
+type FakePAC struct {
+	logger *logger.Logger
+}
+
+// NewFakePAC creates a new instance of FakePAC
+func NewFakePAC(logger *logger.Logger) gateways.PAC {
+	return &FakePAC{logger: logger}
+}
+
+// Stamp adds the TimbreFiscalDigital complement to the CFDI
+func (p *FakePAC) Stamp(ctx context.Context, xml []byte) (*gateways.StampedCFDI, error) {
+	closing := []byte("</cfdi:Comprobante>")
+	end := bytes.LastIndex(xml, closing)
+	if end < 0 {
+		return nil, &gateways.PACRejection{Code: "301", Message: "XML mal formado"}
+	}
+
+	stampedAt := time.Now().UTC().Truncate(time.Second)
+	fiscalUUID := strings.ToUpper(uuid.New().String())
+	complement := fmt.Sprintf(
+		`<cfdi:Complemento><tfd:TimbreFiscalDigital xmlns:tfd="http://www.sat.gob.mx/TimbreFiscalDigital" Version="1.1" UUID="%s" FechaTimbrado="%s" RfcProvCertif="%s" SelloCFD="" NoCertificadoSAT="" SelloSAT=""/></cfdi:Complemento>`,
+		fiscalUUID, stampedAt.Format("2006-01-02T15:04:05"), fakeProviderRFC,
+	)
+
+	stamped := make([]byte, 0, len(xml)+len(complement))
+	stamped = append(stamped, xml[:end]...)
+	stamped = append(stamped, complement...)
+	stamped = append(stamped, xml[end:]...)
+
+	p.logger.Logger.WithField("uuid", fiscalUUID).Info("CFDI stamped (local PAC, not registered with the SAT)")
+
+	return &gateways.StampedCFDI{
+		UUID:      fiscalUUID,
+		XML:       stamped,
+		StampedAt: stampedAt,
+	}, nil
+}
+
+// Cancel cancels the CFDI without waiting for the receiver's acceptance
+func (p *FakePAC) Cancel(ctx context.Context, cancellation *gateways.CFDICancellation) (entities.CFDIStatus, error) {
+	p.logger.Logger.WithFields(map[string]interface{}{
+		"uuid":   cancellation.UUID,
+		"reason": cancellation.Reason,
+	}).Info("CFDI cancelled (local PAC, not registered with the SAT)")
+
+	return entities.CFDIStatusCancelled, nil
+}
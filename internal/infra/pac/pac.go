@@ -0,0 +1,22 @@
+package pac
+
+import (
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/config"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// NewPAC creates the certification provider selected by the configured driver
+func NewPAC(cfg *config.CFDIConfig, logger *logger.Logger) (gateways.PAC, error) {
+	switch cfg.PACDriver {
+	case "", "none":
+		return NewDisabledPAC(), nil
+	case "fake":
+		logger.Logger.Warn("CFDI are stamped by the local PAC and are not registered with the SAT; use it for development only")
+		return NewFakePAC(logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported PAC driver %q", cfg.PACDriver)
+	}
+}
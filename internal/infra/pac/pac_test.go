@@ -0,0 +1,40 @@
+package pac
+
+import (
+	"context"
+	"testing"
+
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/config"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+func TestNewPACRefusesToStampWithoutProvider(t *testing.T) {
+	for _, driver := range []string{"", "none"} {
+		provider, err := NewPAC(&config.CFDIConfig{PACDriver: driver}, logger.NewLogger("error"))
+		if err != nil {
+			t.Fatalf("NewPAC(%q) error = %v", driver, err)
+		}
+
+		if _, err := provider.Stamp(context.Background(), []byte("<cfdi:Comprobante></cfdi:Comprobante>")); err != gateways.ErrPACNotConfigured {
+			t.Errorf("NewPAC(%q).Stamp() error = %v, want %v", driver, err, gateways.ErrPACNotConfigured)
+		}
+		if _, err := provider.Cancel(context.Background(), &gateways.CFDICancellation{UUID: "X"}); err != gateways.ErrPACNotConfigured {
+			t.Errorf("NewPAC(%q).Cancel() error = %v, want %v", driver, err, gateways.ErrPACNotConfigured)
+		}
+	}
+}
+
+func TestNewPACSelectsDriver(t *testing.T) {
+	provider, err := NewPAC(&config.CFDIConfig{PACDriver: "fake"}, logger.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("NewPAC(fake) error = %v", err)
+	}
+	if _, ok := provider.(*FakePAC); !ok {
+		t.Errorf("NewPAC(fake) = %T, want *FakePAC", provider)
+	}
+
+	if _, err := NewPAC(&config.CFDIConfig{PACDriver: "sat"}, logger.NewLogger("error")); err == nil {
+		t.Error("NewPAC(sat) error = nil, want an unsupported driver error")
+	}
+}
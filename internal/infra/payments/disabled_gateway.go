@@ -0,0 +1,32 @@
+package payments
+
+import (
+	"context"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+)
+
+// DisabledGateway is used when no payment provider is configured. It creates no payment links
+// and trusts no notification, so no deposit is ever marked paid without being charged.
+type DisabledGateway struct{}
+
+// NewDisabledGateway creates a new instance of DisabledGateway
+func NewDisabledGateway() gateways.PaymentGateway {
+	return &DisabledGateway{}
+}
+
+// CreatePaymentLink refuses to create a payment link
+func (g *DisabledGateway) CreatePaymentLink(ctx context.Context, req *gateways.PaymentLinkRequest) (*gateways.PaymentLink, error) {
+	return nil, gateways.ErrPaymentGatewayNotConfigured
+}
+
+// ExpirePaymentLink does nothing; no link was ever created
+func (g *DisabledGateway) ExpirePaymentLink(ctx context.Context, linkID string) error {
+	return nil
+}
+
+// ParseNotification rejects every notification
+func (g *DisabledGateway) ParseNotification(payload []byte, signature string) (*gateways.PaymentNotification, error) {
+	return nil, entities.ErrInvalidPaymentNotification
+}
@@ -0,0 +1,110 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/google/uuid"
+)
+
+// FakeGateway is a local stand-in for an online payment provider. Its links point to a checkout
+// page of our own frontend, and nothing is charged: payments are simulated by posting a
+// notification to the webhook, signed with the hex HMAC-SHA256 of the body under the webhook
+// secret. Without a secret every notification is rejected, since the webhook is public.
+type FakeGateway struct {
+	checkoutURL   string
+	webhookSecret string
+	logger        *logger.Logger
+}
+
+// NewFakeGateway creates a new instance of FakeGateway
+func NewFakeGateway(checkoutURL, webhookSecret string, logger *logger.Logger) gateways.PaymentGateway {
+	if webhookSecret == "" {
+		logger.Logger.Warn("PAYMENTS_WEBHOOK_SECRET is not set: payment notifications will be rejected and deposits cannot be paid online")
+	}
+
+	return &FakeGateway{
+		checkoutURL:   strings.TrimRight(checkoutURL, "/"),
+		webhookSecret: webhookSecret,
+		logger:        logger,
+	}
+}
+
+// fakeNotification is the webhook payload of the fake gateway
+type fakeNotification struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	LinkID     string    `json:"link_id"`
+	PaymentID  string    `json:"payment_id"`
+	Amount     int64     `json:"amount"`
+	Currency   string    `json:"currency"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// CreatePaymentLink creates a link to the local checkout page
+func (g *FakeGateway) CreatePaymentLink(ctx context.Context, req *gateways.PaymentLinkRequest) (*gateways.PaymentLink, error) {
+	linkID := "plink_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	g.logger.Logger.WithFields(map[string]interface{}{
+		"link_id":   linkID,
+		"reference": req.Reference,
+		"amount":    req.Amount,
+		"currency":  req.Currency,
+	}).Info("Payment link created (local gateway, nothing is charged)")
+
+	return &gateways.PaymentLink{
+		ID:        linkID,
+		URL:       g.checkoutURL + "/" + linkID,
+		ExpiresAt: req.ExpiresAt,
+	}, nil
+}
+
+// ExpirePaymentLink only logs; the local checkout page has no state to close
+func (g *FakeGateway) ExpirePaymentLink(ctx context.Context, linkID string) error {
+	g.logger.Logger.WithField("link_id", linkID).Info("Payment link expired (local gateway)")
+	return nil
+}
+
+// ParseNotification checks the signature of a notification and decodes it
+func (g *FakeGateway) ParseNotification(payload []byte, signature string) (*gateways.PaymentNotification, error) {
+	if g.webhookSecret == "" {
+		return nil, entities.ErrInvalidPaymentNotification
+	}
+	mac := hmac.New(sha256.New, []byte(g.webhookSecret))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(strings.TrimSpace(signature)))) {
+		return nil, entities.ErrInvalidPaymentNotification
+	}
+
+	var notification fakeNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, entities.ErrInvalidPaymentNotification
+	}
+	notificationType := gateways.PaymentNotificationType(notification.Type)
+	if notification.LinkID == "" || (notificationType != gateways.PaymentSucceeded && notificationType != gateways.PaymentFailed) {
+		return nil, entities.ErrInvalidPaymentNotification
+	}
+	if notification.OccurredAt.IsZero() {
+		notification.OccurredAt = time.Now()
+	}
+
+	return &gateways.PaymentNotification{
+		ID:         notification.ID,
+		Type:       notificationType,
+		LinkID:     notification.LinkID,
+		PaymentID:  notification.PaymentID,
+		Amount:     notification.Amount,
+		Currency:   notification.Currency,
+		OccurredAt: notification.OccurredAt,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	infraLogger "dental-scheduler-backend/internal/infra/logger"
+)
+
+const testNotification = `{"id":"evt_1","type":"payment.succeeded","link_id":"plink_1","payment_id":"pay_1","amount":50000,"currency":"MXN"}`
+
+func signNotification(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestFakeGatewayParseNotification(t *testing.T) {
+	logger := infraLogger.NewLogger("error")
+	gateway := NewFakeGateway("http://localhost:3000/checkout", "webhook-secret", logger)
+
+	notification, err := gateway.ParseNotification([]byte(testNotification), signNotification("webhook-secret", testNotification))
+	if err != nil {
+		t.Fatalf("ParseNotification() error = %v", err)
+	}
+	if notification.Type != gateways.PaymentSucceeded || notification.LinkID != "plink_1" || notification.Amount != 50000 {
+		t.Errorf("ParseNotification() = %+v, want a succeeded payment of 50000 on plink_1", notification)
+	}
+}
+
+func TestFakeGatewayRejectsUnauthenticatedNotifications(t *testing.T) {
+	logger := infraLogger.NewLogger("error")
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+	}{
+		{name: "no secret configured, unsigned", secret: "", signature: ""},
+		{name: "no secret configured, signed", secret: "", signature: signNotification("", testNotification)},
+		{name: "unsigned", secret: "webhook-secret", signature: ""},
+		{name: "bad signature", secret: "webhook-secret", signature: signNotification("other-secret", testNotification)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := NewFakeGateway("http://localhost:3000/checkout", tt.secret, logger)
+			notification, err := gateway.ParseNotification([]byte(testNotification), tt.signature)
+			if err != entities.ErrInvalidPaymentNotification {
+				t.Errorf("ParseNotification() error = %v, want %v", err, entities.ErrInvalidPaymentNotification)
+			}
+			if notification != nil {
+				t.Errorf("ParseNotification() = %+v, want none", notification)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package payments
+
+import (
+	"fmt"
+
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/config"
+	"dental-scheduler-backend/internal/infra/logger"
+)
+
+// NewPaymentGateway creates the payment gateway selected by the configured driver
+func NewPaymentGateway(cfg *config.PaymentsConfig, logger *logger.Logger) (gateways.PaymentGateway, error) {
+	switch cfg.Driver {
+	case "", "none":
+		return NewDisabledGateway(), nil
+	case "fake":
+		logger.Logger.Warn("Deposits are collected by the local payment gateway and nothing is charged; use it for development only")
+		return NewFakeGateway(cfg.CheckoutURL, cfg.WebhookSecret, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment gateway driver %q", cfg.Driver)
+	}
+}
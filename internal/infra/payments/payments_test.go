@@ -0,0 +1,52 @@
+package payments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/config"
+	infraLogger "dental-scheduler-backend/internal/infra/logger"
+
+	"github.com/google/uuid"
+)
+
+func TestNewPaymentGatewayRefusesDepositsWithoutProvider(t *testing.T) {
+	for _, driver := range []string{"", "none"} {
+		gateway, err := NewPaymentGateway(&config.PaymentsConfig{Driver: driver, WebhookSecret: "s3cret"}, infraLogger.NewLogger("error"))
+		if err != nil {
+			t.Fatalf("NewPaymentGateway(%q) error = %v", driver, err)
+		}
+
+		_, err = gateway.CreatePaymentLink(context.Background(), &gateways.PaymentLinkRequest{
+			Reference: uuid.New(),
+			Amount:    50000,
+			Currency:  "MXN",
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if err != gateways.ErrPaymentGatewayNotConfigured {
+			t.Errorf("NewPaymentGateway(%q).CreatePaymentLink() error = %v, want %v", driver, err, gateways.ErrPaymentGatewayNotConfigured)
+		}
+
+		signature := signNotification("s3cret", testNotification)
+		if _, err := gateway.ParseNotification([]byte(testNotification), signature); err != entities.ErrInvalidPaymentNotification {
+			t.Errorf("NewPaymentGateway(%q).ParseNotification() error = %v, want %v", driver, err, entities.ErrInvalidPaymentNotification)
+		}
+	}
+}
+
+func TestNewPaymentGatewaySelectsDriver(t *testing.T) {
+	gateway, err := NewPaymentGateway(&config.PaymentsConfig{Driver: "fake", WebhookSecret: "s3cret"}, infraLogger.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("NewPaymentGateway(fake) error = %v", err)
+	}
+	if _, ok := gateway.(*FakeGateway); !ok {
+		t.Errorf("NewPaymentGateway(fake) = %T, want *FakeGateway", gateway)
+	}
+
+	if _, err := NewPaymentGateway(&config.PaymentsConfig{Driver: "stripe"}, infraLogger.NewLogger("error")); err == nil {
+		t.Error("NewPaymentGateway(stripe) error = nil, want an unsupported driver error")
+	}
+}
@@ -0,0 +1,304 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/entities"
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+)
+
+// S3 request signing constants (AWS Signature Version 4)
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4Service    = "s3"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	amzDateFormat   = "20060102T150405Z"
+	maxErrorBody    = 1024
+	maxPresignTTL   = 7 * 24 * time.Hour // Longest expiry S3 accepts for presigned URLs
+)
+
+// S3Options configures an S3-compatible object store (AWS S3, MinIO, Cloudflare R2, ...)
+type S3Options struct {
+	Endpoint        string // Base URL, e.g. https://s3.us-east-1.amazonaws.com or http://localhost:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool // Address the bucket in the path instead of the host name, as MinIO expects
+	Timeout         time.Duration
+}
+
+// S3Storage stores objects in an S3-compatible bucket, signing requests with SigV4
+type S3Storage struct {
+	options  S3Options
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Storage creates an S3-compatible storage; the endpoint defaults to AWS S3 in the region
+func NewS3Storage(options S3Options) (gateways.BlobStorage, error) {
+	if options.Bucket == "" || options.Region == "" || options.AccessKeyID == "" || options.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 storage requires a bucket, region and access keys")
+	}
+	if options.Endpoint == "" {
+		options.Endpoint = "https://s3." + options.Region + ".amazonaws.com"
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(options.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", options.Endpoint)
+	}
+
+	return &S3Storage{
+		options:  options,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: options.Timeout},
+		now:      time.Now,
+	}, nil
+}
+
+// Put uploads the object in a single PUT request
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), body)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 request: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload object: %w", responseError(resp))
+	}
+
+	return nil
+}
+
+// Get streams the object from the bucket
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 request: %w", err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, entities.ErrBlobNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("failed to download object: %w", responseError(resp))
+	}
+}
+
+// Delete removes the object; S3 reports success for missing keys
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 request: %w", err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete object: %w", responseError(resp))
+	}
+
+	return nil
+}
+
+// PresignGet signs a GET URL carrying the signature in its query string. The response
+// headers are overridden so browsers get the right type and file name.
+func (s *S3Storage) PresignGet(ctx context.Context, key string, expires time.Duration, contentType, fileName string) (string, error) {
+	if expires <= 0 || expires > maxPresignTTL {
+		return "", fmt.Errorf("presigned URL expiry must be between 1s and %s", maxPresignTTL)
+	}
+
+	now := s.now().UTC()
+	u := s.objectURL(key)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", sigV4Algorithm)
+	query.Set("X-Amz-Credential", s.options.AccessKeyID+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires/time.Second)))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if contentType != "" {
+		query.Set("response-content-type", contentType)
+	}
+	if fileName != "" {
+		query.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	}
+
+	canonicalQuery := canonicalQueryString(query)
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + s.signature(now, canonicalRequest)
+	return u.String(), nil
+}
+
+// do signs the request with an Authorization header and sends it. Payloads are not
+// hashed (UNSIGNED-PAYLOAD) so uploads can stream; use an https endpoint in production.
+func (s *S3Storage) do(req *http.Request) (*http.Response, error) {
+	now := s.now().UTC()
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders, canonicalHeaders := canonicalHeaderList(req.Header)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQueryString(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.options.AccessKeyID, s.scope(now), signedHeaders, s.signature(now, canonicalRequest)))
+	req.Header.Del("Host") // net/http sends req.Host; the header was only needed for signing
+
+	return s.client.Do(req)
+}
+
+// objectURL addresses a key in the bucket, path-style or virtual-hosted
+func (s *S3Storage) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	objectPath := "/" + key
+	if s.options.UsePathStyle {
+		objectPath = "/" + s.options.Bucket + objectPath
+	} else {
+		u.Host = s.options.Bucket + "." + u.Host
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/") + objectPath
+	u.RawPath = uriEncode(u.Path, false)
+	return &u
+}
+
+// scope is the credential scope of requests signed at the given time
+func (s *S3Storage) scope(t time.Time) string {
+	return t.Format("20060102") + "/" + s.options.Region + "/" + sigV4Service + "/aws4_request"
+}
+
+// signature derives the day's signing key and signs the canonical request
+func (s *S3Storage) signature(t time.Time, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		t.Format(amzDateFormat),
+		s.scope(t),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.options.SecretAccessKey), t.Format("20060102"))
+	key = hmacSHA256(key, s.options.Region)
+	key = hmacSHA256(key, sigV4Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalHeaderList returns the signed header names and the canonical header block
+func canonicalHeaderList(header http.Header) (string, string) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		var values []string
+		for _, value := range header.Values(name) {
+			values = append(values, strings.Join(strings.Fields(value), " "))
+		}
+		canonical.WriteString(name + ":" + strings.Join(values, ",") + "\n")
+	}
+
+	return strings.Join(names, ";"), canonical.String()
+}
+
+// canonicalQueryString sorts and strictly encodes query parameters
+func canonicalQueryString(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but unreserved characters (RFC 3986), as SigV4 requires
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+// responseError reads the S3 error document of a failed request
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return fmt.Errorf("s3 returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+	"dental-scheduler-backend/internal/infra/config"
+)
+
+// NewBlobStorage creates the storage selected by the configured driver
+func NewBlobStorage(cfg *config.StorageConfig) (gateways.BlobStorage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.LocalPath)
+	case "s3":
+		return NewS3Storage(S3Options{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			UsePathStyle:    cfg.S3UsePathStyle,
+			Timeout:         time.Duration(cfg.S3TimeoutSeconds) * time.Second,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", cfg.Driver)
+	}
+}
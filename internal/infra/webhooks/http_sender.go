@@ -0,0 +1,57 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"dental-scheduler-backend/internal/domain/ports/gateways"
+)
+
+// maxResponseBody caps how much of a receiver response is kept
+const maxResponseBody = 1024
+
+// HTTPSender posts webhook payloads with net/http
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a webhook sender with the given request timeout
+func NewHTTPSender(timeout time.Duration) gateways.WebhookSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Receivers must answer directly; following redirects could leak signed payloads
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts the request and returns the receiver's status code
+func (s *HTTPSender) Send(ctx context.Context, req *gateways.WebhookRequest) (*gateways.WebhookResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	for name, value := range req.Headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+
+	return &gateways.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}, nil
+}
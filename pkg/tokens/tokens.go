@@ -0,0 +1,69 @@
+package tokens
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed or its signature does not match
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrExpiredToken is returned when a token is past its expiry
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Sign creates a URL-safe token carrying subject until expiresAt, signed with HMAC-SHA256
+func Sign(secret []byte, subject string, expiresAt time.Time) string {
+	payload := subject + "|" + strconv.FormatInt(expiresAt.Unix(), 10)
+	encodedPayload := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	signature := base64.RawURLEncoding.EncodeToString(sign(secret, encodedPayload))
+	return encodedPayload + "." + signature
+}
+
+// Verify checks the token signature and expiry and returns its subject
+func Verify(secret []byte, token string, now time.Time) (string, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return "", ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if !hmac.Equal(signature, sign(secret, encodedPayload)) {
+		return "", ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	subject, expiresAtStr, found := strings.Cut(string(payload), "|")
+	if !found {
+		return "", ErrInvalidToken
+	}
+
+	expiresAt, err := strconv.ParseInt(expiresAtStr, 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if !now.Before(time.Unix(expiresAt, 0)) {
+		return "", ErrExpiredToken
+	}
+
+	return subject, nil
+}
+
+// sign computes the HMAC-SHA256 of data with secret
+func sign(secret []byte, data string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
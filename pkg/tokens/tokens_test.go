@@ -0,0 +1,61 @@
+package tokens
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+var secret = []byte("test-secret")
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := Sign(secret, "invitation-123", now.Add(time.Hour))
+
+	subject, err := Verify(secret, token, now)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if subject != "invitation-123" {
+		t.Errorf("Verify() subject = %q, want invitation-123", subject)
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := Sign(secret, "invitation-123", now.Add(time.Hour))
+	payload, signature, _ := strings.Cut(token, ".")
+
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte("invitation-456|" + "9999999999"))
+	otherSignature := Sign(secret, "invitation-456", now.Add(time.Hour))
+	_, otherSig, _ := strings.Cut(otherSignature, ".")
+
+	tests := []struct {
+		name   string
+		secret []byte
+		token  string
+		now    time.Time
+		want   error
+	}{
+		{name: "tampered payload", secret: secret, token: forgedPayload + "." + signature, now: now, want: ErrInvalidToken},
+		{name: "tampered signature", secret: secret, token: payload + "." + otherSig, now: now, want: ErrInvalidToken},
+		{name: "malformed signature", secret: secret, token: payload + ".%%%", now: now, want: ErrInvalidToken},
+		{name: "missing signature", secret: secret, token: payload, now: now, want: ErrInvalidToken},
+		{name: "wrong secret", secret: []byte("other-secret"), token: token, now: now, want: ErrInvalidToken},
+		{name: "expired", secret: secret, token: token, now: now.Add(time.Hour), want: ErrExpiredToken},
+		{name: "long expired", secret: secret, token: token, now: now.Add(48 * time.Hour), want: ErrExpiredToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, err := Verify(tt.secret, tt.token, tt.now)
+			if err != tt.want {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+			if subject != "" {
+				t.Errorf("Verify() subject = %q, want none", subject)
+			}
+		})
+	}
+}